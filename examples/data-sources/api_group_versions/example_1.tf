@@ -0,0 +1,7 @@
+data "kubernetes_api_group_versions" "autoscaling" {
+  group = "autoscaling"
+}
+
+locals {
+  use_hpa_v2 = contains(data.kubernetes_api_group_versions.autoscaling.versions, "v2")
+}
@@ -0,0 +1,12 @@
+data "kubernetes_cluster_health" "this" {}
+
+resource "kubernetes_deployment_v1" "web" {
+  # ...
+
+  lifecycle {
+    precondition {
+      condition     = data.kubernetes_cluster_health.this.ready
+      error_message = "The control plane isn't ready: ${join(", ", [for c in data.kubernetes_cluster_health.this.checks : c.name if !c.healthy])}"
+    }
+  }
+}
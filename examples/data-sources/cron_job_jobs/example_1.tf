@@ -0,0 +1,20 @@
+data "kubernetes_cron_job_jobs" "backup" {
+  namespace     = "default"
+  cron_job_name = "backup"
+}
+
+locals {
+  # The runs of the backup which failed, most recent first.
+  failed_backups = [
+    for job in data.kubernetes_cron_job_jobs.backup.jobs : job.name
+    if anytrue([for c in job.status[0].condition : c.type == "Failed" && c.status == "True"])
+  ]
+}
+
+output "last_backup" {
+  value = try(data.kubernetes_cron_job_jobs.backup.jobs[0].status[0], null)
+}
+
+output "failed_backups" {
+  value = local.failed_backups
+}
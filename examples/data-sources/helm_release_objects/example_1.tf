@@ -0,0 +1,21 @@
+data "kubernetes_helm_release_objects" "my_app" {
+  release_name = "my-app"
+  namespace    = "my-app"
+}
+
+locals {
+  my_app_objects = {
+    for o in data.kubernetes_helm_release_objects.my_app.objects : o.manifest_import_id => o
+  }
+}
+
+import {
+  for_each = local.my_app_objects
+  to       = kubernetes_manifest.my_app[each.key]
+  id       = each.key
+}
+
+resource "kubernetes_manifest" "my_app" {
+  for_each = local.my_app_objects
+  manifest = jsondecode(each.value.manifest)
+}
@@ -0,0 +1,12 @@
+data "kubernetes_import_ids" "deployments" {
+  api_version    = "apps/v1"
+  kind           = "Deployment"
+  namespace      = "my-app"
+  label_selector = "app.kubernetes.io/instance=my-app"
+}
+
+import {
+  for_each = toset(data.kubernetes_import_ids.deployments.ids)
+  to       = kubernetes_deployment_v1.my_app[each.value]
+  id       = each.value
+}
@@ -0,0 +1,12 @@
+data "kubernetes_import_ids" "certificates" {
+  api_version    = "cert-manager.io/v1"
+  kind           = "Certificate"
+  namespace      = "my-app"
+  label_selector = "app.kubernetes.io/instance=my-app"
+}
+
+import {
+  for_each = data.kubernetes_import_ids.certificates.manifest_ids
+  to       = kubernetes_manifest.certificate[each.key]
+  id       = each.value
+}
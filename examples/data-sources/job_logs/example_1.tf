@@ -0,0 +1,30 @@
+resource "kubernetes_job_v1" "seed" {
+  metadata {
+    name = "seed"
+  }
+  spec {
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "seed"
+          image   = "example/app:1.0"
+          command = ["app", "seed"]
+        }
+        restart_policy = "Never"
+      }
+    }
+  }
+  wait_for_completion = true
+}
+
+data "kubernetes_job_logs" "seed" {
+  namespace  = kubernetes_job_v1.seed.metadata[0].namespace
+  job_name   = kubernetes_job_v1.seed.metadata[0].name
+  container  = "seed"
+  tail_lines = 50
+}
+
+output "seed_output" {
+  value = join("", data.kubernetes_job_logs.seed.logs[*].content)
+}
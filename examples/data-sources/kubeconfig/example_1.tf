@@ -0,0 +1,22 @@
+resource "kubernetes_service_account_v1" "ci" {
+  metadata {
+    name      = "ci"
+    namespace = "deployments"
+  }
+}
+
+data "kubernetes_kubeconfig" "ci" {
+  cluster_name = "production"
+  context_name = "ci"
+
+  service_account {
+    name               = kubernetes_service_account_v1.ci.metadata.0.name
+    namespace          = kubernetes_service_account_v1.ci.metadata.0.namespace
+    expiration_seconds = 7200
+  }
+}
+
+output "ci_kubeconfig" {
+  value     = data.kubernetes_kubeconfig.ci.kubeconfig
+  sensitive = true
+}
@@ -0,0 +1,14 @@
+data "kubernetes_limit_ranges" "tenant" {
+  namespace = "tenant-a"
+}
+
+locals {
+  container_max_cpu = [
+    for l in data.kubernetes_limit_ranges.tenant.limits : l.max["cpu"]
+    if l.type == "Container" && contains(keys(l.max), "cpu")
+  ]
+}
+
+output "container_max_cpu" {
+  value = local.container_max_cpu
+}
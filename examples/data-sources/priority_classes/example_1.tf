@@ -0,0 +1,5 @@
+data "kubernetes_priority_classes" "all" {}
+
+output "priority_class_names" {
+  value = [for pc in data.kubernetes_priority_classes.all.priority_classes : pc.name]
+}
@@ -0,0 +1,16 @@
+data "kubernetes_replica_sets" "web" {
+  namespace       = "shop"
+  deployment_name = "web"
+}
+
+locals {
+  # The replica set of the revision before the current one, to roll back to.
+  previous_revision = [
+    for rs in data.kubernetes_replica_sets.web.replica_sets : rs
+    if rs.revision != data.kubernetes_replica_sets.web.current_revision
+  ][0]
+}
+
+output "rollback_images" {
+  value = local.previous_revision.images
+}
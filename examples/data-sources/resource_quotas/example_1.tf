@@ -0,0 +1,9 @@
+data "kubernetes_resource_quotas" "tenant" {
+  namespace = "tenant-a"
+}
+
+output "remaining_pods" {
+  value = {
+    for q in data.kubernetes_resource_quotas.tenant.resource_quotas : q.name => lookup(q.remaining, "pods", null)
+  }
+}
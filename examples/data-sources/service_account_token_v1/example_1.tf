@@ -0,0 +1,15 @@
+resource "kubernetes_service_account_v1" "ci" {
+  metadata {
+    name = "ci"
+  }
+}
+
+data "kubernetes_service_account_token_v1" "ci" {
+  metadata {
+    name = kubernetes_service_account_v1.ci.metadata.0.name
+  }
+  spec {
+    audiences          = ["https://kubernetes.default.svc"]
+    expiration_seconds = 3600
+  }
+}
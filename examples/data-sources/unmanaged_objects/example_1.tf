@@ -0,0 +1,19 @@
+data "kubernetes_unmanaged_objects" "shop" {
+  namespace = "shop"
+
+  expected {
+    api_version = "apps/v1"
+    kind        = "Deployment"
+    names       = [for d in kubernetes_deployment_v1.shop : d.metadata[0].name]
+  }
+
+  expected {
+    api_version = "v1"
+    kind        = "ConfigMap"
+    names       = concat([for c in kubernetes_config_map_v1.shop : c.metadata[0].name], ["kube-root-ca.crt"])
+  }
+}
+
+output "unmanaged_objects" {
+  value = data.kubernetes_unmanaged_objects.shop.extra[*].id
+}
@@ -0,0 +1,34 @@
+resource "kubernetes_cron_job_trigger" "backup" {
+  namespace     = "default"
+  cron_job_name = kubernetes_cron_job_v1.backup.metadata[0].name
+
+  # Back up the database again before every migration.
+  triggers = {
+    release = var.release
+  }
+
+  timeouts {
+    create = "10m"
+  }
+}
+
+resource "kubernetes_job_v1" "migrate" {
+  depends_on = [kubernetes_cron_job_trigger.backup]
+
+  metadata {
+    generate_name = "migrate-"
+  }
+  spec {
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "migrate"
+          image   = "example/app:${var.release}"
+          command = ["app", "migrate"]
+        }
+        restart_policy = "Never"
+      }
+    }
+  }
+}
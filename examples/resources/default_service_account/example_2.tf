@@ -0,0 +1,9 @@
+resource "kubernetes_default_service_account" "hardened" {
+  metadata {
+    namespace = "default"
+  }
+
+  automount_service_account_token = false
+  remove_legacy_token_secrets     = true
+  harden_namespaces               = ["payments", "checkout", "inventory"]
+}
@@ -0,0 +1,26 @@
+resource "kubernetes_device_class_v1beta1" "example" {
+  metadata {
+    name = "gpu.example.com"
+  }
+
+  spec {
+    selectors {
+      cel {
+        expression = "device.driver == \"gpu.example.com\""
+      }
+    }
+
+    config {
+      opaque {
+        driver = "gpu.example.com"
+        parameters = jsonencode({
+          apiVersion = "gpu.example.com/v1"
+          kind       = "GpuConfig"
+          sharing = {
+            strategy = "TimeSlicing"
+          }
+        })
+      }
+    }
+  }
+}
@@ -0,0 +1,16 @@
+resource "kubernetes_event_v1" "example" {
+  metadata {
+    generate_name = "web-deployed-"
+    namespace     = "default"
+  }
+  regarding {
+    api_version = "apps/v1"
+    kind        = "Deployment"
+    name        = kubernetes_deployment_v1.web.metadata[0].name
+    namespace   = kubernetes_deployment_v1.web.metadata[0].namespace
+    uid         = kubernetes_deployment_v1.web.metadata[0].uid
+  }
+  action = "Deploy"
+  reason = "TerraformApply"
+  note   = "Deployed ${var.image} by Terraform run ${var.run_id}"
+}
@@ -0,0 +1,21 @@
+resource "kubernetes_file_copy" "config" {
+  namespace = "default"
+  pod_name  = kubernetes_pod_v1.app.metadata[0].name
+  container = "app"
+
+  file {
+    path    = "/etc/app/config.yaml"
+    content = templatefile("${path.module}/config.yaml.tftpl", { env = "prod" })
+    mode    = "0600"
+  }
+}
+
+resource "kubernetes_file_copy" "seed" {
+  namespace                    = "default"
+  persistent_volume_claim_name = kubernetes_persistent_volume_claim_v1.data.metadata[0].name
+
+  file {
+    path   = "seed/geoip.mmdb"
+    source = "${path.module}/geoip.mmdb"
+  }
+}
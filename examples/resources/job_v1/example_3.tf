@@ -0,0 +1,20 @@
+resource "kubernetes_job_v1" "migrate" {
+  metadata {
+    generate_name = "migrate-"
+  }
+  spec {
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "migrate"
+          image   = "example/app:${var.release}"
+          command = ["./migrate", "up"]
+        }
+        restart_policy = "Never"
+      }
+    }
+    backoff_limit = 0
+  }
+  wait_for_completion = true
+}
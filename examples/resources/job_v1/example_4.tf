@@ -0,0 +1,22 @@
+resource "kubernetes_job_v1" "seed" {
+  metadata {
+    name = "seed"
+  }
+  spec {
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "seed"
+          image   = "example/seed:latest"
+          command = ["./seed"]
+        }
+        restart_policy = "Never"
+      }
+    }
+  }
+  triggers = {
+    fixtures = filesha256("${path.module}/fixtures.sql")
+  }
+  wait_for_completion = true
+}
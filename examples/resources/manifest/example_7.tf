@@ -0,0 +1,11 @@
+resource "kubernetes_manifest" "certificate" {
+  manifest = {
+    apiVersion = "cert-manager.io/v1"
+    kind       = "Certificate"
+    // ...
+  }
+
+  wait {
+    preset = "Ready"
+  }
+}
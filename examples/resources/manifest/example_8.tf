@@ -0,0 +1,61 @@
+resource "kubernetes_manifest" "widgets" {
+  manifest = {
+    apiVersion = "apiextensions.k8s.io/v1"
+    kind       = "CustomResourceDefinition"
+
+    metadata = {
+      name = "widgets.example.com"
+    }
+
+    spec = {
+      group = "example.com"
+
+      names = {
+        kind   = "Widget"
+        plural = "widgets"
+      }
+
+      scope = "Namespaced"
+
+      conversion = {
+        strategy = "Webhook"
+        webhook = {
+          conversionReviewVersions = ["v1"]
+          clientConfig = {
+            caBundle = base64encode(var.webhook_ca)
+            service = {
+              name      = "widgets-webhook"
+              namespace = "widgets"
+              path      = "/convert"
+            }
+          }
+        }
+      }
+
+      versions = [
+        {
+          name    = "v1alpha1"
+          served  = true
+          storage = false
+          schema = {
+            openAPIV3Schema = {
+              type                                   = "object"
+              "x-kubernetes-preserve-unknown-fields" = true
+            }
+          }
+        },
+        {
+          name    = "v1"
+          served  = true
+          storage = true
+          schema = {
+            openAPIV3Schema = {
+              type                                   = "object"
+              "x-kubernetes-preserve-unknown-fields" = true
+            }
+          }
+        },
+      ]
+    }
+  }
+}
@@ -0,0 +1,24 @@
+locals {
+  manifests = [for f in fileset("${path.module}/manifests", "*.yaml") : file("${path.module}/manifests/${f}")]
+}
+
+resource "kubernetes_manifest" "app" {
+  for_each = { for m in local.manifests : yamldecode(m).metadata.name => yamldecode(m) }
+  manifest = each.value
+}
+
+resource "kubernetes_prune" "app" {
+  namespace      = "shop"
+  label_selector = "app.kubernetes.io/instance=shop"
+  kind {
+    api_version = "apps/v1"
+    kind        = "Deployment"
+  }
+  kind {
+    api_version = "v1"
+    kind        = "ConfigMap"
+  }
+  manifests = local.manifests
+
+  depends_on = [kubernetes_manifest.app]
+}
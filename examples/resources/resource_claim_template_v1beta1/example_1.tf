@@ -0,0 +1,57 @@
+resource "kubernetes_resource_claim_template_v1beta1" "example" {
+  metadata {
+    name = "two-gpus"
+  }
+
+  spec {
+    spec {
+      devices {
+        request {
+          name              = "gpus"
+          device_class_name = "gpu.example.com"
+          allocation_mode   = "ExactCount"
+          count             = 2
+        }
+      }
+    }
+  }
+}
+
+resource "kubernetes_deployment_v1" "example" {
+  metadata {
+    name = "training"
+  }
+
+  spec {
+    selector {
+      match_labels = {
+        app = "training"
+      }
+    }
+
+    template {
+      metadata {
+        labels = {
+          app = "training"
+        }
+      }
+
+      spec {
+        resource_claim {
+          name                         = "gpus"
+          resource_claim_template_name = kubernetes_resource_claim_template_v1beta1.example.metadata.0.name
+        }
+
+        container {
+          name  = "training"
+          image = "registry.example.com/training:1.0"
+          resources {
+            claims {
+              name = "gpus"
+            }
+          }
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,42 @@
+resource "kubernetes_resource_claim_v1beta1" "example" {
+  metadata {
+    name = "shared-gpu"
+  }
+
+  spec {
+    devices {
+      request {
+        name              = "gpu"
+        device_class_name = "gpu.example.com"
+        selectors {
+          cel {
+            expression = "device.capacity[\"gpu.example.com\"].memory.compareTo(quantity(\"40Gi\")) >= 0"
+          }
+        }
+      }
+    }
+  }
+}
+
+resource "kubernetes_pod_v1" "example" {
+  metadata {
+    name = "inference"
+  }
+
+  spec {
+    resource_claim {
+      name                = "gpu"
+      resource_claim_name = kubernetes_resource_claim_v1beta1.example.metadata.0.name
+    }
+
+    container {
+      name  = "inference"
+      image = "registry.example.com/inference:1.0"
+      resources {
+        claims {
+          name = "gpu"
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,32 @@
+resource "kubernetes_vertical_pod_autoscaler_v1" "example" {
+  metadata {
+    name = "web"
+  }
+
+  spec {
+    target_ref {
+      api_version = "apps/v1"
+      kind        = "Deployment"
+      name        = "web"
+    }
+
+    update_policy {
+      update_mode = "Recreate"
+    }
+
+    resource_policy {
+      container_policy {
+        container_name = "*"
+        min_allowed = {
+          cpu    = "100m"
+          memory = "64Mi"
+        }
+        max_allowed = {
+          cpu    = "2"
+          memory = "2Gi"
+        }
+        controlled_resources = ["cpu", "memory"]
+      }
+    }
+  }
+}
@@ -0,0 +1,201 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package apierror
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer/protobuf"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestParseFieldPath(t *testing.T) {
+	cases := map[string]struct {
+		field    string
+		expected []FieldPathElement
+		ok       bool
+	}{
+		"Nested": {
+			field: "spec.template.spec.containers[0].image",
+			expected: []FieldPathElement{
+				{Name: "spec", Index: -1},
+				{Name: "template", Index: -1},
+				{Name: "spec", Index: -1},
+				{Name: "containers", Index: -1},
+				{Index: 0},
+				{Name: "image", Index: -1},
+			},
+			ok: true,
+		},
+		"MapKey": {
+			field: "metadata.labels[app.kubernetes.io/name]",
+			expected: []FieldPathElement{
+				{Name: "metadata", Index: -1},
+				{Name: "labels", Index: -1},
+				{Key: "app.kubernetes.io/name", Index: -1},
+			},
+			ok: true,
+		},
+		"Empty": {
+			field: "",
+		},
+		"Unterminated": {
+			field: "data[key",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := ParseFieldPath(tc.field)
+			if ok != tc.ok || !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("expected %#v (%t), got %#v (%t)", tc.expected, tc.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestTransportProtobuf(t *testing.T) {
+	status := &metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Reason:   metav1.StatusReasonInvalid,
+		Details: &metav1.StatusDetails{
+			Name:   "web",
+			Kind:   "Deployment",
+			Causes: []metav1.StatusCause{{Field: "spec.replicas"}},
+		},
+		Code: http.StatusUnprocessableEntity,
+	}
+	var body bytes.Buffer
+	if err := protobuf.NewSerializer(scheme.Scheme, scheme.Scheme).Encode(status, &body); err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.kubernetes.protobuf")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write(body.Bytes())
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+	ctx, r := WithRecorder(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/apis/apps/v1/namespaces/default/deployments", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	invalid := r.Invalid()
+	if len(invalid) != 1 || invalid[0].Details.Causes[0].Field != "spec.replicas" {
+		t.Fatalf("unexpected rejected requests %#v", invalid)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	const status = `{"kind":"Status","apiVersion":"v1","status":"Failure","message":"Deployment.apps \"web\" is invalid: spec.replicas: Invalid value: -1: must be greater than or equal to 0","reason":"Invalid","details":{"name":"web","group":"apps","kind":"Deployment","causes":[{"reason":"FieldValueInvalid","message":"Invalid value: -1: must be greater than or equal to 0","field":"spec.replicas"}]},"code":422}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		io.WriteString(w, status)
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+	ctx, r := WithRecorder(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/apis/apps/v1/namespaces/default/deployments", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	if string(body) != status {
+		t.Fatalf("expected the body to be left intact, got %q", body)
+	}
+
+	invalid := r.Invalid()
+	if len(invalid) != 1 {
+		t.Fatalf("expected one rejected request, got %d", len(invalid))
+	}
+	if c := invalid[0].Details.Causes; len(c) != 1 || c[0].Field != "spec.replicas" {
+		t.Fatalf("unexpected causes %#v", c)
+	}
+	if invalid[0].GroupVersion != "apps/v1" {
+		t.Fatalf("unexpected group version %q", invalid[0].GroupVersion)
+	}
+}
+
+func TestGroupVersionOfPath(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/apis/apps/v1/namespaces/default/deployments":     "apps/v1",
+		"/api/v1/namespaces/default/configmaps":            "v1",
+		"/k8s/clusters/c-1/apis/batch/v1/namespaces/x/job": "batch/v1",
+		"/version": "",
+		"/apis":    "",
+	} {
+		if got := groupVersionOfPath(path); got != expected {
+			t.Errorf("%s: expected %q, got %q", path, expected, got)
+		}
+	}
+}
+
+func TestOpenAPIDocumentLookup(t *testing.T) {
+	doc, err := ParseOpenAPIDocument([]byte(`{"components": {"schemas": {
+		"io.k8s.api.apps.v1.Deployment": {
+			"type": "object",
+			"properties": {"spec": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}], "description": "Specification of the desired behavior of the Deployment."}},
+			"x-kubernetes-group-version-kind": [{"group": "apps", "version": "v1", "kind": "Deployment"}]
+		},
+		"io.k8s.api.apps.v1.DeploymentSpec": {
+			"type": "object",
+			"description": "DeploymentSpec is the specification of the desired behavior of the Deployment.",
+			"properties": {
+				"replicas": {"type": "integer", "description": "Number of desired pods."},
+				"selector": {"type": "object", "additionalProperties": {"type": "string", "description": "A label."}},
+				"containers": {"type": "array", "description": "Containers of the pods.", "items": {"$ref": "#/components/schemas/io.k8s.api.core.v1.Container"}}
+			}
+		},
+		"io.k8s.api.core.v1.Container": {
+			"type": "object",
+			"properties": {"image": {"type": "string", "description": "Container image name."}}
+		}
+	}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	for field, expected := range map[string]Field{
+		"spec":                     {Description: "Specification of the desired behavior of the Deployment."},
+		"spec.replicas":            {Description: "Number of desired pods."},
+		"spec.containers":          {Description: "Containers of the pods.", List: true},
+		"spec.containers[0].image": {Description: "Container image name."},
+		"spec.selector[app]":       {Description: "A label."},
+	} {
+		got, ok := doc.Lookup(gvk, field)
+		if !ok || got != expected {
+			t.Errorf("%s: expected %#v, got %#v (%t)", field, expected, got, ok)
+		}
+	}
+	if _, ok := doc.Lookup(gvk, "spec.unknown"); ok {
+		t.Error("expected unknown fields not to be found")
+	}
+	if _, ok := doc.Lookup(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}, "spec"); ok {
+		t.Error("expected unknown kinds not to be found")
+	}
+}
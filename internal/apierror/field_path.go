@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package apierror
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FieldPathElement is a step of the path of a field of a Kubernetes object:
+// the name of a field, the index of a list element, or the key of a map entry.
+type FieldPathElement struct {
+	Name  string
+	Key   string
+	Index int
+}
+
+// IsName reports whether the element is the name of a field.
+func (e FieldPathElement) IsName() bool {
+	return e.Name != ""
+}
+
+// IsIndex reports whether the element is the index of a list element.
+func (e FieldPathElement) IsIndex() bool {
+	return e.Name == "" && e.Index >= 0
+}
+
+// ParseFieldPath parses the path of a field in the causes of a Kubernetes API
+// error, e.g. spec.template.spec.containers[0].image or
+// metadata.labels[app.kubernetes.io/name]. It returns false when the path
+// isn't valid.
+func ParseFieldPath(field string) ([]FieldPathElement, bool) {
+	var elements []FieldPathElement
+	for field != "" {
+		switch field[0] {
+		case '[':
+			end := strings.IndexByte(field, ']')
+			if end < 0 {
+				return nil, false
+			}
+			v := field[1:end]
+			if i, err := strconv.Atoi(v); err == nil && i >= 0 {
+				elements = append(elements, FieldPathElement{Index: i})
+			} else {
+				elements = append(elements, FieldPathElement{Key: v, Index: -1})
+			}
+			field = field[end+1:]
+		case '.':
+			field = field[1:]
+		default:
+			end := strings.IndexAny(field, ".[")
+			if end < 0 {
+				end = len(field)
+			}
+			elements = append(elements, FieldPathElement{Name: field[:end], Index: -1})
+			field = field[end:]
+		}
+	}
+	return elements, len(elements) > 0
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package apierror
+
+import (
+	"encoding/json"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// openAPISchema is the part of an OpenAPI v3 schema needed to describe the
+// fields of Kubernetes objects.
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref"`
+	AllOf                []openAPISchema           `json:"allOf"`
+	Description          string                    `json:"description"`
+	Type                 string                    `json:"type"`
+	Properties           map[string]*openAPISchema `json:"properties"`
+	Items                *openAPISchema            `json:"items"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties"`
+	GroupVersionKinds    []schema.GroupVersionKind `json:"x-kubernetes-group-version-kind"`
+}
+
+// OpenAPIDocument is the OpenAPI v3 document of a group version, as served by
+// the API server at /openapi/v3/apis/<group>/<version>.
+type OpenAPIDocument struct {
+	Components struct {
+		Schemas map[string]*openAPISchema `json:"schemas"`
+	} `json:"components"`
+}
+
+// ParseOpenAPIDocument parses the OpenAPI v3 document of a group version.
+func ParseOpenAPIDocument(data []byte) (*OpenAPIDocument, error) {
+	doc := &OpenAPIDocument{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Field describes a field of the objects of a kind in the OpenAPI document.
+type Field struct {
+	Description string
+	// List is set when the field is a list, which the resources of the
+	// provider represent with blocks named in the singular.
+	List bool
+}
+
+// Lookup returns the field at the path of a field of the objects of kind gvk,
+// as reported in the causes of an API error, or false when the kind or the
+// field isn't found.
+func (doc *OpenAPIDocument) Lookup(gvk schema.GroupVersionKind, field string) (Field, bool) {
+	elements, ok := ParseFieldPath(field)
+	if !ok {
+		return Field{}, false
+	}
+	s := doc.kind(gvk)
+	for _, e := range elements {
+		if s == nil {
+			return Field{}, false
+		}
+		switch {
+		case e.IsName():
+			s = s.Properties[e.Name]
+		case e.IsIndex():
+			s = s.Items
+		default:
+			s = s.AdditionalProperties
+		}
+		s = doc.resolve(s)
+	}
+	if s == nil {
+		return Field{}, false
+	}
+	return Field{Description: s.Description, List: s.Type == "array"}, true
+}
+
+func (doc *OpenAPIDocument) kind(gvk schema.GroupVersionKind) *openAPISchema {
+	for _, s := range doc.Components.Schemas {
+		for _, k := range s.GroupVersionKinds {
+			if k == gvk {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+// resolve returns the schema s refers to, with the description of s, which
+// describes the field rather than its type.
+func (doc *OpenAPIDocument) resolve(s *openAPISchema) *openAPISchema {
+	if s == nil {
+		return nil
+	}
+	ref := s.Ref
+	if ref == "" && len(s.AllOf) == 1 {
+		ref = s.AllOf[0].Ref
+	}
+	if ref == "" {
+		return s
+	}
+	target, ok := doc.Components.Schemas[strings.TrimPrefix(ref, "#/components/schemas/")]
+	if !ok {
+		return nil
+	}
+	resolved := *target
+	if s.Description != "" {
+		resolved.Description = s.Description
+	}
+	return &resolved
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package apierror records the objects the Kubernetes API rejected as invalid,
+// with the fields which caused it, so that the providers can point to the
+// attributes of the resource whose request was rejected.
+package apierror
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+type recorderKey struct{}
+
+// Recorder gathers the statuses of the requests sent with its context which
+// were rejected with 422 Unprocessable Entity.
+type Recorder struct {
+	mu         sync.Mutex
+	rejections []Rejection
+}
+
+// Rejection is the status of a rejected request, with the group version of the
+// API it was sent to, e.g. apps/v1, which the kind of its details belongs to.
+type Rejection struct {
+	metav1.Status
+	GroupVersion string
+}
+
+// WithRecorder returns a context recording the rejected requests sent with it
+// into the returned recorder.
+func WithRecorder(ctx context.Context) (context.Context, *Recorder) {
+	r := &Recorder{}
+	return context.WithValue(ctx, recorderKey{}, r), r
+}
+
+// Invalid returns the rejected requests recorded so far.
+func (r *Recorder) Invalid() []Rejection {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Rejection(nil), r.rejections...)
+}
+
+func (r *Recorder) add(rj Rejection) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rejections = append(r.rejections, rj)
+}
+
+// NewTransport returns a round tripper adding the statuses of the requests
+// sent through rt and rejected as invalid to the recorder of their context, if
+// any.
+func NewTransport(rt http.RoundTripper) http.RoundTripper {
+	return &transport{rt: rt}
+}
+
+type transport struct {
+	rt http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.rt.RoundTrip(req)
+	if err != nil || res.StatusCode != http.StatusUnprocessableEntity || res.Body == nil {
+		return res, err
+	}
+	r, ok := req.Context().Value(recorderKey{}).(*Recorder)
+	contentType := res.Header.Get("Content-Type")
+	if !ok || !strings.Contains(contentType, "json") && !strings.Contains(contentType, "protobuf") {
+		return res, err
+	}
+	body, rerr := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if rerr != nil {
+		return res, err
+	}
+	// Statuses are encoded like the objects of the request, in JSON or in
+	// protobuf for the built-in APIs.
+	obj, _, derr := scheme.Codecs.UniversalDeserializer().Decode(body, nil, nil)
+	if s, ok := obj.(*metav1.Status); derr == nil && ok && s.Details != nil {
+		r.add(Rejection{Status: *s, GroupVersion: groupVersionOfPath(req.URL.Path)})
+	}
+	return res, err
+}
+
+// groupVersionOfPath returns the group version of the path of a request to the
+// Kubernetes API, e.g. apps/v1 for /apis/apps/v1/namespaces/default/deployments
+// and v1 for /api/v1/namespaces, or an empty string for other paths. The path
+// of the API server, e.g. behind a proxy, may come first.
+func groupVersionOfPath(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, p := range parts {
+		switch {
+		case p == "api" && i+1 < len(parts):
+			return parts[i+1]
+		case p == "apis" && i+2 < len(parts):
+			return parts[i+1] + "/" + parts[i+2]
+		}
+	}
+	return ""
+}
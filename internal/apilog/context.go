@@ -0,0 +1,24 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package apilog
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+)
+
+// Context returns a context logging through tflog for the code which isn't
+// passed the context of a request, e.g. the diff suppression functions of the
+// SDK or the start of the provider. Its logger is set up like the one of the
+// request contexts, with its level set by TF_LOG_PROVIDER_KUBERNETES.
+var Context = sync.OnceValue(func() context.Context {
+	return tfsdklog.NewRootProviderLogger(context.Background(),
+		tfsdklog.WithStderrFromInit(),
+		tfsdklog.WithLogName("kubernetes"),
+		tflog.WithLevelFromEnv("TF_LOG_PROVIDER", "kubernetes"),
+	)
+})
@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package apilog logs the requests sent to the Kubernetes API by the
+// providers, and optionally traces their headers and bodies with secrets
+// redacted.
+package apilog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// Subsystem is the tflog subsystem the requests are logged to. Its level can
+// be set independently from the rest of the provider with the
+// TF_LOG_PROVIDER_KUBERNETES_API environment variable.
+const Subsystem = "kubernetes_api"
+
+// TraceEnvVar enables the trace mode when the trace_requests provider
+// attribute isn't set.
+const TraceEnvVar = "KUBE_TRACE_REQUESTS"
+
+// BodyLimit is the number of bytes of a request or response body logged in
+// trace mode, longer bodies are truncated.
+const BodyLimit = 4096
+
+const redacted = "<redacted>"
+
+// redactedHeaders are the headers whose values are never logged.
+var redactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+}
+
+// NewTransport returns a round tripper logging a summary of every request
+// sent through rt at DEBUG level. When trace is set, the headers and bodies of
+// the requests and responses are logged as well.
+func NewTransport(rt http.RoundTripper, trace bool) http.RoundTripper {
+	return &transport{rt: rt, trace: trace}
+}
+
+type transport struct {
+	rt    http.RoundTripper
+	trace bool
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), Subsystem,
+		tflog.WithLevelFromEnv("TF_LOG_PROVIDER_KUBERNETES", "API"),
+		tflog.WithRootFields(),
+	)
+	ctx = tflog.SubsystemSetField(ctx, Subsystem, "method", req.Method)
+	ctx = tflog.SubsystemSetField(ctx, Subsystem, "url", req.URL.Redacted())
+
+	// Watches and streamed logs never complete, their bodies can't be traced.
+	streaming := req.URL.Query().Get("watch") == "true" || req.URL.Query().Get("follow") == "true"
+
+	if t.trace {
+		fields := map[string]interface{}{
+			"headers": headerFields(req.Header),
+		}
+		if req.Body != nil && req.Body != http.NoBody {
+			body, err := requestBody(req)
+			if err != nil {
+				fields["body_error"] = err.Error()
+			} else {
+				fields["body"] = traceBody(req.URL.Path, req.Header.Get("Content-Type"), body)
+			}
+		}
+		tflog.SubsystemDebug(ctx, Subsystem, "Sending Kubernetes API request", fields)
+	}
+
+	start := time.Now()
+	res, err := t.rt.RoundTrip(req)
+	fields := map[string]interface{}{
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, Subsystem, "Kubernetes API request failed", fields)
+		return res, err
+	}
+	fields["status_code"] = res.StatusCode
+
+	if t.trace {
+		fields["headers"] = headerFields(res.Header)
+		if !streaming && res.Body != nil {
+			body, err := io.ReadAll(res.Body)
+			res.Body.Close()
+			res.Body = io.NopCloser(bytes.NewReader(body))
+			if err != nil {
+				fields["body_error"] = err.Error()
+			} else {
+				fields["body"] = traceBody(req.URL.Path, res.Header.Get("Content-Type"), body)
+			}
+		}
+	}
+	tflog.SubsystemDebug(ctx, Subsystem, "Received Kubernetes API response", fields)
+
+	return res, nil
+}
+
+// requestBody reads the body of req without consuming it.
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+func headerFields(h http.Header) map[string]string {
+	fields := make(map[string]string, len(h))
+	for k, v := range h {
+		fields[k] = strings.Join(v, ", ")
+	}
+	for _, k := range redactedHeaders {
+		if _, ok := fields[k]; ok {
+			fields[k] = redacted
+		}
+	}
+	return fields
+}
+
+// traceBody returns the loggable representation of a body, with the values of
+// secrets redacted and truncated to BodyLimit bytes.
+func traceBody(path, contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if strings.Contains(contentType, "protobuf") {
+		j, err := protobufToJSON(body)
+		if err != nil {
+			return fmt.Sprintf("<%d bytes of %s>", len(body), contentType)
+		}
+		body, contentType = j, "application/json"
+	}
+	if !strings.Contains(contentType, "json") && !json.Valid(body) {
+		return fmt.Sprintf("<%d bytes of %s>", len(body), contentType)
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("<%d bytes of invalid JSON>", len(body))
+	}
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(Redact(v, isSecretPath(path))); err != nil {
+		return fmt.Sprintf("<%d bytes of JSON>", len(body))
+	}
+	return truncate(strings.TrimSuffix(out.String(), "\n"))
+}
+
+// protobufToJSON converts an object of a built-in API encoded in protobuf to
+// JSON.
+func protobufToJSON(body []byte) ([]byte, error) {
+	obj, gvk, err := scheme.Codecs.UniversalDeserializer().Decode(body, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	obj.GetObjectKind().SetGroupVersionKind(*gvk)
+	return json.Marshal(obj)
+}
+
+func truncate(s string) string {
+	if len(s) <= BodyLimit {
+		return s
+	}
+	return fmt.Sprintf("%s... (%d more bytes)", s[:BodyLimit], len(s)-BodyLimit)
+}
+
+// isSecretPath reports whether path addresses secrets, whose patches don't
+// carry a kind.
+func isSecretPath(path string) bool {
+	for _, p := range strings.Split(path, "/") {
+		if p == "secrets" {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact replaces the values of the secret fields of a decoded JSON document
+// with a placeholder: the data of secrets and their last applied
+// configuration, and the tokens of token requests and reviews. When secret is
+// set, the document is known to be a secret or a patch of one.
+func Redact(v interface{}, secret bool) interface{} {
+	switch o := v.(type) {
+	case []interface{}:
+		for i, e := range o {
+			if op, ok := e.(map[string]interface{}); ok && secret {
+				if _, ok := op["op"]; ok {
+					// JSON patch operation
+					if _, ok := op["value"]; ok {
+						op["value"] = redacted
+					}
+					continue
+				}
+			}
+			o[i] = Redact(e, secret)
+		}
+		return o
+	case map[string]interface{}:
+		kind, _ := o["kind"].(string)
+		switch kind {
+		case "Secret":
+			secret = true
+		case "TokenRequest":
+			redactField(o, "status", "token")
+		case "TokenReview":
+			redactField(o, "spec", "token")
+		}
+		if secret && (kind == "" || kind == "Secret") {
+			redactValues(o, "data")
+			redactValues(o, "stringData")
+			if md, ok := o["metadata"].(map[string]interface{}); ok {
+				if a, ok := md["annotations"].(map[string]interface{}); ok {
+					if _, ok := a["kubectl.kubernetes.io/last-applied-configuration"]; ok {
+						a["kubectl.kubernetes.io/last-applied-configuration"] = redacted
+					}
+				}
+			}
+		}
+		if items, ok := o["items"].([]interface{}); ok {
+			o["items"] = Redact(items, secret || kind == "SecretList")
+		}
+		return o
+	}
+	return v
+}
+
+func redactValues(o map[string]interface{}, key string) {
+	m, ok := o[key].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for k := range m {
+		m[k] = redacted
+	}
+}
+
+func redactField(o map[string]interface{}, parent, key string) {
+	p, ok := o[parent].(map[string]interface{})
+	if !ok {
+		return
+	}
+	if _, ok := p[key]; ok {
+		p[key] = redacted
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package apilog
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/serializer/protobuf"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestTraceBody(t *testing.T) {
+	cases := map[string]struct {
+		path        string
+		contentType string
+		body        string
+		expected    string
+	}{
+		"secret": {
+			path:        "/api/v1/namespaces/default/secrets/db",
+			contentType: "application/json",
+			body:        `{"kind":"Secret","metadata":{"name":"db","annotations":{"kubectl.kubernetes.io/last-applied-configuration":"{\"data\":{\"password\":\"aHVudGVyMg==\"}}","team":"data"}},"data":{"password":"aHVudGVyMg=="},"stringData":{"user":"admin"}}`,
+			expected:    `{"data":{"password":"<redacted>"},"kind":"Secret","metadata":{"annotations":{"kubectl.kubernetes.io/last-applied-configuration":"<redacted>","team":"data"},"name":"db"},"stringData":{"user":"<redacted>"}}`,
+		},
+		"secret list": {
+			path:        "/api/v1/namespaces/default/secrets",
+			contentType: "application/json",
+			body:        `{"kind":"SecretList","items":[{"metadata":{"name":"db"},"data":{"password":"aHVudGVyMg=="}}]}`,
+			expected:    `{"items":[{"data":{"password":"<redacted>"},"metadata":{"name":"db"}}],"kind":"SecretList"}`,
+		},
+		"secret json patch": {
+			path:        "/api/v1/namespaces/default/secrets/db",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"replace","path":"/data/password","value":"aHVudGVyMg=="}]`,
+			expected:    `[{"op":"replace","path":"/data/password","value":"<redacted>"}]`,
+		},
+		"token request": {
+			path:        "/api/v1/namespaces/default/serviceaccounts/ci/token",
+			contentType: "application/json",
+			body:        `{"kind":"TokenRequest","status":{"token":"eyJhbGciOi","expirationTimestamp":"2026-01-01T00:00:00Z"}}`,
+			expected:    `{"kind":"TokenRequest","status":{"expirationTimestamp":"2026-01-01T00:00:00Z","token":"<redacted>"}}`,
+		},
+		"config map": {
+			path:        "/api/v1/namespaces/default/configmaps/app",
+			contentType: "application/json",
+			body:        `{"kind":"ConfigMap","data":{"mode":"debug"}}`,
+			expected:    `{"data":{"mode":"debug"},"kind":"ConfigMap"}`,
+		},
+		"protobuf": {
+			path:        "/api/v1/namespaces/default/pods",
+			contentType: "application/vnd.kubernetes.protobuf",
+			body:        "k8s\x00\n\x0f",
+			expected:    "<6 bytes of application/vnd.kubernetes.protobuf>",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := traceBody(tc.path, tc.contentType, []byte(tc.body))
+			if got != tc.expected {
+				t.Fatalf("Unexpected body:\nwant: %s\n got: %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestTraceBodyProtobuf(t *testing.T) {
+	secret := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "db"},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	}
+	var body bytes.Buffer
+	if err := protobuf.NewSerializer(scheme.Scheme, scheme.Scheme).Encode(secret, &body); err != nil {
+		t.Fatal(err)
+	}
+	got := traceBody("/api/v1/namespaces/default/secrets/db", "application/vnd.kubernetes.protobuf", body.Bytes())
+	expected := `{"apiVersion":"v1","data":{"password":"<redacted>"},"kind":"Secret","metadata":{"creationTimestamp":null,"name":"db"}}`
+	if got != expected {
+		t.Fatalf("Unexpected body:\nwant: %s\n got: %s", expected, got)
+	}
+}
+
+func TestTraceBodyTruncated(t *testing.T) {
+	body := `{"kind":"ConfigMap","data":{"large":"` + strings.Repeat("x", 2*BodyLimit) + `"}}`
+	got := traceBody("/api/v1/namespaces/default/configmaps/large", "application/json", []byte(body))
+	if !strings.HasSuffix(got, "... (4136 more bytes)") {
+		t.Fatalf("Expected the body to be truncated, got %q", got[BodyLimit:])
+	}
+}
+
+func TestHeaderFields(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer eyJhbGciOi")
+	h.Set("Content-Type", "application/json")
+
+	fields := headerFields(h)
+	if fields["Authorization"] != redacted {
+		t.Fatalf("Expected the Authorization header to be redacted, got %q", fields["Authorization"])
+	}
+	if fields["Content-Type"] != "application/json" {
+		t.Fatalf("Unexpected Content-Type header %q", fields["Content-Type"])
+	}
+}
+
+func TestTransportPreservesBodies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport, true)}
+	body := `{"kind":"Secret","data":{"password":"aHVudGVyMg=="}}`
+	res, err := client.Post(srv.URL+"/api/v1/namespaces/default/secrets", "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	got, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != body {
+		t.Fatalf("Expected the bodies to be sent and received unmodified, got %s", got)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package apiwarning collects the warnings returned by the Kubernetes API,
+// e.g. for deprecated APIs and fields, so that the providers can report them
+// for the resource whose requests caused them.
+package apiwarning
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// miscPersistentWarning is the code of the warnings sent by the API server.
+const miscPersistentWarning = 299
+
+type collectorKey struct{}
+
+// Collector gathers the distinct warnings of the requests sent with its
+// context, in the order they were first returned.
+type Collector struct {
+	mu       sync.Mutex
+	seen     map[string]bool
+	warnings []string
+}
+
+// WithCollector returns a context collecting the warnings of the requests
+// sent with it into the returned collector.
+func WithCollector(ctx context.Context) (context.Context, *Collector) {
+	c := &Collector{seen: map[string]bool{}}
+	return context.WithValue(ctx, collectorKey{}, c), c
+}
+
+func (c *Collector) add(warning string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.seen[warning] {
+		return
+	}
+	c.seen[warning] = true
+	c.warnings = append(c.warnings, warning)
+}
+
+// Warnings returns the warnings collected so far.
+func (c *Collector) Warnings() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.warnings...)
+}
+
+// NewTransport returns a round tripper adding the warnings of the responses
+// received through rt to the collector of their request's context, if any.
+func NewTransport(rt http.RoundTripper) http.RoundTripper {
+	return &transport{rt: rt}
+}
+
+type transport struct {
+	rt http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.rt.RoundTrip(req)
+	if err != nil || len(res.Header.Values("Warning")) == 0 {
+		return res, err
+	}
+	c, ok := req.Context().Value(collectorKey{}).(*Collector)
+	if !ok {
+		return res, err
+	}
+	warnings, errs := utilnet.ParseWarningHeaders(res.Header.Values("Warning"))
+	for _, e := range errs {
+		tflog.Debug(req.Context(), "Ignoring an invalid Kubernetes API warning", map[string]interface{}{"error": e.Error()})
+	}
+	for _, w := range warnings {
+		if w.Code == miscPersistentWarning {
+			c.add(w.Text)
+		}
+	}
+	return res, err
+}
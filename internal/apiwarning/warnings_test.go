@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package apiwarning
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Warning", `299 - "policy/v1beta1 PodSecurityPolicy is deprecated in v1.21+, unavailable in v1.25+"`)
+		w.Header().Add("Warning", `299 - "spec.template.spec.containers[0].ports[0]: duplicate port definition"`)
+		w.Header().Add("Warning", `199 - "miscellaneous warning"`)
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+
+	get := func(ctx context.Context) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+	}
+
+	// Requests without a collector are left alone.
+	get(context.Background())
+
+	ctx, c := WithCollector(context.Background())
+	get(ctx)
+	get(ctx)
+
+	expected := []string{
+		"policy/v1beta1 PodSecurityPolicy is deprecated in v1.21+, unavailable in v1.25+",
+		"spec.template.spec.containers[0].ports[0]: duplicate port definition",
+	}
+	if got := c.Warnings(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package batchread serves the reads of namespaced Kubernetes objects from a
+// LIST of their namespace, so that refreshing many resources of the same kind
+// and namespace takes one request instead of one per object.
+package batchread
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultTTL is how long a LIST serves the reads of its objects.
+const DefaultTTL = 10 * time.Second
+
+// EnvVar enables batching when the batch_reads provider attribute isn't set.
+const EnvVar = "KUBE_BATCH_READS"
+
+// PageSize is the number of objects requested by each page of a LIST.
+const PageSize = 500
+
+type batchKey struct{}
+
+// WithBatching returns a context whose reads of single namespaced objects may
+// be served from a LIST of their namespace.
+func WithBatching(ctx context.Context) context.Context {
+	return context.WithValue(ctx, batchKey{}, true)
+}
+
+func batching(ctx context.Context) bool {
+	b, _ := ctx.Value(batchKey{}).(bool)
+	return b
+}
+
+// Cache holds the recent LISTs of the collections of namespaced objects. It is
+// shared by the transports of the clients of a provider.
+type Cache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu          sync.Mutex
+	collections map[string]*collection
+}
+
+// NewCache returns a cache serving reads from LISTs for ttl.
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:         ttl,
+		now:         time.Now,
+		collections: map[string]*collection{},
+	}
+}
+
+// collection is the state of a collection, e.g.
+// /apis/apps/v1/namespaces/default/deployments. The first read of one of its
+// objects is sent as is, the following ones are served from a single LIST.
+type collection struct {
+	created time.Time
+	listing bool
+	ready   chan struct{}
+
+	// Set once ready is closed.
+	items map[string][]byte
+	err   error
+}
+
+// Transport returns a round tripper serving the reads of single objects sent
+// through rt with a batching context from the cache. Every other request
+// invalidates the LIST of the collection it changes.
+func (c *Cache) Transport(rt http.RoundTripper) http.RoundTripper {
+	return &transport{cache: c, rt: rt}
+}
+
+type transport struct {
+	cache *Cache
+	rt    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		res, err := t.rt.RoundTrip(req)
+		if path, ok := collectionPath(req.URL.Path); ok {
+			t.cache.invalidate(collectionKey(req, path))
+		}
+		return res, err
+	}
+	path, name, ok := objectPath(req.URL.Path)
+	if !ok || req.URL.RawQuery != "" || !batching(req.Context()) || excluded(path) {
+		return t.rt.RoundTrip(req)
+	}
+
+	col := t.cache.collection(collectionKey(req, path))
+	if col == nil {
+		return t.rt.RoundTrip(req)
+	}
+	select {
+	case <-col.ready:
+	default:
+		if t.cache.startListing(col) {
+			col.items, col.err = t.list(req, path)
+			close(col.ready)
+		}
+	}
+	select {
+	case <-col.ready:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	if col.err != nil {
+		return t.rt.RoundTrip(req)
+	}
+	if item, ok := col.items[name]; ok {
+		return response(req, http.StatusOK, item), nil
+	}
+	return response(req, http.StatusNotFound, notFound(path, name)), nil
+}
+
+// collection returns the state of the collection of key, or nil when the read
+// is the first one of the collection and must be sent as is.
+func (c *Cache) collection(key string) *collection {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	col, ok := c.collections[key]
+	if ok && c.now().Sub(col.created) < c.ttl {
+		return col
+	}
+	c.collections[key] = &collection{
+		created: c.now(),
+		ready:   make(chan struct{}),
+	}
+	return nil
+}
+
+// startListing reports whether the caller has to LIST the collection, the
+// other readers wait for it.
+func (c *Cache) startListing(col *collection) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if col.listing {
+		return false
+	}
+	col.listing = true
+	return true
+}
+
+func (c *Cache) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.collections, key)
+}
+
+// list returns the objects of the collection by name, encoded as JSON. The
+// collection is listed in pages of PageSize objects.
+func (t *transport) list(req *http.Request, path string) (map[string][]byte, error) {
+	items := map[string][]byte{}
+	continueToken := ""
+	for {
+		page, err := t.listPage(req, path, continueToken)
+		if err != nil {
+			return nil, err
+		}
+		kind := strings.TrimSuffix(page.Kind, "List")
+		for _, raw := range page.Items {
+			var item map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &item); err != nil {
+				return nil, err
+			}
+			var meta metav1.ObjectMeta
+			if err := json.Unmarshal(item["metadata"], &meta); err != nil {
+				return nil, err
+			}
+			// The items of lists don't carry their kind.
+			item["apiVersion"], _ = json.Marshal(page.APIVersion)
+			item["kind"], _ = json.Marshal(kind)
+			b, err := json.Marshal(item)
+			if err != nil {
+				return nil, err
+			}
+			items[meta.Name] = b
+		}
+		continueToken = page.Metadata.Continue
+		if continueToken == "" {
+			return items, nil
+		}
+	}
+}
+
+type listPage struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   metav1.ListMeta   `json:"metadata"`
+	Items      []json.RawMessage `json:"items"`
+}
+
+// listPage returns the page of the collection starting at continueToken.
+func (t *transport) listPage(req *http.Request, path, continueToken string) (*listPage, error) {
+	// The LIST serves the reads of other resources, it must not be cancelled
+	// with the one starting it.
+	lreq := req.Clone(context.WithoutCancel(req.Context()))
+	lreq.URL.Path = path
+	query := url.Values{"limit": []string{strconv.Itoa(PageSize)}}
+	if continueToken != "" {
+		query.Set("continue", continueToken)
+	}
+	lreq.URL.RawQuery = query.Encode()
+	lreq.Header.Set("Accept", "application/json")
+
+	res, err := t.rt.RoundTrip(lreq)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("listing %s: %s", path, res.Status)
+	}
+
+	var page listPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// excluded reports whether the reads of the objects of a collection are never
+// batched. Listing Secrets would read the data of every secret of the
+// namespace, and is often not allowed when reading them is.
+func excluded(collection string) bool {
+	return strings.HasPrefix(collection, "/api/v1/") && strings.HasSuffix(collection, "/secrets")
+}
+
+// collectionPath returns the path of the collection of namespaced objects a
+// path is within, e.g. /apis/apps/v1/namespaces/default/deployments for
+// /apis/apps/v1/namespaces/default/deployments/web/scale.
+func collectionPath(path string) (string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) >= 5 && parts[0] == "api" && parts[2] == "namespaces":
+		return "/" + strings.Join(parts[:5], "/"), true
+	case len(parts) >= 6 && parts[0] == "apis" && parts[3] == "namespaces":
+		return "/" + strings.Join(parts[:6], "/"), true
+	}
+	return "", false
+}
+
+// objectPath returns the path of the collection and the name of the object a
+// namespaced object path is for, e.g. /api/v1/namespaces/default/secrets and
+// db for /api/v1/namespaces/default/secrets/db.
+func objectPath(path string) (string, string, bool) {
+	collection, ok := collectionPath(path)
+	if !ok {
+		return "", "", false
+	}
+	name := strings.TrimPrefix(strings.TrimSuffix(path, "/"), collection+"/")
+	if name == "" || strings.Contains(name, "/") || strings.HasPrefix(name, "/") {
+		return "", "", false
+	}
+	return collection, name, true
+}
+
+func collectionKey(req *http.Request, path string) string {
+	return req.URL.Host + path
+}
+
+func notFound(path, name string) []byte {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	resource := parts[len(parts)-1]
+	group := ""
+	if parts[0] == "apis" {
+		group = parts[1]
+	}
+	qualified := resource
+	if group != "" {
+		qualified += "." + group
+	}
+	b, _ := json.Marshal(metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  fmt.Sprintf("%s %q not found", qualified, name),
+		Reason:   metav1.StatusReasonNotFound,
+		Details: &metav1.StatusDetails{
+			Name:  name,
+			Group: group,
+			Kind:  resource,
+		},
+		Code: http.StatusNotFound,
+	})
+	return b
+}
+
+func response(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package batchread
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestObjectPath(t *testing.T) {
+	cases := map[string]struct {
+		collection string
+		name       string
+		ok         bool
+	}{
+		"/api/v1/namespaces/default/secrets/db":                  {"/api/v1/namespaces/default/secrets", "db", true},
+		"/apis/apps/v1/namespaces/default/deployments/web":       {"/apis/apps/v1/namespaces/default/deployments", "web", true},
+		"/apis/apps/v1/namespaces/default/deployments/web/":      {"/apis/apps/v1/namespaces/default/deployments", "web", true},
+		"/apis/apps/v1/namespaces/default/deployments":           {"", "", false},
+		"/apis/apps/v1/namespaces/default/deployments/web/scale": {"", "", false},
+		"/api/v1/namespaces/default":                             {"", "", false},
+		"/apis/rbac.authorization.k8s.io/v1/clusterroles/view":   {"", "", false},
+	}
+	for path, tc := range cases {
+		t.Run(path, func(t *testing.T) {
+			collection, name, ok := objectPath(path)
+			if collection != tc.collection || name != tc.name || ok != tc.ok {
+				t.Fatalf("expected %q %q %t, got %q %q %t", tc.collection, tc.name, tc.ok, collection, name, ok)
+			}
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.Method+" "+r.URL.Path]++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/configmaps":
+			if limit := r.URL.Query().Get("limit"); limit != "500" {
+				http.Error(w, "unexpected limit "+limit, http.StatusBadRequest)
+				return
+			}
+			// The objects are listed in pages.
+			if r.URL.Query().Get("continue") == "" {
+				io.WriteString(w, `{"apiVersion":"v1","kind":"ConfigMapList","metadata":{"continue":"b"},"items":[{"metadata":{"name":"a"},"data":{"k":"a"}}]}`)
+				return
+			}
+			io.WriteString(w, `{"apiVersion":"v1","kind":"ConfigMapList","metadata":{},"items":[{"metadata":{"name":"b"},"data":{"k":"b"}}]}`)
+		default:
+			io.WriteString(w, `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"a"},"data":{"k":"a"}}`)
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewCache(DefaultTTL).Transport(http.DefaultTransport)}
+	getObject := func(ctx context.Context, path string) (int, map[string]interface{}) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/v1/namespaces/default/"+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		var obj map[string]interface{}
+		if err := json.NewDecoder(res.Body).Decode(&obj); err != nil {
+			t.Fatal(err)
+		}
+		return res.StatusCode, obj
+	}
+	get := func(ctx context.Context, name string) (int, map[string]interface{}) {
+		return getObject(ctx, "configmaps/"+name)
+	}
+	ctx := WithBatching(context.Background())
+
+	// Reads without a batching context are sent as is.
+	get(context.Background(), "a")
+	get(context.Background(), "a")
+	if n := requests["GET /api/v1/namespaces/default/configmaps/a"]; n != 2 {
+		t.Fatalf("expected 2 reads, got %d", n)
+	}
+
+	// The first read is sent as is, the following ones are served from a LIST.
+	get(ctx, "a")
+	status, obj := get(ctx, "b")
+	if status != http.StatusOK || obj["kind"] != "ConfigMap" || obj["apiVersion"] != "v1" || obj["data"].(map[string]interface{})["k"] != "b" {
+		t.Fatalf("unexpected response %d %v", status, obj)
+	}
+	if status, obj := get(ctx, "c"); status != http.StatusNotFound || obj["reason"] != "NotFound" {
+		t.Fatalf("unexpected response %d %v", status, obj)
+	}
+	if n := requests["GET /api/v1/namespaces/default/configmaps"]; n != 2 {
+		t.Fatalf("expected 1 list of 2 pages, got %d requests", n)
+	}
+	if n := requests["GET /api/v1/namespaces/default/configmaps/a"]; n != 3 {
+		t.Fatalf("expected 3 reads, got %d", n)
+	}
+
+	// Changes invalidate the LIST.
+	req, _ := http.NewRequest(http.MethodPatch, srv.URL+"/api/v1/namespaces/default/configmaps/b", nil)
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	get(ctx, "b")
+	get(ctx, "b")
+	if n := requests["GET /api/v1/namespaces/default/configmaps"]; n != 4 {
+		t.Fatalf("expected 2 lists of 2 pages, got %d requests", n)
+	}
+
+	// Secrets are never listed.
+	getObject(ctx, "secrets/db")
+	getObject(ctx, "secrets/db")
+	if n := requests["GET /api/v1/namespaces/default/secrets/db"]; n != 2 {
+		t.Fatalf("expected 2 reads of the secret, got %d", n)
+	}
+	if n := requests["GET /api/v1/namespaces/default/secrets"]; n != 0 {
+		t.Fatalf("expected no list of the secrets, got %d", n)
+	}
+}
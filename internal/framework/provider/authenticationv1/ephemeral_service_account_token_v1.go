@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+
+package authenticationv1
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = (*ServiceAccountTokenEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*ServiceAccountTokenEphemeralResource)(nil)
+)
+
+// ServiceAccountTokenEphemeralResource is the ephemeral counterpart of the
+// kubernetes_service_account_token_v1 data source. It issues tokens through the
+// same TokenRequest call as kubernetes_token_request_v1.
+type ServiceAccountTokenEphemeralResource struct {
+	TokenRequestEphemeralResource
+}
+
+func NewServiceAccountTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &ServiceAccountTokenEphemeralResource{}
+}
+
+func (r *ServiceAccountTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_account_token_v1"
+}
+
+func (r *ServiceAccountTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	r.TokenRequestEphemeralResource.Schema(ctx, req, resp)
+	resp.Schema.Description = "Requests a short-lived, audience-scoped token for a service account using the TokenRequest API. The token is never persisted in state or plan."
+}
@@ -0,0 +1,321 @@
+// Copyright (c) HashiCorp, Inc.
+
+package corev1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-provider-kubernetes/kubernetes"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+var (
+	_ ephemeral.EphemeralResource                   = (*PortForwardEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure      = (*PortForwardEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithValidateConfig = (*PortForwardEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithClose          = (*PortForwardEphemeralResource)(nil)
+)
+
+// portForwardPrivateKey is the key of the private data of the ephemeral
+// resource holding the ID of its port forward, which is stopped on close.
+const portForwardPrivateKey = "port_forward_id"
+
+// portForwards are the stop channels of the open port forwards by ID. The
+// port forwards run in the provider process until the ephemeral resource is
+// closed at the end of the run.
+var portForwards = struct {
+	sync.Mutex
+	next int
+	stop map[string]chan struct{}
+}{stop: map[string]chan struct{}{}}
+
+type PortForwardEphemeralResource struct {
+	SDKv2Meta func() any
+}
+
+type PortForwardModel struct {
+	Namespace    types.String `tfsdk:"namespace"`
+	PodName      types.String `tfsdk:"pod_name"`
+	ServiceName  types.String `tfsdk:"service_name"`
+	RemotePort   types.Int64  `tfsdk:"remote_port"`
+	LocalAddress types.String `tfsdk:"local_address"`
+	LocalPort    types.Int64  `tfsdk:"local_port"`
+	Address      types.String `tfsdk:"address"`
+	Pod          types.String `tfsdk:"pod"`
+}
+
+func NewPortForwardEphemeralResource() ephemeral.EphemeralResource {
+	return &PortForwardEphemeralResource{}
+}
+
+func (r *PortForwardEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.SDKv2Meta = req.ProviderData.(func() any)
+}
+
+func (r *PortForwardEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_port_forward"
+}
+
+func (r *PortForwardEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Forwards a local port to a port of a pod, or of a service, for the duration of the Terraform run, like `kubectl port-forward`. Other providers can reach the in-cluster service at `address` during the same run, without exposing it publicly.",
+		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "The namespace of the pod or service. Defaults to `default`.",
+			},
+			"pod_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the pod to forward to. Exactly one of `pod_name` and `service_name` must be set.",
+			},
+			"service_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the service to forward to. The port is forwarded to a running and ready pod selected by the service, like `kubectl port-forward service/<name>`.",
+			},
+			"remote_port": schema.Int64Attribute{
+				Required:    true,
+				Description: "The port of the pod, or of the service, to forward to.",
+			},
+			"local_address": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The local address to listen on. Defaults to `127.0.0.1`.",
+			},
+			"local_port": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The local port to listen on. Defaults to a random free port.",
+			},
+			"address": schema.StringAttribute{
+				Computed:    true,
+				Description: "The local `host:port` address forwarded to the pod.",
+			},
+			"pod": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the pod the port is forwarded to.",
+			},
+		},
+	}
+}
+
+func (r *PortForwardEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var data PortForwardModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.PodName.IsUnknown() || data.ServiceName.IsUnknown() {
+		return
+	}
+	if data.PodName.IsNull() == data.ServiceName.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("pod_name"), "Invalid port forward target", "Exactly one of pod_name and service_name must be set.")
+	}
+}
+
+func (r *PortForwardEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data PortForwardModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace := data.Namespace.ValueString()
+	if namespace == "" {
+		namespace = "default"
+	}
+	localAddress := data.LocalAddress.ValueString()
+	if localAddress == "" {
+		localAddress = "127.0.0.1"
+	}
+
+	conn, err := r.SDKv2Meta().(kubernetes.KubeClientsets).MainClientset()
+	if err != nil {
+		resp.Diagnostics.AddError("error initializing kubernetes client", err.Error())
+		return
+	}
+	config, err := r.SDKv2Meta().(kubernetes.KubeClientsets).RESTConfig()
+	if err != nil || config == nil {
+		resp.Diagnostics.AddError("error initializing kubernetes client", fmt.Sprintf("the provider is not configured: %v", err))
+		return
+	}
+
+	podName := data.PodName.ValueString()
+	remotePort := int32(data.RemotePort.ValueInt64())
+	if podName == "" {
+		serviceName := data.ServiceName.ValueString()
+		svc, err := conn.CoreV1().Services(namespace).Get(ctx, serviceName, metav1.GetOptions{})
+		if err != nil {
+			resp.Diagnostics.AddError("error reading service", err.Error())
+			return
+		}
+		if len(svc.Spec.Selector) == 0 {
+			resp.Diagnostics.AddError("error selecting pod", fmt.Sprintf("service %s/%s has no selector", namespace, serviceName))
+			return
+		}
+		pods, err := conn.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("error listing pods of service", err.Error())
+			return
+		}
+		pod := selectReadyPod(pods.Items)
+		if pod == nil {
+			resp.Diagnostics.AddError("error selecting pod", fmt.Sprintf("service %s/%s has no running and ready pod", namespace, serviceName))
+			return
+		}
+		remotePort, err = servicePodPort(svc, remotePort, pod)
+		if err != nil {
+			resp.Diagnostics.AddError("error selecting pod port", err.Error())
+			return
+		}
+		podName = pod.Name
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		resp.Diagnostics.AddError("error initializing port forward", err.Error())
+		return
+	}
+	url := conn.CoreV1().RESTClient().Post().Resource("pods").Namespace(namespace).Name(podName).SubResource("portforward").URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stop := make(chan struct{})
+	ready := make(chan struct{})
+	ports := []string{fmt.Sprintf("%d:%d", data.LocalPort.ValueInt64(), remotePort)}
+	fw, err := portforward.NewOnAddresses(dialer, []string{localAddress}, ports, stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		resp.Diagnostics.AddError("error initializing port forward", err.Error())
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Forwarding %s to port %d of pod %s/%s", ports[0], remotePort, namespace, podName))
+	failed := make(chan error, 1)
+	go func() {
+		failed <- fw.ForwardPorts()
+	}()
+	select {
+	case <-ready:
+	case err := <-failed:
+		if err == nil {
+			err = errors.New("port forward stopped")
+		}
+		resp.Diagnostics.AddError("error forwarding port", err.Error())
+		return
+	case <-ctx.Done():
+		close(stop)
+		resp.Diagnostics.AddError("error forwarding port", ctx.Err().Error())
+		return
+	}
+
+	forwarded, err := fw.GetPorts()
+	if err != nil || len(forwarded) == 0 {
+		close(stop)
+		resp.Diagnostics.AddError("error forwarding port", fmt.Sprintf("no local port is listening: %v", err))
+		return
+	}
+
+	portForwards.Lock()
+	portForwards.next++
+	id := strconv.Itoa(portForwards.next)
+	portForwards.stop[id] = stop
+	portForwards.Unlock()
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, portForwardPrivateKey, []byte(strconv.Quote(id)))...)
+
+	data.Namespace = types.StringValue(namespace)
+	data.LocalAddress = types.StringValue(localAddress)
+	data.LocalPort = types.Int64Value(int64(forwarded[0].Local))
+	data.Address = types.StringValue(net.JoinHostPort(localAddress, strconv.Itoa(int(forwarded[0].Local))))
+	data.Pod = types.StringValue(podName)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *PortForwardEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	raw, diags := req.Private.GetKey(ctx, portForwardPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || raw == nil {
+		return
+	}
+	id, err := strconv.Unquote(string(raw))
+	if err != nil {
+		resp.Diagnostics.AddError("error reading port forward ID", err.Error())
+		return
+	}
+
+	portForwards.Lock()
+	defer portForwards.Unlock()
+	if stop, ok := portForwards.stop[id]; ok {
+		close(stop)
+		delete(portForwards.stop, id)
+	}
+}
+
+// selectReadyPod returns the first running and ready pod by name, or nil when
+// there is none.
+func selectReadyPod(pods []v1.Pod) *v1.Pod {
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	for i, pod := range pods {
+		if pod.Status.Phase != v1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, c := range pod.Status.Conditions {
+			if c.Type == v1.PodReady && c.Status == v1.ConditionTrue {
+				return &pods[i]
+			}
+		}
+	}
+	return nil
+}
+
+// servicePodPort returns the port of the pod the given port of the service
+// targets, resolving named target ports with the ports of its containers.
+func servicePodPort(svc *v1.Service, port int32, pod *v1.Pod) (int32, error) {
+	for _, p := range svc.Spec.Ports {
+		if p.Port != port {
+			continue
+		}
+		switch {
+		case p.TargetPort.Type == intstr.Int && p.TargetPort.IntVal == 0:
+			return p.Port, nil
+		case p.TargetPort.Type == intstr.Int:
+			return p.TargetPort.IntVal, nil
+		}
+		for _, c := range pod.Spec.Containers {
+			for _, cp := range c.Ports {
+				if cp.Name == p.TargetPort.StrVal {
+					return cp.ContainerPort, nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("pod %s has no port named %q", pod.Name, p.TargetPort.StrVal)
+	}
+	return 0, fmt.Errorf("service %s/%s has no port %d", svc.Namespace, svc.Name, port)
+}
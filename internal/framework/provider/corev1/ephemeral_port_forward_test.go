@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+
+package corev1_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccEphemeralPortForward_service(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testEphemeralPortForwardConfig(name),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"echo.test",
+						tfjsonpath.New("data").AtMapKey("address"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^127\.0\.0\.1:\d+$`)),
+					),
+					statecheck.ExpectKnownValue(
+						"echo.test",
+						tfjsonpath.New("data").AtMapKey("pod"),
+						knownvalue.StringExact(name),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testEphemeralPortForwardConfig(name string) string {
+	return fmt.Sprintf(`
+    resource "kubernetes_pod_v1" "test" {
+      metadata {
+        name   = %[1]q
+        labels = { app = %[1]q }
+      }
+      spec {
+        container {
+          name  = "web"
+          image = "nginx:1.27"
+          port {
+            name           = "http"
+            container_port = 80
+          }
+          readiness_probe {
+            http_get {
+              path = "/"
+              port = 80
+            }
+          }
+        }
+      }
+    }
+
+    resource "kubernetes_service_v1" "test" {
+      metadata {
+        name = %[1]q
+      }
+      spec {
+        selector = kubernetes_pod_v1.test.metadata[0].labels
+        port {
+          port        = 8080
+          target_port = "http"
+        }
+      }
+    }
+
+    ephemeral "kubernetes_port_forward" "test" {
+      service_name = kubernetes_service_v1.test.metadata[0].name
+      remote_port  = 8080
+    }
+
+    provider "echo" {
+      data = ephemeral.kubernetes_port_forward.test
+    }
+
+    resource "echo" "test" {}`, name)
+}
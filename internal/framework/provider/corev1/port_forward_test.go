@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+
+package corev1
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestSelectReadyPod(t *testing.T) {
+	pod := func(name string, phase v1.PodPhase, ready v1.ConditionStatus) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: v1.PodStatus{
+				Phase:      phase,
+				Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: ready}},
+			},
+		}
+	}
+	pods := []v1.Pod{
+		pod("web-c", v1.PodRunning, v1.ConditionTrue),
+		pod("web-a", v1.PodPending, v1.ConditionFalse),
+		pod("web-b", v1.PodRunning, v1.ConditionTrue),
+		pod("web-0", v1.PodRunning, v1.ConditionFalse),
+	}
+	if got := selectReadyPod(pods); got == nil || got.Name != "web-b" {
+		t.Fatalf("Expected pod web-b, got %v", got)
+	}
+	if got := selectReadyPod(pods[1:2]); got != nil {
+		t.Fatalf("Expected no pod, got %v", got.Name)
+	}
+}
+
+func TestServicePodPort(t *testing.T) {
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec: v1.ServiceSpec{Ports: []v1.ServicePort{
+			{Port: 5432, TargetPort: intstr.FromString("postgres")},
+			{Port: 80, TargetPort: intstr.FromInt32(8080)},
+			{Port: 9187},
+		}},
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-0"},
+		Spec: v1.PodSpec{Containers: []v1.Container{
+			{Name: "postgres", Ports: []v1.ContainerPort{{Name: "postgres", ContainerPort: 5433}}},
+		}},
+	}
+	for port, expected := range map[int32]int32{5432: 5433, 80: 8080, 9187: 9187} {
+		got, err := servicePodPort(svc, port, pod)
+		if err != nil || got != expected {
+			t.Errorf("Expected port %d of the service to target %d, got %d, %v", port, expected, got, err)
+		}
+	}
+	if _, err := servicePodPort(svc, 443, pod); err == nil {
+		t.Error("Expected an error for a port the service doesn't have")
+	}
+}
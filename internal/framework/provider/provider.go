@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-provider-kubernetes/internal/framework/provider/authenticationv1"
 	"github.com/hashicorp/terraform-provider-kubernetes/internal/framework/provider/certificatesv1"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/framework/provider/corev1"
 	pfunctions "github.com/hashicorp/terraform-provider-kubernetes/internal/framework/provider/functions"
 )
 
@@ -62,6 +63,9 @@ type KubernetesProviderModel struct {
 	IgnoreAnnotations types.List `tfsdk:"ignore_annotations"`
 	IgnoreLabels      types.List `tfsdk:"ignore_labels"`
 
+	TraceRequests types.Bool `tfsdk:"trace_requests"`
+	BatchReads    types.Bool `tfsdk:"batch_reads"`
+
 	Exec []struct {
 		APIVersion types.String            `tfsdk:"api_version"`
 		Command    types.String            `tfsdk:"command"`
@@ -72,6 +76,11 @@ type KubernetesProviderModel struct {
 	Experiments []struct {
 		ManifestResource types.Bool `tfsdk:"manifest_resource"`
 	} `tfsdk:"experiments"`
+
+	RunAttribution []struct {
+		Workspace types.String `tfsdk:"workspace"`
+		RunID     types.String `tfsdk:"run_id"`
+	} `tfsdk:"run_attribution"`
 }
 
 func (p *KubernetesProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -153,6 +162,14 @@ func (p *KubernetesProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				Description: "List of Kubernetes metadata labels to ignore across all resources handled by this provider for situations where external systems are managing certain resource labels. Each item is a regular expression.",
 				Optional:    true,
 			},
+			"trace_requests": schema.BoolAttribute{
+				Description: "Log the headers and bodies of the requests sent to the Kubernetes API and of their responses at DEBUG level, with the values of secrets and credentials redacted and bodies truncated to 4KiB. Can be set with the `KUBE_TRACE_REQUESTS` environment variable.",
+				Optional:    true,
+			},
+			"batch_reads": schema.BoolAttribute{
+				Description: "Refresh the resources of the same kind and namespace with a single `LIST` request of the namespace, sent in pages of 500 objects, instead of a `GET` request per object. Secrets are always read one by one. Can be set with the `KUBE_BATCH_READS` environment variable.",
+				Optional:    true,
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"exec": schema.ListNestedBlock{
@@ -187,6 +204,21 @@ func (p *KubernetesProvider) Schema(ctx context.Context, req provider.SchemaRequ
 					},
 				},
 			},
+			"run_attribution": schema.ListNestedBlock{
+				Description: "Annotate the objects created and updated by the structured resources with the Terraform workspace and run which last changed them, and with the type of the resource managing them. The annotations are ignored when comparing objects with the configuration.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"workspace": schema.StringAttribute{
+							Description: "The Terraform workspace recorded in the `terraform.io/workspace` annotation. Defaults to the `TFC_WORKSPACE_NAME` or `TF_WORKSPACE` environment variable.",
+							Optional:    true,
+						},
+						"run_id": schema.StringAttribute{
+							Description: "The Terraform run recorded in the `terraform.io/run-id` annotation. Defaults to the `TFC_RUN_ID` environment variable.",
+							Optional:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -202,7 +234,9 @@ func (p *KubernetesProvider) DataSources(ctx context.Context) []func() datasourc
 func (p *KubernetesProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
 	return []func() ephemeral.EphemeralResource{
 		authenticationv1.NewTokenRequestEphemeralResource,
+		authenticationv1.NewServiceAccountTokenEphemeralResource,
 		certificatesv1.NewCertificateSigningRequestEphemeralResource,
+		corev1.NewPortForwardEphemeralResource,
 	}
 }
 
@@ -18,7 +18,7 @@ func MuxServer(ctx context.Context, v string) (tfprotov5.ProviderServer, error)
 	kubernetesProvider := kubernetes.Provider()
 
 	providers := []func() tfprotov5.ProviderServer{
-		kubernetesProvider.GRPCProvider,
+		kubernetes.GRPCProviderServer(kubernetesProvider),
 		manifest.Provider(),
 		providerserver.NewProtocol5(framework.New(v, kubernetesProvider.Meta)),
 	}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package telemetry exports OpenTelemetry traces and metrics of the requests
+// sent to the Kubernetes API by the providers. It is configured with the
+// standard OTEL_* environment variables and disabled unless an OTLP endpoint
+// is set.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"k8s.io/client-go/tools/metrics"
+)
+
+// ServiceName is the default name of the service the telemetry is reported
+// for, it can be changed with OTEL_SERVICE_NAME.
+const ServiceName = "terraform-provider-kubernetes"
+
+const (
+	tracesSignal  = "TRACES"
+	metricsSignal = "METRICS"
+)
+
+// Start sets up the global tracer and meter providers when an OTLP endpoint
+// is configured. The returned function flushes and stops the exporters, it
+// must be called before the process exits.
+func Start(ctx context.Context, version string) (func(context.Context) error, error) {
+	shutdown := func(context.Context) error { return nil }
+
+	traces, metrics := signalEnabled(tracesSignal), signalEnabled(metricsSignal)
+	if !traces && !metrics {
+		return shutdown, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(ServiceName),
+			semconv.ServiceVersion(version),
+		),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return shutdown, err
+	}
+
+	var shutdowns []func(context.Context) error
+	shutdown = func(ctx context.Context) error {
+		var errs []error
+		for _, s := range shutdowns {
+			errs = append(errs, s(ctx))
+		}
+		return errors.Join(errs...)
+	}
+
+	if traces {
+		exporter, err := newTraceExporter(ctx)
+		if err != nil {
+			return shutdown, err
+		}
+		tp := sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(res),
+		)
+		shutdowns = append(shutdowns, tp.Shutdown)
+		otel.SetTracerProvider(tp)
+		// Propagate the trace context to the API server, which records its own
+		// spans when its tracing is enabled.
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		))
+	}
+
+	if metrics {
+		exporter, err := newMetricExporter(ctx)
+		if err != nil {
+			return shutdown, err
+		}
+		mp := sdkmetric.NewMeterProvider(
+			sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
+			sdkmetric.WithResource(res),
+		)
+		shutdowns = append(shutdowns, mp.Shutdown)
+		otel.SetMeterProvider(mp)
+		registerClientMetrics()
+	}
+
+	return shutdown, nil
+}
+
+// signalEnabled reports whether an OTLP endpoint is configured for the signal,
+// and it isn't disabled with OTEL_SDK_DISABLED or OTEL_<signal>_EXPORTER=none.
+func signalEnabled(signal string) bool {
+	if disabled, _ := strconv.ParseBool(os.Getenv("OTEL_SDK_DISABLED")); disabled {
+		return false
+	}
+	if exporter := os.Getenv("OTEL_" + signal + "_EXPORTER"); exporter != "" && exporter != "otlp" {
+		return false
+	}
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_"+signal+"_ENDPOINT") != ""
+}
+
+// protocol returns the OTLP protocol of the signal, http/protobuf by default.
+func protocol(signal string) string {
+	if p := os.Getenv("OTEL_EXPORTER_OTLP_" + signal + "_PROTOCOL"); p != "" {
+		return strings.ToLower(p)
+	}
+	if p := os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"); p != "" {
+		return strings.ToLower(p)
+	}
+	return "http/protobuf"
+}
+
+func newTraceExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	if protocol(tracesSignal) == "grpc" {
+		return otlptracegrpc.New(ctx)
+	}
+	return otlptracehttp.New(ctx)
+}
+
+func newMetricExporter(ctx context.Context) (sdkmetric.Exporter, error) {
+	if protocol(metricsSignal) == "grpc" {
+		return otlpmetricgrpc.New(ctx)
+	}
+	return otlpmetrichttp.New(ctx)
+}
+
+// registerClientMetrics records the retries and the client-side rate limiting
+// of client-go. client-go only accepts the first registration.
+func registerClientMetrics() {
+	i := instruments()
+	metrics.Register(metrics.RegisterOpts{
+		RequestRetry:       retryMetric{i},
+		RateLimiterLatency: rateLimiterMetric{i},
+	})
+}
@@ -0,0 +1,216 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/hashicorp/terraform-provider-kubernetes/internal/telemetry"
+
+const (
+	resourceKey    = attribute.Key("k8s.api.resource")
+	groupKey       = attribute.Key("k8s.api.group")
+	subresourceKey = attribute.Key("k8s.api.subresource")
+	namespaceKey   = attribute.Key("k8s.namespace.name")
+)
+
+type meterInstruments struct {
+	requests    metric.Int64Counter
+	duration    metric.Float64Histogram
+	throttles   metric.Int64Counter
+	retries     metric.Int64Counter
+	rateLimiter metric.Float64Histogram
+}
+
+var (
+	instrumentsOnce sync.Once
+	meters          *meterInstruments
+)
+
+// instruments returns the metric instruments, created from the global meter
+// provider. Instruments created before Start are forwarded to the meter
+// provider it sets up.
+func instruments() *meterInstruments {
+	instrumentsOnce.Do(func() {
+		meter := otel.Meter(instrumentationName)
+		meters = &meterInstruments{}
+		meters.requests, _ = meter.Int64Counter("kubernetes.api.requests",
+			metric.WithDescription("Requests sent to the Kubernetes API."),
+			metric.WithUnit("{request}"))
+		meters.duration, _ = meter.Float64Histogram("kubernetes.api.request.duration",
+			metric.WithDescription("Duration of the requests sent to the Kubernetes API."),
+			metric.WithUnit("s"))
+		meters.throttles, _ = meter.Int64Counter("kubernetes.api.throttles",
+			metric.WithDescription("Requests rejected by the Kubernetes API with 429 Too Many Requests."),
+			metric.WithUnit("{request}"))
+		meters.retries, _ = meter.Int64Counter("kubernetes.api.retries",
+			metric.WithDescription("Requests retried by the Kubernetes client."),
+			metric.WithUnit("{request}"))
+		meters.rateLimiter, _ = meter.Float64Histogram("kubernetes.client.rate_limiter.duration",
+			metric.WithDescription("Time requests were delayed by the client-side rate limiter."),
+			metric.WithUnit("s"))
+	})
+	return meters
+}
+
+// NewTransport returns a round tripper recording a span and metrics for every
+// request sent through rt. Without Start, the global providers are no-ops.
+func NewTransport(rt http.RoundTripper) http.RoundTripper {
+	return &transport{rt: rt}
+}
+
+type transport struct {
+	rt http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	info := requestInfo(req.URL.Path)
+	attrs := append([]attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}, info.attributes()...)
+
+	ctx, span := otel.Tracer(instrumentationName).Start(req.Context(), spanName(req.Method, info),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(semconv.URLFull(req.URL.Redacted())),
+	)
+	defer span.End()
+	if info.namespace != "" {
+		span.SetAttributes(namespaceKey.String(info.namespace))
+	}
+
+	if span.SpanContext().IsValid() {
+		req = req.Clone(ctx)
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	}
+
+	start := time.Now()
+	res, err := t.rt.RoundTrip(req)
+	elapsed := time.Since(start).Seconds()
+
+	i := instruments()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		attrs = append(attrs, semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+	} else {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+		if res.StatusCode >= 500 {
+			span.SetStatus(codes.Error, res.Status)
+		}
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(res.StatusCode))
+		if res.StatusCode == http.StatusTooManyRequests {
+			i.throttles.Add(ctx, 1, metric.WithAttributes(attrs...))
+		}
+	}
+	i.requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+	i.duration.Record(ctx, elapsed, metric.WithAttributes(attrs...))
+
+	return res, err
+}
+
+func spanName(method string, info apiRequestInfo) string {
+	if info.resource == "" {
+		return method
+	}
+	name := info.resource
+	if info.subresource != "" {
+		name += "/" + info.subresource
+	}
+	return method + " " + name
+}
+
+// apiRequestInfo describes the resource a request to the Kubernetes API is for.
+type apiRequestInfo struct {
+	group       string
+	resource    string
+	subresource string
+	namespace   string
+}
+
+func (i apiRequestInfo) attributes() []attribute.KeyValue {
+	if i.resource == "" {
+		return nil
+	}
+	attrs := []attribute.KeyValue{
+		groupKey.String(i.group),
+		resourceKey.String(i.resource),
+	}
+	if i.subresource != "" {
+		attrs = append(attrs, subresourceKey.String(i.subresource))
+	}
+	return attrs
+}
+
+// requestInfo parses the path of a request to the Kubernetes API, e.g.
+// /apis/apps/v1/namespaces/default/deployments/web/scale. The names of the
+// objects are left out to keep the cardinality of the metrics low.
+func requestInfo(path string) apiRequestInfo {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	info := apiRequestInfo{}
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		info.group = parts[1]
+		parts = parts[3:]
+	default:
+		return info
+	}
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		info.namespace = parts[1]
+		parts = parts[2:]
+	}
+	if len(parts) == 0 {
+		return info
+	}
+	info.resource = parts[0]
+	if len(parts) >= 3 {
+		info.subresource = parts[2]
+	}
+	return info
+}
+
+type retryMetric struct {
+	i *meterInstruments
+}
+
+func (m retryMetric) IncrementRetry(ctx context.Context, code, method, host string) {
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(method),
+		semconv.ServerAddress(host),
+	}
+	if status, err := strconv.Atoi(code); err == nil {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(status))
+	}
+	m.i.retries.Add(ctx, 1, metric.WithAttributes(attrs...))
+}
+
+type rateLimiterMetric struct {
+	i *meterInstruments
+}
+
+func (m rateLimiterMetric) Observe(ctx context.Context, verb string, u url.URL, latency time.Duration) {
+	m.i.rateLimiter.Record(ctx, latency.Seconds(), metric.WithAttributes(
+		semconv.HTTPRequestMethodKey.String(verb),
+		semconv.ServerAddress(u.Hostname()),
+	))
+}
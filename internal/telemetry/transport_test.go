@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package telemetry
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRequestInfo(t *testing.T) {
+	cases := map[string]apiRequestInfo{
+		"/api/v1/namespaces/default/pods":                   {resource: "pods", namespace: "default"},
+		"/api/v1/namespaces/default/pods/web-0/log":         {resource: "pods", subresource: "log", namespace: "default"},
+		"/api/v1/namespaces/default":                        {resource: "namespaces"},
+		"/api/v1/nodes/node-1":                              {resource: "nodes"},
+		"/apis/apps/v1/namespaces/default/deployments/web":  {group: "apps", resource: "deployments", namespace: "default"},
+		"/apis/apps/v1/namespaces/ci/deployments/web/scale": {group: "apps", resource: "deployments", subresource: "scale", namespace: "ci"},
+		"/apis/rbac.authorization.k8s.io/v1/clusterroles":   {group: "rbac.authorization.k8s.io", resource: "clusterroles"},
+		"/apis/apps/v1":                                     {group: "apps"},
+		"/apis":                                             {},
+		"/version":                                          {},
+	}
+	for path, expected := range cases {
+		t.Run(path, func(t *testing.T) {
+			if got := requestInfo(path); got != expected {
+				t.Fatalf("Expected %#v, got %#v", expected, got)
+			}
+		})
+	}
+}
+
+func TestTransportSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewTransport(http.DefaultTransport)}
+	res, err := client.Get(srv.URL + "/apis/apps/v1/namespaces/default/deployments/web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected a single span, got %d", len(spans))
+	}
+	if name := spans[0].Name(); name != "GET deployments" {
+		t.Fatalf("Unexpected span name %q", name)
+	}
+	if traceparent == "" {
+		t.Fatal("Expected the trace context to be propagated to the API server")
+	}
+	attrs := make(map[string]string)
+	for _, a := range spans[0].Attributes() {
+		attrs[string(a.Key)] = a.Value.Emit()
+	}
+	for k, v := range map[string]string{
+		"http.request.method":       "GET",
+		"http.response.status_code": "429",
+		"k8s.api.group":             "apps",
+		"k8s.api.resource":          "deployments",
+		"k8s.namespace.name":        "default",
+	} {
+		if attrs[k] != v {
+			t.Fatalf("Expected the %s attribute to be %q, got %q", k, v, attrs[k])
+		}
+	}
+}
+
+func TestSignalEnabled(t *testing.T) {
+	t.Setenv("OTEL_SDK_DISABLED", "")
+	t.Setenv("OTEL_TRACES_EXPORTER", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "")
+	if signalEnabled(tracesSignal) || signalEnabled(metricsSignal) {
+		t.Fatal("Expected telemetry to be disabled without an endpoint")
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "http://localhost:4318/v1/metrics")
+	if signalEnabled(tracesSignal) || !signalEnabled(metricsSignal) {
+		t.Fatal("Expected only the metrics to be enabled")
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+	t.Setenv("OTEL_TRACES_EXPORTER", "none")
+	if signalEnabled(tracesSignal) {
+		t.Fatal("Expected the traces to be disabled by OTEL_TRACES_EXPORTER")
+	}
+
+	t.Setenv("OTEL_SDK_DISABLED", "true")
+	if signalEnabled(metricsSignal) {
+		t.Fatal("Expected the metrics to be disabled by OTEL_SDK_DISABLED")
+	}
+}
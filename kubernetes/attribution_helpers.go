@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	attributionWorkspaceAnnotation    = "terraform.io/workspace"
+	attributionRunIDAnnotation        = "terraform.io/run-id"
+	attributionResourceTypeAnnotation = "terraform.io/resource-type"
+)
+
+// attributionAnnotations are the annotations recording the Terraform run which
+// last changed an object. They are never read into the state.
+var attributionAnnotations = []string{
+	attributionWorkspaceAnnotation,
+	attributionRunIDAnnotation,
+	attributionResourceTypeAnnotation,
+}
+
+func runAttributionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Annotate the objects created and updated by the structured resources with the Terraform workspace and run which last changed them, and with the type of the resource managing them. The annotations are ignored when comparing objects with the configuration.",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"workspace": {
+					Type:        schema.TypeString,
+					Description: "The Terraform workspace recorded in the `terraform.io/workspace` annotation. Defaults to the `TFC_WORKSPACE_NAME` or `TF_WORKSPACE` environment variable.",
+					Optional:    true,
+				},
+				"run_id": {
+					Type:        schema.TypeString,
+					Description: "The Terraform run recorded in the `terraform.io/run-id` annotation. Defaults to the `TFC_RUN_ID` environment variable.",
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+// runAttribution is the Terraform run recorded in the annotations of the
+// objects changed by the structured resources.
+type runAttribution struct {
+	Workspace string
+	RunID     string
+}
+
+func expandRunAttribution(l []interface{}) *runAttribution {
+	if len(l) == 0 {
+		return nil
+	}
+	a := &runAttribution{}
+	if in, ok := l[0].(map[string]interface{}); ok {
+		a.Workspace = in["workspace"].(string)
+		a.RunID = in["run_id"].(string)
+	}
+	if a.Workspace == "" {
+		a.Workspace = os.Getenv("TFC_WORKSPACE_NAME")
+	}
+	if a.Workspace == "" {
+		a.Workspace = os.Getenv("TF_WORKSPACE")
+	}
+	if a.RunID == "" {
+		a.RunID = os.Getenv("TFC_RUN_ID")
+	}
+	return a
+}
+
+// annotations returns the annotations recording the run for an object managed
+// by a resource of the given type.
+func (a *runAttribution) annotations(resourceType string) map[string]string {
+	annotations := map[string]string{
+		attributionResourceTypeAnnotation: resourceType,
+	}
+	if a.Workspace != "" {
+		annotations[attributionWorkspaceAnnotation] = a.Workspace
+	}
+	if a.RunID != "" {
+		annotations[attributionRunIDAnnotation] = a.RunID
+	}
+	return annotations
+}
+
+// removeAttributionKeys removes the run attribution annotations, unless they
+// are configured explicitly.
+func removeAttributionKeys(m map[string]string, d map[string]interface{}) {
+	for _, k := range attributionAnnotations {
+		if !isKeyInMap(k, d) {
+			delete(m, k)
+		}
+	}
+}
+
+// withRunAttribution stamps the object of a structured resource with the run
+// attribution annotations after it was created or updated, when the
+// run_attribution provider block is set.
+func withRunAttribution(r *schema.Resource, resourceType string, wr waitableResource) {
+	stamp := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		a := meta.(providerMetadata).RunAttribution
+		if a == nil || d.Id() == "" {
+			return nil
+		}
+		conn, err := meta.(KubeClientsets).DynamicClient()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		namespace := ""
+		if wr.Namespaced {
+			namespace = d.Get("metadata.0.namespace").(string)
+		}
+		name := d.Get("metadata.0.name").(string)
+
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"annotations": a.annotations(resourceType),
+			},
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Annotating %s %q with the Terraform run: %s", wr.GroupVersionResource.Resource, d.Id(), patch))
+		_, err = conn.Resource(wr.GroupVersionResource).Namespace(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to annotate %s %q with the Terraform run", wr.GroupVersionResource.Resource, d.Id()),
+				Detail:   err.Error(),
+			}}
+		}
+		// Read the object again, its resource version changed.
+		return r.ReadContext(ctx, d, meta)
+	}
+
+	create := r.CreateContext
+	r.CreateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := create(ctx, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, stamp(ctx, d, meta)...)
+	}
+
+	if r.UpdateContext == nil {
+		return
+	}
+	update := r.UpdateContext
+	r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := update(ctx, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, stamp(ctx, d, meta)...)
+	}
+}
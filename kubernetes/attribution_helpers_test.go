@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandRunAttribution(t *testing.T) {
+	t.Setenv("TFC_WORKSPACE_NAME", "")
+	t.Setenv("TF_WORKSPACE", "staging")
+	t.Setenv("TFC_RUN_ID", "run-CZcmD7eagjhyX0vN")
+
+	if a := expandRunAttribution(nil); a != nil {
+		t.Fatalf("Expected no attribution without the block, got %#v", a)
+	}
+
+	a := expandRunAttribution([]interface{}{nil})
+	expected := map[string]string{
+		"terraform.io/workspace":     "staging",
+		"terraform.io/run-id":        "run-CZcmD7eagjhyX0vN",
+		"terraform.io/resource-type": "kubernetes_deployment_v1",
+	}
+	if got := a.annotations("kubernetes_deployment_v1"); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Unexpected annotations: %#v", got)
+	}
+
+	a = expandRunAttribution([]interface{}{map[string]interface{}{
+		"workspace": "production",
+		"run_id":    "",
+	}})
+	if a.Workspace != "production" || a.RunID != "run-CZcmD7eagjhyX0vN" {
+		t.Fatalf("Expected the configured workspace and the run ID of the environment, got %#v", a)
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/batchread"
+)
+
+// withBatchedReads lets the refresh of a resource read its object from a LIST
+// of its namespace shared with the other resources of the same kind, see
+// batchread. Reads made while creating or updating objects are never batched.
+func withBatchedReads(r *schema.Resource) {
+	read := r.ReadContext
+	if read == nil {
+		return
+	}
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return read(batchread.WithBatching(ctx), d, meta)
+	}
+}
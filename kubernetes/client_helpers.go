@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+	aggregator "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
+)
+
+const (
+	// defaultClientQPS and defaultClientBurst replace the client-go defaults of
+	// 5 and 10, which throttle the requests of runs with a high parallelism.
+	// They are shared by all the clients of the provider.
+	defaultClientQPS   = 50
+	defaultClientBurst = 100
+)
+
+// lazyClient creates a client the first time it is needed and returns the same
+// client, or error, afterwards.
+type lazyClient[T any] struct {
+	once   sync.Once
+	client T
+	err    error
+}
+
+func (l *lazyClient[T]) get(create func() (T, error)) (T, error) {
+	l.once.Do(func() {
+		l.client, l.err = create()
+	})
+	return l.client, l.err
+}
+
+// kubeClients holds the clients shared by all the resources of a run. They use
+// a single HTTP client, so that its connections and TLS sessions are reused,
+// and a single rate limiter. Nothing connects to the API server until a client
+// is first used, which lets the provider be configured before the cluster
+// exists.
+type kubeClients struct {
+	config *restclient.Config
+
+	httpClient lazyClient[*http.Client]
+	main       lazyClient[*kubernetes.Clientset]
+	aggregator lazyClient[*aggregator.Clientset]
+	dynamic    lazyClient[dynamic.Interface]
+	discovery  lazyClient[discovery.DiscoveryInterface]
+}
+
+func newKubeClients(config *restclient.Config) *kubeClients {
+	c := restclient.CopyConfig(config)
+	if c.RateLimiter == nil && c.QPS >= 0 {
+		qps, burst := c.QPS, c.Burst
+		if qps == 0 {
+			qps = defaultClientQPS
+		}
+		if burst == 0 {
+			burst = defaultClientBurst
+		}
+		c.RateLimiter = flowcontrol.NewTokenBucketRateLimiter(qps, burst)
+	}
+	return &kubeClients{config: c}
+}
+
+func (c *kubeClients) http() (*http.Client, error) {
+	return c.httpClient.get(func() (*http.Client, error) {
+		rt, err := restclient.TransportFor(c.config)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Client{
+			Transport: &firstConnectionTransport{rt: rt, done: make(chan struct{})},
+			Timeout:   c.config.Timeout,
+		}, nil
+	})
+}
+
+// firstConnectionTransport holds the requests back until the first one
+// completes. Concurrent requests would otherwise each open a connection and
+// go through a TLS handshake, as none is established yet to multiplex them on
+// with HTTP/2.
+type firstConnectionTransport struct {
+	rt   http.RoundTripper
+	once sync.Once
+	done chan struct{}
+}
+
+func (t *firstConnectionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	first := false
+	t.once.Do(func() {
+		first = true
+	})
+	if first {
+		defer close(t.done)
+		return t.rt.RoundTrip(req)
+	}
+	select {
+	case <-t.done:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	return t.rt.RoundTrip(req)
+}
+
+func (c *kubeClients) mainClientset() (*kubernetes.Clientset, error) {
+	return c.main.get(func() (*kubernetes.Clientset, error) {
+		hc, err := c.http()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to configure client: %s", err)
+		}
+		kc, err := kubernetes.NewForConfigAndClient(protobufConfig(c.config), hc)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to configure client: %s", err)
+		}
+		return kc, nil
+	})
+}
+
+func (c *kubeClients) aggregatorClientset() (*aggregator.Clientset, error) {
+	return c.aggregator.get(func() (*aggregator.Clientset, error) {
+		hc, err := c.http()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to configure client: %s", err)
+		}
+		ac, err := aggregator.NewForConfigAndClient(protobufConfig(c.config), hc)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to configure client: %s", err)
+		}
+		return ac, nil
+	})
+}
+
+func (c *kubeClients) dynamicClient() (dynamic.Interface, error) {
+	return c.dynamic.get(func() (dynamic.Interface, error) {
+		hc, err := c.http()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to configure dynamic client: %s", err)
+		}
+		dc, err := dynamic.NewForConfigAndClient(c.config, hc)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to configure dynamic client: %s", err)
+		}
+		return dc, nil
+	})
+}
+
+func (c *kubeClients) discoveryClient() (discovery.DiscoveryInterface, error) {
+	return c.discovery.get(func() (discovery.DiscoveryInterface, error) {
+		hc, err := c.http()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to configure discovery client: %s", err)
+		}
+		dc, err := discovery.NewDiscoveryClientForConfigAndClient(c.config, hc)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to configure discovery client: %s", err)
+		}
+		return dc, nil
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestKubeClientsShared(t *testing.T) {
+	var connections int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"default"}}`))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	cfg := &restclient.Config{
+		Host:            srv.URL,
+		TLSClientConfig: restclient.TLSClientConfig{Insecure: true},
+	}
+	m := providerMetadata{config: cfg, clients: newKubeClients(cfg)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		// Every resource gets its own copy of the metadata.
+		go func(meta interface{}) {
+			defer wg.Done()
+			conn, err := meta.(KubeClientsets).MainClientset()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if _, err := conn.CoreV1().Namespaces().Get(context.Background(), "default", metav1.GetOptions{}); err != nil {
+				t.Error(err)
+			}
+		}(m)
+	}
+	wg.Wait()
+
+	c := m
+	a, _ := m.MainClientset()
+	b, _ := c.MainClientset()
+	if a != b {
+		t.Fatal("expected the copies of the metadata to share the clientset")
+	}
+	if n := atomic.LoadInt32(&connections); n != 1 {
+		t.Fatalf("expected a single connection to the API server, got %d", n)
+	}
+}
+
+func TestNewKubeClientsRateLimiter(t *testing.T) {
+	c := newKubeClients(&restclient.Config{})
+	if c.config.RateLimiter == nil {
+		t.Fatal("expected a shared rate limiter")
+	}
+	if qps := c.config.RateLimiter.QPS(); qps != defaultClientQPS {
+		t.Fatalf("expected %d QPS, got %f", defaultClientQPS, qps)
+	}
+
+	c = newKubeClients(&restclient.Config{QPS: 20, Burst: 40})
+	if qps := c.config.RateLimiter.QPS(); qps != 20 {
+		t.Fatalf("expected 20 QPS, got %f", qps)
+	}
+
+	c = newKubeClients(&restclient.Config{QPS: -1})
+	if c.config.RateLimiter != nil {
+		t.Fatal("expected no rate limiter when the QPS is negative")
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// withExistenceFields adds the `optional` and `exists` attributes to the schema
+// of a data source which reads a single object by name.
+func withExistenceFields(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["optional"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "When true (the default) a missing object is not an error and `exists` is set to false. When false, reading an object that does not exist fails.",
+		Optional:    true,
+		Default:     true,
+	}
+	s["exists"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Whether the object exists in the cluster.",
+		Computed:    true,
+	}
+	return s
+}
+
+// dataSourceObjectNotFound records that the object read by a data source does
+// not exist. It only returns an error when the data source is not optional.
+func dataSourceObjectNotFound(ctx context.Context, d *schema.ResourceData, kind, id string) diag.Diagnostics {
+	if !d.Get("optional").(bool) {
+		return diag.Errorf("%s %q not found", kind, id)
+	}
+	tflog.Info(ctx, fmt.Sprintf("%s %q not found", kind, id))
+	d.SetId(id)
+	if err := d.Set("exists", false); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
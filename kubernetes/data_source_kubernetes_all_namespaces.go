@@ -7,11 +7,12 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -20,6 +21,8 @@ func dataSourceKubernetesAllNamespaces() *schema.Resource {
 		Description: "This data source provides a mechanism for listing the names of all available namespaces in a Kubernetes cluster. It can be used to check for existence of a specific namespaces or to apply another resource to all or a subset of existing namespaces in a cluster.In Kubernetes, namespaces provide a scope for names and are intended as a way to divide cluster resources between multiple users.",
 		ReadContext: dataSourceKubernetesAllNamespacesRead,
 		Schema: map[string]*schema.Schema{
+			"page_size": listPageSizeSchema(),
+			"max_items": listMaxItemsSchema("namespaces"),
 			"namespaces": {
 				Type:        schema.TypeList,
 				Description: "List of all namespaces in a cluster.",
@@ -38,17 +41,24 @@ func dataSourceKubernetesAllNamespacesRead(ctx context.Context, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Listing namespaces")
-	nsRaw, err := conn.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	tflog.Info(ctx, "Listing namespaces")
+	paging := expandListPaging(d)
+	items, truncated, err := listAll(ctx, metav1.ListOptions{}, paging, conn.CoreV1().Namespaces().List, func(l *corev1.NamespaceList) []corev1.Namespace {
+		return l.Items
+	})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	namespaces := make([]string, len(nsRaw.Items))
-	for i, v := range nsRaw.Items {
+	var diags diag.Diagnostics
+	if truncated {
+		diags = listTruncatedDiagnostics("namespaces", paging.maxItems)
+	}
+	namespaces := make([]string, len(items))
+	for i, v := range items {
 		namespaces[i] = v.Name
 	}
-	log.Printf("[INFO] Received namespaces: %#v", namespaces)
+	tflog.Info(ctx, fmt.Sprintf("Received namespaces: %#v", namespaces))
 	err = d.Set("namespaces", namespaces)
 	if err != nil {
 		return diag.FromErr(err)
@@ -62,5 +72,5 @@ func dataSourceKubernetesAllNamespacesRead(ctx context.Context, d *schema.Resour
 	}
 	id := fmt.Sprintf("%x", idsum.Sum(nil))
 	d.SetId(id)
-	return nil
+	return diags
 }
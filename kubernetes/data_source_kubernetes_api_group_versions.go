@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesAPIGroupVersions() *schema.Resource {
+	return &schema.Resource{
+		Description: "This data source returns the versions served by the cluster for a given API group, which can be used to select a compatible API version in modules that target clusters of different Kubernetes versions. When the group is not served by the cluster all version attributes are empty.",
+		ReadContext: dataSourceKubernetesAPIGroupVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:        schema.TypeString,
+				Description: "Name of the API group, e.g. `autoscaling` or `gateway.networking.k8s.io`. Leave empty to query the core (legacy) group.",
+				Optional:    true,
+				Default:     "",
+			},
+			"served": {
+				Type:        schema.TypeBool,
+				Description: "Whether the API group is served by the cluster.",
+				Computed:    true,
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Description: "List of versions served for the API group, e.g. `v2`, in the order returned by the API server.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"group_versions": {
+				Type:        schema.TypeList,
+				Description: "List of group versions served for the API group, e.g. `autoscaling/v2`, suitable for use as an `apiVersion`.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"preferred_version": {
+				Type:        schema.TypeString,
+				Description: "The version preferred by the API server, e.g. `v2`.",
+				Computed:    true,
+			},
+			"preferred_group_version": {
+				Type:        schema.TypeString,
+				Description: "The group version preferred by the API server, e.g. `autoscaling/v2`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesAPIGroupVersionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dc, err := meta.(KubeClientsets).DiscoveryClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	group := d.Get("group").(string)
+
+	tflog.Info(ctx, "Listing server API groups")
+	groups, err := dc.ServerGroups()
+	if err != nil {
+		return diag.Errorf("Unable to list server API groups: %s", err)
+	}
+
+	d.SetId(group)
+	if group == "" {
+		// The core group has no name, use the same ID as kubectl
+		d.SetId("core")
+	}
+
+	var apiGroup *metav1.APIGroup
+	for i := range groups.Groups {
+		if groups.Groups[i].Name == group {
+			apiGroup = &groups.Groups[i]
+			break
+		}
+	}
+
+	versions := []string{}
+	groupVersions := []string{}
+	preferredVersion := ""
+	preferredGroupVersion := ""
+	if apiGroup != nil {
+		for _, v := range apiGroup.Versions {
+			versions = append(versions, v.Version)
+			groupVersions = append(groupVersions, v.GroupVersion)
+		}
+		preferredVersion = apiGroup.PreferredVersion.Version
+		preferredGroupVersion = apiGroup.PreferredVersion.GroupVersion
+	}
+	tflog.Info(ctx, fmt.Sprintf("API group %q serves versions: %#v", group, groupVersions))
+
+	err = d.Set("served", apiGroup != nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("versions", versions)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("group_versions", groupVersions)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("preferred_version", preferredVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("preferred_group_version", preferredGroupVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
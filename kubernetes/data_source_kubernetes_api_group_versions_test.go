@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKubernetesDataSourceAPIGroupVersions_basic(t *testing.T) {
+	dataSourceName := "data.kubernetes_api_group_versions.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceAPIGroupVersionsConfig("apps"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", "apps"),
+					resource.TestCheckResourceAttr(dataSourceName, "served", "true"),
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "versions.*", "v1"),
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "group_versions.*", "apps/v1"),
+					resource.TestCheckResourceAttr(dataSourceName, "preferred_version", "v1"),
+					resource.TestCheckResourceAttr(dataSourceName, "preferred_group_version", "apps/v1"),
+				),
+			},
+			{
+				Config: testAccKubernetesDataSourceAPIGroupVersionsConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", "core"),
+					resource.TestCheckResourceAttr(dataSourceName, "served", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "preferred_group_version", "v1"),
+				),
+			},
+			{
+				Config: testAccKubernetesDataSourceAPIGroupVersionsConfig("tf-acc-test.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "served", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "versions.#", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "preferred_version", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceAPIGroupVersionsConfig(group string) string {
+	return fmt.Sprintf(`data "kubernetes_api_group_versions" "test" {
+  group = %q
+}
+`, group)
+}
@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+// clusterHealthEndpoints are the health endpoints of the API server queried by
+// the kubernetes_cluster_health data source.
+var clusterHealthEndpoints = []string{"livez", "readyz"}
+
+func dataSourceKubernetesClusterHealth() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesClusterHealthRead,
+		Description: "This data source queries the `/livez`, `/readyz` and `/version` endpoints of the API server, with the result of each individual check. Use it to gate risky changes on the health of the control plane. The checks which fail are reported as warnings.",
+		Schema: map[string]*schema.Schema{
+			"exclude": {
+				Type:        schema.TypeSet,
+				Description: "Names of the checks to leave out, e.g. `etcd` or `poststarthook/start-apiextensions-controllers`.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"fail_on_unhealthy": {
+				Type:        schema.TypeBool,
+				Description: "Fail, instead of warning, when the API server isn't live or ready. Defaults to false.",
+				Optional:    true,
+				Default:     false,
+			},
+			"live": {
+				Type:        schema.TypeBool,
+				Description: "Whether all the checks of `/livez` pass.",
+				Computed:    true,
+			},
+			"ready": {
+				Type:        schema.TypeBool,
+				Description: "Whether all the checks of `/readyz` pass.",
+				Computed:    true,
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Description: "The git version of the API server, e.g. `v1.31.2`.",
+				Computed:    true,
+			},
+			"checks": {
+				Type:        schema.TypeList,
+				Description: "The individual checks of `/livez` and `/readyz`.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"endpoint": {
+							Type:        schema.TypeString,
+							Description: "The endpoint of the check, `livez` or `readyz`.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the check, e.g. `etcd`.",
+							Computed:    true,
+						},
+						"healthy": {
+							Type:        schema.TypeBool,
+							Description: "Whether the check passes.",
+							Computed:    true,
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Description: "The result of the check, `ok` or the reason it fails.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesClusterHealthRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sv, err := conn.ServerVersion()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	exclude := expandStringSlice(d.Get("exclude").(*schema.Set).List())
+	severity := diag.Warning
+	if d.Get("fail_on_unhealthy").(bool) {
+		severity = diag.Error
+	}
+
+	var diags diag.Diagnostics
+	var checks []interface{}
+	healthy := map[string]bool{}
+	for _, endpoint := range clusterHealthEndpoints {
+		results, ok, err := queryClusterHealth(ctx, conn, endpoint, exclude)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		healthy[endpoint] = ok
+		for _, c := range results {
+			checks = append(checks, map[string]interface{}{
+				"endpoint": endpoint,
+				"name":     c.name,
+				"healthy":  c.healthy,
+				"message":  c.message,
+			})
+			if !c.healthy {
+				diags = append(diags, diag.Diagnostic{
+					Severity: severity,
+					Summary:  "Cluster health check failed",
+					Detail:   fmt.Sprintf("The %s check %q of the API server fails: %s", endpoint, c.name, c.message),
+				})
+			}
+		}
+		if !ok && len(results) == 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: severity,
+				Summary:  "Cluster health check failed",
+				Detail:   fmt.Sprintf("The %s checks of the API server fail.", endpoint),
+			})
+		}
+	}
+
+	d.SetId(sv.GitVersion)
+	d.Set("version", sv.GitVersion)
+	d.Set("live", healthy["livez"])
+	d.Set("ready", healthy["readyz"])
+	if err := d.Set("checks", checks); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
+// clusterHealthCheck is an individual check of a health endpoint.
+type clusterHealthCheck struct {
+	name    string
+	healthy bool
+	message string
+}
+
+// queryClusterHealth queries a health endpoint of the API server in verbose
+// mode. It reports whether the endpoint passes, the API server responds with
+// an internal server error when a check fails.
+func queryClusterHealth(ctx context.Context, conn kubernetes.Interface, endpoint string, exclude []string) ([]clusterHealthCheck, bool, error) {
+	req := conn.Discovery().RESTClient().Get().AbsPath("/"+endpoint).Param("verbose", "true")
+	for _, e := range exclude {
+		req = req.Param("exclude", e)
+	}
+	res := req.Do(ctx)
+	var code int
+	res.StatusCode(&code)
+	body, err := res.Raw()
+	if err != nil && code != http.StatusInternalServerError {
+		return nil, false, fmt.Errorf("Failed to query /%s: %s", endpoint, err)
+	}
+	return parseClusterHealthChecks(string(body)), code == http.StatusOK, nil
+}
+
+// parseClusterHealthChecks parses the verbose output of a health endpoint,
+// e.g. "[+]ping ok" and "[-]etcd failed: reason withheld".
+func parseClusterHealthChecks(body string) []clusterHealthCheck {
+	var checks []clusterHealthCheck
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		var c clusterHealthCheck
+		switch {
+		case strings.HasPrefix(line, "[+]"):
+			c.healthy = true
+		case strings.HasPrefix(line, "[-]"):
+		default:
+			continue
+		}
+		c.name, c.message, _ = strings.Cut(line[3:], " ")
+		if !c.healthy {
+			c.message = strings.TrimPrefix(c.message, "failed: ")
+		}
+		checks = append(checks, c)
+	}
+	return checks
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestParseClusterHealthChecks(t *testing.T) {
+	body := `[+]ping ok
+[+]log ok
+[-]etcd failed: reason withheld
+[+]poststarthook/start-apiextensions-controllers ok
+readyz check failed
+`
+	expected := []clusterHealthCheck{
+		{name: "ping", healthy: true, message: "ok"},
+		{name: "log", healthy: true, message: "ok"},
+		{name: "etcd", healthy: false, message: "reason withheld"},
+		{name: "poststarthook/start-apiextensions-controllers", healthy: true, message: "ok"},
+	}
+	if diff := cmp.Diff(expected, parseClusterHealthChecks(body), cmp.AllowUnexported(clusterHealthCheck{})); diff != "" {
+		t.Fatalf("Unexpected checks: mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestQueryClusterHealth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("verbose") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/livez":
+			fmt.Fprint(w, "[+]ping ok\nlivez check passed\n")
+		case "/readyz":
+			if len(r.URL.Query()["exclude"]) > 0 {
+				fmt.Fprint(w, "[+]ping ok\nreadyz check passed\n")
+				return
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, "[+]ping ok\n[-]etcd failed: reason withheld\nreadyz check failed\n")
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	checks, ok, err := queryClusterHealth(ctx, conn, "livez", nil)
+	if err != nil || !ok || len(checks) != 1 {
+		t.Fatalf("Expected livez to pass with one check, got %v, %v, %v", checks, ok, err)
+	}
+
+	checks, ok, err = queryClusterHealth(ctx, conn, "readyz", nil)
+	if err != nil || ok {
+		t.Fatalf("Expected readyz to fail without an error, got %v, %v", ok, err)
+	}
+	if len(checks) != 2 || checks[1].healthy || checks[1].message != "reason withheld" {
+		t.Fatalf("Expected the etcd check to fail, got %v", checks)
+	}
+
+	if _, ok, err = queryClusterHealth(ctx, conn, "readyz", []string{"etcd"}); err != nil || !ok {
+		t.Fatalf("Expected readyz to pass without etcd, got %v, %v", ok, err)
+	}
+
+	if _, _, err = queryClusterHealth(ctx, conn, "healthz", nil); err == nil {
+		t.Fatal("Expected an error for a forbidden endpoint")
+	}
+}
+
+func TestAccKubernetesDataSourceClusterHealth_basic(t *testing.T) {
+	dataSourceName := "data.kubernetes_cluster_health.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceClusterHealthConfig_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "live", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "ready", "true"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "version"),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "checks.*", map[string]string{
+						"endpoint": "readyz",
+						"name":     "ping",
+						"healthy":  "true",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceClusterHealthConfig_basic() string {
+	return `data "kubernetes_cluster_health" "test" {
+  fail_on_unhealthy = true
+}`
+}
@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -18,7 +19,7 @@ func dataSourceKubernetesConfigMapV1() *schema.Resource {
 		Description: "Config Maps are key-value pairs containing configuration data. The Config Map data source provides a mechanism for extracting these key-value pairs.",
 		ReadContext: dataSourceKubernetesConfigMapV1Read,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withExistenceFields(map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("config_map", false),
 			"data": {
 				Type:        schema.TypeMap,
@@ -35,7 +36,7 @@ func dataSourceKubernetesConfigMapV1() *schema.Resource {
 				Optional:    true,
 				Description: "Immutable, if set to true, ensures that data stored in the ConfigMap cannot be updated (only object metadata can be modified). If not set to true, the field can be modified at any time. Defaulted to nil.",
 			},
-		},
+		}),
 	}
 }
 
@@ -53,16 +54,20 @@ func dataSourceKubernetesConfigMapV1Read(ctx context.Context, d *schema.Resource
 	}
 	d.SetId(buildId(om))
 
-	log.Printf("[INFO] Reading config map %s", metadata.Name)
+	tflog.Info(ctx, fmt.Sprintf("Reading config map %s", metadata.Name))
 	cfgMap, err := conn.CoreV1().ConfigMaps(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil
+			return dataSourceObjectNotFound(ctx, d, "Config map", d.Id())
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Received config map: %#v", cfgMap))
+	err = d.Set("exists", true)
+	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received config map: %#v", cfgMap)
 
 	err = d.Set("metadata", flattenMetadataFields(cfgMap.ObjectMeta))
 	if err != nil {
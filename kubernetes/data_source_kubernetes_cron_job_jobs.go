@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesCronJobJobs() *schema.Resource {
+	return &schema.Resource{
+		Description: "This data source lists the Jobs of a cron job, i.e. the Jobs it owns, with their status, e.g. to inspect its recent runs. Only the Jobs kept by the history limits of the cron job, or by their TTL, are listed.",
+		ReadContext: dataSourceKubernetesCronJobJobsRead,
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "The namespace of the cron job. Defaults to `default`.",
+				Optional:    true,
+				Default:     "default",
+			},
+			"cron_job_name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the cron job to list the Jobs of. Only `batch/v1` cron jobs are supported.",
+				Required:     true,
+				ValidateFunc: validateName,
+			},
+			"page_size": listPageSizeSchema(),
+			"jobs": {
+				Type:        schema.TypeList,
+				Description: "The Jobs of the cron job, sorted from the most recent one to the oldest one.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the Job.",
+							Computed:    true,
+						},
+						"creation_timestamp": {
+							Type:        schema.TypeString,
+							Description: "The time the Job was created, in RFC 3339 format.",
+							Computed:    true,
+						},
+						"manual": {
+							Type:        schema.TypeBool,
+							Description: "Whether the Job was created manually from the cron job, e.g. by `kubectl create job --from=cronjob/...` or by a `kubernetes_cron_job_trigger`, rather than on schedule.",
+							Computed:    true,
+						},
+						"status": resourceKubernetesJobV1Schema()["status"],
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesCronJobJobsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace := d.Get("namespace").(string)
+	cronJobName := d.Get("cron_job_name").(string)
+	cronJob, err := conn.BatchV1().CronJobs(namespace).Get(ctx, cronJobName, metav1.GetOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to get cron job %s/%s: %s", namespace, cronJobName, err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Listing jobs of cron job %s/%s", namespace, cronJobName))
+	// The Jobs of a cron job carry no label of it, so all the Jobs of the
+	// namespace are listed and checked to be owned by it.
+	paging := listPaging{pageSize: int64(d.Get("page_size").(int))}
+	items, _, err := listAll(ctx, metav1.ListOptions{}, paging, conn.BatchV1().Jobs(namespace).List, func(l *batchv1.JobList) []batchv1.Job {
+		return l.Items
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildId(cronJob.ObjectMeta))
+	err = d.Set("jobs", flattenCronJobJobs(jobsControlledBy(items, cronJob)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func jobsControlledBy(in []batchv1.Job, cronJob *batchv1.CronJob) []batchv1.Job {
+	var out []batchv1.Job
+	for _, job := range in {
+		if owner := metav1.GetControllerOf(&job); owner != nil && owner.UID == cronJob.UID {
+			out = append(out, job)
+		}
+	}
+	return out
+}
+
+func flattenCronJobJobs(in []batchv1.Job) []interface{} {
+	sort.SliceStable(in, func(i, j int) bool {
+		if ti, tj := in[i].CreationTimestamp, in[j].CreationTimestamp; !ti.Equal(&tj) {
+			return tj.Before(&ti)
+		}
+		return in[i].Name > in[j].Name
+	})
+	out := make([]interface{}, len(in))
+	for i, job := range in {
+		out[i] = map[string]interface{}{
+			"name":               job.Name,
+			"creation_timestamp": job.CreationTimestamp.UTC().Format(time.RFC3339),
+			"manual":             job.Annotations[cronJobInstantiateAnnotation] == "manual",
+			"status":             flattenJobV1Status(job.Status),
+		}
+	}
+	return out
+}
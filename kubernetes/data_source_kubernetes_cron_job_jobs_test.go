@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestFlattenCronJobJobs(t *testing.T) {
+	controller := true
+	created := time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)
+	job := func(name string, age time.Duration, cronJobUID types.UID, manual bool) batchv1.Job {
+		j := batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created.Add(-age)),
+			},
+		}
+		if manual {
+			j.Annotations = map[string]string{cronJobInstantiateAnnotation: "manual"}
+		}
+		if cronJobUID != "" {
+			j.OwnerReferences = []metav1.OwnerReference{{APIVersion: "batch/v1", Kind: "CronJob", Name: "backup", UID: cronJobUID, Controller: &controller}}
+		}
+		return j
+	}
+	cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "backup", UID: "1"}}
+	items := jobsControlledBy([]batchv1.Job{
+		job("backup-28570000", 48*time.Hour, "1", false),
+		job("backup-manual-x7k2p", time.Hour, "1", true),
+		job("backup-28571440", 24*time.Hour, "1", false),
+		job("backup-28572880", 0, "2", false),
+		job("migrate", 0, "", false),
+	}, cronJob)
+
+	got := flattenCronJobJobs(items)
+	if len(got) != 3 {
+		t.Fatalf("Expected the 3 jobs of the cron job, got %d", len(got))
+	}
+	var names []string
+	for _, j := range got {
+		names = append(names, j.(map[string]interface{})["name"].(string))
+	}
+	if fmt.Sprint(names) != "[backup-manual-x7k2p backup-28571440 backup-28570000]" {
+		t.Fatalf("Expected the most recent job first, got %v", names)
+	}
+	first := got[0].(map[string]interface{})
+	if first["manual"] != true || first["creation_timestamp"] != "2024-05-01T02:00:00Z" {
+		t.Fatalf("Unexpected first job: %v", first)
+	}
+	if got[1].(map[string]interface{})["manual"] != false {
+		t.Fatalf("Expected the scheduled job not to be manual, got %v", got[1])
+	}
+}
+
+func TestAccKubernetesDataSourceCronJobJobs_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	dataSourceName := "data.kubernetes_cron_job_jobs.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceCronJobJobsConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "jobs.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "jobs.0.name", "kubernetes_cron_job_trigger.test", "job_name"),
+					resource.TestCheckResourceAttr(dataSourceName, "jobs.0.manual", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "jobs.0.status.0.succeeded", "1"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "jobs.0.status.0.completion_time"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceCronJobJobsConfig_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_cron_job_v1" "test" {
+  metadata {
+    name = %[1]q
+  }
+  spec {
+    schedule = "0 0 1 1 *"
+    suspend  = true
+    job_template {
+      metadata {}
+      spec {
+        template {
+          metadata {}
+          spec {
+            container {
+              name    = "backup"
+              image   = %[2]q
+              command = ["sh", "-c", "echo backup"]
+            }
+            restart_policy = "Never"
+          }
+        }
+      }
+    }
+  }
+}
+
+resource "kubernetes_cron_job_trigger" "test" {
+  cron_job_name = kubernetes_cron_job_v1.test.metadata.0.name
+  timeouts {
+    create = "2m"
+  }
+}
+
+data "kubernetes_cron_job_jobs" "test" {
+  cron_job_name = kubernetes_cron_job_trigger.test.cron_job_name
+}
+`, name, busyboxImage)
+}
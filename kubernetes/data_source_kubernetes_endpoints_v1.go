@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -17,7 +18,7 @@ func dataSourceKubernetesEndpointsV1() *schema.Resource {
 	return &schema.Resource{
 		Description: "An Endpoints resource is an abstraction, linked to a Service, which defines the list of endpoints that actually implement the service.",
 		ReadContext: dataSourceKubernetesEndpointsV1Read,
-		Schema: map[string]*schema.Schema{
+		Schema: withExistenceFields(map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("endpoints", true),
 			"subset": {
 				Type:        schema.TypeSet,
@@ -26,7 +27,7 @@ func dataSourceKubernetesEndpointsV1() *schema.Resource {
 				Elem:        schemaEndpointsSubset(),
 				Set:         hashEndpointsSubset(),
 			},
-		},
+		}),
 	}
 }
 
@@ -44,16 +45,20 @@ func dataSourceKubernetesEndpointsV1Read(ctx context.Context, d *schema.Resource
 	}
 	d.SetId(buildId(om))
 
-	log.Printf("[INFO] Reading endpoints %s", metadata.Name)
+	tflog.Info(ctx, fmt.Sprintf("Reading endpoints %s", metadata.Name))
 	ep, err := conn.CoreV1().Endpoints(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil
+			return dataSourceObjectNotFound(ctx, d, "Endpoints", d.Id())
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.Errorf("Failed to read endpoint because: %s", err)
 	}
-	log.Printf("[INFO] Received endpoints: %#v", ep)
+	tflog.Info(ctx, fmt.Sprintf("Received endpoints: %#v", ep))
+	err = d.Set("exists", true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	err = d.Set("metadata", flattenMetadataFields(ep.ObjectMeta))
 	if err != nil {
@@ -0,0 +1,227 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+)
+
+const (
+	helmManagedBySelector          = "app.kubernetes.io/managed-by=Helm"
+	helmReleaseNameAnnotation      = "meta.helm.sh/release-name"
+	helmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+)
+
+func dataSourceKubernetesHelmReleaseObjects() *schema.Resource {
+	return &schema.Resource{
+		Description: "This data source lists the objects of a Helm release and returns their manifests and import IDs, which can be used with `for_each` in `import` blocks to move the management of the objects from Helm to Terraform.",
+		ReadContext: dataSourceKubernetesHelmReleaseObjectsRead,
+		Schema: map[string]*schema.Schema{
+			"release_name": {
+				Type:        schema.TypeString,
+				Description: "The name of the Helm release.",
+				Required:    true,
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "The namespace of the Helm release. Defaults to `default`.",
+				Optional:    true,
+				Default:     "default",
+			},
+			"page_size": listPageSizeSchema(),
+			"objects": {
+				Type:        schema.TypeList,
+				Description: "The objects of the Helm release, sorted by their `manifest_import_id`.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:        schema.TypeString,
+							Description: "The apiVersion of the object.",
+							Computed:    true,
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Description: "The kind of the object.",
+							Computed:    true,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "The namespace of the object, empty for cluster-scoped objects.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the object.",
+							Computed:    true,
+						},
+						"import_id": {
+							Type:        schema.TypeString,
+							Description: "The import ID of the object for the structured resources, `<namespace>/<name>` for namespaced objects and `<name>` for cluster-scoped ones.",
+							Computed:    true,
+						},
+						"manifest_import_id": {
+							Type:        schema.TypeString,
+							Description: "The import ID of the object for the `kubernetes_manifest` resource.",
+							Computed:    true,
+						},
+						"manifest": {
+							Type:        schema.TypeString,
+							Description: "The JSON encoded manifest of the object, without its status and the metadata populated by the API server. The `data` and `stringData` of secrets are left out. Decode it with `jsondecode` to use it as the `manifest` of a `kubernetes_manifest` resource.",
+							Computed:    true,
+							Sensitive:   true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesHelmReleaseObjectsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn, err := m.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	dc, err := m.(KubeClientsets).DiscoveryClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	release := d.Get("release_name").(string)
+	namespace := d.Get("namespace").(string)
+
+	resourceLists, err := dc.ServerPreferredResources()
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return diag.Errorf("Unable to discover the API resources: %s", err)
+	}
+
+	// The objects of the release are only known once their annotations are
+	// checked, all the objects managed by Helm are listed.
+	paging := listPaging{pageSize: int64(d.Get("page_size").(int))}
+	var items []unstructured.Unstructured
+	for _, list := range resourceLists {
+		gv, err := k8sschema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") || !slices.Contains(r.Verbs, "list") {
+				continue
+			}
+			gvr := gv.WithResource(r.Name)
+			l, _, err := listAll(ctx, metav1.ListOptions{LabelSelector: helmManagedBySelector}, paging, conn.Resource(gvr).List, func(l *unstructured.UnstructuredList) []unstructured.Unstructured {
+				return l.Items
+			})
+			if err != nil {
+				if errors.IsForbidden(err) || errors.IsNotFound(err) || errors.IsMethodNotSupported(err) {
+					tflog.Info(ctx, fmt.Sprintf("Skipping %s: %s", gvr, err))
+					continue
+				}
+				return diag.Errorf("Unable to list %s: %s", gvr, err)
+			}
+			items = append(items, l...)
+		}
+	}
+
+	objects, err := helmReleaseObjects(items, release, namespace)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Found %d objects of the Helm release %s/%s", len(objects), namespace, release))
+
+	d.SetId(fmt.Sprintf("%s/%s", namespace, release))
+	err = d.Set("objects", objects)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// helmReleaseObjects returns the attributes of the objects which belong to the
+// given Helm release, sorted by their import ID for kubernetes_manifest. Objects
+// listed more than once, under several API groups, are returned once.
+func helmReleaseObjects(items []unstructured.Unstructured, release, namespace string) ([]interface{}, error) {
+	seen := make(map[types.UID]bool, len(items))
+	objects := make([]map[string]interface{}, 0)
+	for _, item := range items {
+		annotations := item.GetAnnotations()
+		if annotations[helmReleaseNameAnnotation] != release || annotations[helmReleaseNamespaceAnnotation] != namespace {
+			continue
+		}
+		if uid := item.GetUID(); uid != "" {
+			if seen[uid] {
+				continue
+			}
+			seen[uid] = true
+		}
+
+		object := metav1.ObjectMeta{
+			Name:      item.GetName(),
+			Namespace: item.GetNamespace(),
+		}
+		id := object.Name
+		if object.Namespace != "" {
+			id = buildId(object)
+		}
+		manifest, err := json.Marshal(helmReleaseObjectManifest(item).Object)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, map[string]interface{}{
+			"api_version":        item.GetAPIVersion(),
+			"kind":               item.GetKind(),
+			"namespace":          object.Namespace,
+			"name":               object.Name,
+			"import_id":          id,
+			"manifest_import_id": buildIdWithVersionKind(object, item.GetAPIVersion(), item.GetKind()),
+			"manifest":           string(manifest),
+		})
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i]["manifest_import_id"].(string) < objects[j]["manifest_import_id"].(string)
+	})
+
+	out := make([]interface{}, len(objects))
+	for i, o := range objects {
+		out[i] = o
+	}
+	return out, nil
+}
+
+// helmReleaseObjectManifest returns a copy of the object without its status and
+// the metadata populated by the API server. The data of secrets is removed, so
+// that it doesn't end up in the state.
+func helmReleaseObjectManifest(item unstructured.Unstructured) *unstructured.Unstructured {
+	u := item.DeepCopy()
+	unstructured.RemoveNestedField(u.Object, "status")
+	if gvk := u.GroupVersionKind(); gvk.Group == "" && gvk.Kind == "Secret" {
+		unstructured.RemoveNestedField(u.Object, "data")
+		unstructured.RemoveNestedField(u.Object, "stringData")
+	}
+	for _, f := range []string{"uid", "creationTimestamp", "resourceVersion", "generation", "selfLink", "managedFields"} {
+		unstructured.RemoveNestedField(u.Object, "metadata", f)
+	}
+	unstructured.RemoveNestedField(u.Object, "metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration")
+	if len(u.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(u.Object, "metadata", "annotations")
+	}
+	return u
+}
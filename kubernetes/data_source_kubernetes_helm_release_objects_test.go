@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestHelmReleaseObjects(t *testing.T) {
+	object := func(apiVersion, kind, namespace, name, release, releaseNamespace string) unstructured.Unstructured {
+		u := unstructured.Unstructured{Object: map[string]interface{}{
+			"status": map[string]interface{}{"replicas": int64(1)},
+		}}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(kind)
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		u.SetUID(types.UID(kind + "/" + namespace + "/" + name))
+		u.SetResourceVersion("1")
+		u.SetLabels(map[string]string{"app.kubernetes.io/managed-by": "Helm"})
+		u.SetAnnotations(map[string]string{
+			helmReleaseNameAnnotation:      release,
+			helmReleaseNamespaceAnnotation: releaseNamespace,
+		})
+		return u
+	}
+
+	deployment := object("apps/v1", "Deployment", "web", "web", "web", "web")
+	objects, err := helmReleaseObjects([]unstructured.Unstructured{
+		deployment,
+		deployment,
+		object("v1", "Service", "web", "web", "web", "web"),
+		object("rbac.authorization.k8s.io/v1", "ClusterRole", "", "web", "web", "web"),
+		object("v1", "Service", "web", "other", "other", "web"),
+		object("v1", "Service", "other", "web", "web", "other"),
+	}, "web", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, o := range objects {
+		ids = append(ids, o.(map[string]interface{})["manifest_import_id"].(string))
+	}
+	if diff := cmp.Diff([]string{
+		"apiVersion=apps/v1,kind=Deployment,name=web,namespace=web",
+		"apiVersion=rbac.authorization.k8s.io/v1,kind=ClusterRole,name=web",
+		"apiVersion=v1,kind=Service,name=web,namespace=web",
+	}, ids); diff != "" {
+		t.Fatalf("Unexpected objects: mismatch (-want +got):\n%s", diff)
+	}
+
+	clusterRole := objects[1].(map[string]interface{})
+	if clusterRole["import_id"] != "web" || clusterRole["namespace"] != "" {
+		t.Fatalf("Unexpected attributes of a cluster-scoped object: %v", clusterRole)
+	}
+	expected := `{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"ClusterRole","metadata":{"annotations":{"meta.helm.sh/release-name":"web","meta.helm.sh/release-namespace":"web"},"labels":{"app.kubernetes.io/managed-by":"Helm"},"name":"web"}}`
+	if diff := cmp.Diff(expected, clusterRole["manifest"]); diff != "" {
+		t.Fatalf("Unexpected manifest: mismatch (-want +got):\n%s", diff)
+	}
+
+	secret := object("v1", "Secret", "web", "web", "web", "web")
+	secret.Object["data"] = map[string]interface{}{"password": "c2VjcmV0"}
+	secret.Object["stringData"] = map[string]interface{}{"token": "secret"}
+	secret.Object["type"] = "Opaque"
+	objects, err = helmReleaseObjects([]unstructured.Unstructured{secret}, "web", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `{"apiVersion":"v1","kind":"Secret","metadata":{"annotations":{"meta.helm.sh/release-name":"web","meta.helm.sh/release-namespace":"web"},"labels":{"app.kubernetes.io/managed-by":"Helm"},"name":"web","namespace":"web"},"type":"Opaque"}`
+	if diff := cmp.Diff(expected, objects[0].(map[string]interface{})["manifest"]); diff != "" {
+		t.Fatalf("Unexpected manifest of a secret: mismatch (-want +got):\n%s", diff)
+	}
+}
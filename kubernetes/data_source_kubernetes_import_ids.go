@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+func dataSourceKubernetesImportIDs() *schema.Resource {
+	return &schema.Resource{
+		Description: "This data source lists the objects of a kind matching a label selector and returns their import IDs, which can be used with `for_each` in `import` blocks to bring all the objects of an existing application under management at once.",
+		ReadContext: dataSourceKubernetesImportIDsRead,
+		Schema: map[string]*schema.Schema{
+			"api_version": {
+				Type:        schema.TypeString,
+				Description: "The apiVersion of the objects to list, e.g. `apps/v1`.",
+				Required:    true,
+			},
+			"kind": {
+				Type:        schema.TypeString,
+				Description: "The kind of the objects to list, e.g. `Deployment`.",
+				Required:    true,
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "The namespace to list the objects in. Leave empty to list the objects of all namespaces. Ignored for cluster-scoped kinds.",
+				Optional:    true,
+			},
+			"label_selector": {
+				Type:        schema.TypeString,
+				Description: "A label selector the objects must match, e.g. `app.kubernetes.io/instance=my-app`. Leave empty to list all the objects.",
+				Optional:    true,
+			},
+			"page_size": listPageSizeSchema(),
+			"max_items": listMaxItemsSchema("objects"),
+			"ids": {
+				Type:        schema.TypeList,
+				Description: "The import IDs of the matching objects for the structured resources of the kind, `<namespace>/<name>` for namespaced objects and `<name>` for cluster-scoped ones, in sorted order.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"manifest_ids": {
+				Type:        schema.TypeMap,
+				Description: "The import IDs of the matching objects for the `kubernetes_manifest` resource, keyed by the IDs in `ids`.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesImportIDsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn, err := m.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	dc, err := m.(KubeClientsets).DiscoveryClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	apiVersion := d.Get("api_version").(string)
+	kind := d.Get("kind").(string)
+	namespace := d.Get("namespace").(string)
+	selector := d.Get("label_selector").(string)
+	if _, err := labels.Parse(selector); err != nil {
+		return diag.Errorf("Invalid label selector %q: %s", selector, err)
+	}
+
+	gv, err := k8sschema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	agr, err := restmapper.GetAPIGroupResources(dc)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	restMapper := restmapper.NewDiscoveryRESTMapper(agr)
+	mapping, err := restMapper.RESTMapping(gv.WithKind(kind).GroupKind(), gv.Version)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var r dynamic.ResourceInterface = conn.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		r = conn.Resource(mapping.Resource).Namespace(namespace)
+	} else {
+		namespace = ""
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Listing %s matching %q in namespace %q", mapping.Resource.Resource, selector, namespace))
+	paging := expandListPaging(d)
+	items, truncated, err := listAll(ctx, metav1.ListOptions{LabelSelector: selector}, paging, r.List, func(l *unstructured.UnstructuredList) []unstructured.Unstructured {
+		return l.Items
+	})
+	if err != nil {
+		return diag.Errorf("Unable to list %s: %s", mapping.Resource.Resource, err)
+	}
+	var diags diag.Diagnostics
+	if truncated {
+		diags = listTruncatedDiagnostics(mapping.Resource.Resource, paging.maxItems)
+	}
+	ids, manifestIDs := importIDs(items, apiVersion, kind)
+	tflog.Info(ctx, fmt.Sprintf("Found %d %s to import", len(ids), mapping.Resource.Resource))
+
+	d.SetId(fmt.Sprintf("apiVersion=%s,kind=%s,namespace=%s,labelSelector=%s", apiVersion, kind, namespace, selector))
+	err = d.Set("ids", ids)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("manifest_ids", manifestIDs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// importIDs returns the sorted import IDs of the given objects for the structured
+// resources, and their import IDs for kubernetes_manifest keyed by the former.
+func importIDs(items []unstructured.Unstructured, apiVersion, kind string) ([]string, map[string]string) {
+	ids := make([]string, 0, len(items))
+	manifestIDs := make(map[string]string, len(items))
+	for _, item := range items {
+		object := metav1.ObjectMeta{
+			Name:      item.GetName(),
+			Namespace: item.GetNamespace(),
+		}
+		id := object.Name
+		if object.Namespace != "" {
+			id = buildId(object)
+		}
+		ids = append(ids, id)
+		manifestIDs[id] = buildIdWithVersionKind(object, apiVersion, kind)
+	}
+	sort.Strings(ids)
+	return ids, manifestIDs
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestImportIDs(t *testing.T) {
+	object := func(namespace, name string) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		return u
+	}
+
+	ids, manifestIDs := importIDs([]unstructured.Unstructured{
+		object("b", "web"),
+		object("a", "web"),
+		object("a", "api"),
+	}, "apps/v1", "Deployment")
+	if diff := cmp.Diff([]string{"a/api", "a/web", "b/web"}, ids); diff != "" {
+		t.Fatalf("Unexpected IDs: mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{
+		"a/api": "apiVersion=apps/v1,kind=Deployment,name=api,namespace=a",
+		"a/web": "apiVersion=apps/v1,kind=Deployment,name=web,namespace=a",
+		"b/web": "apiVersion=apps/v1,kind=Deployment,name=web,namespace=b",
+	}, manifestIDs); diff != "" {
+		t.Fatalf("Unexpected manifest IDs: mismatch (-want +got):\n%s", diff)
+	}
+
+	ids, manifestIDs = importIDs([]unstructured.Unstructured{object("", "admin")}, "rbac.authorization.k8s.io/v1", "ClusterRole")
+	if diff := cmp.Diff([]string{"admin"}, ids); diff != "" {
+		t.Fatalf("Unexpected IDs: mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{
+		"admin": "apiVersion=rbac.authorization.k8s.io/v1,kind=ClusterRole,name=admin",
+	}, manifestIDs); diff != "" {
+		t.Fatalf("Unexpected manifest IDs: mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAccKubernetesDataSourceImportIDs_basic(t *testing.T) {
+	dataSourceName := "data.kubernetes_import_ids.test"
+	namespace := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceImportIDsConfig_basic(namespace),
+			},
+			{
+				Config: testAccKubernetesDataSourceImportIDsConfig_basic(namespace) +
+					testAccKubernetesDataSourceImportIDsConfig_read(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "ids.0", namespace+"/one"),
+					resource.TestCheckResourceAttr(dataSourceName, "ids.1", namespace+"/two"),
+					resource.TestCheckResourceAttr(dataSourceName, "manifest_ids.%", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "manifest_ids."+namespace+"/one",
+						fmt.Sprintf("apiVersion=v1,kind=ConfigMap,name=one,namespace=%s", namespace)),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceImportIDsConfig_basic(namespace string) string {
+	return fmt.Sprintf(`resource "kubernetes_namespace_v1" "test" {
+  metadata {
+    name = %q
+  }
+}
+
+resource "kubernetes_config_map_v1" "test" {
+  for_each = toset(["one", "two", "three"])
+  metadata {
+    name      = each.key
+    namespace = kubernetes_namespace_v1.test.metadata.0.name
+    labels = {
+      app = each.key == "three" ? "other" : "test"
+    }
+  }
+}
+`, namespace)
+}
+
+func testAccKubernetesDataSourceImportIDsConfig_read() string {
+	return `data "kubernetes_import_ids" "test" {
+  api_version    = "v1"
+  kind           = "ConfigMap"
+  namespace      = kubernetes_namespace_v1.test.metadata.0.name
+  label_selector = "app=test"
+}
+`
+}
@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -26,7 +27,7 @@ func dataSourceKubernetesIngressV1() *schema.Resource {
 	return &schema.Resource{
 		Description: "Ingress is a collection of rules that allow inbound connections to reach the endpoints defined by a backend. An Ingress can be configured to give services externally-reachable urls, load balance traffic, terminate SSL, offer name based virtual hosting etc. This data source allows you to pull data about such ingress.",
 		ReadContext: dataSourceKubernetesIngressV1Read,
-		Schema: map[string]*schema.Schema{
+		Schema: withExistenceFields(map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("ingress", false),
 			"spec": {
 				Type:        schema.TypeList,
@@ -144,7 +145,7 @@ func dataSourceKubernetesIngressV1() *schema.Resource {
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
@@ -161,16 +162,20 @@ func dataSourceKubernetesIngressV1Read(ctx context.Context, d *schema.ResourceDa
 	}
 	d.SetId(buildId(om))
 
-	log.Printf("[INFO] Reading ingress %s", metadata.Name)
+	tflog.Info(ctx, fmt.Sprintf("Reading ingress %s", metadata.Name))
 	ing, err := conn.NetworkingV1().Ingresses(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil
+			return dataSourceObjectNotFound(ctx, d, "Ingress", d.Id())
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Received ingress: %#v", ing))
+	err = d.Set("exists", true)
+	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received ingress: %#v", ing)
 
 	err = d.Set("metadata", flattenMetadataFields(ing.ObjectMeta))
 	if err != nil {
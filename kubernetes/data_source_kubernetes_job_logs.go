@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+func dataSourceKubernetesJobLogs() *schema.Resource {
+	return &schema.Resource{
+		Description: "This data source reads the logs of the containers of the pods of a job, e.g. to capture the output of a migration or seeding job in the outputs of Terraform. Only the pods of the job which still exist are read.",
+		ReadContext: dataSourceKubernetesJobLogsRead,
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "The namespace of the job. Defaults to `default`.",
+				Optional:    true,
+				Default:     "default",
+			},
+			"job_name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the job to read the logs of.",
+				Required:     true,
+				ValidateFunc: validateName,
+			},
+			"container": {
+				Type:        schema.TypeString,
+				Description: "The name of the container to read the logs of. Defaults to all the init containers and containers of the pods.",
+				Optional:    true,
+			},
+			"tail_lines": {
+				Type:         schema.TypeInt,
+				Description:  "The number of lines from the end of the logs of each container to read. Defaults to all of them.",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"limit_bytes": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of bytes of the logs of each container to read. The logs are cut at this size, which may be in the middle of a line. Defaults to no limit.",
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"logs": {
+				Type:        schema.TypeList,
+				Description: "The logs of the containers, from the oldest pod to the most recent one, with the init containers of a pod before its containers.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pod_name": {
+							Type:        schema.TypeString,
+							Description: "The name of the pod.",
+							Computed:    true,
+						},
+						"container": {
+							Type:        schema.TypeString,
+							Description: "The name of the container.",
+							Computed:    true,
+						},
+						"content": {
+							Type:        schema.TypeString,
+							Description: "The logs of the container.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesJobLogsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace := d.Get("namespace").(string)
+	name := d.Get("job_name").(string)
+	job, err := conn.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to get job %s/%s: %s", namespace, name, err)
+	}
+
+	opts := &corev1.PodLogOptions{Container: d.Get("container").(string)}
+	if v, ok := d.GetOk("tail_lines"); ok {
+		opts.TailLines = ptr.To(int64(v.(int)))
+	}
+	if v, ok := d.GetOk("limit_bytes"); ok {
+		opts.LimitBytes = ptr.To(int64(v.(int)))
+	}
+
+	logs, diags := jobV1ContainerLogs(ctx, conn, job, opts)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(buildId(job.ObjectMeta))
+	err = d.Set("logs", logs)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
+// jobV1ContainerLogs reads the logs of the containers of the pods of a job,
+// or of the container set in opts, from the oldest pod to the most recent
+// one. Containers whose logs can't be read, e.g. as they haven't started,
+// are reported as warnings.
+func jobV1ContainerLogs(ctx context.Context, conn kubernetes.Interface, job *batchv1.Job, opts *corev1.PodLogOptions) ([]interface{}, diag.Diagnostics) {
+	logs := []interface{}{}
+	pods, err := listJobV1Pods(ctx, conn, job)
+	if err != nil {
+		return nil, diag.Errorf("Failed to list the pods of job %s: %s", buildId(job.ObjectMeta), err)
+	}
+
+	var diags diag.Diagnostics
+	for _, pod := range pods {
+		for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			if opts.Container != "" && c.Name != opts.Container {
+				continue
+			}
+			containerOpts := *opts
+			containerOpts.Container = c.Name
+			content, err := conn.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &containerOpts).DoRaw(ctx)
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Unable to get the logs of container %q of pod %s/%s", c.Name, pod.Namespace, pod.Name),
+					Detail:   err.Error(),
+				})
+				continue
+			}
+			logs = append(logs, map[string]interface{}{
+				"pod_name":  pod.Name,
+				"container": c.Name,
+				"content":   string(content),
+			})
+		}
+	}
+	if opts.Container != "" && len(pods) > 0 && len(logs) == 0 && len(diags) == 0 {
+		return nil, diag.Errorf("The pods of job %s have no container %q", buildId(job.ObjectMeta), opts.Container)
+	}
+	return logs, diags
+}
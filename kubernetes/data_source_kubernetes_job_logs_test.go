@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestJobV1ContainerLogs(t *testing.T) {
+	now := time.Now()
+	pod := func(name string, age time.Duration) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "shop",
+				Labels:            map[string]string{"job-name": "seed"},
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "wait-for-db"}},
+				Containers:     []corev1.Container{{Name: "seed"}},
+			},
+		}
+	}
+	conn := fake.NewSimpleClientset(pod("seed-b", time.Minute), pod("seed-a", 2*time.Minute))
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "seed", Namespace: "shop"},
+		Spec: batchv1.JobSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"job-name": "seed"}},
+		},
+	}
+
+	logs, diags := jobV1ContainerLogs(context.Background(), conn, job, &corev1.PodLogOptions{})
+	if diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+	var got []string
+	for _, l := range logs {
+		m := l.(map[string]interface{})
+		got = append(got, fmt.Sprintf("%s/%s", m["pod_name"], m["container"]))
+	}
+	if fmt.Sprint(got) != "[seed-a/wait-for-db seed-a/seed seed-b/wait-for-db seed-b/seed]" {
+		t.Fatalf("Expected the containers of the oldest pod first, got %v", got)
+	}
+
+	logs, diags = jobV1ContainerLogs(context.Background(), conn, job, &corev1.PodLogOptions{Container: "seed"})
+	if diags.HasError() || len(logs) != 2 {
+		t.Fatalf("Expected the logs of the 2 seed containers, got %v: %v", logs, diags)
+	}
+
+	_, diags = jobV1ContainerLogs(context.Background(), conn, job, &corev1.PodLogOptions{Container: "migrate"})
+	if !diags.HasError() {
+		t.Fatal("Expected an error for a container the pods don't have")
+	}
+
+	job.Spec.Selector = nil
+	logs, diags = jobV1ContainerLogs(context.Background(), conn, job, &corev1.PodLogOptions{})
+	if diags.HasError() || len(logs) != 0 {
+		t.Fatalf("Expected no logs for a job without selector, got %v: %v", logs, diags)
+	}
+}
+
+func TestAccKubernetesDataSourceJobLogs_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	dataSourceName := "data.kubernetes_job_logs.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceJobLogsConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "logs.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "logs.0.container", "seed"),
+					resource.TestCheckResourceAttr(dataSourceName, "logs.0.content", "three\n"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceJobLogsConfig_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_job_v1" "test" {
+  metadata {
+    name = %[1]q
+  }
+  spec {
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "seed"
+          image   = %[2]q
+          command = ["sh", "-c", "echo one; echo two; echo three"]
+        }
+        restart_policy = "Never"
+      }
+    }
+  }
+  wait_for_completion = true
+}
+
+data "kubernetes_job_logs" "test" {
+  job_name   = kubernetes_job_v1.test.metadata[0].name
+  tail_lines = 1
+}
+`, name, busyboxImage)
+}
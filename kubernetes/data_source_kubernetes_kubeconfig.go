@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func dataSourceKubernetesKubeconfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "This data source renders a kubeconfig document for the cluster the provider is connected to. The credentials are either the ones used by the provider itself, or a token issued for a service account using the TokenRequest API, which makes it possible to hand scoped credentials to CI systems or other tooling.",
+		ReadContext: dataSourceKubernetesKubeconfigRead,
+		Schema: map[string]*schema.Schema{
+			"cluster_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the cluster entry in the rendered kubeconfig.",
+				Optional:    true,
+				Default:     "kubernetes",
+			},
+			"context_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the context entry in the rendered kubeconfig. Also used as the name of the user entry.",
+				Optional:    true,
+				Default:     "terraform",
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Default namespace of the rendered context. Defaults to the namespace of the service account when `service_account` is set.",
+				Optional:    true,
+			},
+			"server": {
+				Type:        schema.TypeString,
+				Description: "Address of the API server written to the kubeconfig. Defaults to the address used by the provider.",
+				Optional:    true,
+			},
+			"service_account": {
+				Type:        schema.TypeList,
+				Description: "Service account to issue the token embedded in the kubeconfig for. When omitted, the credentials of the provider are used.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Description:  "Name of the service account.",
+							Required:     true,
+							ValidateFunc: validateName,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the service account.",
+							Optional:    true,
+							Default:     "default",
+						},
+						"audiences": {
+							Type:        schema.TypeList,
+							Description: "Intended audiences of the token. Defaults to the audience of the API server.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"expiration_seconds": {
+							Type:         schema.TypeInt,
+							Description:  "Requested duration of validity of the token. The API server may return a token with a different validity. The expiration can't be less than 10 minutes.",
+							Optional:     true,
+							Default:      3600,
+							ValidateFunc: validateIntGreaterThan(600),
+						},
+					},
+				},
+			},
+			"kubeconfig": {
+				Type:        schema.TypeString,
+				Description: "The rendered kubeconfig document in YAML format.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expiration_timestamp": {
+				Type:        schema.TypeString,
+				Description: "The time at which the service account token expires, in RFC3339 format. Empty when the provider credentials are used.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesKubeconfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cfg := meta.(providerMetadata).config
+	if cfg == nil {
+		return diag.Errorf("Provider is not configured with a Kubernetes cluster")
+	}
+
+	opts := kubeconfigOptions{
+		ClusterName: d.Get("cluster_name").(string),
+		ContextName: d.Get("context_name").(string),
+		Namespace:   d.Get("namespace").(string),
+		Server:      d.Get("server").(string),
+	}
+
+	expiration := ""
+	if v, ok := d.Get("service_account").([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		sa := v[0].(map[string]interface{})
+		conn, err := meta.(KubeClientsets).MainClientset()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		name := sa["name"].(string)
+		namespace := sa["namespace"].(string)
+		request := authv1.TokenRequest{
+			Spec: authv1.TokenRequestSpec{
+				Audiences: expandStringSlice(sa["audiences"].([]interface{})),
+			},
+		}
+		if v := int64(sa["expiration_seconds"].(int)); v > 0 {
+			request.Spec.ExpirationSeconds = &v
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Requesting token for service account %s/%s", namespace, name))
+		out, err := conn.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, name, &request, metav1.CreateOptions{})
+		if err != nil {
+			return diag.Errorf(`Unable to request token for service account "%s/%s": %s`, namespace, name, err)
+		}
+		opts.Token = out.Status.Token
+		if opts.Namespace == "" {
+			opts.Namespace = namespace
+		}
+		expiration = out.Status.ExpirationTimestamp.Format(time.RFC3339)
+	}
+
+	kc, err := renderKubeconfig(cfg, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", opts.ClusterName, opts.ContextName))
+	err = d.Set("kubeconfig", string(kc))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("expiration_timestamp", expiration)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+type kubeconfigOptions struct {
+	ClusterName string
+	ContextName string
+	Namespace   string
+	Server      string
+	// Token replaces the credentials from the client configuration when set.
+	Token string
+}
+
+// renderKubeconfig serializes a client configuration into a kubeconfig document
+// with a single cluster, user and context.
+func renderKubeconfig(cfg *restclient.Config, opts kubeconfigOptions) ([]byte, error) {
+	cluster := clientcmdapi.NewCluster()
+	cluster.Server = cfg.Host
+	if opts.Server != "" {
+		cluster.Server = opts.Server
+	}
+	cluster.TLSServerName = cfg.TLSClientConfig.ServerName
+	cluster.InsecureSkipTLSVerify = cfg.TLSClientConfig.Insecure
+	cluster.CertificateAuthorityData = cfg.TLSClientConfig.CAData
+	if len(cluster.CertificateAuthorityData) == 0 && cfg.TLSClientConfig.CAFile != "" {
+		ca, err := os.ReadFile(cfg.TLSClientConfig.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read cluster CA certificate: %s", err)
+		}
+		cluster.CertificateAuthorityData = ca
+	}
+
+	user := clientcmdapi.NewAuthInfo()
+	if opts.Token != "" {
+		user.Token = opts.Token
+	} else {
+		user.Token = cfg.BearerToken
+		user.TokenFile = cfg.BearerTokenFile
+		user.Username = cfg.Username
+		user.Password = cfg.Password
+		user.ClientCertificateData = cfg.TLSClientConfig.CertData
+		user.ClientCertificate = cfg.TLSClientConfig.CertFile
+		user.ClientKeyData = cfg.TLSClientConfig.KeyData
+		user.ClientKey = cfg.TLSClientConfig.KeyFile
+		if cfg.ExecProvider != nil {
+			exec := *cfg.ExecProvider
+			// The interactive mode is only relevant for the provider itself.
+			exec.InteractiveMode = clientcmdapi.NeverExecInteractiveMode
+			user.Exec = &exec
+		}
+	}
+
+	kctx := clientcmdapi.NewContext()
+	kctx.Cluster = opts.ClusterName
+	kctx.AuthInfo = opts.ContextName
+	kctx.Namespace = opts.Namespace
+
+	kc := clientcmdapi.NewConfig()
+	kc.Clusters[opts.ClusterName] = cluster
+	kc.AuthInfos[opts.ContextName] = user
+	kc.Contexts[opts.ContextName] = kctx
+	kc.CurrentContext = opts.ContextName
+
+	return clientcmd.Write(*kc)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestRenderKubeconfig(t *testing.T) {
+	cfg := &restclient.Config{
+		Host:        "https://10.0.0.1:6443",
+		BearerToken: "provider-token",
+		TLSClientConfig: restclient.TLSClientConfig{
+			CAData: []byte("ca-data"),
+		},
+		ExecProvider: &clientcmdapi.ExecConfig{
+			APIVersion:      "client.authentication.k8s.io/v1beta1",
+			Command:         "aws",
+			Args:            []string{"eks", "get-token"},
+			InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+		},
+	}
+
+	cases := map[string]struct {
+		opts           kubeconfigOptions
+		expectedServer string
+		expectedToken  string
+		expectExec     bool
+	}{
+		"provider credentials": {
+			opts:           kubeconfigOptions{ClusterName: "kubernetes", ContextName: "terraform"},
+			expectedServer: "https://10.0.0.1:6443",
+			expectedToken:  "provider-token",
+			expectExec:     true,
+		},
+		"service account token": {
+			opts:           kubeconfigOptions{ClusterName: "prod", ContextName: "ci", Namespace: "ci", Server: "https://prod.example.com", Token: "sa-token"},
+			expectedServer: "https://prod.example.com",
+			expectedToken:  "sa-token",
+			expectExec:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			out, err := renderKubeconfig(cfg, tc.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			kc, err := clientcmd.Load(out)
+			if err != nil {
+				t.Fatalf("rendered kubeconfig cannot be loaded: %s", err)
+			}
+			if kc.CurrentContext != tc.opts.ContextName {
+				t.Errorf("expected current context %q, got %q", tc.opts.ContextName, kc.CurrentContext)
+			}
+			cluster, ok := kc.Clusters[tc.opts.ClusterName]
+			if !ok {
+				t.Fatalf("cluster %q not found", tc.opts.ClusterName)
+			}
+			if cluster.Server != tc.expectedServer {
+				t.Errorf("expected server %q, got %q", tc.expectedServer, cluster.Server)
+			}
+			if string(cluster.CertificateAuthorityData) != "ca-data" {
+				t.Errorf("unexpected CA data %q", cluster.CertificateAuthorityData)
+			}
+			user := kc.AuthInfos[tc.opts.ContextName]
+			if user.Token != tc.expectedToken {
+				t.Errorf("expected token %q, got %q", tc.expectedToken, user.Token)
+			}
+			if (user.Exec != nil) != tc.expectExec {
+				t.Errorf("expected exec stanza: %t, got %#v", tc.expectExec, user.Exec)
+			}
+			if kc.Contexts[tc.opts.ContextName].Namespace != tc.opts.Namespace {
+				t.Errorf("expected namespace %q, got %q", tc.opts.Namespace, kc.Contexts[tc.opts.ContextName].Namespace)
+			}
+		})
+	}
+}
+
+func TestAccKubernetesDataSourceKubeconfig_serviceAccount(t *testing.T) {
+	dataSourceName := "data.kubernetes_kubeconfig.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceKubeconfigConfig_serviceAccount(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", "kubernetes/ci"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "expiration_timestamp"),
+					func(s *terraform.State) error {
+						rs := s.RootModule().Resources[dataSourceName]
+						kc, err := clientcmd.Load([]byte(rs.Primary.Attributes["kubeconfig"]))
+						if err != nil {
+							return err
+						}
+						if kc.AuthInfos["ci"].Token == "" {
+							return fmt.Errorf("expected a service account token in the rendered kubeconfig")
+						}
+						if kc.Contexts["ci"].Namespace != "default" {
+							return fmt.Errorf("expected namespace %q, got %q", "default", kc.Contexts["ci"].Namespace)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceKubeconfigConfig_serviceAccount(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_service_account_v1" "test" {
+  metadata {
+    name = %q
+  }
+}
+
+data "kubernetes_kubeconfig" "test" {
+  context_name = "ci"
+  service_account {
+    name = kubernetes_service_account_v1.test.metadata.0.name
+  }
+}
+`, name)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func dataSourceKubernetesLimitRanges() *schema.Resource {
+	return &schema.Resource{
+		Description: "This data source lists the limit ranges of a namespace with the constraints they enforce, e.g. to check that the resources requested by a workload fit within the limits of a tenant namespace before applying it.",
+		ReadContext: dataSourceKubernetesLimitRangesRead,
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "The namespace of the limit ranges. Defaults to `default`.",
+				Optional:    true,
+				Default:     "default",
+			},
+			"label_selector": {
+				Type:        schema.TypeString,
+				Description: "A label selector the limit ranges must match. Leave empty to list all the limit ranges of the namespace.",
+				Optional:    true,
+			},
+			"page_size": listPageSizeSchema(),
+			"max_items": listMaxItemsSchema("limit ranges"),
+			"limits": {
+				Type:        schema.TypeList,
+				Description: "The limits enforced by the limit ranges of the namespace, sorted by the name of their limit range. A workload must satisfy all of them.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"limit_range": {
+							Type:        schema.TypeString,
+							Description: "The name of the limit range which enforces the limit.",
+							Computed:    true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Description: "The kind of object the limit applies to, `Container`, `Pod` or `PersistentVolumeClaim`.",
+							Computed:    true,
+						},
+						"default": {
+							Type:        schema.TypeMap,
+							Description: "The default limits by resource name, for the containers without limits.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"default_request": {
+							Type:        schema.TypeMap,
+							Description: "The default requests by resource name, for the containers without requests.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"max": {
+							Type:        schema.TypeMap,
+							Description: "The maximum usage by resource name.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"min": {
+							Type:        schema.TypeMap,
+							Description: "The minimum usage by resource name.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"max_limit_request_ratio": {
+							Type:        schema.TypeMap,
+							Description: "The maximum ratio of the limit to the request by resource name.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesLimitRangesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace := d.Get("namespace").(string)
+	selector := d.Get("label_selector").(string)
+	if _, err := labels.Parse(selector); err != nil {
+		return diag.Errorf("Invalid label selector %q: %s", selector, err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Listing limit ranges of namespace %s", namespace))
+	paging := expandListPaging(d)
+	items, truncated, err := listAll(ctx, metav1.ListOptions{LabelSelector: selector}, paging, conn.CoreV1().LimitRanges(namespace).List, func(l *corev1.LimitRangeList) []corev1.LimitRange {
+		return l.Items
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var diags diag.Diagnostics
+	if truncated {
+		diags = listTruncatedDiagnostics("limit ranges", paging.maxItems)
+	}
+
+	d.SetId(fmt.Sprintf("%s/labelSelector=%s", namespace, selector))
+	err = d.Set("limits", flattenLimitRangeLimits(items))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func flattenLimitRangeLimits(in []corev1.LimitRange) []interface{} {
+	sort.Slice(in, func(i, j int) bool { return in[i].Name < in[j].Name })
+	out := []interface{}{}
+	for _, lr := range in {
+		for _, l := range lr.Spec.Limits {
+			out = append(out, map[string]interface{}{
+				"limit_range":             lr.Name,
+				"type":                    string(l.Type),
+				"default":                 flattenResourceList(l.Default),
+				"default_request":         flattenResourceList(l.DefaultRequest),
+				"max":                     flattenResourceList(l.Max),
+				"min":                     flattenResourceList(l.Min),
+				"max_limit_request_ratio": flattenResourceList(l.MaxLimitRequestRatio),
+			})
+		}
+	}
+	return out
+}
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	corev1 "k8s.io/api/core/v1"
+	kuberesource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFlattenLimitRangeLimits(t *testing.T) {
+	got := flattenLimitRangeLimits([]corev1.LimitRange{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "storage"},
+			Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{
+				{Type: corev1.LimitTypePersistentVolumeClaim, Max: corev1.ResourceList{corev1.ResourceStorage: kuberesource.MustParse("10Gi")}},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute"},
+			Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{
+				{Type: corev1.LimitTypeContainer, Max: corev1.ResourceList{corev1.ResourceCPU: kuberesource.MustParse("2")}},
+				{Type: corev1.LimitTypePod, Max: corev1.ResourceList{corev1.ResourceMemory: kuberesource.MustParse("4Gi")}},
+			}},
+		},
+	})
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 limits, got %d", len(got))
+	}
+	first := got[0].(map[string]interface{})
+	if first["limit_range"] != "compute" || first["type"] != "Container" || first["max"].(map[string]string)["cpu"] != "2" {
+		t.Fatalf("Unexpected first limit: %v", first)
+	}
+	last := got[2].(map[string]interface{})
+	if last["limit_range"] != "storage" || last["max"].(map[string]string)["storage"] != "10Gi" {
+		t.Fatalf("Unexpected last limit: %v", last)
+	}
+}
+
+func TestAccKubernetesDataSourceLimitRanges_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	dataSourceName := "data.kubernetes_limit_ranges.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceLimitRangesConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "limits.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "limits.0.limit_range", name),
+					resource.TestCheckResourceAttr(dataSourceName, "limits.0.type", "Container"),
+					resource.TestCheckResourceAttr(dataSourceName, "limits.0.max.cpu", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "limits.0.default.memory", "256Mi"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceLimitRangesConfig_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_namespace_v1" "test" {
+  metadata {
+    name = %[1]q
+  }
+}
+
+resource "kubernetes_limit_range_v1" "test" {
+  metadata {
+    name      = %[1]q
+    namespace = kubernetes_namespace_v1.test.metadata[0].name
+  }
+  spec {
+    limit {
+      type = "Container"
+      default = {
+        memory = "256Mi"
+      }
+      max = {
+        cpu = "2"
+      }
+    }
+  }
+}
+
+data "kubernetes_limit_ranges" "test" {
+  namespace = kubernetes_limit_range_v1.test.metadata[0].namespace
+}
+`, name)
+}
@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -21,7 +22,7 @@ func dataSourceKubernetesMutatingWebhookConfigurationV1() *schema.Resource {
 	return &schema.Resource{
 		Description: "A Mutating Webhook Configuration configures a [mutating admission webhook](https://kubernetes.io/docs/reference/access-authn-authz/extensible-admission-controllers/#what-are-admission-webhooks). This data source allows you to pull data about a given mutating webhook configuration based on its name.",
 		ReadContext: dataSourceKubernetesMutatingWebhookConfigurationV1Read,
-		Schema: map[string]*schema.Schema{
+		Schema: withExistenceFields(map[string]*schema.Schema{
 			"metadata": metadataSchema("mutating webhook configuration", false),
 			"webhook": {
 				Type:        schema.TypeList,
@@ -110,7 +111,7 @@ func dataSourceKubernetesMutatingWebhookConfigurationV1() *schema.Resource {
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
@@ -123,23 +124,27 @@ func dataSourceKubernetesMutatingWebhookConfigurationV1Read(ctx context.Context,
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	d.SetId(metadata.Name)
 
-	log.Printf("[INFO] Reading mutating webhook configuration %s", metadata.Name)
+	tflog.Info(ctx, fmt.Sprintf("Reading mutating webhook configuration %s", metadata.Name))
 	cfg, err := conn.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, metadata.Name, metav1.GetOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil
+			return dataSourceObjectNotFound(ctx, d, "Mutating webhook configuration", d.Id())
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Received mutating webhook configuration: %#v", cfg))
+	err = d.Set("exists", true)
+	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received mutating webhook configuration: %#v", cfg)
 
 	err = d.Set("metadata", flattenMetadataFields(cfg.ObjectMeta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[DEBUG] Setting mutating webhook configuration to: %#v", cfg.Webhooks)
+	tflog.Debug(ctx, fmt.Sprintf("Setting mutating webhook configuration to: %#v", cfg.Webhooks))
 
 	err = d.Set("webhook", flattenMutatingWebhooks(cfg.Webhooks))
 	if err != nil {
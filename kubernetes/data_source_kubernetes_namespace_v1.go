@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	corev1 "k8s.io/api/core/v1"
@@ -19,7 +20,7 @@ func dataSourceKubernetesNamespaceV1() *schema.Resource {
 		Description: "This data source provides a mechanism to query attributes of any specific namespace within a Kubernetes cluster. In Kubernetes, namespaces provide a scope for names and are intended as a way to divide cluster resources between multiple users.",
 		ReadContext: dataSourceKubernetesNamespaceV1Read,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withExistenceFields(map[string]*schema.Schema{
 			"metadata": metadataSchema("namespace", false),
 			"spec": {
 				Type:        schema.TypeList,
@@ -38,7 +39,7 @@ func dataSourceKubernetesNamespaceV1() *schema.Resource {
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
@@ -54,12 +55,16 @@ func dataSourceKubernetesNamespaceV1Read(ctx context.Context, d *schema.Resource
 	namespace, err := conn.CoreV1().Namespaces().Get(ctx, metadata.Name, metav1.GetOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil
+			return dataSourceObjectNotFound(ctx, d, "Namespace", d.Id())
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Received namespace: %#v", namespace))
+	err = d.Set("exists", true)
+	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received namespace: %#v", namespace)
 
 	err = d.Set("metadata", flattenMetadataFields(namespace.ObjectMeta))
 	if err != nil {
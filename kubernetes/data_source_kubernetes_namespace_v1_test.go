@@ -5,6 +5,7 @@ package kubernetes
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -27,6 +28,7 @@ func TestAccKubernetesDataSourceNamespaceV1_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(dataSourceName, "spec.#", "1"),
 					resource.TestCheckResourceAttr(dataSourceName, "spec.0.finalizers.#", "1"),
 					resource.TestCheckResourceAttr(dataSourceName, "spec.0.finalizers.0", "kubernetes"),
+					resource.TestCheckResourceAttr(dataSourceName, "exists", "true"),
 				),
 			},
 		},
@@ -45,12 +47,27 @@ func TestAccKubernetesDataSourceNamespaceV1_not_found(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr(dataSourceName, "metadata.0.name", name),
 					resource.TestCheckResourceAttr(dataSourceName, "spec.#", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "exists", "false"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccKubernetesDataSourceNamespaceV1_not_found_not_optional(t *testing.T) {
+	name := fmt.Sprintf("ceci-n.est-pas-une-namespace-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesDataSourceNamespaceV1_nonexistentNotOptional(name),
+				ExpectError: regexp.MustCompile(`Namespace ".+" not found`),
+			},
+		},
+	})
+}
+
 func testAccKubernetesDataSourceNamespaceV1_basic() string {
 	return `data "kubernetes_namespace_v1" "test" {
   metadata {
@@ -68,3 +85,13 @@ func testAccKubernetesDataSourceNamespaceV1_nonexistent(name string) string {
 }
 `, name)
 }
+
+func testAccKubernetesDataSourceNamespaceV1_nonexistentNotOptional(name string) string {
+	return fmt.Sprintf(`data "kubernetes_namespace_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  optional = false
+}
+`, name)
+}
@@ -7,11 +7,12 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -22,6 +23,8 @@ func dataSourceKubernetesNodes() *schema.Resource {
 		Description: "This data source provides a mechanism for listing the names of nodes in a kubernetes cluster.By default, all nodes in the cluster are returned, but queries by node label are also supported. It can be used to check for the existence of a specific node or to lookup a node to apply a taint with the `kubernetes_node_taint` resource.",
 		ReadContext: dataSourceKubernetesNodesRead,
 		Schema: map[string]*schema.Schema{
+			"page_size": listPageSizeSchema(),
+			"max_items": listMaxItemsSchema("nodes"),
 			"metadata": {
 				Type:        schema.TypeList,
 				Description: "Metadata fields to narrow node selection.",
@@ -84,21 +87,28 @@ func dataSourceKubernetesNodesRead(ctx context.Context, d *schema.ResourceData,
 			return diag.FromErr(err)
 		}
 		labelSelector := labels.SelectorFromSet(labelMap).String()
-		log.Printf("[DEBUG] using labelSelector: %s", labelSelector)
+		tflog.Debug(ctx, fmt.Sprintf("using labelSelector: %s", labelSelector))
 		listOptions.LabelSelector = labelSelector
 	}
 
-	log.Printf("[INFO] Listing nodes")
-	nodesRaw, err := conn.CoreV1().Nodes().List(ctx, listOptions)
+	tflog.Info(ctx, "Listing nodes")
+	paging := expandListPaging(d)
+	items, truncated, err := listAll(ctx, listOptions, paging, conn.CoreV1().Nodes().List, func(l *corev1.NodeList) []corev1.Node {
+		return l.Items
+	})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil
 		}
 		return diag.FromErr(err)
 	}
-	nodes := make([]interface{}, len(nodesRaw.Items))
-	for i, v := range nodesRaw.Items {
-		log.Printf("[INFO] Received node: %s", v.Name)
+	var diags diag.Diagnostics
+	if truncated {
+		diags = listTruncatedDiagnostics("nodes", paging.maxItems)
+	}
+	nodes := make([]interface{}, len(items))
+	for i, v := range items {
+		tflog.Info(ctx, fmt.Sprintf("Received node: %s", v.Name))
 		nodes[i] = map[string]interface{}{
 			"metadata": flattenMetadataFields(v.ObjectMeta),
 			"spec":     flattenNodeSpec(v.Spec),
@@ -117,5 +127,5 @@ func dataSourceKubernetesNodesRead(ctx context.Context, d *schema.ResourceData,
 	id := fmt.Sprintf("%x", idsum.Sum(nil))
 	d.SetId(id)
 
-	return nil
+	return diags
 }
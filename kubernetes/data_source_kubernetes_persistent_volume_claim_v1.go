@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -18,7 +19,7 @@ func dataSourceKubernetesPersistentVolumeClaimV1() *schema.Resource {
 		Description: "A PersistentVolumeClaim (PVC) is a request for storage by a user. This data source retrieves information about the specified PVC.",
 		ReadContext: dataSourceKubernetesPersistentVolumeClaimV1Read,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withExistenceFields(map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("persistent volume claim", true),
 			"spec": {
 				Type:        schema.TypeList,
@@ -86,7 +87,7 @@ func dataSourceKubernetesPersistentVolumeClaimV1() *schema.Resource {
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
@@ -104,16 +105,20 @@ func dataSourceKubernetesPersistentVolumeClaimV1Read(ctx context.Context, d *sch
 	}
 	d.SetId(buildId(om))
 
-	log.Printf("[INFO] Reading persistent volume claim %s", metadata.Name)
+	tflog.Info(ctx, fmt.Sprintf("Reading persistent volume claim %s", metadata.Name))
 	claim, err := conn.CoreV1().PersistentVolumeClaims(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil
+			return dataSourceObjectNotFound(ctx, d, "Persistent volume claim", d.Id())
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Received persistent volume claim: %#v", claim))
+	err = d.Set("exists", true)
+	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received persistent volume claim: %#v", claim)
 
 	err = d.Set("metadata", flattenMetadataFields(claim.ObjectMeta))
 	if err != nil {
@@ -5,11 +5,13 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -18,7 +20,7 @@ func dataSourceKubernetesPersistentVolumeV1() *schema.Resource {
 		Description: "A PersistentVolume (PV) is a piece of networked storage in the cluster provisioned by an administrator. It is a resource in the cluster just like a node is a cluster resource. Persistent Volumes have a lifecycle independent of any individual pod that uses the PV. This data source retrieves information about the specified PV.",
 		ReadContext: dataSourceKubernetesPersistentVolumeV1Read,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withExistenceFields(map[string]*schema.Schema{
 			"metadata": metadataSchema("persistent volume", false),
 			"spec": {
 				Type:        schema.TypeList,
@@ -144,7 +146,7 @@ func dataSourceKubernetesPersistentVolumeV1() *schema.Resource {
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
@@ -157,13 +159,20 @@ func dataSourceKubernetesPersistentVolumeV1Read(ctx context.Context, d *schema.R
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	d.SetId(metadata.Name)
 
-	log.Printf("[INFO] Reading persistent volume %s", metadata.Name)
+	tflog.Info(ctx, fmt.Sprintf("Reading persistent volume %s", metadata.Name))
 	volume, err := conn.CoreV1().PersistentVolumes().Get(ctx, metadata.Name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		if apierrors.IsNotFound(err) {
+			return dataSourceObjectNotFound(ctx, d, "Persistent volume", d.Id())
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Received persistent volume: %#v", volume))
+	err = d.Set("exists", true)
+	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received persistent volume: %#v", volume)
 
 	err = d.Set("metadata", flattenMetadataFields(volume.ObjectMeta))
 	if err != nil {
@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -22,7 +23,7 @@ func dataSourceKubernetesPodV1() *schema.Resource {
 		Description: "A pod is a group of one or more containers, the shared storage for those containers, and options about how to run the containers. Pods are always co-located and co-scheduled, and run in a shared context. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod/.",
 		ReadContext: dataSourceKubernetesPodV1Read,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withExistenceFields(map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("pod", true),
 			"spec": {
 				Type:        schema.TypeList,
@@ -36,7 +37,7 @@ func dataSourceKubernetesPodV1() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-		},
+		}),
 	}
 }
 
@@ -54,16 +55,20 @@ func dataSourceKubernetesPodV1Read(ctx context.Context, d *schema.ResourceData,
 	}
 	d.SetId(buildId(om))
 
-	log.Printf("[INFO] Reading pod %s", metadata.Name)
+	tflog.Info(ctx, fmt.Sprintf("Reading pod %s", metadata.Name))
 	pod, err := conn.CoreV1().Pods(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil
+			return dataSourceObjectNotFound(ctx, d, "Pod", d.Id())
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Received pod: %#v", pod))
+	err = d.Set("exists", true)
+	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received pod: %#v", pod)
 
 	err = d.Set("metadata", flattenMetadataFields(pod.ObjectMeta))
 	if err != nil {
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func dataSourceKubernetesPriorityClasses() *schema.Resource {
+	return &schema.Resource{
+		Description: "This data source lists the priority classes of the cluster, e.g. to check that a priority class exists before referencing it with `priority_class_name` in a pod spec.",
+		ReadContext: dataSourceKubernetesPriorityClassesRead,
+		Schema: map[string]*schema.Schema{
+			"label_selector": {
+				Type:        schema.TypeString,
+				Description: "A label selector the priority classes must match. Leave empty to list all the priority classes.",
+				Optional:    true,
+			},
+			"page_size": listPageSizeSchema(),
+			"max_items": listMaxItemsSchema("priority classes"),
+			"priority_classes": {
+				Type:        schema.TypeList,
+				Description: "The priority classes of the cluster, sorted by name.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the priority class, as referenced by `priority_class_name`.",
+							Computed:    true,
+						},
+						"value": {
+							Type:        schema.TypeInt,
+							Description: "The priority of the pods of the priority class.",
+							Computed:    true,
+						},
+						"global_default": {
+							Type:        schema.TypeBool,
+							Description: "Whether the priority class is the default one of pods without a priority class name.",
+							Computed:    true,
+						},
+						"preemption_policy": {
+							Type:        schema.TypeString,
+							Description: "The policy for preempting pods with lower priority, `PreemptLowerPriority` or `Never`.",
+							Computed:    true,
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Description: "The description of the priority class.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesPriorityClassesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	selector := d.Get("label_selector").(string)
+	if _, err := labels.Parse(selector); err != nil {
+		return diag.Errorf("Invalid label selector %q: %s", selector, err)
+	}
+
+	tflog.Info(ctx, "Listing priority classes")
+	paging := expandListPaging(d)
+	items, truncated, err := listAll(ctx, metav1.ListOptions{LabelSelector: selector}, paging, conn.SchedulingV1().PriorityClasses().List, func(l *schedulingv1.PriorityClassList) []schedulingv1.PriorityClass {
+		return l.Items
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var diags diag.Diagnostics
+	if truncated {
+		diags = listTruncatedDiagnostics("priority classes", paging.maxItems)
+	}
+
+	d.SetId(fmt.Sprintf("labelSelector=%s", selector))
+	err = d.Set("priority_classes", flattenPriorityClasses(items))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func flattenPriorityClasses(in []schedulingv1.PriorityClass) []interface{} {
+	sort.Slice(in, func(i, j int) bool { return in[i].Name < in[j].Name })
+	out := make([]interface{}, len(in))
+	for i, pc := range in {
+		m := map[string]interface{}{
+			"name":           pc.Name,
+			"value":          pc.Value,
+			"global_default": pc.GlobalDefault,
+			"description":    pc.Description,
+		}
+		if pc.PreemptionPolicy != nil {
+			m["preemption_policy"] = string(*pc.PreemptionPolicy)
+		}
+		out[i] = m
+	}
+	return out
+}
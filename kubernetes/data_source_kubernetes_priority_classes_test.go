@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFlattenPriorityClasses(t *testing.T) {
+	never := corev1.PreemptNever
+	got := flattenPriorityClasses([]schedulingv1.PriorityClass{
+		{ObjectMeta: metav1.ObjectMeta{Name: "low"}, Value: 10, PreemptionPolicy: &never},
+		{ObjectMeta: metav1.ObjectMeta{Name: "high"}, Value: 1000, GlobalDefault: true},
+	})
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 priority classes, got %d", len(got))
+	}
+	high := got[0].(map[string]interface{})
+	low := got[1].(map[string]interface{})
+	if high["name"] != "high" || high["global_default"] != true {
+		t.Fatalf("Unexpected first priority class: %v", high)
+	}
+	if low["preemption_policy"] != "Never" {
+		t.Fatalf("Unexpected preemption policy: %v", low["preemption_policy"])
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// deploymentRevisionAnnotation is the annotation of the revision of a
+// deployment and of its replica sets, set by the deployment controller.
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+func dataSourceKubernetesReplicaSets() *schema.Resource {
+	return &schema.Resource{
+		Description: "This data source lists the replica sets of a namespace, or those behind a deployment, with their revision and replica counts, e.g. for rollback tooling and canary checks to reference the revisions a rollout created.",
+		ReadContext: dataSourceKubernetesReplicaSetsRead,
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "The namespace of the replica sets. Defaults to `default`.",
+				Optional:    true,
+				Default:     "default",
+			},
+			"deployment_name": {
+				Type:        schema.TypeString,
+				Description: "The name of a deployment to list the replica sets of, i.e. those it controls. Leave empty to list all the replica sets of the namespace.",
+				Optional:    true,
+			},
+			"label_selector": {
+				Type:        schema.TypeString,
+				Description: "A label selector the replica sets must match. Leave empty to list all the replica sets of the namespace or of the deployment.",
+				Optional:    true,
+			},
+			"page_size": listPageSizeSchema(),
+			"max_items": listMaxItemsSchema("replica sets"),
+			"current_revision": {
+				Type:        schema.TypeString,
+				Description: "The revision of the deployment, i.e. the revision of its current replica set. Empty unless `deployment_name` is set.",
+				Computed:    true,
+			},
+			"replica_sets": {
+				Type:        schema.TypeList,
+				Description: "The replica sets, sorted from the latest revision to the oldest one.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the replica set.",
+							Computed:    true,
+						},
+						"revision": {
+							Type:        schema.TypeString,
+							Description: "The revision of the deployment the replica set was created for. Empty for replica sets which aren't controlled by a deployment.",
+							Computed:    true,
+						},
+						"pod_template_hash": {
+							Type:        schema.TypeString,
+							Description: "The `pod-template-hash` label of the replica set, which its pods carry too.",
+							Computed:    true,
+						},
+						"deployment_name": {
+							Type:        schema.TypeString,
+							Description: "The name of the deployment controlling the replica set, if any.",
+							Computed:    true,
+						},
+						"images": {
+							Type:        schema.TypeList,
+							Description: "The images of the containers of the pod template of the replica set.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"replicas": {
+							Type:        schema.TypeInt,
+							Description: "The desired number of replicas.",
+							Computed:    true,
+						},
+						"current_replicas": {
+							Type:        schema.TypeInt,
+							Description: "The number of replicas created.",
+							Computed:    true,
+						},
+						"ready_replicas": {
+							Type:        schema.TypeInt,
+							Description: "The number of ready replicas.",
+							Computed:    true,
+						},
+						"available_replicas": {
+							Type:        schema.TypeInt,
+							Description: "The number of replicas ready for at least the minimum ready seconds of the replica set.",
+							Computed:    true,
+						},
+						"creation_timestamp": {
+							Type:        schema.TypeString,
+							Description: "The time the replica set was created, in RFC 3339 format.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesReplicaSetsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace := d.Get("namespace").(string)
+	deploymentName := d.Get("deployment_name").(string)
+	selector, err := labels.Parse(d.Get("label_selector").(string))
+	if err != nil {
+		return diag.Errorf("Invalid label selector %q: %s", d.Get("label_selector"), err)
+	}
+
+	var deployment *appsv1.Deployment
+	if deploymentName != "" {
+		deployment, err = conn.AppsV1().Deployments(namespace).Get(ctx, deploymentName, metav1.GetOptions{})
+		if err != nil {
+			return diag.Errorf("Failed to get deployment %s/%s: %s", namespace, deploymentName, err)
+		}
+		// Narrow the list down to the replica sets the deployment may
+		// control, which are then checked to be owned by it.
+		deploymentSelector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		requirements, _ := deploymentSelector.Requirements()
+		selector = selector.Add(requirements...)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Listing replica sets of namespace %s matching %q", namespace, selector))
+	paging := expandListPaging(d)
+	items, truncated, err := listAll(ctx, metav1.ListOptions{LabelSelector: selector.String()}, paging, conn.AppsV1().ReplicaSets(namespace).List, func(l *appsv1.ReplicaSetList) []appsv1.ReplicaSet {
+		return l.Items
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var diags diag.Diagnostics
+	if truncated {
+		diags = listTruncatedDiagnostics("replica sets", paging.maxItems)
+	}
+
+	currentRevision := ""
+	if deployment != nil {
+		currentRevision = deployment.Annotations[deploymentRevisionAnnotation]
+		items = replicaSetsControlledBy(items, deployment)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/labelSelector=%s", namespace, deploymentName, d.Get("label_selector")))
+	err = d.Set("current_revision", currentRevision)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("replica_sets", flattenReplicaSets(items))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func replicaSetsControlledBy(in []appsv1.ReplicaSet, deployment *appsv1.Deployment) []appsv1.ReplicaSet {
+	var out []appsv1.ReplicaSet
+	for _, rs := range in {
+		if owner := metav1.GetControllerOf(&rs); owner != nil && owner.UID == deployment.UID {
+			out = append(out, rs)
+		}
+	}
+	return out
+}
+
+func flattenReplicaSets(in []appsv1.ReplicaSet) []interface{} {
+	revision := func(rs appsv1.ReplicaSet) int64 {
+		r, _ := strconv.ParseInt(rs.Annotations[deploymentRevisionAnnotation], 10, 64)
+		return r
+	}
+	sort.SliceStable(in, func(i, j int) bool {
+		if ri, rj := revision(in[i]), revision(in[j]); ri != rj {
+			return ri > rj
+		}
+		return in[i].Name < in[j].Name
+	})
+	out := make([]interface{}, len(in))
+	for i, rs := range in {
+		deploymentName := ""
+		if owner := metav1.GetControllerOf(&rs); owner != nil && owner.Kind == "Deployment" {
+			deploymentName = owner.Name
+		}
+		var images []interface{}
+		for _, c := range rs.Spec.Template.Spec.Containers {
+			images = append(images, c.Image)
+		}
+		replicas := 0
+		if rs.Spec.Replicas != nil {
+			replicas = int(*rs.Spec.Replicas)
+		}
+		out[i] = map[string]interface{}{
+			"name":               rs.Name,
+			"revision":           rs.Annotations[deploymentRevisionAnnotation],
+			"pod_template_hash":  rs.Labels[appsv1.DefaultDeploymentUniqueLabelKey],
+			"deployment_name":    deploymentName,
+			"images":             images,
+			"replicas":           replicas,
+			"current_replicas":   int(rs.Status.Replicas),
+			"ready_replicas":     int(rs.Status.ReadyReplicas),
+			"available_replicas": int(rs.Status.AvailableReplicas),
+			"creation_timestamp": rs.CreationTimestamp.UTC().Format(time.RFC3339),
+		}
+	}
+	return out
+}
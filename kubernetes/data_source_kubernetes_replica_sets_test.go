@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestFlattenReplicaSets(t *testing.T) {
+	controller := true
+	replicaSet := func(name, revision string, deploymentUID types.UID) appsv1.ReplicaSet {
+		rs := appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{deploymentRevisionAnnotation: revision},
+				Labels:      map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: name[len(name)-5:]},
+			},
+		}
+		if deploymentUID != "" {
+			rs.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: deploymentUID, Controller: &controller}}
+		}
+		return rs
+	}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", UID: "1"}}
+	items := replicaSetsControlledBy([]appsv1.ReplicaSet{
+		replicaSet("web-7d9f8b6c5a", "2", "1"),
+		replicaSet("web-5c6b7d8e9f", "10", "1"),
+		replicaSet("web-0a1b2c3d4e", "3", "2"),
+		replicaSet("orphan-abcde", "", ""),
+	}, deployment)
+
+	got := flattenReplicaSets(items)
+	if len(got) != 2 {
+		t.Fatalf("Expected the 2 replica sets of the deployment, got %d", len(got))
+	}
+	first := got[0].(map[string]interface{})
+	if first["name"] != "web-5c6b7d8e9f" || first["revision"] != "10" || first["pod_template_hash"] != "d8e9f" || first["deployment_name"] != "web" {
+		t.Fatalf("Expected the latest revision first, got %v", first)
+	}
+}
+
+func TestAccKubernetesDataSourceReplicaSets_deployment(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	dataSourceName := "data.kubernetes_replica_sets.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceReplicaSetsConfig_deployment(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "current_revision", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "replica_sets.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "replica_sets.0.revision", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "replica_sets.0.deployment_name", name),
+					resource.TestCheckResourceAttr(dataSourceName, "replica_sets.0.replicas", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "replica_sets.0.images.0", busyboxImage),
+					resource.TestCheckResourceAttrSet(dataSourceName, "replica_sets.0.pod_template_hash"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceReplicaSetsConfig_deployment(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_deployment_v1" "test" {
+  metadata {
+    name = %[1]q
+  }
+  spec {
+    replicas = 1
+    selector {
+      match_labels = {
+        app = %[1]q
+      }
+    }
+    template {
+      metadata {
+        labels = {
+          app = %[1]q
+        }
+      }
+      spec {
+        container {
+          name    = "app"
+          image   = %[2]q
+          command = ["sleep", "3600"]
+        }
+      }
+    }
+  }
+}
+
+data "kubernetes_replica_sets" "test" {
+  deployment_name = kubernetes_deployment_v1.test.metadata[0].name
+}
+`, name, busyboxImage)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func dataSourceKubernetesResourceQuotas() *schema.Resource {
+	return &schema.Resource{
+		Description: "This data source lists the resource quotas of a namespace with their hard limits and current usage, e.g. to check that the resources requested by a workload fit within the quota of a tenant namespace before applying it.",
+		ReadContext: dataSourceKubernetesResourceQuotasRead,
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "The namespace of the resource quotas. Defaults to `default`.",
+				Optional:    true,
+				Default:     "default",
+			},
+			"label_selector": {
+				Type:        schema.TypeString,
+				Description: "A label selector the resource quotas must match. Leave empty to list all the resource quotas of the namespace.",
+				Optional:    true,
+			},
+			"page_size": listPageSizeSchema(),
+			"max_items": listMaxItemsSchema("resource quotas"),
+			"resource_quotas": {
+				Type:        schema.TypeList,
+				Description: "The resource quotas of the namespace, sorted by name.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the resource quota.",
+							Computed:    true,
+						},
+						"hard": {
+							Type:        schema.TypeMap,
+							Description: "The hard limits enforced by the quota by resource name, as observed by the quota controller.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"used": {
+							Type:        schema.TypeMap,
+							Description: "The current usage by resource name.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"remaining": {
+							Type:        schema.TypeMap,
+							Description: "What is left of the hard limits by resource name, i.e. `hard` minus `used`.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"scopes": {
+							Type:        schema.TypeSet,
+							Description: "The scopes of the objects tracked by the quota, e.g. `NotBestEffort`. Empty when the quota tracks all the objects of the namespace.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesResourceQuotasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace := d.Get("namespace").(string)
+	selector := d.Get("label_selector").(string)
+	if _, err := labels.Parse(selector); err != nil {
+		return diag.Errorf("Invalid label selector %q: %s", selector, err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Listing resource quotas of namespace %s", namespace))
+	paging := expandListPaging(d)
+	items, truncated, err := listAll(ctx, metav1.ListOptions{LabelSelector: selector}, paging, conn.CoreV1().ResourceQuotas(namespace).List, func(l *corev1.ResourceQuotaList) []corev1.ResourceQuota {
+		return l.Items
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var diags diag.Diagnostics
+	if truncated {
+		diags = listTruncatedDiagnostics("resource quotas", paging.maxItems)
+	}
+
+	d.SetId(fmt.Sprintf("%s/labelSelector=%s", namespace, selector))
+	err = d.Set("resource_quotas", flattenResourceQuotas(items))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func flattenResourceQuotas(in []corev1.ResourceQuota) []interface{} {
+	sort.Slice(in, func(i, j int) bool { return in[i].Name < in[j].Name })
+	out := make([]interface{}, len(in))
+	for i, q := range in {
+		out[i] = map[string]interface{}{
+			"name":      q.Name,
+			"hard":      flattenResourceList(q.Status.Hard),
+			"used":      flattenResourceList(q.Status.Used),
+			"remaining": flattenResourceList(resourceQuotaRemaining(q.Status)),
+			"scopes":    flattenResourceQuotaScopes(q.Spec.Scopes),
+		}
+	}
+	return out
+}
+
+// resourceQuotaRemaining subtracts the usage of a quota from its hard limits.
+// The resources which aren't used yet have all of their hard limit left.
+func resourceQuotaRemaining(status corev1.ResourceQuotaStatus) corev1.ResourceList {
+	out := make(corev1.ResourceList, len(status.Hard))
+	for name, hard := range status.Hard {
+		remaining := hard.DeepCopy()
+		if used, ok := status.Used[name]; ok {
+			remaining.Sub(used)
+		}
+		out[name] = remaining
+	}
+	return out
+}
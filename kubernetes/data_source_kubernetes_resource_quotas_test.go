@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	corev1 "k8s.io/api/core/v1"
+	kuberesource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestResourceQuotaRemaining(t *testing.T) {
+	status := corev1.ResourceQuotaStatus{
+		Hard: corev1.ResourceList{
+			corev1.ResourceRequestsCPU:    kuberesource.MustParse("4"),
+			corev1.ResourceRequestsMemory: kuberesource.MustParse("8Gi"),
+			corev1.ResourcePods:           kuberesource.MustParse("10"),
+		},
+		Used: corev1.ResourceList{
+			corev1.ResourceRequestsCPU:    kuberesource.MustParse("1500m"),
+			corev1.ResourceRequestsMemory: kuberesource.MustParse("2Gi"),
+		},
+	}
+	expected := map[string]string{
+		"requests.cpu":    "2500m",
+		"requests.memory": "6Gi",
+		"pods":            "10",
+	}
+	if diff := cmp.Diff(expected, flattenResourceList(resourceQuotaRemaining(status))); diff != "" {
+		t.Fatalf("Unexpected remaining quota: mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAccKubernetesDataSourceResourceQuotas_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	dataSourceName := "data.kubernetes_resource_quotas.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceResourceQuotasConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "resource_quotas.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "resource_quotas.0.name", name),
+					resource.TestCheckResourceAttr(dataSourceName, "resource_quotas.0.hard.pods", "4"),
+					resource.TestCheckResourceAttr(dataSourceName, "resource_quotas.0.used.pods", "0"),
+					resource.TestCheckResourceAttr(dataSourceName, "resource_quotas.0.remaining.pods", "4"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceResourceQuotasConfig_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_namespace_v1" "test" {
+  metadata {
+    name = %[1]q
+  }
+}
+
+resource "kubernetes_resource_quota_v1" "test" {
+  metadata {
+    name      = %[1]q
+    namespace = kubernetes_namespace_v1.test.metadata[0].name
+  }
+  spec {
+    hard = {
+      pods = 4
+    }
+  }
+}
+
+data "kubernetes_resource_quotas" "test" {
+  namespace = kubernetes_resource_quota_v1.test.metadata[0].namespace
+}
+`, name)
+}
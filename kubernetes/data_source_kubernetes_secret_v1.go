@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -18,7 +19,7 @@ func dataSourceKubernetesSecretV1() *schema.Resource {
 		Description: "The resource provides mechanisms to inject containers with sensitive information, such as passwords, while keeping containers agnostic of Kubernetes. Secrets can be used to store sensitive information either as individual properties or coarse-grained entries like entire files or JSON blobs. The resource will by default create a secret which is available to any pod in the specified (or default) namespace.",
 		ReadContext: dataSourceKubernetesSecretV1Read,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withExistenceFields(map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("secret", true),
 			"data": {
 				Type:        schema.TypeMap,
@@ -42,7 +43,7 @@ func dataSourceKubernetesSecretV1() *schema.Resource {
 				Description: "Ensures that data stored in the Secret cannot be updated (only object metadata can be modified).",
 				Computed:    true,
 			},
-		},
+		}),
 	}
 }
 
@@ -60,15 +61,19 @@ func dataSourceKubernetesSecretV1Read(ctx context.Context, d *schema.ResourceDat
 	}
 	d.SetId(buildId(om))
 
-	log.Printf("[INFO] Reading secret %s", metadata.Name)
+	tflog.Info(ctx, fmt.Sprintf("Reading secret %s", metadata.Name))
 	secret, err := conn.CoreV1().Secrets(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil
+			return dataSourceObjectNotFound(ctx, d, "Secret", d.Id())
 		}
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received secret: %#v", secret.ObjectMeta)
+	tflog.Info(ctx, fmt.Sprintf("Received secret: %#v", secret.ObjectMeta))
+	err = d.Set("exists", true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	err = d.Set("metadata", flattenMetadataFields(secret.ObjectMeta))
 	if err != nil {
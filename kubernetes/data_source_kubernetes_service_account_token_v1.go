@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	authv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func dataSourceKubernetesServiceAccountTokenV1() *schema.Resource {
+	return &schema.Resource{
+		Description: "This data source requests a short-lived, audience-scoped token for a service account using the TokenRequest API. A new token is issued every time the data source is read. Prefer the ephemeral variant of this data source when the token should not be persisted in state.",
+		ReadContext: dataSourceKubernetesServiceAccountTokenV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": {
+				Type:        schema.TypeList,
+				Description: "The service account the token is requested for.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Description:  "Name of the service account.",
+							Required:     true,
+							ValidateFunc: validateName,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the service account.",
+							Optional:    true,
+							Default:     "default",
+						},
+					},
+				},
+			},
+			"spec": {
+				Type:        schema.TypeList,
+				Description: authv1.TokenRequest{}.Spec.SwaggerDoc()["spec"],
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: tokenRequestV1SpecFields(),
+				},
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Description: "Token is the opaque bearer token.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expiration_timestamp": {
+				Type:        schema.TypeString,
+				Description: "The time at which the returned token expires, in RFC3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesServiceAccountTokenV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Get("metadata.0.name").(string)
+	namespace := d.Get("metadata.0.namespace").(string)
+
+	request := authv1.TokenRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: *expandTokenRequestV1Spec(d.Get("spec").([]interface{})),
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Requesting token for service account %s/%s", namespace, name))
+	out, err := conn.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, name, &request, metav1.CreateOptions{})
+	if err != nil {
+		return diag.Errorf(`Unable to request token for service account "%s/%s": %s`, namespace, name, err)
+	}
+
+	d.SetId(buildId(request.ObjectMeta))
+
+	s, err := flattenTokenRequestV1Spec(out.Spec, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("spec", s)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("token", out.Status.Token)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("expiration_timestamp", out.Status.ExpirationTimestamp.Format(time.RFC3339))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
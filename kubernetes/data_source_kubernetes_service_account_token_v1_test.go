@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKubernetesDataSourceServiceAccountTokenV1_basic(t *testing.T) {
+	dataSourceName := "data.kubernetes_service_account_token_v1.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceServiceAccountTokenV1Config_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "metadata.0.name", name),
+					resource.TestCheckResourceAttr(dataSourceName, "metadata.0.namespace", "default"),
+					resource.TestCheckResourceAttr(dataSourceName, "spec.0.audiences.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "spec.0.audiences.0", "vault"),
+					resource.TestCheckResourceAttr(dataSourceName, "spec.0.expiration_seconds", "600"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "token"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "expiration_timestamp"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceServiceAccountTokenV1Config_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_service_account_v1" "test" {
+  metadata {
+    name = %q
+  }
+}
+
+data "kubernetes_service_account_token_v1" "test" {
+  metadata {
+    name = kubernetes_service_account_v1.test.metadata.0.name
+  }
+  spec {
+    audiences          = ["vault"]
+    expiration_seconds = 600
+  }
+}
+`, name)
+}
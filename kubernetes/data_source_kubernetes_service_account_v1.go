@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -18,7 +19,7 @@ func dataSourceKubernetesServiceAccountV1() *schema.Resource {
 		Description: "A service account provides an identity for processes that run in a Pod. This data source reads the service account and makes specific attributes available to Terraform. More info: https://kubernetes.io/docs/reference/access-authn-authz/service-accounts-admin/.",
 		ReadContext: dataSourceKubernetesServiceAccountV1Read,
 
-		Schema: map[string]*schema.Schema{
+		Schema: withExistenceFields(map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("service account", false),
 			"image_pull_secret": {
 				Type:        schema.TypeList,
@@ -58,7 +59,7 @@ func dataSourceKubernetesServiceAccountV1() *schema.Resource {
 				Computed:   true,
 				Deprecated: "Starting from version 1.24.0 Kubernetes does not automatically generate a token for service accounts, in this case, `default_secret_name` will be empty",
 			},
-		},
+		}),
 	}
 }
 
@@ -72,8 +73,7 @@ func dataSourceKubernetesServiceAccountV1Read(ctx context.Context, d *schema.Res
 	sa, err := conn.CoreV1().ServiceAccounts(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			d.SetId(buildId(sa.ObjectMeta))
-			return nil
+			return dataSourceObjectNotFound(ctx, d, "Service account", buildId(metadata))
 		}
 		return diag.Errorf(`Unable to fetch service account "%s/%s" from Kubernetes: %s`, metadata.Namespace, metadata.Name, err)
 	}
@@ -87,17 +87,21 @@ func dataSourceKubernetesServiceAccountV1Read(ctx context.Context, d *schema.Res
 
 	d.SetId(buildId(sa.ObjectMeta))
 
-	log.Printf("[INFO] Reading service account %s", metadata.Name)
+	tflog.Info(ctx, fmt.Sprintf("Reading service account %s", metadata.Name))
 	svcAcc, err := conn.CoreV1().ServiceAccounts(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil
+			return dataSourceObjectNotFound(ctx, d, "Service account", d.Id())
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		diagMsg = append(diagMsg, diag.FromErr(err)...)
 		return diagMsg
 	}
-	log.Printf("[INFO] Received service account: %#v", svcAcc)
+	tflog.Info(ctx, fmt.Sprintf("Received service account: %#v", svcAcc))
+	err = d.Set("exists", true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	err = d.Set("metadata", flattenMetadataFields(svcAcc.ObjectMeta))
 	if err != nil {
@@ -126,9 +130,9 @@ func dataSourceKubernetesServiceAccountV1Read(ctx context.Context, d *schema.Res
 	}
 
 	defaultSecretName := d.Get("default_secret_name").(string)
-	log.Printf("[DEBUG] Default secret name is %q", defaultSecretName)
+	tflog.Debug(ctx, fmt.Sprintf("Default secret name is %q", defaultSecretName))
 	secrets := flattenServiceAccountSecrets(svcAcc.Secrets, defaultSecretName)
-	log.Printf("[DEBUG] Flattened secrets: %#v", secrets)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened secrets: %#v", secrets))
 	err = d.Set("secret", secrets)
 	if err != nil {
 		diagMsg = append(diagMsg, diag.FromErr(err)...)
@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/restmapper"
+)
+
+func dataSourceKubernetesUnmanagedObjects() *schema.Resource {
+	return &schema.Resource{
+		Description: "This data source compares the objects of a namespace with the names expected for each kind, and returns the extra objects, e.g. to report the objects which exist in the cluster but aren't managed by Terraform without granting the provider the permission to delete them.",
+		ReadContext: dataSourceKubernetesUnmanagedObjectsRead,
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "The namespace of the objects.",
+				Required:    true,
+			},
+			"label_selector": {
+				Type:        schema.TypeString,
+				Description: "A label selector the objects must match to be compared. Leave empty to compare all the objects of the namespace.",
+				Optional:    true,
+			},
+			"expected": {
+				Type:        schema.TypeList,
+				Description: "The names of the objects expected in the namespace for a kind. Only the objects of these kinds are compared.",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:        schema.TypeString,
+							Description: "The API version of the kind, e.g. `apps/v1`.",
+							Required:    true,
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Description: "The kind of the objects, e.g. `Deployment`. It must be namespaced.",
+							Required:    true,
+						},
+						"names": {
+							Type:        schema.TypeSet,
+							Description: "The names of the expected objects of the kind.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"extra": {
+				Type:        schema.TypeList,
+				Description: "The objects of the namespace which aren't expected, sorted by ID. Objects owned by a controller, such as the pods of a deployment, and objects being deleted aren't reported.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:        schema.TypeString,
+							Description: "The API version of the object.",
+							Computed:    true,
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Description: "The kind of the object.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the object.",
+							Computed:    true,
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the object, in the format of the import IDs of `kubernetes_manifest`.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesUnmanagedObjectsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	conn, err := m.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	dc, err := m.(KubeClientsets).DiscoveryClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	agr, err := restmapper.GetAPIGroupResources(dc)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	restMapper := restmapper.NewDiscoveryRESTMapper(agr)
+
+	namespace := d.Get("namespace").(string)
+	selector := d.Get("label_selector").(string)
+	if _, err := labels.Parse(selector); err != nil {
+		return diag.Errorf("Invalid label selector %q: %s", selector, err)
+	}
+
+	var extra []prunableObject
+	for _, e := range d.Get("expected").([]interface{}) {
+		expected := e.(map[string]interface{})
+		gv, err := k8sschema.ParseGroupVersion(expected["api_version"].(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		gk := gv.WithKind(expected["kind"].(string)).GroupKind()
+		mapping, err := restMapper.RESTMapping(gk, gv.Version)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+			return diag.Errorf("Kind %s isn't namespaced", gk)
+		}
+
+		var refs []pruneObjectRef
+		for _, name := range expected["names"].(*schema.Set).List() {
+			refs = append(refs, pruneObjectRef{groupKind: gk, namespace: namespace, name: name.(string)})
+		}
+		keep := pruneKeepSet(refs, gk, true, namespace)
+
+		tflog.Info(ctx, fmt.Sprintf("Listing %s of namespace %s matching %q", mapping.Resource.Resource, namespace, selector))
+		items, _, err := listAll(ctx, metav1.ListOptions{LabelSelector: selector}, listPaging{pageSize: defaultListPageSize}, conn.Resource(mapping.Resource).Namespace(namespace).List, func(l *unstructured.UnstructuredList) []unstructured.Unstructured {
+			return l.Items
+		})
+		if err != nil {
+			return diag.Errorf("Unable to list %s: %s", mapping.Resource.Resource, err)
+		}
+		for _, item := range prunableItems(items, gk, keep) {
+			extra = append(extra, prunableObject{resource: mapping.Resource, kind: gk.Kind, object: item})
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/labelSelector=%s", namespace, selector))
+	err = d.Set("extra", flattenUnmanagedObjects(extra))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func flattenUnmanagedObjects(in []prunableObject) []interface{} {
+	sort.Slice(in, func(i, j int) bool { return pruneObjectID(in[i]) < pruneObjectID(in[j]) })
+	out := make([]interface{}, len(in))
+	for i, o := range in {
+		out[i] = map[string]interface{}{
+			"api_version": o.resource.GroupVersion().String(),
+			"kind":        o.kind,
+			"name":        o.object.GetName(),
+			"id":          pruneObjectID(o),
+		}
+	}
+	return out
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKubernetesDataSourceUnmanagedObjects_basic(t *testing.T) {
+	namespace := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	dataSourceName := "data.kubernetes_unmanaged_objects.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceUnmanagedObjectsConfig_basic(namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "extra.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "extra.0.api_version", "v1"),
+					resource.TestCheckResourceAttr(dataSourceName, "extra.0.kind", "ConfigMap"),
+					resource.TestCheckResourceAttr(dataSourceName, "extra.0.name", "three"),
+					resource.TestCheckResourceAttr(dataSourceName, "extra.0.id", fmt.Sprintf("apiVersion=v1,kind=ConfigMap,name=three,namespace=%s", namespace)),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceUnmanagedObjectsConfig_basic(namespace string) string {
+	return fmt.Sprintf(`resource "kubernetes_namespace_v1" "test" {
+  metadata {
+    name = %[1]q
+  }
+}
+
+resource "kubernetes_config_map_v1" "test" {
+  for_each = toset(["one", "two", "three"])
+  metadata {
+    name      = each.key
+    namespace = kubernetes_namespace_v1.test.metadata[0].name
+    labels = {
+      "app.kubernetes.io/managed-by" = "tf-acc-test"
+    }
+  }
+}
+
+data "kubernetes_unmanaged_objects" "test" {
+  namespace      = kubernetes_namespace_v1.test.metadata[0].name
+  label_selector = "app.kubernetes.io/managed-by=tf-acc-test"
+  expected {
+    api_version = "v1"
+    kind        = "ConfigMap"
+    names       = ["one", "two"]
+  }
+  depends_on = [kubernetes_config_map_v1.test]
+}
+`, namespace)
+}
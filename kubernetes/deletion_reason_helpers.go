@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// deletionEventReasons are the reasons of the events which are reported for an
+// object shortly before it is deleted, by the component deleting it.
+var deletionEventReasons = []string{
+	"Deleted",
+	"Evicted",
+	"Killing",
+	"Preempted",
+	"Preempting",
+	"TaintManagerEviction",
+}
+
+// withOutOfBandDeletionReason explains why a structured resource is going to be
+// recreated when its object was found to be gone on read. The explanation is
+// reported as a warning, which shows up in the output of the plan.
+func withOutOfBandDeletionReason(r *schema.Resource, wr waitableResource) {
+	read := r.ReadContext
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		id := d.Id()
+		object := metav1.ObjectMeta{
+			Name: d.Get("metadata.0.name").(string),
+		}
+		if wr.Namespaced {
+			object.Namespace = d.Get("metadata.0.namespace").(string)
+		}
+		if uid, ok := d.Get("metadata.0.uid").(string); ok {
+			object.UID = types.UID(uid)
+		}
+
+		diags := read(ctx, d, meta)
+		if diags.HasError() || id == "" || d.Id() != "" {
+			return diags
+		}
+
+		conn, err := meta.(KubeClientsets).MainClientset()
+		if err != nil {
+			return diags
+		}
+		warning := outOfBandDeletion(ctx, conn, wr.GroupVersionResource.Resource, object)
+		tflog.Info(ctx, fmt.Sprintf("%s: %s", warning.Summary, warning.Detail))
+		return append(diags, warning)
+	}
+}
+
+// outOfBandDeletion describes the deletion of an object which is gone, with the
+// time, the component and the reason of the deletion when they are known.
+func outOfBandDeletion(ctx context.Context, conn kubernetes.Interface, kind string, object metav1.ObjectMeta) diag.Diagnostic {
+	var at time.Time
+	var by, reason string
+
+	if object.Namespace != "" {
+		ns, err := conn.CoreV1().Namespaces().Get(ctx, object.Namespace, metav1.GetOptions{})
+		switch {
+		case errors.IsNotFound(err):
+			reason = fmt.Sprintf("Its namespace %q was deleted.", object.Namespace)
+		case err == nil && ns.DeletionTimestamp != nil:
+			at = ns.DeletionTimestamp.Time
+			reason = fmt.Sprintf("Its namespace %q is being deleted.", object.Namespace)
+		}
+	}
+	if e := lastDeletionEvent(ctx, conn, object); e != nil {
+		at = eventTimestamp(*e)
+		by = eventReporter(*e)
+		reason = fmt.Sprintf("%s: %s", e.Reason, e.Message)
+	}
+
+	var b strings.Builder
+	b.WriteString("It will be recreated because it was deleted out of band")
+	if !at.IsZero() {
+		fmt.Fprintf(&b, " at %s", at.UTC().Format(time.RFC3339))
+	}
+	if by != "" {
+		fmt.Fprintf(&b, " by %s", by)
+	}
+	b.WriteString(".")
+	if reason != "" {
+		fmt.Fprintf(&b, " %s", reason)
+	}
+
+	name := object.Name
+	if object.Namespace != "" {
+		name = object.Namespace + "/" + object.Name
+	}
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s %q was deleted out of band", kind, name),
+		Detail:   b.String(),
+	}
+}
+
+// lastDeletionEvent returns the most recent event reported for the object which
+// indicates its deletion. Events of a previous object of the same name are
+// ignored when the UID of the object is known.
+func lastDeletionEvent(ctx context.Context, conn kubernetes.Interface, object metav1.ObjectMeta) *api.Event {
+	m := fields.Set{"involvedObject.name": object.Name}
+	if object.UID != "" {
+		m["involvedObject.uid"] = string(object.UID)
+	}
+	namespace := object.Namespace
+	if namespace == "" {
+		// Events of cluster-scoped objects are stored in the default namespace.
+		namespace = metav1.NamespaceDefault
+	}
+	out, err := conn.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: m.String(),
+	})
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to list events of %q: %s", object.Name, err))
+		return nil
+	}
+
+	var last *api.Event
+	for i, e := range out.Items {
+		if e.InvolvedObject.Name != object.Name || (object.UID != "" && e.InvolvedObject.UID != object.UID) {
+			continue
+		}
+		if !slices.Contains(deletionEventReasons, e.Reason) {
+			continue
+		}
+		if last == nil || eventTimestamp(e).After(eventTimestamp(*last)) {
+			last = &out.Items[i]
+		}
+	}
+	return last
+}
+
+func eventReporter(e api.Event) string {
+	if e.ReportingController != "" {
+		return e.ReportingController
+	}
+	return e.Source.Component
+}
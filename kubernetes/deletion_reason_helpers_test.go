@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestOutOfBandDeletion(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	object := metav1.ObjectMeta{Namespace: "default", Name: "web", UID: types.UID("new")}
+	namespace := &api.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	event := func(name, uid, reason string, ts time.Time) *api.Event {
+		return &api.Event{
+			ObjectMeta:          metav1.ObjectMeta{Namespace: "default", Name: name},
+			InvolvedObject:      api.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web", UID: types.UID(uid)},
+			Reason:              reason,
+			Message:             "Stopping container web",
+			LastTimestamp:       metav1.NewTime(ts),
+			ReportingController: "kubelet",
+		}
+	}
+
+	cases := map[string]struct {
+		conn     *fake.Clientset
+		expected string
+	}{
+		"unknown": {
+			conn:     fake.NewSimpleClientset(namespace),
+			expected: "It will be recreated because it was deleted out of band.",
+		},
+		"event": {
+			conn: fake.NewSimpleClientset(namespace,
+				event("a", "new", "Killing", at),
+				event("b", "new", "Pulled", at.Add(time.Minute)),
+				event("c", "old", "Killing", at.Add(time.Hour)),
+			),
+			expected: "It will be recreated because it was deleted out of band at 2024-05-01T12:00:00Z by kubelet. Killing: Stopping container web",
+		},
+		"namespace": {
+			conn:     fake.NewSimpleClientset(),
+			expected: `It will be recreated because it was deleted out of band. Its namespace "default" was deleted.`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := outOfBandDeletion(context.Background(), tc.conn, "pods", object)
+			if d.Summary != `pods "default/web" was deleted out of band` {
+				t.Fatalf("unexpected summary %q", d.Summary)
+			}
+			if d.Detail != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, d.Detail)
+			}
+		})
+	}
+}
@@ -4,7 +4,11 @@
 package kubernetes
 
 import (
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -19,3 +23,123 @@ func suppressEquivalentResourceQuantity(k, old, new string, d *schema.ResourceDa
 	}
 	return oldQ.Cmp(newQ) == 0
 }
+
+// suppressDefaultImagePullPolicy suppresses the diff between an image pull policy
+// which isn't set and the policy the API server defaults it to for the image of
+// the container.
+func suppressDefaultImagePullPolicy(k, old, new string, d *schema.ResourceData) bool {
+	image, ok := d.Get(strings.TrimSuffix(k, "image_pull_policy") + "image").(string)
+	if !ok {
+		return false
+	}
+	policy := defaultImagePullPolicy(image)
+	return (old == "" || old == policy) && (new == "" || new == policy)
+}
+
+// suppressAutoAssignedNodePort suppresses the diff of a node port which isn't set,
+// or set to zero, to the port the API server assigned.
+func suppressAutoAssignedNodePort(k, old, new string, d *schema.ResourceData) bool {
+	return (new == "" || new == "0") && old != "" && old != "0"
+}
+
+// suppressDroppedJobManagedBy suppresses the diff of the managed_by of an
+// existing job which the API server dropped, as it does when the JobManagedBy
+// feature gate isn't enabled. Otherwise the job would be replaced on every
+// apply, as the field is immutable.
+func suppressDroppedJobManagedBy(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && old == "" && new != ""
+}
+
+// suppressSecondaryIPFamilyAllocation suppresses the diff of the cluster IPs or
+// IP families of a service which only configures the primary one, when the API
+// server allocated a secondary one because the IP family policy of the service
+// is dual-stack.
+func suppressSecondaryIPFamilyAllocation(k, old, new string, d *schema.ResourceData) bool {
+	prefix := k[:strings.LastIndex(k, ".")]
+	prefix = prefix[:strings.LastIndex(prefix, ".")+1]
+	if d.Get(prefix+"ip_family_policy").(string) == string(v1.IPFamilyPolicySingleStack) {
+		return false
+	}
+	switch {
+	case strings.HasSuffix(k, ".#"):
+		return old == "2" && new == "1"
+	case strings.HasSuffix(k, ".1"):
+		return old != "" && new == ""
+	}
+	return false
+}
+
+// suppressEquivalentJSON suppresses the diff between JSON documents which only
+// differ in formatting or the order of their keys.
+func suppressEquivalentJSON(k, old, new string, d *schema.ResourceData) bool {
+	oldJSON, err := structure.NormalizeJsonString(old)
+	if err != nil {
+		return false
+	}
+	newJSON, err := structure.NormalizeJsonString(new)
+	if err != nil {
+		return false
+	}
+	return oldJSON == newJSON
+}
+
+// suppressAdmissionSecurityContext returns the diff suppression of a security
+// context block with the schema r. It suppresses the removal of a security
+// context which isn't configured and only holds the seccomp or AppArmor profile
+// set by admission controllers and policy engines, e.g. to enforce the
+// restricted Pod Security Standard.
+func suppressAdmissionSecurityContext(r *schema.Resource) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		i := strings.LastIndex(k, ".security_context.")
+		if i < 0 {
+			return false
+		}
+		parent := rawConfigAt(d.GetRawConfig(), k[:i])
+		if parent.IsNull() || !parent.IsKnown() || !parent.Type().IsObjectType() {
+			return false
+		}
+		config := parent.GetAttr("security_context")
+		if !config.IsKnown() || !config.IsNull() && config.LengthInt() > 0 {
+			return false
+		}
+
+		prior, _ := d.GetChange(k[:i] + ".security_context")
+		l, ok := prior.([]interface{})
+		if !ok || len(l) != 1 {
+			return false
+		}
+		fields, _ := l[0].(map[string]interface{})
+		for name, v := range fields {
+			if name == "seccomp_profile" || name == "app_armor_profile" {
+				continue
+			}
+			if s, ok := r.Schema[name]; ok && s.Default != nil && v == s.Default {
+				continue
+			}
+			if !isEmptySecurityContextField(v) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func isEmptySecurityContextField(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case int:
+		return v == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	case *schema.Set:
+		return v.Len() == 0
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSuppressEquivalentResourceQuantity(t *testing.T) {
+	cases := []struct {
+		Old      string
+		New      string
+		Suppress bool
+	}{
+		{"1Gi", "1024Mi", true},
+		{"0.5", "500m", true},
+		{"1", "1000m", true},
+		{"2", "2", true},
+		{"1G", "1Gi", false},
+		{"100m", "200m", false},
+		{"1Gi", "", false},
+		{"", "1Gi", false},
+		{"1Gi", "invalid", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Old+"->"+tc.New, func(t *testing.T) {
+			if got := suppressEquivalentResourceQuantity("spec.0.resources.0.requests.memory", tc.Old, tc.New, nil); got != tc.Suppress {
+				t.Fatalf("expected %t, got %t", tc.Suppress, got)
+			}
+		})
+	}
+}
+
+func TestSuppressDefaultImagePullPolicy(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"container": {
+			Type: schema.TypeList,
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"image":             {Type: schema.TypeString},
+				"image_pull_policy": {Type: schema.TypeString, Optional: true, Computed: true},
+			}},
+		},
+	}
+	cases := []struct {
+		Image    string
+		Old      string
+		New      string
+		Suppress bool
+	}{
+		{"nginx", "Always", "", true},
+		{"nginx:1.25", "IfNotPresent", "", true},
+		{"nginx:1.25", "", "IfNotPresent", true},
+		{"nginx:1.25", "Always", "", false},
+		{"nginx", "Always", "IfNotPresent", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Image+":"+tc.Old+"->"+tc.New, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+				"container": []interface{}{map[string]interface{}{"image": tc.Image}},
+			})
+			if got := suppressDefaultImagePullPolicy("container.0.image_pull_policy", tc.Old, tc.New, d); got != tc.Suppress {
+				t.Fatalf("expected %t, got %t", tc.Suppress, got)
+			}
+		})
+	}
+}
+
+func TestSuppressSecondaryIPFamilyAllocation(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"spec": {
+			Type: schema.TypeList,
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"ip_family_policy": {Type: schema.TypeString, Optional: true},
+			}},
+		},
+	}
+	cases := []struct {
+		Policy   string
+		Key      string
+		Old      string
+		New      string
+		Suppress bool
+	}{
+		{"PreferDualStack", "spec.0.cluster_ips.#", "2", "1", true},
+		{"PreferDualStack", "spec.0.cluster_ips.1", "fd00::1", "", true},
+		{"RequireDualStack", "spec.0.ip_families.1", "IPv6", "", true},
+		{"PreferDualStack", "spec.0.cluster_ips.#", "1", "2", false},
+		{"PreferDualStack", "spec.0.cluster_ips.0", "10.0.0.1", "10.0.0.2", false},
+		{"PreferDualStack", "spec.0.cluster_ips.1", "fd00::1", "fd00::2", false},
+		{"SingleStack", "spec.0.cluster_ips.#", "2", "1", false},
+		{"SingleStack", "spec.0.ip_families.1", "IPv6", "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Policy+":"+tc.Key+":"+tc.Old+"->"+tc.New, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+				"spec": []interface{}{map[string]interface{}{"ip_family_policy": tc.Policy}},
+			})
+			if got := suppressSecondaryIPFamilyAllocation(tc.Key, tc.Old, tc.New, d); got != tc.Suppress {
+				t.Fatalf("expected %t, got %t", tc.Suppress, got)
+			}
+		})
+	}
+}
+
+func TestSuppressDroppedJobManagedBy(t *testing.T) {
+	cases := []struct {
+		ID       string
+		Old      string
+		New      string
+		Suppress bool
+	}{
+		{"default/job", "", "kueue.x-k8s.io/multikueue", true},
+		{"", "", "kueue.x-k8s.io/multikueue", false},
+		{"default/job", "kueue.x-k8s.io/multikueue", "example.com/controller", false},
+		{"default/job", "kueue.x-k8s.io/multikueue", "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.ID+":"+tc.Old+"->"+tc.New, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+			d.SetId(tc.ID)
+			if got := suppressDroppedJobManagedBy("spec.0.managed_by", tc.Old, tc.New, d); got != tc.Suppress {
+				t.Fatalf("expected %t, got %t", tc.Suppress, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apilog"
+	"golang.org/x/sync/singleflight"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	dryRunDefaultsTimeout   = 30 * time.Second
+	dryRunDefaultsCacheSize = 64
+)
+
+// dryRunDefaultsFailureTTL is how long a failed dry-run is cached, so that the
+// diffs of the other fields of the same plan don't wait for it again.
+var dryRunDefaultsFailureTTL = time.Minute
+
+// dryRunDefaults suppresses the diffs of a pod spec which a server-side dry-run
+// of the planned pod spec shows to be the result of defaulting by the API server
+// or admission controllers.
+type dryRunDefaults struct {
+	prefix  string
+	podSpec func(ctx context.Context, d *schema.ResourceData) map[string]interface{}
+}
+
+// withDryRunDefaults adds the dry_run_defaults attribute to a resource managing
+// pods and wraps the diff suppression of every field of its pod spec.
+func withDryRunDefaults(p *schema.Provider, r *schema.Resource, specPath []string) {
+	r.Schema["dry_run_defaults"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Compare the planned pod spec with the result of a server-side dry-run, so that values defaulted by the API server or admission controllers aren't planned for removal. Requires permission to create pods in the namespace of the resource.",
+		Optional:    true,
+		ForceNew:    r.UpdateContext == nil,
+		Default:     false,
+	}
+
+	dr := &dryRunDefaults{
+		prefix: strings.Join(specPath, ".0.") + ".0.",
+	}
+	dr.podSpec = func(ctx context.Context, d *schema.ResourceData) map[string]interface{} {
+		return dryRunPodSpec(ctx, p.Meta(), d, specPath)
+	}
+
+	s := r.Schema[specPath[0]]
+	for _, step := range specPath[1:] {
+		s = s.Elem.(*schema.Resource).Schema[step]
+	}
+	dr.wrapDiffSuppressFuncs(s.Elem.(*schema.Resource).Schema)
+}
+
+func (dr *dryRunDefaults) wrapDiffSuppressFuncs(m map[string]*schema.Schema) {
+	for _, s := range m {
+		switch s.Type {
+		case schema.TypeBool, schema.TypeInt, schema.TypeFloat, schema.TypeString, schema.TypeMap:
+			if s.Computed && !s.Optional {
+				continue
+			}
+			s.DiffSuppressFunc = dr.wrap(s.DiffSuppressFunc)
+		case schema.TypeList:
+			switch e := s.Elem.(type) {
+			case *schema.Resource:
+				dr.wrapDiffSuppressFuncs(e.Schema)
+			case *schema.Schema:
+				e.DiffSuppressFunc = dr.wrap(e.DiffSuppressFunc)
+			}
+		}
+		// The keys of sets are hashes of their elements, which can't be
+		// looked up in the result of the dry-run.
+	}
+}
+
+func (dr *dryRunDefaults) wrap(next schema.SchemaDiffSuppressFunc) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		if next != nil && next(k, old, new, d) {
+			return true
+		}
+		if old == new || d.Id() == "" || !strings.HasPrefix(k, dr.prefix) {
+			return false
+		}
+		if v, ok := d.Get("dry_run_defaults").(bool); !ok || !v {
+			return false
+		}
+		// Diff suppression functions aren't passed the context of the request.
+		ctx := apilog.Context()
+		spec := dr.podSpec(ctx, d)
+		if spec == nil {
+			return false
+		}
+		v, ok := lookupFlattenedValue(spec, strings.Split(strings.TrimPrefix(k, dr.prefix), "."))
+		if ok && v == old {
+			tflog.Debug(ctx, fmt.Sprintf("Suppressing the diff of %s, %q is the value defaulted by the API server", k, old))
+			return true
+		}
+		return false
+	}
+}
+
+// lookupFlattenedValue returns the string representation of the value at the
+// given state key path of a flattened object, as used by the diff.
+func lookupFlattenedValue(v interface{}, path []string) (string, bool) {
+	for _, step := range path {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			if step == "%" {
+				return strconv.Itoa(len(t)), true
+			}
+			v = t[step]
+		case map[string]string:
+			if step == "%" {
+				return strconv.Itoa(len(t)), true
+			}
+			s, ok := t[step]
+			if !ok {
+				return "", false
+			}
+			v = s
+		case []interface{}:
+			if step == "#" {
+				return strconv.Itoa(len(t)), true
+			}
+			i, err := strconv.Atoi(step)
+			if err != nil || i < 0 || i >= len(t) {
+				return "", false
+			}
+			v = t[i]
+		default:
+			return "", false
+		}
+	}
+
+	switch t := v.(type) {
+	case string:
+		return t, true
+	case bool:
+		return strconv.FormatBool(t), true
+	case int:
+		return strconv.Itoa(t), true
+	case int32:
+		return strconv.FormatInt(int64(t), 10), true
+	case int64:
+		return strconv.FormatInt(t, 10), true
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// dryRunResult is the cached result of the dry-run of a pod spec. Failures
+// expire, so that they are retried by the next plan.
+type dryRunResult struct {
+	spec    map[string]interface{}
+	err     error
+	expires time.Time
+}
+
+var dryRunPodSpecCache = struct {
+	sync.Mutex
+	entries map[string]dryRunResult
+	// calls deduplicates the concurrent dry-runs of the same pod spec, so
+	// that the cache isn't locked while the API server is called.
+	calls singleflight.Group
+}{entries: make(map[string]dryRunResult)}
+
+// dryRunPodSpec creates a pod with the planned pod spec of d using a server-side
+// dry-run and returns the flattened pod spec returned by the API server. The
+// results are cached by object and request, as the diff of every field asks
+// for it, failures for dryRunDefaultsFailureTTL.
+func dryRunPodSpec(ctx context.Context, meta interface{}, d *schema.ResourceData, specPath []string) map[string]interface{} {
+	l, _ := d.Get(specPath[0]).([]interface{})
+	spec, err := expandPodSpec([]interface{}{podSpecAt(l, specPath[1:])})
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Failed to expand the pod spec for a dry-run: %s", err))
+		return nil
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "terraform-dry-run-",
+			Namespace:    d.Get("metadata.0.namespace").(string),
+		},
+		Spec: *spec,
+	}
+	if pod.Namespace == "" {
+		pod.Namespace = "default"
+	}
+	body, err := json.Marshal(pod)
+	if err != nil {
+		return nil
+	}
+	key := d.Id() + "\n" + string(body)
+
+	dryRunPodSpecCache.Lock()
+	v, ok := dryRunPodSpecCache.entries[key]
+	dryRunPodSpecCache.Unlock()
+	if ok && (v.err == nil || time.Now().Before(v.expires)) {
+		return v.spec
+	}
+
+	result, _, _ := dryRunPodSpecCache.calls.Do(key, func() (interface{}, error) {
+		spec, err := createDryRunPod(ctx, meta, pod)
+		result := dryRunResult{spec: spec, err: err}
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Server-side dry-run of the pod spec of %q failed, diffs won't account for defaulted values: %s", d.Id(), err))
+			result.expires = time.Now().Add(dryRunDefaultsFailureTTL)
+		}
+		dryRunPodSpecCache.Lock()
+		defer dryRunPodSpecCache.Unlock()
+		if len(dryRunPodSpecCache.entries) >= dryRunDefaultsCacheSize {
+			dryRunPodSpecCache.entries = make(map[string]dryRunResult)
+		}
+		dryRunPodSpecCache.entries[key] = result
+		return result, nil
+	})
+	return result.(dryRunResult).spec
+}
+
+func createDryRunPod(ctx context.Context, meta interface{}, pod *corev1.Pod) (map[string]interface{}, error) {
+	if meta == nil {
+		return nil, fmt.Errorf("the provider is not configured")
+	}
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, dryRunDefaultsTimeout)
+	defer cancel()
+
+	out, err := conn.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+	if err != nil {
+		return nil, err
+	}
+	flattened, err := flattenPodSpec(out.Spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(flattened) == 0 {
+		return nil, nil
+	}
+	return flattened[0].(map[string]interface{}), nil
+}
@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestLookupFlattenedValue(t *testing.T) {
+	spec := map[string]interface{}{
+		"dns_policy":                       "ClusterFirst",
+		"termination_grace_period_seconds": 30,
+		"host_network":                     false,
+		"node_selector":                    map[string]string{"zone": "a"},
+		"container": []interface{}{
+			map[string]interface{}{
+				"image_pull_policy": "IfNotPresent",
+			},
+		},
+	}
+	cases := []struct {
+		Path     string
+		Expected string
+		Found    bool
+	}{
+		{"dns_policy", "ClusterFirst", true},
+		{"termination_grace_period_seconds", "30", true},
+		{"host_network", "false", true},
+		{"node_selector.zone", "a", true},
+		{"node_selector.%", "1", true},
+		{"container.#", "1", true},
+		{"container.0.image_pull_policy", "IfNotPresent", true},
+		{"container.1.image_pull_policy", "", false},
+		{"missing", "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Path, func(t *testing.T) {
+			v, ok := lookupFlattenedValue(spec, strings.Split(tc.Path, "."))
+			if ok != tc.Found || v != tc.Expected {
+				t.Fatalf("expected (%q, %t), got (%q, %t)", tc.Expected, tc.Found, v, ok)
+			}
+		})
+	}
+}
+
+func TestDryRunDefaultsSuppress(t *testing.T) {
+	r := resourceKubernetesPodV1()
+	r.Schema["dry_run_defaults"] = &schema.Schema{Type: schema.TypeBool, Optional: true}
+	dr := &dryRunDefaults{
+		prefix: "spec.0.",
+		podSpec: func(ctx context.Context, d *schema.ResourceData) map[string]interface{} {
+			return map[string]interface{}{"scheduler_name": "default-scheduler", "hostname": "web"}
+		},
+	}
+	suppress := dr.wrap(nil)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"dry_run_defaults": true})
+	d.SetId("default/web")
+
+	if !suppress("spec.0.hostname", "web", "", d) {
+		t.Fatal("expected the defaulted value to be suppressed")
+	}
+	if suppress("spec.0.hostname", "other", "", d) {
+		t.Fatal("expected a value differing from the dry-run not to be suppressed")
+	}
+	if suppress("metadata.0.name", "web", "", d) {
+		t.Fatal("expected keys outside of the pod spec not to be suppressed")
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("default/web")
+	if suppress("spec.0.hostname", "web", "", d) {
+		t.Fatal("expected no suppression unless dry_run_defaults is enabled")
+	}
+}
+
+func TestDryRunPodSpec(t *testing.T) {
+	// The namespaces are random, as the cache of the dry-runs is global.
+	suffix := acctest.RandString(8)
+	slow, fast, broken := "slow-"+suffix, "fast-"+suffix, "broken-"+suffix
+	release := make(chan struct{})
+	slowStarted := make(chan struct{})
+	var brokenCalls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namespace := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/namespaces/"), "/")[0]
+		switch namespace {
+		case slow:
+			close(slowStarted)
+			<-release
+		case broken:
+			if atomic.AddInt32(&brokenCalls, 1) == 1 {
+				http.Error(w, "etcd is unavailable", http.StatusInternalServerError)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"terraform-dry-run-x","namespace":%q},"spec":{"containers":[{"name":"web","image":"nginx"}],"schedulerName":"default-scheduler"}}`, namespace)
+	}))
+	defer srv.Close()
+	defer func() {
+		select {
+		case <-release:
+		default:
+			close(release)
+		}
+	}()
+
+	ctx := context.Background()
+	meta := providerMetadata{clients: newKubeClients(&restclient.Config{Host: srv.URL})}
+	podData := func(namespace string) *schema.ResourceData {
+		return schema.TestResourceDataRaw(t, resourceKubernetesPodV1().Schema, map[string]interface{}{
+			"metadata": []interface{}{map[string]interface{}{"name": "web", "namespace": namespace}},
+			"spec": []interface{}{map[string]interface{}{
+				"container": []interface{}{map[string]interface{}{"name": "web", "image": "nginx"}},
+			}},
+		})
+	}
+
+	// A failed dry-run is cached for a short while, the diffs of the other
+	// fields don't try again but the next plan does.
+	defer func(ttl time.Duration) { dryRunDefaultsFailureTTL = ttl }(dryRunDefaultsFailureTTL)
+	dryRunDefaultsFailureTTL = 200 * time.Millisecond
+	for i := 0; i < 2; i++ {
+		if spec := dryRunPodSpec(ctx, meta, podData(broken), []string{"spec"}); spec != nil {
+			t.Fatalf("Expected no pod spec when the dry-run fails, got %v", spec)
+		}
+	}
+	if n := atomic.LoadInt32(&brokenCalls); n != 1 {
+		t.Fatalf("Expected the failed dry-run to be cached, got %d calls", n)
+	}
+	time.Sleep(dryRunDefaultsFailureTTL)
+	spec := dryRunPodSpec(ctx, meta, podData(broken), []string{"spec"})
+	if spec == nil || spec["scheduler_name"] != "default-scheduler" {
+		t.Fatalf("Expected the dry-run to be retried, got %v", spec)
+	}
+	dryRunPodSpec(ctx, meta, podData(broken), []string{"spec"})
+	if n := atomic.LoadInt32(&brokenCalls); n != 2 {
+		t.Fatalf("Expected the successful dry-run to be cached, got %d calls", n)
+	}
+
+	// A slow dry-run doesn't hold up the dry-runs of other pod specs.
+	go dryRunPodSpec(ctx, meta, podData(slow), []string{"spec"})
+	<-slowStarted
+	done := make(chan map[string]interface{})
+	go func() { done <- dryRunPodSpec(ctx, meta, podData(fast), []string{"spec"}) }()
+	select {
+	case spec := <-done:
+		if spec == nil {
+			t.Fatal("Expected the pod spec of the fast dry-run")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("The dry-run waited for the dry-run of another pod spec")
+	}
+}
@@ -6,15 +6,27 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	api "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 )
 
+const (
+	// waitEventsInterval is how often warning events are collected during a wait.
+	waitEventsInterval = 10 * time.Second
+	// waitEventsLimit is the number of most recent warning events reported for a wait.
+	waitEventsLimit = 10
+)
+
 func getLastWarningsForObject(ctx context.Context, conn *kubernetes.Clientset, metadata metav1.ObjectMeta, kind string, limit int) ([]api.Event, error) {
 	m := map[string]string{
 		"involvedObject.name": metadata.Name,
@@ -25,7 +37,7 @@ func getLastWarningsForObject(ctx context.Context, conn *kubernetes.Clientset, m
 	}
 
 	fs := fields.Set(m).String()
-	log.Printf("[DEBUG] Looking up events via this selector: %q", fs)
+	tflog.Debug(ctx, fmt.Sprintf("Looking up events via this selector: %q", fs))
 	out, err := conn.CoreV1().Events(metadata.Namespace).List(ctx, metav1.ListOptions{
 		FieldSelector: fs,
 	})
@@ -42,8 +54,7 @@ func getLastWarningsForObject(ctx context.Context, conn *kubernetes.Clientset, m
 		return out.Items[i].LastTimestamp.After(out.Items[j].LastTimestamp.Time)
 	})
 
-	log.Printf("[DEBUG] Received %d events for %s/%s (%s)",
-		len(out.Items), metadata.Namespace, metadata.Name, kind)
+	tflog.Debug(ctx, fmt.Sprintf("Received %d events for %s/%s (%s)", len(out.Items), metadata.Namespace, metadata.Name, kind))
 
 	warnCount := 0
 	uniqueWarnings := make(map[string]api.Event, 0)
@@ -75,3 +86,187 @@ func stringifyEvents(events []api.Event) string {
 	}
 	return output
 }
+
+// waitEventReporter collects the warning events of an object, and of the pods
+// it manages, while the provider waits for it. Every new event is logged as soon
+// as it is seen, so that the cause of a slow rollout shows up in the logs before
+// the wait times out.
+type waitEventReporter struct {
+	conn   kubernetes.Interface
+	object metav1.ObjectMeta
+	kind   string
+	pods   labels.Selector
+	// replicaSets is set for deployments, whose replica sets report the
+	// failures to create pods, e.g. exceeded quotas or rejected pod specs.
+	replicaSets bool
+	since       time.Time
+	seen        map[string]bool
+	events      []api.Event
+	stop        chan struct{}
+	done        chan struct{}
+	once        sync.Once
+}
+
+// startWaitEventReporter starts collecting warning events for the given object.
+// pods selects the pods managed by the object and may be nil. The events of the
+// replica sets of a deployment are collected too.
+func startWaitEventReporter(ctx context.Context, conn kubernetes.Interface, object metav1.ObjectMeta, kind string, pods *metav1.LabelSelector) *waitEventReporter {
+	r := &waitEventReporter{
+		conn:   conn,
+		object: object,
+		kind:   kind,
+		since:  time.Now().Truncate(time.Second),
+		seen:   make(map[string]bool),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	if pods != nil {
+		selector, err := metav1.LabelSelectorAsSelector(pods)
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to use the pod selector of %s %s/%s: %s", kind, object.Namespace, object.Name, err))
+		} else if !selector.Empty() {
+			r.pods = selector
+			r.replicaSets = kind == "Deployment"
+		}
+	}
+
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(waitEventsInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				r.collect(ctx)
+			}
+		}
+	}()
+
+	return r
+}
+
+// Stop stops the reporter and returns the most recent warning events seen
+// since it was started.
+func (r *waitEventReporter) Stop(ctx context.Context) []api.Event {
+	r.once.Do(func() {
+		close(r.stop)
+		<-r.done
+	})
+	r.collect(ctx)
+
+	if len(r.events) > waitEventsLimit {
+		return r.events[len(r.events)-waitEventsLimit:]
+	}
+	return r.events
+}
+
+// Diagnostics stops the reporter and turns the outcome of the wait into
+// diagnostics with waitEventsDiagnostics. When the wait failed without any
+// warning event seen during it, the warning events reported before the wait
+// started are included instead, as the cause may predate the wait, e.g. pods
+// which were already crash looping.
+func (r *waitEventReporter) Diagnostics(ctx context.Context, err error) diag.Diagnostics {
+	events := r.Stop(ctx)
+	if err != nil && len(events) == 0 {
+		r.since = time.Time{}
+		events = r.Stop(ctx)
+	}
+	return waitEventsDiagnostics(err, r.kind, r.object, events)
+}
+
+func (r *waitEventReporter) collect(ctx context.Context) {
+	pods := make(map[string]bool)
+	if r.pods != nil {
+		out, err := r.conn.CoreV1().Pods(r.object.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: r.pods.String(),
+		})
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to list pods of %s %s/%s: %s", r.kind, r.object.Namespace, r.object.Name, err))
+		} else {
+			for _, p := range out.Items {
+				pods[p.Name] = true
+			}
+		}
+	}
+
+	replicaSets := make(map[string]bool)
+	if r.replicaSets {
+		out, err := r.conn.AppsV1().ReplicaSets(r.object.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: r.pods.String(),
+		})
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to list replica sets of %s %s/%s: %s", r.kind, r.object.Namespace, r.object.Name, err))
+		} else {
+			for _, rs := range out.Items {
+				if owner := metav1.GetControllerOf(&rs); owner != nil && owner.Kind == r.kind && owner.Name == r.object.Name {
+					replicaSets[rs.Name] = true
+				}
+			}
+		}
+	}
+
+	out, err := r.conn.CoreV1().Events(r.object.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", api.EventTypeWarning).String(),
+	})
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to list events of %s %s/%s: %s", r.kind, r.object.Namespace, r.object.Name, err))
+		return
+	}
+
+	sort.SliceStable(out.Items, func(i, j int) bool {
+		return eventTimestamp(out.Items[i]).Before(eventTimestamp(out.Items[j]))
+	})
+	for _, e := range out.Items {
+		if e.Type != api.EventTypeWarning || eventTimestamp(e).Before(r.since) {
+			continue
+		}
+		involved := e.InvolvedObject.Kind == r.kind && e.InvolvedObject.Name == r.object.Name
+		if e.InvolvedObject.Kind == "Pod" && pods[e.InvolvedObject.Name] {
+			involved = true
+		}
+		if e.InvolvedObject.Kind == "ReplicaSet" && replicaSets[e.InvolvedObject.Name] {
+			involved = true
+		}
+		if !involved {
+			continue
+		}
+		key := strings.Join([]string{e.InvolvedObject.Kind, e.InvolvedObject.Name, e.Reason, e.Message}, "/")
+		if r.seen[key] {
+			continue
+		}
+		r.seen[key] = true
+		tflog.Warn(ctx, fmt.Sprintf("%s %s/%s: %s (%s): %s: %s", r.kind, r.object.Namespace, r.object.Name, e.InvolvedObject.Name, e.InvolvedObject.Kind, e.Reason, e.Message))
+		r.events = append(r.events, e)
+	}
+}
+
+func eventTimestamp(e api.Event) time.Time {
+	if !e.LastTimestamp.IsZero() {
+		return e.LastTimestamp.Time
+	}
+	if !e.EventTime.IsZero() {
+		return e.EventTime.Time
+	}
+	return e.CreationTimestamp.Time
+}
+
+// waitEventsDiagnostics turns the outcome of a wait into diagnostics. The warning
+// events seen during the wait are appended to the error, or reported as a
+// warning when the wait succeeded regardless.
+func waitEventsDiagnostics(err error, kind string, object metav1.ObjectMeta, events []api.Event) diag.Diagnostics {
+	if err != nil {
+		return diag.Errorf("%s%s", err, stringifyEvents(events))
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Warning events were reported while waiting for %s %s/%s", kind, object.Namespace, object.Name),
+		Detail:   strings.TrimPrefix(stringifyEvents(events), "\n"),
+	}}
+}
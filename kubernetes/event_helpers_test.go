@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	appsv1 "k8s.io/api/apps/v1"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testWaitEvent(name, kind, object, eventType, reason string, ts time.Time) *api.Event {
+	return &api.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		InvolvedObject: api.ObjectReference{
+			Kind:      kind,
+			Name:      object,
+			Namespace: "default",
+		},
+		Type:          eventType,
+		Reason:        reason,
+		Message:       reason + " message",
+		LastTimestamp: metav1.NewTime(ts),
+	}
+}
+
+func TestWaitEventReporter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	deployment := metav1.ObjectMeta{Name: "web", Namespace: "default"}
+
+	conn := fake.NewSimpleClientset(
+		&api.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+		&api.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", Labels: map[string]string{"app": "other"}}},
+		testWaitEvent("e1", "Pod", "web-abc", api.EventTypeWarning, "FailedScheduling", now.Add(time.Second)),
+		testWaitEvent("e2", "Deployment", "web", api.EventTypeWarning, "ReplicaFailure", now.Add(2*time.Second)),
+		testWaitEvent("e3", "Pod", "other", api.EventTypeWarning, "BackOff", now.Add(time.Second)),
+		testWaitEvent("e4", "Pod", "web-abc", api.EventTypeNormal, "Scheduled", now.Add(time.Second)),
+		testWaitEvent("e5", "Pod", "web-abc", api.EventTypeWarning, "Unhealthy", now.Add(-time.Hour)),
+	)
+
+	r := startWaitEventReporter(ctx, conn, deployment, "Deployment", &metav1.LabelSelector{
+		MatchLabels: map[string]string{"app": "web"},
+	})
+	// Collecting again must not report the same events twice.
+	r.collect(ctx)
+	events := r.Stop(ctx)
+
+	var reasons []string
+	for _, e := range events {
+		reasons = append(reasons, e.Reason)
+	}
+	if got := strings.Join(reasons, ","); got != "FailedScheduling,ReplicaFailure" {
+		t.Fatalf("unexpected events: %s", got)
+	}
+}
+
+func TestWaitEventReporterReplicaSets(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	controller := true
+	deployment := metav1.ObjectMeta{Name: "web", Namespace: "default"}
+	replicaSet := func(name, deploymentName string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			Labels:          map[string]string{"app": "web"},
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: deploymentName, Controller: &controller}},
+		}}
+	}
+
+	conn := fake.NewSimpleClientset(
+		replicaSet("web-7d9f8b6c5a", "web"),
+		replicaSet("web-canary-5c6b7d8e9f", "web-canary"),
+		testWaitEvent("e1", "ReplicaSet", "web-7d9f8b6c5a", api.EventTypeWarning, "FailedCreate", now.Add(time.Second)),
+		testWaitEvent("e2", "ReplicaSet", "web-canary-5c6b7d8e9f", api.EventTypeWarning, "FailedCreate", now.Add(time.Second)),
+	)
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+
+	events := startWaitEventReporter(ctx, conn, deployment, "Deployment", selector).Stop(ctx)
+	if len(events) != 1 || events[0].InvolvedObject.Name != "web-7d9f8b6c5a" {
+		t.Fatalf("expected the events of the replica set of the deployment only, got %#v", events)
+	}
+
+	// Only the replica sets of deployments are looked up.
+	events = startWaitEventReporter(ctx, conn, deployment, "StatefulSet", selector).Stop(ctx)
+	if len(events) != 0 {
+		t.Fatalf("expected no events, got %#v", events)
+	}
+}
+
+func TestWaitEventsDiagnostics(t *testing.T) {
+	object := metav1.ObjectMeta{Name: "web", Namespace: "default"}
+	events := []api.Event{*testWaitEvent("e1", "Pod", "web-abc", api.EventTypeWarning, "FailedScheduling", time.Now())}
+
+	if diags := waitEventsDiagnostics(nil, "Deployment", object, nil); len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %#v", diags)
+	}
+
+	diags := waitEventsDiagnostics(nil, "Deployment", object, events)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, "FailedScheduling") {
+		t.Fatalf("expected a warning with the events, got %#v", diags)
+	}
+
+	diags = waitEventsDiagnostics(errors.New("timeout"), "Deployment", object, events)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "timeout") || !strings.Contains(diags[0].Summary, "FailedScheduling") {
+		t.Fatalf("expected an error with the events, got %#v", diags)
+	}
+}
+
+func TestWaitEventReporterDiagnostics(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	statefulSet := metav1.ObjectMeta{Name: "db", Namespace: "default"}
+	pods := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}
+
+	conn := fake.NewSimpleClientset(
+		&api.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "default", Labels: map[string]string{"app": "db"}}},
+		testWaitEvent("e1", "Pod", "db-0", api.EventTypeWarning, "BackOff", now.Add(-10*time.Minute)),
+	)
+
+	// The pod was crash looping before the wait started.
+	diags := startWaitEventReporter(ctx, conn, statefulSet, "StatefulSet", pods).Diagnostics(ctx, errors.New("timeout"))
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "BackOff") {
+		t.Fatalf("expected an error with the earlier events, got %#v", diags)
+	}
+
+	// Earlier events aren't reported when the wait succeeded.
+	diags = startWaitEventReporter(ctx, conn, statefulSet, "StatefulSet", pods).Diagnostics(ctx, nil)
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %#v", diags)
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// withInvalidFieldDiagnostics replaces the errors of the objects the Kubernetes
+// API rejected as invalid with a diagnostic per rejected field, pointing to the
+// attribute of the resource the field is set with and describing it with the
+// description of the field in the OpenAPI document of the API.
+func withInvalidFieldDiagnostics(r *schema.Resource) {
+	r.CreateContext = explainInvalidFields(r, r.CreateContext)
+	r.UpdateContext = explainInvalidFields(r, r.UpdateContext)
+}
+
+func explainInvalidFields[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](r *schema.Resource, f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx, rejected := apierror.WithRecorder(ctx)
+		diags := f(ctx, d, meta)
+		if !diags.HasError() {
+			return diags
+		}
+		for _, rj := range rejected.Invalid() {
+			gvk := k8sschema.FromAPIVersionAndKind(rj.GroupVersion, rj.Details.Kind)
+			doc := openAPIDocument(ctx, meta, gvk.GroupVersion())
+			diags = invalidFieldDiagnostics(r.Schema, rj.Status, gvk, doc, diags)
+		}
+		return diags
+	}
+}
+
+// openAPIDocument returns the OpenAPI v3 document of a group version, or nil
+// when the API server doesn't publish it.
+func openAPIDocument(ctx context.Context, meta interface{}, gv k8sschema.GroupVersion) *apierror.OpenAPIDocument {
+	if gv.Version == "" {
+		return nil
+	}
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return nil
+	}
+	paths, err := conn.Discovery().OpenAPIV3().Paths()
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Failed to get the OpenAPI v3 documents of the API: %s", err))
+		return nil
+	}
+	path := "apis/" + gv.Group + "/" + gv.Version
+	if gv.Group == "" {
+		path = "api/" + gv.Version
+	}
+	p, ok := paths[path]
+	if !ok {
+		return nil
+	}
+	data, err := p.Schema(runtime.ContentTypeJSON)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Failed to get the OpenAPI v3 document of %s: %s", gv, err))
+		return nil
+	}
+	doc, err := apierror.ParseOpenAPIDocument(data)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Failed to parse the OpenAPI v3 document of %s: %s", gv, err))
+		return nil
+	}
+	return doc
+}
+
+// invalidFieldDiagnostics replaces the error diagnostics of diags reporting the
+// rejected object s of kind gvk with a diagnostic per cause, described with the
+// OpenAPI document doc when it isn't nil. diags are returned unchanged when none
+// of the causes can be mapped to an attribute.
+func invalidFieldDiagnostics(s map[string]*schema.Schema, status metav1.Status, gvk k8sschema.GroupVersionKind, doc *apierror.OpenAPIDocument, diags diag.Diagnostics) diag.Diagnostics {
+	lookup := func(field string) (apierror.Field, bool) {
+		if doc == nil {
+			return apierror.Field{}, false
+		}
+		return doc.Lookup(gvk, field)
+	}
+	var causes diag.Diagnostics
+	mapped := false
+	for _, c := range status.Details.Causes {
+		path, attr, name := attributePathForField(s, c.Field, lookup)
+		if attr == nil {
+			causes = append(causes, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Invalid %s %q", status.Details.Kind, status.Details.Name),
+				Detail:   strings.TrimSpace(fmt.Sprintf("%s: %s", c.Field, c.Message)),
+			})
+			continue
+		}
+		mapped = true
+		detail := fmt.Sprintf("The Kubernetes API rejected the field %s of %s %q: %s", c.Field, status.Details.Kind, status.Details.Name, c.Message)
+		description := attr.Description
+		if f, ok := lookup(c.Field); ok && f.Description != "" {
+			description = f.Description
+		}
+		if description != "" {
+			detail += fmt.Sprintf("\n\n%s: %s", name, description)
+		}
+		causes = append(causes, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid value for %s", name),
+			Detail:        detail,
+			AttributePath: path,
+		})
+	}
+	if !mapped {
+		return diags
+	}
+
+	var out diag.Diagnostics
+	for _, d := range diags {
+		if d.Severity == diag.Error && strings.Contains(d.Summary+d.Detail, status.Message) {
+			continue
+		}
+		out = append(out, d)
+	}
+	return append(out, causes...)
+}
+
+// attributePathForField maps the path of a field of a Kubernetes object, e.g.
+// spec.template.spec.containers[0].image, to the path of the attribute of the
+// resource it is set with, e.g. spec.0.template.0.spec.0.container.0.image.
+// The path is mapped as far as the schema allows. It returns the schema of the
+// deepest attribute found, or nil when the field doesn't match any attribute.
+// lookup returns the fields of the OpenAPI document of the object, if known.
+func attributePathForField(s map[string]*schema.Schema, field string, lookup func(string) (apierror.Field, bool)) (cty.Path, *schema.Schema, string) {
+	elements, ok := apierror.ParseFieldPath(field)
+	if !ok {
+		return nil, nil, ""
+	}
+	var path cty.Path
+	var names []string
+	var attr *schema.Schema
+	var prefix strings.Builder
+	for i := 0; i < len(elements); i++ {
+		e := elements[i]
+		if !e.IsName() || s == nil {
+			break
+		}
+		if prefix.Len() > 0 {
+			prefix.WriteByte('.')
+		}
+		prefix.WriteString(e.Name)
+		// Fields missing from the OpenAPI document, e.g. when it isn't
+		// available, may be lists.
+		list := true
+		if f, ok := lookup(prefix.String()); ok {
+			list = f.List
+		}
+		k, a := schemaKeyForField(s, e.Name, list)
+		if a == nil {
+			break
+		}
+		path = path.GetAttr(k)
+		names = append(names, k)
+		attr, s = a, nil
+
+		var next *apierror.FieldPathElement
+		if i+1 < len(elements) {
+			next = &elements[i+1]
+		}
+		switch a.Type {
+		case schema.TypeMap:
+			if next != nil && !next.IsName() && !next.IsIndex() {
+				path = path.IndexString(next.Key)
+				names = append(names, next.Key)
+			}
+			i = len(elements)
+		case schema.TypeList:
+			elem, ok := a.Elem.(*schema.Resource)
+			switch {
+			case next != nil && next.IsIndex():
+				path = path.IndexInt(next.Index)
+				names = append(names, strconv.Itoa(next.Index))
+				fmt.Fprintf(&prefix, "[%d]", next.Index)
+				i++
+			case ok:
+				// Objects are represented as blocks of a single element.
+				path = path.IndexInt(0)
+				names = append(names, "0")
+			}
+			if ok {
+				s = elem.Schema
+			}
+		}
+	}
+	return path, attr, strings.Join(names, ".")
+}
+
+// schemaKeyForField returns the attribute of the schema representing an API
+// field: the attribute of the same name, or when the field is a list, the list
+// or set block of the same name in the singular, as blocks are named.
+func schemaKeyForField(s map[string]*schema.Schema, field string, list bool) (string, *schema.Schema) {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if strings.EqualFold(snakeToCamel(k), field) {
+			return k, s[k]
+		}
+	}
+	if !list {
+		return "", nil
+	}
+	singulars := []string{strings.TrimSuffix(field, "s"), strings.TrimSuffix(field, "es")}
+	if strings.HasSuffix(field, "ies") {
+		singulars = append(singulars, strings.TrimSuffix(field, "ies")+"y")
+	}
+	for _, k := range keys {
+		a := s[k]
+		if a.Type != schema.TypeList && a.Type != schema.TypeSet {
+			continue
+		}
+		for _, singular := range singulars {
+			if singular != field && strings.EqualFold(snakeToCamel(k), singular) {
+				return k, a
+			}
+		}
+	}
+	return "", nil
+}
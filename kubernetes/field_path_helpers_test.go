@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var testDeploymentGVK = k8sschema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+func noOpenAPIFields(string) (apierror.Field, bool) {
+	return apierror.Field{}, false
+}
+
+func testDeploymentOpenAPIDocument(t *testing.T) *apierror.OpenAPIDocument {
+	doc, err := apierror.ParseOpenAPIDocument([]byte(`{"components": {"schemas": {
+		"io.k8s.api.apps.v1.Deployment": {
+			"type": "object",
+			"properties": {"spec": {"allOf": [{"$ref": "#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}]}},
+			"x-kubernetes-group-version-kind": [{"group": "apps", "version": "v1", "kind": "Deployment"}]
+		},
+		"io.k8s.api.apps.v1.DeploymentSpec": {
+			"type": "object",
+			"properties": {
+				"replicas": {"type": "integer", "description": "Number of desired pods."},
+				"selectors": {"type": "object"},
+				"template": {"$ref": "#/components/schemas/io.k8s.api.core.v1.PodTemplateSpec"}
+			}
+		},
+		"io.k8s.api.core.v1.PodTemplateSpec": {
+			"type": "object",
+			"properties": {"spec": {"$ref": "#/components/schemas/io.k8s.api.core.v1.PodSpec"}}
+		},
+		"io.k8s.api.core.v1.PodSpec": {
+			"type": "object",
+			"properties": {"containers": {"type": "array", "items": {"type": "object"}}}
+		}
+	}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestAttributePathForField(t *testing.T) {
+	deployment := resourceKubernetesDeploymentV1().Schema
+	configMap := resourceKubernetesConfigMapV1().Schema
+
+	for field, expected := range map[string]string{
+		"spec.replicas":                                    "spec.0.replicas",
+		"spec.template.spec.containers[0].image":           "spec.0.template.0.spec.0.container.0.image",
+		"spec.template.spec.containers[1].ports[0].hostIP": "spec.0.template.0.spec.0.container.1.port.0.host_ip",
+		"metadata.labels[app.kubernetes.io/name]":          "metadata.0.labels.app.kubernetes.io/name",
+		"spec.template.spec.unknownField":                  "spec.0.template.0.spec.0",
+		"status.replicas":                                  "",
+	} {
+		t.Run(field, func(t *testing.T) {
+			_, attr, name := attributePathForField(deployment, field, noOpenAPIFields)
+			if name != expected {
+				t.Fatalf("expected %q, got %q", expected, name)
+			}
+			if (attr == nil) != (expected == "") {
+				t.Fatalf("unexpected attribute %#v", attr)
+			}
+		})
+	}
+
+	path, _, _ := attributePathForField(configMap, "data[config.yaml]", noOpenAPIFields)
+	expected := cty.GetAttrPath("data").IndexString("config.yaml")
+	if !path.Equals(expected) {
+		t.Fatalf("expected %#v, got %#v", expected, path)
+	}
+}
+
+func TestAttributePathForFieldOpenAPI(t *testing.T) {
+	deployment := resourceKubernetesDeploymentV1().Schema
+	lookup := func(field string) (apierror.Field, bool) {
+		return testDeploymentOpenAPIDocument(t).Lookup(testDeploymentGVK, field)
+	}
+
+	// The OpenAPI document says spec.template.spec.containers is a list, so
+	// it maps to the container block.
+	if _, _, name := attributePathForField(deployment, "spec.template.spec.containers[0].image", lookup); name != "spec.0.template.0.spec.0.container.0.image" {
+		t.Fatalf("unexpected attribute %q", name)
+	}
+	// The OpenAPI document says spec.selector isn't a list, so it isn't
+	// matched with the blocks named in the singular.
+	if _, _, name := attributePathForField(map[string]*schema.Schema{
+		"spec": {Type: schema.TypeList, Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"selecto": {Type: schema.TypeList, Elem: &schema.Resource{}},
+		}}},
+	}, "spec.selectors", lookup); name != "spec.0" {
+		t.Fatalf("unexpected attribute %q", name)
+	}
+}
+
+func TestSchemaKeyForField(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"policy":   {Type: schema.TypeList},
+		"address":  {Type: schema.TypeList},
+		"port":     {Type: schema.TypeList},
+		"replicas": {Type: schema.TypeInt},
+		"name":     {Type: schema.TypeString},
+	}
+	for _, tc := range []struct {
+		field    string
+		list     bool
+		expected string
+	}{
+		{"replicas", false, "replicas"},
+		{"ports", true, "port"},
+		{"ports", false, ""},
+		{"addresses", true, "address"},
+		{"policies", true, "policy"},
+		{"names", true, ""},
+	} {
+		if k, _ := schemaKeyForField(s, tc.field, tc.list); k != tc.expected {
+			t.Errorf("%s (list %t): expected %q, got %q", tc.field, tc.list, tc.expected, k)
+		}
+	}
+}
+
+func TestInvalidFieldDiagnostics(t *testing.T) {
+	status := metav1.Status{
+		Message: `Deployment.apps "web" is invalid: spec.replicas: Invalid value: -1: must be greater than or equal to 0`,
+		Details: &metav1.StatusDetails{
+			Name: "web",
+			Kind: "Deployment",
+			Causes: []metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "Invalid value: -1: must be greater than or equal to 0",
+				Field:   "spec.replicas",
+			}},
+		},
+	}
+	diags := diag.Diagnostics{
+		{Severity: diag.Warning, Summary: "Kubernetes API warning"},
+		{Severity: diag.Error, Summary: "Failed to create deployment: " + status.Message},
+	}
+
+	got := invalidFieldDiagnostics(resourceKubernetesDeploymentV1().Schema, status, testDeploymentGVK, nil, diags)
+	if len(got) != 2 || got[0].Severity != diag.Warning {
+		t.Fatalf("expected the warning and the invalid field, got %#v", got)
+	}
+	if got[1].Summary != "Invalid value for spec.0.replicas" {
+		t.Fatalf("unexpected summary %q", got[1].Summary)
+	}
+	if !got[1].AttributePath.Equals(cty.GetAttrPath("spec").IndexInt(0).GetAttr("replicas")) {
+		t.Fatalf("unexpected attribute path %#v", got[1].AttributePath)
+	}
+
+	got = invalidFieldDiagnostics(resourceKubernetesDeploymentV1().Schema, status, testDeploymentGVK, testDeploymentOpenAPIDocument(t), diags)
+	if !strings.HasSuffix(got[1].Detail, "spec.0.replicas: Number of desired pods.") {
+		t.Fatalf("expected the OpenAPI description of the field, got %q", got[1].Detail)
+	}
+
+	status.Details.Causes[0].Field = "status.replicas"
+	if got := invalidFieldDiagnostics(resourceKubernetesDeploymentV1().Schema, status, testDeploymentGVK, nil, diags); len(got) != len(diags) || got[1].Summary != diags[1].Summary {
+		t.Fatalf("expected the diagnostics to be left unchanged, got %#v", got)
+	}
+}
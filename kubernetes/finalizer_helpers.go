@@ -0,0 +1,313 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+func stuckDeletionSchema(forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Inspect the finalizers of the object when its deletion doesn't complete in time, and optionally remove some of them so that the destroy can complete.",
+		Optional:    true,
+		ForceNew:    forceNew,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"after": {
+					Type:         schema.TypeString,
+					Description:  "How long to wait for the deletion to complete before the remaining finalizers are inspected, e.g. `2m`.",
+					Optional:     true,
+					ForceNew:     forceNew,
+					Default:      "5m",
+					ValidateFunc: validateWaitDuration,
+				},
+				"remove_finalizers": {
+					Type:        schema.TypeList,
+					Description: "Finalizers which are removed from the object when its deletion hasn't completed in time. This skips the cleanup of the controllers responsible for them, so only list finalizers whose controller is known to be gone.",
+					Optional:    true,
+					ForceNew:    forceNew,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// withStuckDeletionBlock adds the stuck_deletion block to a structured resource
+// and watches over the deletion of the object when it is configured.
+func withStuckDeletionBlock(r *schema.Resource, wr waitableResource) {
+	if r.DeleteContext == nil {
+		return
+	}
+	r.Schema["stuck_deletion"] = stuckDeletionSchema(r.UpdateContext == nil)
+
+	del := r.DeleteContext
+	r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		sd, err := expandStuckDeletion(d.Get("stuck_deletion").([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if sd == nil {
+			return del(ctx, d, meta)
+		}
+
+		conn, err := meta.(KubeClientsets).DynamicClient()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		namespace := ""
+		if wr.Namespaced {
+			namespace = d.Get("metadata.0.namespace").(string)
+		}
+		sd.client = conn.Resource(wr.GroupVersionResource).Namespace(namespace)
+		sd.name = d.Get("metadata.0.name").(string)
+		sd.kind = wr.GroupVersionResource.Resource
+
+		id := d.Id()
+		start := time.Now()
+		timer := time.AfterFunc(sd.After, func() { sd.inspect(ctx) })
+
+		diags := del(ctx, d, meta)
+		if !diags.HasError() {
+			// Not every resource waits for the object to be gone, finalizers
+			// would otherwise go unnoticed.
+			err := waitForObjectDeletion(ctx, sd.client, fmt.Sprintf("%s %q to be deleted", sd.kind, id), sd.name, d.Timeout(schema.TimeoutDelete)-time.Since(start))
+			if err != nil {
+				diags = append(diags, diag.Errorf("Failed waiting for %s %q to be deleted: %s", sd.kind, id, err)...)
+				// Keep the object in the state, it still exists.
+				d.SetId(id)
+			}
+		}
+		if !timer.Stop() {
+			<-sd.done
+		}
+		return append(diags, sd.diagnostics(ctx, diags.HasError())...)
+	}
+}
+
+type stuckDeletion struct {
+	After            time.Duration
+	RemoveFinalizers []string
+
+	client dynamic.ResourceInterface
+	kind   string
+	name   string
+
+	done      chan struct{}
+	inspected bool
+	pending   []string
+	removed   []string
+	err       error
+}
+
+func expandStuckDeletion(l []interface{}) (*stuckDeletion, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+	in := l[0].(map[string]interface{})
+	sd := &stuckDeletion{After: 5 * time.Minute, done: make(chan struct{})}
+
+	if v, ok := in["after"].(string); ok && v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, err
+		}
+		sd.After = d
+	}
+	if v, ok := in["remove_finalizers"].([]interface{}); ok {
+		sd.RemoveFinalizers = expandStringSlice(v)
+	}
+	return sd, nil
+}
+
+// inspect records the finalizers of an object whose deletion hasn't completed
+// and removes the ones listed in the stuck_deletion block.
+func (sd *stuckDeletion) inspect(ctx context.Context) {
+	defer close(sd.done)
+
+	obj, err := sd.client.Get(ctx, sd.name, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			tflog.Warn(ctx, fmt.Sprintf("Failed to inspect the deletion of %s %q: %s", sd.kind, sd.name, err))
+		}
+		return
+	}
+	sd.inspected = true
+	sd.pending = obj.GetFinalizers()
+	tflog.Warn(ctx, fmt.Sprintf("Deletion of %s %q hasn't completed after %s, remaining finalizers: %s", sd.kind, sd.name, sd.After, strings.Join(sd.pending, ", ")))
+
+	if len(sd.RemoveFinalizers) == 0 {
+		return
+	}
+	sd.removed, sd.err = removeFinalizers(ctx, sd.client, obj, sd.RemoveFinalizers)
+	if sd.err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Failed to remove finalizers from %s %q: %s", sd.kind, sd.name, sd.err))
+		return
+	}
+	if len(sd.removed) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("Removed finalizers %s from %s %q", strings.Join(sd.removed, ", "), sd.kind, sd.name))
+	}
+}
+
+// diagnostics reports the finalizers found when the deletion got stuck. When
+// the deletion failed the object is fetched again, so that the diagnostic
+// describes the finalizers which are still blocking it.
+func (sd *stuckDeletion) diagnostics(ctx context.Context, failed bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if sd.err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Failed to remove finalizers from %s %q", sd.kind, sd.name),
+			Detail:   sd.err.Error(),
+		})
+	}
+	if len(sd.removed) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Removed finalizers from %s %q", sd.kind, sd.name),
+			Detail:   fmt.Sprintf("The deletion didn't complete within %s, the finalizers %s were removed without waiting for their controllers.", sd.After, strings.Join(sd.removed, ", ")),
+		})
+	}
+
+	if failed {
+		obj, err := sd.client.Get(ctx, sd.name, metav1.GetOptions{})
+		if err != nil {
+			return diags
+		}
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s %q is stuck in deletion", sd.kind, sd.name),
+			Detail:   describeStuckDeletion(obj),
+		})
+	}
+	if sd.inspected && len(sd.removed) == 0 && len(sd.pending) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Deletion of %s %q was slow", sd.kind, sd.name),
+			Detail:   fmt.Sprintf("The deletion didn't complete within %s while waiting on the finalizers %s.", sd.After, strings.Join(sd.pending, ", ")),
+		})
+	}
+	return diags
+}
+
+func describeStuckDeletion(obj *unstructured.Unstructured) string {
+	var b strings.Builder
+	if finalizers := obj.GetFinalizers(); len(finalizers) > 0 {
+		fmt.Fprintf(&b, "The object still has the finalizers %s. ", strings.Join(finalizers, ", "))
+		b.WriteString("The controllers responsible for them may be missing or failing. Once it is safe to skip their cleanup, they can be listed in stuck_deletion.remove_finalizers.")
+	} else {
+		b.WriteString("The object has no finalizers left.")
+	}
+	// Namespaces report the content which keeps them from being deleted.
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		m, ok := c.(map[string]interface{})
+		if !ok || m["status"] != "True" || m["message"] == nil {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s: %s", m["type"], m["message"])
+	}
+	return b.String()
+}
+
+// removeFinalizers removes the given finalizers from the object and returns the
+// ones which were actually present.
+func removeFinalizers(ctx context.Context, client dynamic.ResourceInterface, obj *unstructured.Unstructured, remove []string) ([]string, error) {
+	var removed []string
+	err := retry.RetryContext(ctx, time.Minute, func() *retry.RetryError {
+		current := obj.GetFinalizers()
+		kept := []string{}
+		removed = nil
+		for _, f := range current {
+			if slices.Contains(remove, f) {
+				removed = append(removed, f)
+				continue
+			}
+			kept = append(kept, f)
+		}
+		if len(removed) == 0 {
+			return nil
+		}
+
+		ops := PatchOperations{
+			&TestOperation{Path: "/metadata/finalizers", Value: current},
+			&ReplaceOperation{Path: "/metadata/finalizers", Value: kept},
+		}
+		data, err := json.Marshal(ops)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		_, err = client.Patch(ctx, obj.GetName(), types.JSONPatchType, data, metav1.PatchOptions{})
+		if err == nil {
+			return nil
+		}
+		if errors.IsNotFound(err) {
+			removed = nil
+			return nil
+		}
+		if !errors.IsInvalid(err) && !errors.IsConflict(err) {
+			return retry.NonRetryableError(err)
+		}
+		// The finalizers changed in the meantime.
+		obj, err = client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				removed = nil
+				return nil
+			}
+			return retry.NonRetryableError(err)
+		}
+		return retry.RetryableError(fmt.Errorf("finalizers of %q changed while removing them", obj.GetName()))
+	})
+	return removed, err
+}
+
+// waitForObjectDeletion waits until the object is gone, reporting the
+// finalizers it is waiting for as the progress of waiting for subject.
+func waitForObjectDeletion(ctx context.Context, client dynamic.ResourceInterface, subject, name string, timeout time.Duration) error {
+	if timeout < time.Second {
+		timeout = time.Second
+	}
+	progress := startWaitProgress(ctx, subject)
+	defer progress.Stop()
+
+	stateConf := &retry.StateChangeConf{
+		Target:  []string{},
+		Pending: []string{"Terminating"},
+		Timeout: timeout,
+		Refresh: func() (interface{}, string, error) {
+			obj, err := client.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				if errors.IsNotFound(err) {
+					return nil, "", nil
+				}
+				return nil, "", err
+			}
+			progress.Update(ctx, deletionProgress(metav1.ObjectMeta{
+				DeletionTimestamp: obj.GetDeletionTimestamp(),
+				Finalizers:        obj.GetFinalizers(),
+			}))
+			return obj, "Terminating", nil
+		},
+	}
+	_, err := stateConf.WaitForStateContext(ctx)
+	return err
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func testStuckConfigMap(finalizers ...interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":       "test",
+			"namespace":  "default",
+			"finalizers": finalizers,
+		},
+	}}
+}
+
+func TestRemoveFinalizers(t *testing.T) {
+	ctx := context.Background()
+	gvr := k8sschema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	obj := testStuckConfigMap("example.com/a", "example.com/b", "example.com/c")
+	conn := fake.NewSimpleDynamicClient(runtime.NewScheme(), obj)
+	client := conn.Resource(gvr).Namespace("default")
+
+	removed, err := removeFinalizers(ctx, client, obj, []string{"example.com/b", "example.com/missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(removed, []string{"example.com/b"}) {
+		t.Fatalf("unexpected removed finalizers: %#v", removed)
+	}
+
+	out, err := client.Get(ctx, "test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out.GetFinalizers(), []string{"example.com/a", "example.com/c"}) {
+		t.Fatalf("unexpected finalizers: %#v", out.GetFinalizers())
+	}
+}
+
+func TestStuckDeletionDiagnostics(t *testing.T) {
+	ctx := context.Background()
+	gvr := k8sschema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	conn := fake.NewSimpleDynamicClient(runtime.NewScheme(), testStuckConfigMap("example.com/a", "example.com/b"))
+
+	sd, err := expandStuckDeletion([]interface{}{
+		map[string]interface{}{
+			"after":             "1m",
+			"remove_finalizers": []interface{}{"example.com/a"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sd.After != time.Minute {
+		t.Fatalf("unexpected duration: %s", sd.After)
+	}
+	sd.client = conn.Resource(gvr).Namespace("default")
+	sd.kind = gvr.Resource
+	sd.name = "test"
+
+	sd.inspect(ctx)
+	if !reflect.DeepEqual(sd.pending, []string{"example.com/a", "example.com/b"}) {
+		t.Fatalf("unexpected pending finalizers: %#v", sd.pending)
+	}
+
+	diags := sd.diagnostics(ctx, true)
+	if len(diags) != 2 {
+		t.Fatalf("expected two diagnostics, got %#v", diags)
+	}
+	if diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, "example.com/a") {
+		t.Fatalf("expected a warning about the removed finalizer, got %#v", diags[0])
+	}
+	if diags[1].Severity != diag.Error || !strings.Contains(diags[1].Detail, "example.com/b") || strings.Contains(diags[1].Detail, "example.com/a") {
+		t.Fatalf("expected an error about the remaining finalizer, got %#v", diags[1])
+	}
+}
+
+func TestDescribeStuckDeletionNamespace(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":     "Namespace",
+		"metadata": map[string]interface{}{"name": "test"},
+		"status": map[string]interface{}{
+			"phase": "Terminating",
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "NamespaceDeletionDiscoveryFailure", "status": "False", "message": "All resources successfully discovered"},
+				map[string]interface{}{"type": "NamespaceFinalizersRemaining", "status": "True", "message": "Some content in the namespace has finalizers remaining: example.com/a in 1 resource instances"},
+			},
+		},
+	}}
+	expected := "The object has no finalizers left.\nNamespaceFinalizersRemaining: Some content in the namespace has finalizers remaining: example.com/a in 1 resource instances"
+	if got := describeStuckDeletion(obj); got != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// hashedValuePrefix prefixes the values of the data of config maps and secrets
+// stored in the state as their hash.
+const hashedValuePrefix = "sha256:"
+
+func stateHashThresholdSchema(objectName string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  fmt.Sprintf("The values of `data` and `binary_data` larger than this number of bytes are stored in the Terraform state as their SHA-256 hash, prefixed with `sha256:`, instead of in full. Changes to the values in the configuration are detected by comparing their hash. Use it to keep the state and the plans of a %s with multi-megabyte values manageable. Defaults to 0, which stores all the values in full.", objectName),
+	}
+}
+
+func hashedValue(v string) string {
+	sum := sha256.Sum256([]byte(v))
+	return hashedValuePrefix + hex.EncodeToString(sum[:])
+}
+
+func isHashedValue(v string) bool {
+	return strings.HasPrefix(v, hashedValuePrefix) && len(v) == len(hashedValuePrefix)+2*sha256.Size
+}
+
+// hashLargeValues returns a copy of m where the values larger than threshold
+// bytes are replaced by their hash. A threshold of 0 disables the hashing.
+func hashLargeValues(m map[string]string, threshold int) map[string]string {
+	if threshold <= 0 {
+		return m
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		if len(v) > threshold {
+			v = hashedValue(v)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// suppressHashedValueDiff suppresses the diff of a value of a map when the
+// state holds the hash of the value in the configuration.
+func suppressHashedValueDiff(k, old, new string, d *schema.ResourceData) bool {
+	return isHashedValue(old) && hashedValue(new) == old
+}
+
+func hasHashedValues(m map[string]interface{}) bool {
+	for _, v := range m {
+		if isHashedValue(v.(string)) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveHashedValues returns a copy of m where the hashes are replaced by the
+// values of live they are the hash of, for the updates which send the whole
+// map to the API server.
+func resolveHashedValues(m map[string]interface{}, live map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if lv, ok := live[k]; ok && isHashedValue(v.(string)) && hashedValue(lv) == v {
+			v = lv
+		}
+		out[k] = v
+	}
+	return out
+}
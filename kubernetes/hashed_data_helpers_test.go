@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHashLargeValues(t *testing.T) {
+	large := strings.Repeat("x", 1025)
+	m := map[string]string{
+		"small": "value",
+		"large": large,
+	}
+
+	if got := hashLargeValues(m, 0); !reflect.DeepEqual(got, m) {
+		t.Fatalf("expected the values to be kept with a threshold of 0, got %v", got)
+	}
+
+	got := hashLargeValues(m, 1024)
+	if got["small"] != "value" {
+		t.Fatalf("expected the small value to be kept, got %q", got["small"])
+	}
+	if !isHashedValue(got["large"]) {
+		t.Fatalf("expected the large value to be hashed, got %q", got["large"])
+	}
+	if m["large"] != large {
+		t.Fatal("expected the original map to be left unchanged")
+	}
+}
+
+func TestSuppressHashedValueDiff(t *testing.T) {
+	cases := map[string]struct {
+		old, new string
+		suppress bool
+	}{
+		"SameHash":        {hashedValue("payload"), "payload", true},
+		"ChangedValue":    {hashedValue("payload"), "changed", false},
+		"FullValue":       {"payload", "changed", false},
+		"LooksLikeHash":   {"sha256:abc", "payload", false},
+		"ValueNowRemoved": {hashedValue("payload"), "", false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := suppressHashedValueDiff("data.key", tc.old, tc.new, nil); got != tc.suppress {
+				t.Fatalf("expected %t, got %t", tc.suppress, got)
+			}
+		})
+	}
+}
+
+func TestResolveHashedValues(t *testing.T) {
+	m := map[string]interface{}{
+		"hashed":  hashedValue("live"),
+		"stale":   hashedValue("old"),
+		"changed": "new",
+	}
+	live := map[string]string{
+		"hashed":  "live",
+		"stale":   "live",
+		"changed": "live",
+	}
+	if !hasHashedValues(m) {
+		t.Fatal("expected the map to have hashed values")
+	}
+	expected := map[string]interface{}{
+		"hashed":  "live",
+		"stale":   hashedValue("old"),
+		"changed": "new",
+	}
+	if got := resolveHashedValues(m, live); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
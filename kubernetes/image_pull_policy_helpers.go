@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	v1 "k8s.io/api/core/v1"
+)
+
+// defaultImagePullPolicy returns the image pull policy the API server defaults
+// a container with the given image to.
+func defaultImagePullPolicy(image string) string {
+	// Images referenced by digest are never pulled again.
+	if strings.Contains(image, "@") {
+		return string(v1.PullIfNotPresent)
+	}
+	tag := ""
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		tag = image[i+1:]
+	}
+	if tag == "" || tag == "latest" {
+		return string(v1.PullAlways)
+	}
+	return string(v1.PullIfNotPresent)
+}
+
+// withDefaultedImagePullPolicy lets the API server default the image pull policy
+// of the containers of a resource managing pods again when their image changes
+// and the policy isn't configured. Otherwise the policy the previous image was
+// defaulted to would be sent along with the new image.
+func withDefaultedImagePullPolicy(r *schema.Resource, specPath []string) {
+	if r.UpdateContext == nil {
+		return
+	}
+	update := r.UpdateContext
+	r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		o, n := d.GetChange(specPath[0])
+		prior, _ := o.([]interface{})
+		current, _ := n.([]interface{})
+		if clearDefaultedImagePullPolicies(podSpecAt(prior, specPath[1:]), podSpecAt(current, specPath[1:]), podSpecConfigAt(d.GetRawConfig(), specPath)) {
+			if err := d.Set(specPath[0], current); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		return update(ctx, d, meta)
+	}
+}
+
+// clearDefaultedImagePullPolicies clears the image pull policy of the containers
+// of the pod spec current whose image differs from prior and whose policy isn't
+// set in the configuration of the pod spec. Containers are matched by name, as
+// their order may differ between the configuration and the live object. It
+// reports whether anything was cleared.
+func clearDefaultedImagePullPolicies(prior, current map[string]interface{}, config cty.Value) bool {
+	if prior == nil || current == nil || config.IsNull() || !config.IsKnown() {
+		return false
+	}
+	cleared := false
+	for _, k := range []string{"init_container", "container"} {
+		priorImages := make(map[string]interface{})
+		if l, ok := prior[k].([]interface{}); ok {
+			for _, c := range l {
+				if m, ok := c.(map[string]interface{}); ok {
+					priorImages[fmt.Sprintf("%v", m["name"])] = m["image"]
+				}
+			}
+		}
+		configured := imagePullPolicyConfigured(config, k)
+		l, _ := current[k].([]interface{})
+		for _, c := range l {
+			m, ok := c.(map[string]interface{})
+			if !ok || m["image_pull_policy"] == "" {
+				continue
+			}
+			name := fmt.Sprintf("%v", m["name"])
+			image, ok := priorImages[name]
+			if !ok || image == m["image"] {
+				continue
+			}
+			if policyConfigured, ok := configured[name]; !ok || policyConfigured {
+				continue
+			}
+			m["image_pull_policy"] = ""
+			cleared = true
+		}
+	}
+	return cleared
+}
+
+// imagePullPolicyConfigured reports, for each container of the given kind in the
+// configuration of a pod spec by name, whether its image pull policy is
+// configured. Unknown policies count as configured, containers whose name isn't
+// known are left out.
+func imagePullPolicyConfigured(config cty.Value, kind string) map[string]bool {
+	l := config.GetAttr(kind)
+	if l.IsNull() || !l.IsKnown() {
+		return nil
+	}
+	out := make(map[string]bool)
+	for it := l.ElementIterator(); it.Next(); {
+		_, c := it.Element()
+		if c.IsNull() || !c.IsKnown() {
+			continue
+		}
+		name := c.GetAttr("name")
+		if name.IsNull() || !name.IsKnown() {
+			continue
+		}
+		out[name.AsString()] = !c.GetAttr("image_pull_policy").IsNull()
+	}
+	return out
+}
+
+// podSpecConfigAt follows the path from the raw configuration of a resource to
+// the configuration of its pod spec.
+func podSpecConfigAt(v cty.Value, path []string) cty.Value {
+	for _, step := range path {
+		if v.IsNull() || !v.IsKnown() {
+			return cty.NilVal
+		}
+		v = v.GetAttr(step)
+		if v.IsNull() || !v.IsKnown() || v.LengthInt() == 0 {
+			return cty.NilVal
+		}
+		v = v.Index(cty.NumberIntVal(0))
+	}
+	return v
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestDefaultImagePullPolicy(t *testing.T) {
+	cases := map[string]string{
+		"nginx":                             "Always",
+		"nginx:latest":                      "Always",
+		"nginx:1.25":                        "IfNotPresent",
+		"registry:5000/nginx":               "Always",
+		"registry:5000/nginx:1.25":          "IfNotPresent",
+		"nginx@sha256:0123456789abcdef":     "IfNotPresent",
+		"nginx:latest@sha256:0123456789abc": "IfNotPresent",
+	}
+	for image, expected := range cases {
+		if got := defaultImagePullPolicy(image); got != expected {
+			t.Errorf("%s: expected %s, got %s", image, expected, got)
+		}
+	}
+}
+
+func TestClearDefaultedImagePullPolicies(t *testing.T) {
+	prior := map[string]interface{}{
+		"container": []interface{}{
+			map[string]interface{}{"name": "app", "image": "nginx", "image_pull_policy": "Always"},
+			map[string]interface{}{"name": "sidecar", "image": "envoy", "image_pull_policy": "Always"},
+			map[string]interface{}{"name": "pinned", "image": "redis", "image_pull_policy": "Always"},
+		},
+	}
+	current := map[string]interface{}{
+		"container": []interface{}{
+			map[string]interface{}{"name": "app", "image": "nginx:1.25", "image_pull_policy": "Always"},
+			map[string]interface{}{"name": "sidecar", "image": "envoy", "image_pull_policy": "Always"},
+			map[string]interface{}{"name": "pinned", "image": "redis:7", "image_pull_policy": "Always"},
+		},
+	}
+	container := func(name string, policy cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal(name), "image_pull_policy": policy})
+	}
+	// The containers are configured in a different order than the one of the
+	// live object.
+	config := cty.ObjectVal(map[string]cty.Value{
+		"init_container": cty.NullVal(cty.List(cty.Object(map[string]cty.Type{"name": cty.String, "image_pull_policy": cty.String}))),
+		"container": cty.ListVal([]cty.Value{
+			container("pinned", cty.StringVal("Always")),
+			container("app", cty.NullVal(cty.String)),
+			container("sidecar", cty.NullVal(cty.String)),
+		}),
+	})
+
+	if !clearDefaultedImagePullPolicies(prior, current, config) {
+		t.Fatal("expected image pull policies to be cleared")
+	}
+	for i, expected := range []string{"", "Always", "Always"} {
+		got := current["container"].([]interface{})[i].(map[string]interface{})["image_pull_policy"]
+		if got != expected {
+			t.Errorf("container %d: expected %q, got %q", i, expected, got)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// withImportedDefaults sets the top-level attributes of a resource which have a
+// default value to this value when the resource is imported.
+//
+// Attributes that only control the provider, e.g. wait_for_rollout, aren't part
+// of the object read from the cluster and would otherwise be null in the state of
+// an imported resource, so that the first plan would show a change of each of them
+// to its default even when the configuration matches the object. Attributes of the
+// object are set again by the read following the import.
+//
+// Nested blocks aren't walked: they don't exist in the state yet when the
+// resource is imported, and those of the object are set by the read with the
+// values of the object. Blocks which only control the provider, e.g.
+// stuck_deletion, are therefore added by the plan following the import when
+// they are configured.
+func withImportedDefaults(r *schema.Resource) {
+	if r.Importer == nil {
+		return
+	}
+	importState := r.Importer.StateContext
+	if importState == nil {
+		importState = schema.ImportStatePassthroughContext
+	}
+	r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		if err := setImportedDefaults(d, r.Schema); err != nil {
+			return nil, err
+		}
+		return importState(ctx, d, meta)
+	}
+}
+
+func setImportedDefaults(d *schema.ResourceData, s map[string]*schema.Schema) error {
+	for k, sch := range s {
+		if sch.Computed || (sch.Default == nil && sch.DefaultFunc == nil) {
+			continue
+		}
+		v, err := sch.DefaultValue()
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			continue
+		}
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestWithImportedDefaults(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"wait_for_rollout": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"timeout": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_TEST_IMPORT_TIMEOUT", "5m"),
+			},
+			"dry_run": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"stuck_deletion": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"after": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "5m",
+						},
+					},
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+	withImportedDefaults(r)
+
+	d := r.Data(nil)
+	d.SetId("default/test")
+	imported, err := r.Importer.StateContext(context.Background(), d, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := imported[0].State()
+	expected := map[string]string{
+		"id":               "default/test",
+		"wait_for_rollout": "true",
+		"timeout":          "5m",
+	}
+	for k, v := range expected {
+		if state.Attributes[k] != v {
+			t.Errorf("Expected %s to be %q, got %q", k, v, state.Attributes[k])
+		}
+	}
+	// The defaults of nested blocks aren't set, as the blocks don't exist yet.
+	for _, k := range []string{"name", "dry_run", "stuck_deletion.#", "stuck_deletion.0.after"} {
+		if _, ok := state.Attributes[k]; ok {
+			t.Errorf("Expected %s not to be set, got %q", k, state.Attributes[k])
+		}
+	}
+}
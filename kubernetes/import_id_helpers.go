@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-kubernetes/util"
+)
+
+// withImportIDNormalization checks the import ID of a structured resource before
+// it is imported, so that an ID in the wrong format fails with an explanation of
+// the expected one. IDs in the formats of kubernetes_manifest are accepted too.
+func withImportIDNormalization(r *schema.Resource, wr waitableResource) {
+	if r.Importer == nil {
+		return
+	}
+	importState := r.Importer.StateContext
+	if importState == nil {
+		importState = schema.ImportStatePassthroughContext
+	}
+	r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		id, err := normalizeImportID(d.Id(), wr)
+		if err != nil {
+			return nil, err
+		}
+		d.SetId(id)
+		return importState(ctx, d, meta)
+	}
+}
+
+// normalizeImportID returns the ID of a structured resource, "<namespace>/<name>"
+// for namespaced objects and "<name>" for cluster-scoped ones, for an import ID
+// in this format or in one of the formats of kubernetes_manifest.
+func normalizeImportID(id string, wr waitableResource) (string, error) {
+	id = strings.TrimSpace(id)
+	parts := strings.Split(id, "/")
+	if strings.Contains(id, "=") || len(parts) > 2 {
+		_, name, namespace, err := util.ParseResourceID(id)
+		if err != nil {
+			return "", err
+		}
+		parts = []string{namespace, name}
+		if !wr.Namespaced {
+			parts = []string{name}
+		}
+	}
+	for _, p := range parts {
+		if p == "" {
+			return "", fmt.Errorf("Unexpected import ID %q: the ID must not contain empty parts", id)
+		}
+	}
+
+	kind := wr.GroupVersionResource.Resource
+	switch {
+	case wr.Namespaced && len(parts) != 2:
+		return "", fmt.Errorf(`Unexpected import ID %q: %s are namespaced, the ID must be in the "<namespace>/<name>" format, e.g. "default/%s"`, id, kind, parts[0])
+	case !wr.Namespaced && len(parts) != 1:
+		return "", fmt.Errorf(`Unexpected import ID %q: %s are cluster-scoped, the ID must be the name of the object, e.g. %q`, id, kind, parts[len(parts)-1])
+	}
+	return strings.Join(parts, "/"), nil
+}
@@ -0,0 +1,49 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"testing"
+)
+
+func TestNormalizeImportID(t *testing.T) {
+	configMaps := namespacedWaitable("", "v1", "configmaps")
+	namespaces := clusterWaitable("", "v1", "namespaces")
+
+	cases := []struct {
+		ID       string
+		Resource waitableResource
+		Expected string
+		Error    string
+	}{
+		{"test/app", configMaps, "test/app", ""},
+		{" test/app\n", configMaps, "test/app", ""},
+		{"apiVersion=v1,kind=ConfigMap,namespace=test,name=app", configMaps, "test/app", ""},
+		{"v1/ConfigMap/test/app", configMaps, "test/app", ""},
+		{"v1/ConfigMap/app", configMaps, "default/app", ""},
+		{"app", configMaps, "", `Unexpected import ID "app": configmaps are namespaced, the ID must be in the "<namespace>/<name>" format, e.g. "default/app"`},
+		{"test/", configMaps, "", `Unexpected import ID "test/": the ID must not contain empty parts`},
+		{"test", namespaces, "test", ""},
+		{"apiVersion=v1,kind=Namespace,name=test", namespaces, "test", ""},
+		{"v1/Namespace/test", namespaces, "test", ""},
+		{"default/test", namespaces, "", `Unexpected import ID "default/test": namespaces are cluster-scoped, the ID must be the name of the object, e.g. "test"`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.ID, func(t *testing.T) {
+			id, err := normalizeImportID(tc.ID, tc.Resource)
+			if tc.Error != "" {
+				if err == nil || err.Error() != tc.Error {
+					t.Fatalf("expected error %q, got %v", tc.Error, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if id != tc.Expected {
+				t.Fatalf("expected %q, got %q", tc.Expected, id)
+			}
+		})
+	}
+}
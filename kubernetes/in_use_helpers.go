@@ -0,0 +1,226 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxListedDependents is the number of dependents listed in the error of a
+// destroy prevented by them, the others are only counted.
+const maxListedDependents = 10
+
+// inUseKind is a kind of object which other objects depend on while in use.
+type inUseKind struct {
+	// Name is the name of the kind in messages, e.g. "secret".
+	Name string
+	// Namespaced is whether the objects of the kind are namespaced.
+	Namespaced bool
+	// DependentsName is the name of the objects which use them, e.g. "pods".
+	DependentsName string
+	// Dependents lists the objects using the named object.
+	Dependents func(ctx context.Context, conn kubernetes.Interface, namespace, name string) ([]string, error)
+}
+
+var (
+	secretInUse = inUseKind{
+		Name:           "secret",
+		Namespaced:     true,
+		DependentsName: "pods",
+		Dependents: func(ctx context.Context, conn kubernetes.Interface, namespace, name string) ([]string, error) {
+			return podsReferencing(ctx, conn, namespace, func(r podReferences) []string { return r.Secrets }, name)
+		},
+	}
+	configMapInUse = inUseKind{
+		Name:           "config map",
+		Namespaced:     true,
+		DependentsName: "pods",
+		Dependents: func(ctx context.Context, conn kubernetes.Interface, namespace, name string) ([]string, error) {
+			return podsReferencing(ctx, conn, namespace, func(r podReferences) []string { return r.ConfigMaps }, name)
+		},
+	}
+	persistentVolumeClaimInUse = inUseKind{
+		Name:           "persistent volume claim",
+		Namespaced:     true,
+		DependentsName: "pods",
+		Dependents: func(ctx context.Context, conn kubernetes.Interface, namespace, name string) ([]string, error) {
+			return podsReferencing(ctx, conn, namespace, func(r podReferences) []string { return r.PersistentVolumeClaims }, name)
+		},
+	}
+	storageClassInUse = inUseKind{
+		Name:           "storage class",
+		DependentsName: "persistent volume claims",
+		Dependents: func(ctx context.Context, conn kubernetes.Interface, _, name string) ([]string, error) {
+			claims, err := conn.CoreV1().PersistentVolumeClaims(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			var dependents []string
+			for _, c := range claims.Items {
+				if c.Spec.StorageClassName != nil && *c.Spec.StorageClassName == name {
+					dependents = append(dependents, fmt.Sprintf("persistent volume claim %s/%s", c.Namespace, c.Name))
+				}
+			}
+			return dependents, nil
+		},
+	}
+)
+
+// inUseResources lists the resources supporting prevent_destroy_if_in_use.
+var inUseResources = map[string]inUseKind{
+	"kubernetes_secret":                     secretInUse,
+	"kubernetes_secret_v1":                  secretInUse,
+	"kubernetes_config_map":                 configMapInUse,
+	"kubernetes_config_map_v1":              configMapInUse,
+	"kubernetes_persistent_volume_claim":    persistentVolumeClaimInUse,
+	"kubernetes_persistent_volume_claim_v1": persistentVolumeClaimInUse,
+	"kubernetes_storage_class":              storageClassInUse,
+	"kubernetes_storage_class_v1":           storageClassInUse,
+}
+
+// withInUseDestroyProtection adds the prevent_destroy_if_in_use attribute to a
+// resource. When it is set, destroying the resource fails while other objects
+// use its object, listing them, instead of breaking them.
+func withInUseDestroyProtection(r *schema.Resource, kind inUseKind) {
+	r.Schema["prevent_destroy_if_in_use"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: fmt.Sprintf("Fail to destroy the %[1]s while %[2]s use it, listing them, instead of breaking them. Requires permission to list %[2]s.", kind.Name, kind.DependentsName),
+		Optional:    true,
+		ForceNew:    r.UpdateContext == nil,
+		Default:     false,
+	}
+
+	del := r.DeleteContext
+	r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if !d.Get("prevent_destroy_if_in_use").(bool) {
+			return del(ctx, d, meta)
+		}
+		conn, err := meta.(KubeClientsets).MainClientset()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		namespace, name := "", d.Id()
+		if kind.Namespaced {
+			namespace, name, err = idParts(d.Id())
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		dependents, err := kind.Dependents(ctx, conn, namespace, name)
+		if err != nil {
+			return diag.Errorf("Unable to check whether %s %q is in use: %s", kind.Name, d.Id(), err)
+		}
+		if len(dependents) > 0 {
+			return diag.Diagnostics{inUseDiagnostic(kind.Name, d.Id(), dependents)}
+		}
+		tflog.Info(ctx, fmt.Sprintf("The %s %q is not in use, destroying it", kind.Name, d.Id()))
+		return del(ctx, d, meta)
+	}
+}
+
+func inUseDiagnostic(kind, id string, dependents []string) diag.Diagnostic {
+	slices.Sort(dependents)
+	listed := dependents
+	if len(listed) > maxListedDependents {
+		listed = listed[:maxListedDependents]
+	}
+	detail := fmt.Sprintf("The %s is in use by:\n\n  - %s\n", kind, strings.Join(listed, "\n  - "))
+	if more := len(dependents) - len(listed); more > 0 {
+		detail += fmt.Sprintf("  - and %d more\n", more)
+	}
+	detail += "\nStop using it before destroying it, or set prevent_destroy_if_in_use to false."
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Cannot destroy %s %q while it is in use", kind, id),
+		Detail:   detail,
+	}
+}
+
+// podsReferencing lists the pods of the namespace which aren't terminated and
+// reference the named object.
+func podsReferencing(ctx context.Context, conn kubernetes.Interface, namespace string, refs func(podReferences) []string, name string) ([]string, error) {
+	pods, err := conn.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var dependents []string
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == api.PodSucceeded || pod.Status.Phase == api.PodFailed {
+			continue
+		}
+		if slices.Contains(refs(referencesOfPod(pod.Spec)), name) {
+			dependents = append(dependents, fmt.Sprintf("pod %s/%s", pod.Namespace, pod.Name))
+		}
+	}
+	return dependents, nil
+}
+
+// podReferences are the names of the objects referenced by a pod spec.
+type podReferences struct {
+	Secrets                []string
+	ConfigMaps             []string
+	PersistentVolumeClaims []string
+}
+
+func referencesOfPod(spec api.PodSpec) podReferences {
+	var r podReferences
+	for _, s := range spec.ImagePullSecrets {
+		r.Secrets = append(r.Secrets, s.Name)
+	}
+	for _, v := range spec.Volumes {
+		switch {
+		case v.Secret != nil:
+			r.Secrets = append(r.Secrets, v.Secret.SecretName)
+		case v.ConfigMap != nil:
+			r.ConfigMaps = append(r.ConfigMaps, v.ConfigMap.Name)
+		case v.PersistentVolumeClaim != nil:
+			r.PersistentVolumeClaims = append(r.PersistentVolumeClaims, v.PersistentVolumeClaim.ClaimName)
+		case v.Projected != nil:
+			for _, s := range v.Projected.Sources {
+				if s.Secret != nil {
+					r.Secrets = append(r.Secrets, s.Secret.Name)
+				}
+				if s.ConfigMap != nil {
+					r.ConfigMaps = append(r.ConfigMaps, s.ConfigMap.Name)
+				}
+			}
+		}
+	}
+	containers := slices.Concat(spec.InitContainers, spec.Containers)
+	for _, c := range spec.EphemeralContainers {
+		containers = append(containers, api.Container(c.EphemeralContainerCommon))
+	}
+	for _, c := range containers {
+		for _, e := range c.EnvFrom {
+			if e.SecretRef != nil {
+				r.Secrets = append(r.Secrets, e.SecretRef.Name)
+			}
+			if e.ConfigMapRef != nil {
+				r.ConfigMaps = append(r.ConfigMaps, e.ConfigMapRef.Name)
+			}
+		}
+		for _, e := range c.Env {
+			if e.ValueFrom == nil {
+				continue
+			}
+			if e.ValueFrom.SecretKeyRef != nil {
+				r.Secrets = append(r.Secrets, e.ValueFrom.SecretKeyRef.Name)
+			}
+			if e.ValueFrom.ConfigMapKeyRef != nil {
+				r.ConfigMaps = append(r.ConfigMaps, e.ValueFrom.ConfigMapKeyRef.Name)
+			}
+		}
+	}
+	return r
+}
@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestReferencesOfPod(t *testing.T) {
+	spec := corev1.PodSpec{
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+		Volumes: []corev1.Volume{
+			{Name: "tls", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "tls"}}},
+			{Name: "settings", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}}},
+			{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}},
+			{Name: "bundle", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+				{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "ca"}}},
+				{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "bundle"}}},
+			}}}},
+		},
+		InitContainers: []corev1.Container{{
+			EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "init"}}}},
+		}},
+		Containers: []corev1.Container{{
+			Env: []corev1.EnvVar{
+				{Name: "PLAIN", Value: "value"},
+				{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "db"}, Key: "password"}}},
+			},
+		}},
+		EphemeralContainers: []corev1.EphemeralContainer{{EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "debug"}}}},
+		}}},
+	}
+	expected := podReferences{
+		Secrets:                []string{"registry", "tls", "ca", "db", "debug"},
+		ConfigMaps:             []string{"settings", "bundle", "init"},
+		PersistentVolumeClaims: []string{"data"},
+	}
+	if diff := cmp.Diff(expected, referencesOfPod(spec)); diff != "" {
+		t.Fatalf("Unexpected references: mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInUseDependents(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase, claim string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+				Name:         "data",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim}},
+			}}},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+	fast := "fast"
+	conn := fake.NewSimpleClientset(
+		pod("web-0", corev1.PodRunning, "data"),
+		pod("web-1", corev1.PodPending, "data"),
+		pod("migrate", corev1.PodSucceeded, "data"),
+		pod("cache", corev1.PodRunning, "cache"),
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "shop"},
+			Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: &fast},
+		},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "shop"}},
+	)
+
+	dependents, err := persistentVolumeClaimInUse.Dependents(context.Background(), conn, "shop", "data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"pod shop/web-0", "pod shop/web-1"}, dependents); diff != "" {
+		t.Fatalf("Unexpected dependents of the claim: mismatch (-want +got):\n%s", diff)
+	}
+
+	dependents, err = storageClassInUse.Dependents(context.Background(), conn, "", "fast")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"persistent volume claim shop/data"}, dependents); diff != "" {
+		t.Fatalf("Unexpected dependents of the storage class: mismatch (-want +got):\n%s", diff)
+	}
+
+	dependents, err = secretInUse.Dependents(context.Background(), conn, "shop", "data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dependents) != 0 {
+		t.Fatalf("Expected no dependents of the secret, got %v", dependents)
+	}
+}
+
+func TestInUseDiagnostic(t *testing.T) {
+	var dependents []string
+	for _, c := range "lkjihgfedcba" {
+		dependents = append(dependents, "pod shop/"+string(c))
+	}
+	d := inUseDiagnostic("secret", "shop/db", dependents)
+	if d.Summary != `Cannot destroy secret "shop/db" while it is in use` {
+		t.Fatalf("Unexpected summary %q", d.Summary)
+	}
+	if !strings.HasPrefix(d.Detail, "The secret is in use by:\n\n  - pod shop/a\n") {
+		t.Fatalf("Expected the dependents to be sorted, got %q", d.Detail)
+	}
+	if strings.Contains(d.Detail, "pod shop/k") || !strings.Contains(d.Detail, "  - and 2 more\n") {
+		t.Fatalf("Expected only %d dependents to be listed, got %q", maxListedDependents, d.Detail)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	gocty "github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func injectedNamesSchema(description string, forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: description,
+		Optional:    true,
+		ForceNew:    forceNew,
+		Elem: &schema.Schema{
+			Type:             schema.TypeString,
+			ValidateDiagFunc: validateInjectedNamePattern,
+		},
+	}
+}
+
+func validateInjectedNamePattern(value interface{}, _ gocty.Path) diag.Diagnostics {
+	if _, err := path.Match(value.(string), ""); err != nil {
+		return diag.Errorf("Invalid name pattern %q: %s", value, err)
+	}
+	return nil
+}
+
+// withInjectedObjectsIgnored adds the injected_containers and injected_volumes
+// attributes to a resource managing pods. Containers and volumes added to the
+// pod spec by mutating admission webhooks which match them are left out of the
+// state, so that they aren't planned for removal.
+func withInjectedObjectsIgnored(r *schema.Resource, specPath []string) {
+	forceNew := r.UpdateContext == nil
+	r.Schema["injected_containers"] = injectedNamesSchema("Name patterns of containers and init containers injected into the pod spec by mutating admission webhooks, e.g. `istio-*`. Matching containers which aren't declared in the configuration are ignored.", forceNew)
+	r.Schema["injected_volumes"] = injectedNamesSchema("Name patterns of volumes injected into the pod spec by mutating admission webhooks, e.g. `vault-*`. Matching volumes which aren't declared in the configuration are ignored, together with their mounts.", forceNew)
+
+	r.CreateContext = ignoreInjectedObjects(r.CreateContext, specPath)
+	r.ReadContext = ignoreInjectedObjects(r.ReadContext, specPath)
+	if r.UpdateContext != nil {
+		r.UpdateContext = ignoreInjectedObjects(r.UpdateContext, specPath)
+	}
+}
+
+func ignoreInjectedObjects(fn func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, specPath []string) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		prior, _ := d.Get(specPath[0]).([]interface{})
+		diags := fn(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+		containers := expandStringSlice(d.Get("injected_containers").([]interface{}))
+		volumes := expandStringSlice(d.Get("injected_volumes").([]interface{}))
+		if len(containers) == 0 && len(volumes) == 0 {
+			return diags
+		}
+
+		current, ok := d.Get(specPath[0]).([]interface{})
+		if !ok || !removeInjectedObjects(podSpecAt(prior, specPath[1:]), podSpecAt(current, specPath[1:]), containers, volumes) {
+			return diags
+		}
+		if err := d.Set(specPath[0], current); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		return diags
+	}
+}
+
+// removeInjectedObjects removes the containers and volumes matching the given
+// name patterns from the pod spec current, unless prior declares them. The
+// mounts of removed volumes are removed from the remaining containers. It
+// reports whether anything was removed.
+func removeInjectedObjects(prior, current map[string]interface{}, containers, volumes []string) bool {
+	if current == nil {
+		return false
+	}
+	removedContainers := removeInjected(prior, current, "init_container", containers)
+	removedContainers = removeInjected(prior, current, "container", containers) || removedContainers
+	if !removeInjected(prior, current, "volume", volumes) {
+		return removedContainers
+	}
+
+	kept := make(map[string]bool)
+	l, _ := current["volume"].([]interface{})
+	for _, v := range l {
+		if m, ok := v.(map[string]interface{}); ok {
+			kept[fmt.Sprintf("%v", m["name"])] = true
+		}
+	}
+	for _, k := range []string{"init_container", "container"} {
+		cl, _ := current[k].([]interface{})
+		for _, c := range cl {
+			m, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			mounts, _ := m["volume_mount"].([]interface{})
+			var keptMounts []interface{}
+			for _, vm := range mounts {
+				if mm, ok := vm.(map[string]interface{}); ok {
+					name := fmt.Sprintf("%v", mm["name"])
+					if !kept[name] && nameMatchesAny(name, volumes) {
+						continue
+					}
+				}
+				keptMounts = append(keptMounts, vm)
+			}
+			m["volume_mount"] = keptMounts
+		}
+	}
+	return true
+}
+
+// removeInjected removes the elements of the list field of current whose name
+// matches one of the patterns and which aren't declared in prior.
+func removeInjected(prior, current map[string]interface{}, field string, patterns []string) bool {
+	l, _ := current[field].([]interface{})
+	if len(patterns) == 0 || len(l) == 0 {
+		return false
+	}
+	declared := make(map[string]bool)
+	if prior != nil {
+		pl, _ := prior[field].([]interface{})
+		for _, e := range pl {
+			if m, ok := e.(map[string]interface{}); ok {
+				declared[fmt.Sprintf("%v", m["name"])] = true
+			}
+		}
+	}
+
+	var kept []interface{}
+	for _, e := range l {
+		if m, ok := e.(map[string]interface{}); ok {
+			name := fmt.Sprintf("%v", m["name"])
+			if !declared[name] && nameMatchesAny(name, patterns) {
+				continue
+			}
+		}
+		kept = append(kept, e)
+	}
+	if len(kept) == len(l) {
+		return false
+	}
+	current[field] = kept
+	return true
+}
+
+func nameMatchesAny(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
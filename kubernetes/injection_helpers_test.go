@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRemoveInjectedObjects(t *testing.T) {
+	named := func(names ...string) []interface{} {
+		var l []interface{}
+		for _, n := range names {
+			l = append(l, map[string]interface{}{"name": n})
+		}
+		return l
+	}
+	container := func(name string, mounts ...string) map[string]interface{} {
+		return map[string]interface{}{"name": name, "volume_mount": named(mounts...)}
+	}
+
+	prior := map[string]interface{}{
+		"container": []interface{}{container("app", "data")},
+		"volume":    named("data", "vault-config"),
+	}
+	current := map[string]interface{}{
+		"init_container": []interface{}{container("istio-init")},
+		"container": []interface{}{
+			container("app", "data", "vault-secrets", "vault-config"),
+			container("istio-proxy", "istio-envoy"),
+		},
+		"volume": named("data", "vault-config", "vault-secrets", "istio-envoy"),
+	}
+	if !removeInjectedObjects(prior, current, []string{"istio-*"}, []string{"istio-*", "vault-*"}) {
+		t.Fatal("expected the injected objects to be removed")
+	}
+
+	expected := map[string]interface{}{
+		"init_container": []interface{}(nil),
+		"container":      []interface{}{container("app", "data", "vault-config")},
+		"volume":         named("data", "vault-config"),
+	}
+	if !reflect.DeepEqual(current, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, current)
+	}
+	if removeInjectedObjects(prior, current, []string{"istio-*"}, []string{"istio-*", "vault-*"}) {
+		t.Fatal("expected nothing else to be removed")
+	}
+}
+
+func TestValidateInjectedNamePattern(t *testing.T) {
+	if diags := validateInjectedNamePattern("istio-*", nil); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if diags := validateInjectedNamePattern("istio-[", nil); !diags.HasError() {
+		t.Fatal("expected an error for an invalid pattern")
+	}
+}
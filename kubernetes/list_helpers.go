@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultListPageSize is the number of objects requested per page by the list
+// data sources, the same as kubectl.
+const defaultListPageSize = 500
+
+func listPageSizeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Description:  fmt.Sprintf("The number of objects requested from the API server at a time. The objects are listed page by page, so that listing many of them doesn't time out. Defaults to %d.", defaultListPageSize),
+		Optional:     true,
+		Default:      defaultListPageSize,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+}
+
+func listMaxItemsSchema(objectsName string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeInt,
+		Description:  fmt.Sprintf("The maximum number of %s to list. The data source warns when more %[1]s exist, which are left out. Defaults to 0, which lists all of them.", objectsName),
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+	}
+}
+
+// listPaging is the page size and the maximum number of objects of a list.
+type listPaging struct {
+	pageSize int64
+	maxItems int
+}
+
+func expandListPaging(d *schema.ResourceData) listPaging {
+	return listPaging{
+		pageSize: int64(d.Get("page_size").(int)),
+		maxItems: d.Get("max_items").(int),
+	}
+}
+
+// listPage is a page of a list, e.g. *corev1.NodeList.
+type listPage interface {
+	GetContinue() string
+}
+
+// listAll lists the objects page by page with list, until all of them, or
+// paging.maxItems of them, are listed. It reports whether objects were left
+// out because of paging.maxItems.
+func listAll[L listPage, T any](ctx context.Context, opts metav1.ListOptions, paging listPaging, list func(context.Context, metav1.ListOptions) (L, error), items func(L) []T) ([]T, bool, error) {
+	var all []T
+	for {
+		opts.Limit = paging.pageSize
+		if remaining := int64(paging.maxItems - len(all)); paging.maxItems > 0 && (opts.Limit <= 0 || remaining < opts.Limit) {
+			opts.Limit = remaining
+		}
+		page, err := list(ctx, opts)
+		if err != nil {
+			return nil, false, err
+		}
+		all = append(all, items(page)...)
+		opts.Continue = page.GetContinue()
+
+		if paging.maxItems > 0 && len(all) >= paging.maxItems {
+			truncated := opts.Continue != "" || len(all) > paging.maxItems
+			return all[:paging.maxItems], truncated, nil
+		}
+		if opts.Continue == "" {
+			return all, false, nil
+		}
+	}
+}
+
+// listTruncatedDiagnostics warns that objects were left out of a list because
+// of max_items.
+func listTruncatedDiagnostics(objectsName string, maxItems int) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "List truncated",
+		Detail:   fmt.Sprintf("More than %d %s exist, only the first %[1]d are returned. Narrow down the selection or raise max_items to list the others.", maxItems, objectsName),
+	}}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// pagedNamespaces serves n namespaces page by page, with the index of the
+// next one as the continue token.
+func pagedNamespaces(n int, limits *[]int64) func(context.Context, metav1.ListOptions) (*corev1.NamespaceList, error) {
+	return func(_ context.Context, opts metav1.ListOptions) (*corev1.NamespaceList, error) {
+		*limits = append(*limits, opts.Limit)
+		start := 0
+		if opts.Continue != "" {
+			start, _ = strconv.Atoi(opts.Continue)
+		}
+		end := start + int(opts.Limit)
+		if opts.Limit == 0 || end > n {
+			end = n
+		}
+		l := &corev1.NamespaceList{}
+		for i := start; i < end; i++ {
+			l.Items = append(l.Items, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("ns-%d", i)}})
+		}
+		if end < n {
+			l.Continue = strconv.Itoa(end)
+		}
+		return l, nil
+	}
+}
+
+func namespaceItems(l *corev1.NamespaceList) []corev1.Namespace {
+	return l.Items
+}
+
+func TestListAll(t *testing.T) {
+	cases := map[string]struct {
+		total     int
+		paging    listPaging
+		items     int
+		truncated bool
+		limits    []int64
+	}{
+		"SinglePage":      {3, listPaging{pageSize: 10}, 3, false, []int64{10}},
+		"SeveralPages":    {25, listPaging{pageSize: 10}, 25, false, []int64{10, 10, 10}},
+		"Capped":          {25, listPaging{pageSize: 10, maxItems: 15}, 15, true, []int64{10, 5}},
+		"CapAboveTotal":   {12, listPaging{pageSize: 10, maxItems: 20}, 12, false, []int64{10, 10}},
+		"CapEqualToTotal": {10, listPaging{pageSize: 10, maxItems: 10}, 10, false, []int64{10}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var limits []int64
+			items, truncated, err := listAll(context.Background(), metav1.ListOptions{}, tc.paging, pagedNamespaces(tc.total, &limits), namespaceItems)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != tc.items {
+				t.Fatalf("expected %d items, got %d", tc.items, len(items))
+			}
+			if truncated != tc.truncated {
+				t.Fatalf("expected truncated to be %t", tc.truncated)
+			}
+			if fmt.Sprint(limits) != fmt.Sprint(tc.limits) {
+				t.Fatalf("expected the limits %v, got %v", tc.limits, limits)
+			}
+			if items[len(items)-1].Name != fmt.Sprintf("ns-%d", tc.items-1) {
+				t.Fatalf("expected the items in order, got %s last", items[len(items)-1].Name)
+			}
+		})
+	}
+}
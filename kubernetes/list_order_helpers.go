@@ -0,0 +1,249 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// podSpecPaths maps the resources managing pods to the path of their pod spec.
+// Every step of a path is a block of a single element.
+var podSpecPaths = map[string][]string{
+	"kubernetes_pod":                       {"spec"},
+	"kubernetes_pod_v1":                    {"spec"},
+	"kubernetes_replication_controller":    {"spec", "template", "spec"},
+	"kubernetes_replication_controller_v1": {"spec", "template", "spec"},
+	"kubernetes_deployment":                {"spec", "template", "spec"},
+	"kubernetes_deployment_v1":             {"spec", "template", "spec"},
+	"kubernetes_daemonset":                 {"spec", "template", "spec"},
+	"kubernetes_daemon_set_v1":             {"spec", "template", "spec"},
+	"kubernetes_stateful_set":              {"spec", "template", "spec"},
+	"kubernetes_stateful_set_v1":           {"spec", "template", "spec"},
+	"kubernetes_job":                       {"spec", "template", "spec"},
+	"kubernetes_job_v1":                    {"spec", "template", "spec"},
+	"kubernetes_cron_job":                  {"spec", "job_template", "spec", "template", "spec"},
+	"kubernetes_cron_job_v1":               {"spec", "job_template", "spec", "template", "spec"},
+}
+
+// containerListKeys returns the key identifying an element of the container
+// lists whose order is irrelevant to Kubernetes.
+var containerListKeys = map[string]func(map[string]interface{}) string{
+	"env": func(m map[string]interface{}) string {
+		return fmt.Sprintf("%v", m["name"])
+	},
+	"env_from": func(m map[string]interface{}) string {
+		return fmt.Sprintf("%v/%v/%v", m["prefix"], firstListElemValue(m["config_map_ref"], "name"), firstListElemValue(m["secret_ref"], "name"))
+	},
+	"port": func(m map[string]interface{}) string {
+		return fmt.Sprintf("%v/%v", m["container_port"], m["protocol"])
+	},
+	"volume_mount": func(m map[string]interface{}) string {
+		return fmt.Sprintf("%v", m["mount_path"])
+	},
+}
+
+func matchExpressionKey(m map[string]interface{}) string {
+	return fmt.Sprintf("%v/%v", m["key"], m["operator"])
+}
+
+// podSpecListKeys returns the key identifying an element of the lists of a pod
+// spec which Kubernetes treats as sets, by their path relative to the pod spec.
+// The elements of the intermediate lists of a path are matched by their index.
+var podSpecListKeys = map[string]func(map[string]interface{}) string{
+	"image_pull_secrets": func(m map[string]interface{}) string {
+		return fmt.Sprintf("%v", m["name"])
+	},
+	"toleration": func(m map[string]interface{}) string {
+		return fmt.Sprintf("%v/%v/%v/%v", m["key"], m["operator"], m["value"], m["effect"])
+	},
+	"topology_spread_constraint": func(m map[string]interface{}) string {
+		return fmt.Sprintf("%v/%v", m["topology_key"], m["when_unsatisfiable"])
+	},
+	"topology_spread_constraint.label_selector.match_expressions":                                                                        matchExpressionKey,
+	"affinity.node_affinity.required_during_scheduling_ignored_during_execution.node_selector_term.match_expressions":                    matchExpressionKey,
+	"affinity.node_affinity.required_during_scheduling_ignored_during_execution.node_selector_term.match_fields":                         matchExpressionKey,
+	"affinity.node_affinity.preferred_during_scheduling_ignored_during_execution.preference.match_expressions":                           matchExpressionKey,
+	"affinity.node_affinity.preferred_during_scheduling_ignored_during_execution.preference.match_fields":                                matchExpressionKey,
+	"affinity.pod_affinity.required_during_scheduling_ignored_during_execution.label_selector.match_expressions":                         matchExpressionKey,
+	"affinity.pod_affinity.preferred_during_scheduling_ignored_during_execution.pod_affinity_term.label_selector.match_expressions":      matchExpressionKey,
+	"affinity.pod_anti_affinity.required_during_scheduling_ignored_during_execution.label_selector.match_expressions":                    matchExpressionKey,
+	"affinity.pod_anti_affinity.preferred_during_scheduling_ignored_during_execution.pod_affinity_term.label_selector.match_expressions": matchExpressionKey,
+}
+
+// withPodSpecListOrder keeps the lists of the pod spec of a resource whose order
+// is irrelevant to Kubernetes in the order they were declared in, so that the
+// API server or admission webhooks reordering them don't cause a diff.
+func withPodSpecListOrder(r *schema.Resource, path []string) {
+	r.CreateContext = preservePodSpecListOrder(r.CreateContext, path)
+	r.ReadContext = preservePodSpecListOrder(r.ReadContext, path)
+	if r.UpdateContext != nil {
+		r.UpdateContext = preservePodSpecListOrder(r.UpdateContext, path)
+	}
+}
+
+func preservePodSpecListOrder(fn func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, path []string) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		prior, _ := d.Get(path[0]).([]interface{})
+		diags := fn(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" || len(prior) == 0 {
+			return diags
+		}
+
+		current, ok := d.Get(path[0]).([]interface{})
+		if !ok || !reorderPodSpecLists(podSpecAt(prior, path[1:]), podSpecAt(current, path[1:])) {
+			return diags
+		}
+		if err := d.Set(path[0], current); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		return diags
+	}
+}
+
+// podSpecAt follows the path from the given block to the pod spec.
+func podSpecAt(l []interface{}, path []string) map[string]interface{} {
+	for {
+		if len(l) == 0 || l[0] == nil {
+			return nil
+		}
+		m, ok := l[0].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if len(path) == 0 {
+			return m
+		}
+		l, _ = m[path[0]].([]interface{})
+		path = path[1:]
+	}
+}
+
+// reorderPodSpecLists reorders the lists of the pod spec current in place to
+// follow the order of prior. The lists of containers are matched with the
+// containers of prior by name. It reports whether anything was reordered.
+func reorderPodSpecLists(prior, current map[string]interface{}) bool {
+	if prior == nil || current == nil {
+		return false
+	}
+	changed := false
+	for path, key := range podSpecListKeys {
+		if reorderNestedList([]interface{}{prior}, []interface{}{current}, strings.Split(path, "."), key) {
+			changed = true
+		}
+	}
+	for _, k := range []string{"init_container", "container"} {
+		priorContainers := make(map[string]map[string]interface{})
+		if l, ok := prior[k].([]interface{}); ok {
+			for _, c := range l {
+				if m, ok := c.(map[string]interface{}); ok {
+					priorContainers[fmt.Sprintf("%v", m["name"])] = m
+				}
+			}
+		}
+		l, _ := current[k].([]interface{})
+		for _, c := range l {
+			m, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			p, ok := priorContainers[fmt.Sprintf("%v", m["name"])]
+			if !ok {
+				continue
+			}
+			for field, key := range containerListKeys {
+				pl, _ := p[field].([]interface{})
+				cl, _ := m[field].([]interface{})
+				if reorderList(pl, cl, key) {
+					changed = true
+				}
+			}
+		}
+	}
+	return changed
+}
+
+// reorderNestedList reorders the lists at the path below the elements of
+// current, matching the elements of prior and current by index.
+func reorderNestedList(prior, current []interface{}, path []string, key func(map[string]interface{}) string) bool {
+	changed := false
+	for i := 0; i < len(prior) && i < len(current); i++ {
+		pm, ok := prior[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		cm, ok := current[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pl, _ := pm[path[0]].([]interface{})
+		cl, _ := cm[path[0]].([]interface{})
+		if len(path) == 1 {
+			changed = reorderList(pl, cl, key) || changed
+		} else {
+			changed = reorderNestedList(pl, cl, path[1:], key) || changed
+		}
+	}
+	return changed
+}
+
+// reorderList sorts current in place so that the elements also found in prior
+// come first, in the order of prior, followed by the remaining elements in
+// their original order. It reports whether the order changed.
+func reorderList(prior, current []interface{}, key func(map[string]interface{}) string) bool {
+	if len(prior) == 0 || len(current) < 2 {
+		return false
+	}
+	rank := make(map[string]int, len(prior))
+	for i, e := range prior {
+		if m, ok := e.(map[string]interface{}); ok {
+			k := key(m)
+			if _, ok := rank[k]; !ok {
+				rank[k] = i
+			}
+		}
+	}
+
+	ranks := make([]int, len(current))
+	for i, e := range current {
+		ranks[i] = len(prior) + i
+		if m, ok := e.(map[string]interface{}); ok {
+			if r, ok := rank[key(m)]; ok {
+				ranks[i] = r
+			}
+		}
+	}
+	if sort.IntsAreSorted(ranks) {
+		return false
+	}
+
+	idx := make([]int, len(current))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return ranks[idx[i]] < ranks[idx[j]] })
+	sorted := make([]interface{}, len(current))
+	for i, j := range idx {
+		sorted[i] = current[j]
+	}
+	copy(current, sorted)
+	return true
+}
+
+func firstListElemValue(v interface{}, key string) interface{} {
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 || l[0] == nil {
+		return ""
+	}
+	m, ok := l[0].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	return m[key]
+}
@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testEnvList(names ...string) []interface{} {
+	l := make([]interface{}, len(names))
+	for i, n := range names {
+		l[i] = map[string]interface{}{"name": n, "value": n + "-value"}
+	}
+	return l
+}
+
+func TestReorderList(t *testing.T) {
+	key := containerListKeys["env"]
+	cases := []struct {
+		Name     string
+		Prior    []interface{}
+		Current  []interface{}
+		Expected []interface{}
+		Changed  bool
+	}{
+		{
+			"same order",
+			testEnvList("A", "B", "C"),
+			testEnvList("A", "B", "C"),
+			testEnvList("A", "B", "C"),
+			false,
+		},
+		{
+			"reordered",
+			testEnvList("A", "B", "C"),
+			testEnvList("C", "A", "B"),
+			testEnvList("A", "B", "C"),
+			true,
+		},
+		{
+			"added by the server",
+			testEnvList("A", "B"),
+			testEnvList("INJECTED", "B", "A"),
+			testEnvList("A", "B", "INJECTED"),
+			true,
+		},
+		{
+			"no prior",
+			nil,
+			testEnvList("B", "A"),
+			testEnvList("B", "A"),
+			false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			changed := reorderList(tc.Prior, tc.Current, key)
+			if changed != tc.Changed {
+				t.Fatalf("expected changed to be %t", tc.Changed)
+			}
+			if !reflect.DeepEqual(tc.Current, tc.Expected) {
+				t.Fatalf("expected %#v, got %#v", tc.Expected, tc.Current)
+			}
+		})
+	}
+}
+
+func TestPreserveContainerListOrder(t *testing.T) {
+	r := resourceKubernetesDeploymentV1()
+	container := func(env []interface{}, mounts ...string) map[string]interface{} {
+		m := map[string]interface{}{
+			"name":  "app",
+			"image": "nginx",
+			"env":   env,
+		}
+		var l []interface{}
+		for _, p := range mounts {
+			l = append(l, map[string]interface{}{"name": "data", "mount_path": p})
+		}
+		m["volume_mount"] = l
+		return m
+	}
+	spec := func(c map[string]interface{}) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"template": []interface{}{
+					map[string]interface{}{
+						"spec": []interface{}{
+							map[string]interface{}{
+								"container": []interface{}{c},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"spec": spec(container(testEnvList("A", "B"), "/a", "/b")),
+	})
+	d.SetId("default/app")
+
+	read := preservePodSpecListOrder(func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return diag.FromErr(d.Set("spec", spec(container(testEnvList("B", "A"), "/b", "/a"))))
+	}, podSpecPaths["kubernetes_deployment_v1"])
+	if diags := read(context.Background(), d, nil); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if v := d.Get("spec.0.template.0.spec.0.container.0.env.0.name"); v != "A" {
+		t.Fatalf("expected the env to keep its declared order, got %q first", v)
+	}
+	if v := d.Get("spec.0.template.0.spec.0.container.0.volume_mount.0.mount_path"); v != "/a" {
+		t.Fatalf("expected the volume mounts to keep their declared order, got %q first", v)
+	}
+}
+
+func TestReorderPodSpecLists(t *testing.T) {
+	toleration := func(key string) map[string]interface{} {
+		return map[string]interface{}{"key": key, "operator": "Exists", "value": "", "effect": "NoSchedule"}
+	}
+	expression := func(key string) map[string]interface{} {
+		return map[string]interface{}{"key": key, "operator": "In"}
+	}
+	podSpec := func(tolerations []string, expressions []string) map[string]interface{} {
+		var tl, el []interface{}
+		for _, k := range tolerations {
+			tl = append(tl, toleration(k))
+		}
+		for _, k := range expressions {
+			el = append(el, expression(k))
+		}
+		return map[string]interface{}{
+			"toleration": tl,
+			"affinity": []interface{}{
+				map[string]interface{}{
+					"node_affinity": []interface{}{
+						map[string]interface{}{
+							"required_during_scheduling_ignored_during_execution": []interface{}{
+								map[string]interface{}{
+									"node_selector_term": []interface{}{
+										map[string]interface{}{"match_expressions": el},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	prior := podSpec([]string{"a", "b"}, []string{"zone", "arch"})
+	current := podSpec([]string{"b", "a"}, []string{"arch", "zone"})
+	if !reorderPodSpecLists(prior, current) {
+		t.Fatal("expected the lists to be reordered")
+	}
+	if !reflect.DeepEqual(prior, current) {
+		t.Fatalf("expected %#v, got %#v", prior, current)
+	}
+	if reorderPodSpecLists(prior, current) {
+		t.Fatal("expected no further reordering")
+	}
+}
@@ -0,0 +1,328 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// structuredFieldManager is the field manager the API server records for the
+// changes made by structured resources. They don't set a field manager, so the
+// API server derives it from the user agent of the provider.
+const structuredFieldManager = "HashiCorp"
+
+// withOwnedFieldsOnly adds the owned_fields_only attribute to a structured
+// resource. When it is set, changes made out of band to fields which are owned
+// by other field managers, and not by the provider, aren't read into the state.
+func withOwnedFieldsOnly(r *schema.Resource, wr waitableResource) {
+	r.Schema["owned_fields_only"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Only detect drift of fields owned by the provider. Changes to fields owned by other field managers, such as the replicas of a deployment scaled by a horizontal pod autoscaler, are ignored. Ownership is taken from the managed fields of the object.",
+		Optional:    true,
+		ForceNew:    r.UpdateContext == nil,
+		Default:     false,
+	}
+
+	read := r.ReadContext
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if !d.Get("owned_fields_only").(bool) {
+			return read(ctx, d, meta)
+		}
+
+		prior := make(map[string]interface{})
+		for k := range r.Schema {
+			prior[k] = d.Get(k)
+		}
+		diags := read(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+
+		owners, err := readFieldOwners(ctx, d, meta, wr)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Failed to read the managed fields of %q, drift of fields owned by other field managers isn't ignored: %s", d.Id(), err))
+			return diags
+		}
+
+		keys := make([]string, 0, len(r.Schema))
+		for k := range r.Schema {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			current := map[string]interface{}{k: d.Get(k)}
+			if !owners.restoreBlock(ctx, r.Schema, prior, current, owners.root(), []string{}) {
+				continue
+			}
+			if err := d.Set(k, current[k]); err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+		}
+		return diags
+	}
+}
+
+// fieldOwners holds the field sets of the managed fields of an object, decoded
+// from their FieldsV1 representation.
+type fieldOwners struct {
+	managers []string
+	sets     []map[string]interface{}
+	manager  string
+}
+
+func readFieldOwners(ctx context.Context, d *schema.ResourceData, meta interface{}, wr waitableResource) (*fieldOwners, error) {
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	namespace := ""
+	if wr.Namespaced {
+		namespace = d.Get("metadata.0.namespace").(string)
+	}
+	obj, err := conn.Resource(wr.GroupVersionResource).Namespace(namespace).Get(ctx, d.Get("metadata.0.name").(string), metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return &fieldOwners{manager: structuredFieldManager}, nil
+		}
+		return nil, err
+	}
+	return newFieldOwners(obj.GetManagedFields(), structuredFieldManager)
+}
+
+func newFieldOwners(entries []metav1.ManagedFieldsEntry, manager string) (*fieldOwners, error) {
+	o := &fieldOwners{manager: manager}
+	for _, e := range entries {
+		// Fields of subresources such as status are never planned.
+		if e.Subresource != "" || e.FieldsV1 == nil {
+			continue
+		}
+		set := make(map[string]interface{})
+		if err := json.Unmarshal(e.FieldsV1.Raw, &set); err != nil {
+			return nil, fmt.Errorf("failed to decode the managed fields of %q: %s", e.Manager, err)
+		}
+		o.managers = append(o.managers, e.Manager)
+		o.sets = append(o.sets, set)
+	}
+	return o, nil
+}
+
+// root returns the nodes of every field set at the root of the object.
+func (o *fieldOwners) root() []map[string]interface{} {
+	nodes := make([]map[string]interface{}, len(o.sets))
+	copy(nodes, o.sets)
+	return nodes
+}
+
+// foreign reports whether the field at the given nodes is owned by another
+// field manager and not by the provider.
+func (o *fieldOwners) foreign(nodes []map[string]interface{}) bool {
+	ours, others := false, false
+	for i, n := range nodes {
+		if n == nil {
+			continue
+		}
+		if o.managers[i] == o.manager {
+			ours = true
+		} else {
+			others = true
+		}
+	}
+	return others && !ours
+}
+
+// children returns the child nodes with the first of the keys found in any of
+// the nodes. An empty node owns all of its children.
+func children(nodes []map[string]interface{}, keys ...string) []map[string]interface{} {
+	key := ""
+	for _, k := range keys {
+		for _, n := range nodes {
+			if _, ok := n[k]; ok {
+				key = k
+				break
+			}
+		}
+		if key != "" {
+			break
+		}
+	}
+
+	out := make([]map[string]interface{}, len(nodes))
+	for i, n := range nodes {
+		switch {
+		case n == nil:
+		case len(n) == 0:
+			out[i] = n
+		case key != "":
+			out[i], _ = n[key].(map[string]interface{})
+		}
+	}
+	return out
+}
+
+// fieldKeys returns the candidate FieldsV1 keys of the API field represented by
+// a schema attribute. Blocks representing lists are named in the singular.
+func fieldKeys(attr string) []string {
+	name := snakeToCamel(attr)
+	return []string{"f:" + name, "f:" + name + "s", "f:" + name + "es"}
+}
+
+// restoreBlock restores the fields of the block current which differ from prior
+// and are owned by other field managers to their value in prior. It reports
+// whether anything was restored.
+func (o *fieldOwners) restoreBlock(ctx context.Context, s map[string]*schema.Schema, prior, current map[string]interface{}, nodes []map[string]interface{}, path []string) bool {
+	restored := false
+	for k, v := range current {
+		attr, ok := s[k]
+		if !ok {
+			continue
+		}
+		pv := prior[k]
+		if reflect.DeepEqual(pv, v) {
+			continue
+		}
+		child := children(nodes, fieldKeys(k)...)
+		p := append(append([]string{}, path...), k)
+
+		switch attr.Type {
+		case schema.TypeMap:
+			pm, _ := pv.(map[string]interface{})
+			cm, _ := v.(map[string]interface{})
+			if cm == nil {
+				continue
+			}
+			for mk := range unionKeys(pm, cm) {
+				if reflect.DeepEqual(pm[mk], cm[mk]) || !o.foreign(children(child, "f:"+mk)) {
+					continue
+				}
+				tflog.Debug(ctx, fmt.Sprintf("Ignoring the change of %s.%s, it is owned by another field manager", strings.Join(p, "."), mk))
+				if pval, ok := pm[mk]; ok {
+					cm[mk] = pval
+				} else {
+					delete(cm, mk)
+				}
+				restored = true
+			}
+		case schema.TypeList:
+			elem, ok := attr.Elem.(*schema.Resource)
+			pl, _ := pv.([]interface{})
+			cl, _ := v.([]interface{})
+			if !ok || len(pl) != len(cl) {
+				if o.foreign(child) {
+					tflog.Debug(ctx, fmt.Sprintf("Ignoring the change of %s, it is owned by another field manager", strings.Join(p, ".")))
+					current[k] = pv
+					restored = true
+				}
+				continue
+			}
+			for i := range cl {
+				pe, _ := pl[i].(map[string]interface{})
+				ce, _ := cl[i].(map[string]interface{})
+				if pe == nil || ce == nil {
+					continue
+				}
+				elemNodes := child
+				if attr.MaxItems != 1 {
+					elemNodes = listElementNodes(child, ce)
+				}
+				if o.restoreBlock(ctx, elem.Schema, pe, ce, elemNodes, append(append([]string{}, p...), fmt.Sprint(i))) {
+					restored = true
+				}
+			}
+		case schema.TypeSet:
+			// Elements of sets can't be matched with their fields.
+		default:
+			if o.foreign(child) {
+				tflog.Debug(ctx, fmt.Sprintf("Ignoring the change of %s, it is owned by another field manager", strings.Join(p, ".")))
+				current[k] = pv
+				restored = true
+			}
+		}
+	}
+	return restored
+}
+
+// listElementNodes returns the nodes of the element of an associative list
+// whose keys match the fields of the flattened element.
+func listElementNodes(nodes []map[string]interface{}, elem map[string]interface{}) []map[string]interface{} {
+	out := make([]map[string]interface{}, len(nodes))
+	for i, n := range nodes {
+		if n == nil {
+			continue
+		}
+		if len(n) == 0 {
+			out[i] = n
+			continue
+		}
+		for k, v := range n {
+			if !strings.HasPrefix(k, "k:") {
+				continue
+			}
+			key := make(map[string]interface{})
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(k, "k:")), &key); err != nil {
+				continue
+			}
+			if listElementMatches(key, elem) {
+				out[i], _ = v.(map[string]interface{})
+				break
+			}
+		}
+	}
+	return out
+}
+
+func listElementMatches(key, elem map[string]interface{}) bool {
+	for k, v := range key {
+		if fmt.Sprint(elem[camelToSnake(k)]) != fmt.Sprint(v) {
+			return false
+		}
+	}
+	return len(key) > 0
+}
+
+func unionKeys(a, b map[string]interface{}) map[string]struct{} {
+	keys := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+	return keys
+}
+
+func snakeToCamel(s string) string {
+	parts := strings.Split(s, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func camelToSnake(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFieldOwnersRestoreBlock(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"metadata": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"annotations": {Type: schema.TypeMap},
+			}},
+		},
+		"spec": {
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"replicas": {Type: schema.TypeString},
+				"template": {
+					Type:     schema.TypeList,
+					MaxItems: 1,
+					Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+						"container": {
+							Type: schema.TypeList,
+							Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+								"name":  {Type: schema.TypeString},
+								"image": {Type: schema.TypeString},
+							}},
+						},
+					}},
+				},
+			}},
+		},
+	}
+	entries := []metav1.ManagedFieldsEntry{
+		{
+			Manager: structuredFieldManager,
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{
+				"f:metadata": {"f:annotations": {"f:owner": {}}},
+				"f:spec": {"f:template": {"f:containers": {"k:{\"name\":\"app\"}": {".": {}, "f:name": {}}}}}
+			}`)},
+		},
+		{
+			Manager: "kube-controller-manager",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{
+				"f:spec": {"f:replicas": {}}
+			}`)},
+		},
+		{
+			Manager: "kubectl-edit",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{
+				"f:metadata": {"f:annotations": {"f:edited": {}}},
+				"f:spec": {"f:template": {"f:containers": {"k:{\"name\":\"app\"}": {"f:image": {}}}}}
+			}`)},
+		},
+		{
+			Manager:     "kube-controller-manager",
+			Subresource: "status",
+			FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:status": {}}`)},
+		},
+	}
+	owners, err := newFieldOwners(entries, structuredFieldManager)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	object := func(owner, edited, replicas, image string) map[string]interface{} {
+		annotations := map[string]interface{}{"owner": owner}
+		if edited != "" {
+			annotations["edited"] = edited
+		}
+		return map[string]interface{}{
+			"metadata": []interface{}{map[string]interface{}{"annotations": annotations}},
+			"spec": []interface{}{map[string]interface{}{
+				"replicas": replicas,
+				"template": []interface{}{map[string]interface{}{
+					"container": []interface{}{map[string]interface{}{"name": "app", "image": image}},
+				}},
+			}},
+		}
+	}
+
+	prior := object("terraform", "", "1", "nginx:1")
+	current := object("someone-else", "yes", "5", "nginx:2")
+	if !owners.restoreBlock(context.Background(), s, prior, current, owners.root(), nil) {
+		t.Fatal("expected changes to be restored")
+	}
+	// The annotation owned by the provider keeps its drift, everything else is
+	// owned by other field managers.
+	expected := object("someone-else", "", "1", "nginx:1")
+	if !reflect.DeepEqual(current, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, current)
+	}
+}
+
+func TestSnakeToCamel(t *testing.T) {
+	for snake, camel := range map[string]string{
+		"replicas":                         "replicas",
+		"volume_mount":                     "volumeMount",
+		"termination_grace_period_seconds": "terminationGracePeriodSeconds",
+	} {
+		if got := snakeToCamel(snake); got != camel {
+			t.Errorf("expected %q, got %q", camel, got)
+		}
+		if got := camelToSnake(camel); got != snake {
+			t.Errorf("expected %q, got %q", snake, got)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/convert"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GRPCProviderServer returns the protocol server of the provider. Unlike the one
+// of the SDK, it supports `moved` blocks from a resource type to the resource type
+// of the same kind with the `_v1` suffix, e.g. from kubernetes_cron_job to
+// kubernetes_cron_job_v1.
+func GRPCProviderServer(p *schema.Provider) func() tfprotov5.ProviderServer {
+	return func() tfprotov5.ProviderServer {
+		return &movableProviderServer{
+			ProviderServer: p.GRPCProvider(),
+			provider:       p,
+		}
+	}
+}
+
+type movableProviderServer struct {
+	tfprotov5.ProviderServer
+	provider *schema.Provider
+}
+
+// movableResourceSource returns the resource type the state of which can be moved
+// to the given resource type, or an empty string when there is none.
+func movableResourceSource(p *schema.Provider, target string) string {
+	source, ok := strings.CutSuffix(target, "_v1")
+	if !ok {
+		return ""
+	}
+	if _, ok := p.ResourcesMap[source]; !ok {
+		return ""
+	}
+	if _, ok := p.ResourcesMap[target]; !ok {
+		return ""
+	}
+	return source
+}
+
+// MoveResourceState moves the state of a resource to the resource type of the
+// same kind with the `_v1` suffix. The state is first upgraded to the current
+// schema of the source resource type, then translated to the schema of the target
+// resource type attribute by attribute. Attributes which don't exist in the
+// target schema are dropped, they are set again by the read which follows the move.
+func (s *movableProviderServer) MoveResourceState(ctx context.Context, req *tfprotov5.MoveResourceStateRequest) (*tfprotov5.MoveResourceStateResponse, error) {
+	if req == nil || movableResourceSource(s.provider, req.TargetTypeName) != req.SourceTypeName {
+		return s.ProviderServer.MoveResourceState(ctx, req)
+	}
+	resp := &tfprotov5.MoveResourceStateResponse{}
+
+	tflog.Info(ctx, fmt.Sprintf("Moving the state of %s to %s", req.SourceTypeName, req.TargetTypeName))
+	upgraded, err := s.ProviderServer.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: req.SourceTypeName,
+		Version:  req.SourceSchemaVersion,
+		RawState: req.SourceState,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Diagnostics = upgraded.Diagnostics
+	for _, d := range upgraded.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			return resp, nil
+		}
+	}
+
+	sourceType := s.provider.ResourcesMap[req.SourceTypeName].CoreConfigSchema().ImpliedType()
+	targetType := s.provider.ResourcesMap[req.TargetTypeName].CoreConfigSchema().ImpliedType()
+	state, err := msgpack.Unmarshal(upgraded.UpgradedState.MsgPack, sourceType)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to decode the state of the source resource",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+	b, err := msgpack.Marshal(translateState(state, targetType), targetType)
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to encode the state of the target resource",
+			Detail:   fmt.Sprintf("Unable to move the state of %s to %s: %s", req.SourceTypeName, req.TargetTypeName, err),
+		})
+		return resp, nil
+	}
+	resp.TargetState = &tfprotov5.DynamicValue{MsgPack: b}
+	resp.TargetPrivate = req.SourcePrivate
+	return resp, nil
+}
+
+// translateState translates a value to the given type by matching the attributes
+// of objects by name. Attributes missing from the value are null, and values which
+// can't be converted to the type are dropped.
+func translateState(v cty.Value, ty cty.Type) cty.Value {
+	if v.IsNull() || !v.IsKnown() {
+		return cty.NullVal(ty)
+	}
+	if v.Type().Equals(ty) {
+		return v
+	}
+
+	switch {
+	case ty.IsObjectType() && v.Type().IsObjectType():
+		attrs := make(map[string]cty.Value, len(ty.AttributeTypes()))
+		for name, at := range ty.AttributeTypes() {
+			if v.Type().HasAttribute(name) {
+				attrs[name] = translateState(v.GetAttr(name), at)
+			} else {
+				attrs[name] = cty.NullVal(at)
+			}
+		}
+		return cty.ObjectVal(attrs)
+	case ty.IsListType() && (v.Type().IsListType() || v.Type().IsSetType()):
+		if v.LengthInt() == 0 {
+			return cty.ListValEmpty(ty.ElementType())
+		}
+		var elems []cty.Value
+		for it := v.ElementIterator(); it.Next(); {
+			_, e := it.Element()
+			elems = append(elems, translateState(e, ty.ElementType()))
+		}
+		return cty.ListVal(elems)
+	case ty.IsSetType() && (v.Type().IsListType() || v.Type().IsSetType()):
+		if v.LengthInt() == 0 {
+			return cty.SetValEmpty(ty.ElementType())
+		}
+		var elems []cty.Value
+		for it := v.ElementIterator(); it.Next(); {
+			_, e := it.Element()
+			elems = append(elems, translateState(e, ty.ElementType()))
+		}
+		return cty.SetVal(elems)
+	case ty.IsMapType() && v.Type().IsMapType():
+		if v.LengthInt() == 0 {
+			return cty.MapValEmpty(ty.ElementType())
+		}
+		elems := make(map[string]cty.Value, v.LengthInt())
+		for it := v.ElementIterator(); it.Next(); {
+			k, e := it.Element()
+			elems[k.AsString()] = translateState(e, ty.ElementType())
+		}
+		return cty.MapVal(elems)
+	}
+
+	c, err := convert.Convert(v, ty)
+	if err != nil {
+		return cty.NullVal(ty)
+	}
+	return c
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+func TestMoveResourceState(t *testing.T) {
+	p := Provider()
+	server := GRPCProviderServer(p)()
+
+	cases := []struct {
+		Source  string
+		Target  string
+		Version int64
+		Error   bool
+	}{
+		{"kubernetes_deployment", "kubernetes_deployment_v1", 1, false},
+		{"kubernetes_cron_job", "kubernetes_cron_job_v1", 1, false},
+		{"kubernetes_deployment", "kubernetes_stateful_set_v1", 1, true},
+		{"kubernetes_deployment_v1", "kubernetes_deployment", 1, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Source+"_"+tc.Target, func(t *testing.T) {
+			resp, err := server.MoveResourceState(context.Background(), &tfprotov5.MoveResourceStateRequest{
+				SourceTypeName:      tc.Source,
+				SourceSchemaVersion: tc.Version,
+				SourceState: &tfprotov5.RawState{
+					JSON: []byte(`{"id":"default/test","metadata":[{"name":"test","namespace":"default","labels":{"app":"test"}}]}`),
+				},
+				SourcePrivate:  []byte(`{}`),
+				TargetTypeName: tc.Target,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.Error {
+				if len(resp.Diagnostics) == 0 {
+					t.Fatal("Expected the move to fail")
+				}
+				return
+			}
+			if len(resp.Diagnostics) != 0 {
+				t.Fatalf("Unexpected diagnostics: %s: %s", resp.Diagnostics[0].Summary, resp.Diagnostics[0].Detail)
+			}
+
+			ty := p.ResourcesMap[tc.Target].CoreConfigSchema().ImpliedType()
+			state, err := msgpack.Unmarshal(resp.TargetState.MsgPack, ty)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if id := state.GetAttr("id").AsString(); id != "default/test" {
+				t.Fatalf("Expected the ID to be moved, got %q", id)
+			}
+			metadata := state.GetAttr("metadata").Index(cty.NumberIntVal(0))
+			if label := metadata.GetAttr("labels").Index(cty.StringVal("app")).AsString(); label != "test" {
+				t.Fatalf("Expected the labels to be moved, got %q", label)
+			}
+		})
+	}
+}
+
+func TestTranslateState(t *testing.T) {
+	v := cty.ObjectVal(map[string]cty.Value{
+		"name":    cty.StringVal("test"),
+		"dropped": cty.StringVal("dropped"),
+		"port":    cty.StringVal("80"),
+		"rules": cty.SetVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{"host": cty.StringVal("example.com")}),
+		}),
+	})
+	ty := cty.Object(map[string]cty.Type{
+		"name":  cty.String,
+		"added": cty.Bool,
+		"port":  cty.Number,
+		"rules": cty.List(cty.Object(map[string]cty.Type{
+			"host": cty.String,
+			"path": cty.String,
+		})),
+	})
+	expected := cty.ObjectVal(map[string]cty.Value{
+		"name":  cty.StringVal("test"),
+		"added": cty.NullVal(cty.Bool),
+		"port":  cty.NumberIntVal(80),
+		"rules": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"host": cty.StringVal("example.com"),
+				"path": cty.NullVal(cty.String),
+			}),
+		}),
+	})
+
+	got := translateState(v, ty)
+	if !got.RawEquals(expected) {
+		t.Fatalf("Unexpected translation:\nexpected %#v\ngot %#v", expected, got)
+	}
+}
@@ -155,3 +155,23 @@ func (o *RemoveOperation) String() string {
 	b, _ := o.MarshalJSON()
 	return string(b)
 }
+
+type TestOperation struct {
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+	Op    string      `json:"op"`
+}
+
+func (o *TestOperation) GetPath() string {
+	return o.Path
+}
+
+func (o *TestOperation) MarshalJSON() ([]byte, error) {
+	o.Op = "test"
+	return json.Marshal(*o)
+}
+
+func (o *TestOperation) String() string {
+	b, _ := o.MarshalJSON()
+	return string(b)
+}
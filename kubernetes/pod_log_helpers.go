@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	batchv1 "k8s.io/api/batch/v1"
+	api "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxFailedPodLogs is the number of failed pods whose logs are reported, the
+// most recent ones. Their other pods usually failed for the same reason.
+const maxFailedPodLogs = 3
+
+// failedContainer is a container which terminated with an error.
+type failedContainer struct {
+	pod       string
+	container string
+	exitCode  int32
+	reason    string
+	// previous is set when the container was restarted since, its logs are
+	// those of its previous instance.
+	previous bool
+}
+
+// failedContainersOf lists the containers of pods which terminated with an
+// error, from the most recently started pod to the oldest one.
+func failedContainersOf(pods []api.Pod) []failedContainer {
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[j].CreationTimestamp.Before(&pods[i].CreationTimestamp)
+	})
+	var out []failedContainer
+	for _, pod := range pods {
+		for _, s := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			terminated, previous := s.State.Terminated, false
+			if terminated == nil && s.LastTerminationState.Terminated != nil {
+				terminated, previous = s.LastTerminationState.Terminated, true
+			}
+			if terminated == nil || terminated.ExitCode == 0 {
+				continue
+			}
+			out = append(out, failedContainer{
+				pod:       pod.Name,
+				container: s.Name,
+				exitCode:  terminated.ExitCode,
+				reason:    terminated.Reason,
+				previous:  previous,
+			})
+		}
+	}
+	return out
+}
+
+// failedPodLogsDiagnostics reports the last lines of the logs of the failed
+// containers of the pods of a job, so that the reason of a failure shows up in
+// the output of Terraform.
+func failedPodLogsDiagnostics(ctx context.Context, conn kubernetes.Interface, job *batchv1.Job, lines int64) diag.Diagnostics {
+	pods, err := listJobV1Pods(ctx, conn, job)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to list the pods of job %s: %s", buildId(job.ObjectMeta), err))
+		return nil
+	}
+	namespace := job.Namespace
+
+	var diags diag.Diagnostics
+	reported := map[string]bool{}
+	for _, c := range failedContainersOf(pods) {
+		if !reported[c.pod] && len(reported) == maxFailedPodLogs {
+			break
+		}
+		reported[c.pod] = true
+
+		logs, err := conn.CoreV1().Pods(namespace).GetLogs(c.pod, &api.PodLogOptions{
+			Container: c.container,
+			TailLines: &lines,
+			Previous:  c.previous,
+		}).DoRaw(ctx)
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to get the logs of container %s of pod %s/%s: %s", c.container, namespace, c.pod, err))
+			continue
+		}
+		detail := strings.TrimRight(string(logs), "\n")
+		if detail == "" {
+			detail = "The container didn't log anything."
+		}
+		reason := ""
+		if c.reason != "" {
+			reason = ", " + c.reason
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Container %q of pod %s/%s failed (exit code %d%s), last %d lines of its logs", c.container, namespace, c.pod, c.exitCode, reason, lines),
+			Detail:   detail,
+		})
+	}
+	return diags
+}
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testFailedPod(name string, created time.Time, statuses ...corev1.ContainerStatus) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "shop",
+			CreationTimestamp: metav1.NewTime(created),
+			Labels:            map[string]string{"job-name": "migrate"},
+		},
+		Status: corev1.PodStatus{ContainerStatuses: statuses},
+	}
+}
+
+func TestFailedContainersOf(t *testing.T) {
+	now := time.Now()
+	terminated := func(exitCode int32) corev1.ContainerState {
+		return corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode, Reason: "Error"}}
+	}
+	pods := []corev1.Pod{
+		*testFailedPod("migrate-old", now.Add(-time.Minute),
+			corev1.ContainerStatus{Name: "migrate", State: terminated(1)},
+			corev1.ContainerStatus{Name: "sidecar", State: terminated(0)},
+		),
+		*testFailedPod("migrate-new", now,
+			corev1.ContainerStatus{Name: "migrate", LastTerminationState: terminated(2), State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+		),
+	}
+	expected := []failedContainer{
+		{pod: "migrate-new", container: "migrate", exitCode: 2, reason: "Error", previous: true},
+		{pod: "migrate-old", container: "migrate", exitCode: 1, reason: "Error"},
+	}
+	if diff := cmp.Diff(expected, failedContainersOf(pods), cmp.AllowUnexported(failedContainer{})); diff != "" {
+		t.Fatalf("Unexpected failed containers: mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFailedPodLogsDiagnostics(t *testing.T) {
+	now := time.Now()
+	failed := corev1.ContainerStatus{Name: "migrate", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}}}
+	conn := fake.NewSimpleClientset(
+		testFailedPod("migrate-1", now.Add(-4*time.Minute), failed),
+		testFailedPod("migrate-2", now.Add(-3*time.Minute), failed),
+		testFailedPod("migrate-3", now.Add(-2*time.Minute), failed),
+		testFailedPod("migrate-4", now.Add(-1*time.Minute), failed),
+	)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "shop"},
+		Spec:       batchv1.JobSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"job-name": "migrate"}}},
+	}
+
+	diags := failedPodLogsDiagnostics(context.Background(), conn, job, 10)
+	if len(diags) != maxFailedPodLogs {
+		t.Fatalf("Expected the logs of %d pods, got %d: %v", maxFailedPodLogs, len(diags), diags)
+	}
+	expected := `Container "migrate" of pod shop/migrate-4 failed (exit code 1), last 10 lines of its logs`
+	if diags[0].Summary != expected {
+		t.Fatalf("Expected the most recent pod first with summary %q, got %q", expected, diags[0].Summary)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// withPriorityClassValidation adds the validate_priority_class attribute to a
+// resource managing pods. When it is set, applying a pod spec which references
+// a priority class which doesn't exist reports a warning, as it would otherwise
+// only show up as pods which can't be created. The check is done on apply
+// rather than on plan, since the priority class may be created by the same
+// apply.
+func withPriorityClassValidation(r *schema.Resource, specPath []string) {
+	r.Schema["validate_priority_class"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Check on apply that the priority class referenced by `priority_class_name` exists, and report a warning listing the existing priority classes when it doesn't. Requires permission to get and list priority classes.",
+		Optional:    true,
+		ForceNew:    r.UpdateContext == nil,
+		Default:     false,
+	}
+
+	key := strings.Join(specPath, ".0.") + ".0.priority_class_name"
+	warn := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		name := d.Get(key).(string)
+		if name == "" {
+			return nil
+		}
+		conn, err := meta.(KubeClientsets).MainClientset()
+		if err != nil {
+			return nil
+		}
+		if w := checkPriorityClassExists(ctx, conn.SchedulingV1().PriorityClasses(), name); w != nil {
+			return diag.Diagnostics{*w}
+		}
+		return nil
+	}
+
+	create := r.CreateContext
+	r.CreateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if !d.Get("validate_priority_class").(bool) {
+			return create(ctx, d, meta)
+		}
+		diags := warn(ctx, d, meta)
+		return append(diags, create(ctx, d, meta)...)
+	}
+	if r.UpdateContext == nil {
+		return
+	}
+	update := r.UpdateContext
+	r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if !d.Get("validate_priority_class").(bool) || !d.HasChange(key) && !d.HasChange("validate_priority_class") {
+			return update(ctx, d, meta)
+		}
+		diags := warn(ctx, d, meta)
+		return append(diags, update(ctx, d, meta)...)
+	}
+}
+
+type priorityClassGetter interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*schedulingv1.PriorityClass, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*schedulingv1.PriorityClassList, error)
+}
+
+// checkPriorityClassExists returns a warning listing the existing priority
+// classes when the named one doesn't exist. Errors other than the priority class
+// not being found, e.g. missing permissions, are only logged.
+func checkPriorityClassExists(ctx context.Context, client priorityClassGetter, name string) *diag.Diagnostic {
+	_, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to check that priority class %q exists: %s", name, err))
+		return nil
+	}
+
+	w := &diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Priority class %q doesn't exist", name),
+		Detail:   "The pods referencing the priority class can't be created until it exists.",
+	}
+	var names []string
+	if list, err := client.List(ctx, metav1.ListOptions{}); err == nil {
+		for _, pc := range list.Items {
+			names = append(names, pc.Name)
+		}
+	}
+	if len(names) > 0 {
+		w.Detail += " Existing priority classes: " + strings.Join(names, ", ")
+	}
+	return w
+}
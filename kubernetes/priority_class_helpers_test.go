@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckPriorityClassExists(t *testing.T) {
+	conn := fake.NewSimpleClientset(
+		&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "high-priority"}, Value: 1000},
+		&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "low-priority"}, Value: 10},
+	)
+	client := conn.SchedulingV1().PriorityClasses()
+
+	if w := checkPriorityClassExists(context.Background(), client, "high-priority"); w != nil {
+		t.Fatalf("Expected no warning for an existing priority class, got %#v", w)
+	}
+
+	w := checkPriorityClassExists(context.Background(), client, "hihg-priority")
+	if w == nil || w.Severity != diag.Warning {
+		t.Fatalf("Expected a warning, got %#v", w)
+	}
+	expected := "The pods referencing the priority class can't be created until it exists. Existing priority classes: high-priority, low-priority"
+	if w.Summary != `Priority class "hihg-priority" doesn't exist` || w.Detail != expected {
+		t.Fatalf("Unexpected warning %q: %q", w.Summary, w.Detail)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// waitProgressInterval is how often the progress of a long wait is reported.
+var waitProgressInterval = 30 * time.Second
+
+// waitProgress reports the state of a wait, e.g. "3/5 replicas ready", when
+// it changes and periodically while it doesn't, so that a stalled wait can be
+// told apart from a slow one.
+type waitProgress struct {
+	subject string
+	start   time.Time
+
+	mu      sync.Mutex
+	status  string
+	changed time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// startWaitProgress starts reporting the progress of waiting for subject,
+// e.g. `Deployment "default/app" rollout`. The reporting ends with Stop.
+func startWaitProgress(ctx context.Context, subject string) *waitProgress {
+	now := time.Now()
+	p := &waitProgress{
+		subject: subject,
+		start:   now,
+		changed: now,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer close(p.done)
+		ticker := time.NewTicker(waitProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.report(ctx, time.Now())
+			}
+		}
+	}()
+	return p
+}
+
+// Update records the current state of the wait, it is reported right away
+// when it differs from the previous one.
+func (p *waitProgress) Update(ctx context.Context, status string) {
+	p.mu.Lock()
+	if status == p.status {
+		p.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	p.status = status
+	p.changed = now
+	p.mu.Unlock()
+
+	p.report(ctx, now)
+}
+
+// Stop ends the periodic reports.
+func (p *waitProgress) Stop() {
+	close(p.stop)
+	<-p.done
+}
+
+func (p *waitProgress) report(ctx context.Context, now time.Time) {
+	p.mu.Lock()
+	msg, fields := p.message(now)
+	p.mu.Unlock()
+	if msg == "" {
+		return
+	}
+	tflog.Info(ctx, msg, fields)
+}
+
+// message returns the progress report at the given time, or an empty string
+// when no state was recorded yet.
+func (p *waitProgress) message(now time.Time) (string, map[string]interface{}) {
+	if p.status == "" {
+		return "", nil
+	}
+	elapsed := now.Sub(p.start).Truncate(time.Second)
+	unchanged := now.Sub(p.changed).Truncate(time.Second)
+	fields := map[string]interface{}{
+		"wait_subject":       p.subject,
+		"wait_status":        p.status,
+		"wait_elapsed":       elapsed.String(),
+		"wait_unchanged_for": unchanged.String(),
+	}
+	msg := fmt.Sprintf("Waiting for %s (%s elapsed): %s", p.subject, elapsed, p.status)
+	if unchanged >= waitProgressInterval {
+		msg += fmt.Sprintf(" (no progress for %s)", unchanged)
+	}
+	return msg, fields
+}
+
+// retryWithProgress is retry.RetryContext reporting the retryable errors of f
+// as the progress of waiting for subject.
+func retryWithProgress(ctx context.Context, timeout time.Duration, subject string, f retry.RetryFunc) error {
+	progress := startWaitProgress(ctx, subject)
+	defer progress.Stop()
+
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		rerr := f()
+		if rerr != nil && rerr.Retryable && rerr.Err != nil {
+			progress.Update(ctx, rerr.Err.Error())
+		}
+		return rerr
+	})
+}
+
+// deletionProgress describes what the deletion of an object is waiting for,
+// e.g. "terminating, waiting for finalizers [kubernetes.io/pvc-protection]".
+func deletionProgress(meta metav1.ObjectMeta) string {
+	if meta.DeletionTimestamp == nil {
+		return "not terminating yet"
+	}
+	if len(meta.Finalizers) > 0 {
+		return fmt.Sprintf("terminating, waiting for finalizers %v", meta.Finalizers)
+	}
+	return "terminating"
+}
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestWaitProgressMessage(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := &waitProgress{subject: `rollout of deployment "default/app"`, start: start, changed: start}
+
+	if msg, _ := p.message(start.Add(time.Minute)); msg != "" {
+		t.Fatalf("Expected no report before any progress, got %q", msg)
+	}
+
+	p.status = "3/5 replicas ready"
+	p.changed = start.Add(50 * time.Second)
+	msg, fields := p.message(start.Add(time.Minute))
+	expected := `Waiting for rollout of deployment "default/app" (1m0s elapsed): 3/5 replicas ready`
+	if msg != expected {
+		t.Fatalf("Unexpected report:\nwant: %s\n got: %s", expected, msg)
+	}
+	if fields["wait_unchanged_for"] != "10s" {
+		t.Fatalf("Unexpected wait_unchanged_for field %v", fields["wait_unchanged_for"])
+	}
+
+	msg, _ = p.message(start.Add(2 * time.Minute))
+	expected = `Waiting for rollout of deployment "default/app" (2m0s elapsed): 3/5 replicas ready (no progress for 1m10s)`
+	if msg != expected {
+		t.Fatalf("Unexpected report:\nwant: %s\n got: %s", expected, msg)
+	}
+}
+
+func TestDeletionProgress(t *testing.T) {
+	now := metav1.Now()
+	cases := map[string]struct {
+		meta     metav1.ObjectMeta
+		expected string
+	}{
+		"not terminating": {
+			meta:     metav1.ObjectMeta{},
+			expected: "not terminating yet",
+		},
+		"terminating": {
+			meta:     metav1.ObjectMeta{DeletionTimestamp: &now},
+			expected: "terminating",
+		},
+		"finalizers": {
+			meta:     metav1.ObjectMeta{DeletionTimestamp: &now, Finalizers: []string{"kubernetes.io/pvc-protection"}},
+			expected: "terminating, waiting for finalizers [kubernetes.io/pvc-protection]",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := deletionProgress(tc.meta); got != tc.expected {
+				t.Fatalf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDeploymentV1Progress(t *testing.T) {
+	dply := &appsv1.Deployment{Status: appsv1.DeploymentStatus{ReadyReplicas: 1}}
+	if got := deploymentV1Progress(dply, 3); got != "1/3 replicas ready" {
+		t.Fatalf("Unexpected progress: %q", got)
+	}
+
+	dply.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type:    appsv1.DeploymentReplicaFailure,
+		Status:  corev1.ConditionTrue,
+		Reason:  "FailedCreate",
+		Message: `pods "web-7d9f8b6c5a-x7k2p" is forbidden: exceeded quota: compute`,
+	}}
+	expected := `1/3 replicas ready, replica failure: FailedCreate: pods "web-7d9f8b6c5a-x7k2p" is forbidden: exceeded quota: compute`
+	if got := deploymentV1Progress(dply, 3); got != expected {
+		t.Fatalf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestJobV1Progress(t *testing.T) {
+	job := &batchv1.Job{
+		Spec:   batchv1.JobSpec{Completions: ptr.To(int32(5))},
+		Status: batchv1.JobStatus{Active: 2, Ready: ptr.To(int32(1)), Succeeded: 3, Failed: 1},
+	}
+	expected := "active=2 ready=1 succeeded=3/5 failed=1"
+	if got := jobV1Progress(job); got != expected {
+		t.Fatalf("Expected %q, got %q", expected, got)
+	}
+
+	job.Spec.Completions = nil
+	job.Status.Ready = nil
+	expected = "active=2 ready=0 succeeded=3 failed=1"
+	if got := jobV1Progress(job); got != expected {
+		t.Fatalf("Expected %q, got %q", expected, got)
+	}
+
+	job.Spec.Suspend = ptr.To(true)
+	job.Spec.ManagedBy = ptr.To("kueue.x-k8s.io/multikueue")
+	expected = "active=2 ready=0 succeeded=3 failed=1 suspended (managed by kueue.x-k8s.io/multikueue)"
+	if got := jobV1Progress(job); got != expected {
+		t.Fatalf("Expected %q, got %q", expected, got)
+	}
+
+	job.Spec.Suspend = ptr.To(false)
+	job.Spec.ManagedBy = ptr.To(batchv1.JobControllerName)
+	expected = "active=2 ready=0 succeeded=3 failed=1"
+	if got := jobV1Progress(job); got != expected {
+		t.Fatalf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestCronJobV1Progress(t *testing.T) {
+	cronJob := &batchv1.CronJob{}
+	expected := "active=0 last schedule=never"
+	if got := cronJobV1Progress(cronJob); got != expected {
+		t.Fatalf("Expected %q, got %q", expected, got)
+	}
+
+	scheduled := metav1.NewTime(time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC))
+	cronJob.Status = batchv1.CronJobStatus{
+		Active:           []corev1.ObjectReference{{Name: "backup-28574100"}},
+		LastScheduleTime: &scheduled,
+	}
+	expected = "active=1 last schedule=2024-05-01T03:00:00Z"
+	if got := cronJobV1Progress(cronJob); got != expected {
+		t.Fatalf("Expected %q, got %q", expected, got)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -15,10 +14,16 @@ import (
 
 	"github.com/hashicorp/go-cty/cty"
 	gversion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/mitchellh/go-homedir"
 
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apierror"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apilog"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apiwarning"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/batchread"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/telemetry"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -28,6 +33,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/utils/ptr"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	apimachineryschema "k8s.io/apimachinery/pkg/runtime/schema"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	restclient "k8s.io/client-go/rest"
@@ -213,6 +219,19 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Description: "List of Kubernetes metadata labels to ignore across all resources handled by this provider for situations where external systems are managing certain resource labels. Each item is a regular expression.",
 			},
+			"trace_requests": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(apilog.TraceEnvVar, false),
+				Description: "Log the headers and bodies of the requests sent to the Kubernetes API and of their responses at DEBUG level, with the values of secrets and credentials redacted and bodies truncated to 4KiB. Can be set with the `KUBE_TRACE_REQUESTS` environment variable.",
+			},
+			"batch_reads": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(batchread.EnvVar, false),
+				Description: "Refresh the resources of the same kind and namespace with a single `LIST` request of the namespace, sent in pages of 500 objects, instead of a `GET` request per object. Secrets are always read one by one. Can be set with the `KUBE_BATCH_READS` environment variable.",
+			},
+			"run_attribution": runAttributionSchema(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -231,16 +250,31 @@ func Provider() *schema.Provider {
 			"kubernetes_pod_v1":                     dataSourceKubernetesPodV1(),
 			"kubernetes_service_account":            dataSourceKubernetesServiceAccountV1(),
 			"kubernetes_service_account_v1":         dataSourceKubernetesServiceAccountV1(),
+			"kubernetes_service_account_token_v1":   dataSourceKubernetesServiceAccountTokenV1(),
 			"kubernetes_persistent_volume_v1":       dataSourceKubernetesPersistentVolumeV1(),
 			"kubernetes_persistent_volume_claim":    dataSourceKubernetesPersistentVolumeClaimV1(),
 			"kubernetes_persistent_volume_claim_v1": dataSourceKubernetesPersistentVolumeClaimV1(),
 			"kubernetes_nodes":                      dataSourceKubernetesNodes(),
 			"kubernetes_server_version":             dataSourceKubernetesServerVersion(),
+			"kubernetes_cluster_health":             dataSourceKubernetesClusterHealth(),
+			"kubernetes_api_group_versions":         dataSourceKubernetesAPIGroupVersions(),
+			"kubernetes_import_ids":                 dataSourceKubernetesImportIDs(),
+			"kubernetes_priority_classes":           dataSourceKubernetesPriorityClasses(),
+			"kubernetes_limit_ranges":               dataSourceKubernetesLimitRanges(),
+			"kubernetes_replica_sets":               dataSourceKubernetesReplicaSets(),
+			"kubernetes_resource_quotas":            dataSourceKubernetesResourceQuotas(),
+			"kubernetes_unmanaged_objects":          dataSourceKubernetesUnmanagedObjects(),
+			"kubernetes_helm_release_objects":       dataSourceKubernetesHelmReleaseObjects(),
+			"kubernetes_kubeconfig":                 dataSourceKubernetesKubeconfig(),
 
 			// networking
 			"kubernetes_ingress":    dataSourceKubernetesIngress(),
 			"kubernetes_ingress_v1": dataSourceKubernetesIngressV1(),
 
+			// batch
+			"kubernetes_cron_job_jobs": dataSourceKubernetesCronJobJobs(),
+			"kubernetes_job_logs":      dataSourceKubernetesJobLogs(),
+
 			// storage
 			"kubernetes_storage_class":    dataSourceKubernetesStorageClassV1(),
 			"kubernetes_storage_class_v1": dataSourceKubernetesStorageClassV1(),
@@ -283,6 +317,9 @@ func Provider() *schema.Provider {
 			"kubernetes_resource_quota":             resourceKubernetesResourceQuotaV1(),
 			"kubernetes_resource_quota_v1":          resourceKubernetesResourceQuotaV1(),
 
+			// events
+			"kubernetes_event_v1": resourceKubernetesEventV1(),
+
 			// api registration
 			"kubernetes_api_service":    resourceKubernetesAPIServiceV1(),
 			"kubernetes_api_service_v1": resourceKubernetesAPIServiceV1(),
@@ -296,16 +333,18 @@ func Provider() *schema.Provider {
 			"kubernetes_stateful_set_v1": resourceKubernetesStatefulSetV1(),
 
 			// batch
-			"kubernetes_job":         resourceKubernetesJobV1(),
-			"kubernetes_job_v1":      resourceKubernetesJobV1(),
-			"kubernetes_cron_job":    resourceKubernetesCronJobV1Beta1(),
-			"kubernetes_cron_job_v1": resourceKubernetesCronJobV1(),
+			"kubernetes_job":              resourceKubernetesJobV1(),
+			"kubernetes_job_v1":           resourceKubernetesJobV1(),
+			"kubernetes_cron_job":         resourceKubernetesCronJobV1Beta1(),
+			"kubernetes_cron_job_v1":      resourceKubernetesCronJobV1(),
+			"kubernetes_cron_job_trigger": resourceKubernetesCronJobTrigger(),
 
 			// autoscaling
 			"kubernetes_horizontal_pod_autoscaler":         resourceKubernetesHorizontalPodAutoscaler(),
 			"kubernetes_horizontal_pod_autoscaler_v1":      resourceKubernetesHorizontalPodAutoscalerV1(),
 			"kubernetes_horizontal_pod_autoscaler_v2beta2": resourceKubernetesHorizontalPodAutoscalerV2Beta2(),
 			"kubernetes_horizontal_pod_autoscaler_v2":      resourceKubernetesHorizontalPodAutoscalerV2(),
+			"kubernetes_vertical_pod_autoscaler_v1":        resourceKubernetesVerticalPodAutoscalerV1(),
 
 			// certificates
 			"kubernetes_certificate_signing_request":    resourceKubernetesCertificateSigningRequest(),
@@ -354,15 +393,54 @@ func Provider() *schema.Provider {
 			// provider helper resources
 			"kubernetes_labels":      resourceKubernetesLabels(),
 			"kubernetes_annotations": resourceKubernetesAnnotations(),
+			"kubernetes_prune":       resourceKubernetesPrune(),
+			"kubernetes_file_copy":   resourceKubernetesFileCopy(),
 
 			// authentication
 			"kubernetes_token_request_v1": resourceKubernetesTokenRequestV1(),
 
 			//node
 			"kubernetes_runtime_class_v1": resourceKubernetesRuntimeClassV1(),
+
+			// resource
+			"kubernetes_device_class_v1beta1":            resourceKubernetesDeviceClassV1Beta1(),
+			"kubernetes_resource_claim_v1beta1":          resourceKubernetesResourceClaimV1Beta1(),
+			"kubernetes_resource_claim_template_v1beta1": resourceKubernetesResourceClaimTemplateV1Beta1(),
 		},
 	}
 
+	for name, path := range podSpecPaths {
+		withPodSpecListOrder(p.ResourcesMap[name], path)
+		withInjectedObjectsIgnored(p.ResourcesMap[name], path)
+		withDryRunDefaults(p, p.ResourcesMap[name], path)
+		withDefaultedImagePullPolicy(p.ResourcesMap[name], path)
+		withPriorityClassValidation(p.ResourcesMap[name], path)
+		withSchedulerNameValidation(p.ResourcesMap[name], path)
+	}
+
+	for name, wr := range waitableResources {
+		withWaitBlock(p.ResourcesMap[name], wr)
+		withStuckDeletionBlock(p.ResourcesMap[name], wr)
+		withOwnedFieldsOnly(p.ResourcesMap[name], wr)
+		withOutOfBandDeletionReason(p.ResourcesMap[name], wr)
+		withImportIDNormalization(p.ResourcesMap[name], wr)
+		withRunAttribution(p.ResourcesMap[name], name, wr)
+	}
+
+	for name, kind := range inUseResources {
+		withInUseDestroyProtection(p.ResourcesMap[name], kind)
+	}
+
+	for _, r := range p.ResourcesMap {
+		withImportedDefaults(r)
+		withAPIWarnings(r)
+		withInvalidFieldDiagnostics(r)
+		withBatchedReads(r)
+	}
+	for _, r := range p.DataSourcesMap {
+		withAPIWarnings(r)
+	}
+
 	p.ConfigureProvider = func(ctx context.Context, req schema.ConfigureProviderRequest, res *schema.ConfigureProviderResponse) {
 		if req.DeferralAllowed && !req.ResourceData.GetRawConfig().IsWhollyKnown() {
 			res.Deferred = &schema.Deferred{
@@ -370,6 +448,12 @@ func Provider() *schema.Provider {
 			}
 		}
 		res.Meta, res.Diagnostics = providerConfigure(ctx, req.ResourceData, p.TerraformVersion)
+		if res.Diagnostics.HasError() || res.Deferred != nil || !req.ResourceData.GetRawConfig().IsWhollyKnown() {
+			return
+		}
+		// Only the SDKv2 provider checks the version skew, the warning would
+		// be repeated by every provider of the mux otherwise.
+		res.Diagnostics = append(res.Diagnostics, checkVersionSkew(ctx, res.Meta.(providerMetadata).config)...)
 	}
 
 	return p
@@ -380,83 +464,73 @@ type KubeClientsets interface {
 	AggregatorClientset() (*aggregator.Clientset, error)
 	DynamicClient() (dynamic.Interface, error)
 	DiscoveryClient() (discovery.DiscoveryInterface, error)
+	RESTConfig() (*restclient.Config, error)
 }
 
 type providerMetadata struct {
 	// TODO: this struct has become overloaded we should
 	// rename this or break it into smaller structs
-	config              *restclient.Config
-	mainClientset       *kubernetes.Clientset
-	aggregatorClientset *aggregator.Clientset
-	dynamicClient       dynamic.Interface
-	discoveryClient     discovery.DiscoveryInterface
+	config  *restclient.Config
+	clients *kubeClients
 
 	IgnoreAnnotations []string
 	IgnoreLabels      []string
+	RunAttribution    *runAttribution
+
+	watches *sharedWatches
 }
 
 func (k providerMetadata) MainClientset() (*kubernetes.Clientset, error) {
-	if k.mainClientset != nil {
-		return k.mainClientset, nil
+	if k.clients == nil {
+		return nil, nil
 	}
+	return k.clients.mainClientset()
+}
 
-	if k.config != nil {
-		kc, err := kubernetes.NewForConfig(k.config)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to configure client: %s", err)
-		}
-		k.mainClientset = kc
-	}
-	return k.mainClientset, nil
+// protobufConfig returns a copy of config encoding the requests and responses
+// in protobuf, which is smaller and cheaper to process for the API server. It
+// is only supported by the built-in APIs, the clients of custom resources keep
+// using JSON.
+func protobufConfig(config *restclient.Config) *restclient.Config {
+	c := restclient.CopyConfig(config)
+	c.ContentType = runtime.ContentTypeProtobuf
+	c.AcceptContentTypes = runtime.ContentTypeProtobuf + "," + runtime.ContentTypeJSON
+	return c
 }
 
 func (k providerMetadata) AggregatorClientset() (*aggregator.Clientset, error) {
-	if k.aggregatorClientset != nil {
-		return k.aggregatorClientset, nil
-	}
-	if k.config != nil {
-		ac, err := aggregator.NewForConfig(k.config)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to configure client: %s", err)
-		}
-		k.aggregatorClientset = ac
+	if k.clients == nil {
+		return nil, nil
 	}
-	return k.aggregatorClientset, nil
+	return k.clients.aggregatorClientset()
 }
 
 func (k providerMetadata) DynamicClient() (dynamic.Interface, error) {
-	if k.dynamicClient != nil {
-		return k.dynamicClient, nil
+	if k.clients == nil {
+		return nil, nil
 	}
-
-	if k.config != nil {
-		kc, err := dynamic.NewForConfig(k.config)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to configure dynamic client: %s", err)
-		}
-		k.dynamicClient = kc
-	}
-	return k.dynamicClient, nil
+	return k.clients.dynamicClient()
 }
 
 func (k providerMetadata) DiscoveryClient() (discovery.DiscoveryInterface, error) {
-	if k.discoveryClient != nil {
-		return k.discoveryClient, nil
+	if k.clients == nil {
+		return nil, nil
 	}
+	return k.clients.discoveryClient()
+}
 
-	if k.config != nil {
-		kc, err := discovery.NewDiscoveryClientForConfig(k.config)
-		if err != nil {
-			return nil, fmt.Errorf("Failed to configure discovery client: %s", err)
-		}
-		k.discoveryClient = kc
+// RESTConfig returns the configuration of the clients, for the requests which
+// aren't made through a client, e.g. port forwarding.
+func (k providerMetadata) RESTConfig() (*restclient.Config, error) {
+	if k.config == nil {
+		return nil, nil
 	}
-	return k.discoveryClient, nil
+	return restclient.CopyConfig(k.config), nil
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
 	// Config initialization
-	cfg, diags := initializeConfiguration(d)
+	cfg, diags := initializeConfiguration(ctx, d)
 	if diags.HasError() {
 		return nil, diags
 	}
@@ -470,11 +544,22 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVer
 
 	cfg.UserAgent = fmt.Sprintf("HashiCorp/1.0 Terraform/%s", terraformVersion)
 
-	if logging.IsDebugOrHigher() {
-		log.Printf("[DEBUG] Enabling HTTP requests/responses tracing")
-		cfg.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
-			return logging.NewSubsystemLoggingHTTPTransport("Kubernetes", rt)
+	trace := d.Get("trace_requests").(bool)
+	if trace {
+		tflog.Debug(ctx, "Tracing the requests sent to the Kubernetes API")
+	}
+	var reads *batchread.Cache
+	if d.Get("batch_reads").(bool) {
+		tflog.Debug(ctx, "Batching the reads of the refresh")
+		// The reads of the refresh are batched across all the clients.
+		reads = batchread.NewCache(batchread.DefaultTTL)
+	}
+	cfg.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		rt = telemetry.NewTransport(apierror.NewTransport(apiwarning.NewTransport(apilog.NewTransport(rt, trace))))
+		if reads != nil {
+			rt = reads.Transport(rt)
 		}
+		return rt
 	}
 
 	ignoreAnnotations := []string{}
@@ -488,16 +573,17 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData, terraformVer
 	}
 
 	m := providerMetadata{
-		config:              cfg,
-		mainClientset:       nil,
-		aggregatorClientset: nil,
-		IgnoreAnnotations:   ignoreAnnotations,
-		IgnoreLabels:        ignoreLabels,
+		config:            cfg,
+		clients:           newKubeClients(cfg),
+		IgnoreAnnotations: ignoreAnnotations,
+		IgnoreLabels:      ignoreLabels,
+		RunAttribution:    expandRunAttribution(d.Get("run_attribution").([]interface{})),
+		watches:           newSharedWatches(),
 	}
 	return m, diag.Diagnostics{}
 }
 
-func initializeConfiguration(d *schema.ResourceData) (*restclient.Config, diag.Diagnostics) {
+func initializeConfiguration(ctx context.Context, d *schema.ResourceData) (*restclient.Config, diag.Diagnostics) {
 	diags := make(diag.Diagnostics, 0)
 	overrides := &clientcmd.ConfigOverrides{}
 	loader := &clientcmd.ClientConfigLoadingRules{}
@@ -524,7 +610,7 @@ func initializeConfiguration(d *schema.ResourceData) (*restclient.Config, diag.D
 				return nil, append(diags, diag.FromErr(err)...)
 			}
 
-			log.Printf("[DEBUG] Using kubeconfig: %s", path)
+			tflog.Debug(ctx, fmt.Sprintf("Using kubeconfig: %s", path))
 			expandedPaths = append(expandedPaths, path)
 		}
 
@@ -544,7 +630,7 @@ func initializeConfiguration(d *schema.ResourceData) (*restclient.Config, diag.D
 			if ctxOk {
 				overrides.CurrentContext = kubectx.(string)
 				ctxSuffix += fmt.Sprintf("; config ctx: %s", overrides.CurrentContext)
-				log.Printf("[DEBUG] Using custom current context: %q", overrides.CurrentContext)
+				tflog.Debug(ctx, fmt.Sprintf("Using custom current context: %q", overrides.CurrentContext))
 			}
 
 			overrides.Context = clientcmdapi.Context{}
@@ -556,7 +642,7 @@ func initializeConfiguration(d *schema.ResourceData) (*restclient.Config, diag.D
 				overrides.Context.Cluster = cluster.(string)
 				ctxSuffix += fmt.Sprintf("; cluster: %s", overrides.Context.Cluster)
 			}
-			log.Printf("[DEBUG] Using overridden context: %#v", overrides.Context)
+			tflog.Debug(ctx, fmt.Sprintf("Using overridden context: %#v", overrides.Context))
 		}
 	}
 
@@ -639,7 +725,7 @@ func initializeConfiguration(d *schema.ResourceData) (*restclient.Config, diag.D
 			Summary:  "Provider was supplied an invalid configuration. Further operations likely to fail.",
 			Detail:   err.Error(),
 		}
-		log.Printf("[WARN] Provider was supplied an invalid configuration. Further operations likely to fail: %v", err)
+		tflog.Warn(ctx, fmt.Sprintf("Provider was supplied an invalid configuration. Further operations likely to fail: %v", err))
 		return nil, append(diags, nd)
 	}
 
@@ -648,7 +734,7 @@ func initializeConfiguration(d *schema.ResourceData) (*restclient.Config, diag.D
 
 var useadmissionregistrationv1beta1 *bool
 
-func useAdmissionregistrationV1beta1(conn *kubernetes.Clientset) (bool, error) {
+func useAdmissionregistrationV1beta1(ctx context.Context, conn *kubernetes.Clientset) (bool, error) {
 	if useadmissionregistrationv1beta1 != nil {
 		return *useadmissionregistrationv1beta1, nil
 	}
@@ -664,7 +750,7 @@ func useAdmissionregistrationV1beta1(conn *kubernetes.Clientset) (bool, error) {
 
 	err = discovery.ServerSupportsVersion(d, v1)
 	if err == nil {
-		log.Printf("[INFO] Using %s/v1", group)
+		tflog.Info(ctx, fmt.Sprintf("Using %s/v1", group))
 		useadmissionregistrationv1beta1 = ptr.To(false)
 		return false, nil
 	}
@@ -679,7 +765,7 @@ func useAdmissionregistrationV1beta1(conn *kubernetes.Clientset) (bool, error) {
 		return false, err
 	}
 
-	log.Printf("[INFO] Using %s/v1beta1", group)
+	tflog.Info(ctx, fmt.Sprintf("Using %s/v1beta1", group))
 	useadmissionregistrationv1beta1 = ptr.To(true)
 	return true, nil
 }
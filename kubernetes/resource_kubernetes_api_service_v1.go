@@ -5,16 +5,22 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	v1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	aggregator "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
 )
 
 func resourceKubernetesAPIServiceV1() *schema.Resource {
@@ -27,6 +33,10 @@ func resourceKubernetesAPIServiceV1() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"metadata": metadataSchema("api_service", true),
@@ -101,6 +111,12 @@ func resourceKubernetesAPIServiceV1() *schema.Resource {
 					},
 				},
 			},
+			"wait_for_available": {
+				Type:        schema.TypeBool,
+				Description: "Terraform will wait for the API service to report the `Available` condition, i.e. for the aggregated API server to be reachable and to serve its API version, before considering the resource created or updated. Defaults to false.",
+				Optional:    true,
+				Default:     false,
+			},
 		},
 	}
 }
@@ -117,14 +133,21 @@ func resourceKubernetesAPIServiceV1Create(ctx context.Context, d *schema.Resourc
 		Spec:       expandAPIServiceV1Spec(d.Get("spec").([]interface{})),
 	}
 
-	log.Printf("[INFO] Creating new API service: %#v", svc)
+	tflog.Info(ctx, fmt.Sprintf("Creating new API service: %#v", svc))
 	out, err := conn.ApiregistrationV1().APIServices().Create(ctx, &svc, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new API service: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new API service: %#v", out))
 	d.SetId(out.ObjectMeta.Name)
 
+	if d.Get("wait_for_available").(bool) {
+		err = waitForAPIServiceV1Available(ctx, conn, out.Name, d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceKubernetesAPIServiceV1Read(ctx, d, meta)
 }
 
@@ -143,20 +166,20 @@ func resourceKubernetesAPIServiceV1Read(ctx context.Context, d *schema.ResourceD
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Reading service %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading service %s", name))
 	svc, err := conn.ApiregistrationV1().APIServices().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received API service: %#v", svc)
+	tflog.Info(ctx, fmt.Sprintf("Received API service: %#v", svc))
 	err = d.Set("metadata", flattenMetadata(svc.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattened := flattenAPIServiceV1Spec(svc.Spec)
-	log.Printf("[DEBUG] Flattened API service spec: %#v", flattened)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened API service spec: %#v", flattened))
 	err = d.Set("spec", flattened)
 	if err != nil {
 		return diag.FromErr(err)
@@ -183,14 +206,21 @@ func resourceKubernetesAPIServiceV1Update(ctx context.Context, d *schema.Resourc
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating service %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating service %q: %v", name, string(data)))
 	out, err := conn.ApiregistrationV1().APIServices().Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update API service: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated API service: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated API service: %#v", out))
 	d.SetId(out.ObjectMeta.Name)
 
+	if d.Get("wait_for_available").(bool) {
+		err = waitForAPIServiceV1Available(ctx, conn, out.Name, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceKubernetesAPIServiceV1Read(ctx, d, meta)
 }
 
@@ -202,7 +232,7 @@ func resourceKubernetesAPIServiceV1Delete(ctx context.Context, d *schema.Resourc
 
 	name := d.Id()
 
-	log.Printf("[INFO] Deleting API service: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting API service: %#v", name))
 	err = conn.ApiregistrationV1().APIServices().Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -211,7 +241,7 @@ func resourceKubernetesAPIServiceV1Delete(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] API service %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("API service %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -225,13 +255,46 @@ func resourceKubernetesAPIServiceV1Exists(ctx context.Context, d *schema.Resourc
 
 	name := d.Id()
 
-	log.Printf("[INFO] Checking API service %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking API service %s", name))
 	_, err = conn.ApiregistrationV1().APIServices().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
+
+// waitForAPIServiceV1Available watches the API service until it reports the
+// Available condition. On timeout, the error includes the reason the
+// aggregated API server isn't available, e.g. a missing service or endpoints.
+func waitForAPIServiceV1Available(ctx context.Context, conn *aggregator.Clientset, name string, timeout time.Duration) error {
+	tflog.Debug(ctx, fmt.Sprintf("Waiting for API service %q to be available", name))
+
+	lw := singleObjectListWatch[*v1.APIServiceList](ctx, conn.ApiregistrationV1().APIServices(), name)
+	return watchUntil(ctx, timeout, fmt.Sprintf("API service %q to be available", name), lw, &v1.APIService{}, func(event watch.Event) *retry.RetryError {
+		out, ok := event.Object.(*v1.APIService)
+		if event.Type == watch.Deleted || !ok {
+			return retry.NonRetryableError(fmt.Errorf("API service %q was deleted while waiting for it to be available", name))
+		}
+		return apiServiceV1Available(out)
+	})
+}
+
+func apiServiceV1Available(svc *v1.APIService) *retry.RetryError {
+	var unmet []string
+	for _, c := range svc.Status.Conditions {
+		if c.Type != v1.Available {
+			continue
+		}
+		if c.Status == v1.ConditionTrue {
+			return nil
+		}
+		unmet = append(unmet, fmt.Sprintf("%s is %s (%s): %s", c.Type, c.Status, c.Reason, c.Message))
+	}
+	if len(unmet) == 0 {
+		return retry.RetryableError(fmt.Errorf("Waiting for API service %q to report its conditions", svc.Name))
+	}
+	return retry.RetryableError(fmt.Errorf("Waiting for API service %q to be available: %s", svc.Name, strings.Join(unmet, "; ")))
+}
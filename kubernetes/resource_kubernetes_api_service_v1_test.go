@@ -6,14 +6,46 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
 )
 
+func TestAPIServiceV1Available(t *testing.T) {
+	svc := func(conditions ...v1.APIServiceCondition) *v1.APIService {
+		return &v1.APIService{
+			ObjectMeta: metav1.ObjectMeta{Name: "v1beta1.metrics.k8s.io"},
+			Status:     v1.APIServiceStatus{Conditions: conditions},
+		}
+	}
+
+	if err := apiServiceV1Available(svc(v1.APIServiceCondition{Type: v1.Available, Status: v1.ConditionTrue})); err != nil {
+		t.Fatalf("Expected the API service to be available, got %v", err.Err)
+	}
+
+	err := apiServiceV1Available(svc(v1.APIServiceCondition{
+		Type:    v1.Available,
+		Status:  v1.ConditionFalse,
+		Reason:  "ServiceNotFound",
+		Message: `service/metrics-server in "kube-system" is not present`,
+	}))
+	if err == nil || !err.Retryable {
+		t.Fatalf("Expected a retryable error, got %v", err)
+	}
+	if !strings.Contains(err.Err.Error(), "Available is False (ServiceNotFound)") {
+		t.Fatalf("Expected the error to explain the condition, got %q", err.Err)
+	}
+
+	if err := apiServiceV1Available(svc()); err == nil || !err.Retryable {
+		t.Fatalf("Expected a retryable error without conditions, got %v", err)
+	}
+}
+
 func TestAccKubernetesAPIServiceV1_basic(t *testing.T) {
 	group := fmt.Sprintf("tf-acc-test-%s.k8s.io", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
 	version := "v1"
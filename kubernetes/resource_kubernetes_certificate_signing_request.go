@@ -6,10 +6,10 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"reflect"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -104,7 +104,7 @@ func resourceKubernetesCertificateSigningRequestCreate(ctx context.Context, d *s
 		ObjectMeta: metadata,
 		Spec:       *spec,
 	}
-	log.Printf("[INFO] Creating new certificate signing request: %#v", csr)
+	tflog.Info(ctx, fmt.Sprintf("Creating new certificate signing request: %#v", csr))
 	newCSR, createErr := conn.CertificatesV1beta1().CertificateSigningRequests().Create(ctx, &csr, metav1.CreateOptions{})
 	if createErr != nil {
 		return diag.Errorf("Failed to create certificate signing request: %s", createErr)
@@ -138,7 +138,7 @@ func resourceKubernetesCertificateSigningRequestCreate(ctx context.Context, d *s
 		fmt.Println("CSR auto-approve update succeeded")
 	}
 
-	log.Printf("[DEBUG] Waiting for certificate to be issued")
+	tflog.Debug(ctx, "Waiting for certificate to be issued")
 	stateConf := &retry.StateChangeConf{
 		Target:  []string{"Issued"},
 		Pending: []string{"", "Approved"},
@@ -146,7 +146,7 @@ func resourceKubernetesCertificateSigningRequestCreate(ctx context.Context, d *s
 		Refresh: func() (interface{}, string, error) {
 			out, refreshErr := conn.CertificatesV1beta1().CertificateSigningRequests().Get(ctx, csrName, metav1.GetOptions{})
 			if refreshErr != nil {
-				log.Printf("[ERROR] Received error: %v", refreshErr)
+				tflog.Error(ctx, fmt.Sprintf("Received error: %v", refreshErr))
 				return out, "Error", refreshErr
 			}
 			var csrStatus string
@@ -166,15 +166,15 @@ func resourceKubernetesCertificateSigningRequestCreate(ctx context.Context, d *s
 			// Check to see if a certificate has been issued, and update status accordingly,
 			// since 'Issued' is not a state ever populated in the Status Conditions.
 			for _, condition := range out.Status.Conditions {
-				log.Printf("[DEBUG] Found Status.Condition.Type: %v", condition.Type)
+				tflog.Debug(ctx, fmt.Sprintf("Found Status.Condition.Type: %v", condition.Type))
 				if string(condition.Type) == "Approved" {
 					if string(out.Status.Certificate) != "" {
-						log.Printf("[DEBUG] Found non-empty Certificate field in Status")
+						tflog.Debug(ctx, "Found non-empty Certificate field in Status")
 						csrStatus = "Issued"
 					}
 				}
 			}
-			log.Printf("[DEBUG] CertificateSigningRequest %s status received: %#v", csrName, csrStatus)
+			tflog.Debug(ctx, fmt.Sprintf("CertificateSigningRequest %s status received: %#v", csrName, csrStatus))
 			return out, csrStatus, nil
 		},
 	}
@@ -182,7 +182,7 @@ func resourceKubernetesCertificateSigningRequestCreate(ctx context.Context, d *s
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Certificate issued for request: %s", csrName)
+	tflog.Info(ctx, fmt.Sprintf("Certificate issued for request: %s", csrName))
 
 	issued, err := conn.CertificatesV1beta1().CertificateSigningRequests().Get(ctx, csrName, metav1.GetOptions{})
 	if err != nil {
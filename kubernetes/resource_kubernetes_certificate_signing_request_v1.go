@@ -5,13 +5,17 @@ package kubernetes
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
-	"log"
+	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	certificates "k8s.io/api/certificates/v1"
 	v1 "k8s.io/api/core/v1"
@@ -28,6 +32,7 @@ func resourceKubernetesCertificateSigningRequestV1() *schema.Resource {
 		Description:   "Use this resource to generate TLS certificates using Kubernetes. This is a *logical resource*, so it contributes only to the current Terraform state and does not persist any external managed resources. This resource enables automation of [X.509](https://www.itu.int/rec/T-REC-X.509) credential provisioning (including TLS/SSL certificates). It does this by creating a CertificateSigningRequest using the Kubernetes API, which generates a certificate from the Certificate Authority (CA) configured in the Kubernetes cluster. The CSR can be approved automatically by Terraform, or it can be approved by a custom controller running in Kubernetes. See [Kubernetes reference](https://kubernetes.io/docs/reference/access-authn-authz/certificate-signing-requests/) for all available options pertaining to CertificateSigningRequests.",
 		CreateContext: resourceKubernetesCertificateSigningRequestV1Create,
 		ReadContext:   resourceKubernetesCertificateSigningRequestV1Read,
+		UpdateContext: resourceKubernetesCertificateSigningRequestV1Update,
 		DeleteContext: resourceKubernetesCertificateSigningRequestV1Delete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -35,6 +40,17 @@ func resourceKubernetesCertificateSigningRequestV1() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
 		},
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			// Read marks the certificate as ready for renewal, which is planned
+			// as a replacement issuing a new certificate.
+			if diff.Id() == "" || !diff.Get("ready_for_renewal").(bool) {
+				return nil
+			}
+			if err := diff.SetNew("ready_for_renewal", false); err != nil {
+				return err
+			}
+			return diff.ForceNew("ready_for_renewal")
+		},
 		Schema: map[string]*schema.Schema{
 			"auto_approve": {
 				Type:        schema.TypeBool,
@@ -48,7 +64,19 @@ func resourceKubernetesCertificateSigningRequestV1() *schema.Resource {
 				Description: apiDocStatus["certificate"],
 				Computed:    true,
 			},
+			"early_renewal_hours": {
+				Type:         schema.TypeInt,
+				Description:  "The certificate is replaced by a new one, issued for a new certificate signing request, when it expires in less than this number of hours. The replacement is planned by the first Terraform run from then on. Defaults to 0, which replaces the certificate once it has expired.",
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 			"metadata": metadataSchemaForceNew(metadataSchema("certificate signing request", true)),
+			"ready_for_renewal": {
+				Type:        schema.TypeBool,
+				Description: "Whether the certificate is within `early_renewal_hours` of its expiry, or has expired, and will be replaced.",
+				Computed:    true,
+			},
 			"spec": {
 				ForceNew:    true,
 				Type:        schema.TypeList,
@@ -86,6 +114,11 @@ func resourceKubernetesCertificateSigningRequestV1() *schema.Resource {
 					},
 				},
 			},
+			"validity_end_time": {
+				Type:        schema.TypeString,
+				Description: "The time the certificate expires, in RFC 3339 format.",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -106,7 +139,7 @@ func resourceKubernetesCertificateSigningRequestV1Create(ctx context.Context, d
 		ObjectMeta: metadata,
 		Spec:       *spec,
 	}
-	log.Printf("[INFO] Creating new certificate signing request: %#v", csr)
+	tflog.Info(ctx, fmt.Sprintf("Creating new certificate signing request: %#v", csr))
 	newCSR, err := conn.CertificatesV1().CertificateSigningRequests().Create(ctx, &csr, metav1.CreateOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to create certificate signing request: %s", err)
@@ -140,35 +173,35 @@ func resourceKubernetesCertificateSigningRequestV1Create(ctx context.Context, d
 		if retryErr != nil {
 			return diag.Errorf("CSR auto-approve update failed: %v", retryErr)
 		}
-		log.Printf("[DEBUG] Auto approve succeeded")
+		tflog.Debug(ctx, "Auto approve succeeded")
 	}
 
-	log.Printf("[DEBUG] Waiting for certificate to be issued")
+	tflog.Debug(ctx, "Waiting for certificate to be issued")
 	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
 		out, err := conn.CertificatesV1().CertificateSigningRequests().Get(ctx, csrName, metav1.GetOptions{})
 		if err != nil {
-			log.Printf("[ERROR] Received error: %v", err)
+			tflog.Error(ctx, fmt.Sprintf("Received error: %v", err))
 			return retry.NonRetryableError(err)
 		}
 
 		// Check to see if a certificate has been issued, and update status accordingly,
 		// since 'Issued' is not a state ever populated in the Status Conditions.
 		for _, condition := range out.Status.Conditions {
-			log.Printf("[DEBUG] Found Status.Condition.Type: %v", condition.Type)
+			tflog.Debug(ctx, fmt.Sprintf("Found Status.Condition.Type: %v", condition.Type))
 			if condition.Type == certificates.CertificateApproved &&
 				len(out.Status.Certificate) > 0 {
-				log.Printf("[DEBUG] Found non-empty Certificate field in Status")
+				tflog.Debug(ctx, "Found non-empty Certificate field in Status")
 				return nil
 
 			}
 		}
-		log.Printf("[DEBUG] CertificateSigningRequest %s status received: %#v", csrName, out.Status)
+		tflog.Debug(ctx, fmt.Sprintf("CertificateSigningRequest %s status received: %#v", csrName, out.Status))
 		return retry.RetryableError(errors.New("Waiting for certificate to be issued"))
 	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Certificate issued for request: %s", csrName)
+	tflog.Info(ctx, fmt.Sprintf("Certificate issued for request: %s", csrName))
 
 	issued, err := conn.CertificatesV1().CertificateSigningRequests().Get(ctx, csrName, metav1.GetOptions{})
 	if err != nil {
@@ -181,12 +214,48 @@ func resourceKubernetesCertificateSigningRequestV1Create(ctx context.Context, d
 	return resourceKubernetesCertificateSigningRequestV1Read(ctx, d, meta)
 }
 
-// resourceKubernetesCertificateSigningRequestV1Read does not return any data, because Read functions exist to
-// sync the local state with the remote state. Since this data is local-only, there is nothing to read.
-func resourceKubernetesCertificateSigningRequestV1Read(ctx context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+// resourceKubernetesCertificateSigningRequestV1Read does not read anything from the cluster, because the CSR is
+// deleted once the certificate is issued and the certificate is only kept in the state. It checks whether the
+// certificate is due for renewal instead.
+func resourceKubernetesCertificateSigningRequestV1Read(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	cert := d.Get("certificate").(string)
+	if cert == "" {
+		return diag.Diagnostics{}
+	}
+	notAfter, err := certificateNotAfter(cert)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to check the expiry of the certificate: %s", err))
+		return diag.Diagnostics{}
+	}
+	d.Set("validity_end_time", notAfter.Format(time.RFC3339))
+	d.Set("ready_for_renewal", certificateRenewalDue(notAfter, d.Get("early_renewal_hours").(int), time.Now()))
 	return diag.Diagnostics{}
 }
 
+// resourceKubernetesCertificateSigningRequestV1Update only stores the new early_renewal_hours, all the other
+// attributes force a new certificate.
+func resourceKubernetesCertificateSigningRequestV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceKubernetesCertificateSigningRequestV1Read(ctx, d, meta)
+}
+
+// certificateNotAfter returns the expiry of the first certificate of a PEM encoded certificate chain.
+func certificateNotAfter(in string) (time.Time, error) {
+	block, _ := pem.Decode([]byte(in))
+	if block == nil {
+		return time.Time{}, errors.New("no PEM encoded certificate found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cert.NotAfter, nil
+}
+
+// certificateRenewalDue reports whether a certificate expiring at notAfter is within earlyRenewalHours of its expiry.
+func certificateRenewalDue(notAfter time.Time, earlyRenewalHours int, now time.Time) bool {
+	return !now.Before(notAfter.Add(-time.Duration(earlyRenewalHours) * time.Hour))
+}
+
 func resourceKubernetesCertificateSigningRequestV1Delete(ctx context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	d.SetId("")
 	return diag.Diagnostics{}
@@ -5,9 +5,17 @@ package kubernetes
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -15,6 +23,51 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+func TestCertificateSigningRequestV1Renewal(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := certificateNotAfter(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(notAfter) {
+		t.Fatalf("Expected the certificate to expire at %s, got %s", notAfter, got)
+	}
+	if _, err := certificateNotAfter("not a certificate"); err == nil {
+		t.Fatal("Expected an error without a PEM encoded certificate")
+	}
+
+	cases := []struct {
+		hours int
+		now   time.Time
+		due   bool
+	}{
+		{0, notAfter.Add(-time.Minute), false},
+		{0, notAfter, true},
+		{24, notAfter.Add(-25 * time.Hour), false},
+		{24, notAfter.Add(-23 * time.Hour), true},
+	}
+	for _, c := range cases {
+		if due := certificateRenewalDue(notAfter, c.hours, c.now); due != c.due {
+			t.Errorf("Expected renewal due to be %t %s before expiry with %d early renewal hours", c.due, notAfter.Sub(c.now), c.hours)
+		}
+	}
+}
+
 func TestAccKubernetesCertificateSigningRequestV1_basic(t *testing.T) {
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
 	usages := []string{"client auth"}
@@ -40,6 +93,8 @@ func TestAccKubernetesCertificateSigningRequestV1_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "spec.0.signer_name", signerName),
 					resource.TestCheckResourceAttr(resourceName, "spec.0.usages.0", usages[0]),
 					resource.TestCheckResourceAttr(resourceName, "spec.0.expiration_seconds", "604800"),
+					resource.TestCheckResourceAttrSet(resourceName, "validity_end_time"),
+					resource.TestCheckResourceAttr(resourceName, "ready_for_renewal", "false"),
 				),
 			},
 		},
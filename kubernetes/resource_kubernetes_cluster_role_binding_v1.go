@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -63,13 +64,13 @@ func resourceKubernetesClusterRoleBindingV1Create(ctx context.Context, d *schema
 		RoleRef:    expandRBACRoleRef(d.Get("role_ref").([]interface{})),
 		Subjects:   expandRBACSubjects(d.Get("subject").([]interface{})),
 	}
-	log.Printf("[INFO] Creating new ClusterRoleBinding: %#v", binding)
+	tflog.Info(ctx, fmt.Sprintf("Creating new ClusterRoleBinding: %#v", binding))
 	binding, err = conn.RbacV1().ClusterRoleBindings().Create(ctx, binding, metav1.CreateOptions{})
 
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new ClusterRoleBinding: %#v", binding)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new ClusterRoleBinding: %#v", binding))
 	d.SetId(binding.Name)
 
 	return resourceKubernetesClusterRoleBindingV1Read(ctx, d, meta)
@@ -90,28 +91,28 @@ func resourceKubernetesClusterRoleBindingV1Read(ctx context.Context, d *schema.R
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Reading ClusterRoleBinding %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading ClusterRoleBinding %s", name))
 	binding, err := conn.RbacV1().ClusterRoleBindings().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Received ClusterRoleBinding: %#v", binding)
+	tflog.Info(ctx, fmt.Sprintf("Received ClusterRoleBinding: %#v", binding))
 	err = d.Set("metadata", flattenMetadata(binding.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattenedRef := flattenRBACRoleRef(binding.RoleRef)
-	log.Printf("[DEBUG] Flattened ClusterRoleBinding roleRef: %#v", flattenedRef)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened ClusterRoleBinding roleRef: %#v", flattenedRef))
 	err = d.Set("role_ref", flattenedRef)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattenedSubjects := flattenRBACSubjects(binding.Subjects)
-	log.Printf("[DEBUG] Flattened ClusterRoleBinding subjects: %#v", flattenedSubjects)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened ClusterRoleBinding subjects: %#v", flattenedSubjects))
 	err = d.Set("subject", flattenedSubjects)
 	if err != nil {
 		return diag.FromErr(err)
@@ -137,12 +138,12 @@ func resourceKubernetesClusterRoleBindingV1Update(ctx context.Context, d *schema
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating ClusterRoleBinding %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating ClusterRoleBinding %q: %v", name, string(data)))
 	out, err := conn.RbacV1().ClusterRoleBindings().Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update ClusterRoleBinding: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated ClusterRoleBinding: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated ClusterRoleBinding: %#v", out))
 	d.SetId(out.ObjectMeta.Name)
 
 	return resourceKubernetesClusterRoleBindingV1Read(ctx, d, meta)
@@ -155,7 +156,7 @@ func resourceKubernetesClusterRoleBindingV1Delete(ctx context.Context, d *schema
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Deleting ClusterRoleBinding: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting ClusterRoleBinding: %#v", name))
 	err = conn.RbacV1().ClusterRoleBindings().Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -163,7 +164,7 @@ func resourceKubernetesClusterRoleBindingV1Delete(ctx context.Context, d *schema
 		}
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] ClusterRoleBinding %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("ClusterRoleBinding %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -176,13 +177,13 @@ func resourceKubernetesClusterRoleBindingV1Exists(ctx context.Context, d *schema
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Checking ClusterRoleBinding %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking ClusterRoleBinding %s", name))
 	_, err = conn.RbacV1().ClusterRoleBindings().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -76,12 +77,12 @@ func resourceKubernetesClusterRoleV1Create(ctx context.Context, d *schema.Resour
 		cRole.AggregationRule = expandClusterRoleAggregationRule(v.([]interface{}))
 	}
 
-	log.Printf("[INFO] Creating new cluster role: %#v", cRole)
+	tflog.Info(ctx, fmt.Sprintf("Creating new cluster role: %#v", cRole))
 	out, err := conn.RbacV1().ClusterRoles().Create(ctx, &cRole, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new cluster role: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new cluster role: %#v", out))
 	d.SetId(out.Name)
 
 	return resourceKubernetesClusterRoleV1Read(ctx, d, meta)
@@ -107,12 +108,12 @@ func resourceKubernetesClusterRoleV1Update(ctx context.Context, d *schema.Resour
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating ClusterRole %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating ClusterRole %q: %v", name, string(data)))
 	out, err := conn.RbacV1().ClusterRoles().Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update ClusterRole: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated ClusterRole: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated ClusterRole: %#v", out))
 	d.SetId(out.ObjectMeta.Name)
 
 	return resourceKubernetesClusterRoleV1Read(ctx, d, meta)
@@ -134,17 +135,17 @@ func resourceKubernetesClusterRoleV1Read(ctx context.Context, d *schema.Resource
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Reading cluster role %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading cluster role %s", name))
 	cRole, err := conn.RbacV1().ClusterRoles().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if errors.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received cluster role: %#v", cRole)
+	tflog.Info(ctx, fmt.Sprintf("Received cluster role: %#v", cRole))
 	err = d.Set("metadata", flattenMetadata(cRole.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
@@ -169,7 +170,7 @@ func resourceKubernetesClusterRoleV1Delete(ctx context.Context, d *schema.Resour
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Deleting cluster role: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting cluster role: %#v", name))
 	err = conn.RbacV1().ClusterRoles().Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -178,7 +179,7 @@ func resourceKubernetesClusterRoleV1Delete(ctx context.Context, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] cluster role %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("cluster role %s deleted", name))
 
 	return nil
 }
@@ -190,13 +191,13 @@ func resourceKubernetesClusterRoleV1Exists(ctx context.Context, d *schema.Resour
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Checking cluster role %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking cluster role %s", name))
 	_, err = conn.RbacV1().ClusterRoles().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -54,21 +55,24 @@ func resourceKubernetesConfigMapV1() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("config map", true),
 			"binary_data": {
-				Type:         schema.TypeMap,
-				Description:  "BinaryData contains the binary data. Each key must consist of alphanumeric characters, '-', '_' or '.'. BinaryData can contain byte sequences that are not in the UTF-8 range. The keys stored in BinaryData must not overlap with the ones in the Data field, this is enforced during validation process. Using this field will require 1.10+ apiserver and kubelet. This field only accepts base64-encoded payloads that will be decoded/encoded before being sent/received to/from the apiserver.",
-				Optional:     true,
-				ValidateFunc: validateBase64EncodedMap,
+				Type:             schema.TypeMap,
+				Description:      "BinaryData contains the binary data. Each key must consist of alphanumeric characters, '-', '_' or '.'. BinaryData can contain byte sequences that are not in the UTF-8 range. The keys stored in BinaryData must not overlap with the ones in the Data field, this is enforced during validation process. Using this field will require 1.10+ apiserver and kubelet. This field only accepts base64-encoded payloads that will be decoded/encoded before being sent/received to/from the apiserver.",
+				Optional:         true,
+				ValidateFunc:     validateBase64EncodedMap,
+				DiffSuppressFunc: suppressHashedValueDiff,
 			},
 			"data": {
-				Type:        schema.TypeMap,
-				Description: "Data contains the configuration data. Each key must consist of alphanumeric characters, '-', '_' or '.'. Values with non-UTF-8 byte sequences must use the BinaryData field. The keys stored in Data must not overlap with the keys in the BinaryData field, this is enforced during validation process.",
-				Optional:    true,
+				Type:             schema.TypeMap,
+				Description:      "Data contains the configuration data. Each key must consist of alphanumeric characters, '-', '_' or '.'. Values with non-UTF-8 byte sequences must use the BinaryData field. The keys stored in Data must not overlap with the keys in the BinaryData field, this is enforced during validation process.",
+				Optional:         true,
+				DiffSuppressFunc: suppressHashedValueDiff,
 			},
 			"immutable": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Description: "Immutable, if set to true, ensures that data stored in the ConfigMap cannot be updated (only object metadata can be modified). If not set to true, the field can be modified at any time. Defaulted to nil.",
 			},
+			"state_hash_threshold": stateHashThresholdSchema("config map"),
 		},
 	}
 }
@@ -87,12 +91,12 @@ func resourceKubernetesConfigMapV1Create(ctx context.Context, d *schema.Resource
 		Immutable:  ptr.To(d.Get("immutable").(bool)),
 	}
 
-	log.Printf("[INFO] Creating new config map: %#v", cfgMap)
+	tflog.Info(ctx, fmt.Sprintf("Creating new config map: %#v", cfgMap))
 	out, err := conn.CoreV1().ConfigMaps(metadata.Namespace).Create(ctx, &cfgMap, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new config map: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new config map: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesConfigMapV1Read(ctx, d, meta)
@@ -116,20 +120,21 @@ func resourceKubernetesConfigMapV1Read(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Reading config map %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading config map %s", name))
 	cfgMap, err := conn.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received config map: %#v", cfgMap)
+	tflog.Info(ctx, fmt.Sprintf("Received config map: %#v", cfgMap))
 	err = d.Set("metadata", flattenMetadata(cfgMap.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.Set("binary_data", flattenByteMapToBase64Map(cfgMap.BinaryData))
-	d.Set("data", cfgMap.Data)
+	threshold := d.Get("state_hash_threshold").(int)
+	d.Set("binary_data", hashLargeValues(flattenByteMapToBase64Map(cfgMap.BinaryData), threshold))
+	d.Set("data", hashLargeValues(cfgMap.Data, threshold))
 	d.Set("immutable", cfgMap.Immutable)
 
 	return nil
@@ -171,12 +176,12 @@ func resourceKubernetesConfigMapV1Update(ctx context.Context, d *schema.Resource
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
 
-	log.Printf("[INFO] Updating config map %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating config map %q: %v", name, string(data)))
 	out, err := conn.CoreV1().ConfigMaps(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update Config Map: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated config map: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated config map: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesConfigMapV1Read(ctx, d, meta)
@@ -192,7 +197,7 @@ func resourceKubernetesConfigMapV1Delete(ctx context.Context, d *schema.Resource
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Deleting config map: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting config map: %#v", name))
 	err = conn.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -201,7 +206,7 @@ func resourceKubernetesConfigMapV1Delete(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Config map %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Config map %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -218,13 +223,13 @@ func resourceKubernetesConfigMapV1Exists(ctx context.Context, d *schema.Resource
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking config map %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking config map %s", name))
 	_, err = conn.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -241,6 +242,38 @@ func TestAccKubernetesConfigMap_immutable(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesConfigMapV1_stateHashThreshold(t *testing.T) {
+	var conf corev1.ConfigMap
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "kubernetes_config_map_v1.test"
+	large := strings.Repeat("a", 2048)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesConfigMapV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesConfigMapV1Config_stateHashThreshold(name, large),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesConfigMapV1Exists(resourceName, &conf),
+					testAccCheckConfigMapV1Data(&conf, map[string]string{"small": "one", "large": large}),
+					resource.TestCheckResourceAttr(resourceName, "data.small", "one"),
+					resource.TestCheckResourceAttr(resourceName, "data.large", hashedValue(large)),
+				),
+			},
+			{
+				Config: testAccKubernetesConfigMapV1Config_stateHashThreshold(name, large+"b"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesConfigMapV1Exists(resourceName, &conf),
+					testAccCheckConfigMapV1Data(&conf, map[string]string{"small": "one", "large": large + "b"}),
+					resource.TestCheckResourceAttr(resourceName, "data.large", hashedValue(large+"b")),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckConfigMapV1Data(m *corev1.ConfigMap, expected map[string]string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if len(expected) == 0 && len(m.Data) == 0 {
@@ -465,3 +498,19 @@ func testAccKubernetesConfigMapV1Config_immutable(name string, immutable bool, d
 }
 `, name, immutable, data)
 }
+
+func testAccKubernetesConfigMapV1Config_stateHashThreshold(name, large string) string {
+	return fmt.Sprintf(`resource "kubernetes_config_map_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  state_hash_threshold = 1024
+
+  data = {
+    small = "one"
+    large = "%s"
+  }
+}
+`, name, large)
+}
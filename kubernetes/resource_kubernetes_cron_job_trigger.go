@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// cronJobInstantiateAnnotation marks the jobs created manually from a cron
+// job, as `kubectl create job --from=cronjob/...` does.
+const cronJobInstantiateAnnotation = "cronjob.kubernetes.io/instantiate"
+
+func resourceKubernetesCronJobTrigger() *schema.Resource {
+	return &schema.Resource{
+		Description:   "This resource runs a cron job on demand, by creating a Job from its job template, like `kubectl create job --from=cronjob/...`, e.g. to run a scheduled backup before a migration. The cron job runs again when `triggers` change. The Job is owned by the cron job, so that it counts towards its history limits, and is deleted with the resource.",
+		CreateContext: resourceKubernetesCronJobTriggerCreate,
+		ReadContext:   resourceKubernetesCronJobTriggerRead,
+		UpdateContext: resourceKubernetesCronJobTriggerUpdate,
+		DeleteContext: resourceKubernetesCronJobTriggerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Minute),
+			Delete: schema.DefaultTimeout(1 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "The namespace of the cron job, where the Job is created. Defaults to `default`.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "default",
+			},
+			"cron_job_name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the cron job to run. Only `batch/v1` cron jobs are supported.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateName,
+			},
+			"job_name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the Job. Defaults to a name generated from the name of the cron job, e.g. `backup-manual-x7k2p`, so that every run gets its own Job.",
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateName,
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary values which run the cron job again when they change, e.g. the version of a release.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Description: "Wait for the Job to complete, and fail when it fails. The Job isn't waited for when the job template of the cron job is suspended.",
+				Optional:    true,
+				Default:     true,
+			},
+			"failed_pod_log_lines": {
+				Type:         schema.TypeInt,
+				Description:  "The number of lines of the logs of the failed containers of the Job reported when it fails while waiting for its completion. The logs of the 3 most recent failed pods are reported. Set it to 0 to not report them.",
+				Optional:     true,
+				Default:      20,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"status": resourceKubernetesJobV1Schema()["status"],
+		},
+	}
+}
+
+func resourceKubernetesCronJobTriggerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace := d.Get("namespace").(string)
+	cronJobName := d.Get("cron_job_name").(string)
+	cronJob, err := conn.BatchV1().CronJobs(namespace).Get(ctx, cronJobName, metav1.GetOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to read CronJob %s/%s! API error: %s", namespace, cronJobName, err)
+	}
+
+	job := jobV1FromCronJob(cronJob, d.Get("job_name").(string))
+	tflog.Info(ctx, fmt.Sprintf("Creating new job from cron job %s/%s: %#v", namespace, cronJobName, job))
+
+	out, err := conn.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to create Job! API error: %s", err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Submitted new job: %#v", out))
+
+	d.SetId(buildId(out.ObjectMeta))
+
+	var diags diag.Diagnostics
+	if d.Get("wait_for_completion").(bool) && out.Spec.Suspend != nil && *out.Spec.Suspend {
+		tflog.Info(ctx, fmt.Sprintf("Not waiting for job %s to complete while it is suspended", d.Id()))
+	} else if d.Get("wait_for_completion").(bool) {
+		diags = waitForJobV1ToFinish(ctx, conn, sharedWatchesOf(meta), out, nil, d.Timeout(schema.TimeoutCreate), int64(d.Get("failed_pod_log_lines").(int)))
+		if diags.HasError() {
+			return diags
+		}
+	}
+	return append(diags, resourceKubernetesCronJobTriggerRead(ctx, d, meta)...)
+}
+
+func resourceKubernetesCronJobTriggerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	job, err := conn.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// The Job may have been cleaned up by its TTL or by the history
+			// limits of the cron job, which doesn't mean it must run again.
+			tflog.Info(ctx, fmt.Sprintf("Job %s is gone, keeping the run of the cron job in the state", d.Id()))
+			return nil
+		}
+		return diag.Errorf("Failed to read Job! API error: %s", err)
+	}
+
+	d.Set("namespace", job.Namespace)
+	d.Set("job_name", job.Name)
+	if owner := metav1.GetControllerOf(job); owner != nil && owner.Kind == "CronJob" {
+		d.Set("cron_job_name", owner.Name)
+	}
+	if err := d.Set("status", flattenJobV1Status(job.Status)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceKubernetesCronJobTriggerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only the attributes which don't affect the Job can be updated.
+	return resourceKubernetesCronJobTriggerRead(ctx, d, meta)
+}
+
+func resourceKubernetesCronJobTriggerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting job: %#v", name))
+	err = conn.BatchV1().Jobs(namespace).Delete(ctx, name, deleteOptions)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Failed to delete Job! API error: %s", err)
+	}
+
+	err = retryWithProgress(ctx, d.Timeout(schema.TimeoutDelete), fmt.Sprintf("job %q to be deleted", d.Id()), func() *retry.RetryError {
+		out, err := conn.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return retry.NonRetryableError(err)
+		}
+		return retry.RetryableError(fmt.Errorf("Job %s still exists (%s, %s)", name, deletionProgress(out.ObjectMeta), jobV1Progress(out)))
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Job %s deleted", name))
+	d.SetId("")
+	return nil
+}
+
+// jobV1FromCronJob builds a Job from the job template of a cron job, owned by
+// the cron job, like `kubectl create job --from=cronjob/...`. The name of the
+// Job is generated from the name of the cron job when name is empty.
+func jobV1FromCronJob(cronJob *batchv1.CronJob, name string) *batchv1.Job {
+	annotations := map[string]string{cronJobInstantiateAnnotation: "manual"}
+	for k, v := range cronJob.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+	labels := map[string]string{}
+	for k, v := range cronJob.Spec.JobTemplate.Labels {
+		labels[k] = v
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       cronJob.Namespace,
+			Annotations:     annotations,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob"))},
+		},
+		Spec: *cronJob.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+	if name == "" {
+		job.GenerateName = cronJob.Name + "-manual-"
+	}
+	return job
+}
@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestJobV1FromCronJob(t *testing.T) {
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "db", UID: "1234"},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "0 3 * * *",
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app": "backup"},
+					Annotations: map[string]string{"team": "data"},
+				},
+				Spec: batchv1.JobSpec{BackoffLimit: ptr.To(int32(2))},
+			},
+		},
+	}
+
+	job := jobV1FromCronJob(cronJob, "")
+	expected := metav1.ObjectMeta{
+		GenerateName: "backup-manual-",
+		Namespace:    "db",
+		Labels:       map[string]string{"app": "backup"},
+		Annotations:  map[string]string{"team": "data", "cronjob.kubernetes.io/instantiate": "manual"},
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion:         "batch/v1",
+			Kind:               "CronJob",
+			Name:               "backup",
+			UID:                "1234",
+			Controller:         ptr.To(true),
+			BlockOwnerDeletion: ptr.To(true),
+		}},
+	}
+	if diff := cmp.Diff(expected, job.ObjectMeta); diff != "" {
+		t.Fatalf("Unexpected metadata: mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(cronJob.Spec.JobTemplate.Spec, job.Spec); diff != "" {
+		t.Fatalf("Unexpected spec: mismatch (-want +got):\n%s", diff)
+	}
+
+	job.Labels["run"] = "1"
+	if _, ok := cronJob.Spec.JobTemplate.Labels["run"]; ok {
+		t.Fatal("Expected the labels of the job template to be left untouched")
+	}
+
+	job = jobV1FromCronJob(cronJob, "backup-before-migration")
+	if job.Name != "backup-before-migration" || job.GenerateName != "" {
+		t.Fatalf("Expected the given name to be used, got name %q and generate name %q", job.Name, job.GenerateName)
+	}
+}
+
+func TestAccKubernetesCronJobTrigger_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := busyboxImage
+	resourceName := "kubernetes_cron_job_trigger.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesCronJobTriggerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesCronJobTriggerConfig_basic(name, imageName, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "cron_job_name", name),
+					resource.TestMatchResourceAttr(resourceName, "job_name", regexp.MustCompile("^"+name+"-manual-")),
+					resource.TestCheckResourceAttr(resourceName, "status.0.succeeded", "1"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"triggers", "wait_for_completion", "failed_pod_log_lines"},
+			},
+			{
+				Config: testAccKubernetesCronJobTriggerConfig_basic(name, imageName, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "triggers.run", "2"),
+					resource.TestCheckResourceAttr(resourceName, "status.0.succeeded", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesCronJobTriggerDestroy(s *terraform.State) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_cron_job_trigger" {
+			continue
+		}
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := conn.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+			return fmt.Errorf("Job still exists: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccKubernetesCronJobTriggerConfig_basic(name, imageName, run string) string {
+	return fmt.Sprintf(`resource "kubernetes_cron_job_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    schedule = "0 0 1 1 *"
+    suspend  = true
+    job_template {
+      metadata {}
+      spec {
+        template {
+          metadata {}
+          spec {
+            container {
+              name    = "backup"
+              image   = "%s"
+              command = ["sh", "-c", "echo backup"]
+            }
+            restart_policy = "Never"
+          }
+        }
+      }
+    }
+  }
+}
+
+resource "kubernetes_cron_job_trigger" "test" {
+  cron_job_name = kubernetes_cron_job_v1.test.metadata.0.name
+  triggers = {
+    run = "%s"
+  }
+  timeouts {
+    create = "2m"
+  }
+}`, name, imageName, run)
+}
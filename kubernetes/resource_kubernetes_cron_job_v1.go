@@ -6,9 +6,9 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -17,6 +17,8 @@ import (
 
 	batch "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesCronJobV1() *schema.Resource {
@@ -26,10 +28,15 @@ func resourceKubernetesCronJobV1() *schema.Resource {
 		ReadContext:   resourceKubernetesCronJobV1Read,
 		UpdateContext: resourceKubernetesCronJobV1Update,
 		DeleteContext: resourceKubernetesCronJobV1Delete,
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			return validateJobV1Spec(rawConfigAt(diff.GetRawConfig(), "spec.0.job_template.0.spec.0"))
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
 			Delete: schema.DefaultTimeout(1 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
@@ -43,6 +50,31 @@ func resourceKubernetesCronJobV1() *schema.Resource {
 					Schema: cronJobSpecFieldsV1(),
 				},
 			},
+			"wait_for_first_completion": {
+				Type:        schema.TypeBool,
+				Description: "Wait for a job of the cron job to complete successfully, e.g. for a bootstrap task which other resources depend on. The cron job isn't waited for while `spec.suspend` is true, nor once one of its jobs has completed. The create and update timeouts should cover the schedule of the cron job.",
+				Optional:    true,
+				Default:     false,
+			},
+			"status": {
+				Type:        schema.TypeList,
+				Description: "The most recently observed status of the cron job.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"last_schedule_time": {
+							Type:        schema.TypeString,
+							Description: "The last time a job was successfully scheduled, in RFC 3339 format. Empty until the cron job first runs.",
+							Computed:    true,
+						},
+						"last_successful_time": {
+							Type:        schema.TypeString,
+							Description: "The last time a job of the cron job completed successfully, in RFC 3339 format. Empty until one of its jobs completes.",
+							Computed:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -58,22 +90,30 @@ func resourceKubernetesCronJobV1Create(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	unsetJobV1IndexLimits(&spec.JobTemplate.Spec, rawConfigAt(d.GetRawConfig(), "spec.0.job_template.0.spec.0"))
 
 	job := batch.CronJob{
 		ObjectMeta: metadata,
 		Spec:       spec,
 	}
-	log.Printf("[INFO] Creating new cron job: %#v", job)
+	tflog.Info(ctx, fmt.Sprintf("Creating new cron job: %#v", job))
 
 	out, err := conn.BatchV1().CronJobs(metadata.Namespace).Create(ctx, &job, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new cron job: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new cron job: %#v", out))
 
 	d.SetId(buildId(out.ObjectMeta))
 
-	return resourceKubernetesCronJobV1Read(ctx, d, meta)
+	var diags diag.Diagnostics
+	if d.Get("wait_for_first_completion").(bool) {
+		diags = waitForCronJobV1FirstCompletion(ctx, conn, sharedWatchesOf(meta), out, d.Timeout(schema.TimeoutCreate))
+		if diags.HasError() {
+			return diags
+		}
+	}
+	return append(diags, resourceKubernetesCronJobV1Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesCronJobV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -92,22 +132,31 @@ func resourceKubernetesCronJobV1Update(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	unsetJobV1IndexLimits(&spec.JobTemplate.Spec, rawConfigAt(d.GetRawConfig(), "spec.0.job_template.0.spec.0"))
 
 	cronjob := &batch.CronJob{
 		ObjectMeta: metadata,
 		Spec:       spec,
 	}
 
-	log.Printf("[INFO] Updating cron job %s: %s", d.Id(), cronjob)
+	tflog.Info(ctx, fmt.Sprintf("Updating cron job %s: %s", d.Id(), cronjob))
 
 	out, err := conn.BatchV1().CronJobs(namespace).Update(ctx, cronjob, metav1.UpdateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted updated cron job: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated cron job: %#v", out))
 
 	d.SetId(buildId(out.ObjectMeta))
-	return resourceKubernetesCronJobV1Read(ctx, d, meta)
+
+	var diags diag.Diagnostics
+	if d.Get("wait_for_first_completion").(bool) {
+		diags = waitForCronJobV1FirstCompletion(ctx, conn, sharedWatchesOf(meta), out, d.Timeout(schema.TimeoutUpdate))
+		if diags.HasError() {
+			return diags
+		}
+	}
+	return append(diags, resourceKubernetesCronJobV1Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesCronJobV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -129,13 +178,13 @@ func resourceKubernetesCronJobV1Read(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading cron job %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading cron job %s", name))
 	job, err := conn.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received cron job: %#v", job)
+	tflog.Info(ctx, fmt.Sprintf("Received cron job: %#v", job))
 
 	// Remove server-generated labels unless using manual selector
 	if _, ok := d.GetOk("spec.0.job_template.spec.0.manual_selector"); !ok {
@@ -160,6 +209,11 @@ func resourceKubernetesCronJobV1Read(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
+	err = d.Set("status", flattenCronJobStatusV1(job.Status))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
@@ -174,7 +228,7 @@ func resourceKubernetesCronJobV1Delete(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting cron job: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting cron job: %#v", name))
 	err = conn.BatchV1().CronJobs(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		return diag.FromErr(err)
@@ -196,7 +250,7 @@ func resourceKubernetesCronJobV1Delete(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Cron Job %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Cron Job %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -213,13 +267,46 @@ func resourceKubernetesCronJobV1Exists(ctx context.Context, d *schema.ResourceDa
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking cron job %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking cron job %s", name))
 	_, err = conn.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
+
+// waitForCronJobV1FirstCompletion watches a cron job until one of its jobs has
+// completed successfully, reporting the warning events of the cron job along the
+// way. A suspended cron job isn't waited for, as it doesn't schedule jobs.
+func waitForCronJobV1FirstCompletion(ctx context.Context, conn *kubernetes.Clientset, watches *sharedWatches, cronJob *batch.CronJob, timeout time.Duration) diag.Diagnostics {
+	ns, name := cronJob.Namespace, cronJob.Name
+	if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
+		tflog.Info(ctx, fmt.Sprintf("Not waiting for cron job %s/%s to complete a job while it is suspended", ns, name))
+		return nil
+	}
+	events := startWaitEventReporter(ctx, conn, cronJob.ObjectMeta, "CronJob", nil)
+
+	err := watchObjectUntil(ctx, watches, timeout, fmt.Sprintf("cron job %q to complete a job", buildId(cronJob.ObjectMeta)), conn.BatchV1().CronJobs(ns), ns, name, &batch.CronJob{}, func(event watch.Event) *retry.RetryError {
+		cronJob, ok := event.Object.(*batch.CronJob)
+		if event.Type == watch.Deleted || !ok {
+			return retry.NonRetryableError(fmt.Errorf("cron job: %s/%s was deleted before completing a job", ns, name))
+		}
+		if cronJob.Status.LastSuccessfulTime != nil {
+			return nil
+		}
+		return retry.RetryableError(fmt.Errorf("cron job: %s/%s has not completed a job yet: %s", ns, name, cronJobV1Progress(cronJob)))
+	})
+	return events.Diagnostics(ctx, err)
+}
+
+// cronJobV1Progress summarizes the runs of a cron job, e.g. "active=1 last schedule=2024-05-01T03:00:00Z".
+func cronJobV1Progress(cronJob *batch.CronJob) string {
+	lastSchedule := "never"
+	if cronJob.Status.LastScheduleTime != nil {
+		lastSchedule = cronJob.Status.LastScheduleTime.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf("active=%d last schedule=%s", len(cronJob.Status.Active), lastSchedule)
+}
@@ -277,6 +277,37 @@ func TestAccKubernetesCronJobV1_minimalWithBackoffLimitPerIndex(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesCronJobV1_waitForFirstCompletion(t *testing.T) {
+	var conf batchv1.CronJob
+
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "kubernetes_cron_job_v1.test"
+	imageName := busyboxImage
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesCronJobV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesCronJobV1ConfigWaitForFirstCompletion(name, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesCronJobV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "wait_for_first_completion", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "status.0.last_schedule_time"),
+					resource.TestCheckResourceAttrSet(resourceName, "status.0.last_successful_time"),
+					func(s *terraform.State) error {
+						if conf.Status.LastSuccessfulTime == nil {
+							return fmt.Errorf("Expected a job of the cron job to have completed")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckKubernetesCronJobV1Destroy(s *terraform.State) error {
 	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
 
@@ -516,6 +547,38 @@ func testAccKubernetesCronJobV1ConfigMinimal(name, imageName string) string {
 `, name, imageName)
 }
 
+func testAccKubernetesCronJobV1ConfigWaitForFirstCompletion(name, imageName string) string {
+	return fmt.Sprintf(`resource "kubernetes_cron_job_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    schedule = "*/1 * * * *"
+    job_template {
+      metadata {}
+      spec {
+        template {
+          metadata {}
+          spec {
+            container {
+              name    = "test"
+              image   = "%s"
+              command = ["sh", "-c", "echo bootstrapped"]
+            }
+            restart_policy = "Never"
+          }
+        }
+      }
+    }
+  }
+  wait_for_first_completion = true
+  timeouts {
+    create = "3m"
+  }
+}
+`, name, imageName)
+}
+
 func testAccKubernetesCronJobV1ConfigMinimalWithBackoffLimitPerIndex(name, imageName string) string {
 	return fmt.Sprintf(`resource "kubernetes_cron_job_v1" "test" {
   metadata {
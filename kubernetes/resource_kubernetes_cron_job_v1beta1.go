@@ -6,9 +6,9 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -74,13 +74,13 @@ func resourceKubernetesCronJobV1Beta1Create(ctx context.Context, d *schema.Resou
 		Spec:       spec,
 	}
 
-	log.Printf("[INFO] Creating new cron job: %#v", job)
+	tflog.Info(ctx, fmt.Sprintf("Creating new cron job: %#v", job))
 
 	out, err := conn.BatchV1beta1().CronJobs(metadata.Namespace).Create(ctx, &job, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new cron job: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new cron job: %#v", out))
 
 	d.SetId(buildId(out.ObjectMeta))
 
@@ -110,13 +110,13 @@ func resourceKubernetesCronJobV1Beta1Update(ctx context.Context, d *schema.Resou
 		Spec:       spec,
 	}
 
-	log.Printf("[INFO] Updating cron job %s: %s", d.Id(), cronjob)
+	tflog.Info(ctx, fmt.Sprintf("Updating cron job %s: %s", d.Id(), cronjob))
 
 	out, err := conn.BatchV1beta1().CronJobs(namespace).Update(ctx, cronjob, metav1.UpdateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted updated cron job: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated cron job: %#v", out))
 
 	d.SetId(buildId(out.ObjectMeta))
 	return resourceKubernetesCronJobV1Beta1Read(ctx, d, meta)
@@ -141,13 +141,13 @@ func resourceKubernetesCronJobV1Beta1Read(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading cron job %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading cron job %s", name))
 	job, err := conn.BatchV1beta1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received cron job: %#v", job)
+	tflog.Info(ctx, fmt.Sprintf("Received cron job: %#v", job))
 
 	// Remove server-generated labels unless using manual selector
 	if _, ok := d.GetOk("spec.0.job_template.spec.0.manual_selector"); !ok {
@@ -186,7 +186,7 @@ func resourceKubernetesCronJobV1Beta1Delete(ctx context.Context, d *schema.Resou
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting cron job: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting cron job: %#v", name))
 	err = conn.BatchV1beta1().CronJobs(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		return diag.FromErr(err)
@@ -208,7 +208,7 @@ func resourceKubernetesCronJobV1Beta1Delete(ctx context.Context, d *schema.Resou
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Cron Job %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Cron Job %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -225,13 +225,13 @@ func resourceKubernetesCronJobV1Beta1Exists(ctx context.Context, d *schema.Resou
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking cron job %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking cron job %s", name))
 	_, err = conn.BatchV1beta1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
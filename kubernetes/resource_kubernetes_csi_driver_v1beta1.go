@@ -6,8 +6,8 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -78,12 +78,12 @@ func resourceKubernetesCSIDriverV1Beta1Create(ctx context.Context, d *schema.Res
 		Spec:       expandCSIDriverSpec(d.Get("spec").([]interface{})),
 	}
 
-	log.Printf("[INFO] Creating new CSIDriver: %#v", CSIDriver)
+	tflog.Info(ctx, fmt.Sprintf("Creating new CSIDriver: %#v", CSIDriver))
 	out, err := conn.StorageV1beta1().CSIDrivers().Create(ctx, &CSIDriver, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new CSIDriver: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new CSIDriver: %#v", out))
 	d.SetId(out.Name)
 
 	return resourceKubernetesCSIDriverV1Beta1Read(ctx, d, meta)
@@ -104,13 +104,13 @@ func resourceKubernetesCSIDriverV1Beta1Read(ctx context.Context, d *schema.Resou
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Reading CSIDriver %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading CSIDriver %s", name))
 	CSIDriver, err := conn.StorageV1beta1().CSIDrivers().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received CSIDriver: %#v", CSIDriver)
+	tflog.Info(ctx, fmt.Sprintf("Received CSIDriver: %#v", CSIDriver))
 	err = d.Set("metadata", flattenMetadata(CSIDriver.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
@@ -142,12 +142,12 @@ func resourceKubernetesCSIDriverV1Beta1Update(ctx context.Context, d *schema.Res
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating CSIDriver %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating CSIDriver %q: %v", name, string(data)))
 	out, err := conn.StorageV1beta1().CSIDrivers().Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update CSIDriver: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated CSIDriver: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated CSIDriver: %#v", out))
 	d.SetId(out.ObjectMeta.Name)
 
 	return resourceKubernetesCSIDriverV1Beta1Read(ctx, d, meta)
@@ -159,7 +159,7 @@ func resourceKubernetesCSIDriverV1Beta1Delete(ctx context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting CSIDriver: %s", d.Id())
+	tflog.Info(ctx, fmt.Sprintf("Deleting CSIDriver: %s", d.Id()))
 	err = conn.StorageV1beta1().CSIDrivers().Delete(ctx, d.Id(), metav1.DeleteOptions{})
 	if err != nil {
 		return diag.FromErr(err)
@@ -181,7 +181,7 @@ func resourceKubernetesCSIDriverV1Beta1Delete(ctx context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] CSIDriver %s deleted", d.Id())
+	tflog.Info(ctx, fmt.Sprintf("CSIDriver %s deleted", d.Id()))
 
 	d.SetId("")
 	return nil
@@ -194,13 +194,13 @@ func resourceKubernetesCSIDriverV1Beta1Exists(ctx context.Context, d *schema.Res
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Checking CSIDriver %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking CSIDriver %s", name))
 	_, err = conn.StorageV1beta1().CSIDrivers().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
@@ -6,19 +6,25 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"regexp"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -29,6 +35,12 @@ func resourceKubernetesDaemonSetV1() *schema.Resource {
 		ReadContext:   resourceKubernetesDaemonSetV1Read,
 		UpdateContext: resourceKubernetesDaemonSetV1Update,
 		DeleteContext: resourceKubernetesDaemonSetV1Delete,
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			if err := customizeDaemonSetV1RollingUpdateDiff(diff); err != nil {
+				return err
+			}
+			return customizeRolloutRestartDiff(ctx, diff, meta)
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -106,17 +118,17 @@ func resourceKubernetesDaemonSetSchemaV1() map[string]*schema.Schema {
 										Schema: map[string]*schema.Schema{
 											"max_surge": {
 												Type:         schema.TypeString,
-												Description:  "The maximum number of nodes with an existing available DaemonSet pod that can have an updated DaemonSet pod during during an update. Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%). This can not be 0 if MaxUnavailable is 0. Absolute number is calculated from percentage by rounding up to a minimum of 1. Default value is 0. Example: when this is set to 30%, at most 30% of the total number of nodes that should be running the daemon pod (i.e. status.desiredNumberScheduled) can have their a new pod created before the old pod is marked as deleted. The update starts by launching new pods on 30% of nodes. Once an updated pod is available (Ready for at least minReadySeconds) the old DaemonSet pod on that node is marked deleted. If the old pod becomes unavailable for any reason Ready transitions to false, is evicted, or is drained) an updated pod is immediatedly created on that node without considering surge limits. Allowing surge implies the possibility that the resources consumed by the daemonset on any given node can double if the readiness check fails, and so resource intensive daemonsets should take into account that they may cause evictionsduring disruption.",
+												Description:  "The maximum number of nodes with an existing available DaemonSet pod that can have an updated DaemonSet pod during an update. Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%). Exactly one of `max_surge` and `max_unavailable` must be non-zero: set `max_unavailable` to 0 to update with surge. Absolute number is calculated from percentage by rounding up to a minimum of 1. Default value is 0. Example: when this is set to 30%, at most 30% of the total number of nodes that should be running the daemon pod (i.e. status.desiredNumberScheduled) can have a new pod created before the old pod is marked as deleted. The update starts by launching new pods on 30% of nodes. Once an updated pod is available (Ready for at least minReadySeconds) the old DaemonSet pod on that node is marked deleted. If the old pod becomes unavailable for any reason Ready transitions to false, is evicted, or is drained) an updated pod is immediately created on that node without considering surge limits. Allowing surge implies the possibility that the resources consumed by the daemonset on any given node can double if the readiness check fails, and so resource intensive daemonsets should take into account that they may cause evictions during disruption.",
 												Optional:     true,
 												Default:      0,
-												ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(0|[1-9][0-9]*|[1-9][0-9]%|100%)$`), ""),
+												ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(0|[1-9][0-9]*|(0|[1-9][0-9]?|100)%)$`), ""),
 											},
 											"max_unavailable": {
 												Type:         schema.TypeString,
-												Description:  "The maximum number of DaemonSet pods that can be unavailable during the update. Value can be an absolute number (ex: 5) or a percentage of total number of DaemonSet pods at the start of the update (ex: 10%). Absolute number is calculated from percentage by rounding up. This cannot be 0 if MaxSurge is 0 Default value is 1. Example: when this is set to 30%, at most 30% of the total number of nodes that should be running the daemon pod (i.e. status.desiredNumberScheduled) can have their pods stopped for an update at any given time. The update starts by stopping at most 30% of those DaemonSet pods and then brings up new DaemonSet pods in their place. Once the new pods are available, it then proceeds onto other DaemonSet pods, thus ensuring that at least 70% of original number of DaemonSet pods are available at all times during the update.",
+												Description:  "The maximum number of DaemonSet pods that can be unavailable during the update. Value can be an absolute number (ex: 5) or a percentage of total number of DaemonSet pods at the start of the update (ex: 10%). Absolute number is calculated from percentage by rounding up. Exactly one of `max_surge` and `max_unavailable` must be non-zero. Default value is 1. Example: when this is set to 30%, at most 30% of the total number of nodes that should be running the daemon pod (i.e. status.desiredNumberScheduled) can have their pods stopped for an update at any given time. The update starts by stopping at most 30% of those DaemonSet pods and then brings up new DaemonSet pods in their place. Once the new pods are available, it then proceeds onto other DaemonSet pods, thus ensuring that at least 70% of original number of DaemonSet pods are available at all times during the update.",
 												Optional:     true,
 												Default:      1,
-												ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(0|[1-9][0-9]*|[1-9][0-9]%|100%)$`), ""),
+												ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(0|[1-9][0-9]*|(0|[1-9][0-9]?|100)%)$`), ""),
 											},
 										},
 									},
@@ -136,15 +148,45 @@ func resourceKubernetesDaemonSetSchemaV1() map[string]*schema.Schema {
 				},
 			},
 		},
+		"rollout_restart_triggers": rolloutRestartTriggersSchema("daemon set"),
+		"rollout_restarted_at":     rolloutRestartedAtSchema("daemon set"),
 		"wait_for_rollout": {
 			Type:        schema.TypeBool,
-			Description: "Wait for the rollout of the deployment to complete. Defaults to true.",
+			Description: "Wait for the rollout of the daemon set to complete, i.e. for its pods to be updated and ready on the nodes it should run on, given its node selector, node affinity and tolerations. Cordoned and not ready nodes are not waited for. Without permission to list the nodes, only waits for the pods to be scheduled. Defaults to true.",
 			Default:     true,
 			Optional:    true,
 		},
 	}
 }
 
+// customizeDaemonSetV1RollingUpdateDiff checks that exactly one of max_surge
+// and max_unavailable of the rolling update of a DaemonSet is non-zero, as
+// the API server requires, so that the error shows up in the plan.
+func customizeDaemonSetV1RollingUpdateDiff(diff *schema.ResourceDiff) error {
+	if diff.Get("spec.0.strategy.0.type").(string) != string(appsv1.RollingUpdateDaemonSetStrategyType) {
+		return nil
+	}
+	if len(diff.Get("spec.0.strategy.0.rolling_update").([]interface{})) == 0 {
+		return nil
+	}
+	prefix := "spec.0.strategy.0.rolling_update.0."
+	if !diff.NewValueKnown(prefix+"max_surge") || !diff.NewValueKnown(prefix+"max_unavailable") {
+		return nil
+	}
+	return validateDaemonSetV1RollingUpdate(diff.Get(prefix+"max_surge").(string), diff.Get(prefix+"max_unavailable").(string))
+}
+
+func validateDaemonSetV1RollingUpdate(maxSurge, maxUnavailable string) error {
+	surge, unavailable := !isZeroIntOrPercent(maxSurge), !isZeroIntOrPercent(maxUnavailable)
+	switch {
+	case surge && unavailable:
+		return fmt.Errorf("spec.0.strategy.0.rolling_update.0.max_unavailable must be \"0\" when max_surge is set, got max_surge %q and max_unavailable %q", maxSurge, maxUnavailable)
+	case !surge && !unavailable:
+		return fmt.Errorf("spec.0.strategy.0.rolling_update.0.max_surge and max_unavailable can't both be \"0\"")
+	}
+	return nil
+}
+
 func resourceKubernetesDaemonSetV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conn, err := meta.(KubeClientsets).MainClientset()
 	if err != nil {
@@ -162,26 +204,26 @@ func resourceKubernetesDaemonSetV1Create(ctx context.Context, d *schema.Resource
 		Spec:       spec,
 	}
 
-	log.Printf("[INFO] Creating new daemonset: %#v", daemonset)
+	tflog.Info(ctx, fmt.Sprintf("Creating new daemonset: %#v", daemonset))
 
 	out, err := conn.AppsV1().DaemonSets(metadata.Namespace).Create(ctx, &daemonset, metav1.CreateOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to create daemonset: %s", err)
 	}
 
+	var diags diag.Diagnostics
 	if d.Get("wait_for_rollout").(bool) {
-		err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate),
-			waitForDaemonSetReplicasFunc(ctx, conn, metadata.Namespace, metadata.Name))
-		if err != nil {
-			return diag.FromErr(err)
+		diags = waitForDaemonSetV1Rollout(ctx, conn, sharedWatchesOf(meta), out, d.Timeout(schema.TimeoutCreate))
+		if diags.HasError() {
+			return diags
 		}
 	}
 
 	d.SetId(buildId(out.ObjectMeta))
 
-	log.Printf("[INFO] Submitted new daemonset: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new daemonset: %#v", out))
 
-	return resourceKubernetesDaemonSetV1Read(ctx, d, meta)
+	return append(diags, resourceKubernetesDaemonSetV1Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesDaemonSetV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -197,11 +239,12 @@ func resourceKubernetesDaemonSetV1Update(ctx context.Context, d *schema.Resource
 
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 
-	if d.HasChange("spec") {
+	if d.HasChange("spec") || d.HasChange("rollout_restart_triggers") {
 		spec, err := expandDaemonSetSpec(d.Get("spec").([]interface{}))
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		expandRolloutRestartedAt(&spec.Template, d)
 
 		ops = append(ops, &ReplaceOperation{
 			Path:  "/spec",
@@ -212,23 +255,23 @@ func resourceKubernetesDaemonSetV1Update(ctx context.Context, d *schema.Resource
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating daemonset: %q", name)
+	tflog.Info(ctx, fmt.Sprintf("Updating daemonset: %q", name))
 
 	out, err := conn.AppsV1().DaemonSets(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update daemonset: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated daemonset: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated daemonset: %#v", out))
 
+	var diags diag.Diagnostics
 	if d.Get("wait_for_rollout").(bool) {
-		err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate),
-			waitForDaemonSetReplicasFunc(ctx, conn, namespace, name))
-		if err != nil {
-			return diag.FromErr(err)
+		diags = waitForDaemonSetV1Rollout(ctx, conn, sharedWatchesOf(meta), out, d.Timeout(schema.TimeoutUpdate))
+		if diags.HasError() {
+			return diags
 		}
 	}
 
-	return resourceKubernetesDaemonSetV1Read(ctx, d, meta)
+	return append(diags, resourceKubernetesDaemonSetV1Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesDaemonSetV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -250,23 +293,28 @@ func resourceKubernetesDaemonSetV1Read(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading daemonset %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading daemonset %s", name))
 	daemonset, err := conn.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if errors.IsNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received daemonset: %#v", daemonset)
+	tflog.Info(ctx, fmt.Sprintf("Received daemonset: %#v", daemonset))
 
 	err = d.Set("metadata", flattenMetadata(daemonset.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	err = d.Set("rollout_restarted_at", flattenRolloutRestartedAt(&daemonset.Spec.Template, d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	spec, err := flattenDaemonSetSpec(daemonset.Spec, d, meta)
 	if err != nil {
 		return diag.FromErr(err)
@@ -291,7 +339,7 @@ func resourceKubernetesDaemonSetV1Delete(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting daemonset: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting daemonset: %#v", name))
 
 	err = conn.AppsV1().DaemonSets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
@@ -301,7 +349,7 @@ func resourceKubernetesDaemonSetV1Delete(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] DaemonSet %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("DaemonSet %s deleted", name))
 
 	return nil
 }
@@ -317,33 +365,267 @@ func resourceKubernetesDaemonSetV1Exists(ctx context.Context, d *schema.Resource
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking daemonset %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking daemonset %s", name))
 	_, err = conn.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
 
-func waitForDaemonSetReplicasFunc(ctx context.Context, conn *kubernetes.Clientset, ns, name string) retry.RetryFunc {
-	return func() *retry.RetryError {
-		daemonSet, err := conn.AppsV1().DaemonSets(ns).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return retry.NonRetryableError(err)
+// waitForDaemonSetV1Rollout waits until the pods of the daemonset are updated
+// and ready on the nodes it should run on, reporting the warning events of the
+// daemonset and its pods along the way.
+func waitForDaemonSetV1Rollout(ctx context.Context, conn *kubernetes.Clientset, watches *sharedWatches, ds *appsv1.DaemonSet, timeout time.Duration) diag.Diagnostics {
+	ns, name := ds.Namespace, ds.Name
+	// The nodes are listed once per wait rather than on every check, as
+	// listing them is expensive in large clusters.
+	var nodes []corev1.Node
+	if list, err := conn.CoreV1().Nodes().List(ctx, metav1.ListOptions{}); err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to list the nodes of DaemonSet %s/%s: %s", ns, name, err))
+	} else {
+		nodes = list.Items
+	}
+	events := startWaitEventReporter(ctx, conn, ds.ObjectMeta, "DaemonSet", ds.Spec.Selector)
+	err := watchObjectUntil(ctx, watches, timeout, fmt.Sprintf("rollout of daemonset %q", buildId(ds.ObjectMeta)), conn.AppsV1().DaemonSets(ns), ns, name, &appsv1.DaemonSet{}, func(event watch.Event) *retry.RetryError {
+		daemonSet, ok := event.Object.(*appsv1.DaemonSet)
+		if event.Type == watch.Deleted || !ok {
+			return retry.NonRetryableError(fmt.Errorf("DaemonSet %s/%s was deleted while waiting for rollout", ns, name))
 		}
+		return daemonSetV1RolloutStatus(ctx, conn, daemonSet, nodes)
+	})
+	return events.Diagnostics(ctx, err)
+}
 
-		desiredReplicas := daemonSet.Status.DesiredNumberScheduled
-		log.Printf("[DEBUG] Current number of labelled replicas of %q: %d (of %d)\n",
-			daemonSet.GetName(), daemonSet.Status.CurrentNumberScheduled, desiredReplicas)
+// daemonSetV1RolloutStatus checks whether the rollout of a DaemonSet has
+// finished, i.e. whether each ready and schedulable node of the given nodes
+// the DaemonSet should run on has an updated and ready pod. Cordoned and not
+// ready nodes are skipped, as their pods may never become ready. The nodes
+// still pending are reported in the error, which ends up in the timeout error
+// of the wait. Without nodes, e.g. as they can't be listed, only the status
+// of the DaemonSet is checked.
+func daemonSetV1RolloutStatus(ctx context.Context, conn kubernetes.Interface, ds *appsv1.DaemonSet, nodes []corev1.Node) *retry.RetryError {
+	ns, name := ds.Namespace, ds.Name
+	if ds.Status.ObservedGeneration < ds.Generation {
+		return retry.RetryableError(fmt.Errorf("DaemonSet %s/%s is not finished rolling out: waiting for the spec update to be observed", ns, name))
+	}
 
-		if daemonSet.Status.CurrentNumberScheduled == desiredReplicas {
+	if nodes == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Current number of labelled replicas of %q: %d (of %d)", name, ds.Status.CurrentNumberScheduled, ds.Status.DesiredNumberScheduled))
+		if ds.Status.CurrentNumberScheduled == ds.Status.DesiredNumberScheduled {
 			return nil
 		}
-
 		return retry.RetryableError(fmt.Errorf("Waiting for %d replicas of %q to be scheduled (%d)",
-			desiredReplicas, daemonSet.GetName(), daemonSet.Status.CurrentNumberScheduled))
+			ds.Status.DesiredNumberScheduled, name, ds.Status.CurrentNumberScheduled))
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+	if err != nil {
+		return retry.NonRetryableError(err)
+	}
+	pods, err := conn.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return retry.NonRetryableError(fmt.Errorf("Failed to list the pods of DaemonSet %s/%s: %s", ns, name, err))
+	}
+	podsByNode := map[string][]corev1.Pod{}
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp == nil && metav1.IsControlledBy(&pod, ds) {
+			podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+		}
+	}
+
+	var desired int
+	var pending []string
+	for _, node := range nodes {
+		if !daemonSetV1ShouldRunOnNode(&ds.Spec.Template, &node) {
+			continue
+		}
+		if node.Spec.Unschedulable || !isNodeReady(&node) {
+			tflog.Debug(ctx, fmt.Sprintf("Not waiting for the pod of DaemonSet %s/%s on node %q as it is cordoned or not ready", ns, name, node.Name))
+			continue
+		}
+		desired++
+		if reason := daemonSetV1NodePending(ds, podsByNode[node.Name]); reason != "" {
+			pending = append(pending, fmt.Sprintf("%s (%s)", node.Name, reason))
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	sort.Strings(pending)
+	count := len(pending)
+	const maxReported = 5
+	if count > maxReported {
+		pending = append(pending[:maxReported], fmt.Sprintf("and %d more", count-maxReported))
+	}
+	return retry.RetryableError(fmt.Errorf("DaemonSet %s/%s is not finished rolling out: %d/%d nodes pending: %s", ns, name, count, desired, strings.Join(pending, ", ")))
+}
+
+// daemonSetV1NodePending returns why the rollout of a DaemonSet isn't finished
+// on a node, given the pods of the DaemonSet on the node, or an empty string
+// when it is.
+func daemonSetV1NodePending(ds *appsv1.DaemonSet, pods []corev1.Pod) string {
+	if len(pods) == 0 {
+		return "no pod"
+	}
+	reason := ""
+	for _, pod := range pods {
+		switch {
+		case !isDaemonSetV1PodUpdated(ds, &pod):
+			reason = fmt.Sprintf("pod %s not updated", pod.Name)
+		case !isPodReady(&pod):
+			reason = fmt.Sprintf("pod %s not ready", pod.Name)
+		default:
+			return ""
+		}
+	}
+	return reason
+}
+
+// isDaemonSetV1PodUpdated returns whether a pod of a DaemonSet runs its
+// current pod template, by comparing the template generation the DaemonSet
+// controller labels its pods with. The pods of DaemonSets updated on delete
+// are never replaced by the rollout, so they're always up to date.
+func isDaemonSetV1PodUpdated(ds *appsv1.DaemonSet, pod *corev1.Pod) bool {
+	if ds.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		return true
+	}
+	generation, ok := ds.Annotations[daemonSetTemplateGenerationAnnotation]
+	if !ok {
+		return true
+	}
+	return pod.Labels[daemonSetTemplateGenerationLabel] == generation
+}
+
+const (
+	// daemonSetTemplateGenerationAnnotation is the generation of the pod
+	// template of a DaemonSet, bumped by the API server when it changes.
+	daemonSetTemplateGenerationAnnotation = "deprecated.daemonset.template.generation"
+	// daemonSetTemplateGenerationLabel is the generation of the pod template
+	// of a DaemonSet a pod was created from.
+	daemonSetTemplateGenerationLabel = "pod-template-generation"
+)
+
+// daemonSetV1ShouldRunOnNode returns whether the DaemonSet controller runs a
+// pod of the template on a node, i.e. whether the node matches its node name,
+// node selector and required node affinity, and whether the pod tolerates the
+// taints of the node, along with the tolerations the controller adds to the
+// pods of every DaemonSet.
+func daemonSetV1ShouldRunOnNode(template *corev1.PodTemplateSpec, node *corev1.Node) bool {
+	spec := template.Spec
+	if spec.NodeName != "" && spec.NodeName != node.Name {
+		return false
+	}
+	if !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	if spec.Affinity != nil && spec.Affinity.NodeAffinity != nil && spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		if !nodeSelectorTermsMatch(spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms, node) {
+			return false
+		}
+	}
+	tolerations := append(daemonSetV1DefaultTolerations(spec.HostNetwork), spec.Tolerations...)
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect != corev1.TaintEffectNoSchedule && taint.Effect != corev1.TaintEffectNoExecute {
+			continue
+		}
+		tolerated := false
+		for _, toleration := range tolerations {
+			if toleration.ToleratesTaint(&taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// daemonSetV1DefaultTolerations returns the tolerations the DaemonSet
+// controller adds to the pods of every DaemonSet, so that they run on nodes
+// which are cordoned, not ready or under pressure.
+func daemonSetV1DefaultTolerations(hostNetwork bool) []corev1.Toleration {
+	taints := map[string]corev1.TaintEffect{
+		"node.kubernetes.io/not-ready":       corev1.TaintEffectNoExecute,
+		"node.kubernetes.io/unreachable":     corev1.TaintEffectNoExecute,
+		"node.kubernetes.io/disk-pressure":   corev1.TaintEffectNoSchedule,
+		"node.kubernetes.io/memory-pressure": corev1.TaintEffectNoSchedule,
+		"node.kubernetes.io/pid-pressure":    corev1.TaintEffectNoSchedule,
+		"node.kubernetes.io/unschedulable":   corev1.TaintEffectNoSchedule,
+	}
+	if hostNetwork {
+		taints["node.kubernetes.io/network-unavailable"] = corev1.TaintEffectNoSchedule
+	}
+	tolerations := make([]corev1.Toleration, 0, len(taints))
+	for key, effect := range taints {
+		tolerations = append(tolerations, corev1.Toleration{Key: key, Operator: corev1.TolerationOpExists, Effect: effect})
+	}
+	return tolerations
+}
+
+// nodeSelectorTermsMatch returns whether a node matches any of the node
+// selector terms of a node affinity.
+func nodeSelectorTermsMatch(terms []corev1.NodeSelectorTerm, node *corev1.Node) bool {
+	for _, term := range terms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue
+		}
+		labelSelector, err := nodeSelectorRequirementsAsSelector(term.MatchExpressions)
+		if err != nil || !labelSelector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		fieldSelector, err := nodeSelectorRequirementsAsSelector(term.MatchFields)
+		if err != nil || !fieldSelector.Matches(labels.Set{"metadata.name": node.Name}) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func nodeSelectorRequirementsAsSelector(requirements []corev1.NodeSelectorRequirement) (labels.Selector, error) {
+	operators := map[corev1.NodeSelectorOperator]selection.Operator{
+		corev1.NodeSelectorOpIn:           selection.In,
+		corev1.NodeSelectorOpNotIn:        selection.NotIn,
+		corev1.NodeSelectorOpExists:       selection.Exists,
+		corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+		corev1.NodeSelectorOpGt:           selection.GreaterThan,
+		corev1.NodeSelectorOpLt:           selection.LessThan,
+	}
+	selector := labels.NewSelector()
+	for _, r := range requirements {
+		op, ok := operators[r.Operator]
+		if !ok {
+			return nil, fmt.Errorf("unknown node selector operator %q", r.Operator)
+		}
+		req, err := labels.NewRequirement(r.Key, op, r.Values)
+		if err != nil {
+			return nil, err
+		}
+		selector = selector.Add(*req)
+	}
+	return selector, nil
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
 	}
+	return false
 }
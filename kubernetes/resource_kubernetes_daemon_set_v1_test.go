@@ -6,15 +6,169 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
 )
 
+func TestDaemonSetV1RolloutStatus(t *testing.T) {
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "agent",
+			Namespace:   "kube-system",
+			UID:         "agent-uid",
+			Generation:  2,
+			Annotations: map[string]string{daemonSetTemplateGenerationAnnotation: "2"},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{NodeSelector: map[string]string{"pool": "web"}},
+			},
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{Type: appsv1.RollingUpdateDaemonSetStrategyType},
+		},
+		Status: appsv1.DaemonSetStatus{ObservedGeneration: 2},
+	}
+	node := func(name string, ready bool, mutate func(*corev1.Node)) *corev1.Node {
+		status := corev1.ConditionTrue
+		if !ready {
+			status = corev1.ConditionFalse
+		}
+		n := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"pool": "web"}},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}}},
+		}
+		if mutate != nil {
+			mutate(n)
+		}
+		return n
+	}
+	pod := func(name, nodeName, generation string, ready bool) *corev1.Pod {
+		status := corev1.ConditionTrue
+		if !ready {
+			status = corev1.ConditionFalse
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "kube-system",
+				Labels:          map[string]string{"app": "agent", daemonSetTemplateGenerationLabel: generation},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent", UID: "agent-uid", Controller: ptr.To(true)}},
+			},
+			Spec:   corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+		}
+	}
+	nodes := []corev1.Node{
+		*node("node-a", true, nil),
+		*node("node-b", true, nil),
+		*node("node-c", true, func(n *corev1.Node) { n.Spec.Unschedulable = true }),
+		*node("node-d", true, func(n *corev1.Node) {
+			n.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule}}
+		}),
+		*node("node-e", false, nil),
+		*node("node-f", true, func(n *corev1.Node) { n.Labels["pool"] = "db" }),
+		*node("node-g", true, func(n *corev1.Node) {
+			// The controller tolerates the memory pressure taint.
+			n.Spec.Taints = []corev1.Taint{{Key: "node.kubernetes.io/memory-pressure", Effect: corev1.TaintEffectNoSchedule}}
+		}),
+	}
+	conn := fake.NewSimpleClientset(
+		pod("agent-a", "node-a", "2", true),
+		pod("agent-b", "node-b", "1", true),
+	)
+
+	err := daemonSetV1RolloutStatus(context.Background(), conn, ds, nodes)
+	if err == nil || !err.Retryable {
+		t.Fatalf("Expected the rollout not to be finished, got %v", err)
+	}
+	expected := "2/3 nodes pending: node-b (pod agent-b not updated), node-g (no pod)"
+	if !strings.Contains(err.Err.Error(), expected) {
+		t.Fatalf("Expected the pending nodes %q, got %q", expected, err.Err)
+	}
+
+	// A new pod surges on node-b while the old one is still running.
+	for _, p := range []*corev1.Pod{pod("agent-b2", "node-b", "2", true), pod("agent-g", "node-g", "2", false)} {
+		if _, err := conn.CoreV1().Pods("kube-system").Create(context.Background(), p, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = daemonSetV1RolloutStatus(context.Background(), conn, ds, nodes)
+	if err == nil || !strings.Contains(err.Err.Error(), "1/3 nodes pending: node-g (pod agent-g not ready)") {
+		t.Fatalf("Expected node-g to be pending, got %v", err)
+	}
+
+	if _, err := conn.CoreV1().Pods("kube-system").Update(context.Background(), pod("agent-g", "node-g", "2", true), metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := daemonSetV1RolloutStatus(context.Background(), conn, ds, nodes); err != nil {
+		t.Fatalf("Expected the rollout to be finished, got %v", err.Err)
+	}
+
+	// Tolerating the taint of node-d makes the daemon set run on it.
+	ds.Spec.Template.Spec.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "db"}}
+	err = daemonSetV1RolloutStatus(context.Background(), conn, ds, nodes)
+	if err == nil || !strings.Contains(err.Err.Error(), "1/4 nodes pending: node-d (no pod)") {
+		t.Fatalf("Expected node-d to be pending, got %v", err)
+	}
+
+	// Without the nodes, only the status of the daemon set is checked.
+	ds.Status.DesiredNumberScheduled, ds.Status.CurrentNumberScheduled = 3, 2
+	if err := daemonSetV1RolloutStatus(context.Background(), conn, ds, nil); err == nil {
+		t.Fatal("Expected the rollout not to be finished while a pod isn't scheduled")
+	}
+	ds.Status.CurrentNumberScheduled = 3
+	if err := daemonSetV1RolloutStatus(context.Background(), conn, ds, nil); err != nil {
+		t.Fatalf("Expected the rollout to be finished once the pods are scheduled, got %v", err.Err)
+	}
+}
+
+func TestDaemonSetV1ShouldRunOnNode(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{"zone": "a", "cores": "8"}}}
+	affinity := func(terms ...corev1.NodeSelectorTerm) *corev1.PodTemplateSpec {
+		return &corev1.PodTemplateSpec{Spec: corev1.PodSpec{Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms},
+		}}}}
+	}
+	cases := []struct {
+		name     string
+		template *corev1.PodTemplateSpec
+		expected bool
+	}{
+		{"no constraints", &corev1.PodTemplateSpec{}, true},
+		{"other node name", &corev1.PodTemplateSpec{Spec: corev1.PodSpec{NodeName: "node-b"}}, false},
+		{"matching expression", affinity(corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+			{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a", "b"}},
+			{Key: "cores", Operator: corev1.NodeSelectorOpGt, Values: []string{"4"}},
+		}}), true},
+		{"not matching expression", affinity(corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+			{Key: "zone", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"a"}},
+		}}), false},
+		{"any matching term", affinity(
+			corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "gpu", Operator: corev1.NodeSelectorOpExists}}},
+			corev1.NodeSelectorTerm{MatchFields: []corev1.NodeSelectorRequirement{{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-a"}}}},
+		), true},
+		{"empty term", affinity(corev1.NodeSelectorTerm{}), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := daemonSetV1ShouldRunOnNode(tc.template, node); got != tc.expected {
+				t.Fatalf("Expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
 func TestAccKubernetesDaemonSetV1_minimal(t *testing.T) {
 	var conf appsv1.DaemonSet
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
@@ -432,6 +586,36 @@ func TestAccKubernetesDaemonSetV1_minimalWithTemplateNamespace(t *testing.T) {
 	})
 }
 
+func TestValidateDaemonSetV1RollingUpdate(t *testing.T) {
+	cases := []struct {
+		maxSurge       string
+		maxUnavailable string
+		valid          bool
+	}{
+		{"0", "1", true},
+		{"0", "25%", true},
+		{"1", "0", true},
+		{"10%", "0%", true},
+		{"0", "0", false},
+		{"0%", "0", false},
+		{"1", "1", false},
+		{"5%", "10%", false},
+	}
+	rollingUpdate := resourceKubernetesDaemonSetSchemaV1()["spec"].Elem.(*schema.Resource).Schema["strategy"].Elem.(*schema.Resource).Schema["rolling_update"].Elem.(*schema.Resource).Schema
+	for _, tc := range cases {
+		// Every value must get past the schema to be checked together.
+		for k, v := range map[string]string{"max_surge": tc.maxSurge, "max_unavailable": tc.maxUnavailable} {
+			if _, errs := rollingUpdate[k].ValidateFunc(v, k); len(errs) > 0 {
+				t.Errorf("Expected %s %q to be valid, got: %v", k, v, errs)
+			}
+		}
+		err := validateDaemonSetV1RollingUpdate(tc.maxSurge, tc.maxUnavailable)
+		if (err == nil) != tc.valid {
+			t.Errorf("max_surge %q and max_unavailable %q: expected valid: %t, got: %v", tc.maxSurge, tc.maxUnavailable, tc.valid, err)
+		}
+	}
+}
+
 func TestAccKubernetesDaemonSetV1_MaxSurge(t *testing.T) {
 	var conf appsv1.DaemonSet
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
@@ -445,6 +629,14 @@ func TestAccKubernetesDaemonSetV1_MaxSurge(t *testing.T) {
 		ProviderFactories: testAccProviderFactories,
 		CheckDestroy:      testAccCheckKubernetesDaemonSetV1Destroy,
 		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesDaemonSetV1ConfigWithRollingUpdate(name, imageName, "2", "1"),
+				ExpectError: regexp.MustCompile(`max_unavailable must be "0" when max_surge is set`),
+			},
+			{
+				Config:      testAccKubernetesDaemonSetV1ConfigWithRollingUpdate(name, imageName, "0", "0"),
+				ExpectError: regexp.MustCompile(`max_surge and max_unavailable can't both be "0"`),
+			},
 			{
 				Config: testAccKubernetesDaemonSetV1ConfigWithMaxSurge(name, imageName, "0"),
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -466,6 +658,13 @@ func TestAccKubernetesDaemonSetV1_MaxSurge(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "spec.0.strategy.0.rolling_update.0.max_surge", "10%"),
 				),
 			},
+			{
+				Config: testAccKubernetesDaemonSetV1ConfigWithMaxSurge(name, imageName, "5%"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDaemonSetV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.strategy.0.rolling_update.0.max_surge", "5%"),
+				),
+			},
 		},
 	})
 }
@@ -625,6 +824,47 @@ func testAccKubernetesDaemonSetV1ConfigWithMaxSurge(name, imageName, maxSurge st
 `, name, imageName, maxSurge)
 }
 
+func testAccKubernetesDaemonSetV1ConfigWithRollingUpdate(name, imageName, maxSurge, maxUnavailable string) string {
+	return fmt.Sprintf(`resource "kubernetes_daemon_set_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    selector {
+      match_labels = {
+        foo = "bar"
+      }
+    }
+
+    template {
+      metadata {
+        labels = {
+          foo = "bar"
+        }
+      }
+
+      spec {
+        container {
+          image   = "%s"
+          name    = "tf-acc-test"
+          command = ["sleep", "300"]
+        }
+        termination_grace_period_seconds = 1
+      }
+    }
+
+    strategy {
+      rolling_update {
+        max_surge       = "%s"
+        max_unavailable = "%s"
+      }
+    }
+  }
+}
+`, name, imageName, maxSurge, maxUnavailable)
+}
+
 func testAccKubernetesDaemonSetV1Config_minimal(name, imageName string) string {
 	return fmt.Sprintf(`resource "kubernetes_daemon_set_v1" "test" {
   metadata {
@@ -5,8 +5,12 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesDefaultServiceAccountV1() *schema.Resource {
@@ -28,8 +33,25 @@ func resourceKubernetesDefaultServiceAccountV1() *schema.Resource {
 	nameField.ValidateFunc = validation.StringInSlice([]string{"default"}, false)
 
 	serviceAccountResource.Schema["metadata"] = metaSchema
+	serviceAccountResource.Schema["remove_legacy_token_secrets"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Delete the long-lived token secrets generated for the default service account, and remove them from its secrets, in its namespace and in `harden_namespaces`. They are no longer generated since Kubernetes 1.24. Only the secrets referenced by the service account and named `default-token-<suffix>` by the token controller are deleted, token secrets created on purpose are kept.",
+		Optional:    true,
+		Default:     false,
+	}
+	serviceAccountResource.Schema["harden_namespaces"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Description: "Other namespaces whose default service account is hardened: `automountServiceAccountToken` is set to false and, with `remove_legacy_token_secrets`, its long-lived token secrets are removed. A change made to them outside of Terraform is reverted by the next apply. They are left as they are when removed from the set.",
+		Optional:    true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validateName,
+		},
+	}
 
 	serviceAccountResource.CreateContext = resourceKubernetesDefaultServiceAccountV1Create
+	serviceAccountResource.ReadContext = resourceKubernetesDefaultServiceAccountV1Read
+	serviceAccountResource.UpdateContext = resourceKubernetesDefaultServiceAccountV1Update
 
 	return serviceAccountResource
 }
@@ -43,19 +65,19 @@ func resourceKubernetesDefaultServiceAccountV1Create(ctx context.Context, d *sch
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	svcAcc := corev1.ServiceAccount{ObjectMeta: metadata}
 
-	log.Printf("[INFO] Checking for default service account existence: %s", metadata.Namespace)
+	tflog.Info(ctx, fmt.Sprintf("Checking for default service account existence: %s", metadata.Namespace))
 	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
 		_, err := conn.CoreV1().ServiceAccounts(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
 		if err != nil {
 			if errors.IsNotFound(err) {
-				log.Printf("[INFO] Default service account does not exist, will retry: %s", metadata.Namespace)
+				tflog.Info(ctx, fmt.Sprintf("Default service account does not exist, will retry: %s", metadata.Namespace))
 				return retry.RetryableError(err)
 			}
 
 			return retry.NonRetryableError(err)
 		}
 
-		log.Printf("[INFO] Default service account exists: %s", metadata.Namespace)
+		tflog.Info(ctx, fmt.Sprintf("Default service account exists: %s", metadata.Namespace))
 		return nil
 	})
 	if err != nil {
@@ -94,14 +116,212 @@ func resourceKubernetesDefaultServiceAccountV1Create(ctx context.Context, d *sch
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating default service account %q: %v", metadata.Name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating default service account %q: %v", metadata.Name, string(data)))
 	out, err := conn.CoreV1().ServiceAccounts(metadata.Namespace).Patch(ctx, metadata.Name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update default service account: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated default service account: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated default service account: %#v", out))
 
 	d.SetId(buildId(metadata))
 
-	return resourceKubernetesServiceAccountV1Read(ctx, d, meta)
+	diags := hardenDefaultServiceAccounts(ctx, conn, d)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, resourceKubernetesDefaultServiceAccountV1Read(ctx, d, meta)...)
+}
+
+func resourceKubernetesDefaultServiceAccountV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := resourceKubernetesServiceAccountV1Read(ctx, d, meta)
+	if diags.HasError() || d.Id() == "" {
+		return diags
+	}
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	namespace, _, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Drift of the hardening is surfaced by removing it from the state, so
+	// that the next apply hardens the service accounts again.
+	removeLegacy := d.Get("remove_legacy_token_secrets").(bool)
+	if removeLegacy {
+		hardened, err := defaultServiceAccountHardened(ctx, conn, namespace, false, true)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		if !hardened {
+			d.Set("remove_legacy_token_secrets", false)
+		}
+	}
+	var hardenedNamespaces []interface{}
+	for _, ns := range d.Get("harden_namespaces").(*schema.Set).List() {
+		hardened, err := defaultServiceAccountHardened(ctx, conn, ns.(string), true, removeLegacy)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		if hardened {
+			hardenedNamespaces = append(hardenedNamespaces, ns)
+		}
+	}
+	d.Set("harden_namespaces", hardenedNamespaces)
+	return diags
+}
+
+func resourceKubernetesDefaultServiceAccountV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	diags := hardenDefaultServiceAccounts(ctx, conn, d)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, resourceKubernetesServiceAccountV1Update(ctx, d, meta)...)
+}
+
+// hardenDefaultServiceAccounts hardens the default service accounts of the
+// harden_namespaces, and removes the legacy token secrets of the one of the
+// resource when remove_legacy_token_secrets is set.
+func hardenDefaultServiceAccounts(ctx context.Context, conn kubernetes.Interface, d *schema.ResourceData) diag.Diagnostics {
+	namespace, _, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+	removeLegacy := d.Get("remove_legacy_token_secrets").(bool)
+	if removeLegacy {
+		if err := hardenDefaultServiceAccount(ctx, conn, namespace, false, true, timeout); err != nil {
+			return diag.Errorf("Failed to remove the legacy token secrets of the default service account of namespace %q: %s", namespace, err)
+		}
+	}
+	var diags diag.Diagnostics
+	for _, ns := range d.Get("harden_namespaces").(*schema.Set).List() {
+		if err := hardenDefaultServiceAccount(ctx, conn, ns.(string), true, removeLegacy, timeout); err != nil {
+			diags = append(diags, diag.Errorf("Failed to harden the default service account of namespace %q: %s", ns, err)...)
+		}
+	}
+	return diags
+}
+
+// hardenDefaultServiceAccount disables the automounting of the token of the
+// default service account of namespace, and deletes its legacy token secrets.
+func hardenDefaultServiceAccount(ctx context.Context, conn kubernetes.Interface, namespace string, disableAutomount, removeLegacy bool, timeout time.Duration) error {
+	// The default service account of a new namespace is created shortly
+	// after it by a controller.
+	var sa *corev1.ServiceAccount
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var err error
+		sa, err = conn.CoreV1().ServiceAccounts(namespace).Get(ctx, "default", metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return retry.RetryableError(err)
+		}
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	var ops PatchOperations
+	if disableAutomount && (sa.AutomountServiceAccountToken == nil || *sa.AutomountServiceAccountToken) {
+		// Adding the field replaces it when it is set.
+		ops = append(ops, &AddOperation{
+			Path:  "/automountServiceAccountToken",
+			Value: false,
+		})
+	}
+	if removeLegacy {
+		tokens, err := legacyServiceAccountTokens(ctx, conn, sa)
+		if err != nil {
+			return err
+		}
+		for _, token := range tokens {
+			tflog.Info(ctx, fmt.Sprintf("Deleting legacy token secret %s/%s of the default service account", namespace, token.Name))
+			err := conn.CoreV1().Secrets(namespace).Delete(ctx, token.Name, metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+		if secrets := withoutSecretReferences(sa.Secrets, tokens); len(secrets) != len(sa.Secrets) {
+			ops = append(ops, &ReplaceOperation{
+				Path:  "/secrets",
+				Value: secrets,
+			})
+		}
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	_, err = conn.CoreV1().ServiceAccounts(namespace).Patch(ctx, sa.Name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	return err
+}
+
+// defaultServiceAccountHardened checks whether the default service account
+// of namespace is hardened as hardenDefaultServiceAccount would.
+func defaultServiceAccountHardened(ctx context.Context, conn kubernetes.Interface, namespace string, disableAutomount, removeLegacy bool) (bool, error) {
+	sa, err := conn.CoreV1().ServiceAccounts(namespace).Get(ctx, "default", metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if disableAutomount && (sa.AutomountServiceAccountToken == nil || *sa.AutomountServiceAccountToken) {
+		return false, nil
+	}
+	if removeLegacy {
+		tokens, err := legacyServiceAccountTokens(ctx, conn, sa)
+		if err != nil {
+			return false, err
+		}
+		return len(tokens) == 0, nil
+	}
+	return true, nil
+}
+
+// legacyServiceAccountTokens lists the long-lived token secrets generated for
+// the service account by the token controller before Kubernetes 1.24. These
+// are referenced by the secrets of the service account and named after it, by
+// the controller. Token secrets created on purpose are left alone.
+func legacyServiceAccountTokens(ctx context.Context, conn kubernetes.Interface, sa *corev1.ServiceAccount) ([]corev1.Secret, error) {
+	secrets, err := conn.CoreV1().Secrets(sa.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("type=%s", corev1.SecretTypeServiceAccountToken),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var tokens []corev1.Secret
+	for _, secret := range secrets.Items {
+		if secret.Type != corev1.SecretTypeServiceAccountToken || secret.Annotations[corev1.ServiceAccountNameKey] != sa.Name {
+			continue
+		}
+		referenced := slices.ContainsFunc(sa.Secrets, func(ref corev1.ObjectReference) bool { return ref.Name == secret.Name })
+		if referenced && strings.HasPrefix(secret.Name, sa.Name+"-token-") {
+			tokens = append(tokens, secret)
+		}
+	}
+	return tokens, nil
+}
+
+func withoutSecretReferences(refs []corev1.ObjectReference, secrets []corev1.Secret) []corev1.ObjectReference {
+	out := []corev1.ObjectReference{}
+	for _, ref := range refs {
+		if !slices.ContainsFunc(secrets, func(s corev1.Secret) bool { return s.Name == ref.Name }) {
+			out = append(out, ref)
+		}
+	}
+	return out
 }
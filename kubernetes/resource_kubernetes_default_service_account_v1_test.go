@@ -4,15 +4,87 @@
 package kubernetes
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
+func TestHardenDefaultServiceAccount(t *testing.T) {
+	token := func(name, serviceAccount string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "shop",
+				Annotations: map[string]string{corev1.ServiceAccountNameKey: serviceAccount},
+			},
+			Type: corev1.SecretTypeServiceAccountToken,
+		}
+	}
+	conn := fake.NewSimpleClientset(
+		&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "shop"},
+			Secrets:    []corev1.ObjectReference{{Name: "default-token-abcde"}, {Name: "registry"}, {Name: "default-deploy"}},
+		},
+		token("default-token-abcde", "default"),
+		token("ci-token-fghij", "ci"),
+		// Long-lived tokens created on purpose for the default service account.
+		token("default-deploy", "default"),
+		token("default-token-manual", "default"),
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "shop"}},
+	)
+	ctx := context.Background()
+
+	hardened, err := defaultServiceAccountHardened(ctx, conn, "shop", true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hardened {
+		t.Fatal("Expected the default service account not to be hardened")
+	}
+
+	if err := hardenDefaultServiceAccount(ctx, conn, "shop", true, true, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	hardened, err = defaultServiceAccountHardened(ctx, conn, "shop", true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hardened {
+		t.Fatal("Expected the default service account to be hardened")
+	}
+
+	sa, err := conn.CoreV1().ServiceAccounts("shop").Get(ctx, "default", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sa.AutomountServiceAccountToken == nil || *sa.AutomountServiceAccountToken {
+		t.Fatalf("Expected automountServiceAccountToken to be false, got %v", sa.AutomountServiceAccountToken)
+	}
+	if fmt.Sprint(sa.Secrets) != fmt.Sprint([]corev1.ObjectReference{{Name: "registry"}, {Name: "default-deploy"}}) {
+		t.Fatalf("Expected only the legacy token secret to be removed, got %v", sa.Secrets)
+	}
+	secrets, err := conn.CoreV1().Secrets("shop").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, s := range secrets.Items {
+		names = append(names, s.Name)
+	}
+	if fmt.Sprint(names) != "[ci-token-fghij default-deploy default-token-manual registry]" {
+		t.Fatalf("Expected only the legacy token of the default service account to be deleted, got %v", names)
+	}
+}
+
 func TestAccKubernetesDefaultServiceAccountV1_basic(t *testing.T) {
 	var conf corev1.ServiceAccount
 	namespace := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
@@ -123,6 +195,48 @@ func TestAccKubernetesDefaultServiceAccountV1_automountServiceAccountToken(t *te
 	})
 }
 
+func TestAccKubernetesDefaultServiceAccountV1_hardenNamespaces(t *testing.T) {
+	namespace := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "kubernetes_default_service_account_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfClusterVersionLessThan(t, "1.24.0")
+		},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesServiceAccountV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDefaultServiceAccountV1Config_hardenNamespaces(namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "remove_legacy_token_secrets", "true"),
+					resource.TestCheckResourceAttr(resourceName, "harden_namespaces.#", "2"),
+					testAccCheckKubernetesDefaultServiceAccountV1Hardened(namespace+"-a"),
+					testAccCheckKubernetesDefaultServiceAccountV1Hardened(namespace+"-b"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesDefaultServiceAccountV1Hardened(namespace string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+		if err != nil {
+			return err
+		}
+		hardened, err := defaultServiceAccountHardened(context.Background(), conn, namespace, true, true)
+		if err != nil {
+			return err
+		}
+		if !hardened {
+			return fmt.Errorf("The default service account of namespace %q is not hardened", namespace)
+		}
+		return nil
+	}
+}
+
 func testAccKubernetesDefaultServiceAccountV1Config_basic(namespace string) string {
 	return fmt.Sprintf(`resource "kubernetes_namespace_v1" "test" {
   metadata {
@@ -202,3 +316,26 @@ resource "kubernetes_default_service_account_v1" "test" {
 }
 `, namespace)
 }
+
+func testAccKubernetesDefaultServiceAccountV1Config_hardenNamespaces(namespace string) string {
+	return fmt.Sprintf(`resource "kubernetes_namespace_v1" "test" {
+  for_each = toset(["%[1]s", "%[1]s-a", "%[1]s-b"])
+  metadata {
+    name = each.key
+  }
+}
+
+resource "kubernetes_default_service_account_v1" "test" {
+  metadata {
+    namespace = kubernetes_namespace_v1.test["%[1]s"].metadata.0.name
+  }
+
+  automount_service_account_token = false
+  remove_legacy_token_secrets     = true
+  harden_namespaces = [
+    kubernetes_namespace_v1.test["%[1]s-a"].metadata.0.name,
+    kubernetes_namespace_v1.test["%[1]s-b"].metadata.0.name,
+  ]
+}
+`, namespace)
+}
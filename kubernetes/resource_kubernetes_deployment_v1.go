@@ -6,10 +6,10 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"regexp"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -35,6 +36,7 @@ func resourceKubernetesDeploymentV1() *schema.Resource {
 		ReadContext:   resourceKubernetesDeploymentV1Read,
 		UpdateContext: resourceKubernetesDeploymentV1Update,
 		DeleteContext: resourceKubernetesDeploymentV1Delete,
+		CustomizeDiff: customizeRolloutRestartDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -73,7 +75,7 @@ func resourceKubernetesDeploymentSchemaV1() map[string]*schema.Schema {
 					},
 					"paused": {
 						Type:        schema.TypeBool,
-						Description: "Indicates that the deployment is paused.",
+						Description: "Indicates that the deployment is paused, i.e. that changes of its pod template aren't rolled out until it's resumed, e.g. to stage several changes and roll them out at once. Scaling a paused deployment still takes effect.",
 						Optional:    true,
 						Default:     false,
 					},
@@ -209,9 +211,11 @@ func resourceKubernetesDeploymentSchemaV1() map[string]*schema.Schema {
 				},
 			},
 		},
+		"rollout_restart_triggers": rolloutRestartTriggersSchema("deployment"),
+		"rollout_restarted_at":     rolloutRestartedAtSchema("deployment"),
 		"wait_for_rollout": {
 			Type:        schema.TypeBool,
-			Description: "Wait for the rollout of the deployment to complete. Defaults to true.",
+			Description: "Wait for the rollout of the deployment to complete. When the rollout fails or times out, the warning events of the deployment, of its replica sets and of its pods, e.g. `FailedScheduling` or `FailedCreate`, are reported with the error. The rollout isn't waited for while the deployment is paused. Defaults to true.",
 			Default:     true,
 			Optional:    true,
 		},
@@ -245,7 +249,7 @@ func resourceKubernetesDeploymentV1Create(ctx context.Context, d *schema.Resourc
 		Spec:       *spec,
 	}
 
-	log.Printf("[INFO] Creating new deployment: %#v", deployment)
+	tflog.Info(ctx, fmt.Sprintf("Creating new deployment: %#v", deployment))
 	out, err := conn.AppsV1().Deployments(metadata.Namespace).Create(ctx, &deployment, metav1.CreateOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to create deployment: %s", err)
@@ -253,20 +257,22 @@ func resourceKubernetesDeploymentV1Create(ctx context.Context, d *schema.Resourc
 
 	d.SetId(buildId(out.ObjectMeta))
 
-	log.Printf("[DEBUG] Waiting for deployment %s to schedule %d replicas", d.Id(), *out.Spec.Replicas)
+	tflog.Debug(ctx, fmt.Sprintf("Waiting for deployment %s to schedule %d replicas", d.Id(), *out.Spec.Replicas))
 
-	if d.Get("wait_for_rollout").(bool) {
-		log.Printf("[INFO] Waiting for deployment %s/%s to rollout", out.ObjectMeta.Namespace, out.ObjectMeta.Name)
-		err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate),
-			waitForDeploymentReplicasFunc(ctx, conn, out.GetNamespace(), out.GetName()))
-		if err != nil {
-			return diag.FromErr(err)
+	var diags diag.Diagnostics
+	if d.Get("wait_for_rollout").(bool) && out.Spec.Paused {
+		tflog.Info(ctx, fmt.Sprintf("Not waiting for deployment %s/%s to rollout while it is paused", out.ObjectMeta.Namespace, out.ObjectMeta.Name))
+	} else if d.Get("wait_for_rollout").(bool) {
+		tflog.Info(ctx, fmt.Sprintf("Waiting for deployment %s/%s to rollout", out.ObjectMeta.Namespace, out.ObjectMeta.Name))
+		diags = waitForDeploymentRollout(ctx, conn, sharedWatchesOf(meta), out, d.Timeout(schema.TimeoutCreate))
+		if diags.HasError() {
+			return diags
 		}
 	}
 
-	log.Printf("[INFO] Submitted new deployment: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new deployment: %#v", out))
 
-	return resourceKubernetesDeploymentV1Read(ctx, d, meta)
+	return append(diags, resourceKubernetesDeploymentV1Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesDeploymentV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -282,11 +288,12 @@ func resourceKubernetesDeploymentV1Update(ctx context.Context, d *schema.Resourc
 
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 
-	if d.HasChange("spec") {
+	if d.HasChange("spec") || d.HasChange("rollout_restart_triggers") {
 		spec, err := expandDeploymentSpec(d.Get("spec").([]interface{}))
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		expandRolloutRestartedAt(&spec.Template, d)
 
 		ops = append(ops, &ReplaceOperation{
 			Path:  "/spec",
@@ -308,23 +315,25 @@ func resourceKubernetesDeploymentV1Update(ctx context.Context, d *schema.Resourc
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating deployment %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating deployment %q: %v", name, string(data)))
 	out, err := conn.AppsV1().Deployments(namespace).Patch(ctx, name, types.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update deployment: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated deployment: %#v", out)
-
-	if d.Get("wait_for_rollout").(bool) {
-		log.Printf("[INFO] Waiting for deployment %s/%s to rollout", out.ObjectMeta.Namespace, out.ObjectMeta.Name)
-		err := retry.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate),
-			waitForDeploymentReplicasFunc(ctx, conn, out.GetNamespace(), out.GetName()))
-		if err != nil {
-			return diag.FromErr(err)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated deployment: %#v", out))
+
+	var diags diag.Diagnostics
+	if d.Get("wait_for_rollout").(bool) && out.Spec.Paused {
+		tflog.Info(ctx, fmt.Sprintf("Not waiting for deployment %s/%s to rollout while it is paused", out.ObjectMeta.Namespace, out.ObjectMeta.Name))
+	} else if d.Get("wait_for_rollout").(bool) {
+		tflog.Info(ctx, fmt.Sprintf("Waiting for deployment %s/%s to rollout", out.ObjectMeta.Namespace, out.ObjectMeta.Name))
+		diags = waitForDeploymentRollout(ctx, conn, sharedWatchesOf(meta), out, d.Timeout(schema.TimeoutUpdate))
+		if diags.HasError() {
+			return diags
 		}
 	}
 
-	return resourceKubernetesDeploymentV1Read(ctx, d, meta)
+	return append(diags, resourceKubernetesDeploymentV1Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesDeploymentV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -346,19 +355,24 @@ func resourceKubernetesDeploymentV1Read(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading deployment %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading deployment %s", name))
 	deployment, err := conn.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received deployment: %#v", deployment)
+	tflog.Info(ctx, fmt.Sprintf("Received deployment: %#v", deployment))
 
 	err = d.Set("metadata", flattenMetadata(deployment.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	err = d.Set("rollout_restarted_at", flattenRolloutRestartedAt(&deployment.Spec.Template, d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	spec, err := flattenDeploymentSpec(deployment.Spec, d, meta)
 	if err != nil {
 		return diag.FromErr(err)
@@ -383,7 +397,7 @@ func resourceKubernetesDeploymentV1Delete(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting deployment: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting deployment: %#v", name))
 
 	err = conn.AppsV1().Deployments(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
@@ -393,8 +407,8 @@ func resourceKubernetesDeploymentV1Delete(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
-	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
-		_, err := conn.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	err = retryWithProgress(ctx, d.Timeout(schema.TimeoutDelete), fmt.Sprintf("deployment %q to be deleted", d.Id()), func() *retry.RetryError {
+		out, err := conn.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
 			if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 				return nil
@@ -402,14 +416,14 @@ func resourceKubernetesDeploymentV1Delete(ctx context.Context, d *schema.Resourc
 			return retry.NonRetryableError(err)
 		}
 
-		e := fmt.Errorf("Deployment (%s) still exists", d.Id())
+		e := fmt.Errorf("Deployment (%s) still exists (%s)", d.Id(), deletionProgress(out.ObjectMeta))
 		return retry.RetryableError(e)
 	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deployment %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Deployment %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -426,13 +440,13 @@ func resourceKubernetesDeploymentV1Exists(ctx context.Context, d *schema.Resourc
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking deployment %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking deployment %s", name))
 	_, err = conn.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
@@ -449,12 +463,16 @@ func GetDeploymentCondition(status appsv1.DeploymentStatus, condType appsv1.Depl
 	return nil
 }
 
-func waitForDeploymentReplicasFunc(ctx context.Context, conn *kubernetes.Clientset, ns, name string) retry.RetryFunc {
-	return func() *retry.RetryError {
-		// Query the deployment to get a status update.
-		dply, err := conn.AppsV1().Deployments(ns).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return retry.NonRetryableError(err)
+// waitForDeploymentRollout watches the deployment until its rollout has finished,
+// reporting the warning events of the deployment and its pods along the way.
+func waitForDeploymentRollout(ctx context.Context, conn *kubernetes.Clientset, watches *sharedWatches, deployment *appsv1.Deployment, timeout time.Duration) diag.Diagnostics {
+	ns, name := deployment.Namespace, deployment.Name
+	events := startWaitEventReporter(ctx, conn, deployment.ObjectMeta, "Deployment", deployment.Spec.Selector)
+
+	err := watchObjectUntil(ctx, watches, timeout, fmt.Sprintf("rollout of deployment %q", buildId(deployment.ObjectMeta)), conn.AppsV1().Deployments(ns), ns, name, &appsv1.Deployment{}, func(event watch.Event) *retry.RetryError {
+		dply, ok := event.Object.(*appsv1.Deployment)
+		if event.Type == watch.Deleted || !ok {
+			return retry.NonRetryableError(fmt.Errorf("Deployment %s/%s was deleted while waiting for rollout", ns, name))
 		}
 
 		var specReplicas int32 = 1 // default, according to API docs
@@ -469,27 +487,39 @@ func waitForDeploymentReplicasFunc(ctx context.Context, conn *kubernetes.Clients
 		if dply.Generation == dply.Status.ObservedGeneration {
 			cond := GetDeploymentCondition(dply.Status, appsv1.DeploymentProgressing)
 			if cond != nil && cond.Reason == TimedOutReason {
-				return retry.NonRetryableError(fmt.Errorf("Deployment exceeded its progress deadline"))
+				return retry.NonRetryableError(fmt.Errorf("Deployment exceeded its progress deadline: %s (%s)", cond.Message, deploymentV1Progress(dply, specReplicas)))
 			}
 
 			if dply.Status.UpdatedReplicas < specReplicas {
-				return retry.RetryableError(fmt.Errorf("Waiting for rollout to finish: %d out of %d new replicas have been updated...", dply.Status.UpdatedReplicas, specReplicas))
+				return retry.RetryableError(fmt.Errorf("Waiting for rollout to finish: %d out of %d new replicas have been updated... (%s)", dply.Status.UpdatedReplicas, specReplicas, deploymentV1Progress(dply, specReplicas)))
 			}
 
 			if dply.Status.Replicas > dply.Status.UpdatedReplicas {
-				return retry.RetryableError(fmt.Errorf("Waiting for rollout to finish: %d old replicas are pending termination...", dply.Status.Replicas-dply.Status.UpdatedReplicas))
+				return retry.RetryableError(fmt.Errorf("Waiting for rollout to finish: %d old replicas are pending termination... (%s)", dply.Status.Replicas-dply.Status.UpdatedReplicas, deploymentV1Progress(dply, specReplicas)))
 			}
 
 			if dply.Status.Replicas > dply.Status.ReadyReplicas {
-				return retry.RetryableError(fmt.Errorf("Waiting for rollout to finish: %d replicas wanted; %d replicas Ready", dply.Status.Replicas, dply.Status.ReadyReplicas))
+				return retry.RetryableError(fmt.Errorf("Waiting for rollout to finish: %d/%d replicas ready", dply.Status.ReadyReplicas, dply.Status.Replicas))
 			}
 
 			if dply.Status.AvailableReplicas < dply.Status.UpdatedReplicas {
-				return retry.RetryableError(fmt.Errorf("Waiting for rollout to finish: %d of %d updated replicas are available...", dply.Status.AvailableReplicas, dply.Status.UpdatedReplicas))
+				return retry.RetryableError(fmt.Errorf("Waiting for rollout to finish: %d of %d updated replicas are available... (%s)", dply.Status.AvailableReplicas, dply.Status.UpdatedReplicas, deploymentV1Progress(dply, specReplicas)))
 			}
 			return nil
 		}
 
 		return retry.NonRetryableError(fmt.Errorf("Observed generation %d is not expected to be greater than generation %d", dply.Status.ObservedGeneration, dply.Generation))
+	})
+
+	return events.Diagnostics(ctx, err)
+}
+
+// deploymentV1Progress summarizes the replicas of a deployment, e.g. "3/5 replicas ready",
+// with the reason its replica sets fail to create pods, if any.
+func deploymentV1Progress(dply *appsv1.Deployment, specReplicas int32) string {
+	progress := fmt.Sprintf("%d/%d replicas ready", dply.Status.ReadyReplicas, specReplicas)
+	if cond := GetDeploymentCondition(dply.Status, appsv1.DeploymentReplicaFailure); cond != nil && cond.Status == corev1.ConditionTrue {
+		progress += fmt.Sprintf(", replica failure: %s: %s", cond.Reason, cond.Message)
 	}
+	return progress
 }
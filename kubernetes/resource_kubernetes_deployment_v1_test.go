@@ -1255,6 +1255,101 @@ func TestAccKubernetesDeploymentV1_with_restart_policy(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesDeploymentV1_paused(t *testing.T) {
+	var conf1, conf2 appsv1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "kubernetes_deployment_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesDeploymentV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentV1Config_paused(name, busyboxImage, "300", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentV1Exists(resourceName, &conf1),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.paused", "false"),
+				),
+			},
+			{
+				// The change of the template isn't rolled out, which must not
+				// be waited for.
+				Config: testAccKubernetesDeploymentV1Config_paused(name, busyboxImage, "600", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentV1Exists(resourceName, &conf2),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.paused", "true"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.template.0.spec.0.container.0.command.1", "600"),
+					func(s *terraform.State) error {
+						if conf2.Status.UpdatedReplicas != 0 {
+							return fmt.Errorf("Expected the change of the template not to be rolled out, got %d updated replicas", conf2.Status.UpdatedReplicas)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccKubernetesDeploymentV1Config_paused(name, busyboxImage, "600", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentV1Exists(resourceName, &conf2),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.paused", "false"),
+					func(s *terraform.State) error {
+						if conf2.Status.UpdatedReplicas != 1 {
+							return fmt.Errorf("Expected the change of the template to be rolled out once resumed, got %d updated replicas", conf2.Status.UpdatedReplicas)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDeploymentV1_rolloutRestartTriggers(t *testing.T) {
+	var conf1, conf2 appsv1.Deployment
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "kubernetes_deployment_v1.test"
+	imageName := busyboxImage
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesDeploymentV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeploymentV1Config_rolloutRestartTriggers(name, imageName, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentV1Exists(resourceName, &conf1),
+					resource.TestCheckResourceAttr(resourceName, "rollout_restart_triggers.config", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rollout_restarted_at", ""),
+				),
+			},
+			{
+				Config: testAccKubernetesDeploymentV1Config_rolloutRestartTriggers(name, imageName, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesDeploymentV1Exists(resourceName, &conf2),
+					resource.TestCheckResourceAttr(resourceName, "rollout_restart_triggers.config", "2"),
+					resource.TestCheckResourceAttrSet(resourceName, "rollout_restarted_at"),
+					resource.TestCheckNoResourceAttr(resourceName, "spec.0.template.0.metadata.0.annotations.kubectl.kubernetes.io/restartedAt"),
+					func(s *terraform.State) error {
+						if conf2.Spec.Template.Annotations[rolloutRestartedAtAnnotation] == "" {
+							return fmt.Errorf("Expected the pod template to be annotated with %s", rolloutRestartedAtAnnotation)
+						}
+						if conf2.Generation == conf1.Generation {
+							return fmt.Errorf("Expected a new rollout of the deployment")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:   testAccKubernetesDeploymentV1Config_rolloutRestartTriggers(name, imageName, "2"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func testAccCheckKubernetesDeploymentForceNew(old, new *appsv1.Deployment, wantNew bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if wantNew {
@@ -1472,6 +1567,74 @@ func testAccKubernetesDeploymentV1Config_with_restart_policy(name, imageName, re
 `, name, imageName, restartPolicy)
 }
 
+func testAccKubernetesDeploymentV1Config_paused(name, imageName, sleep string, paused bool) string {
+	return fmt.Sprintf(`resource "kubernetes_deployment_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    replicas = 1
+    paused   = %t
+    selector {
+      match_labels = {
+        TestLabelOne = "one"
+      }
+    }
+    template {
+      metadata {
+        labels = {
+          TestLabelOne = "one"
+        }
+      }
+      spec {
+        container {
+          image   = "%s"
+          name    = "tf-acc-test"
+          command = ["sleep", "%s"]
+        }
+        termination_grace_period_seconds = 1
+      }
+    }
+  }
+}
+`, name, paused, imageName, sleep)
+}
+
+func testAccKubernetesDeploymentV1Config_rolloutRestartTriggers(name, imageName, config string) string {
+	return fmt.Sprintf(`resource "kubernetes_deployment_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    replicas = 1
+    selector {
+      match_labels = {
+        TestLabelOne = "one"
+      }
+    }
+    template {
+      metadata {
+        labels = {
+          TestLabelOne = "one"
+        }
+      }
+      spec {
+        container {
+          image   = "%s"
+          name    = "tf-acc-test"
+          command = ["sleep", "300"]
+        }
+        termination_grace_period_seconds = 1
+      }
+    }
+  }
+  rollout_restart_triggers = {
+    config = "%s"
+  }
+}
+`, name, imageName, config)
+}
+
 func testAccKubernetesDeploymentV1Config_initContainer(namespace, name, imageName, imageName1, memory, envName, initName, initCommand, pullPolicy string) string {
 	return fmt.Sprintf(`resource "kubernetes_namespace_v1" "test" {
   metadata {
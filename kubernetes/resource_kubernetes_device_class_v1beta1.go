@@ -0,0 +1,231 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	resourcev1beta1 "k8s.io/api/resource/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func resourceKubernetesDeviceClassV1Beta1() *schema.Resource {
+	return &schema.Resource{
+		Description:   "A device class contains the device configuration and selectors of a kind of devices, e.g. the GPUs of a vendor, which can be referenced in the device requests of resource claims. Requires the Dynamic Resource Allocation API `resource.k8s.io/v1beta1` to be enabled.",
+		CreateContext: resourceKubernetesDeviceClassV1Beta1Create,
+		ReadContext:   resourceKubernetesDeviceClassV1Beta1Read,
+		UpdateContext: resourceKubernetesDeviceClassV1Beta1Update,
+		DeleteContext: resourceKubernetesDeviceClassV1Beta1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: resourceKubernetesDeviceClassV1Beta1Schema(),
+	}
+}
+
+func resourceKubernetesDeviceClassV1Beta1Schema() map[string]*schema.Schema {
+	docDeviceClass := resourcev1beta1.DeviceClass{}.SwaggerDoc()
+	docDeviceClassSpec := resourcev1beta1.DeviceClassSpec{}.SwaggerDoc()
+
+	return map[string]*schema.Schema{
+		"metadata": metadataSchema("device class", true),
+		"spec": {
+			Type:        schema.TypeList,
+			Description: docDeviceClass["spec"],
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"selectors": deviceSelectorSchema(true),
+					"config": {
+						Type:        schema.TypeList,
+						Description: docDeviceClassSpec["config"],
+						Optional:    true,
+						MaxItems:    resourcev1beta1.DeviceConfigMaxSize,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"opaque": opaqueDeviceConfigurationSchema(true),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesDeviceClassV1Beta1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := checkResourceV1Beta1Served(meta, "deviceclasses"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	deviceClass := &resourcev1beta1.DeviceClass{
+		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:       expandDeviceClassV1Beta1Spec(d.Get("spec").([]interface{})),
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating new device class: %#v", deviceClass))
+	out, err := conn.ResourceV1beta1().DeviceClasses().Create(ctx, deviceClass, metav1.CreateOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to create device class %q: %s", deviceClass.Name, err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Submitted new device class: %#v", out))
+	d.SetId(out.Name)
+
+	return resourceKubernetesDeviceClassV1Beta1Read(ctx, d, meta)
+}
+
+func resourceKubernetesDeviceClassV1Beta1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	exists, err := resourceKubernetesDeviceClassV1Beta1Exists(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !exists {
+		d.SetId("")
+		return diag.Diagnostics{}
+	}
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+
+	tflog.Info(ctx, fmt.Sprintf("Reading device class %s", name))
+	deviceClass, err := conn.ResourceV1beta1().DeviceClasses().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
+		return diag.Errorf("Failed to read device class %q: %s", name, err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Received device class: %#v", deviceClass))
+
+	err = d.Set("metadata", flattenMetadata(deviceClass.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("spec", flattenDeviceClassV1Beta1Spec(deviceClass.Spec))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKubernetesDeviceClassV1Beta1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+
+	deviceClass, err := conn.ResourceV1beta1().DeviceClasses().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to read device class %q: %s", name, err)
+	}
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	deviceClass.Labels = metadata.Labels
+	deviceClass.Annotations = metadata.Annotations
+	deviceClass.Spec = expandDeviceClassV1Beta1Spec(d.Get("spec").([]interface{}))
+
+	tflog.Info(ctx, fmt.Sprintf("Updating device class %q: %#v", name, deviceClass))
+	out, err := conn.ResourceV1beta1().DeviceClasses().Update(ctx, deviceClass, metav1.UpdateOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to update device class %q: %s", name, err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated device class: %#v", out))
+
+	return resourceKubernetesDeviceClassV1Beta1Read(ctx, d, meta)
+}
+
+func resourceKubernetesDeviceClassV1Beta1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	name := d.Id()
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting device class: %#v", name))
+	err = conn.ResourceV1beta1().DeviceClasses().Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return diag.Errorf("Failed to delete device class %q: %s", name, err)
+	}
+
+	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+		_, err := conn.ResourceV1beta1().DeviceClasses().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return retry.NonRetryableError(err)
+		}
+
+		e := fmt.Errorf("Device class (%s) still exists", name)
+		return retry.RetryableError(e)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Device class %s deleted", name))
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesDeviceClassV1Beta1Exists(ctx context.Context, d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return false, err
+	}
+
+	name := d.Id()
+
+	tflog.Info(ctx, fmt.Sprintf("Checking device class %s", name))
+	_, err = conn.ResourceV1beta1().DeviceClasses().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
+	}
+	return true, err
+}
+
+// checkResourceV1Beta1Served returns an error explaining how to enable the
+// Dynamic Resource Allocation API when the cluster doesn't serve the given
+// resource of resource.k8s.io/v1beta1.
+func checkResourceV1Beta1Served(meta interface{}, resource string) error {
+	dc, err := meta.(KubeClientsets).DiscoveryClient()
+	if err != nil {
+		return err
+	}
+	gv := resourcev1beta1.SchemeGroupVersion.String()
+	resources, err := dc.ServerResourcesForGroupVersion(gv)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if resources != nil {
+		for _, r := range resources.APIResources {
+			if r.Name == resource {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("The cluster doesn't serve %s in %s. The Dynamic Resource Allocation API requires Kubernetes 1.32 or later with the DynamicResourceAllocation feature gate and the %s API enabled: https://kubernetes.io/docs/concepts/scheduling-eviction/dynamic-resource-allocation/", resource, gv, gv)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesDeviceClassV1Beta1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	resourceName := "kubernetes_device_class_v1beta1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfNoDynamicResourceAllocation(t)
+		},
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesDeviceClassV1Beta1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDeviceClassV1Beta1Config_basic(name, "gpu.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.uid"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.selectors.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.selectors.0.cel.0.expression", `device.driver == "gpu.example.com"`),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.config.0.opaque.0.driver", "gpu.example.com"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+			{
+				Config: testAccKubernetesDeviceClassV1Beta1Config_basic(name, "tpu.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "spec.0.selectors.0.cel.0.expression", `device.driver == "tpu.example.com"`),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.config.0.opaque.0.driver", "tpu.example.com"),
+				),
+			},
+		},
+	})
+}
+
+func skipIfNoDynamicResourceAllocation(t *testing.T) {
+	if err := checkResourceV1Beta1Served(testAccProvider.Meta(), "deviceclasses"); err != nil {
+		t.Skip("The resource.k8s.io/v1beta1 API must be enabled for this test to run - skipping")
+	}
+}
+
+func testAccCheckKubernetesDeviceClassV1Beta1Destroy(s *terraform.State) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	if err != nil {
+		return err
+	}
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_device_class_v1beta1" {
+			continue
+		}
+
+		_, err := conn.ResourceV1beta1().DeviceClasses().Get(ctx, rs.Primary.ID, metav1.GetOptions{})
+		if err == nil {
+			return fmt.Errorf("Device class still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccKubernetesDeviceClassV1Beta1Config_basic(name, driver string) string {
+	return fmt.Sprintf(`resource "kubernetes_device_class_v1beta1" "test" {
+  metadata {
+    name = %[1]q
+  }
+  spec {
+    selectors {
+      cel {
+        expression = "device.driver == \"%[2]s\""
+      }
+    }
+    config {
+      opaque {
+        driver = %[2]q
+        parameters = jsonencode({
+          apiVersion = "%[2]s/v1"
+          kind       = "DeviceConfig"
+        })
+      }
+    }
+  }
+}
+`, name, driver)
+}
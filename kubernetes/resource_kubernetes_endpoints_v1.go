@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -51,12 +52,12 @@ func resourceKubernetesEndpointsV1Create(ctx context.Context, d *schema.Resource
 		ObjectMeta: metadata,
 		Subsets:    expandEndpointsSubsets(d.Get("subset").(*schema.Set)),
 	}
-	log.Printf("[INFO] Creating new endpoints: %#v", ep)
+	tflog.Info(ctx, fmt.Sprintf("Creating new endpoints: %#v", ep))
 	out, err := conn.CoreV1().Endpoints(metadata.Namespace).Create(ctx, &ep, metav1.CreateOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to create endpoints because: %s", err)
 	}
-	log.Printf("[INFO] Submitted new endpoints: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new endpoints: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesEndpointsV1Read(ctx, d, meta)
@@ -81,20 +82,20 @@ func resourceKubernetesEndpointsV1Read(ctx context.Context, d *schema.ResourceDa
 		return diag.Errorf("Failed to read endpoints because: %s", err)
 	}
 
-	log.Printf("[INFO] Reading endpoints %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading endpoints %s", name))
 	ep, err := conn.CoreV1().Endpoints(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.Errorf("Failed to read endpoint because: %s", err)
 	}
-	log.Printf("[INFO] Received endpoints: %#v", ep)
+	tflog.Info(ctx, fmt.Sprintf("Received endpoints: %#v", ep))
 	err = d.Set("metadata", flattenMetadata(ep.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.Errorf("Failed to read endpoints because: %s", err)
 	}
 
 	flattened := flattenEndpointsSubsets(ep.Subsets)
-	log.Printf("[DEBUG] Flattened endpoints subset: %#v", flattened)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened endpoints subset: %#v", flattened))
 	err = d.Set("subset", flattened)
 	if err != nil {
 		return diag.Errorf("Failed to read endpoints because: %s", err)
@@ -126,12 +127,12 @@ func resourceKubernetesEndpointsV1Update(ctx context.Context, d *schema.Resource
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating endpoints %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating endpoints %q: %v", name, string(data)))
 	out, err := conn.CoreV1().Endpoints(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update endpoints: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated endpoints: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated endpoints: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesEndpointsV1Read(ctx, d, meta)
@@ -147,7 +148,7 @@ func resourceKubernetesEndpointsV1Delete(ctx context.Context, d *schema.Resource
 	if err != nil {
 		return diag.Errorf("Failed to delete endpoints because: %s", err)
 	}
-	log.Printf("[INFO] Deleting endpoints: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting endpoints: %#v", name))
 	err = conn.CoreV1().Endpoints(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -155,7 +156,7 @@ func resourceKubernetesEndpointsV1Delete(ctx context.Context, d *schema.Resource
 		}
 		return diag.Errorf("Failed to delete endpoints because: %s", err)
 	}
-	log.Printf("[INFO] Endpoints %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Endpoints %s deleted", name))
 	d.SetId("")
 
 	return nil
@@ -172,13 +173,13 @@ func resourceKubernetesEndpointsV1Exists(ctx context.Context, d *schema.Resource
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking endpoints %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking endpoints %s", name))
 	_, err = conn.CoreV1().Endpoints(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
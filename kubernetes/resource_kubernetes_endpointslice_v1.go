@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -66,12 +67,12 @@ func resourceKubernetesEndpointSliceV1Create(ctx context.Context, d *schema.Reso
 		Ports:       expandEndpointSlicePorts(d.Get("port").([]interface{})),
 	}
 
-	log.Printf("[INFO] Creating new endpoint_slice: %#v", endpoint_slice)
+	tflog.Info(ctx, fmt.Sprintf("Creating new endpoint_slice: %#v", endpoint_slice))
 	out, err := conn.DiscoveryV1().EndpointSlices(metadata.Namespace).Create(ctx, &endpoint_slice, metav1.CreateOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to create endpoint_slice because: %s", err)
 	}
-	log.Printf("[INFO] Submitted new endpoint_slice: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new endpoint_slice: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesEndpointSliceV1Read(ctx, d, meta)
@@ -88,15 +89,15 @@ func resourceKubernetesEndpointSliceV1Read(ctx context.Context, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading endpoint slice %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading endpoint slice %s", name))
 	endpoint, err := conn.DiscoveryV1().EndpointSlices(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to read endpoint_slice because: %s", err)
 	}
-	log.Printf("[INFO] Received endpoint slice: %#v", endpoint)
+	tflog.Info(ctx, fmt.Sprintf("Received endpoint slice: %#v", endpoint))
 
 	address_type := d.Get("address_type").(string)
-	log.Printf("[DEBUG] Default address type is %q", address_type)
+	tflog.Debug(ctx, fmt.Sprintf("Default address type is %q", address_type))
 	d.Set("address_type", address_type)
 
 	err = d.Set("metadata", flattenMetadata(endpoint.ObjectMeta, d, meta))
@@ -105,14 +106,14 @@ func resourceKubernetesEndpointSliceV1Read(ctx context.Context, d *schema.Resour
 	}
 
 	flattenedEndpoints := flattenEndpointSliceEndpoints(endpoint.Endpoints)
-	log.Printf("[DEBUG] Flattened EndpointSlice Endpoints: %#v", flattenedEndpoints)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened EndpointSlice Endpoints: %#v", flattenedEndpoints))
 	err = d.Set("endpoint", flattenedEndpoints)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattenedPorts := flattenEndpointSlicePorts(endpoint.Ports)
-	log.Printf("[DEBUG] Flattened EndpointSlice Ports: %#v", flattenedPorts)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened EndpointSlice Ports: %#v", flattenedPorts))
 	err = d.Set("port", flattenedPorts)
 	if err != nil {
 		return diag.FromErr(err)
@@ -158,12 +159,12 @@ func resourceKubernetesEndpointSliceV1Update(ctx context.Context, d *schema.Reso
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating endpointSlice %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating endpointSlice %q: %v", name, string(data)))
 	out, err := conn.DiscoveryV1().EndpointSlices(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update endpointSlice: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated endpointSlice: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated endpointSlice: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesEndpointSliceV1Read(ctx, d, meta)
@@ -179,7 +180,7 @@ func resourceKubernetesEndpointSliceV1Delete(ctx context.Context, d *schema.Reso
 	if err != nil {
 		return diag.Errorf("Failed to delete endpointSlice because: %s", err)
 	}
-	log.Printf("[INFO] Deleting endpointSlice: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting endpointSlice: %#v", name))
 	err = conn.DiscoveryV1().EndpointSlices(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -187,7 +188,7 @@ func resourceKubernetesEndpointSliceV1Delete(ctx context.Context, d *schema.Reso
 		}
 		return diag.Errorf("Failed to delete endpoints because: %s", err)
 	}
-	log.Printf("[INFO] EndpointSlice %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("EndpointSlice %s deleted", name))
 	d.SetId("")
 
 	return nil
@@ -0,0 +1,279 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	defaultEventReportingController = "terraform.io/terraform-provider-kubernetes"
+	defaultEventReportingInstance   = "terraform-provider-kubernetes"
+)
+
+func resourceKubernetesEventV1() *schema.Resource {
+	return &schema.Resource{
+		Description:   "An event reports something that happened to an object, e.g. that it was deployed by a Terraform run. Events show up in `kubectl describe` and `kubectl events` for the object they are about. The API server deletes events after the event TTL, one hour by default, after which Terraform creates the event again.",
+		CreateContext: resourceKubernetesEventV1Create,
+		ReadContext:   resourceKubernetesEventV1Read,
+		UpdateContext: resourceKubernetesEventV1Update,
+		DeleteContext: resourceKubernetesEventV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("event", true),
+			"regarding": {
+				Type:        schema.TypeList,
+				Description: "The object this event is about. Its namespace must be the namespace of the event, unless the object is cluster-scoped.",
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:        schema.TypeString,
+							Description: "API version of the object, e.g. `apps/v1`.",
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"field_path": {
+							Type:        schema.TypeString,
+							Description: "The field of the object the event is about, e.g. `spec.containers{web}`.",
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Description: "Kind of the object, e.g. `Deployment`.",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the object.",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the object. Empty for cluster-scoped objects.",
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"uid": {
+							Type:        schema.TypeString,
+							Description: "UID of the object.",
+							Optional:    true,
+							ForceNew:    true,
+						},
+					},
+				},
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Description:  "What was done to the object, or what failed, e.g. `Deploy`.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 128),
+			},
+			"reason": {
+				Type:         schema.TypeString,
+				Description:  "Why the action was taken, in UpperCamelCase, e.g. `TerraformApply`.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringLenBetween(1, 128),
+			},
+			"note": {
+				Type:         schema.TypeString,
+				Description:  "A human-readable description of the event, e.g. the Terraform run which deployed the object.",
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 1024),
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Description:  "Type of the event, `Normal` or `Warning`. Defaults to `Normal`.",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      corev1.EventTypeNormal,
+				ValidateFunc: validation.StringInSlice([]string{corev1.EventTypeNormal, corev1.EventTypeWarning}, false),
+			},
+			"reporting_controller": {
+				Type:         schema.TypeString,
+				Description:  fmt.Sprintf("Name of the controller which emitted the event. Defaults to `%s`.", defaultEventReportingController),
+				Optional:     true,
+				ForceNew:     true,
+				Default:      defaultEventReportingController,
+				ValidateFunc: validateQualifiedName,
+			},
+			"reporting_instance": {
+				Type:         schema.TypeString,
+				Description:  fmt.Sprintf("ID of the instance of the controller which emitted the event, e.g. the name of the Terraform workspace. Defaults to `%s`.", defaultEventReportingInstance),
+				Optional:     true,
+				ForceNew:     true,
+				Default:      defaultEventReportingInstance,
+				ValidateFunc: validation.StringLenBetween(1, 128),
+			},
+			"event_time": {
+				Type:        schema.TypeString,
+				Description: "The time the event was emitted, in RFC 3339 format.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesEventV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	event := eventsv1.Event{
+		ObjectMeta:          metadata,
+		EventTime:           metav1.NewMicroTime(time.Now()),
+		Regarding:           expandEventV1Regarding(d.Get("regarding").([]interface{})),
+		Action:              d.Get("action").(string),
+		Reason:              d.Get("reason").(string),
+		Note:                d.Get("note").(string),
+		Type:                d.Get("type").(string),
+		ReportingController: d.Get("reporting_controller").(string),
+		ReportingInstance:   d.Get("reporting_instance").(string),
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating new event: %#v", event))
+	out, err := conn.EventsV1().Events(metadata.Namespace).Create(ctx, &event, metav1.CreateOptions{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Submitted new event: %#v", out))
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesEventV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesEventV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Reading event %s", name))
+	event, err := conn.EventsV1().Events(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("Event %s was deleted, e.g. after the event TTL", name))
+			d.SetId("")
+			return nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Received event: %#v", event))
+
+	err = d.Set("metadata", flattenMetadata(event.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("regarding", flattenEventV1Regarding(event.Regarding))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("action", event.Action)
+	d.Set("reason", event.Reason)
+	d.Set("note", event.Note)
+	d.Set("type", event.Type)
+	d.Set("reporting_controller", event.ReportingController)
+	d.Set("reporting_instance", event.ReportingInstance)
+	d.Set("event_time", event.EventTime.UTC().Format(time.RFC3339Nano))
+
+	return nil
+}
+
+func resourceKubernetesEventV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("note") {
+		o, n := d.GetChange("note")
+		ops = append(ops, patchEventV1Note(o.(string), n.(string))...)
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return diag.Errorf("Failed to marshal update operations: %s", err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating event %q: %v", name, string(data)))
+	out, err := conn.EventsV1().Events(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to update event: %s", err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated event: %#v", out))
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesEventV1Read(ctx, d, meta)
+}
+
+// patchEventV1Note returns the operation changing the note of an event, which
+// is left out of the event when it is empty, so it can't always be replaced.
+func patchEventV1Note(o, n string) PatchOperations {
+	switch {
+	case o == "":
+		return PatchOperations{&AddOperation{Path: "/note", Value: n}}
+	case n == "":
+		return PatchOperations{&RemoveOperation{Path: "/note"}}
+	}
+	return PatchOperations{&ReplaceOperation{Path: "/note", Value: n}}
+}
+
+func resourceKubernetesEventV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Deleting event: %#v", name))
+	err = conn.EventsV1().Events(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Event %s deleted", name))
+
+	d.SetId("")
+	return nil
+}
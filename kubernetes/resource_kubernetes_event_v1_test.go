@@ -0,0 +1,156 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEventV1Regarding(t *testing.T) {
+	in := []interface{}{map[string]interface{}{
+		"api_version": "apps/v1",
+		"field_path":  "",
+		"kind":        "Deployment",
+		"name":        "web",
+		"namespace":   "default",
+		"uid":         "6b3a9b5c-6f4e-4b8e-9a57-3c2e0f1d2a4b",
+	}}
+
+	ref := expandEventV1Regarding(in)
+	expected := corev1.ObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       "web",
+		Namespace:  "default",
+		UID:        "6b3a9b5c-6f4e-4b8e-9a57-3c2e0f1d2a4b",
+	}
+	if diff := cmp.Diff(expected, ref); diff != "" {
+		t.Fatalf("Unexpected reference: mismatch (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(in, flattenEventV1Regarding(ref)); diff != "" {
+		t.Fatalf("Unexpected flattened reference: mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPatchEventV1Note(t *testing.T) {
+	cases := []struct {
+		Old, New string
+		Expected string
+	}{
+		// An event without note has no /note to replace.
+		{"", "Scaled up", `[{"path":"/note","value":"Scaled up","op":"add"}]`},
+		{"Scaled up", "Scaled down", `[{"path":"/note","value":"Scaled down","op":"replace"}]`},
+		{"Scaled down", "", `[{"path":"/note","op":"remove"}]`},
+	}
+	for _, tc := range cases {
+		data, err := patchEventV1Note(tc.Old, tc.New).MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tc.Expected {
+			t.Errorf("Changing the note from %q to %q: expected %s, got %s", tc.Old, tc.New, tc.Expected, data)
+		}
+	}
+}
+
+func TestAccKubernetesEventV1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	resourceName := "kubernetes_event_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesEventV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesEventV1Config_basic(name, "Deployed by run 1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.uid"),
+					resource.TestCheckResourceAttr(resourceName, "regarding.0.kind", "ConfigMap"),
+					resource.TestCheckResourceAttrPair(resourceName, "regarding.0.uid", "kubernetes_config_map_v1.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr(resourceName, "type", "Normal"),
+					resource.TestCheckResourceAttr(resourceName, "note", "Deployed by run 1"),
+					resource.TestCheckResourceAttr(resourceName, "reporting_controller", defaultEventReportingController),
+					resource.TestCheckResourceAttrSet(resourceName, "event_time"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+			{
+				Config: testAccKubernetesEventV1Config_basic(name, "Deployed by run 2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "note", "Deployed by run 2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesEventV1Destroy(s *terraform.State) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	if err != nil {
+		return err
+	}
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_event_v1" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.EventsV1().Events(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			return fmt.Errorf("Event still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccKubernetesEventV1Config_basic(name, note string) string {
+	return fmt.Sprintf(`resource "kubernetes_config_map_v1" "test" {
+  metadata {
+    name = %[1]q
+  }
+}
+
+resource "kubernetes_event_v1" "test" {
+  metadata {
+    name = %[1]q
+  }
+  regarding {
+    api_version = "v1"
+    kind        = "ConfigMap"
+    name        = kubernetes_config_map_v1.test.metadata[0].name
+    namespace   = kubernetes_config_map_v1.test.metadata[0].namespace
+    uid         = kubernetes_config_map_v1.test.metadata[0].uid
+  }
+  action = "Deploy"
+  reason = "TerraformApply"
+  note   = %[2]q
+}
+`, name, note)
+}
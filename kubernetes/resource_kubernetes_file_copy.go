@@ -0,0 +1,479 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	defaultFileCopyHelperImage = "busybox:1.36"
+	fileCopyHelperMountPath    = "/data"
+)
+
+func resourceKubernetesFileCopy() *schema.Resource {
+	return &schema.Resource{
+		Description:   "This resource copies files into a running pod, like `kubectl cp`, or into a persistent volume claim through a helper Job, e.g. to bootstrap data too large for a config map. The files are copied with `tar`, which must be available in the container. The files are copied again when they change, or when the pod is replaced. Destroying the resource leaves the files in place.",
+		CreateContext: resourceKubernetesFileCopyCreate,
+		ReadContext:   resourceKubernetesFileCopyRead,
+		UpdateContext: resourceKubernetesFileCopyUpdate,
+		DeleteContext: resourceKubernetesFileCopyDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "The namespace of the pod or persistent volume claim. Defaults to `default`.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "default",
+			},
+			"pod_name": {
+				Type:         schema.TypeString,
+				Description:  "The name of the pod to copy the files into. Exactly one of `pod_name` and `persistent_volume_claim_name` must be set.",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"pod_name", "persistent_volume_claim_name"},
+			},
+			"container": {
+				Type:        schema.TypeString,
+				Description: "The container of the pod to copy the files into. Defaults to the first container of the pod.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"persistent_volume_claim_name": {
+				Type:        schema.TypeString,
+				Description: "The name of the persistent volume claim to copy the files into. The claim is mounted by a helper Job, which is deleted once the files are copied. A `ReadWriteOnce` claim can only be mounted when it isn't in use by a pod on another node.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"helper_image": {
+				Type:        schema.TypeString,
+				Description: fmt.Sprintf("The image of the helper Job copying the files into a persistent volume claim. It must provide `sh` and `tar`. Defaults to `%s`.", defaultFileCopyHelperImage),
+				Optional:    true,
+				Default:     defaultFileCopyHelperImage,
+			},
+			"file": {
+				Type:        schema.TypeList,
+				Description: "The files to copy.",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:         schema.TypeString,
+							Description:  "The path of the file in the container, or in the volume of the persistent volume claim. Missing directories are created.",
+							Required:     true,
+							ValidateFunc: validateFileCopyPath,
+						},
+						"content": {
+							Type:        schema.TypeString,
+							Description: "The content of the file, e.g. from `templatefile()`. Only its SHA-256 hash is stored in the state. At most one of `content`, `content_base64` and `source` can be set.",
+							Optional:    true,
+							Sensitive:   true,
+							StateFunc:   fileCopyContentHash,
+						},
+						"content_base64": {
+							Type:        schema.TypeString,
+							Description: "The base64-encoded content of the file, for binary files. Only its SHA-256 hash is stored in the state.",
+							Optional:    true,
+							Sensitive:   true,
+							StateFunc:   fileCopyContentHash,
+						},
+						"source": {
+							Type:        schema.TypeString,
+							Description: "The path of a local file to copy, read when applying. Only the SHA-256 hash of its content is stored in the state, so the file is copied again when it changes. Prefer it to `content` for large files.",
+							Optional:    true,
+							StateFunc:   fileCopySourceHash,
+						},
+						"mode": {
+							Type:         schema.TypeString,
+							Description:  "The permissions of the file in octal notation. Defaults to `0644`.",
+							Optional:     true,
+							Default:      "0644",
+							ValidateFunc: validateModeBits,
+						},
+					},
+				},
+			},
+			"pod_uid": {
+				Type:        schema.TypeString,
+				Description: "The UID of the pod the files were copied into. The files are copied again when the pod is replaced.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesFileCopyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := resourceKubernetesFileCopyApply(ctx, d, meta, d.Timeout(schema.TimeoutCreate))
+	if diags.HasError() {
+		return diags
+	}
+	target := d.Get("pod_name").(string)
+	if target == "" {
+		target = "pvc/" + d.Get("persistent_volume_claim_name").(string)
+	}
+	d.SetId(d.Get("namespace").(string) + "/" + target)
+
+	return resourceKubernetesFileCopyRead(ctx, d, meta)
+}
+
+func resourceKubernetesFileCopyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace := d.Get("namespace").(string)
+	if claim := d.Get("persistent_volume_claim_name").(string); claim != "" {
+		_, err := conn.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, claim, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("Persistent volume claim %s/%s is gone, the files must be copied again", namespace, claim))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	podName := d.Get("pod_name").(string)
+	pod, err := conn.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			tflog.Info(ctx, fmt.Sprintf("Pod %s/%s is gone, the files must be copied again", namespace, podName))
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if uid := d.Get("pod_uid").(string); uid != "" && uid != string(pod.UID) {
+		tflog.Info(ctx, fmt.Sprintf("Pod %s/%s was replaced, the files must be copied again", namespace, podName))
+		d.SetId("")
+		return nil
+	}
+	d.Set("pod_uid", string(pod.UID))
+
+	return nil
+}
+
+func resourceKubernetesFileCopyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange("file") {
+		diags := resourceKubernetesFileCopyApply(ctx, d, meta, d.Timeout(schema.TimeoutUpdate))
+		if diags.HasError() {
+			return diags
+		}
+	}
+	return resourceKubernetesFileCopyRead(ctx, d, meta)
+}
+
+func resourceKubernetesFileCopyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// resourceKubernetesFileCopyApply copies the files into the pod, or into the
+// persistent volume claim through a helper Job.
+func resourceKubernetesFileCopyApply(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	config, err := meta.(KubeClientsets).RESTConfig()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	files := fileCopyConfigFiles(d)
+	archive, err := buildFileCopyArchive(files)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace := d.Get("namespace").(string)
+	podName := d.Get("pod_name").(string)
+	container := d.Get("container").(string)
+	root := "/"
+	if claim := d.Get("persistent_volume_claim_name").(string); claim != "" {
+		job, err := createFileCopyHelperJob(ctx, conn, namespace, claim, d.Get("helper_image").(string), timeout)
+		if err != nil {
+			return diag.Errorf("Failed to create the helper Job copying the files into persistent volume claim %s/%s: %s", namespace, claim, err)
+		}
+		defer func() {
+			// The files are copied, or failed to be, so the helper Job and its pod are no longer needed.
+			policy := metav1.DeletePropagationBackground
+			err := conn.BatchV1().Jobs(namespace).Delete(context.Background(), job.Name, metav1.DeleteOptions{PropagationPolicy: &policy})
+			if err != nil && !apierrors.IsNotFound(err) {
+				tflog.Warn(ctx, fmt.Sprintf("Failed to delete the helper Job %s/%s: %s", namespace, job.Name, err))
+			}
+		}()
+		podName, err = waitForFileCopyHelperPod(ctx, conn, job, timeout)
+		if err != nil {
+			return diag.Errorf("The helper Job copying the files into persistent volume claim %s/%s didn't start: %s", namespace, claim, err)
+		}
+		container = "copy"
+		root = fileCopyHelperMountPath
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Copying %d files into pod %s/%s", len(files), namespace, podName))
+	err = execInPod(ctx, config, conn, namespace, podName, container, []string{"tar", "-xmf", "-", "-C", root}, bytes.NewReader(archive))
+	if err != nil {
+		return diag.Errorf("Failed to copy the files into pod %s/%s: %s", namespace, podName, err)
+	}
+	// The StateFuncs of nested attributes are only applied to the plan, so the
+	// hashes are set explicitly to keep the content out of the state.
+	if err := d.Set("file", fileCopyStateFiles(files)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// fileCopyContentHash is the StateFunc of the content of the files: the state
+// only holds its hash, which is enough to detect changes.
+func fileCopyContentHash(v interface{}) string {
+	sum := sha256.Sum256([]byte(v.(string)))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// fileCopySourceHash is the StateFunc of the source of the files: the state
+// holds the hash of the content of the local file when planning, so that the
+// file is copied again when it changes.
+func fileCopySourceHash(v interface{}) string {
+	content, err := os.ReadFile(v.(string))
+	if err != nil {
+		// The error is reported when applying, reading the file again.
+		return "unreadable:" + v.(string)
+	}
+	return fileCopyContentHash(string(content))
+}
+
+// fileCopyConfigFiles returns the files to copy with their content taken from
+// the configuration, as the state only holds its hash.
+func fileCopyConfigFiles(d *schema.ResourceData) []interface{} {
+	files := d.Get("file").([]interface{})
+	for i, f := range files {
+		file := f.(map[string]interface{})
+		for _, k := range []string{"content", "content_base64", "source"} {
+			file[k] = ""
+			v := rawConfigAt(d.GetRawConfig(), fmt.Sprintf("file.%d.%s", i, k))
+			if v.IsKnown() && !v.IsNull() && v.Type() == cty.String {
+				file[k] = v.AsString()
+			}
+		}
+	}
+	return files
+}
+
+// fileCopyStateFiles returns the files as stored in the state, with the hashes
+// of their content.
+func fileCopyStateFiles(files []interface{}) []interface{} {
+	result := make([]interface{}, len(files))
+	for i, f := range files {
+		file := f.(map[string]interface{})
+		s := map[string]interface{}{
+			"path": file["path"],
+			"mode": file["mode"],
+		}
+		for k, hash := range map[string]schema.SchemaStateFunc{
+			"content":        fileCopyContentHash,
+			"content_base64": fileCopyContentHash,
+			"source":         fileCopySourceHash,
+		} {
+			if v := file[k].(string); v != "" {
+				s[k] = hash(v)
+			}
+		}
+		result[i] = s
+	}
+	return result
+}
+
+// buildFileCopyArchive builds a tar archive of the files, with their paths
+// relative to the root they are extracted to.
+func buildFileCopyArchive(files []interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, f := range files {
+		file := f.(map[string]interface{})
+		name := strings.TrimPrefix(path.Clean("/"+file["path"].(string)), "/")
+		content := []byte(file["content"].(string))
+		if v := file["content_base64"].(string); v != "" {
+			if len(content) > 0 {
+				return nil, fmt.Errorf("Only one of content, content_base64 and source can be set for %s", file["path"])
+			}
+			var err error
+			content, err = base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to decode the content of %s: %s", file["path"], err)
+			}
+		}
+		if v := file["source"].(string); v != "" {
+			if len(content) > 0 || file["content_base64"] != "" {
+				return nil, fmt.Errorf("Only one of content, content_base64 and source can be set for %s", file["path"])
+			}
+			var err error
+			content, err = os.ReadFile(v)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to read the source of %s: %s", file["path"], err)
+			}
+		}
+		mode, err := strconv.ParseInt(file["mode"].(string), 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid mode of %s: %s", file["path"], err)
+		}
+		err = tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     mode,
+			Size:     int64(len(content)),
+			ModTime:  time.Now(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func validateFileCopyPath(value interface{}, key string) ([]string, []error) {
+	p := value.(string)
+	if p == "" || strings.HasSuffix(p, "/") {
+		return nil, []error{fmt.Errorf("%s must be the path of a file, got %q", key, p)}
+	}
+	for _, e := range strings.Split(p, "/") {
+		if e == ".." {
+			return nil, []error{fmt.Errorf("%s must not contain '..', got %q", key, p)}
+		}
+	}
+	return nil, nil
+}
+
+// execInPod runs a command in a container of a pod with the given input, and
+// returns its error output when it fails.
+func execInPod(ctx context.Context, config *restclient.Config, conn kubernetes.Interface, namespace, pod, container string, command []string, stdin io.Reader) error {
+	if config == nil {
+		return fmt.Errorf("the provider is not configured")
+	}
+	req := conn.CoreV1().RESTClient().Post().Resource("pods").Namespace(namespace).Name(pod).SubResource("exec")
+	req.VersionedParams(&corev1.PodExecOptions{
+		Container: container,
+		Command:   command,
+		Stdin:     stdin != nil,
+		Stdout:    true,
+		Stderr:    true,
+	}, scheme.ParameterCodec)
+	exec, err := remotecommand.NewSPDYExecutor(config, http.MethodPost, req.URL())
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: io.Discard,
+		Stderr: &stderr,
+	})
+	if err != nil && stderr.Len() > 0 {
+		return fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return err
+}
+
+// createFileCopyHelperJob creates a Job with a pod mounting the claim, which
+// waits for the files to be copied into it.
+func createFileCopyHelperJob(ctx context.Context, conn kubernetes.Interface, namespace, claim, image string, timeout time.Duration) (*batchv1.Job, error) {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "tf-file-copy-",
+			Namespace:    namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "terraform",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptr.To(int32(0)),
+			ActiveDeadlineSeconds: ptr.To(int64(timeout.Seconds())),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "copy",
+						Image:   image,
+						Command: []string{"sh", "-c", "sleep 3600"},
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "data",
+							MountPath: fileCopyHelperMountPath,
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "data",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim},
+						},
+					}},
+				},
+			},
+		},
+	}
+	return conn.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
+}
+
+// waitForFileCopyHelperPod waits for the pod of the helper Job to run, and
+// returns its name.
+func waitForFileCopyHelperPod(ctx context.Context, conn kubernetes.Interface, job *batchv1.Job, timeout time.Duration) (string, error) {
+	var name string
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		pods, err := conn.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: "job-name=" + job.Name,
+		})
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		for _, pod := range pods.Items {
+			switch pod.Status.Phase {
+			case corev1.PodRunning:
+				name = pod.Name
+				return nil
+			case corev1.PodFailed, corev1.PodSucceeded:
+				return retry.NonRetryableError(fmt.Errorf("pod %s is %s: %s", pod.Name, pod.Status.Phase, pod.Status.Message))
+			}
+		}
+		return retry.RetryableError(fmt.Errorf("the pod of Job %s/%s is not running yet", job.Namespace, job.Name))
+	})
+	return name, err
+}
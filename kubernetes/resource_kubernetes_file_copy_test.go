@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestBuildFileCopyArchive(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "index.html")
+	if err := os.WriteFile(source, []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	archive, err := buildFileCopyArchive([]interface{}{
+		map[string]interface{}{
+			"path":           "/etc/app/config.yaml",
+			"content":        "debug: true\n",
+			"content_base64": "",
+			"source":         "",
+			"mode":           "0600",
+		},
+		map[string]interface{}{
+			"path":           "seed/data.bin",
+			"content":        "",
+			"content_base64": "AAEC",
+			"source":         "",
+			"mode":           "0644",
+		},
+		map[string]interface{}{
+			"path":           "/var/www/index.html",
+			"content":        "",
+			"content_base64": "",
+			"source":         source,
+			"mode":           "0644",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type entry struct {
+		name    string
+		mode    int64
+		content string
+	}
+	var got []entry
+	tr := tar.NewReader(bytes.NewReader(archive))
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, entry{h.Name, h.Mode, string(content)})
+	}
+	expected := []entry{
+		{"etc/app/config.yaml", 0600, "debug: true\n"},
+		{"seed/data.bin", 0644, "\x00\x01\x02"},
+		{"var/www/index.html", 0644, "<html></html>"},
+	}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Fatalf("Unexpected archive: expected %v, got %v", expected, got)
+	}
+
+	_, err = buildFileCopyArchive([]interface{}{map[string]interface{}{
+		"path":           "bad.bin",
+		"content":        "",
+		"content_base64": "not base64!",
+		"source":         "",
+		"mode":           "0644",
+	}})
+	if err == nil {
+		t.Fatal("Expected an error for invalid base64 content")
+	}
+
+	_, err = buildFileCopyArchive([]interface{}{map[string]interface{}{
+		"path":           "index.html",
+		"content":        "<html></html>",
+		"content_base64": "",
+		"source":         source,
+		"mode":           "0644",
+	}})
+	if err == nil {
+		t.Fatal("Expected an error for both content and source")
+	}
+}
+
+func TestFileCopyStateFiles(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "index.html")
+	if err := os.WriteFile(source, []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	d := schema.TestResourceDataRaw(t, resourceKubernetesFileCopy().Schema, map[string]interface{}{
+		"pod_name": "web",
+		"file": []interface{}{
+			map[string]interface{}{"path": "/etc/secret", "content": "s3cr3t"},
+			map[string]interface{}{"path": "/var/www/index.html", "source": source},
+		},
+	})
+	err := d.Set("file", fileCopyStateFiles([]interface{}{
+		map[string]interface{}{"path": "/etc/secret", "content": "s3cr3t", "content_base64": "", "source": "", "mode": "0644"},
+		map[string]interface{}{"path": "/var/www/index.html", "content": "", "content_base64": "", "source": source, "mode": "0644"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetId("default/web")
+
+	state := d.State()
+	expected := map[string]string{
+		"file.0.content":        fileCopyContentHash("s3cr3t"),
+		"file.0.content_base64": "",
+		"file.1.source":         fileCopyContentHash("<html></html>"),
+		"file.1.mode":           "0644",
+	}
+	for k, v := range expected {
+		if got := state.Attributes[k]; got != v {
+			t.Errorf("Expected %s to be %q, got %q", k, v, got)
+		}
+	}
+}
+
+func TestValidateFileCopyPath(t *testing.T) {
+	for p, valid := range map[string]bool{
+		"/etc/app/config.yaml": true,
+		"seed/data.bin":        true,
+		"":                     false,
+		"/etc/app/":            false,
+		"../etc/passwd":        false,
+		"/data/../etc/passwd":  false,
+	} {
+		_, errs := validateFileCopyPath(p, "path")
+		if valid != (len(errs) == 0) {
+			t.Errorf("Expected path %q to be valid: %t, got %v", p, valid, errs)
+		}
+	}
+}
+
+func TestAccKubernetesFileCopy_pod(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "kubernetes_file_copy.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesFileCopyConfig_pod(name, "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "pod_uid", "kubernetes_pod_v1.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr(resourceName, "file.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "file.0.content", fileCopyContentHash("first")),
+				),
+			},
+			{
+				Config: testAccKubernetesFileCopyConfig_pod(name, "second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "pod_uid", "kubernetes_pod_v1.test", "metadata.0.uid"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesFileCopyConfig_pod(name, content string) string {
+	return fmt.Sprintf(`resource "kubernetes_pod_v1" "test" {
+  metadata {
+    name = %[1]q
+  }
+  spec {
+    container {
+      name    = "app"
+      image   = %[2]q
+      command = ["sleep", "3600"]
+    }
+  }
+}
+
+resource "kubernetes_file_copy" "test" {
+  pod_name = kubernetes_pod_v1.test.metadata[0].name
+  file {
+    path    = "/tmp/seed/data.txt"
+    content = %[3]q
+    mode    = "0600"
+  }
+}
+`, name, busyboxImage, content)
+}
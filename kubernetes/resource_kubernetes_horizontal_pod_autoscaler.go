@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -31,7 +32,7 @@ func resourceKubernetesHorizontalPodAutoscaler() *schema.Resource {
 }
 
 func resourceKubernetesHorizontalPodAutoscalerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	if useV2Beta2(d) {
+	if useV2Beta2(ctx, d) {
 		return resourceKubernetesHorizontalPodAutoscalerV2Beta2Create(ctx, d, meta)
 	}
 
@@ -50,13 +51,13 @@ func resourceKubernetesHorizontalPodAutoscalerCreate(ctx context.Context, d *sch
 		ObjectMeta: metadata,
 		Spec:       *spec,
 	}
-	log.Printf("[INFO] Creating new horizontal pod autoscaler: %#v", hpa)
+	tflog.Info(ctx, fmt.Sprintf("Creating new horizontal pod autoscaler: %#v", hpa))
 	out, err := conn.AutoscalingV1().HorizontalPodAutoscalers(metadata.Namespace).Create(ctx, &hpa, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitted new horizontal pod autoscaler: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new horizontal pod autoscaler: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesHorizontalPodAutoscalerRead(ctx, d, meta)
@@ -71,7 +72,7 @@ func resourceKubernetesHorizontalPodAutoscalerRead(ctx context.Context, d *schem
 		d.SetId("")
 		return diag.Diagnostics{}
 	}
-	if useV2Beta2(d) {
+	if useV2Beta2(ctx, d) {
 		return resourceKubernetesHorizontalPodAutoscalerV2Beta2Read(ctx, d, meta)
 	}
 
@@ -84,10 +85,10 @@ func resourceKubernetesHorizontalPodAutoscalerRead(ctx context.Context, d *schem
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Reading horizontal pod autoscaler %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading horizontal pod autoscaler %s", name))
 	hpa, err := conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
 
@@ -96,14 +97,14 @@ func resourceKubernetesHorizontalPodAutoscalerRead(ctx context.Context, d *schem
 		return resourceKubernetesHorizontalPodAutoscalerV2Beta2Read(ctx, d, meta)
 	}
 
-	log.Printf("[INFO] Received horizontal pod autoscaler: %#v", hpa)
+	tflog.Info(ctx, fmt.Sprintf("Received horizontal pod autoscaler: %#v", hpa))
 	err = d.Set("metadata", flattenMetadata(hpa.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattened := flattenHorizontalPodAutoscalerSpec(hpa.Spec)
-	log.Printf("[DEBUG] Flattened horizontal pod autoscaler spec: %#v", flattened)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened horizontal pod autoscaler spec: %#v", flattened))
 	err = d.Set("spec", flattened)
 	if err != nil {
 		return diag.FromErr(err)
@@ -113,7 +114,7 @@ func resourceKubernetesHorizontalPodAutoscalerRead(ctx context.Context, d *schem
 }
 
 func resourceKubernetesHorizontalPodAutoscalerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	if useV2Beta2(d) {
+	if useV2Beta2(ctx, d) {
 		return resourceKubernetesHorizontalPodAutoscalerV2Beta2Update(ctx, d, meta)
 	}
 
@@ -136,19 +137,19 @@ func resourceKubernetesHorizontalPodAutoscalerUpdate(ctx context.Context, d *sch
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating horizontal pod autoscaler %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating horizontal pod autoscaler %q: %v", name, string(data)))
 	out, err := conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update horizontal pod autoscaler: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated horizontal pod autoscaler: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated horizontal pod autoscaler: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesHorizontalPodAutoscalerRead(ctx, d, meta)
 }
 
 func resourceKubernetesHorizontalPodAutoscalerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	if useV2Beta2(d) {
+	if useV2Beta2(ctx, d) {
 		return resourceKubernetesHorizontalPodAutoscalerV2Beta2Delete(ctx, d, meta)
 	}
 
@@ -161,7 +162,7 @@ func resourceKubernetesHorizontalPodAutoscalerDelete(ctx context.Context, d *sch
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Deleting horizontal pod autoscaler: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting horizontal pod autoscaler: %#v", name))
 	err = conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -170,14 +171,14 @@ func resourceKubernetesHorizontalPodAutoscalerDelete(ctx context.Context, d *sch
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Horizontal Pod Autoscaler %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Horizontal Pod Autoscaler %s deleted", name))
 
 	d.SetId("")
 	return nil
 }
 
 func resourceKubernetesHorizontalPodAutoscalerExists(ctx context.Context, d *schema.ResourceData, meta interface{}) (bool, error) {
-	if useV2Beta2(d) {
+	if useV2Beta2(ctx, d) {
 		return resourceKubernetesHorizontalPodAutoscalerV2Beta2Exists(ctx, d, meta)
 	}
 
@@ -191,25 +192,25 @@ func resourceKubernetesHorizontalPodAutoscalerExists(ctx context.Context, d *sch
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking horizontal pod autoscaler %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking horizontal pod autoscaler %s", name))
 	_, err = conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
 
-func useV2Beta2(d *schema.ResourceData) bool {
+func useV2Beta2(ctx context.Context, d *schema.ResourceData) bool {
 	if len(d.Get("spec.0.metric").([]interface{})) > 0 {
-		log.Printf("[INFO] Using autoscaling/v2beta2 because this resource has a metric field")
+		tflog.Info(ctx, "Using autoscaling/v2beta2 because this resource has a metric field")
 		return true
 	}
 
 	if len(d.Get("spec.0.behavior").([]interface{})) > 0 {
-		log.Printf("[INFO] Using autoscaling/v2beta2 because this resource has a behavior field")
+		tflog.Info(ctx, "Using autoscaling/v2beta2 because this resource has a behavior field")
 		return true
 	}
 
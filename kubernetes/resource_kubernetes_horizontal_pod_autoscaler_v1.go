@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -100,13 +101,13 @@ func resourceKubernetesHorizontalPodAutoscalerV1Create(ctx context.Context, d *s
 		ObjectMeta: metadata,
 		Spec:       *spec,
 	}
-	log.Printf("[INFO] Creating new horizontal pod autoscaler: %#v", hpa)
+	tflog.Info(ctx, fmt.Sprintf("Creating new horizontal pod autoscaler: %#v", hpa))
 	out, err := conn.AutoscalingV1().HorizontalPodAutoscalers(metadata.Namespace).Create(ctx, &hpa, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitted new horizontal pod autoscaler: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new horizontal pod autoscaler: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesHorizontalPodAutoscalerV1Read(ctx, d, meta)
@@ -131,21 +132,21 @@ func resourceKubernetesHorizontalPodAutoscalerV1Read(ctx context.Context, d *sch
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Reading horizontal pod autoscaler %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading horizontal pod autoscaler %s", name))
 	hpa, err := conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Received horizontal pod autoscaler: %#v", hpa)
+	tflog.Info(ctx, fmt.Sprintf("Received horizontal pod autoscaler: %#v", hpa))
 	err = d.Set("metadata", flattenMetadata(hpa.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattened := flattenHorizontalPodAutoscalerSpec(hpa.Spec)
-	log.Printf("[DEBUG] Flattened horizontal pod autoscaler spec: %#v", flattened)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened horizontal pod autoscaler spec: %#v", flattened))
 	err = d.Set("spec", flattened)
 	if err != nil {
 		return diag.FromErr(err)
@@ -174,12 +175,12 @@ func resourceKubernetesHorizontalPodAutoscalerV1Update(ctx context.Context, d *s
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating horizontal pod autoscaler %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating horizontal pod autoscaler %q: %v", name, string(data)))
 	out, err := conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update horizontal pod autoscaler: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated horizontal pod autoscaler: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated horizontal pod autoscaler: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesHorizontalPodAutoscalerV1Read(ctx, d, meta)
@@ -195,7 +196,7 @@ func resourceKubernetesHorizontalPodAutoscalerV1Delete(ctx context.Context, d *s
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Deleting horizontal pod autoscaler: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting horizontal pod autoscaler: %#v", name))
 	err = conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -204,7 +205,7 @@ func resourceKubernetesHorizontalPodAutoscalerV1Delete(ctx context.Context, d *s
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Horizontal Pod Autoscaler %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Horizontal Pod Autoscaler %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -221,13 +222,13 @@ func resourceKubernetesHorizontalPodAutoscalerV1Exists(ctx context.Context, d *s
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking horizontal pod autoscaler %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking horizontal pod autoscaler %s", name))
 	_, err = conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
@@ -5,19 +5,35 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"k8s.io/apimachinery/pkg/api/errors"
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesHorizontalPodAutoscalerV2() *schema.Resource {
+	fields := horizontalPodAutoscalerSchemaV2()
+	fields["status"] = horizontalPodAutoscalerV2StatusSchema()
+	fields["wait_for_able_to_scale"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Terraform will wait for the autoscaler to report the `AbleToScale` condition, i.e. to be able to fetch and update the scale of its target, before considering the resource created or updated. Defaults to false.",
+		Optional:    true,
+		Default:     false,
+	}
+
 	return &schema.Resource{
 		Description:   "Horizontal Pod Autoscaler automatically scales the number of pods in a replication controller, deployment or replica set based on observed CPU utilization.",
 		CreateContext: resourceKubernetesHorizontalPodAutoscalerV2Create,
@@ -27,7 +43,11 @@ func resourceKubernetesHorizontalPodAutoscalerV2() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: horizontalPodAutoscalerSchemaV2(),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: fields,
 	}
 }
 
@@ -47,16 +67,26 @@ func resourceKubernetesHorizontalPodAutoscalerV2Create(ctx context.Context, d *s
 		ObjectMeta: metadata,
 		Spec:       *spec,
 	}
-	log.Printf("[INFO] Creating new horizontal pod autoscaler: %#v", hpa)
+	tflog.Info(ctx, fmt.Sprintf("Creating new horizontal pod autoscaler: %#v", hpa))
 	out, err := conn.AutoscalingV2().HorizontalPodAutoscalers(metadata.Namespace).Create(ctx, &hpa, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitted new horizontal pod autoscaler: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new horizontal pod autoscaler: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
-	return resourceKubernetesHorizontalPodAutoscalerV2Read(ctx, d, meta)
+	var diags diag.Diagnostics
+	if d.Get("wait_for_able_to_scale").(bool) {
+		events := startWaitEventReporter(ctx, conn, out.ObjectMeta, "HorizontalPodAutoscaler", nil)
+		err = waitForHorizontalPodAutoscalerV2AbleToScale(ctx, conn, out.ObjectMeta, d.Timeout(schema.TimeoutCreate))
+		diags = events.Diagnostics(ctx, err)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceKubernetesHorizontalPodAutoscalerV2Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesHorizontalPodAutoscalerV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -77,25 +107,30 @@ func resourceKubernetesHorizontalPodAutoscalerV2Read(ctx context.Context, d *sch
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Reading horizontal pod autoscaler %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading horizontal pod autoscaler %s", name))
 	hpa, err := conn.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received horizontal pod autoscaler: %#v", hpa)
+	tflog.Info(ctx, fmt.Sprintf("Received horizontal pod autoscaler: %#v", hpa))
 	err = d.Set("metadata", flattenMetadata(hpa.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattened := flattenHorizontalPodAutoscalerV2Spec(hpa.Spec)
-	log.Printf("[DEBUG] Flattened horizontal pod autoscaler spec: %#v", flattened)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened horizontal pod autoscaler spec: %#v", flattened))
 	err = d.Set("spec", flattened)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	err = d.Set("status", flattenHorizontalPodAutoscalerV2Status(hpa.Status))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
@@ -119,15 +154,25 @@ func resourceKubernetesHorizontalPodAutoscalerV2Update(ctx context.Context, d *s
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating horizontal pod autoscaler %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating horizontal pod autoscaler %q: %v", name, string(data)))
 	out, err := conn.AutoscalingV2().HorizontalPodAutoscalers(namespace).Patch(ctx, name, types.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update horizontal pod autoscaler: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated horizontal pod autoscaler: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated horizontal pod autoscaler: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
-	return resourceKubernetesHorizontalPodAutoscalerV2Read(ctx, d, meta)
+	var diags diag.Diagnostics
+	if d.Get("wait_for_able_to_scale").(bool) {
+		events := startWaitEventReporter(ctx, conn, out.ObjectMeta, "HorizontalPodAutoscaler", nil)
+		err = waitForHorizontalPodAutoscalerV2AbleToScale(ctx, conn, out.ObjectMeta, d.Timeout(schema.TimeoutUpdate))
+		diags = events.Diagnostics(ctx, err)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceKubernetesHorizontalPodAutoscalerV2Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesHorizontalPodAutoscalerV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -140,7 +185,7 @@ func resourceKubernetesHorizontalPodAutoscalerV2Delete(ctx context.Context, d *s
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Deleting horizontal pod autoscaler: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting horizontal pod autoscaler: %#v", name))
 	err = conn.AutoscalingV2().HorizontalPodAutoscalers(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -149,7 +194,7 @@ func resourceKubernetesHorizontalPodAutoscalerV2Delete(ctx context.Context, d *s
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Horizontal Pod Autoscaler %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Horizontal Pod Autoscaler %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -166,13 +211,46 @@ func resourceKubernetesHorizontalPodAutoscalerV2Exists(ctx context.Context, d *s
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking horizontal pod autoscaler %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking horizontal pod autoscaler %s", name))
 	_, err = conn.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
+
+// waitForHorizontalPodAutoscalerV2AbleToScale watches the autoscaler until it
+// reports the AbleToScale condition. On timeout, the error includes the last
+// conditions which aren't met, e.g. a missing target or unavailable metrics.
+func waitForHorizontalPodAutoscalerV2AbleToScale(ctx context.Context, conn *kubernetes.Clientset, hpa metav1.ObjectMeta, timeout time.Duration) error {
+	id := buildId(hpa)
+	tflog.Debug(ctx, fmt.Sprintf("Waiting for horizontal pod autoscaler %q to be able to scale", id))
+
+	lw := singleObjectListWatch[*autoscalingv2.HorizontalPodAutoscalerList](ctx, conn.AutoscalingV2().HorizontalPodAutoscalers(hpa.Namespace), hpa.Name)
+	return watchUntil(ctx, timeout, fmt.Sprintf("horizontal pod autoscaler %q to be able to scale", id), lw, &autoscalingv2.HorizontalPodAutoscaler{}, func(event watch.Event) *retry.RetryError {
+		out, ok := event.Object.(*autoscalingv2.HorizontalPodAutoscaler)
+		if event.Type == watch.Deleted || !ok {
+			return retry.NonRetryableError(fmt.Errorf("Horizontal pod autoscaler %q was deleted while waiting for it to be able to scale", id))
+		}
+		return horizontalPodAutoscalerV2AbleToScale(out)
+	})
+}
+
+func horizontalPodAutoscalerV2AbleToScale(hpa *autoscalingv2.HorizontalPodAutoscaler) *retry.RetryError {
+	var unmet []string
+	for _, c := range hpa.Status.Conditions {
+		if c.Type == autoscalingv2.AbleToScale && c.Status == corev1.ConditionTrue {
+			return nil
+		}
+		if c.Status != corev1.ConditionTrue {
+			unmet = append(unmet, fmt.Sprintf("%s is %s (%s): %s", c.Type, c.Status, c.Reason, c.Message))
+		}
+	}
+	if len(unmet) == 0 {
+		return retry.RetryableError(fmt.Errorf("Waiting for horizontal pod autoscaler %q to report its conditions", buildId(hpa.ObjectMeta)))
+	}
+	return retry.RetryableError(fmt.Errorf("Waiting for horizontal pod autoscaler %q to be able to scale: %s", buildId(hpa.ObjectMeta), strings.Join(unmet, "; ")))
+}
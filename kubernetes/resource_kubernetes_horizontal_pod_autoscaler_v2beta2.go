@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -47,13 +48,13 @@ func resourceKubernetesHorizontalPodAutoscalerV2Beta2Create(ctx context.Context,
 		ObjectMeta: metadata,
 		Spec:       *spec,
 	}
-	log.Printf("[INFO] Creating new horizontal pod autoscaler: %#v", hpa)
+	tflog.Info(ctx, fmt.Sprintf("Creating new horizontal pod autoscaler: %#v", hpa))
 	out, err := conn.AutoscalingV2beta2().HorizontalPodAutoscalers(metadata.Namespace).Create(ctx, &hpa, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitted new horizontal pod autoscaler: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new horizontal pod autoscaler: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesHorizontalPodAutoscalerV2Beta2Read(ctx, d, meta)
@@ -77,20 +78,20 @@ func resourceKubernetesHorizontalPodAutoscalerV2Beta2Read(ctx context.Context, d
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Reading horizontal pod autoscaler %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading horizontal pod autoscaler %s", name))
 	hpa, err := conn.AutoscalingV2beta2().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received horizontal pod autoscaler: %#v", hpa)
+	tflog.Info(ctx, fmt.Sprintf("Received horizontal pod autoscaler: %#v", hpa))
 	err = d.Set("metadata", flattenMetadata(hpa.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattened := flattenHorizontalPodAutoscalerV2Beta2Spec(hpa.Spec)
-	log.Printf("[DEBUG] Flattened horizontal pod autoscaler spec: %#v", flattened)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened horizontal pod autoscaler spec: %#v", flattened))
 	err = d.Set("spec", flattened)
 	if err != nil {
 		return diag.FromErr(err)
@@ -119,12 +120,12 @@ func resourceKubernetesHorizontalPodAutoscalerV2Beta2Update(ctx context.Context,
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating horizontal pod autoscaler %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating horizontal pod autoscaler %q: %v", name, string(data)))
 	out, err := conn.AutoscalingV2beta2().HorizontalPodAutoscalers(namespace).Patch(ctx, name, types.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update horizontal pod autoscaler: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated horizontal pod autoscaler: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated horizontal pod autoscaler: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesHorizontalPodAutoscalerV2Beta2Read(ctx, d, meta)
@@ -140,7 +141,7 @@ func resourceKubernetesHorizontalPodAutoscalerV2Beta2Delete(ctx context.Context,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Deleting horizontal pod autoscaler: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting horizontal pod autoscaler: %#v", name))
 	err = conn.AutoscalingV2beta2().HorizontalPodAutoscalers(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -149,7 +150,7 @@ func resourceKubernetesHorizontalPodAutoscalerV2Beta2Delete(ctx context.Context,
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Horizontal Pod Autoscaler %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Horizontal Pod Autoscaler %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -166,13 +167,13 @@ func resourceKubernetesHorizontalPodAutoscalerV2Beta2Exists(ctx context.Context,
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking horizontal pod autoscaler %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking horizontal pod autoscaler %s", name))
 	_, err = conn.AutoscalingV2beta2().HorizontalPodAutoscalers(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
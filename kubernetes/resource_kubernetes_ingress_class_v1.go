@@ -6,8 +6,8 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -104,12 +104,12 @@ func resourceKubernetesIngressClassV1Create(ctx context.Context, d *schema.Resou
 		Spec: expandIngressClassV1Spec(d.Get("spec").([]interface{})),
 	}
 	ing.ObjectMeta = metadata
-	log.Printf("[INFO] Creating new Ingress Class: %#v", ing)
+	tflog.Info(ctx, fmt.Sprintf("Creating new Ingress Class: %#v", ing))
 	out, err := conn.NetworkingV1().IngressClasses().Create(ctx, ing, metav1.CreateOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to create Ingress Class '%s' because: %s", buildId(ing.ObjectMeta), err)
 	}
-	log.Printf("[INFO] Submitted new IngressClass: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new IngressClass: %#v", out))
 	d.SetId(out.ObjectMeta.GetName())
 
 	return diag.Diagnostics{}
@@ -131,20 +131,20 @@ func resourceKubernetesIngressClassV1Read(ctx context.Context, d *schema.Resourc
 
 	name := d.Id()
 
-	log.Printf("[INFO] Reading Ingress Class %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading Ingress Class %s", name))
 	ing, err := conn.NetworkingV1().IngressClasses().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.Errorf("Failed to read Ingress Class '%s' because: %s", buildId(ing.ObjectMeta), err)
 	}
-	log.Printf("[INFO] Received Ingress Class: %#v", ing)
+	tflog.Info(ctx, fmt.Sprintf("Received Ingress Class: %#v", ing))
 	err = d.Set("metadata", flattenMetadata(ing.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattened := flattenIngressClassV1Spec(ing.Spec)
-	log.Printf("[DEBUG] Flattened Ingress Class spec: %#v", flattened)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened Ingress Class spec: %#v", flattened))
 	err = d.Set("spec", flattened)
 	if err != nil {
 		return diag.FromErr(err)
@@ -175,7 +175,7 @@ func resourceKubernetesIngressClassV1Update(ctx context.Context, d *schema.Resou
 	if err != nil {
 		return diag.Errorf("Failed to update Ingress Class %s because: %s", buildId(ingressClass.ObjectMeta), err)
 	}
-	log.Printf("[INFO] Submitted updated Ingress Class: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated Ingress Class: %#v", out))
 
 	return resourceKubernetesIngressClassV1Read(ctx, d, meta)
 }
@@ -188,7 +188,7 @@ func resourceKubernetesIngressClassV1Delete(ctx context.Context, d *schema.Resou
 
 	name := d.Id()
 
-	log.Printf("[INFO] Deleting Ingress Class: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting Ingress Class: %#v", name))
 	err = conn.NetworkingV1().IngressClasses().Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to delete Ingress Class %s because: %s", d.Id(), err)
@@ -210,7 +210,7 @@ func resourceKubernetesIngressClassV1Delete(ctx context.Context, d *schema.Resou
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Ingress Class %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Ingress Class %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -224,13 +224,13 @@ func resourceKubernetesIngressClassV1Exists(ctx context.Context, d *schema.Resou
 
 	name := d.Id()
 
-	log.Printf("[INFO] Checking Ingress Class %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking Ingress Class %s", name))
 	_, err = conn.NetworkingV1().IngressClasses().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
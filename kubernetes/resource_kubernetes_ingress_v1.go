@@ -6,9 +6,9 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	networking "k8s.io/api/networking/v1"
 
@@ -185,21 +185,21 @@ func resourceKubernetesIngressV1Create(ctx context.Context, d *schema.ResourceDa
 		Spec: expandIngressV1Spec(d.Get("spec").([]interface{})),
 	}
 	ing.ObjectMeta = metadata
-	log.Printf("[INFO] Creating new ingress: %#v", ing)
+	tflog.Info(ctx, fmt.Sprintf("Creating new ingress: %#v", ing))
 	out, err := conn.NetworkingV1().Ingresses(metadata.Namespace).Create(ctx, ing, metav1.CreateOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to create Ingress '%s' because: %s", buildId(ing.ObjectMeta), err)
 	}
-	log.Printf("[INFO] Submitted new ingress: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new ingress: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	if !d.Get("wait_for_load_balancer").(bool) {
 		return resourceKubernetesIngressV1Read(ctx, d, meta)
 	}
 
-	log.Printf("[INFO] Waiting for load balancer to become ready: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Waiting for load balancer to become ready: %#v", out))
 	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
-		res, err := conn.NetworkingV1().Ingresses(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
+		res, err := conn.NetworkingV1().Ingresses(out.Namespace).Get(ctx, out.Name, metav1.GetOptions{})
 		if err != nil {
 			// NOTE it is possible in some HA apiserver setups that are eventually consistent
 			// that we could get a 404 when doing a Get immediately after a Create
@@ -218,7 +218,7 @@ func resourceKubernetesIngressV1Create(ctx context.Context, d *schema.ResourceDa
 			return nil
 		}
 
-		log.Printf("[INFO] Load Balancer not ready yet...")
+		tflog.Info(ctx, "Load Balancer not ready yet...")
 		return retry.RetryableError(fmt.Errorf("Load Balancer is not ready yet"))
 	})
 	if err != nil {
@@ -246,20 +246,20 @@ func resourceKubernetesIngressV1Read(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading ingress %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading ingress %s", name))
 	ing, err := conn.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.Errorf("Failed to read Ingress '%s' because: %s", buildId(ing.ObjectMeta), err)
 	}
-	log.Printf("[INFO] Received ingress: %#v", ing)
+	tflog.Info(ctx, fmt.Sprintf("Received ingress: %#v", ing))
 	err = d.Set("metadata", flattenMetadata(ing.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattened := flattenIngressV1Spec(ing.Spec)
-	log.Printf("[DEBUG] Flattened ingress spec: %#v", flattened)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened ingress spec: %#v", flattened))
 	err = d.Set("spec", flattened)
 	if err != nil {
 		return diag.FromErr(err)
@@ -304,7 +304,7 @@ func resourceKubernetesIngressV1Update(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return diag.Errorf("Failed to update Ingress %s because: %s", buildId(ingress.ObjectMeta), err)
 	}
-	log.Printf("[INFO] Submitted updated ingress: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated ingress: %#v", out))
 
 	return resourceKubernetesIngressV1Read(ctx, d, meta)
 }
@@ -320,7 +320,7 @@ func resourceKubernetesIngressV1Delete(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting ingress: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting ingress: %#v", name))
 	err = conn.NetworkingV1().Ingresses(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to delete Ingress %s because: %s", d.Id(), err)
@@ -342,7 +342,7 @@ func resourceKubernetesIngressV1Delete(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Ingress %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Ingress %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -359,13 +359,13 @@ func resourceKubernetesIngressV1Exists(ctx context.Context, d *schema.ResourceDa
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking ingress %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking ingress %s", name))
 	_, err = conn.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
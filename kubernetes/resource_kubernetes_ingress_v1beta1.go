@@ -6,8 +6,8 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	networking "k8s.io/api/networking/v1beta1"
 
@@ -176,21 +176,21 @@ func resourceKubernetesIngressV1Beta1Create(ctx context.Context, d *schema.Resou
 		Spec: expandIngressSpec(d.Get("spec").([]interface{})),
 	}
 	ing.ObjectMeta = metadata
-	log.Printf("[INFO] Creating new ingress: %#v", ing)
+	tflog.Info(ctx, fmt.Sprintf("Creating new ingress: %#v", ing))
 	out, err := conn.ExtensionsV1beta1().Ingresses(metadata.Namespace).Create(ctx, ing, metav1.CreateOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to create Ingress '%s' because: %s", buildId(ing.ObjectMeta), err)
 	}
-	log.Printf("[INFO] Submitted new ingress: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new ingress: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	if !d.Get("wait_for_load_balancer").(bool) {
 		return resourceKubernetesIngressV1Beta1Read(ctx, d, meta)
 	}
 
-	log.Printf("[INFO] Waiting for load balancer to become ready: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Waiting for load balancer to become ready: %#v", out))
 	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
-		res, err := conn.ExtensionsV1beta1().Ingresses(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
+		res, err := conn.ExtensionsV1beta1().Ingresses(out.Namespace).Get(ctx, out.Name, metav1.GetOptions{})
 		if err != nil {
 			// NOTE it is possible in some HA apiserver setups that are eventually consistent
 			// that we could get a 404 when doing a Get immediately after a Create
@@ -209,7 +209,7 @@ func resourceKubernetesIngressV1Beta1Create(ctx context.Context, d *schema.Resou
 			return nil
 		}
 
-		log.Printf("[INFO] Load Balancer not ready yet...")
+		tflog.Info(ctx, "Load Balancer not ready yet...")
 		return retry.RetryableError(fmt.Errorf("Load Balancer is not ready yet"))
 	})
 	if err != nil {
@@ -237,20 +237,20 @@ func resourceKubernetesIngressV1Beta1Read(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading ingress %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading ingress %s", name))
 	ing, err := conn.ExtensionsV1beta1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.Errorf("Failed to read Ingress '%s' because: %s", buildId(ing.ObjectMeta), err)
 	}
-	log.Printf("[INFO] Received ingress: %#v", ing)
+	tflog.Info(ctx, fmt.Sprintf("Received ingress: %#v", ing))
 	err = d.Set("metadata", flattenMetadata(ing.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattened := flattenIngressSpec(ing.Spec)
-	log.Printf("[DEBUG] Flattened ingress spec: %#v", flattened)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened ingress spec: %#v", flattened))
 	err = d.Set("spec", flattened)
 	if err != nil {
 		return diag.FromErr(err)
@@ -295,7 +295,7 @@ func resourceKubernetesIngressV1Beta1Update(ctx context.Context, d *schema.Resou
 	if err != nil {
 		return diag.Errorf("Failed to update Ingress %s because: %s", buildId(ingress.ObjectMeta), err)
 	}
-	log.Printf("[INFO] Submitted updated ingress: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated ingress: %#v", out))
 
 	return resourceKubernetesIngressV1Beta1Read(ctx, d, meta)
 }
@@ -311,7 +311,7 @@ func resourceKubernetesIngressV1Beta1Delete(ctx context.Context, d *schema.Resou
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting ingress: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting ingress: %#v", name))
 	err = conn.ExtensionsV1beta1().Ingresses(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to delete Ingress %s because: %s", d.Id(), err)
@@ -333,7 +333,7 @@ func resourceKubernetesIngressV1Beta1Delete(ctx context.Context, d *schema.Resou
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Ingress %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Ingress %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -350,13 +350,13 @@ func resourceKubernetesIngressV1Beta1Exists(ctx context.Context, d *schema.Resou
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking ingress %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking ingress %s", name))
 	_, err = conn.ExtensionsV1beta1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
@@ -6,18 +6,21 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
+	"sort"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -28,6 +31,9 @@ func resourceKubernetesJobV1() *schema.Resource {
 		ReadContext:   resourceKubernetesJobV1Read,
 		UpdateContext: resourceKubernetesJobV1Update,
 		DeleteContext: resourceKubernetesJobV1Delete,
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			return validateJobV1Spec(rawConfigAt(diff.GetRawConfig(), "spec.0"))
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -62,9 +68,148 @@ func resourceKubernetesJobV1Schema() map[string]*schema.Schema {
 			},
 		},
 		"wait_for_completion": {
-			Type:     schema.TypeBool,
-			Optional: true,
-			Default:  true,
+			Type:        schema.TypeBool,
+			Description: "Wait for the job to complete, and fail when it fails. The job isn't waited for while `spec.suspend` is true.",
+			Optional:    true,
+			Default:     true,
+		},
+		"wait_for_condition": {
+			Type:        schema.TypeList,
+			Description: "Wait for the job to report the given condition instead of waiting for it to complete, e.g. `SuccessCriteriaMet` to continue as soon as its success policy is met, when `wait_for_completion` is true. The wait still fails when the job fails, or completes without reporting the condition.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:         schema.TypeString,
+						Description:  "The type of the condition, e.g. `SuccessCriteriaMet` or `FailureTarget`.",
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					"status": {
+						Type:         schema.TypeString,
+						Description:  "The expected status of the condition, one of `True`, `False` or `Unknown`.",
+						Optional:     true,
+						Default:      string(corev1.ConditionTrue),
+						ValidateFunc: validation.StringInSlice([]string{string(corev1.ConditionTrue), string(corev1.ConditionFalse), string(corev1.ConditionUnknown)}, false),
+					},
+					"timeout": {
+						Type:         schema.TypeString,
+						Description:  "How long to wait for the condition, e.g. `5m`. Defaults to the create or update timeout of the resource.",
+						Optional:     true,
+						ValidateFunc: validateWaitDuration,
+					},
+				},
+			},
+		},
+		"failed_pod_log_lines": {
+			Type:         schema.TypeInt,
+			Description:  "The number of lines of the logs of the failed containers of the job reported when it fails while waiting for its completion. The logs of the 3 most recent failed pods are reported. Set it to 0 to not report them.",
+			Optional:     true,
+			Default:      20,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"triggers": {
+			Type:        schema.TypeMap,
+			Description: "Arbitrary values which recreate the job when they change, e.g. the tag of an image or the hash of a configuration, to run a migration again.",
+			Optional:    true,
+			ForceNew:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"skip_destroy": {
+			Type:        schema.TypeBool,
+			Description: "Keep the job in the cluster when the resource is destroyed, only removing it from the state, e.g. to retain the history of the job for audits. A job which is replaced is kept as well, so the replacement needs another name.",
+			Optional:    true,
+			Default:     false,
+		},
+		"pod_names": {
+			Type:        schema.TypeList,
+			Description: "The names of the pods of the job which still exist, matched by its selector, e.g. to collect their logs. Pods which were deleted, e.g. by the garbage collector of terminated pods, aren't listed.",
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"status": {
+			Type:        schema.TypeList,
+			Description: "The most recently observed status of the job.",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"start_time": {
+						Type:        schema.TypeString,
+						Description: "The time the job controller started processing the job, in RFC 3339 format. Empty while the job is suspended or not started yet.",
+						Computed:    true,
+					},
+					"completion_time": {
+						Type:        schema.TypeString,
+						Description: "The time the job completed successfully, in RFC 3339 format. Empty unless the job is complete.",
+						Computed:    true,
+					},
+					"active": {
+						Type:        schema.TypeInt,
+						Description: "The number of pending and running pods which aren't terminating.",
+						Computed:    true,
+					},
+					"ready": {
+						Type:        schema.TypeInt,
+						Description: "The number of active pods which are ready.",
+						Computed:    true,
+					},
+					"succeeded": {
+						Type:        schema.TypeInt,
+						Description: "The number of pods which succeeded.",
+						Computed:    true,
+					},
+					"failed": {
+						Type:        schema.TypeInt,
+						Description: "The number of pods which failed.",
+						Computed:    true,
+					},
+					"completed_indexes": {
+						Type:        schema.TypeString,
+						Description: "The indexes which succeeded, as a comma-separated list of indexes and ranges of indexes, e.g. `1,3-5,7`. Only set when `completion_mode` is `Indexed`.",
+						Computed:    true,
+					},
+					"failed_indexes": {
+						Type:        schema.TypeString,
+						Description: "The indexes which failed, in the same format as `completed_indexes`. Only set when `backoff_limit_per_index` is set.",
+						Computed:    true,
+					},
+					"condition": {
+						Type:        schema.TypeList,
+						Description: "The conditions of the job, e.g. `Complete` or `Failed` once it finished.",
+						Computed:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"type": {
+									Type:        schema.TypeString,
+									Description: "The type of the condition.",
+									Computed:    true,
+								},
+								"status": {
+									Type:        schema.TypeString,
+									Description: "The status of the condition, one of `True`, `False` or `Unknown`.",
+									Computed:    true,
+								},
+								"reason": {
+									Type:        schema.TypeString,
+									Description: "The reason for the last transition of the condition, e.g. `BackoffLimitExceeded`.",
+									Computed:    true,
+								},
+								"message": {
+									Type:        schema.TypeString,
+									Description: "A human-readable explanation containing details about the transition.",
+									Computed:    true,
+								},
+								"last_transition_time": {
+									Type:        schema.TypeString,
+									Description: "The last time the condition transitioned from one status to another, in RFC 3339 format.",
+									Computed:    true,
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -80,36 +225,42 @@ func resourceKubernetesJobV1Create(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	unsetJobV1IndexLimits(&spec, rawConfigAt(d.GetRawConfig(), "spec.0"))
 
 	job := batchv1.Job{
 		ObjectMeta: metadata,
 		Spec:       spec,
 	}
 
-	log.Printf("[INFO] Creating new Job: %#v", job)
+	tflog.Info(ctx, fmt.Sprintf("Creating new Job: %#v", job))
 
 	out, err := conn.BatchV1().Jobs(metadata.Namespace).Create(ctx, &job, metav1.CreateOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to create Job! API error: %s", err)
 	}
-	log.Printf("[INFO] Submitted new job: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new job: %#v", out))
 
 	d.SetId(buildId(out.ObjectMeta))
 
-	namespace, name, err := idParts(d.Id())
-	if err != nil {
-		return diag.FromErr(err)
+	var diags diag.Diagnostics
+	if spec.ManagedBy != nil && out.Spec.ManagedBy == nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The API server dropped the managed_by of job %s", d.Id()),
+			Detail: fmt.Sprintf("The job is managed by the built-in job controller instead of %q, likely because the JobManagedBy feature gate isn't enabled on the cluster. "+
+				"The missing managed_by doesn't show up as a diff, so the job isn't replaced on every apply.", *spec.ManagedBy),
+		})
 	}
-	if d.Get("wait_for_completion").(bool) {
-		err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate),
-			retryUntilJobV1IsFinished(ctx, conn, namespace, name))
-		if err != nil {
-			return diag.FromErr(err)
+	if d.Get("wait_for_completion").(bool) && d.Get("spec.0.suspend").(bool) {
+		tflog.Info(ctx, fmt.Sprintf("Not waiting for job %s to complete while it is suspended", d.Id()))
+	} else if d.Get("wait_for_completion").(bool) {
+		target, timeout := expandJobV1WaitForCondition(d.Get("wait_for_condition").([]interface{}), d.Timeout(schema.TimeoutCreate))
+		diags = waitForJobV1ToFinish(ctx, conn, sharedWatchesOf(meta), out, target, timeout, int64(d.Get("failed_pod_log_lines").(int)))
+		if diags.HasError() {
+			return diags
 		}
-		return diag.Diagnostics{}
 	}
-
-	return resourceKubernetesJobV1Read(ctx, d, meta)
+	return append(diags, resourceKubernetesJobV1Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesJobV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -122,7 +273,7 @@ func resourceKubernetesJobV1Read(ctx context.Context, d *schema.ResourceData, me
 		if ttl, ok := d.GetOk("spec.0.ttl_seconds_after_finished"); ok {
 			// ttl_seconds_after_finished is set, Job is deleted due to TTL
 			// We don't need to remove the resource from the state
-			log.Printf("[INFO] Job %s has been deleted by Kubernetes due to TTL (ttl_seconds_after_finished = %v), keeping resource in state", d.Id(), ttl)
+			tflog.Info(ctx, fmt.Sprintf("Job %s has been deleted by Kubernetes due to TTL (ttl_seconds_after_finished = %v), keeping resource in state", d.Id(), ttl))
 			return diag.Diagnostics{}
 		} else {
 			// ttl_seconds_after_finished is not set, remove the resource from the state
@@ -140,13 +291,22 @@ func resourceKubernetesJobV1Read(ctx context.Context, d *schema.ResourceData, me
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading job %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading job %s", name))
 	job, err := conn.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.Errorf("Failed to read Job! API error: %s", err)
 	}
-	log.Printf("[INFO] Received job: %#v", job)
+	tflog.Info(ctx, fmt.Sprintf("Received job: %#v", job))
+
+	// The pods are listed before the generated labels are removed from the
+	// selector.
+	podNames, err := jobV1PodNames(ctx, conn, job)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to list the pods of job %s: %s", d.Id(), err))
+	} else if err := d.Set("pod_names", podNames); err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Remove server-generated labels unless using manual selector
 	if _, ok := d.GetOk("spec.0.manual_selector"); !ok {
@@ -168,6 +328,11 @@ func resourceKubernetesJobV1Read(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	err = d.Set("status", flattenJobV1Status(job.Status))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	return diag.Diagnostics{}
 }
 
@@ -194,24 +359,27 @@ func resourceKubernetesJobV1Update(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
 
-	log.Printf("[INFO] Updating job %s: %#v", d.Id(), ops)
+	tflog.Info(ctx, fmt.Sprintf("Updating job %s: %#v", d.Id(), ops))
 
 	out, err := conn.BatchV1().Jobs(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update Job! API error: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated job: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated job: %#v", out))
 
 	d.SetId(buildId(out.ObjectMeta))
 
-	if d.Get("wait_for_completion").(bool) {
-		err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate),
-			retryUntilJobV1IsFinished(ctx, conn, namespace, name))
-		if err != nil {
-			return diag.FromErr(err)
+	var diags diag.Diagnostics
+	if d.Get("wait_for_completion").(bool) && d.Get("spec.0.suspend").(bool) {
+		tflog.Info(ctx, fmt.Sprintf("Not waiting for job %s to complete while it is suspended", d.Id()))
+	} else if d.Get("wait_for_completion").(bool) {
+		target, timeout := expandJobV1WaitForCondition(d.Get("wait_for_condition").([]interface{}), d.Timeout(schema.TimeoutUpdate))
+		diags = waitForJobV1ToFinish(ctx, conn, sharedWatchesOf(meta), out, target, timeout, int64(d.Get("failed_pod_log_lines").(int)))
+		if diags.HasError() {
+			return diags
 		}
 	}
-	return resourceKubernetesJobV1Read(ctx, d, meta)
+	return append(diags, resourceKubernetesJobV1Read(ctx, d, meta)...)
 }
 func resourceKubernetesJobV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conn, err := meta.(KubeClientsets).MainClientset()
@@ -224,7 +392,13 @@ func resourceKubernetesJobV1Delete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting job: %#v", name)
+	if d.Get("skip_destroy").(bool) {
+		tflog.Info(ctx, fmt.Sprintf("Not deleting job %s, removing it from the state only as skip_destroy is set", d.Id()))
+		d.SetId("")
+		return nil
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting job: %#v", name))
 	err = conn.BatchV1().Jobs(namespace).Delete(ctx, name, deleteOptions)
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -233,8 +407,8 @@ func resourceKubernetesJobV1Delete(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("Failed to delete Job! API error: %s", err)
 	}
 
-	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
-		_, err := conn.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	err = retryWithProgress(ctx, d.Timeout(schema.TimeoutDelete), fmt.Sprintf("job %q to be deleted", d.Id()), func() *retry.RetryError {
+		out, err := conn.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
 			if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 				return nil
@@ -242,14 +416,14 @@ func resourceKubernetesJobV1Delete(ctx context.Context, d *schema.ResourceData,
 			return retry.NonRetryableError(err)
 		}
 
-		e := fmt.Errorf("Job %s still exists", name)
+		e := fmt.Errorf("Job %s still exists (%s, %s)", name, deletionProgress(out.ObjectMeta), jobV1Progress(out))
 		return retry.RetryableError(e)
 	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Job %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Job %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -266,40 +440,143 @@ func resourceKubernetesJobV1Exists(ctx context.Context, d *schema.ResourceData,
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking job %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking job %s", name))
 	_, err = conn.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
 
-// retryUntilJobV1IsFinished checks if a given job has finished its execution in either a Complete or Failed state
-func retryUntilJobV1IsFinished(ctx context.Context, conn *kubernetes.Clientset, ns, name string) retry.RetryFunc {
-	return func() *retry.RetryError {
-		job, err := conn.BatchV1().Jobs(ns).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
-				return nil
-			}
-			return retry.NonRetryableError(err)
+// waitForJobV1ToFinish watches a given job until it has finished its execution in either a Complete or Failed state,
+// or until it reports the target condition when there's one, reporting the warning events of the job and its pods
+// along the way, and the last logLines lines of the logs of its failed containers when it fails
+func waitForJobV1ToFinish(ctx context.Context, conn *kubernetes.Clientset, watches *sharedWatches, job *batchv1.Job, target *batchv1.JobCondition, timeout time.Duration, logLines int64) diag.Diagnostics {
+	ns, name := job.Namespace, job.Name
+	events := startWaitEventReporter(ctx, conn, job.ObjectMeta, "Job", job.Spec.Selector)
+	failed := false
+
+	description := fmt.Sprintf("job %q to finish", buildId(job.ObjectMeta))
+	if target != nil {
+		description = fmt.Sprintf("job %q to report condition %s=%s", buildId(job.ObjectMeta), target.Type, target.Status)
+	}
+	err := watchObjectUntil(ctx, watches, timeout, description, conn.BatchV1().Jobs(ns), ns, name, &batchv1.Job{}, func(event watch.Event) *retry.RetryError {
+		job, ok := event.Object.(*batchv1.Job)
+		if event.Type == watch.Deleted || !ok {
+			// The job may have been cleaned up by its TTL controller after finishing.
+			return nil
 		}
 
+		if target != nil {
+			for _, c := range job.Status.Conditions {
+				if c.Type == target.Type && c.Status == target.Status {
+					return nil
+				}
+			}
+		}
 		for _, c := range job.Status.Conditions {
 			if c.Status == corev1.ConditionTrue {
-				log.Printf("[DEBUG] Current condition of job: %s/%s: %s\n", ns, name, c.Type)
+				tflog.Debug(ctx, fmt.Sprintf("Current condition of job: %s/%s: %s", ns, name, c.Type))
 				switch c.Type {
-				case batchv1.JobComplete:
-					return nil
+				case batchv1.JobComplete, batchv1.JobSuccessCriteriaMet:
+					if target == nil {
+						// A job which met its success policy completes once its
+						// lingering pods are terminated.
+						return nil
+					}
+					if c.Type == batchv1.JobComplete {
+						return retry.NonRetryableError(fmt.Errorf("job: %s/%s completed without reporting condition %s=%s", ns, name, target.Type, target.Status))
+					}
 				case batchv1.JobFailed:
-					return retry.NonRetryableError(fmt.Errorf("job: %s/%s is in failed state", ns, name))
+					failed = true
+					return retry.NonRetryableError(fmt.Errorf("job: %s/%s is in failed state: %s: %s", ns, name, c.Reason, c.Message))
 				}
 			}
 		}
 
-		return retry.RetryableError(fmt.Errorf("job: %s/%s is not in complete state", ns, name))
+		if target != nil {
+			return retry.RetryableError(fmt.Errorf("job: %s/%s has not reported condition %s=%s: %s", ns, name, target.Type, target.Status, jobV1Progress(job)))
+		}
+		return retry.RetryableError(fmt.Errorf("job: %s/%s is not in complete state: %s", ns, name, jobV1Progress(job)))
+	})
+
+	diags := events.Diagnostics(ctx, err)
+	// The pods of a job managed by another controller may run in another
+	// cluster, e.g. with Kueue.
+	if failed && logLines > 0 && !jobV1ManagedExternally(job) {
+		diags = append(diags, failedPodLogsDiagnostics(ctx, conn, job, logLines)...)
+	}
+	return diags
+}
+
+// jobV1Progress summarizes the pods of a job, e.g. "active=2 ready=1 succeeded=0/5 failed=0".
+func jobV1Progress(job *batchv1.Job) string {
+	var ready int32
+	if job.Status.Ready != nil {
+		ready = *job.Status.Ready
+	}
+	succeeded := fmt.Sprintf("%d", job.Status.Succeeded)
+	if job.Spec.Completions != nil {
+		succeeded = fmt.Sprintf("%d/%d", job.Status.Succeeded, *job.Spec.Completions)
+	}
+	progress := fmt.Sprintf("active=%d ready=%d succeeded=%s failed=%d", job.Status.Active, ready, succeeded, job.Status.Failed)
+	if job.Spec.Suspend != nil && *job.Spec.Suspend {
+		progress += " suspended"
+	}
+	if jobV1ManagedExternally(job) {
+		progress += fmt.Sprintf(" (managed by %s)", *job.Spec.ManagedBy)
+	}
+	return progress
+}
+
+// jobV1PodNames returns the sorted names of the pods of a job which still
+// exist, matched by its selector.
+func jobV1PodNames(ctx context.Context, conn kubernetes.Interface, job *batchv1.Job) ([]string, error) {
+	pods, err := listJobV1Pods(ctx, conn, job)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, pod := range pods {
+		names = append(names, pod.Name)
 	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// listJobV1Pods lists the pods of a job matched by its selector, from the oldest
+// pod to the most recent one. A job without selector, or with an empty one which
+// would match every pod of the namespace, has no pods.
+func listJobV1Pods(ctx context.Context, conn kubernetes.Interface, job *batchv1.Job) ([]corev1.Pod, error) {
+	if job.Spec.Selector == nil {
+		return nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	if selector.Empty() {
+		return nil, nil
+	}
+	tflog.Info(ctx, fmt.Sprintf("Listing pods of job %s matching %q", buildId(job.ObjectMeta), selector))
+	pods, err := conn.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(pods.Items, func(i, j int) bool {
+		if ti, tj := pods.Items[i].CreationTimestamp, pods.Items[j].CreationTimestamp; !ti.Equal(&tj) {
+			return ti.Before(&tj)
+		}
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+	return pods.Items, nil
+}
+
+// jobV1ManagedExternally returns whether the job is managed by another
+// controller than the built-in job controller, which updates its status.
+func jobV1ManagedExternally(job *batchv1.Job) bool {
+	return job.Spec.ManagedBy != nil && *job.Spec.ManagedBy != batchv1.JobControllerName
 }
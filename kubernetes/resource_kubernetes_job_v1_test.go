@@ -6,6 +6,7 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -42,6 +43,322 @@ func TestAccKubernetesJobV1_wait_for_completion(t *testing.T) {
 					testAccCheckJobV1Waited(time.Duration(10)*time.Second),
 					testAccCheckKubernetesJobV1Exists(resourceName, &conf),
 					resource.TestCheckResourceAttr(resourceName, "wait_for_completion", "true"),
+					resource.TestCheckResourceAttr(resourceName, "status.0.succeeded", "1"),
+					resource.TestCheckResourceAttr(resourceName, "status.0.active", "0"),
+					resource.TestCheckResourceAttrSet(resourceName, "status.0.start_time"),
+					resource.TestCheckResourceAttrSet(resourceName, "status.0.completion_time"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "status.0.condition.*", map[string]string{
+						"type":   "Complete",
+						"status": "True",
+					}),
+					resource.TestCheckResourceAttr(resourceName, "pod_names.#", "1"),
+					resource.TestMatchResourceAttr(resourceName, "pod_names.0", regexp.MustCompile("^"+name+"-")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJobV1_generateName(t *testing.T) {
+	var conf batchv1.Job
+	generateName := "tf-acc-test-migrate-"
+	imageName := busyboxImage
+	resourceName := "kubernetes_job_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesJobV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobV1Config_generateName(generateName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.generate_name", generateName),
+					resource.TestMatchResourceAttr(resourceName, "metadata.0.name", regexp.MustCompile("^"+generateName)),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.uid"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_completion", "status"},
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJobV1_failedPodLogs(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := busyboxImage
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesJobV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesJobV1Config_failedPodLogs(name, imageName),
+				ExpectError: regexp.MustCompile(`(?s)BackoffLimitExceeded.*exit code 3.*table users is locked`),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJobV1_indexLimits(t *testing.T) {
+	var conf batchv1.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := busyboxImage
+	resourceName := "kubernetes_job_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfClusterVersionLessThan(t, "1.29.0")
+		},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesJobV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesJobV1Config_indexLimits(name, imageName, "NonIndexed", 2),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`backoff_limit_per_index` can only be set when `completion_mode` is `Indexed`"),
+			},
+			{
+				Config:      testAccKubernetesJobV1Config_indexLimits(name, imageName, "Indexed", 5),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("`max_failed_indexes` must be at most `completions` \\(4\\)"),
+			},
+			{
+				Config: testAccKubernetesJobV1Config_indexLimits(name, imageName, "Indexed", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.backoff_limit_per_index", "1"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.max_failed_indexes", "2"),
+				),
+			},
+			{
+				Config: testAccKubernetesJobV1Config_indexLimits(name, imageName, "Indexed", 3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.max_failed_indexes", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJobV1_successPolicy(t *testing.T) {
+	var conf batchv1.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := busyboxImage
+	resourceName := "kubernetes_job_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfClusterVersionLessThan(t, "1.31.0")
+		},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesJobV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobV1Config_successPolicy(name, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.success_policy.0.rule.0.succeeded_indexes", "0"),
+					resource.TestCheckResourceAttr(resourceName, "status.0.completed_indexes", "0"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "status.0.condition.*", map[string]string{
+						"type":   "SuccessCriteriaMet",
+						"status": "True",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJobV1_suspend(t *testing.T) {
+	var conf1, conf2 batchv1.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := busyboxImage
+	resourceName := "kubernetes_job_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesJobV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobV1Config_suspend(name, imageName, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobV1Exists(resourceName, &conf1),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.suspend", "true"),
+					resource.TestCheckResourceAttr(resourceName, "status.0.succeeded", "0"),
+				),
+			},
+			{
+				Config: testAccKubernetesJobV1Config_suspend(name, imageName, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobV1Exists(resourceName, &conf2),
+					testAccCheckKubernetesJobV1ForceNew(&conf1, &conf2, false),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.suspend", "false"),
+					resource.TestCheckResourceAttr(resourceName, "status.0.succeeded", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJobV1_triggers(t *testing.T) {
+	var conf1, conf2, conf3 batchv1.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := busyboxImage
+	resourceName := "kubernetes_job_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesJobV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobV1Config_triggers(name, imageName, "1.0.0"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobV1Exists(resourceName, &conf1),
+					resource.TestCheckResourceAttr(resourceName, "triggers.release", "1.0.0"),
+				),
+			},
+			{
+				Config: testAccKubernetesJobV1Config_triggers(name, imageName, "1.0.0"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobV1Exists(resourceName, &conf2),
+					testAccCheckKubernetesJobV1ForceNew(&conf1, &conf2, false),
+				),
+			},
+			{
+				Config: testAccKubernetesJobV1Config_triggers(name, imageName, "1.1.0"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobV1Exists(resourceName, &conf3),
+					testAccCheckKubernetesJobV1ForceNew(&conf2, &conf3, true),
+					resource.TestCheckResourceAttr(resourceName, "triggers.release", "1.1.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJobV1_skipDestroy(t *testing.T) {
+	var conf batchv1.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := busyboxImage
+	resourceName := "kubernetes_job_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testAccCheckKubernetesJobV1Retained("default", name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobV1Config_skipDestroy(name, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "skip_destroy", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJobV1_waitForCondition(t *testing.T) {
+	var conf batchv1.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := busyboxImage
+	resourceName := "kubernetes_job_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfClusterVersionLessThan(t, "1.31.0")
+		},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesJobV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobV1Config_waitForCondition(name, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "wait_for_condition.0.type", "FailureTarget"),
+					resource.TestCheckResourceAttr(resourceName, "wait_for_condition.0.status", "True"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "status.0.condition.*", map[string]string{
+						"type":   "FailureTarget",
+						"status": "True",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJobV1_managedBy(t *testing.T) {
+	var conf batchv1.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := busyboxImage
+	resourceName := "kubernetes_job_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfClusterVersionLessThan(t, "1.32.0")
+		},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesJobV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobV1Config_managedBy(name, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.managed_by", "example.com/external-controller"),
+					// The built-in job controller ignores the job.
+					resource.TestCheckResourceAttr(resourceName, "status.0.active", "0"),
+					resource.TestCheckResourceAttr(resourceName, "status.0.start_time", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJobV1_podReplacementPolicy(t *testing.T) {
+	var conf1, conf2 batchv1.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := busyboxImage
+	resourceName := "kubernetes_job_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfClusterVersionLessThan(t, "1.29.0")
+		},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesJobV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobV1Config_podReplacementPolicy(name, imageName, "Failed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobV1Exists(resourceName, &conf1),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.pod_replacement_policy", "Failed"),
+				),
+			},
+			{
+				Config: testAccKubernetesJobV1Config_podReplacementPolicy(name, imageName, "TerminatingOrFailed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobV1Exists(resourceName, &conf2),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.pod_replacement_policy", "TerminatingOrFailed"),
+					testAccCheckKubernetesJobV1ForceNew(&conf1, &conf2, false),
 				),
 			},
 		},
@@ -371,6 +688,21 @@ func testAccCheckKubernetesJobV1Destroy(s *terraform.State) error {
 	return nil
 }
 
+// testAccCheckKubernetesJobV1Retained checks that a job was kept in the
+// cluster when its resource was destroyed, and then deletes it.
+func testAccCheckKubernetesJobV1Retained(namespace, name string) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	if _, err := conn.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
+		return fmt.Errorf("Expected job %s/%s to be kept in the cluster: %s", namespace, name, err)
+	}
+	return conn.BatchV1().Jobs(namespace).Delete(ctx, name, deleteOptions)
+}
+
 func testAccCheckKubernetesJobV1Exists(n string, obj *batchv1.Job) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -559,6 +891,263 @@ func testAccKubernetesJobV1Config_wait_for_completion(name, imageName string) st
 }`, name, imageName)
 }
 
+func testAccKubernetesJobV1Config_failedPodLogs(name, imageName string) string {
+	return fmt.Sprintf(`resource "kubernetes_job_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    backoff_limit = 0
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "migrate"
+          image   = "%s"
+          command = ["sh", "-c", "echo 'migration failed: table users is locked'; exit 3"]
+        }
+        restart_policy = "Never"
+      }
+    }
+  }
+  wait_for_completion  = true
+  failed_pod_log_lines = 5
+  timeouts {
+    create = "1m"
+  }
+}`, name, imageName)
+}
+
+func testAccKubernetesJobV1Config_generateName(generateName, imageName string) string {
+	return fmt.Sprintf(`resource "kubernetes_job_v1" "test" {
+  metadata {
+    generate_name = "%s"
+  }
+  spec {
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "migrate"
+          image   = "%s"
+          command = ["sleep", "5"]
+        }
+      }
+    }
+  }
+  wait_for_completion = true
+  timeouts {
+    create = "1m"
+  }
+}`, generateName, imageName)
+}
+
+func testAccKubernetesJobV1Config_indexLimits(name, imageName, completionMode string, maxFailedIndexes int) string {
+	return fmt.Sprintf(`resource "kubernetes_job_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    completion_mode         = "%s"
+    completions             = 4
+    parallelism             = 2
+    backoff_limit_per_index = 1
+    max_failed_indexes      = %d
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "work"
+          image   = "%s"
+          command = ["sleep", "1"]
+        }
+        restart_policy = "Never"
+      }
+    }
+  }
+  wait_for_completion = false
+}`, name, completionMode, maxFailedIndexes, imageName)
+}
+
+func testAccKubernetesJobV1Config_successPolicy(name, imageName string) string {
+	return fmt.Sprintf(`resource "kubernetes_job_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    completion_mode = "Indexed"
+    completions     = 3
+    parallelism     = 3
+    success_policy {
+      rule {
+        succeeded_indexes = "0"
+      }
+    }
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "work"
+          image   = "%s"
+          command = ["sh", "-c", "[ \"$JOB_COMPLETION_INDEX\" = 0 ] || sleep 600"]
+        }
+        restart_policy = "Never"
+      }
+    }
+  }
+  wait_for_completion = true
+  timeouts {
+    create = "2m"
+  }
+}`, name, imageName)
+}
+
+func testAccKubernetesJobV1Config_suspend(name, imageName string, suspend bool) string {
+	return fmt.Sprintf(`resource "kubernetes_job_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    suspend = %t
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "work"
+          image   = "%s"
+          command = ["sleep", "5"]
+        }
+        restart_policy = "Never"
+      }
+    }
+  }
+  wait_for_completion = true
+  timeouts {
+    create = "1m"
+    update = "1m"
+  }
+}`, name, suspend, imageName)
+}
+
+func testAccKubernetesJobV1Config_triggers(name, imageName, release string) string {
+	return fmt.Sprintf(`resource "kubernetes_job_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "migrate"
+          image   = "%s"
+          command = ["sleep", "1"]
+        }
+        restart_policy = "Never"
+      }
+    }
+  }
+  triggers = {
+    release = "%s"
+  }
+  wait_for_completion = true
+}`, name, imageName, release)
+}
+
+func testAccKubernetesJobV1Config_skipDestroy(name, imageName string) string {
+	return fmt.Sprintf(`resource "kubernetes_job_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "work"
+          image   = "%s"
+          command = ["sleep", "1"]
+        }
+        restart_policy = "Never"
+      }
+    }
+  }
+  skip_destroy = true
+}`, name, imageName)
+}
+
+func testAccKubernetesJobV1Config_waitForCondition(name, imageName string) string {
+	return fmt.Sprintf(`resource "kubernetes_job_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    backoff_limit = 0
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "work"
+          image   = "%s"
+          command = ["sh", "-c", "exit 1"]
+        }
+        restart_policy = "Never"
+      }
+    }
+  }
+  wait_for_condition {
+    type    = "FailureTarget"
+    timeout = "2m"
+  }
+}`, name, imageName)
+}
+
+func testAccKubernetesJobV1Config_managedBy(name, imageName string) string {
+	return fmt.Sprintf(`resource "kubernetes_job_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    managed_by = "example.com/external-controller"
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "work"
+          image   = "%s"
+          command = ["sleep", "1"]
+        }
+        restart_policy = "Never"
+      }
+    }
+  }
+  wait_for_completion = false
+}`, name, imageName)
+}
+
+func testAccKubernetesJobV1Config_podReplacementPolicy(name, imageName, policy string) string {
+	return fmt.Sprintf(`resource "kubernetes_job_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    pod_replacement_policy = "%s"
+    template {
+      metadata {}
+      spec {
+        container {
+          name    = "work"
+          image   = "%s"
+          command = ["sleep", "60"]
+        }
+        restart_policy = "Never"
+      }
+    }
+  }
+  wait_for_completion = false
+}`, name, policy, imageName)
+}
+
 func testAccKubernetesJobV1Config_modified(name, imageName string) string {
 	return fmt.Sprintf(`resource "kubernetes_job_v1" "test" {
   metadata {
@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -43,30 +44,35 @@ func resourceKubernetesLimitRangeV1() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"default": {
-										Type:        schema.TypeMap,
-										Description: "Default resource requirement limit value by resource name if resource limit is omitted.",
-										Optional:    true,
+										Type:             schema.TypeMap,
+										Description:      "Default resource requirement limit value by resource name if resource limit is omitted.",
+										Optional:         true,
+										DiffSuppressFunc: suppressEquivalentResourceQuantity,
 									},
 									"default_request": {
-										Type:        schema.TypeMap,
-										Description: "The default resource requirement request value by resource name if resource request is omitted.",
-										Optional:    true,
-										Computed:    true,
+										Type:             schema.TypeMap,
+										Description:      "The default resource requirement request value by resource name if resource request is omitted.",
+										Optional:         true,
+										Computed:         true,
+										DiffSuppressFunc: suppressEquivalentResourceQuantity,
 									},
 									"max": {
-										Type:        schema.TypeMap,
-										Description: "Max usage constraints on this kind by resource name.",
-										Optional:    true,
+										Type:             schema.TypeMap,
+										Description:      "Max usage constraints on this kind by resource name.",
+										Optional:         true,
+										DiffSuppressFunc: suppressEquivalentResourceQuantity,
 									},
 									"max_limit_request_ratio": {
-										Type:        schema.TypeMap,
-										Description: "The named resource must have a request and limit that are both non-zero where limit divided by request is less than or equal to the enumerated value; this represents the max burst for the named resource.",
-										Optional:    true,
+										Type:             schema.TypeMap,
+										Description:      "The named resource must have a request and limit that are both non-zero where limit divided by request is less than or equal to the enumerated value; this represents the max burst for the named resource.",
+										Optional:         true,
+										DiffSuppressFunc: suppressEquivalentResourceQuantity,
 									},
 									"min": {
-										Type:        schema.TypeMap,
-										Description: "Min usage constraints on this kind by resource name.",
-										Optional:    true,
+										Type:             schema.TypeMap,
+										Description:      "Min usage constraints on this kind by resource name.",
+										Optional:         true,
+										DiffSuppressFunc: suppressEquivalentResourceQuantity,
 									},
 									"type": {
 										Type:        schema.TypeString,
@@ -98,12 +104,12 @@ func resourceKubernetesLimitRangeV1Create(ctx context.Context, d *schema.Resourc
 		ObjectMeta: metadata,
 		Spec:       *spec,
 	}
-	log.Printf("[INFO] Creating new limit range: %#v", limitRange)
+	tflog.Info(ctx, fmt.Sprintf("Creating new limit range: %#v", limitRange))
 	out, err := conn.CoreV1().LimitRanges(metadata.Namespace).Create(ctx, &limitRange, metav1.CreateOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to create limit range: %s", err)
 	}
-	log.Printf("[INFO] Submitted new limit range: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new limit range: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesLimitRangeV1Read(ctx, d, meta)
@@ -127,13 +133,13 @@ func resourceKubernetesLimitRangeV1Read(ctx context.Context, d *schema.ResourceD
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Reading limit range %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading limit range %s", name))
 	limitRange, err := conn.CoreV1().LimitRanges(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received limit range: %#v", limitRange)
+	tflog.Info(ctx, fmt.Sprintf("Received limit range: %#v", limitRange))
 
 	err = d.Set("metadata", flattenMetadata(limitRange.ObjectMeta, d, meta))
 	if err != nil {
@@ -173,12 +179,12 @@ func resourceKubernetesLimitRangeV1Update(ctx context.Context, d *schema.Resourc
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating limit range %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating limit range %q: %v", name, string(data)))
 	out, err := conn.CoreV1().LimitRanges(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update limit range: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated limit range: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated limit range: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesLimitRangeV1Read(ctx, d, meta)
@@ -195,13 +201,13 @@ func resourceKubernetesLimitRangeV1Delete(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting limit range: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting limit range: %#v", name))
 	err = conn.CoreV1().LimitRanges(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Limit range %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Limit range %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -218,13 +224,13 @@ func resourceKubernetesLimitRangeV1Exists(ctx context.Context, d *schema.Resourc
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking limit range %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking limit range %s", name))
 	_, err = conn.CoreV1().LimitRanges(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
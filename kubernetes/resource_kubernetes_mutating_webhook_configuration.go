@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -154,11 +155,11 @@ func resourceKubernetesMutatingWebhookConfigurationCreate(ctx context.Context, d
 		Webhooks:   expandMutatingWebhooks(d.Get("webhook").([]interface{})),
 	}
 
-	log.Printf("[INFO] Creating new MutatingWebhookConfiguration: %#v", cfg)
+	tflog.Info(ctx, fmt.Sprintf("Creating new MutatingWebhookConfiguration: %#v", cfg))
 
 	res := &admissionregistrationv1.MutatingWebhookConfiguration{}
 
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -176,7 +177,7 @@ func resourceKubernetesMutatingWebhookConfigurationCreate(ctx context.Context, d
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitted new MutatingWebhookConfiguration: %#v", res)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new MutatingWebhookConfiguration: %#v", res))
 
 	d.SetId(res.Name)
 
@@ -201,8 +202,8 @@ func resourceKubernetesMutatingWebhookConfigurationRead(ctx context.Context, d *
 
 	cfg := &admissionregistrationv1.MutatingWebhookConfiguration{}
 
-	log.Printf("[INFO] Reading MutatingWebhookConfiguration %s", name)
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	tflog.Info(ctx, fmt.Sprintf("Reading MutatingWebhookConfiguration %s", name))
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -222,7 +223,7 @@ func resourceKubernetesMutatingWebhookConfigurationRead(ctx context.Context, d *
 		return nil
 	}
 
-	log.Printf("[DEBUG] Setting webhook to: %#v", cfg.Webhooks)
+	tflog.Debug(ctx, fmt.Sprintf("Setting webhook to: %#v", cfg.Webhooks))
 
 	err = d.Set("webhook", flattenMutatingWebhooks(cfg.Webhooks))
 	if err != nil {
@@ -247,7 +248,7 @@ func resourceKubernetesMutatingWebhookConfigurationUpdate(ctx context.Context, d
 
 		patch := expandMutatingWebhooks(d.Get("webhook").([]interface{}))
 
-		useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+		useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -268,11 +269,11 @@ func resourceKubernetesMutatingWebhookConfigurationUpdate(ctx context.Context, d
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Updating MutatingWebhookConfiguration %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating MutatingWebhookConfiguration %q: %v", name, string(data)))
 
 	res := &admissionregistrationv1.MutatingWebhookConfiguration{}
 
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -287,7 +288,7 @@ func resourceKubernetesMutatingWebhookConfigurationUpdate(ctx context.Context, d
 		return diag.Errorf("Failed to update MutatingWebhookConfiguration: %s", err)
 	}
 
-	log.Printf("[INFO] Submitted updated MutatingWebhookConfiguration: %#v", res)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated MutatingWebhookConfiguration: %#v", res))
 
 	return resourceKubernetesMutatingWebhookConfigurationRead(ctx, d, meta)
 }
@@ -300,8 +301,8 @@ func resourceKubernetesMutatingWebhookConfigurationDelete(ctx context.Context, d
 
 	name := d.Id()
 
-	log.Printf("[INFO] Deleting MutatingWebhookConfiguration: %#v", name)
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	tflog.Info(ctx, fmt.Sprintf("Deleting MutatingWebhookConfiguration: %#v", name))
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -317,7 +318,7 @@ func resourceKubernetesMutatingWebhookConfigurationDelete(ctx context.Context, d
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] MutatingWebhookConfiguration %#v is deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("MutatingWebhookConfiguration %#v is deleted", name))
 
 	d.SetId("")
 	return nil
@@ -331,9 +332,9 @@ func resourceKubernetesMutatingWebhookConfigurationExists(ctx context.Context, d
 
 	name := d.Id()
 
-	log.Printf("[INFO] Checking MutatingWebhookConfiguration %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking MutatingWebhookConfiguration %s", name))
 
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return false, err
 	}
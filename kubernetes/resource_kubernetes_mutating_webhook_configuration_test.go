@@ -160,7 +160,7 @@ func testAccCheckKubernetesMutatingWebhookConfigurationDestroy(s *terraform.Stat
 
 		name := rs.Primary.ID
 
-		useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+		useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 		if err != nil {
 			return err
 		}
@@ -198,7 +198,7 @@ func testAccCheckKubernetesMutatingWebhookConfigurationExists(n string) resource
 
 		name := rs.Primary.ID
 
-		useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+		useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 		if err != nil {
 			return err
 		}
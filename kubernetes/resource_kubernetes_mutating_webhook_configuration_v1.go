@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -152,14 +153,14 @@ func resourceKubernetesMutatingWebhookConfigurationV1Create(ctx context.Context,
 		Webhooks:   expandMutatingWebhooks(d.Get("webhook").([]interface{})),
 	}
 
-	log.Printf("[INFO] Creating new MutatingWebhookConfiguration: %#v", cfg)
+	tflog.Info(ctx, fmt.Sprintf("Creating new MutatingWebhookConfiguration: %#v", cfg))
 
 	res, err := conn.AdmissionregistrationV1().MutatingWebhookConfigurations().Create(ctx, &cfg, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitted new MutatingWebhookConfiguration: %#v", res)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new MutatingWebhookConfiguration: %#v", res))
 
 	d.SetId(res.Name)
 
@@ -191,7 +192,7 @@ func resourceKubernetesMutatingWebhookConfigurationV1Read(ctx context.Context, d
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[DEBUG] Setting webhook to: %#v", cfg.Webhooks)
+	tflog.Debug(ctx, fmt.Sprintf("Setting webhook to: %#v", cfg.Webhooks))
 
 	err = d.Set("webhook", flattenMutatingWebhooks(cfg.Webhooks))
 	if err != nil {
@@ -224,14 +225,14 @@ func resourceKubernetesMutatingWebhookConfigurationV1Update(ctx context.Context,
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Updating MutatingWebhookConfiguration %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating MutatingWebhookConfiguration %q: %v", name, string(data)))
 
 	res, err := conn.AdmissionregistrationV1().MutatingWebhookConfigurations().Patch(ctx, name, types.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update MutatingWebhookConfiguration: %s", err)
 	}
 
-	log.Printf("[INFO] Submitted updated MutatingWebhookConfiguration: %#v", res)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated MutatingWebhookConfiguration: %#v", res))
 
 	return resourceKubernetesMutatingWebhookConfigurationV1Read(ctx, d, meta)
 }
@@ -244,7 +245,7 @@ func resourceKubernetesMutatingWebhookConfigurationV1Delete(ctx context.Context,
 
 	name := d.Id()
 
-	log.Printf("[INFO] Deleting MutatingWebhookConfiguration: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting MutatingWebhookConfiguration: %#v", name))
 	err = conn.AdmissionregistrationV1().MutatingWebhookConfigurations().Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -253,7 +254,7 @@ func resourceKubernetesMutatingWebhookConfigurationV1Delete(ctx context.Context,
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] MutatingWebhookConfiguration %#v is deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("MutatingWebhookConfiguration %#v is deleted", name))
 
 	d.SetId("")
 	return nil
@@ -267,7 +268,7 @@ func resourceKubernetesMutatingWebhookConfigurationV1Exists(ctx context.Context,
 
 	name := d.Id()
 
-	log.Printf("[INFO] Checking MutatingWebhookConfiguration %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking MutatingWebhookConfiguration %s", name))
 
 	_, err = conn.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
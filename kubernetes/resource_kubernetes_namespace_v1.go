@@ -6,9 +6,9 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -55,28 +55,28 @@ func resourceKubernetesNamespaceV1Create(ctx context.Context, d *schema.Resource
 	namespace := corev1.Namespace{
 		ObjectMeta: metadata,
 	}
-	log.Printf("[INFO] Creating new namespace: %#v", namespace)
+	tflog.Info(ctx, fmt.Sprintf("Creating new namespace: %#v", namespace))
 	out, err := conn.CoreV1().Namespaces().Create(ctx, &namespace, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new namespace: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new namespace: %#v", out))
 	d.SetId(out.Name)
 
 	if d.Get("wait_for_default_service_account").(bool) {
-		log.Printf("[DEBUG] Waiting for default service account to be created")
+		tflog.Debug(ctx, "Waiting for default service account to be created")
 		err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
 			_, err := conn.CoreV1().ServiceAccounts(out.Name).Get(ctx, "default", metav1.GetOptions{})
 			if err != nil {
 				if errors.IsNotFound(err) {
-					log.Printf("[INFO] Default service account does not exist, will retry: %s", metadata.Namespace)
+					tflog.Info(ctx, fmt.Sprintf("Default service account does not exist, will retry: %s", metadata.Namespace))
 					return retry.RetryableError(err)
 				}
 
 				return retry.NonRetryableError(err)
 			}
 
-			log.Printf("[INFO] Default service account exists: %s", metadata.Namespace)
+			tflog.Info(ctx, fmt.Sprintf("Default service account exists: %s", metadata.Namespace))
 			return nil
 		})
 		if err != nil {
@@ -101,13 +101,13 @@ func resourceKubernetesNamespaceV1Read(ctx context.Context, d *schema.ResourceDa
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Reading namespace %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading namespace %s", name))
 	namespace, err := conn.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received namespace: %#v", namespace)
+	tflog.Info(ctx, fmt.Sprintf("Received namespace: %#v", namespace))
 	err = d.Set("metadata", flattenMetadata(namespace.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
@@ -128,12 +128,12 @@ func resourceKubernetesNamespaceV1Update(ctx context.Context, d *schema.Resource
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
 
-	log.Printf("[INFO] Updating namespace: %s", ops)
+	tflog.Info(ctx, fmt.Sprintf("Updating namespace: %s", ops))
 	out, err := conn.CoreV1().Namespaces().Patch(ctx, d.Id(), pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted updated namespace: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated namespace: %#v", out))
 	d.SetId(out.Name)
 
 	return resourceKubernetesNamespaceV1Read(ctx, d, meta)
@@ -146,7 +146,7 @@ func resourceKubernetesNamespaceV1Delete(ctx context.Context, d *schema.Resource
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Deleting namespace: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting namespace: %#v", name))
 	err = conn.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -165,12 +165,12 @@ func resourceKubernetesNamespaceV1Delete(ctx context.Context, d *schema.Resource
 				if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 					return nil, "", nil
 				}
-				log.Printf("[ERROR] Received error: %#v", err)
+				tflog.Error(ctx, fmt.Sprintf("Received error: %#v", err))
 				return out, "Error", err
 			}
 
 			statusPhase := fmt.Sprintf("%v", out.Status.Phase)
-			log.Printf("[DEBUG] Namespace %s status received: %#v", out.Name, statusPhase)
+			tflog.Debug(ctx, fmt.Sprintf("Namespace %s status received: %#v", out.Name, statusPhase))
 			return out, statusPhase, nil
 		},
 	}
@@ -178,7 +178,7 @@ func resourceKubernetesNamespaceV1Delete(ctx context.Context, d *schema.Resource
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Namespace %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Namespace %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -191,14 +191,14 @@ func resourceKubernetesNamespaceV1Exists(ctx context.Context, d *schema.Resource
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Checking namespace %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking namespace %s", name))
 	_, err = conn.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
-	log.Printf("[INFO] Namespace %s exists", name)
+	tflog.Info(ctx, fmt.Sprintf("Namespace %s exists", name))
 	return true, err
 }
@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	networking "k8s.io/api/networking/v1"
@@ -265,13 +266,13 @@ func resourceKubernetesNetworkPolicyV1Create(ctx context.Context, d *schema.Reso
 		ObjectMeta: metadata,
 		Spec:       *spec,
 	}
-	log.Printf("[INFO] Creating new network policy: %#v", svc)
+	tflog.Info(ctx, fmt.Sprintf("Creating new network policy: %#v", svc))
 	out, err := conn.NetworkingV1().NetworkPolicies(metadata.Namespace).Create(ctx, &svc, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitted new network policy: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new network policy: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesNetworkPolicyV1Read(ctx, d, meta)
@@ -295,20 +296,20 @@ func resourceKubernetesNetworkPolicyV1Read(ctx context.Context, d *schema.Resour
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Reading network policy %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading network policy %s", name))
 	svc, err := conn.NetworkingV1().NetworkPolicies(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received network policy: %#v", svc)
+	tflog.Info(ctx, fmt.Sprintf("Received network policy: %#v", svc))
 	err = d.Set("metadata", flattenMetadata(svc.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattened := flattenNetworkPolicyV1Spec(svc.Spec)
-	log.Printf("[DEBUG] Flattened network policy spec: %#v", flattened)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened network policy spec: %#v", flattened))
 	err = d.Set("spec", flattened)
 	if err != nil {
 		return diag.FromErr(err)
@@ -340,12 +341,12 @@ func resourceKubernetesNetworkPolicyV1Update(ctx context.Context, d *schema.Reso
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating network policy %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating network policy %q: %v", name, string(data)))
 	out, err := conn.NetworkingV1().NetworkPolicies(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update network policy: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated network policy: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated network policy: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesNetworkPolicyV1Read(ctx, d, meta)
@@ -361,7 +362,7 @@ func resourceKubernetesNetworkPolicyV1Delete(ctx context.Context, d *schema.Reso
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Deleting network policy: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting network policy: %#v", name))
 	err = conn.NetworkingV1().NetworkPolicies(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -370,7 +371,7 @@ func resourceKubernetesNetworkPolicyV1Delete(ctx context.Context, d *schema.Reso
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Network Policy %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Network Policy %s deleted", name))
 
 	return nil
 }
@@ -386,13 +387,13 @@ func resourceKubernetesNetworkPolicyV1Exists(ctx context.Context, d *schema.Reso
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking network policy %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking network policy %s", name))
 	_, err = conn.NetworkingV1().NetworkPolicies(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
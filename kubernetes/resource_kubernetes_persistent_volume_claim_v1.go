@@ -6,9 +6,9 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -18,6 +18,7 @@ import (
 	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 func resourceKubernetesPersistentVolumeClaimV1() *schema.Resource {
@@ -77,8 +78,8 @@ func resourceKubernetesPersistentVolumeClaimV1() *schema.Resource {
 					return err
 				}
 				if newStorageQuantity.Cmp(oldStorageQuantity) == -1 {
-					log.Printf("[DEBUG] CustomizeDiff spec.resources.requests.storage: field can not be less than previous value")
-					log.Printf("[DEBUG] CustomizeDiff creating new PVC with size: %v", new)
+					tflog.Debug(ctx, "CustomizeDiff spec.resources.requests.storage: field can not be less than previous value")
+					tflog.Debug(ctx, fmt.Sprintf("CustomizeDiff creating new PVC with size: %v", new))
 					err := diff.ForceNew(key)
 					if err != nil {
 						return err
@@ -103,43 +104,40 @@ func resourceKubernetesPersistentVolumeClaimV1Create(ctx context.Context, d *sch
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Creating new persistent volume claim: %#v", claim)
+	tflog.Info(ctx, fmt.Sprintf("Creating new persistent volume claim: %#v", claim))
 	out, err := conn.CoreV1().PersistentVolumeClaims(claim.Namespace).Create(ctx, claim, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new persistent volume claim: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new persistent volume claim: %#v", out))
 
 	d.SetId(buildId(out.ObjectMeta))
 	name := out.ObjectMeta.Name
 
+	var diags diag.Diagnostics
 	if d.Get("wait_until_bound").(bool) {
-		stateConf := &retry.StateChangeConf{
-			Target:  []string{"Bound"},
-			Pending: []string{"Pending"},
-			Timeout: d.Timeout(schema.TimeoutCreate),
-			Refresh: func() (interface{}, string, error) {
-				out, err := conn.CoreV1().PersistentVolumeClaims(claim.Namespace).Get(ctx, name, metav1.GetOptions{})
-				if err != nil {
-					log.Printf("[ERROR] Received error: %#v", err)
-					return out, "", err
-				}
+		events := startWaitEventReporter(ctx, conn, out.ObjectMeta, "PersistentVolumeClaim", nil)
+		lw := singleObjectListWatch[*api.PersistentVolumeClaimList](ctx, conn.CoreV1().PersistentVolumeClaims(claim.Namespace), name)
+		err = watchUntil(ctx, d.Timeout(schema.TimeoutCreate), fmt.Sprintf("persistent volume claim %q to be bound", d.Id()), lw, &api.PersistentVolumeClaim{}, func(event watch.Event) *retry.RetryError {
+			pvc, ok := event.Object.(*api.PersistentVolumeClaim)
+			if event.Type == watch.Deleted || !ok {
+				return retry.NonRetryableError(fmt.Errorf("Persistent volume claim %s was deleted while waiting for it to be bound", d.Id()))
+			}
 
-				statusPhase := fmt.Sprintf("%v", out.Status.Phase)
-				log.Printf("[DEBUG] Persistent volume claim %s status received: %#v", out.Name, statusPhase)
-				return out, statusPhase, nil
-			},
-		}
-		_, err = stateConf.WaitForStateContext(ctx)
+			tflog.Debug(ctx, fmt.Sprintf("Persistent volume claim %s status received: %#v", pvc.Name, pvc.Status.Phase))
+			switch pvc.Status.Phase {
+			case api.ClaimBound:
+				return nil
+			case api.ClaimPending:
+				return retry.RetryableError(fmt.Errorf("Waiting for persistent volume claim %s to be bound", d.Id()))
+			default:
+				return retry.NonRetryableError(fmt.Errorf("unexpected state '%s', wanted target '%s'", pvc.Status.Phase, api.ClaimBound))
+			}
+		})
+		lastWarnings := events.Stop(ctx)
 		if err != nil {
-			var lastWarnings []api.Event
 			var wErr error
 
-			lastWarnings, wErr = getLastWarningsForObject(ctx, conn, out.ObjectMeta, "PersistentVolumeClaim", 3)
-			if wErr != nil {
-				return diag.FromErr(wErr)
-			}
-
 			if len(lastWarnings) == 0 {
 				lastWarnings, wErr = getLastWarningsForObject(ctx, conn, metav1.ObjectMeta{
 					Name: out.Spec.VolumeName,
@@ -151,10 +149,11 @@ func resourceKubernetesPersistentVolumeClaimV1Create(ctx context.Context, d *sch
 
 			return diag.Errorf("%s%s", err, stringifyEvents(lastWarnings))
 		}
+		diags = waitEventsDiagnostics(nil, "PersistentVolumeClaim", out.ObjectMeta, lastWarnings)
 	}
-	log.Printf("[INFO] Persistent volume claim %s created", out.Name)
+	tflog.Info(ctx, fmt.Sprintf("Persistent volume claim %s created", out.Name))
 
-	return resourceKubernetesPersistentVolumeClaimV1Read(ctx, d, meta)
+	return append(diags, resourceKubernetesPersistentVolumeClaimV1Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesPersistentVolumeClaimV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -176,13 +175,13 @@ func resourceKubernetesPersistentVolumeClaimV1Read(ctx context.Context, d *schem
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading persistent volume claim %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading persistent volume claim %s", name))
 	claim, err := conn.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received persistent volume claim: %#v", claim)
+	tflog.Info(ctx, fmt.Sprintf("Received persistent volume claim: %#v", claim))
 	err = d.Set("metadata", flattenMetadata(claim.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
@@ -224,12 +223,12 @@ func resourceKubernetesPersistentVolumeClaimV1Update(ctx context.Context, d *sch
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
 
-	log.Printf("[INFO] Updating persistent volume claim: %s", ops)
+	tflog.Info(ctx, fmt.Sprintf("Updating persistent volume claim: %s", ops))
 	out, err := conn.CoreV1().PersistentVolumeClaims(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted updated persistent volume claim: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated persistent volume claim: %#v", out))
 
 	return resourceKubernetesPersistentVolumeClaimV1Read(ctx, d, meta)
 }
@@ -245,7 +244,7 @@ func resourceKubernetesPersistentVolumeClaimV1Delete(ctx context.Context, d *sch
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting persistent volume claim: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting persistent volume claim: %#v", name))
 	err = conn.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -263,7 +262,7 @@ func resourceKubernetesPersistentVolumeClaimV1Delete(ctx context.Context, d *sch
 			return retry.NonRetryableError(err)
 		}
 
-		log.Printf("[DEBUG] Current state of persistent volume claim finalizers: %#v", out.Finalizers)
+		tflog.Debug(ctx, fmt.Sprintf("Current state of persistent volume claim finalizers: %#v", out.Finalizers))
 		e := fmt.Errorf("Persistent volume claim %s still exists with finalizers: %v", name, out.Finalizers)
 		return retry.RetryableError(e)
 	})
@@ -271,7 +270,7 @@ func resourceKubernetesPersistentVolumeClaimV1Delete(ctx context.Context, d *sch
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Persistent volume claim %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Persistent volume claim %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -288,13 +287,13 @@ func resourceKubernetesPersistentVolumeClaimV1Exists(ctx context.Context, d *sch
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking persistent volume claim %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking persistent volume claim %s", name))
 	_, err = conn.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
@@ -7,10 +7,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -51,13 +51,13 @@ func resourceKubernetesPersistentVolumeV1() *schema.Resource {
 			kindValue, _ := diff.GetOk(kind)
 			diskURIValue, diskURIExists := diff.GetOk(diskURI)
 			if diskURIExists && strings.Contains(diskURIValue.(string), "blob.core.windows.net") && kindValue == "Managed" {
-				log.Printf("Configuration error:")
-				log.Printf("Mismatch between Disk URI: %v = %v and Disk Kind: %v = %v", diskURI, diskURIValue, kind, kindValue)
+				tflog.Info(ctx, "Configuration error:")
+				tflog.Info(ctx, fmt.Sprintf("Mismatch between Disk URI: %v = %v and Disk Kind: %v = %v", diskURI, diskURIValue, kind, kindValue))
 				return errors.New(persistentVolumeAzureBlobError)
 			}
 			if diskURIExists && strings.Contains(diskURIValue.(string), "/providers/Microsoft.Compute/disks/") && kindValue != "Managed" {
-				log.Printf("Configuration error:")
-				log.Printf("Mismatch between Disk URI: %v = %v and disk Kind: %v = %v", diskURI, diskURIValue, kind, kindValue)
+				tflog.Info(ctx, "Configuration error:")
+				tflog.Info(ctx, fmt.Sprintf("Mismatch between Disk URI: %v = %v and disk Kind: %v = %v", diskURI, diskURIValue, kind, kindValue))
 				return errors.New(persistentVolumeAzureManagedError)
 			}
 			// The following applies to Updates only.
@@ -67,8 +67,8 @@ func resourceKubernetesPersistentVolumeV1() *schema.Resource {
 			// Any change to Persistent Volume Source requires a new resource.
 			keys := diff.GetChangedKeysPrefix("spec.0.persistent_volume_source")
 			for _, key := range keys {
-				log.Printf("[DEBUG] CustomizeDiff GetChangedKeysPrefix key: %v", key)
-				log.Printf("[DEBUG] CustomizeDiff key: %v", key)
+				tflog.Debug(ctx, fmt.Sprintf("CustomizeDiff GetChangedKeysPrefix key: %v", key))
+				tflog.Debug(ctx, fmt.Sprintf("CustomizeDiff key: %v", key))
 				err := diff.ForceNew(key)
 				if err != nil {
 					return err
@@ -78,7 +78,7 @@ func resourceKubernetesPersistentVolumeV1() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": metadataSchema("persistent volume", false),
+			"metadata": metadataSchema("persistent volume", true),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec of the persistent volume owned by the cluster",
@@ -223,33 +223,34 @@ func resourceKubernetesPersistentVolumeV1Create(ctx context.Context, d *schema.R
 		Spec:       *spec,
 	}
 
-	log.Printf("[INFO] Creating new persistent volume: %#v", volume)
+	tflog.Info(ctx, fmt.Sprintf("Creating new persistent volume: %#v", volume))
 	out, err := conn.CoreV1().PersistentVolumes().Create(ctx, &volume, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new persistent volume: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new persistent volume: %#v", out))
 
+	name := out.Name
 	stateConf := &retry.StateChangeConf{
 		Target:  []string{"Available", "Bound"},
 		Pending: []string{"Pending"},
 		Timeout: d.Timeout(schema.TimeoutCreate),
 		Refresh: func() (interface{}, string, error) {
-			out, err := conn.CoreV1().PersistentVolumes().Get(ctx, metadata.Name, metav1.GetOptions{})
+			out, err := conn.CoreV1().PersistentVolumes().Get(ctx, name, metav1.GetOptions{})
 			if err != nil {
-				log.Printf("[ERROR] Received error: %#v", err)
+				tflog.Error(ctx, fmt.Sprintf("Received error: %#v", err))
 				return out, "Error", err
 			}
 
 			statusPhase := fmt.Sprintf("%v", out.Status.Phase)
 			statusMessage := fmt.Sprintf("%v", out.Status.Message)
 			if statusMessage == "" {
-				log.Printf("[DEBUG] Persistent volume %s status received: %#v", out.Name, statusPhase)
+				tflog.Debug(ctx, fmt.Sprintf("Persistent volume %s status received: %#v", out.Name, statusPhase))
 			} else {
-				log.Printf("[DEBUG] Persistent volume %s status received: %#v, message received: %#v", out.Name, statusPhase, statusMessage)
+				tflog.Debug(ctx, fmt.Sprintf("Persistent volume %s status received: %#v, message received: %#v", out.Name, statusPhase, statusMessage))
 			}
 			if out.Status.LastPhaseTransitionTime != nil {
-				log.Printf("[DEBUG] Persistent volume last phrase transition time: %v", out.Status.LastPhaseTransitionTime)
+				tflog.Debug(ctx, fmt.Sprintf("Persistent volume last phrase transition time: %v", out.Status.LastPhaseTransitionTime))
 			}
 			return out, statusPhase, nil
 		},
@@ -258,7 +259,7 @@ func resourceKubernetesPersistentVolumeV1Create(ctx context.Context, d *schema.R
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Persistent volume %s created", out.Name)
+	tflog.Info(ctx, fmt.Sprintf("Persistent volume %s created", out.Name))
 
 	d.SetId(out.Name)
 
@@ -280,15 +281,15 @@ func resourceKubernetesPersistentVolumeV1Read(ctx context.Context, d *schema.Res
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Reading persistent volume %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading persistent volume %s", name))
 	volume, err := conn.CoreV1().PersistentVolumes().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received persistent volume: %#v", volume)
+	tflog.Info(ctx, fmt.Sprintf("Received persistent volume: %#v", volume))
 	if volume.Status.LastPhaseTransitionTime != nil {
-		log.Printf("[DEBUG] Persistent volume last phrase transition time: %v", volume.Status.LastPhaseTransitionTime)
+		tflog.Debug(ctx, fmt.Sprintf("Persistent volume last phrase transition time: %v", volume.Status.LastPhaseTransitionTime))
 	}
 	err = d.Set("metadata", flattenMetadata(volume.ObjectMeta, d, meta))
 	if err != nil {
@@ -321,12 +322,12 @@ func resourceKubernetesPersistentVolumeV1Update(ctx context.Context, d *schema.R
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
 
-	log.Printf("[INFO] Updating persistent volume %s: %s", d.Id(), ops)
+	tflog.Info(ctx, fmt.Sprintf("Updating persistent volume %s: %s", d.Id(), ops))
 	out, err := conn.CoreV1().PersistentVolumes().Patch(ctx, d.Id(), pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted updated persistent volume: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated persistent volume: %#v", out))
 	d.SetId(out.Name)
 
 	return resourceKubernetesPersistentVolumeV1Read(ctx, d, meta)
@@ -339,7 +340,7 @@ func resourceKubernetesPersistentVolumeV1Delete(ctx context.Context, d *schema.R
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Deleting persistent volume: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting persistent volume: %#v", name))
 	err = conn.CoreV1().PersistentVolumes().Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*k8serrors.StatusError); ok && k8serrors.IsNotFound(statusErr) {
@@ -358,12 +359,12 @@ func resourceKubernetesPersistentVolumeV1Delete(ctx context.Context, d *schema.R
 		}
 		statusMessage := fmt.Sprintf("%v", out.Status.Message)
 		if statusMessage == "" {
-			log.Printf("[DEBUG] Current state of persistent volume: %#v", out.Status.Phase)
+			tflog.Debug(ctx, fmt.Sprintf("Current state of persistent volume: %#v", out.Status.Phase))
 		} else {
-			log.Printf("[DEBUG] Current state of persistent volume: %#v, message received: %#v", out.Status.Phase, out.Status.Message)
+			tflog.Debug(ctx, fmt.Sprintf("Current state of persistent volume: %#v, message received: %#v", out.Status.Phase, out.Status.Message))
 		}
 		if out.Status.LastPhaseTransitionTime != nil {
-			log.Printf("[DEBUG] Persistent volume last phrase transition time: %v", out.Status.LastPhaseTransitionTime)
+			tflog.Debug(ctx, fmt.Sprintf("Persistent volume last phrase transition time: %v", out.Status.LastPhaseTransitionTime))
 		}
 		e := fmt.Errorf("Persistent volume %s still exists (%s)", name, out.Status.Phase)
 		return retry.RetryableError(e)
@@ -372,7 +373,7 @@ func resourceKubernetesPersistentVolumeV1Delete(ctx context.Context, d *schema.R
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Persistent volume %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Persistent volume %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -385,16 +386,16 @@ func resourceKubernetesPersistentVolumeV1Exists(ctx context.Context, d *schema.R
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Checking persistent volume %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking persistent volume %s", name))
 	out, err := conn.CoreV1().PersistentVolumes().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	if out.Status.LastPhaseTransitionTime != nil {
-		log.Printf("[DEBUG] Persistent volume last phrase transition time: %v", out.Status.LastPhaseTransitionTime)
+		tflog.Debug(ctx, fmt.Sprintf("Persistent volume last phrase transition time: %v", out.Status.LastPhaseTransitionTime))
 	}
 	return true, err
 }
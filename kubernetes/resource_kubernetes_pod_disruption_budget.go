@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -96,13 +97,13 @@ func resourceKubernetesPodDisruptionBudgetUpdate(ctx context.Context, d *schema.
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
 
-	log.Printf("[INFO] Updating pod disruption budget %s: %s", d.Id(), ops)
+	tflog.Info(ctx, fmt.Sprintf("Updating pod disruption budget %s: %s", d.Id(), ops))
 	out, err := conn.PolicyV1beta1().PodDisruptionBudgets(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitted updated pod disruption budget: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated pod disruption budget: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesPodDisruptionBudgetRead(ctx, d, meta)
@@ -124,13 +125,13 @@ func resourceKubernetesPodDisruptionBudgetCreate(ctx context.Context, d *schema.
 		Spec:       *spec,
 	}
 
-	log.Printf("[INFO] Creating new pod disruption budget: %#v", pdb)
+	tflog.Info(ctx, fmt.Sprintf("Creating new pod disruption budget: %#v", pdb))
 	out, err := conn.PolicyV1beta1().PodDisruptionBudgets(metadata.Namespace).Create(ctx, &pdb, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitted new pod disruption budget: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new pod disruption budget: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesPodDisruptionBudgetRead(ctx, d, meta)
@@ -155,14 +156,14 @@ func resourceKubernetesPodDisruptionBudgetRead(ctx context.Context, d *schema.Re
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading pod disruption budget %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading pod disruption budget %s", name))
 	pdb, err := conn.PolicyV1beta1().PodDisruptionBudgets(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Received pod disruption budget: %#v", pdb)
+	tflog.Info(ctx, fmt.Sprintf("Received pod disruption budget: %#v", pdb))
 	err = d.Set("metadata", flattenMetadata(pdb.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
@@ -187,17 +188,17 @@ func resourceKubernetesPodDisruptionBudgetDelete(ctx context.Context, d *schema.
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting pod disruption budget %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting pod disruption budget %#v", name))
 	err = conn.PolicyV1beta1().PodDisruptionBudgets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Pod disruption budget %#v deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Pod disruption budget %#v deleted", name))
 
 	d.SetId("")
 	return nil
@@ -214,13 +215,13 @@ func resourceKubernetesPodDisruptionBudgetExists(ctx context.Context, d *schema.
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking pod disruption budget %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking pod disruption budget %s", name))
 	_, err = conn.PolicyV1beta1().PodDisruptionBudgets(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
@@ -5,10 +5,12 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	policy "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -72,6 +74,41 @@ func resourceKubernetesPodDisruptionBudgetV1() *schema.Resource {
 								Schema: labelSelectorFields(false),
 							},
 						},
+						"unhealthy_pod_eviction_policy": {
+							Type:         schema.TypeString,
+							Description:  "Defines the criteria for when unhealthy pods should be considered for eviction, `IfHealthyBudget` or `AlwaysAllow`. With `IfHealthyBudget`, the default when not set, running but not yet healthy pods can only be evicted when the application isn't disrupted. With `AlwaysAllow`, they can always be evicted, which lets nodes with misbehaving applications be drained.",
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{string(policy.IfHealthyBudget), string(policy.AlwaysAllow)}, false),
+						},
+					},
+				},
+			},
+			"status": {
+				Type:        schema.TypeList,
+				Description: "The most recently observed status of the pod disruption budget.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"current_healthy": {
+							Type:        schema.TypeInt,
+							Description: "Current number of healthy pods.",
+							Computed:    true,
+						},
+						"desired_healthy": {
+							Type:        schema.TypeInt,
+							Description: "Minimum desired number of healthy pods.",
+							Computed:    true,
+						},
+						"disruptions_allowed": {
+							Type:        schema.TypeInt,
+							Description: "Number of pod disruptions that are currently allowed, e.g. `0` when draining a node would be blocked by the budget.",
+							Computed:    true,
+						},
+						"expected_pods": {
+							Type:        schema.TypeInt,
+							Description: "Total number of pods counted by this disruption budget.",
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -91,18 +128,30 @@ func resourceKubernetesPodDisruptionBudgetV1Update(ctx context.Context, d *schem
 	}
 
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("spec.0.unhealthy_pod_eviction_policy") {
+		if v := d.Get("spec.0.unhealthy_pod_eviction_policy").(string); v != "" {
+			ops = append(ops, &AddOperation{
+				Path:  "/spec/unhealthyPodEvictionPolicy",
+				Value: v,
+			})
+		} else {
+			ops = append(ops, &RemoveOperation{
+				Path: "/spec/unhealthyPodEvictionPolicy",
+			})
+		}
+	}
 	data, err := ops.MarshalJSON()
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
 
-	log.Printf("[INFO] Updating pod disruption budget %s: %s", d.Id(), ops)
+	tflog.Info(ctx, fmt.Sprintf("Updating pod disruption budget %s: %s", d.Id(), ops))
 	out, err := conn.PolicyV1().PodDisruptionBudgets(namespace).Patch(ctx, name, types.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitted updated pod disruption budget: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated pod disruption budget: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesPodDisruptionBudgetV1Read(ctx, d, meta)
@@ -124,13 +173,13 @@ func resourceKubernetesPodDisruptionBudgetV1Create(ctx context.Context, d *schem
 		Spec:       *spec,
 	}
 
-	log.Printf("[INFO] Creating new pod disruption budget: %#v", pdb)
+	tflog.Info(ctx, fmt.Sprintf("Creating new pod disruption budget: %#v", pdb))
 	out, err := conn.PolicyV1().PodDisruptionBudgets(metadata.Namespace).Create(ctx, &pdb, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitted new pod disruption budget: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new pod disruption budget: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesPodDisruptionBudgetV1Read(ctx, d, meta)
@@ -155,14 +204,14 @@ func resourceKubernetesPodDisruptionBudgetV1Read(ctx context.Context, d *schema.
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading pod disruption budget %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading pod disruption budget %s", name))
 	pdb, err := conn.PolicyV1().PodDisruptionBudgets(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Received pod disruption budget: %#v", pdb)
+	tflog.Info(ctx, fmt.Sprintf("Received pod disruption budget: %#v", pdb))
 	err = d.Set("metadata", flattenMetadata(pdb.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
@@ -173,6 +222,11 @@ func resourceKubernetesPodDisruptionBudgetV1Read(ctx context.Context, d *schema.
 		return diag.FromErr(err)
 	}
 
+	err = d.Set("status", flattenPodDisruptionBudgetV1Status(pdb.Status))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
@@ -187,17 +241,17 @@ func resourceKubernetesPodDisruptionBudgetV1Delete(ctx context.Context, d *schem
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting pod disruption budget %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting pod disruption budget %#v", name))
 	err = conn.PolicyV1().PodDisruptionBudgets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Pod disruption budget %#v deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Pod disruption budget %#v deleted", name))
 
 	d.SetId("")
 	return nil
@@ -214,13 +268,13 @@ func resourceKubernetesPodDisruptionBudgetV1Exists(ctx context.Context, d *schem
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking pod disruption budget %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking pod disruption budget %s", name))
 	_, err = conn.PolicyV1().PodDisruptionBudgets(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
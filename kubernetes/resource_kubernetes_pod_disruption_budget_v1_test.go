@@ -145,6 +145,71 @@ func testAccCheckKubernetesPodDisruptionBudgetV1Exists(n string, obj *policy.Pod
 	}
 }
 
+func TestAccKubernetesPodDisruptionBudgetV1_unhealthyPodEvictionPolicy(t *testing.T) {
+	var conf1, conf2 policy.PodDisruptionBudget
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	resourceName := "kubernetes_pod_disruption_budget_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfClusterVersionLessThan(t, "1.27.0")
+		},
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesPodDisruptionBudgetV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodDisruptionBudgetV1Config_unhealthyPodEvictionPolicy(name, "IfHealthyBudget"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodDisruptionBudgetV1Exists(resourceName, &conf1),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.unhealthy_pod_eviction_policy", "IfHealthyBudget"),
+					resource.TestCheckResourceAttr(resourceName, "status.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "status.0.expected_pods", "0"),
+					resource.TestCheckResourceAttr(resourceName, "status.0.disruptions_allowed", "0"),
+				),
+			},
+			{
+				Config: testAccKubernetesPodDisruptionBudgetV1Config_unhealthyPodEvictionPolicy(name, "AlwaysAllow"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodDisruptionBudgetV1Exists(resourceName, &conf2),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.unhealthy_pod_eviction_policy", "AlwaysAllow"),
+					testAccCheckKubernetesPodDisruptionBudgetV1NotRecreated(&conf1, &conf2),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesPodDisruptionBudgetV1NotRecreated(before, after *policy.PodDisruptionBudget) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if before.UID != after.UID {
+			return fmt.Errorf("Expected the pod disruption budget to be updated in place, it was recreated")
+		}
+		return nil
+	}
+}
+
+func testAccKubernetesPodDisruptionBudgetV1Config_unhealthyPodEvictionPolicy(name, evictionPolicy string) string {
+	return fmt.Sprintf(`resource "kubernetes_pod_disruption_budget_v1" "test" {
+  metadata {
+    name = %q
+  }
+
+  spec {
+    max_unavailable               = 1
+    unhealthy_pod_eviction_policy = %q
+    selector {
+      match_labels = {
+        foo = "bar"
+      }
+    }
+  }
+}
+`, name, evictionPolicy)
+}
+
 func testAccKubernetesPodDisruptionBudgetV1Config_maxUnavailable(name string) string {
 	return fmt.Sprintf(`resource "kubernetes_pod_disruption_budget_v1" "test" {
   metadata {
@@ -5,15 +5,19 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	policy "k8s.io/api/policy/v1beta1"
+	policy "github.com/hashicorp/terraform-provider-kubernetes/internal/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 )
 
 // Use generated swagger docs from kubernetes' client-go to avoid copy/pasting them here
@@ -370,7 +374,7 @@ func resourceKubernetesPodSecurityPolicyV1Beta1() *schema.Resource {
 }
 
 func resourceKubernetesPodSecurityPolicyV1Beta1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn, err := meta.(KubeClientsets).MainClientset()
+	conn, err := meta.(KubeClientsets).DynamicClient()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -387,13 +391,13 @@ func resourceKubernetesPodSecurityPolicyV1Beta1Create(ctx context.Context, d *sc
 		Spec:       spec,
 	}
 
-	log.Printf("[INFO] Creating new PodSecurityPolicy: %#v", psp)
-	out, err := conn.PolicyV1beta1().PodSecurityPolicies().Create(ctx, psp, metav1.CreateOptions{})
+	tflog.Info(ctx, fmt.Sprintf("Creating new PodSecurityPolicy: %#v", psp))
+	out, err := createPodSecurityPolicyV1Beta1(ctx, conn, psp)
 
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new PodSecurityPolicy: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new PodSecurityPolicy: %#v", out))
 	d.SetId(out.Name)
 
 	return resourceKubernetesPodSecurityPolicyV1Beta1Read(ctx, d, meta)
@@ -408,28 +412,28 @@ func resourceKubernetesPodSecurityPolicyV1Beta1Read(ctx context.Context, d *sche
 		d.SetId("")
 		return diag.Diagnostics{}
 	}
-	conn, err := meta.(KubeClientsets).MainClientset()
+	conn, err := meta.(KubeClientsets).DynamicClient()
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	name := d.Id()
 
-	log.Printf("[INFO] Reading PodSecurityPolicy %s", name)
-	psp, err := conn.PolicyV1beta1().PodSecurityPolicies().Get(ctx, name, metav1.GetOptions{})
+	tflog.Info(ctx, fmt.Sprintf("Reading PodSecurityPolicy %s", name))
+	psp, err := getPodSecurityPolicyV1Beta1(ctx, conn, name)
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Received PodSecurityPolicy: %#v", psp)
+	tflog.Info(ctx, fmt.Sprintf("Received PodSecurityPolicy: %#v", psp))
 	err = d.Set("metadata", flattenMetadata(psp.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattenedSpec := flattenPodSecurityPolicySpec(psp.Spec)
-	log.Printf("[DEBUG] Flattened PodSecurityPolicy roleRef: %#v", flattenedSpec)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened PodSecurityPolicy roleRef: %#v", flattenedSpec))
 	err = d.Set("spec", flattenedSpec)
 	if err != nil {
 		return diag.FromErr(err)
@@ -439,7 +443,7 @@ func resourceKubernetesPodSecurityPolicyV1Beta1Read(ctx context.Context, d *sche
 }
 
 func resourceKubernetesPodSecurityPolicyV1Beta1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn, err := meta.(KubeClientsets).MainClientset()
+	conn, err := meta.(KubeClientsets).DynamicClient()
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -456,57 +460,90 @@ func resourceKubernetesPodSecurityPolicyV1Beta1Update(ctx context.Context, d *sc
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating PodSecurityPolicy %q: %v", name, string(data))
-	out, err := conn.PolicyV1beta1().PodSecurityPolicies().Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	tflog.Info(ctx, fmt.Sprintf("Updating PodSecurityPolicy %q: %v", name, string(data)))
+	out, err := conn.Resource(policy.PodSecurityPolicies).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update PodSecurityPolicy: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated PodSecurityPolicy: %#v", out)
-	d.SetId(out.Name)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated PodSecurityPolicy: %#v", out))
+	d.SetId(out.GetName())
 
 	return resourceKubernetesPodSecurityPolicyV1Beta1Read(ctx, d, meta)
 }
 
 func resourceKubernetesPodSecurityPolicyV1Beta1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	conn, err := meta.(KubeClientsets).MainClientset()
+	conn, err := meta.(KubeClientsets).DynamicClient()
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	name := d.Id()
 
-	log.Printf("[INFO] Deleting PodSecurityPolicy: %#v", name)
-	err = conn.PolicyV1beta1().PodSecurityPolicies().Delete(ctx, name, metav1.DeleteOptions{})
+	tflog.Info(ctx, fmt.Sprintf("Deleting PodSecurityPolicy: %#v", name))
+	err = conn.Resource(policy.PodSecurityPolicies).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return nil
 		}
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] PodSecurityPolicy %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("PodSecurityPolicy %s deleted", name))
 
 	return nil
 }
 
 func resourceKubernetesPodSecurityPolicyV1Beta1Exists(ctx context.Context, d *schema.ResourceData, meta interface{}) (bool, error) {
-	conn, err := meta.(KubeClientsets).MainClientset()
+	conn, err := meta.(KubeClientsets).DynamicClient()
 	if err != nil {
 		return false, err
 	}
 
 	name := d.Id()
 
-	log.Printf("[INFO] Checking PodSecurityPolicy %s", name)
-	_, err = conn.PolicyV1beta1().PodSecurityPolicies().Get(ctx, name, metav1.GetOptions{})
+	tflog.Info(ctx, fmt.Sprintf("Checking PodSecurityPolicy %s", name))
+	_, err = conn.Resource(policy.PodSecurityPolicies).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
 
+// The PodSecurityPolicy API was removed from client-go, the policies are
+// managed with the dynamic client and converted from and to the API types.
+func createPodSecurityPolicyV1Beta1(ctx context.Context, conn dynamic.Interface, psp *policy.PodSecurityPolicy) (*policy.PodSecurityPolicy, error) {
+	psp.APIVersion = policy.SchemeGroupVersion.String()
+	psp.Kind = "PodSecurityPolicy"
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(psp)
+	if err != nil {
+		return nil, err
+	}
+	out, err := conn.Resource(policy.PodSecurityPolicies).Create(ctx, &unstructured.Unstructured{Object: obj}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return podSecurityPolicyV1Beta1FromUnstructured(out)
+}
+
+func getPodSecurityPolicyV1Beta1(ctx context.Context, conn dynamic.Interface, name string) (*policy.PodSecurityPolicy, error) {
+	out, err := conn.Resource(policy.PodSecurityPolicies).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return podSecurityPolicyV1Beta1FromUnstructured(out)
+}
+
+func podSecurityPolicyV1Beta1FromUnstructured(u *unstructured.Unstructured) (*policy.PodSecurityPolicy, error) {
+	psp := &policy.PodSecurityPolicy{}
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), psp)
+	if err != nil {
+		return nil, err
+	}
+	return psp, nil
+}
+
 func idRangeSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"min": {
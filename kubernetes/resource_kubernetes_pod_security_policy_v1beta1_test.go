@@ -11,8 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	policy "k8s.io/api/policy/v1beta1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	policy "github.com/hashicorp/terraform-provider-kubernetes/internal/policy/v1beta1"
 )
 
 func TestAccKubernetesPodSecurityPolicyV1Beta1_basic(t *testing.T) {
@@ -169,7 +168,7 @@ func TestAccKubernetesPodSecurityPolicyV1Beta1_basic(t *testing.T) {
 }
 
 func testAccCheckKubernetesPodSecurityPolicyV1Beta1Destroy(s *terraform.State) error {
-	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	conn, err := testAccProvider.Meta().(KubeClientsets).DynamicClient()
 
 	if err != nil {
 		return err
@@ -183,7 +182,7 @@ func testAccCheckKubernetesPodSecurityPolicyV1Beta1Destroy(s *terraform.State) e
 
 		name := rs.Primary.ID
 
-		resp, err := conn.PolicyV1beta1().PodSecurityPolicies().Get(ctx, name, metav1.GetOptions{})
+		resp, err := getPodSecurityPolicyV1Beta1(ctx, conn, name)
 		if err == nil {
 			if resp.Name == name {
 				return fmt.Errorf("Pod Security Policy still exists: %s", rs.Primary.ID)
@@ -201,7 +200,7 @@ func testAccCheckKubernetesPodSecurityPolicyV1Beta1Exists(n string, obj *policy.
 			return fmt.Errorf("Not found: %s", n)
 		}
 
-		conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+		conn, err := testAccProvider.Meta().(KubeClientsets).DynamicClient()
 		if err != nil {
 			return err
 		}
@@ -209,7 +208,7 @@ func testAccCheckKubernetesPodSecurityPolicyV1Beta1Exists(n string, obj *policy.
 
 		name := rs.Primary.ID
 
-		out, err := conn.PolicyV1beta1().PodSecurityPolicies().Get(ctx, name, metav1.GetOptions{})
+		out, err := getPodSecurityPolicyV1Beta1(ctx, conn, name)
 		if err != nil {
 			return err
 		}
@@ -6,9 +6,9 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -94,13 +94,13 @@ func resourceKubernetesPodV1Create(ctx context.Context, d *schema.ResourceData,
 		Spec:       *spec,
 	}
 
-	log.Printf("[INFO] Creating new pod: %#v", pod)
+	tflog.Info(ctx, fmt.Sprintf("Creating new pod: %#v", pod))
 	out, err := conn.CoreV1().Pods(metadata.Namespace).Create(ctx, &pod, metav1.CreateOptions{})
 
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new pod: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new pod: %#v", out))
 
 	d.SetId(buildId(out.ObjectMeta))
 
@@ -109,14 +109,14 @@ func resourceKubernetesPodV1Create(ctx context.Context, d *schema.ResourceData,
 		Pending: []string{string(corev1.PodPending)},
 		Timeout: d.Timeout(schema.TimeoutCreate),
 		Refresh: func() (interface{}, string, error) {
-			out, err := conn.CoreV1().Pods(metadata.Namespace).Get(ctx, metadata.Name, metav1.GetOptions{})
+			out, err := conn.CoreV1().Pods(out.Namespace).Get(ctx, out.Name, metav1.GetOptions{})
 			if err != nil {
-				log.Printf("[ERROR] Received error: %#v", err)
+				tflog.Error(ctx, fmt.Sprintf("Received error: %#v", err))
 				return out, "Error", err
 			}
 
 			statusPhase := fmt.Sprintf("%v", out.Status.Phase)
-			log.Printf("[DEBUG] Pods %s status received: %#v", out.Name, statusPhase)
+			tflog.Debug(ctx, fmt.Sprintf("Pods %s status received: %#v", out.Name, statusPhase))
 			return out, statusPhase, nil
 		},
 	}
@@ -128,7 +128,7 @@ func resourceKubernetesPodV1Create(ctx context.Context, d *schema.ResourceData,
 		}
 		return diag.Errorf("%s%s", err, stringifyEvents(lastWarnings))
 	}
-	log.Printf("[INFO] Pod %s created", out.Name)
+	tflog.Info(ctx, fmt.Sprintf("Pod %s created", out.Name))
 
 	return resourceKubernetesPodV1Read(ctx, d, meta)
 }
@@ -157,13 +157,13 @@ func resourceKubernetesPodV1Update(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
 
-	log.Printf("[INFO] Updating pod %s: %s", d.Id(), ops)
+	tflog.Info(ctx, fmt.Sprintf("Updating pod %s: %s", d.Id(), ops))
 
 	out, err := conn.CoreV1().Pods(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted updated pod: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated pod: %#v", out))
 
 	d.SetId(buildId(out.ObjectMeta))
 	return resourceKubernetesPodV1Read(ctx, d, meta)
@@ -188,13 +188,13 @@ func resourceKubernetesPodV1Read(ctx context.Context, d *schema.ResourceData, me
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading pod %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading pod %s", name))
 	pod, err := conn.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received pod: %#v", pod)
+	tflog.Info(ctx, fmt.Sprintf("Received pod: %#v", pod))
 
 	err = d.Set("metadata", flattenMetadata(pod.ObjectMeta, d, meta))
 	if err != nil {
@@ -225,7 +225,7 @@ func resourceKubernetesPodV1Delete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting pod: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting pod: %#v", name))
 	err = conn.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -234,7 +234,7 @@ func resourceKubernetesPodV1Delete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+	err = retryWithProgress(ctx, d.Timeout(schema.TimeoutDelete), fmt.Sprintf("pod %q to be deleted", d.Id()), func() *retry.RetryError {
 		out, err := conn.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
 			if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -243,15 +243,15 @@ func resourceKubernetesPodV1Delete(ctx context.Context, d *schema.ResourceData,
 			return retry.NonRetryableError(err)
 		}
 
-		log.Printf("[DEBUG] Current state of pod: %#v", out.Status.Phase)
-		e := fmt.Errorf("Pod %s still exists (%s)", name, out.Status.Phase)
+		tflog.Debug(ctx, fmt.Sprintf("Current state of pod: %#v", out.Status.Phase))
+		e := fmt.Errorf("Pod %s still exists (%s, %s)", name, out.Status.Phase, deletionProgress(out.ObjectMeta))
 		return retry.RetryableError(e)
 	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Pod %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Pod %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -268,13 +268,13 @@ func resourceKubernetesPodV1Exists(ctx context.Context, d *schema.ResourceData,
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking pod %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking pod %s", name))
 	_, err = conn.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
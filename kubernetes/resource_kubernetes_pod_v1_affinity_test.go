@@ -284,6 +284,35 @@ func TestAccKubernetesPodV1_with_pod_anti_affinity_with_preferred_during_schedul
 	})
 }
 
+func TestAccKubernetesPodV1_with_pod_anti_affinity_with_match_label_keys(t *testing.T) {
+	var conf corev1.Pod
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := busyboxImage
+	keyName := "spec.0.affinity.0.pod_anti_affinity.0.preferred_during_scheduling_ignored_during_execution.0.pod_affinity_term"
+	resourceName := "kubernetes_pod_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfClusterVersionLessThan(t, "1.31.0")
+		},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesPodV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodV1ConfigWithPodAntiAffinityWithMatchLabelKeys(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, fmt.Sprintf("%s.0.match_label_keys.#", keyName), "1"),
+					resource.TestCheckResourceAttr(resourceName, fmt.Sprintf("%s.0.match_label_keys.0", keyName), "revision"),
+					resource.TestCheckResourceAttr(resourceName, fmt.Sprintf("%s.0.mismatch_label_keys.#", keyName), "1"),
+					resource.TestCheckResourceAttr(resourceName, fmt.Sprintf("%s.0.mismatch_label_keys.0", keyName), "tenant"),
+				),
+			},
+		},
+	})
+}
+
 func testAccKubernetesPodV1ConfigWithNodeAffinityWithRequiredDuringSchedulingIgnoredDuringExecution_MatchExpressions(podName, imageName string) string {
 	return fmt.Sprintf(`resource "kubernetes_namespace_v1" "test" {
   metadata {
@@ -714,3 +743,42 @@ resource "kubernetes_pod_v1" "test" {
 }
 `, podName, imageName)
 }
+
+func testAccKubernetesPodV1ConfigWithPodAntiAffinityWithMatchLabelKeys(podName, imageName string) string {
+	return fmt.Sprintf(`resource "kubernetes_pod_v1" "test" {
+  metadata {
+    labels = {
+      app      = "pod_label"
+      revision = "1"
+      tenant   = "team-a"
+    }
+    name = %[1]q
+  }
+  spec {
+    affinity {
+      pod_anti_affinity {
+        preferred_during_scheduling_ignored_during_execution {
+          weight = 100
+          pod_affinity_term {
+            label_selector {
+              match_labels = {
+                app = "pod_label"
+              }
+            }
+            match_label_keys    = ["revision"]
+            mismatch_label_keys = ["tenant"]
+            topology_key        = "kubernetes.io/hostname"
+          }
+        }
+      }
+    }
+    container {
+      image = %[2]q
+      name  = "containername"
+      args  = ["sleep", "300"]
+    }
+    termination_grace_period_seconds = 1
+  }
+}
+`, podName, imageName)
+}
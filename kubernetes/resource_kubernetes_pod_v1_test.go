@@ -466,6 +466,39 @@ func TestAccKubernetesPodV1_with_pod_security_context_seccomp_profile(t *testing
 	})
 }
 
+func TestAccKubernetesPodV1_with_security_context_app_armor_profile(t *testing.T) {
+	var conf api.Pod
+
+	podName := acctest.RandomWithPrefix("tf-acc-test")
+	imageName := busyboxImage
+	resourceName := "kubernetes_pod_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfClusterVersionLessThan(t, "1.30.0")
+		},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesPodV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodV1ConfigWithSecurityContextAppArmorProfile(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.security_context.0.app_armor_profile.0.type", "RuntimeDefault"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.container.0.security_context.0.app_armor_profile.0.type", "Unconfined"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+		},
+	})
+}
+
 func TestAccKubernetesPodV1_with_pod_security_context_seccomp_localhost_profile(t *testing.T) {
 	var conf api.Pod
 
@@ -2052,6 +2085,38 @@ func testAccKubernetesPodV1ConfigWithSecurityContextSeccompProfileLocalhost(podN
 `, podName, imageName)
 }
 
+func testAccKubernetesPodV1ConfigWithSecurityContextAppArmorProfile(podName, imageName string) string {
+	return fmt.Sprintf(`resource "kubernetes_pod_v1" "test" {
+  metadata {
+    labels = {
+      app = "pod_label"
+    }
+
+    name = "%s"
+  }
+
+  spec {
+    automount_service_account_token = false
+    security_context {
+      app_armor_profile {
+        type = "RuntimeDefault"
+      }
+    }
+
+    container {
+      image = "%s"
+      name  = "containername"
+      security_context {
+        app_armor_profile {
+          type = "Unconfined"
+        }
+      }
+    }
+  }
+}
+`, podName, imageName)
+}
+
 func testAccKubernetesPodV1ConfigWithLivenessProbeUsingExec(podName, imageName string) string {
 	return fmt.Sprintf(`resource "kubernetes_pod_v1" "test" {
   metadata {
@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -84,12 +85,12 @@ func resourceKubernetesPriorityClassV1Create(ctx context.Context, d *schema.Reso
 		PreemptionPolicy: (*v1.PreemptionPolicy)(&preemptionPolicy),
 	}
 
-	log.Printf("[INFO] Creating new priority class: %#v", priorityClass)
+	tflog.Info(ctx, fmt.Sprintf("Creating new priority class: %#v", priorityClass))
 	out, err := conn.SchedulingV1().PriorityClasses().Create(ctx, &priorityClass, metav1.CreateOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to create priority class: %s", err)
 	}
-	log.Printf("[INFO] Submitted new priority class: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new priority class: %#v", out))
 	d.SetId(out.ObjectMeta.Name)
 
 	return resourceKubernetesPriorityClassV1Read(ctx, d, meta)
@@ -111,13 +112,13 @@ func resourceKubernetesPriorityClassV1Read(ctx context.Context, d *schema.Resour
 
 	name := d.Id()
 
-	log.Printf("[INFO] Reading priority class %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading priority class %s", name))
 	priorityClass, err := conn.SchedulingV1().PriorityClasses().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received priority class: %#v", priorityClass)
+	tflog.Info(ctx, fmt.Sprintf("Received priority class: %#v", priorityClass))
 
 	err = d.Set("metadata", flattenMetadata(priorityClass.ObjectMeta, d, meta))
 	if err != nil {
@@ -185,12 +186,12 @@ func resourceKubernetesPriorityClassV1Update(ctx context.Context, d *schema.Reso
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating priority class %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating priority class %q: %v", name, string(data)))
 	out, err := conn.SchedulingV1().PriorityClasses().Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update priority class: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated priority class: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated priority class: %#v", out))
 	d.SetId(out.ObjectMeta.Name)
 
 	return resourceKubernetesPriorityClassV1Read(ctx, d, meta)
@@ -204,7 +205,7 @@ func resourceKubernetesPriorityClassV1Delete(ctx context.Context, d *schema.Reso
 
 	name := d.Id()
 
-	log.Printf("[INFO] Deleting priority class: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting priority class: %#v", name))
 	err = conn.SchedulingV1().PriorityClasses().Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -213,7 +214,7 @@ func resourceKubernetesPriorityClassV1Delete(ctx context.Context, d *schema.Reso
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] priority class %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("priority class %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -227,13 +228,13 @@ func resourceKubernetesPriorityClassV1Exists(ctx context.Context, d *schema.Reso
 
 	name := d.Id()
 
-	log.Printf("[INFO] Checking priority class %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking priority class %s", name))
 	_, err = conn.SchedulingV1().PriorityClasses().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
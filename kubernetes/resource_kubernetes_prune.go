@@ -0,0 +1,344 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+func resourceKubernetesPrune() *schema.Resource {
+	return &schema.Resource{
+		Description:   "This resource deletes the objects of the listed kinds which match a label selector but are not in a set of manifests, like `kubectl apply --prune`. It cleans up the objects left behind when manifests are removed from a configuration. The objects to prune are found when refreshing, and deleted by the following apply, if they are still prunable. Creating or destroying the resource deletes nothing.",
+		CreateContext: resourceKubernetesPruneCreate,
+		ReadContext:   resourceKubernetesPruneRead,
+		UpdateContext: resourceKubernetesPruneUpdate,
+		DeleteContext: resourceKubernetesPruneDelete,
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			// Read lists the objects to prune, which are deleted by an update.
+			if diff.Id() == "" || len(diff.Get("prunable").([]interface{})) == 0 {
+				return nil
+			}
+			return diff.SetNew("prunable", []interface{}{})
+		},
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Type:        schema.TypeList,
+				Description: "The kinds of the objects to prune. Objects of other kinds are never deleted.",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:        schema.TypeString,
+							Description: "The apiVersion of the kind, e.g. `apps/v1`.",
+							Required:    true,
+						},
+						"kind": {
+							Type:        schema.TypeString,
+							Description: "The kind of the objects, e.g. `Deployment`.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"label_selector": {
+				Type:         schema.TypeString,
+				Description:  "A label selector the objects to prune must match, e.g. `app.kubernetes.io/managed-by=terraform,app.kubernetes.io/instance=my-app`. Objects which don't match it are never deleted.",
+				Required:     true,
+				ValidateFunc: validatePruneLabelSelector,
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "The namespace to prune the objects of. Leave empty to prune the objects of all namespaces. Ignored for cluster-scoped kinds.",
+				Optional:    true,
+			},
+			"manifests": {
+				Type:        schema.TypeList,
+				Description: "The manifests of the objects to keep, in YAML or JSON. A manifest may hold several YAML documents. Only the `apiVersion`, `kind`, `metadata.name` and `metadata.namespace` of the objects are used. Objects without a namespace are in `namespace`, or `default` when it's empty.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"prunable": {
+				Type:        schema.TypeList,
+				Description: "The objects which are deleted by the next apply, as `kubernetes_manifest` import IDs.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"pruned": {
+				Type:        schema.TypeList,
+				Description: "The objects deleted by the last apply, as `kubernetes_manifest` import IDs.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceKubernetesPruneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(fmt.Sprintf("namespace=%s,labelSelector=%s", d.Get("namespace").(string), d.Get("label_selector").(string)))
+	// The plan of the creation can't list the objects to prune, which are
+	// deleted by the following apply.
+	err := d.Set("pruned", []string{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceKubernetesPruneRead(ctx, d, meta)
+}
+
+func resourceKubernetesPruneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	objects, err := findPrunableObjects(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ids := make([]string, len(objects))
+	for i, o := range objects {
+		ids[i] = pruneObjectID(o)
+	}
+	err = d.Set("prunable", ids)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceKubernetesPruneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	objects, err := findPrunableObjects(ctx, d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Only the objects listed by the plan are deleted, the objects which
+	// became prunable since are left to the next apply.
+	planned, _ := d.GetChange("prunable")
+	pruned, err := pruneObjects(ctx, conn, expandStringSlice(planned.([]interface{})), objects)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("pruned", pruned)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKubernetesPruneRead(ctx, d, meta)
+}
+
+// pruneObjects deletes the objects whose ID is planned and returns their IDs.
+// Planned objects which are no longer prunable are left alone.
+func pruneObjects(ctx context.Context, conn dynamic.Interface, planned []string, objects []prunableObject) ([]string, error) {
+	plannedIDs := make(map[string]bool, len(planned))
+	for _, id := range planned {
+		plannedIDs[id] = true
+	}
+	pruned := make([]string, 0, len(planned))
+	for _, o := range objects {
+		id := pruneObjectID(o)
+		if !plannedIDs[id] {
+			tflog.Debug(ctx, fmt.Sprintf("Not pruning %s, it's not in the plan", id))
+			continue
+		}
+		tflog.Info(ctx, fmt.Sprintf("Pruning %s", id))
+		r := conn.Resource(o.resource).Namespace(o.object.GetNamespace())
+		err := r.Delete(ctx, o.object.GetName(), deleteOptions)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("Failed to prune %s: %s", id, err)
+		}
+		pruned = append(pruned, id)
+	}
+	return pruned, nil
+}
+
+func resourceKubernetesPruneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// prunableObject is an object to prune with the API resource it belongs to.
+type prunableObject struct {
+	resource k8sschema.GroupVersionResource
+	kind     string
+	object   unstructured.Unstructured
+}
+
+func pruneObjectID(o prunableObject) string {
+	object := metav1.ObjectMeta{
+		Name:      o.object.GetName(),
+		Namespace: o.object.GetNamespace(),
+	}
+	return buildIdWithVersionKind(object, o.resource.GroupVersion().String(), o.kind)
+}
+
+// pruneObjectKey identifies an object regardless of the version of its kind.
+func pruneObjectKey(gk k8sschema.GroupKind, namespace, name string) string {
+	return strings.Join([]string{gk.String(), namespace, name}, "/")
+}
+
+func validatePruneLabelSelector(value interface{}, key string) ([]string, []error) {
+	v := value.(string)
+	selector, err := labels.Parse(v)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid label selector: %s", key, err)}
+	}
+	if selector.Empty() {
+		return nil, []error{fmt.Errorf("%s must not be empty, to prune only the objects managed by this configuration", key)}
+	}
+	return nil, nil
+}
+
+// findPrunableObjects lists the objects of the kinds to prune which match the
+// label selector and are not in the manifests, sorted by ID. Objects owned by
+// a controller are left to their controller.
+func findPrunableObjects(ctx context.Context, d *schema.ResourceData, m interface{}) ([]prunableObject, error) {
+	conn, err := m.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	dc, err := m.(KubeClientsets).DiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	agr, err := restmapper.GetAPIGroupResources(dc)
+	if err != nil {
+		return nil, err
+	}
+	restMapper := restmapper.NewDiscoveryRESTMapper(agr)
+
+	namespace := d.Get("namespace").(string)
+	defaultNamespace := namespace
+	if defaultNamespace == "" {
+		defaultNamespace = "default"
+	}
+	refs, err := parsePruneManifests(expandStringSlice(d.Get("manifests").([]interface{})))
+	if err != nil {
+		return nil, err
+	}
+	selector := d.Get("label_selector").(string)
+
+	var prunable []prunableObject
+	for _, k := range d.Get("kind").([]interface{}) {
+		kind := k.(map[string]interface{})
+		gv, err := k8sschema.ParseGroupVersion(kind["api_version"].(string))
+		if err != nil {
+			return nil, err
+		}
+		gk := gv.WithKind(kind["kind"].(string)).GroupKind()
+		mapping, err := restMapper.RESTMapping(gk, gv.Version)
+		if err != nil {
+			return nil, err
+		}
+		namespaced := mapping.Scope.Name() == meta.RESTScopeNameNamespace
+		keep := pruneKeepSet(refs, gk, namespaced, defaultNamespace)
+
+		var r dynamic.ResourceInterface = conn.Resource(mapping.Resource)
+		if namespaced {
+			r = conn.Resource(mapping.Resource).Namespace(namespace)
+		}
+		tflog.Info(ctx, fmt.Sprintf("Listing %s matching %q to prune", mapping.Resource.Resource, selector))
+		items, _, err := listAll(ctx, metav1.ListOptions{LabelSelector: selector}, listPaging{pageSize: defaultListPageSize}, r.List, func(l *unstructured.UnstructuredList) []unstructured.Unstructured {
+			return l.Items
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Unable to list %s: %s", mapping.Resource.Resource, err)
+		}
+		for _, item := range prunableItems(items, gk, keep) {
+			prunable = append(prunable, prunableObject{resource: mapping.Resource, kind: gk.Kind, object: item})
+		}
+	}
+	sort.Slice(prunable, func(i, j int) bool { return pruneObjectID(prunable[i]) < pruneObjectID(prunable[j]) })
+	return prunable, nil
+}
+
+// pruneObjectRef is an object of the manifests to keep.
+type pruneObjectRef struct {
+	groupKind k8sschema.GroupKind
+	namespace string
+	name      string
+}
+
+// parsePruneManifests parses the objects of YAML or JSON manifests, each of
+// which may hold several YAML documents.
+func parsePruneManifests(manifests []string) ([]pruneObjectRef, error) {
+	var refs []pruneObjectRef
+	for _, manifest := range manifests {
+		decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+		for {
+			var object unstructured.Unstructured
+			err := decoder.Decode(&object.Object)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("Failed to parse manifest: %s", err)
+			}
+			if len(object.Object) == 0 {
+				continue
+			}
+			if object.GetKind() == "" || object.GetName() == "" {
+				return nil, fmt.Errorf("Manifest of object %q has no kind or name", object.GetName())
+			}
+			refs = append(refs, pruneObjectRef{
+				groupKind: object.GroupVersionKind().GroupKind(),
+				namespace: object.GetNamespace(),
+				name:      object.GetName(),
+			})
+		}
+	}
+	return refs, nil
+}
+
+// pruneKeepSet returns the keys of the objects of the manifests of a kind.
+func pruneKeepSet(refs []pruneObjectRef, gk k8sschema.GroupKind, namespaced bool, defaultNamespace string) map[string]bool {
+	keep := map[string]bool{}
+	for _, ref := range refs {
+		if ref.groupKind != gk {
+			continue
+		}
+		namespace := ""
+		if namespaced {
+			namespace = ref.namespace
+			if namespace == "" {
+				namespace = defaultNamespace
+			}
+		}
+		keep[pruneObjectKey(gk, namespace, ref.name)] = true
+	}
+	return keep
+}
+
+// prunableItems returns the objects which are neither kept nor owned by a
+// controller, or already being deleted.
+func prunableItems(items []unstructured.Unstructured, gk k8sschema.GroupKind, keep map[string]bool) []unstructured.Unstructured {
+	var out []unstructured.Unstructured
+	for _, item := range items {
+		if keep[pruneObjectKey(gk, item.GetNamespace(), item.GetName())] {
+			continue
+		}
+		if metav1.GetControllerOf(&item) != nil || item.GetDeletionTimestamp() != nil {
+			continue
+		}
+		out = append(out, item)
+	}
+	return out
+}
@@ -0,0 +1,240 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func TestParsePruneManifests(t *testing.T) {
+	manifests := []string{
+		`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+`,
+		`{"apiVersion": "rbac.authorization.k8s.io/v1", "kind": "ClusterRole", "metadata": {"name": "reader"}}`,
+	}
+	refs, err := parsePruneManifests(manifests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []pruneObjectRef{
+		{groupKind: k8sschema.GroupKind{Kind: "ConfigMap"}, name: "settings"},
+		{groupKind: k8sschema.GroupKind{Group: "apps", Kind: "Deployment"}, namespace: "shop", name: "web"},
+		{groupKind: k8sschema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}, name: "reader"},
+	}
+	if diff := cmp.Diff(expected, refs, cmp.AllowUnexported(pruneObjectRef{})); diff != "" {
+		t.Fatalf("Unexpected objects: mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := parsePruneManifests([]string{"apiVersion: v1\nkind: ConfigMap\n"}); err == nil {
+		t.Fatal("Expected an error for a manifest without a name")
+	}
+}
+
+func TestPrunableItems(t *testing.T) {
+	configMaps := k8sschema.GroupKind{Kind: "ConfigMap"}
+	refs := []pruneObjectRef{
+		{groupKind: configMaps, name: "kept"},
+		{groupKind: configMaps, namespace: "other", name: "elsewhere"},
+		{groupKind: k8sschema.GroupKind{Group: "apps", Kind: "Deployment"}, name: "removed"},
+	}
+	keep := pruneKeepSet(refs, configMaps, true, "shop")
+
+	object := func(name string) unstructured.Unstructured {
+		var u unstructured.Unstructured
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace("shop")
+		u.SetName(name)
+		return u
+	}
+	owned := object("owned")
+	controller := true
+	owned.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "v1", Kind: "Pod", Name: "p", UID: "1", Controller: &controller}})
+	deleting := object("deleting")
+	now := metav1.Now()
+	deleting.SetDeletionTimestamp(&now)
+
+	items := []unstructured.Unstructured{object("kept"), object("elsewhere"), object("removed"), owned, deleting}
+	var names []string
+	for _, item := range prunableItems(items, configMaps, keep) {
+		names = append(names, item.GetName())
+	}
+	if diff := cmp.Diff([]string{"elsewhere", "removed"}, names); diff != "" {
+		t.Fatalf("Unexpected objects to prune: mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPruneObjects(t *testing.T) {
+	gvr := k8sschema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	configMap := func(name string) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": name, "namespace": "default"},
+		}}
+	}
+	planned, stray := configMap("planned"), configMap("stray")
+	conn := fake.NewSimpleDynamicClient(runtime.NewScheme(), &planned, &stray)
+	objects := []prunableObject{
+		{resource: gvr, kind: "ConfigMap", object: planned},
+		{resource: gvr, kind: "ConfigMap", object: stray},
+	}
+
+	// The stray config map became prunable after the plan, and the config map
+	// gone isn't prunable anymore, neither is deleted.
+	pruned, err := pruneObjects(context.Background(), conn, []string{
+		"apiVersion=v1,kind=ConfigMap,name=gone,namespace=default",
+		"apiVersion=v1,kind=ConfigMap,name=planned,namespace=default",
+	}, objects)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"apiVersion=v1,kind=ConfigMap,name=planned,namespace=default"}, pruned); diff != "" {
+		t.Fatalf("Unexpected pruned objects (-want +got):\n%s", diff)
+	}
+	_, err = conn.Resource(gvr).Namespace("default").Get(context.Background(), "planned", metav1.GetOptions{})
+	if err == nil {
+		t.Fatal("Expected the planned config map to be pruned")
+	}
+	_, err = conn.Resource(gvr).Namespace("default").Get(context.Background(), "stray", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected the config map not in the plan to survive: %s", err)
+	}
+}
+
+func TestValidatePruneLabelSelector(t *testing.T) {
+	for selector, valid := range map[string]bool{
+		"app.kubernetes.io/instance=shop": true,
+		"tier in (web, api),!legacy":      true,
+		"":                                false,
+		"app=(":                           false,
+	} {
+		_, errs := validatePruneLabelSelector(selector, "label_selector")
+		if valid != (len(errs) == 0) {
+			t.Errorf("Expected selector %q to be valid: %t, got %v", selector, valid, errs)
+		}
+	}
+}
+
+func TestAccKubernetesPrune_basic(t *testing.T) {
+	namespace := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "kubernetes_prune.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPruneConfig_basic(namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "prunable.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "pruned.#", "0"),
+				),
+			},
+			{
+				// A config map which matches the label selector but isn't in the
+				// manifests is pruned by the following apply.
+				PreConfig: func() { testAccCreateKubernetesPruneConfigMap(t, namespace, "stray") },
+				Config:    testAccKubernetesPruneConfig_basic(namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "prunable.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "pruned.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "pruned.0", fmt.Sprintf("apiVersion=v1,kind=ConfigMap,name=stray,namespace=%s", namespace)),
+					testAccCheckKubernetesPruneConfigMapDeleted(namespace, "stray"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCreateKubernetesPruneConfigMap(t *testing.T, namespace, name string) {
+	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "tf-acc-test"},
+		},
+	}
+	_, err = conn.CoreV1().ConfigMaps(namespace).Create(context.Background(), cm, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testAccCheckKubernetesPruneConfigMapDeleted(namespace, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+		if err != nil {
+			return err
+		}
+		_, err = conn.CoreV1().ConfigMaps(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err == nil {
+			return fmt.Errorf("Config map %s/%s was not pruned", namespace, name)
+		}
+		return nil
+	}
+}
+
+func testAccKubernetesPruneConfig_basic(namespace string) string {
+	return fmt.Sprintf(`resource "kubernetes_namespace_v1" "test" {
+  metadata {
+    name = %[1]q
+  }
+}
+
+resource "kubernetes_config_map_v1" "test" {
+  for_each = toset(["one", "two"])
+  metadata {
+    name      = each.key
+    namespace = kubernetes_namespace_v1.test.metadata[0].name
+    labels = {
+      "app.kubernetes.io/managed-by" = "tf-acc-test"
+    }
+  }
+}
+
+resource "kubernetes_prune" "test" {
+  namespace      = kubernetes_namespace_v1.test.metadata[0].name
+  label_selector = "app.kubernetes.io/managed-by=tf-acc-test"
+  kind {
+    api_version = "v1"
+    kind        = "ConfigMap"
+  }
+  manifests = [for cm in kubernetes_config_map_v1.test : jsonencode({
+    apiVersion = "v1"
+    kind       = "ConfigMap"
+    metadata = {
+      name      = cm.metadata[0].name
+      namespace = cm.metadata[0].namespace
+    }
+  })]
+}
+`, namespace)
+}
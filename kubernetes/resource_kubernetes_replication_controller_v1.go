@@ -6,9 +6,9 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -129,7 +129,7 @@ func resourceKubernetesReplicationControllerV1Create(ctx context.Context, d *sch
 		Spec:       *spec,
 	}
 
-	log.Printf("[INFO] Creating new replication controller: %#v", rc)
+	tflog.Info(ctx, fmt.Sprintf("Creating new replication controller: %#v", rc))
 	out, err := conn.CoreV1().ReplicationControllers(metadata.Namespace).Create(ctx, &rc, metav1.CreateOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to create replication controller: %s", err)
@@ -137,21 +137,19 @@ func resourceKubernetesReplicationControllerV1Create(ctx context.Context, d *sch
 
 	d.SetId(buildId(out.ObjectMeta))
 
-	log.Printf("[DEBUG] Waiting for replication controller %s to schedule %d replicas",
-		d.Id(), *out.Spec.Replicas)
+	tflog.Debug(ctx, fmt.Sprintf("Waiting for replication controller %s to schedule %d replicas", d.Id(), *out.Spec.Replicas))
 	// 10 mins should be sufficient for scheduling ~10k replicas
-	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate),
-		waitForDesiredReplicasFunc(ctx, conn, out.GetNamespace(), out.GetName()))
-	if err != nil {
-		return diag.FromErr(err)
+	diags := waitForReplicationControllerV1Replicas(ctx, conn, out, d.Timeout(schema.TimeoutCreate))
+	if diags.HasError() {
+		return diags
 	}
 	// We could wait for all pods to actually reach Ready state
 	// but that means checking each pod status separately (which can be expensive at scale)
 	// as there's no aggregate data available from the API
 
-	log.Printf("[INFO] Submitted new replication controller: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new replication controller: %#v", out))
 
-	return resourceKubernetesReplicationControllerV1Read(ctx, d, meta)
+	return append(diags, resourceKubernetesReplicationControllerV1Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesReplicationControllerV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -173,13 +171,13 @@ func resourceKubernetesReplicationControllerV1Read(ctx context.Context, d *schem
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading replication controller %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading replication controller %s", name))
 	rc, err := conn.CoreV1().ReplicationControllers(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received replication controller: %#v", rc)
+	tflog.Info(ctx, fmt.Sprintf("Received replication controller: %#v", rc))
 
 	err = d.Set("metadata", flattenMetadata(rc.ObjectMeta, d, meta))
 	if err != nil {
@@ -227,20 +225,19 @@ func resourceKubernetesReplicationControllerV1Update(ctx context.Context, d *sch
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating replication controller %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating replication controller %q: %v", name, string(data)))
 	out, err := conn.CoreV1().ReplicationControllers(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update replication controller: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated replication controller: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated replication controller: %#v", out))
 
-	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate),
-		waitForDesiredReplicasFunc(ctx, conn, namespace, name))
-	if err != nil {
-		return diag.FromErr(err)
+	diags := waitForReplicationControllerV1Replicas(ctx, conn, out, d.Timeout(schema.TimeoutUpdate))
+	if diags.HasError() {
+		return diags
 	}
 
-	return resourceKubernetesReplicationControllerV1Read(ctx, d, meta)
+	return append(diags, resourceKubernetesReplicationControllerV1Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesReplicationControllerV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -254,7 +251,7 @@ func resourceKubernetesReplicationControllerV1Delete(ctx context.Context, d *sch
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting replication controller: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting replication controller: %#v", name))
 
 	// Drain all replicas before deleting
 	var ops PatchOperations
@@ -272,7 +269,7 @@ func resourceKubernetesReplicationControllerV1Delete(ctx context.Context, d *sch
 	}
 
 	// Wait until all replicas are gone
-	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete),
+	err = retryWithProgress(ctx, d.Timeout(schema.TimeoutDelete), fmt.Sprintf("replicas of replication controller %q to be drained", d.Id()),
 		waitForDesiredReplicasFunc(ctx, conn, namespace, name))
 	if err != nil {
 		return diag.FromErr(err)
@@ -287,8 +284,8 @@ func resourceKubernetesReplicationControllerV1Delete(ctx context.Context, d *sch
 	}
 
 	// Wait for Delete to finish. Necessary for ForceNew operations.
-	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
-		_, err := conn.CoreV1().ReplicationControllers(namespace).Get(ctx, name, metav1.GetOptions{})
+	err = retryWithProgress(ctx, d.Timeout(schema.TimeoutDelete), fmt.Sprintf("replication controller %q to be deleted", d.Id()), func() *retry.RetryError {
+		out, err := conn.CoreV1().ReplicationControllers(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
 			if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 				return nil
@@ -296,13 +293,13 @@ func resourceKubernetesReplicationControllerV1Delete(ctx context.Context, d *sch
 			return retry.NonRetryableError(err)
 		}
 
-		e := fmt.Errorf("Replication Controller (%s) still exists", d.Id())
+		e := fmt.Errorf("Replication Controller (%s) still exists (%s)", d.Id(), deletionProgress(out.ObjectMeta))
 		return retry.RetryableError(e)
 	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Replication controller %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Replication controller %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -319,17 +316,27 @@ func resourceKubernetesReplicationControllerV1Exists(ctx context.Context, d *sch
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking replication controller %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking replication controller %s", name))
 	_, err = conn.CoreV1().ReplicationControllers(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
 
+// waitForReplicationControllerV1Replicas waits until the replicas of the
+// replication controller are scheduled, reporting the warning events of the
+// replication controller and its pods along the way.
+func waitForReplicationControllerV1Replicas(ctx context.Context, conn *kubernetes.Clientset, rc *api.ReplicationController, timeout time.Duration) diag.Diagnostics {
+	events := startWaitEventReporter(ctx, conn, rc.ObjectMeta, "ReplicationController", &metav1.LabelSelector{MatchLabels: rc.Spec.Selector})
+	err := retryWithProgress(ctx, timeout, fmt.Sprintf("replicas of replication controller %q", buildId(rc.ObjectMeta)),
+		waitForDesiredReplicasFunc(ctx, conn, rc.Namespace, rc.Name))
+	return events.Diagnostics(ctx, err)
+}
+
 func waitForDesiredReplicasFunc(ctx context.Context, conn *kubernetes.Clientset, ns, name string) retry.RetryFunc {
 	return func() *retry.RetryError {
 		rc, err := conn.CoreV1().ReplicationControllers(ns).Get(ctx, name, metav1.GetOptions{})
@@ -338,8 +345,7 @@ func waitForDesiredReplicasFunc(ctx context.Context, conn *kubernetes.Clientset,
 		}
 
 		desiredReplicas := *rc.Spec.Replicas
-		log.Printf("[DEBUG] Current number of labelled replicas of %q: %d (of %d)\n",
-			rc.GetName(), rc.Status.FullyLabeledReplicas, desiredReplicas)
+		tflog.Debug(ctx, fmt.Sprintf("Current number of labelled replicas of %q: %d (of %d)", rc.GetName(), rc.Status.FullyLabeledReplicas, desiredReplicas))
 
 		if rc.Status.FullyLabeledReplicas == desiredReplicas {
 			return nil
@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	resourcev1beta1 "k8s.io/api/resource/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func resourceKubernetesResourceClaimTemplateV1Beta1() *schema.Resource {
+	docTemplate := resourcev1beta1.ResourceClaimTemplate{}.SwaggerDoc()
+	docTemplateSpec := resourcev1beta1.ResourceClaimTemplateSpec{}.SwaggerDoc()
+
+	return &schema.Resource{
+		Description:   "A resource claim template describes the resource claims created for each pod referencing it in `resource_claim`, which are deleted together with the pods. Requires the Dynamic Resource Allocation API `resource.k8s.io/v1beta1` to be enabled.",
+		CreateContext: resourceKubernetesResourceClaimTemplateV1Beta1Create,
+		ReadContext:   resourceKubernetesResourceClaimTemplateV1Beta1Read,
+		UpdateContext: resourceKubernetesResourceClaimTemplateV1Beta1Update,
+		DeleteContext: resourceKubernetesResourceClaimTemplateV1Beta1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("resource claim template", true),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: docTemplate["spec"],
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"metadata": {
+							Type:        schema.TypeList,
+							Description: docTemplateSpec["metadata"],
+							Optional:    true,
+							ForceNew:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"annotations": {
+										Type:         schema.TypeMap,
+										Description:  "An unstructured key value map copied into the annotations of the resource claims.",
+										Optional:     true,
+										ForceNew:     true,
+										Elem:         &schema.Schema{Type: schema.TypeString},
+										ValidateFunc: validateAnnotations,
+									},
+									"labels": {
+										Type:         schema.TypeMap,
+										Description:  "Map of string keys and values copied into the labels of the resource claims.",
+										Optional:     true,
+										ForceNew:     true,
+										Elem:         &schema.Schema{Type: schema.TypeString},
+										ValidateFunc: validateLabels,
+									},
+								},
+							},
+						},
+						"spec": {
+							Type:        schema.TypeList,
+							Description: docTemplateSpec["spec"],
+							Required:    true,
+							ForceNew:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: resourceClaimSpecFields(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesResourceClaimTemplateV1Beta1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := checkResourceV1Beta1Served(meta, "resourceclaimtemplates"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	template := &resourcev1beta1.ResourceClaimTemplate{
+		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:       expandResourceClaimTemplateV1Beta1Spec(d.Get("spec").([]interface{})),
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating new resource claim template: %#v", template))
+	out, err := conn.ResourceV1beta1().ResourceClaimTemplates(template.Namespace).Create(ctx, template, metav1.CreateOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to create resource claim template %q: %s", buildId(template.ObjectMeta), err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Submitted new resource claim template: %#v", out))
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesResourceClaimTemplateV1Beta1Read(ctx, d, meta)
+}
+
+func resourceKubernetesResourceClaimTemplateV1Beta1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Reading resource claim template %s", name))
+	template, err := conn.ResourceV1beta1().ResourceClaimTemplates(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
+		return diag.Errorf("Failed to read resource claim template %q: %s", d.Id(), err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Received resource claim template: %#v", template))
+
+	err = d.Set("metadata", flattenMetadata(template.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("spec", flattenResourceClaimTemplateV1Beta1Spec(template.Spec))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKubernetesResourceClaimTemplateV1Beta1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The spec of resource claim templates is immutable, only their metadata is updated.
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return diag.Errorf("Failed to marshal update operations: %s", err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating resource claim template %q: %v", name, string(data)))
+	out, err := conn.ResourceV1beta1().ResourceClaimTemplates(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to update resource claim template: %s", err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated resource claim template: %#v", out))
+
+	return resourceKubernetesResourceClaimTemplateV1Beta1Read(ctx, d, meta)
+}
+
+func resourceKubernetesResourceClaimTemplateV1Beta1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting resource claim template: %#v", name))
+	err = conn.ResourceV1beta1().ResourceClaimTemplates(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return diag.Errorf("Failed to delete resource claim template %q: %s", d.Id(), err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Resource claim template %s deleted", name))
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesResourceClaimTemplateV1Beta1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	resourceName := "kubernetes_resource_claim_template_v1beta1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfNoDynamicResourceAllocation(t)
+		},
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesResourceClaimTemplateV1Beta1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesResourceClaimTemplateV1Beta1Config_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.uid"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.metadata.0.labels.app", "inference"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.spec.0.devices.0.request.0.name", "gpu"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.spec.0.devices.0.request.0.allocation_mode", "ExactCount"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.spec.0.devices.0.request.0.count", "2"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesResourceClaimTemplateV1Beta1Destroy(s *terraform.State) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	if err != nil {
+		return err
+	}
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_resource_claim_template_v1beta1" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.ResourceV1beta1().ResourceClaimTemplates(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			return fmt.Errorf("Resource claim template still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccKubernetesResourceClaimTemplateV1Beta1Config_basic(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_resource_claim_template_v1beta1" "test" {
+  metadata {
+    name = %[1]q
+  }
+  spec {
+    metadata {
+      labels = {
+        app = "inference"
+      }
+    }
+    spec {
+      devices {
+        request {
+          name              = "gpu"
+          device_class_name = "gpu.example.com"
+          allocation_mode   = "ExactCount"
+          count             = 2
+        }
+      }
+    }
+  }
+}
+`, name)
+}
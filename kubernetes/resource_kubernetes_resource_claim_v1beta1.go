@@ -0,0 +1,258 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	resourcev1beta1 "k8s.io/api/resource/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+func resourceKubernetesResourceClaimV1Beta1() *schema.Resource {
+	docResourceClaim := resourcev1beta1.ResourceClaim{}.SwaggerDoc()
+
+	return &schema.Resource{
+		Description:   "A resource claim requests access to devices, e.g. GPUs or other accelerators, for the pods referencing it in `resource_claim`. The scheduler allocates matching devices when the first pod using the claim is scheduled. Requires the Dynamic Resource Allocation API `resource.k8s.io/v1beta1` to be enabled.",
+		CreateContext: resourceKubernetesResourceClaimV1Beta1Create,
+		ReadContext:   resourceKubernetesResourceClaimV1Beta1Read,
+		UpdateContext: resourceKubernetesResourceClaimV1Beta1Update,
+		DeleteContext: resourceKubernetesResourceClaimV1Beta1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("resource claim", true),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: docResourceClaim["spec"],
+				Required:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: resourceClaimSpecFields(),
+				},
+			},
+			"status": {
+				Type:        schema.TypeList,
+				Description: docResourceClaim["status"],
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"allocated": {
+							Type:        schema.TypeBool,
+							Description: "Whether devices have been allocated for the claim.",
+							Computed:    true,
+						},
+						"allocated_devices": {
+							Type:        schema.TypeList,
+							Description: "The devices allocated for the requests of the claim.",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"request": {
+										Type:        schema.TypeString,
+										Description: "The name of the request the device was allocated for.",
+										Computed:    true,
+									},
+									"driver": {
+										Type:        schema.TypeString,
+										Description: "The name of the driver of the device.",
+										Computed:    true,
+									},
+									"pool": {
+										Type:        schema.TypeString,
+										Description: "The name of the resource pool of the device.",
+										Computed:    true,
+									},
+									"device": {
+										Type:        schema.TypeString,
+										Description: "The name of the device in its resource pool.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+						"reserved_for": {
+							Type:        schema.TypeList,
+							Description: "The consumers, usually pods, currently allowed to use the claim.",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"api_group": {
+										Type:        schema.TypeString,
+										Description: "The API group of the consumer, empty for the core API.",
+										Computed:    true,
+									},
+									"resource": {
+										Type:        schema.TypeString,
+										Description: "The resource of the consumer, e.g. `pods`.",
+										Computed:    true,
+									},
+									"name": {
+										Type:        schema.TypeString,
+										Description: "The name of the consumer.",
+										Computed:    true,
+									},
+									"uid": {
+										Type:        schema.TypeString,
+										Description: "The UID of the consumer.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesResourceClaimV1Beta1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := checkResourceV1Beta1Served(meta, "resourceclaims"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	claim := &resourcev1beta1.ResourceClaim{
+		ObjectMeta: expandMetadata(d.Get("metadata").([]interface{})),
+		Spec:       expandResourceClaimV1Beta1Spec(d.Get("spec").([]interface{})),
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating new resource claim: %#v", claim))
+	out, err := conn.ResourceV1beta1().ResourceClaims(claim.Namespace).Create(ctx, claim, metav1.CreateOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to create resource claim %q: %s", buildId(claim.ObjectMeta), err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Submitted new resource claim: %#v", out))
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesResourceClaimV1Beta1Read(ctx, d, meta)
+}
+
+func resourceKubernetesResourceClaimV1Beta1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Reading resource claim %s", name))
+	claim, err := conn.ResourceV1beta1().ResourceClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
+		return diag.Errorf("Failed to read resource claim %q: %s", d.Id(), err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Received resource claim: %#v", claim))
+
+	err = d.Set("metadata", flattenMetadata(claim.ObjectMeta, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("spec", flattenResourceClaimV1Beta1Spec(claim.Spec))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("status", flattenResourceClaimV1Beta1Status(claim.Status))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKubernetesResourceClaimV1Beta1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The spec of resource claims is immutable, only their metadata is updated.
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return diag.Errorf("Failed to marshal update operations: %s", err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating resource claim %q: %v", name, string(data)))
+	out, err := conn.ResourceV1beta1().ResourceClaims(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to update resource claim: %s", err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated resource claim: %#v", out))
+
+	return resourceKubernetesResourceClaimV1Beta1Read(ctx, d, meta)
+}
+
+func resourceKubernetesResourceClaimV1Beta1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).MainClientset()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting resource claim: %#v", name))
+	err = conn.ResourceV1beta1().ResourceClaims(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return diag.Errorf("Failed to delete resource claim %q: %s", d.Id(), err)
+	}
+
+	// The claim is only removed once the devices allocated for it are released,
+	// which requires the pods using it to be gone.
+	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+		_, err := conn.ResourceV1beta1().ResourceClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return retry.NonRetryableError(err)
+		}
+
+		e := fmt.Errorf("Resource claim (%s) still exists", d.Id())
+		return retry.RetryableError(e)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Resource claim %s deleted", name))
+
+	d.SetId("")
+	return nil
+}
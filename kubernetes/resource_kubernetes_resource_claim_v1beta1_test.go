@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAccKubernetesResourceClaimV1Beta1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	resourceName := "kubernetes_resource_claim_v1beta1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfNoDynamicResourceAllocation(t)
+		},
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesResourceClaimV1Beta1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesResourceClaimV1Beta1Config_basic(name, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.name", name),
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.labels.version", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.uid"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.devices.0.request.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.devices.0.request.0.name", "gpu"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.devices.0.request.0.device_class_name", name),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.devices.0.request.0.allocation_mode", "ExactCount"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.devices.0.request.0.count", "1"),
+					resource.TestCheckResourceAttr(resourceName, "status.0.allocated", "false"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+			{
+				Config: testAccKubernetesResourceClaimV1Beta1Config_basic(name, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.labels.version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesResourceClaimV1Beta1Destroy(s *terraform.State) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+	if err != nil {
+		return err
+	}
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_resource_claim_v1beta1" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.ResourceV1beta1().ResourceClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			return fmt.Errorf("Resource claim still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccKubernetesResourceClaimV1Beta1Config_basic(name, version string) string {
+	return fmt.Sprintf(`resource "kubernetes_device_class_v1beta1" "test" {
+  metadata {
+    name = %[1]q
+  }
+  spec {
+    selectors {
+      cel {
+        expression = "device.driver == \"gpu.example.com\""
+      }
+    }
+  }
+}
+
+resource "kubernetes_resource_claim_v1beta1" "test" {
+  metadata {
+    name = %[1]q
+    labels = {
+      version = %[2]q
+    }
+  }
+  spec {
+    devices {
+      request {
+        name              = "gpu"
+        device_class_name = kubernetes_device_class_v1beta1.test.metadata.0.name
+      }
+    }
+  }
+}
+`, name, version)
+}
@@ -6,9 +6,9 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -123,12 +123,12 @@ func resourceKubernetesResourceQuotaV1Create(ctx context.Context, d *schema.Reso
 		ObjectMeta: metadata,
 		Spec:       *spec,
 	}
-	log.Printf("[INFO] Creating new resource quota: %#v", resQuota)
+	tflog.Info(ctx, fmt.Sprintf("Creating new resource quota: %#v", resQuota))
 	out, err := conn.CoreV1().ResourceQuotas(metadata.Namespace).Create(ctx, &resQuota, metav1.CreateOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to create resource quota: %s", err)
 	}
-	log.Printf("[INFO] Submitted new resource quota: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new resource quota: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
@@ -169,13 +169,13 @@ func resourceKubernetesResourceQuotaV1Read(ctx context.Context, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading resource quota %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading resource quota %s", name))
 	resQuota, err := conn.CoreV1().ResourceQuotas(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received resource quota: %#v", resQuota)
+	tflog.Info(ctx, fmt.Sprintf("Received resource quota: %#v", resQuota))
 
 	// This is to work around K8S bug
 	// See https://github.com/kubernetes/kubernetes/issues/44539
@@ -226,12 +226,12 @@ func resourceKubernetesResourceQuotaV1Update(ctx context.Context, d *schema.Reso
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating resource quota %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating resource quota %q: %v", name, string(data)))
 	out, err := conn.CoreV1().ResourceQuotas(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update resource quota: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated resource quota: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated resource quota: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	if waitForChangedSpec {
@@ -266,7 +266,7 @@ func resourceKubernetesResourceQuotaV1Delete(ctx context.Context, d *schema.Reso
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting resource quota: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting resource quota: %#v", name))
 	err = conn.CoreV1().ResourceQuotas(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -275,7 +275,7 @@ func resourceKubernetesResourceQuotaV1Delete(ctx context.Context, d *schema.Reso
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Resource quota %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Resource quota %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -292,13 +292,13 @@ func resourceKubernetesResourceQuotaV1Exists(ctx context.Context, d *schema.Reso
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking resource quota %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking resource quota %s", name))
 	_, err = conn.CoreV1().ResourceQuotas(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -63,13 +64,13 @@ func resourceKubernetesRoleBindingV1Create(ctx context.Context, d *schema.Resour
 		RoleRef:    expandRBACRoleRef(d.Get("role_ref").([]interface{})),
 		Subjects:   expandRBACSubjects(d.Get("subject").([]interface{})),
 	}
-	log.Printf("[INFO] Creating new RoleBinding: %#v", binding)
+	tflog.Info(ctx, fmt.Sprintf("Creating new RoleBinding: %#v", binding))
 	out, err := conn.RbacV1().RoleBindings(metadata.Namespace).Create(ctx, binding, metav1.CreateOptions{})
 
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new RoleBinding: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new RoleBinding: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesRoleBindingV1Read(ctx, d, meta)
@@ -94,28 +95,28 @@ func resourceKubernetesRoleBindingV1Read(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading RoleBinding %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading RoleBinding %s", name))
 	binding, err := conn.RbacV1().RoleBindings(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Received RoleBinding: %#v", binding)
+	tflog.Info(ctx, fmt.Sprintf("Received RoleBinding: %#v", binding))
 	err = d.Set("metadata", flattenMetadata(binding.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattenedRef := flattenRBACRoleRef(binding.RoleRef)
-	log.Printf("[DEBUG] Flattened RoleBinding roleRef: %#v", flattenedRef)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened RoleBinding roleRef: %#v", flattenedRef))
 	err = d.Set("role_ref", flattenedRef)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	flattenedSubjects := flattenRBACSubjects(binding.Subjects)
-	log.Printf("[DEBUG] Flattened RoleBinding subjects: %#v", flattenedSubjects)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened RoleBinding subjects: %#v", flattenedSubjects))
 	err = d.Set("subject", flattenedSubjects)
 	if err != nil {
 		return diag.FromErr(err)
@@ -144,12 +145,12 @@ func resourceKubernetesRoleBindingV1Update(ctx context.Context, d *schema.Resour
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating RoleBinding %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating RoleBinding %q: %v", name, string(data)))
 	out, err := conn.RbacV1().RoleBindings(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update RoleBinding: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated RoleBinding: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated RoleBinding: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesRoleBindingV1Read(ctx, d, meta)
@@ -166,7 +167,7 @@ func resourceKubernetesRoleBindingV1Delete(ctx context.Context, d *schema.Resour
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting RoleBinding: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting RoleBinding: %#v", name))
 	err = conn.RbacV1().RoleBindings(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -174,7 +175,7 @@ func resourceKubernetesRoleBindingV1Delete(ctx context.Context, d *schema.Resour
 		}
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] RoleBinding %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("RoleBinding %s deleted", name))
 
 	return nil
 }
@@ -190,13 +191,13 @@ func resourceKubernetesRoleBindingV1Exists(ctx context.Context, d *schema.Resour
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking RoleBinding %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking RoleBinding %s", name))
 	_, err = conn.RbacV1().RoleBindings(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
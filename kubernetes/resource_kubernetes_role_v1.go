@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -83,13 +84,13 @@ func resourceKubernetesRoleV1Create(ctx context.Context, d *schema.ResourceData,
 		ObjectMeta: metadata,
 		Rules:      *rules,
 	}
-	log.Printf("[INFO] Creating new role: %#v", role)
+	tflog.Info(ctx, fmt.Sprintf("Creating new role: %#v", role))
 	out, err := conn.RbacV1().Roles(metadata.Namespace).Create(ctx, &role, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitted new role: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new role: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesRoleV1Read(ctx, d, meta)
@@ -114,14 +115,14 @@ func resourceKubernetesRoleV1Read(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading role %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading role %s", name))
 	role, err := conn.RbacV1().Roles(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Received role: %#v", role)
+	tflog.Info(ctx, fmt.Sprintf("Received role: %#v", role))
 	err = d.Set("metadata", flattenMetadata(role.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
@@ -160,12 +161,12 @@ func resourceKubernetesRoleV1Update(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating role %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating role %q: %v", name, string(data)))
 	out, err := conn.RbacV1().Roles(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update role: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated role: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated role: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesRoleV1Read(ctx, d, meta)
@@ -182,7 +183,7 @@ func resourceKubernetesRoleV1Delete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting role: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting role: %#v", name))
 	err = conn.RbacV1().Roles(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -191,7 +192,7 @@ func resourceKubernetesRoleV1Delete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Role %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Role %s deleted", name))
 
 	return nil
 }
@@ -207,13 +208,13 @@ func resourceKubernetesRoleV1Exists(ctx context.Context, d *schema.ResourceData,
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking role %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking role %s", name))
 	_, err = conn.RbacV1().Roles(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
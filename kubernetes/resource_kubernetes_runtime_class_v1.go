@@ -5,9 +5,10 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -64,7 +65,7 @@ func resourceKubernetesRuntimeClassV1Create(ctx context.Context, d *schema.Resou
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] New runtime class created: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("New runtime class created: %#v", out))
 	d.SetId(out.Name)
 
 	return resourceKubernetesRuntimeClassV1Read(ctx, d, meta)
@@ -87,14 +88,14 @@ func resourceKubernetesRuntimeClassV1Read(ctx context.Context, d *schema.Resourc
 
 	name := d.Id()
 
-	log.Printf("[INFO] Reading runtime class %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading runtime class %s", name))
 	rc, err := conn.NodeV1().RuntimeClasses().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Received runtime class: %#v", rc)
+	tflog.Info(ctx, fmt.Sprintf("Received runtime class: %#v", rc))
 	err = d.Set("metadata", flattenMetadata(rc.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
@@ -123,14 +124,14 @@ func resourceKubernetesRuntimeClassV1Update(ctx context.Context, d *schema.Resou
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
 
-	log.Printf("[INFO] Updating runtime class %s: %#v", d.Id(), patch)
+	tflog.Info(ctx, fmt.Sprintf("Updating runtime class %s: %#v", d.Id(), patch))
 
 	out, err := conn.NodeV1().RuntimeClasses().Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update runtime class! API error: %s", err)
 	}
 
-	log.Printf("[INFO] Submitted updated runtime class: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated runtime class: %#v", out))
 	d.SetId(out.Name)
 
 	return resourceKubernetesRuntimeClassV1Read(ctx, d, meta)
@@ -144,7 +145,7 @@ func resourceKubernetesRuntimeClassV1Delete(ctx context.Context, d *schema.Resou
 
 	name := d.Id()
 
-	log.Printf("[INFO] Deleting runtime class: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting runtime class: %#v", name))
 	err = conn.NodeV1().RuntimeClasses().Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -152,7 +153,7 @@ func resourceKubernetesRuntimeClassV1Delete(ctx context.Context, d *schema.Resou
 		}
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] runtime class %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("runtime class %s deleted", name))
 
 	return nil
 }
@@ -165,13 +166,13 @@ func resourceKubernetesRuntimeClassV1Exists(ctx context.Context, d *schema.Resou
 
 	name := d.Id()
 
-	log.Printf("[INFO] Checking runtime class %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking runtime class %s", name))
 	_, err = conn.NodeV1().RuntimeClasses().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 
 	return true, err
@@ -6,9 +6,9 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -57,17 +57,19 @@ func resourceKubernetesSecretV1() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("secret", true),
 			"data": {
-				Type:        schema.TypeMap,
-				Description: "A map of the secret data.",
-				Optional:    true,
-				Computed:    true,
-				Sensitive:   true,
+				Type:             schema.TypeMap,
+				Description:      "A map of the secret data.",
+				Optional:         true,
+				Computed:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedValueDiff,
 			},
 			"binary_data": {
-				Type:        schema.TypeMap,
-				Optional:    true,
-				Sensitive:   true,
-				Description: "A map of the secret data in base64 encoding. Use this for binary data.",
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedValueDiff,
+				Description:      "A map of the secret data in base64 encoding. Use this for binary data.",
 			},
 			"immutable": {
 				Type:        schema.TypeBool,
@@ -87,6 +89,7 @@ func resourceKubernetesSecretV1() *schema.Resource {
 				Default:     true,
 				Description: "Terraform will wait for the service account token to be created.",
 			},
+			"state_hash_threshold": stateHashThresholdSchema("secret"),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(1 * time.Minute),
@@ -130,28 +133,28 @@ func resourceKubernetesSecretV1Create(ctx context.Context, d *schema.ResourceDat
 		secret.Immutable = ptr.To(v.(bool))
 	}
 
-	log.Printf("[INFO] Creating new secret: %#v", secret)
+	tflog.Info(ctx, fmt.Sprintf("Creating new secret: %#v", secret))
 	out, err := conn.CoreV1().Secrets(metadata.Namespace).Create(ctx, &secret, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitting new secret: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitting new secret: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	if out.Type == corev1.SecretTypeServiceAccountToken && d.Get("wait_for_service_account_token").(bool) {
-		log.Printf("[DEBUG] Waiting for secret service account token to be created")
+		tflog.Debug(ctx, "Waiting for secret service account token to be created")
 
 		err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
 			secret, err := conn.CoreV1().Secrets(out.Namespace).Get(ctx, out.Name, metav1.GetOptions{})
 			if err != nil {
-				log.Printf("[DEBUG] Received error: %#v", err)
+				tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 				return retry.NonRetryableError(err)
 			}
 
-			log.Printf("[INFO] Received secret: %#v", secret.Name)
+			tflog.Info(ctx, fmt.Sprintf("Received secret: %#v", secret.Name))
 			if _, ok := secret.Data["token"]; ok {
-				log.Println("[INFO] Secret service account token created")
+				tflog.Info(ctx, "Secret service account token created")
 				return nil
 			}
 
@@ -189,18 +192,19 @@ func resourceKubernetesSecretV1Read(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading secret %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading secret %s", name))
 	secret, err := conn.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Received secret: %#v", secret.ObjectMeta)
+	tflog.Info(ctx, fmt.Sprintf("Received secret: %#v", secret.ObjectMeta))
 	err = d.Set("metadata", flattenMetadata(secret.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	threshold := d.Get("state_hash_threshold").(int)
 	binaryDataKeys := []string{}
 	if v, ok := d.GetOk("binary_data"); ok {
 		binaryData := map[string][]byte{}
@@ -208,13 +212,13 @@ func resourceKubernetesSecretV1Read(ctx context.Context, d *schema.ResourceData,
 			binaryData[k] = secret.Data[k]
 			binaryDataKeys = append(binaryDataKeys, k)
 		}
-		d.Set("binary_data", base64EncodeByteMap(binaryData))
+		d.Set("binary_data", hashLargeValues(flattenByteMapToBase64Map(binaryData), threshold))
 	}
 
 	for _, k := range binaryDataKeys {
 		delete(secret.Data, k)
 	}
-	d.Set("data", flattenByteMapToStringMap(secret.Data))
+	d.Set("data", hashLargeValues(flattenByteMapToStringMap(secret.Data), threshold))
 	d.Set("type", secret.Type)
 	d.Set("immutable", secret.Immutable)
 
@@ -234,30 +238,32 @@ func resourceKubernetesSecretV1Update(ctx context.Context, d *schema.ResourceDat
 
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 
+	// The whole data is replaced, the values stored in the state as their
+	// hash are taken from the secret.
+	dataV := d.Get("data").(map[string]interface{})
+	binaryDataV := d.Get("binary_data").(map[string]interface{})
+	if hasHashedValues(dataV) || hasHashedValues(binaryDataV) {
+		live, err := conn.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		dataV = resolveHashedValues(dataV, flattenByteMapToStringMap(live.Data))
+		binaryDataV = resolveHashedValues(binaryDataV, flattenByteMapToBase64Map(live.Data))
+	}
+
 	newData := map[string]interface{}{}
 	updateData := false
 	if d.HasChange("data") {
-		_, new := d.GetChange("data")
-		new = base64EncodeStringMap(new.(map[string]interface{}))
-		for k, v := range new.(map[string]interface{}) {
-			newData[k] = v
-		}
 		updateData = true
-	} else if v, ok := d.GetOk("data"); ok {
-		for k, vv := range base64EncodeStringMap(v.(map[string]interface{})) {
-			newData[k] = vv
-		}
+	}
+	for k, v := range base64EncodeStringMap(dataV) {
+		newData[k] = v
 	}
 	if d.HasChange("binary_data") {
-		_, new := d.GetChange("binary_data")
-		for k, v := range new.(map[string]interface{}) {
-			newData[k] = v
-		}
 		updateData = true
-	} else if v, ok := d.GetOk("binary_data"); ok {
-		for k, vv := range v.(map[string]interface{}) {
-			newData[k] = vv
-		}
+	}
+	for k, v := range binaryDataV {
+		newData[k] = v
 	}
 
 	if updateData {
@@ -279,13 +285,13 @@ func resourceKubernetesSecretV1Update(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
 
-	log.Printf("[INFO] Updating secret %q: %v", name, data)
+	tflog.Info(ctx, fmt.Sprintf("Updating secret %q: %v", name, data))
 	out, err := conn.CoreV1().Secrets(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update secret: %s", err)
 	}
 
-	log.Printf("[INFO] Submitting updated secret: %#v", out.ObjectMeta)
+	tflog.Info(ctx, fmt.Sprintf("Submitting updated secret: %#v", out.ObjectMeta))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesSecretV1Read(ctx, d, meta)
@@ -302,7 +308,7 @@ func resourceKubernetesSecretV1Delete(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting secret: %q", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting secret: %q", name))
 	err = conn.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -311,7 +317,7 @@ func resourceKubernetesSecretV1Delete(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Secret %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Secret %s deleted", name))
 
 	d.SetId("")
 
@@ -329,13 +335,13 @@ func resourceKubernetesSecretV1Exists(ctx context.Context, d *schema.ResourceDat
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking secret %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking secret %s", name))
 	_, err = conn.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 
 	return true, err
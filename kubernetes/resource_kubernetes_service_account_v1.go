@@ -6,10 +6,10 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -73,6 +73,12 @@ func resourceKubernetesServiceAccountV1() *schema.Resource {
 				Optional:    true,
 				Default:     true,
 			},
+			"ignore_appended_secrets": {
+				Type:        schema.TypeBool,
+				Description: "Ignore image pull secrets and secrets which are appended to the service account by controllers, such as the registry credentials some platforms add to every service account. They are neither read into the state nor removed on update.",
+				Optional:    true,
+				Default:     false,
+			},
 			"default_secret_name": {
 				Type:       schema.TypeString,
 				Computed:   true,
@@ -95,12 +101,12 @@ func resourceKubernetesServiceAccountV1Create(ctx context.Context, d *schema.Res
 		ImagePullSecrets:             expandLocalObjectReferenceArray(d.Get("image_pull_secret").(*schema.Set).List()),
 		Secrets:                      expandServiceAccountSecrets(d.Get("secret").(*schema.Set).List(), ""),
 	}
-	log.Printf("[INFO] Creating new service account: %#v", svcAcc)
+	tflog.Info(ctx, fmt.Sprintf("Creating new service account: %#v", svcAcc))
 	out, err := conn.CoreV1().ServiceAccounts(metadata.Namespace).Create(ctx, &svcAcc, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new service account: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new service account: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	secret, err := getServiceAccountDefaultSecretV1(ctx, out.Name, svcAcc, d.Timeout(schema.TimeoutCreate), conn)
@@ -133,7 +139,7 @@ func getServiceAccountDefaultSecretV1(ctx context.Context, name string, config c
 		}
 
 		if len(resp.Secrets) == len(config.Secrets) {
-			log.Printf("[DEBUG] Configuration contains %d secrets, saw %d, expected %d", len(config.Secrets), len(resp.Secrets), len(config.Secrets)+1)
+			tflog.Debug(ctx, fmt.Sprintf("Configuration contains %d secrets, saw %d, expected %d", len(config.Secrets), len(resp.Secrets), len(config.Secrets)+1))
 			return retry.RetryableError(fmt.Errorf("Waiting for default secret of %q to appear", buildId(resp.ObjectMeta)))
 		}
 
@@ -184,7 +190,7 @@ func findDefaultServiceAccountV1(ctx context.Context, sa *corev1.ServiceAccount,
 
 	for _, saSecret := range sa.Secrets {
 		if !strings.HasPrefix(saSecret.Name, fmt.Sprintf("%s-token-", sa.Name)) {
-			log.Printf("[DEBUG] Skipping %s as it doesn't have the right name", saSecret.Name)
+			tflog.Debug(ctx, fmt.Sprintf("Skipping %s as it doesn't have the right name", saSecret.Name))
 			continue
 		}
 
@@ -194,21 +200,21 @@ func findDefaultServiceAccountV1(ctx context.Context, sa *corev1.ServiceAccount,
 		}
 
 		if secret.Type != corev1.SecretTypeServiceAccountToken {
-			log.Printf("[DEBUG] Skipping %s as it is of the wrong type", saSecret.Name)
+			tflog.Debug(ctx, fmt.Sprintf("Skipping %s as it is of the wrong type", saSecret.Name))
 			continue
 		}
 
 		if secret.Annotations[corev1.ServiceAccountNameKey] != sa.ObjectMeta.Name {
-			log.Printf("[DEBUG] Skipping %s as it has a different name than the service account", saSecret.Name)
+			tflog.Debug(ctx, fmt.Sprintf("Skipping %s as it has a different name than the service account", saSecret.Name))
 			continue
 		}
 
 		if secret.Annotations[corev1.ServiceAccountUIDKey] != string(sa.ObjectMeta.UID) {
-			log.Printf("[DEBUG] Skipping %s as it has a different UID than the service account", saSecret.Name)
+			tflog.Debug(ctx, fmt.Sprintf("Skipping %s as it has a different UID than the service account", saSecret.Name))
 			continue
 		}
 
-		log.Printf("[DEBUG] Found %s as a candidate for the default service account token", saSecret.Name)
+		tflog.Debug(ctx, fmt.Sprintf("Found %s as a candidate for the default service account token", saSecret.Name))
 		ds = append(ds, saSecret.Name)
 	}
 
@@ -251,6 +257,33 @@ func diffObjectReferences(origOrs []corev1.ObjectReference, ors []corev1.ObjectR
 	return diff
 }
 
+// referenceNames returns the names of the flattened object references.
+func referenceNames(refs []interface{}) map[string]bool {
+	names := make(map[string]bool, len(refs))
+	for _, r := range refs {
+		if m, ok := r.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok {
+				names[name] = true
+			}
+		}
+	}
+	return names
+}
+
+// filterReferencesByName returns the flattened object references with the given
+// names.
+func filterReferencesByName(refs []interface{}, names map[string]bool) []interface{} {
+	out := make([]interface{}, 0, len(refs))
+	for _, r := range refs {
+		if m, ok := r.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok && names[name] {
+				out = append(out, r)
+			}
+		}
+	}
+	return out
+}
+
 func resourceKubernetesServiceAccountV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	exists, err := resourceKubernetesServiceAccountV1Exists(ctx, d, meta)
 	if err != nil {
@@ -270,13 +303,13 @@ func resourceKubernetesServiceAccountV1Read(ctx context.Context, d *schema.Resou
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading service account %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading service account %s", name))
 	svcAcc, err := conn.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received service account: %#v", svcAcc)
+	tflog.Info(ctx, fmt.Sprintf("Received service account: %#v", svcAcc))
 	err = d.Set("metadata", flattenMetadata(svcAcc.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
@@ -293,15 +326,21 @@ func resourceKubernetesServiceAccountV1Read(ctx context.Context, d *schema.Resou
 			return diag.FromErr(err)
 		}
 	}
-	err = d.Set("image_pull_secret", flattenLocalObjectReferenceArray(svcAcc.ImagePullSecrets))
+	imagePullSecrets := flattenLocalObjectReferenceArray(svcAcc.ImagePullSecrets)
+	defaultSecretName := d.Get("default_secret_name").(string)
+	tflog.Debug(ctx, fmt.Sprintf("Default secret name is %q", defaultSecretName))
+	secrets := flattenServiceAccountSecrets(svcAcc.Secrets, defaultSecretName)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened secrets: %#v", secrets))
+
+	if d.Get("ignore_appended_secrets").(bool) {
+		imagePullSecrets = filterReferencesByName(imagePullSecrets, referenceNames(d.Get("image_pull_secret").(*schema.Set).List()))
+		secrets = filterReferencesByName(secrets, referenceNames(d.Get("secret").(*schema.Set).List()))
+	}
+
+	err = d.Set("image_pull_secret", imagePullSecrets)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-
-	defaultSecretName := d.Get("default_secret_name").(string)
-	log.Printf("[DEBUG] Default secret name is %q", defaultSecretName)
-	secrets := flattenServiceAccountSecrets(svcAcc.Secrets, defaultSecretName)
-	log.Printf("[DEBUG] Flattened secrets: %#v", secrets)
 	err = d.Set("secret", secrets)
 	if err != nil {
 		return diag.FromErr(err)
@@ -321,21 +360,45 @@ func resourceKubernetesServiceAccountV1Update(ctx context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 
+	// Secrets appended by controllers aren't part of the state, keep them.
+	current := &corev1.ServiceAccount{}
+	if d.Get("ignore_appended_secrets").(bool) && d.HasChanges("image_pull_secret", "secret") {
+		current, err = conn.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return diag.Errorf("Failed to read service account: %s", err)
+		}
+	}
+
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 	if d.HasChange("image_pull_secret") {
-		v := d.Get("image_pull_secret").(*schema.Set).List()
+		o, n := d.GetChange("image_pull_secret")
+		imagePullSecrets := expandLocalObjectReferenceArray(n.(*schema.Set).List())
+		managed := referenceNames(append(o.(*schema.Set).List(), n.(*schema.Set).List()...))
+		for _, r := range current.ImagePullSecrets {
+			if !managed[r.Name] {
+				imagePullSecrets = append(imagePullSecrets, r)
+			}
+		}
 		ops = append(ops, &ReplaceOperation{
 			Path:  "/imagePullSecrets",
-			Value: expandLocalObjectReferenceArray(v),
+			Value: imagePullSecrets,
 		})
 	}
 	if d.HasChange("secret") {
-		v := d.Get("secret").(*schema.Set).List()
+		o, n := d.GetChange("secret")
 		defaultSecretName := d.Get("default_secret_name").(string)
+		secrets := expandServiceAccountSecrets(n.(*schema.Set).List(), defaultSecretName)
+		managed := referenceNames(append(o.(*schema.Set).List(), n.(*schema.Set).List()...))
+		managed[defaultSecretName] = true
+		for _, r := range current.Secrets {
+			if !managed[r.Name] {
+				secrets = append(secrets, r)
+			}
+		}
 
 		ops = append(ops, &ReplaceOperation{
 			Path:  "/secrets",
-			Value: expandServiceAccountSecrets(v, defaultSecretName),
+			Value: secrets,
 		})
 	}
 	if d.HasChange("automount_service_account_token") {
@@ -349,12 +412,12 @@ func resourceKubernetesServiceAccountV1Update(ctx context.Context, d *schema.Res
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating service account %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating service account %q: %v", name, string(data)))
 	out, err := conn.CoreV1().ServiceAccounts(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update service account: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated service account: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated service account: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesServiceAccountV1Read(ctx, d, meta)
@@ -371,7 +434,7 @@ func resourceKubernetesServiceAccountV1Delete(ctx context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting service account: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting service account: %#v", name))
 	err = conn.CoreV1().ServiceAccounts(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -380,7 +443,7 @@ func resourceKubernetesServiceAccountV1Delete(ctx context.Context, d *schema.Res
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Service account %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Service account %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -397,13 +460,13 @@ func resourceKubernetesServiceAccountV1Exists(ctx context.Context, d *schema.Res
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking service account %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking service account %s", name))
 	_, err = conn.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
@@ -426,7 +489,7 @@ func resourceKubernetesServiceAccountV1ImportState(ctx context.Context, d *schem
 
 	defaultSecret, diagMsg := findDefaultServiceAccountV1(ctx, sa, conn)
 	if diagMsg.HasError() {
-		log.Print("[WARN] Failed to discover the default service account token")
+		tflog.Warn(ctx, "Failed to discover the default service account token")
 	}
 
 	err = d.Set("default_secret_name", defaultSecret)
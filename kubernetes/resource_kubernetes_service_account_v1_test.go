@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func TestAccKubernetesServiceAccountV1_basic(t *testing.T) {
@@ -145,6 +146,55 @@ func TestAccKubernetesServiceAccountV1_automount(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesServiceAccountV1_ignoreAppendedSecrets(t *testing.T) {
+	var conf corev1.ServiceAccount
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "kubernetes_service_account_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesServiceAccountV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesServiceAccountV1Config_ignoreAppendedSecrets(name, "one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceAccountV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "image_pull_secret.#", "1"),
+				),
+			},
+			{
+				// A controller appends a pull secret, which isn't planned for removal.
+				PreConfig: func() {
+					conn, err := testAccProvider.Meta().(KubeClientsets).MainClientset()
+					if err != nil {
+						t.Fatal(err)
+					}
+					patch := []byte(`[{"op":"add","path":"/imagePullSecrets/-","value":{"name":"appended"}}]`)
+					_, err = conn.CoreV1().ServiceAccounts("default").Patch(context.Background(), name, types.JSONPatchType, patch, metav1.PatchOptions{})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:   testAccKubernetesServiceAccountV1Config_ignoreAppendedSecrets(name, "one"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccKubernetesServiceAccountV1Config_ignoreAppendedSecrets(name, "two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceAccountV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "image_pull_secret.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "image_pull_secret.0.name", "two"),
+					testAccCheckServiceAccountV1ImagePullSecrets(&conf, []*regexp.Regexp{
+						regexp.MustCompile("^two$"),
+						regexp.MustCompile("^appended$"),
+					}),
+				),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesServiceAccountV1_update(t *testing.T) {
 	var conf corev1.ServiceAccount
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
@@ -591,3 +641,18 @@ resource "kubernetes_secret_v1" "four" {
 }
 `, name, name, name, name, name)
 }
+
+func testAccKubernetesServiceAccountV1Config_ignoreAppendedSecrets(name, pullSecret string) string {
+	return fmt.Sprintf(`resource "kubernetes_service_account_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  image_pull_secret {
+    name = "%s"
+  }
+
+  ignore_appended_secrets = true
+}
+`, name, pullSecret)
+}
@@ -5,8 +5,8 @@ package kubernetes
 
 import (
 	"context"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -174,7 +174,7 @@ func resourceKubernetesServiceV0() *schema.Resource {
 }
 
 func resourceKubernetesServiceStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
-	log.Println("[INFO] Found Kubernetes Service state v0; upgrading state to v1")
+	tflog.Info(ctx, "Found Kubernetes Service state v0; upgrading state to v1")
 	delete(rawState, "load_balancer_ingress")
 	// Return a nil error here to satisfy StateUpgradeFunc signature
 	return rawState, nil
@@ -6,18 +6,23 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
 )
 
 func resourceKubernetesServiceV1() *schema.Resource {
@@ -34,6 +39,25 @@ func resourceKubernetesServiceV1() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 		},
+		CustomizeDiff: func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+			if diff.Id() == "" {
+				return nil
+			}
+
+			// Secondary cluster IPs and IP families can be added or removed,
+			// the primary ones can't be changed.
+			for _, f := range []string{"spec.0.cluster_ips", "spec.0.ip_families"} {
+				if !diff.HasChange(f) {
+					continue
+				}
+				o, n := diff.GetChange(f)
+				if primaryChanged(o.([]interface{}), n.([]interface{})) {
+					diff.ForceNew(f)
+				}
+			}
+
+			return nil
+		},
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
 			{
@@ -74,12 +98,12 @@ func resourceKubernetesServiceSchemaV1() map[string]*schema.Schema {
 						),
 					},
 					"cluster_ips": {
-						Type:        schema.TypeList,
-						Description: "List of IP addresses assigned to this service, and are usually assigned randomly. If an address is specified manually and is not in use by others, it will be allocated to the service; otherwise creation of the service will fail. If this field is not specified, it will be initialized from the `clusterIP` field. If this field is specified, clients must ensure that `clusterIPs[0]` and `clusterIP` have the same value. More info: https://kubernetes.io/docs/concepts/services-networking/service/#virtual-ips-and-service-proxies",
-						Optional:    true,
-						ForceNew:    true,
-						Computed:    true,
-						MaxItems:    2,
+						Type:             schema.TypeList,
+						Description:      "List of IP addresses assigned to this service, and are usually assigned randomly. If an address is specified manually and is not in use by others, it will be allocated to the service; otherwise creation of the service will fail. If this field is not specified, it will be initialized from the `clusterIP` field. If this field is specified, clients must ensure that `clusterIPs[0]` and `clusterIP` have the same value. The secondary address can be added or removed, changing the primary one forces a new service. More info: https://kubernetes.io/docs/concepts/services-networking/service/#virtual-ips-and-service-proxies",
+						Optional:         true,
+						Computed:         true,
+						MaxItems:         2,
+						DiffSuppressFunc: suppressSecondaryIPFamilyAllocation,
 						Elem: &schema.Schema{
 							Type: schema.TypeString,
 							ValidateFunc: validation.Any(
@@ -113,11 +137,12 @@ func resourceKubernetesServiceSchemaV1() map[string]*schema.Schema {
 						}, false),
 					},
 					"ip_families": {
-						Type:        schema.TypeList,
-						Description: "IPFamilies is a list of IP families (e.g. IPv4, IPv6) assigned to this service. This field is usually assigned automatically based on cluster configuration and the ipFamilyPolicy field. If this field is specified manually, the requested family is available in the cluster, and ipFamilyPolicy allows it, it will be used; otherwise creation of the service will fail. This field is conditionally mutable: it allows for adding or removing a secondary IP family, but it does not allow changing the primary IP family of the Service.",
-						Optional:    true,
-						Computed:    true,
-						MaxItems:    2,
+						Type:             schema.TypeList,
+						Description:      "IPFamilies is a list of IP families (e.g. IPv4, IPv6) assigned to this service. This field is usually assigned automatically based on cluster configuration and the ipFamilyPolicy field. If this field is specified manually, the requested family is available in the cluster, and ipFamilyPolicy allows it, it will be used; otherwise creation of the service will fail. This field is conditionally mutable: it allows for adding or removing a secondary IP family, but it does not allow changing the primary IP family of the Service.",
+						Optional:         true,
+						Computed:         true,
+						MaxItems:         2,
+						DiffSuppressFunc: suppressSecondaryIPFamilyAllocation,
 						Elem: &schema.Schema{
 							Type: schema.TypeString,
 							ValidateFunc: validation.StringInSlice([]string{
@@ -186,11 +211,12 @@ func resourceKubernetesServiceSchemaV1() map[string]*schema.Schema {
 									Optional:    true,
 								},
 								"node_port": {
-									Type:         schema.TypeInt,
-									Description:  "The port on each node on which this service is exposed when `type` is `NodePort` or `LoadBalancer`. Usually assigned by the system. If specified, it will be allocated to the service if unused or else creation of the service will fail. Default is to auto-allocate a port if the `type` of this service requires one. More info: https://kubernetes.io/docs/concepts/services-networking/service/#type-nodeport",
-									Computed:     true,
-									Optional:     true,
-									ValidateFunc: validation.IsPortNumberOrZero,
+									Type:             schema.TypeInt,
+									Description:      "The port on each node on which this service is exposed when `type` is `NodePort` or `LoadBalancer`. Usually assigned by the system. If specified, it will be allocated to the service if unused or else creation of the service will fail. Default is to auto-allocate a port if the `type` of this service requires one. More info: https://kubernetes.io/docs/concepts/services-networking/service/#type-nodeport",
+									Computed:         true,
+									Optional:         true,
+									ValidateFunc:     validation.IsPortNumberOrZero,
+									DiffSuppressFunc: suppressAutoAssignedNodePort,
 								},
 								"port": {
 									Type:         schema.TypeInt,
@@ -297,6 +323,13 @@ func resourceKubernetesServiceSchemaV1() map[string]*schema.Schema {
 			Default:     true,
 			Description: "Terraform will wait for the load balancer to have at least 1 endpoint before considering the resource created.",
 		},
+		"wait_for_ready_endpoints": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			Description:  "Terraform will wait for the EndpointSlices of the service to contain at least this many ready endpoints before considering the resource created or updated. Defaults to 0, which disables the wait.",
+			ValidateFunc: validateNonNegativeInteger,
+		},
 		"status": {
 			Type:     schema.TypeList,
 			Computed: true,
@@ -343,27 +376,29 @@ func resourceKubernetesServiceV1Create(ctx context.Context, d *schema.ResourceDa
 		ObjectMeta: metadata,
 		Spec:       expandServiceSpec(d.Get("spec").([]interface{})),
 	}
-	log.Printf("[INFO] Creating new service: %#v", svc)
+	tflog.Info(ctx, fmt.Sprintf("Creating new service: %#v", svc))
 	out, err := conn.CoreV1().Services(metadata.Namespace).Create(ctx, &svc, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new service: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new service: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
+	var diags diag.Diagnostics
 	if out.Spec.Type == corev1.ServiceTypeLoadBalancer && d.Get("wait_for_load_balancer").(bool) {
-		log.Printf("[DEBUG] Waiting for load balancer to assign IP/hostname")
-
-		err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
-			svc, err := conn.CoreV1().Services(out.Namespace).Get(ctx, out.Name, metav1.GetOptions{})
-			if err != nil {
-				log.Printf("[DEBUG] Received error: %#v", err)
-				return retry.NonRetryableError(err)
+		tflog.Debug(ctx, "Waiting for load balancer to assign IP/hostname")
+
+		events := startWaitEventReporter(ctx, conn, out.ObjectMeta, "Service", nil)
+		lw := singleObjectListWatch[*corev1.ServiceList](ctx, conn.CoreV1().Services(out.Namespace), out.Name)
+		err = watchUntil(ctx, d.Timeout(schema.TimeoutCreate), fmt.Sprintf("load balancer of service %q", d.Id()), lw, &corev1.Service{}, func(event watch.Event) *retry.RetryError {
+			svc, ok := event.Object.(*corev1.Service)
+			if event.Type == watch.Deleted || !ok {
+				return retry.NonRetryableError(fmt.Errorf("Service %q was deleted while waiting for a load balancer", d.Id()))
 			}
 
 			lbIngress := svc.Status.LoadBalancer.Ingress
 
-			log.Printf("[INFO] Received service status: %#v", svc.Status)
+			tflog.Info(ctx, fmt.Sprintf("Received service status: %#v", svc.Status))
 			if len(lbIngress) > 0 {
 				return nil
 			}
@@ -371,16 +406,20 @@ func resourceKubernetesServiceV1Create(ctx context.Context, d *schema.ResourceDa
 			return retry.RetryableError(fmt.Errorf(
 				"Waiting for service %q to assign IP/hostname for a load balancer", d.Id()))
 		})
-		if err != nil {
-			lastWarnings, wErr := getLastWarningsForObject(ctx, conn, out.ObjectMeta, "Service", 3)
-			if wErr != nil {
-				return diag.FromErr(wErr)
-			}
-			return diag.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+		diags = events.Diagnostics(ctx, err)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if n := d.Get("wait_for_ready_endpoints").(int); n > 0 {
+		diags = append(diags, waitForServiceReadyEndpoints(ctx, conn, out, n, d.Timeout(schema.TimeoutCreate))...)
+		if diags.HasError() {
+			return diags
 		}
 	}
 
-	return resourceKubernetesServiceV1Read(ctx, d, meta)
+	return append(diags, resourceKubernetesServiceV1Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesServiceV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -402,13 +441,13 @@ func resourceKubernetesServiceV1Read(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading service %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading service %s", name))
 	svc, err := conn.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Received service: %#v", svc)
+	tflog.Info(ctx, fmt.Sprintf("Received service: %#v", svc))
 	err = d.Set("metadata", flattenMetadata(svc.ObjectMeta, d, meta))
 	if err != nil {
 		return diag.FromErr(err)
@@ -424,7 +463,7 @@ func resourceKubernetesServiceV1Read(ctx context.Context, d *schema.ResourceData
 	}
 
 	flattened := flattenServiceSpec(svc.Spec)
-	log.Printf("[DEBUG] Flattened service spec: %#v", flattened)
+	tflog.Debug(ctx, fmt.Sprintf("Flattened service spec: %#v", flattened))
 	err = d.Set("spec", flattened)
 	if err != nil {
 		return diag.FromErr(err)
@@ -457,15 +496,23 @@ func resourceKubernetesServiceV1Update(ctx context.Context, d *schema.ResourceDa
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
-	log.Printf("[INFO] Updating service %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating service %q: %v", name, string(data)))
 	out, err := conn.CoreV1().Services(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update service: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated service: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated service: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
-	return resourceKubernetesServiceV1Read(ctx, d, meta)
+	var diags diag.Diagnostics
+	if n := d.Get("wait_for_ready_endpoints").(int); n > 0 {
+		diags = waitForServiceReadyEndpoints(ctx, conn, out, n, d.Timeout(schema.TimeoutUpdate))
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceKubernetesServiceV1Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesServiceV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -479,7 +526,7 @@ func resourceKubernetesServiceV1Delete(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting service: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting service: %#v", name))
 	err = conn.CoreV1().Services(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -504,7 +551,7 @@ func resourceKubernetesServiceV1Delete(ctx context.Context, d *schema.ResourceDa
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Service %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Service %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -521,13 +568,78 @@ func resourceKubernetesServiceV1Exists(ctx context.Context, d *schema.ResourceDa
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking service %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking service %s", name))
 	_, err = conn.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
+
+// primaryChanged reports whether the first element of a list of cluster IPs or IP
+// families changed. Lists which are empty don't have a primary element yet.
+func primaryChanged(old, new []interface{}) bool {
+	if len(old) == 0 || len(new) == 0 {
+		return false
+	}
+	return old[0] != new[0]
+}
+
+// waitForServiceReadyEndpoints watches the EndpointSlices of a service until they
+// contain at least count ready endpoints, reporting the warning events of the
+// service and its pods along the way.
+func waitForServiceReadyEndpoints(ctx context.Context, conn *kubernetes.Clientset, service *corev1.Service, count int, timeout time.Duration) diag.Diagnostics {
+	svc := service.ObjectMeta
+	tflog.Info(ctx, fmt.Sprintf("Waiting for service %s/%s to have %d ready endpoints", svc.Namespace, svc.Name, count))
+
+	var pods *metav1.LabelSelector
+	if len(service.Spec.Selector) > 0 {
+		pods = &metav1.LabelSelector{MatchLabels: service.Spec.Selector}
+	}
+	events := startWaitEventReporter(ctx, conn, svc, "Service", pods)
+
+	selector := labels.Set{discoveryv1.LabelServiceName: svc.Name}.String()
+	lw := labelSelectorListWatch[*discoveryv1.EndpointSliceList](ctx, conn.DiscoveryV1().EndpointSlices(svc.Namespace), selector)
+
+	slices := make(map[string]*discoveryv1.EndpointSlice)
+	err := watchUntil(ctx, timeout, fmt.Sprintf("ready endpoints of service %q", buildId(svc)), lw, &discoveryv1.EndpointSlice{}, func(event watch.Event) *retry.RetryError {
+		if slice, ok := event.Object.(*discoveryv1.EndpointSlice); ok {
+			if event.Type == watch.Deleted {
+				delete(slices, slice.Name)
+			} else {
+				slices[slice.Name] = slice
+			}
+		}
+
+		ready := countReadyEndpoints(slices)
+		if ready >= count {
+			return nil
+		}
+		return retry.RetryableError(fmt.Errorf("Waiting for service %s/%s to have %d ready endpoints: %d ready", svc.Namespace, svc.Name, count, ready))
+	})
+	return events.Diagnostics(ctx, err)
+}
+
+// countReadyEndpoints returns the number of distinct ready endpoints across
+// EndpointSlices. Endpoints of dual-stack services appear once per address
+// family, so they are counted by the object backing them when possible.
+func countReadyEndpoints(slices map[string]*discoveryv1.EndpointSlice) int {
+	ready := make(map[string]bool)
+	for _, slice := range slices {
+		for _, e := range slice.Endpoints {
+			// A nil ready condition is to be interpreted as ready.
+			if e.Conditions.Ready != nil && !*e.Conditions.Ready {
+				continue
+			}
+			key := strings.Join(e.Addresses, ",")
+			if e.TargetRef != nil {
+				key = fmt.Sprintf("%s/%s/%s", e.TargetRef.Kind, e.TargetRef.Namespace, e.TargetRef.Name)
+			}
+			ready[key] = true
+		}
+	}
+	return len(ready)
+}
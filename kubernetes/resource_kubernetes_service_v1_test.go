@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
@@ -65,7 +66,7 @@ func TestAccKubernetesServiceV1_basic(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer"},
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer", "wait_for_ready_endpoints"},
 			},
 			{
 				Config: testAccKubernetesConfig_ignoreAnnotations() +
@@ -179,7 +180,7 @@ func TestAccKubernetesServiceV1_loadBalancer(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer"},
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer", "wait_for_ready_endpoints"},
 			},
 			{
 				Config: testAccKubernetesConfig_ignoreAnnotations() +
@@ -249,7 +250,7 @@ func TestAccKubernetesServiceV1_loadBalancer_internal_traffic_policy(t *testing.
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer"},
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer", "wait_for_ready_endpoints"},
 			},
 			{
 				Config: testAccKubernetesConfig_ignoreAnnotations() +
@@ -290,7 +291,7 @@ func TestAccKubernetesServiceV1_loadBalancer_class(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer"},
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer", "wait_for_ready_endpoints"},
 			},
 		},
 	})
@@ -321,7 +322,7 @@ func TestAccKubernetesServiceV1_loadBalancer_healthcheck(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer"},
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer", "wait_for_ready_endpoints"},
 			},
 			{
 				Config: testAccKubernetesConfig_ignoreAnnotations() +
@@ -360,7 +361,7 @@ func TestAccKubernetesServiceV1_headless(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer"},
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer", "wait_for_ready_endpoints"},
 			},
 		},
 	})
@@ -417,7 +418,7 @@ func TestAccKubernetesServiceV1_loadBalancer_annotations_aws(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer"},
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer", "wait_for_ready_endpoints"},
 			},
 			{
 				Config: testAccKubernetesConfig_ignoreAnnotations() +
@@ -525,7 +526,7 @@ func TestAccKubernetesServiceV1_nodePort(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer"},
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer", "wait_for_ready_endpoints"},
 			},
 			{
 				Config: testAccKubernetesConfig_ignoreAnnotations() +
@@ -617,7 +618,7 @@ func TestAccKubernetesServiceV1_noTargetPort(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer"},
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer", "wait_for_ready_endpoints"},
 			},
 		},
 	})
@@ -652,7 +653,7 @@ func TestAccKubernetesServiceV1_stringTargetPort(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer"},
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer", "wait_for_ready_endpoints"},
 			},
 		},
 	})
@@ -691,7 +692,7 @@ func TestAccKubernetesServiceV1_externalName(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer"},
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer", "wait_for_ready_endpoints"},
 			},
 		},
 	})
@@ -722,7 +723,7 @@ func TestAccKubernetesServiceV1_externalName_toClusterIp(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer"},
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer", "wait_for_ready_endpoints"},
 			},
 			{
 				Config: testAccKubernetesConfig_ignoreAnnotations() +
@@ -778,7 +779,7 @@ func TestAccKubernetesServiceV1_generatedName(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer"},
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer", "wait_for_ready_endpoints"},
 			},
 		},
 	})
@@ -813,12 +814,68 @@ func TestAccKubernetesServiceV1_ipFamilies(t *testing.T) {
 				ResourceName:            resourceName,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer"},
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version", "wait_for_load_balancer", "wait_for_ready_endpoints"},
 			},
 		},
 	})
 }
 
+func TestAccKubernetesServiceV1_waitForReadyEndpoints(t *testing.T) {
+	var conf corev1.Service
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "kubernetes_service_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesServiceV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesServiceV1Config_waitForReadyEndpoints(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceV1Exists(resourceName, &conf),
+					resource.TestCheckResourceAttr(resourceName, "wait_for_ready_endpoints", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestCountReadyEndpoints(t *testing.T) {
+	slices := map[string]*discoveryv1.EndpointSlice{
+		"ipv4": {
+			Endpoints: []discoveryv1.Endpoint{
+				{
+					Addresses:  []string{"10.0.0.1"},
+					Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+					TargetRef:  &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "a"},
+				},
+				{
+					Addresses:  []string{"10.0.0.2"},
+					Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(false)},
+					TargetRef:  &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "b"},
+				},
+				{
+					Addresses: []string{"10.0.0.3"},
+				},
+			},
+		},
+		"ipv6": {
+			Endpoints: []discoveryv1.Endpoint{
+				{
+					Addresses:  []string{"fd00::1"},
+					Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(true)},
+					TargetRef:  &corev1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "a"},
+				},
+			},
+		},
+	}
+	if n := countReadyEndpoints(slices); n != 2 {
+		t.Fatalf("expected 2 ready endpoints, got %d", n)
+	}
+}
+
 func testAccCheckServiceV1Ports(svc *corev1.Service, expected []corev1.ServicePort) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if len(expected) == 0 && len(svc.Spec.Ports) == 0 {
@@ -1417,3 +1474,51 @@ func testAccKubernetesServiceV1ConfigV1_ipFamilies(prefix string) string {
 }
 `, prefix)
 }
+
+func testAccKubernetesServiceV1Config_waitForReadyEndpoints(name string) string {
+	return fmt.Sprintf(`resource "kubernetes_deployment_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    replicas = 2
+    selector {
+      match_labels = {
+        app = "%s"
+      }
+    }
+    template {
+      metadata {
+        labels = {
+          app = "%s"
+        }
+      }
+      spec {
+        container {
+          name    = "test"
+          image   = "%s"
+          command = ["sleep", "infinity"]
+        }
+      }
+    }
+  }
+  wait_for_rollout = false
+}
+
+resource "kubernetes_service_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    selector = {
+      app = kubernetes_deployment_v1.test.spec.0.template.0.metadata.0.labels.app
+    }
+    port {
+      port        = 8080
+      target_port = 80
+    }
+  }
+  wait_for_ready_endpoints = 2
+}
+`, name, name, name, busyboxImage, name)
+}
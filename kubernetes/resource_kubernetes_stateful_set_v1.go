@@ -6,9 +6,9 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -19,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/kubectl/pkg/polymorphichelpers"
 )
@@ -30,6 +31,7 @@ func resourceKubernetesStatefulSetV1() *schema.Resource {
 		ReadContext:   resourceKubernetesStatefulSetV1Read,
 		UpdateContext: resourceKubernetesStatefulSetV1Update,
 		DeleteContext: resourceKubernetesStatefulSetV1Delete,
+		CustomizeDiff: customizeRolloutRestartDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -64,9 +66,11 @@ func resourceKubernetesStatefulSetSchemaV1() map[string]*schema.Schema {
 				Schema: statefulSetSpecFields(),
 			},
 		},
+		"rollout_restart_triggers": rolloutRestartTriggersSchema("stateful set"),
+		"rollout_restarted_at":     rolloutRestartedAtSchema("stateful set"),
 		"wait_for_rollout": {
 			Type:        schema.TypeBool,
-			Description: "Wait for the rollout of the stateful set to complete. Defaults to true.",
+			Description: "Wait for the rollout of the stateful set to complete. When `spec.update_strategy.rolling_update.partition` is set, the rollout is complete once the pods whose replica index is at or above the partition are updated and ready, so that the stateful set can be updated in stages. Defaults to true.",
 			Default:     true,
 			Optional:    true,
 		},
@@ -80,7 +84,7 @@ func resourceKubernetesStatefulSetV1Create(ctx context.Context, d *schema.Resour
 	}
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
-	spec, err := expandStatefulSetSpec(d.Get("spec").([]interface{}))
+	spec, err := expandStatefulSetSpec(ctx, d.Get("spec").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -88,32 +92,30 @@ func resourceKubernetesStatefulSetV1Create(ctx context.Context, d *schema.Resour
 		ObjectMeta: metadata,
 		Spec:       *spec,
 	}
-	log.Printf("[INFO] Creating new StatefulSet: %#v", statefulSet)
+	tflog.Info(ctx, fmt.Sprintf("Creating new StatefulSet: %#v", statefulSet))
 
 	out, err := conn.AppsV1().StatefulSets(metadata.Namespace).Create(ctx, &statefulSet, metav1.CreateOptions{})
 
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new StatefulSet: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new StatefulSet: %#v", out))
 
 	id := buildId(out.ObjectMeta)
 	d.SetId(id)
 
-	log.Printf("[INFO] StatefulSet %s created", id)
+	tflog.Info(ctx, fmt.Sprintf("StatefulSet %s created", id))
 
+	var diags diag.Diagnostics
 	if d.Get("wait_for_rollout").(bool) {
-		log.Printf("[INFO] Waiting for StatefulSet %s to rollout", id)
-		namespace := out.ObjectMeta.Namespace
-		name := out.ObjectMeta.Name
-		err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutCreate),
-			retryUntilStatefulSetRolloutComplete(ctx, conn, namespace, name))
-		if err != nil {
-			return diag.FromErr(err)
+		tflog.Info(ctx, fmt.Sprintf("Waiting for StatefulSet %s to rollout", id))
+		diags = waitForStatefulSetV1Rollout(ctx, conn, sharedWatchesOf(meta), out, d.Timeout(schema.TimeoutCreate))
+		if diags.HasError() {
+			return diags
 		}
 	}
 
-	return resourceKubernetesStatefulSetV1Read(ctx, d, meta)
+	return append(diags, resourceKubernetesStatefulSetV1Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesStatefulSetV1Exists(ctx context.Context, d *schema.ResourceData, meta interface{}) (bool, error) {
@@ -127,13 +129,13 @@ func resourceKubernetesStatefulSetV1Exists(ctx context.Context, d *schema.Resour
 		return false, err
 	}
 
-	log.Printf("[INFO] Checking StatefulSet %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking StatefulSet %s", name))
 	_, err = conn.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
@@ -157,23 +159,27 @@ func resourceKubernetesStatefulSetV1Read(ctx context.Context, d *schema.Resource
 	if err != nil {
 		return diag.Errorf("Error parsing resource ID: %#v", err)
 	}
-	log.Printf("[INFO] Reading stateful set %s", id)
+	tflog.Info(ctx, fmt.Sprintf("Reading stateful set %s", id))
 	statefulSet, err := conn.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		switch {
 		case errors.IsNotFound(err):
-			log.Printf("[DEBUG] Stateful Set %q was not found in Namespace %q - removing from state!", namespace, name)
+			tflog.Debug(ctx, fmt.Sprintf("Stateful Set %q was not found in Namespace %q - removing from state!", namespace, name))
 			d.SetId("")
 			return nil
 		default:
-			log.Printf("[DEBUG] Error reading stateful set: %#v", err)
+			tflog.Debug(ctx, fmt.Sprintf("Error reading stateful set: %#v", err))
 			return diag.FromErr(err)
 		}
 	}
-	log.Printf("[INFO] Received stateful set: %#v", statefulSet)
+	tflog.Info(ctx, fmt.Sprintf("Received stateful set: %#v", statefulSet))
 	if d.Set("metadata", flattenMetadata(statefulSet.ObjectMeta, d, meta)) != nil {
 		return diag.Errorf("Error setting `metadata`: %+v", err)
 	}
+	err = d.Set("rollout_restarted_at", flattenRolloutRestartedAt(&statefulSet.Spec.Template, d))
+	if err != nil {
+		return diag.Errorf("Error setting `rollout_restarted_at`: %+v", err)
+	}
 	sss, err := flattenStatefulSetSpec(statefulSet.Spec, d, meta)
 	if err != nil {
 		return diag.Errorf("Error flattening `spec`: %+v", err)
@@ -197,9 +203,9 @@ func resourceKubernetesStatefulSetV1Update(ctx context.Context, d *schema.Resour
 	}
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 
-	if d.HasChange("spec") {
-		log.Println("[TRACE] StatefulSet.Spec has changes")
-		specPatch, err := patchStatefulSetSpec(d)
+	if d.HasChange("spec") || d.HasChange("rollout_restart_triggers") {
+		tflog.Trace(ctx, "StatefulSet.Spec has changes")
+		specPatch, err := patchStatefulSetSpec(ctx, d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -210,24 +216,23 @@ func resourceKubernetesStatefulSetV1Update(ctx context.Context, d *schema.Resour
 	if err != nil {
 		return diag.Errorf("Failed to marshal update operations for StatefulSet: %s", err)
 	}
-	log.Printf("[INFO] Updating StatefulSet %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating StatefulSet %q: %v", name, string(data)))
 	out, err := conn.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update StatefulSet: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated StatefulSet: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated StatefulSet: %#v", out))
 
+	var diags diag.Diagnostics
 	if d.Get("wait_for_rollout").(bool) {
-		log.Printf("[INFO] Waiting for StatefulSet %s to rollout", d.Id())
-		err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutUpdate),
-			retryUntilStatefulSetRolloutComplete(ctx, conn, namespace, name))
-		if err != nil {
-			return diag.FromErr(err)
+		tflog.Info(ctx, fmt.Sprintf("Waiting for StatefulSet %s to rollout", d.Id()))
+		diags = waitForStatefulSetV1Rollout(ctx, conn, sharedWatchesOf(meta), out, d.Timeout(schema.TimeoutUpdate))
+		if diags.HasError() {
+			return diags
 		}
-		return diag.Diagnostics{}
 	}
 
-	return resourceKubernetesStatefulSetV1Read(ctx, d, meta)
+	return append(diags, resourceKubernetesStatefulSetV1Read(ctx, d, meta)...)
 }
 
 func resourceKubernetesStatefulSetV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -240,7 +245,7 @@ func resourceKubernetesStatefulSetV1Delete(ctx context.Context, d *schema.Resour
 	if err != nil {
 		return diag.Errorf("Error parsing resource ID: %#v", err)
 	}
-	log.Printf("[INFO] Deleting StatefulSet: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting StatefulSet: %#v", name))
 	err = conn.AppsV1().StatefulSets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -248,7 +253,7 @@ func resourceKubernetesStatefulSetV1Delete(ctx context.Context, d *schema.Resour
 		}
 		return diag.FromErr(err)
 	}
-	err = retry.RetryContext(ctx, d.Timeout(schema.TimeoutDelete), func() *retry.RetryError {
+	err = retryWithProgress(ctx, d.Timeout(schema.TimeoutDelete), fmt.Sprintf("StatefulSet %q to be deleted", d.Id()), func() *retry.RetryError {
 		out, err := conn.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
 			switch {
@@ -259,62 +264,101 @@ func resourceKubernetesStatefulSetV1Delete(ctx context.Context, d *schema.Resour
 			}
 		}
 
-		log.Printf("[DEBUG] Current state of StatefulSet: %#v", out.Status.Conditions)
-		e := fmt.Errorf("StatefulSet %s still exists %#v", name, out.Status.Conditions)
+		tflog.Debug(ctx, fmt.Sprintf("Current state of StatefulSet: %#v", out.Status.Conditions))
+		e := fmt.Errorf("StatefulSet %s still exists (%s)", name, deletionProgress(out.ObjectMeta))
 		return retry.RetryableError(e)
 	})
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] StatefulSet %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("StatefulSet %s deleted", name))
 
 	return nil
 }
 
-// retryUntilStatefulSetRolloutComplete checks if a given job finished its execution and is either in 'Complete' or 'Failed' state.
-func retryUntilStatefulSetRolloutComplete(ctx context.Context, conn *kubernetes.Clientset, ns, name string) retry.RetryFunc {
-	return func() *retry.RetryError {
-		res, err := conn.AppsV1().StatefulSets(ns).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return retry.NonRetryableError(err)
+// waitForStatefulSetV1Rollout waits until the rollout of the StatefulSet has
+// finished, reporting the warning events of the StatefulSet and its pods along the way.
+func waitForStatefulSetV1Rollout(ctx context.Context, conn *kubernetes.Clientset, watches *sharedWatches, sts *appsv1.StatefulSet, timeout time.Duration) diag.Diagnostics {
+	ns, name := sts.Namespace, sts.Name
+	events := startWaitEventReporter(ctx, conn, sts.ObjectMeta, "StatefulSet", sts.Spec.Selector)
+	err := watchObjectUntil(ctx, watches, timeout, fmt.Sprintf("rollout of StatefulSet %q", buildId(sts.ObjectMeta)), conn.AppsV1().StatefulSets(ns), ns, name, &appsv1.StatefulSet{}, func(event watch.Event) *retry.RetryError {
+		res, ok := event.Object.(*appsv1.StatefulSet)
+		if event.Type == watch.Deleted || !ok {
+			return retry.NonRetryableError(fmt.Errorf("StatefulSet %s/%s was deleted while waiting for rollout", ns, name))
 		}
+		return statefulSetV1RolloutStatus(res)
+	})
+	return events.Diagnostics(ctx, err)
+}
 
-		if res.Status.ReadyReplicas != *res.Spec.Replicas {
-			return retry.RetryableError(fmt.Errorf("StatefulSet %s/%s is not finished rolling out", ns, name))
-		}
+// statefulSetV1RolloutStatus checks whether the rollout of a StatefulSet has finished.
+func statefulSetV1RolloutStatus(res *appsv1.StatefulSet) *retry.RetryError {
+	ns, name := res.Namespace, res.Name
 
-		// NOTE: This is what kubectl uses to determine if a rollout is done.
-		// We are using this here because the logic for determining if a StatefulSet
-		// is done is gnarly and we don't want to duplicate it in the provider.
-		gvk := appsv1.SchemeGroupVersion.WithKind("StatefulSet")
-		gk := gvk.GroupKind()
-		statusViewer, err := polymorphichelpers.StatusViewerFor(gk)
-		if err != nil {
-			return retry.NonRetryableError(err)
-		}
+	if res.Status.ReadyReplicas != *res.Spec.Replicas {
+		return retry.RetryableError(fmt.Errorf("StatefulSet %s/%s is not finished rolling out: %d/%d replicas ready", ns, name, res.Status.ReadyReplicas, *res.Spec.Replicas))
+	}
 
-		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(res)
-		if err != nil {
-			return retry.NonRetryableError(err)
+	if updated, partition, ok := statefulSetV1PartitionedReplicas(res); ok {
+		if res.Status.ObservedGeneration < res.Generation {
+			return retry.RetryableError(fmt.Errorf("StatefulSet %s/%s is not finished rolling out: waiting for the spec update to be observed", ns, name))
 		}
+		if res.Status.UpdatedReplicas < updated {
+			return retry.RetryableError(fmt.Errorf("StatefulSet %s/%s is not finished rolling out: %d/%d replicas at or above partition %d updated", ns, name, res.Status.UpdatedReplicas, updated, partition))
+		}
+		return nil
+	}
 
-		// NOTE: For some reason, the Kind and apiVersion get lost when converting to unstructured.
-		obj["apiVersion"] = gvk.GroupVersion().String()
-		obj["kind"] = gvk.Kind
-		u := unstructured.Unstructured{Object: obj}
+	// NOTE: This is what kubectl uses to determine if a rollout is done.
+	// We are using this here because the logic for determining if a StatefulSet
+	// is done is gnarly and we don't want to duplicate it in the provider.
+	gvk := appsv1.SchemeGroupVersion.WithKind("StatefulSet")
+	gk := gvk.GroupKind()
+	statusViewer, err := polymorphichelpers.StatusViewerFor(gk)
+	if err != nil {
+		return retry.NonRetryableError(err)
+	}
 
-		// NOTE: the revision parameter of the Status function below is not actually used.
-		// for StatefulSet so it is set to 0 here
-		_, done, err := statusViewer.Status(&u, 0)
-		if err != nil {
-			return retry.NonRetryableError(err)
-		}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(res)
+	if err != nil {
+		return retry.NonRetryableError(err)
+	}
 
-		if done {
-			return nil
-		}
+	// NOTE: For some reason, the Kind and apiVersion get lost when converting to unstructured.
+	obj["apiVersion"] = gvk.GroupVersion().String()
+	obj["kind"] = gvk.Kind
+	u := unstructured.Unstructured{Object: obj}
 
-		return retry.RetryableError(fmt.Errorf("StatefulSet %s/%s is not finished rolling out", ns, name))
+	// NOTE: the revision parameter of the Status function below is not actually used.
+	// for StatefulSet so it is set to 0 here
+	_, done, err := statusViewer.Status(&u, 0)
+	if err != nil {
+		return retry.NonRetryableError(err)
+	}
+
+	if done {
+		return nil
+	}
+
+	return retry.RetryableError(fmt.Errorf("StatefulSet %s/%s is not finished rolling out: %d/%d replicas updated", ns, name, res.Status.UpdatedReplicas, *res.Spec.Replicas))
+}
+
+// statefulSetV1PartitionedReplicas returns the number of replicas of a
+// StatefulSet whose rolling update is partitioned, and its partition. Only the
+// pods whose replica index is at or above the partition are updated, so that
+// the rollout is complete once they are, while the others keep their revision.
+// The controller compares the partition to the index of the replicas, which
+// starts at 0 whatever spec.ordinals.start is.
+func statefulSetV1PartitionedReplicas(sts *appsv1.StatefulSet) (int32, int32, bool) {
+	strategy := sts.Spec.UpdateStrategy
+	if strategy.Type != appsv1.RollingUpdateStatefulSetStrategyType || strategy.RollingUpdate == nil || strategy.RollingUpdate.Partition == nil || sts.Spec.Replicas == nil {
+		return 0, 0, false
+	}
+	partition := *strategy.RollingUpdate.Partition
+	if partition <= 0 {
+		return 0, 0, false
 	}
+	replicas := *sts.Spec.Replicas
+	return replicas - min(partition, replicas), partition, true
 }
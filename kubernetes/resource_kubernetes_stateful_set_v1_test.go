@@ -15,8 +15,68 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
+func TestStatefulSetV1RolloutStatus(t *testing.T) {
+	statefulSet := func(partition, start, updated int32) *appsv1.StatefulSet {
+		sts := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop", Generation: 2},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To(int32(5)),
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+					Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To(partition)},
+				},
+			},
+			Status: appsv1.StatefulSetStatus{
+				ObservedGeneration: 2,
+				ReadyReplicas:      5,
+				UpdatedReplicas:    updated,
+				CurrentRevision:    "db-1",
+				UpdateRevision:     "db-2",
+			},
+		}
+		if start > 0 {
+			sts.Spec.Ordinals = &appsv1.StatefulSetOrdinals{Start: start}
+		}
+		return sts
+	}
+
+	cases := []struct {
+		name string
+		sts  *appsv1.StatefulSet
+		done bool
+	}{
+		{"not partitioned", statefulSet(0, 0, 3), false},
+		{"pods above the partition updating", statefulSet(3, 0, 1), false},
+		{"pods above the partition updated", statefulSet(3, 0, 2), true},
+		{"partition above the replicas", statefulSet(5, 0, 0), true},
+		{"ordinals starting below the partition", statefulSet(3, 1, 1), false},
+		{"ordinals starting below the partition updated", statefulSet(3, 1, 2), true},
+		{"ordinals starting above the partition", statefulSet(3, 4, 1), false},
+		{"ordinals starting above the partition updated", statefulSet(3, 4, 2), true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := statefulSetV1RolloutStatus(tc.sts); (err == nil) != tc.done {
+				t.Fatalf("Expected the rollout to be done: %t, got: %v", tc.done, err)
+			}
+		})
+	}
+
+	sts := statefulSet(3, 0, 2)
+	sts.Status.ReadyReplicas = 4
+	if err := statefulSetV1RolloutStatus(sts); err == nil {
+		t.Fatal("Expected the rollout not to be done while a replica isn't ready")
+	}
+	sts = statefulSet(3, 0, 2)
+	sts.Generation = 3
+	if err := statefulSetV1RolloutStatus(sts); err == nil {
+		t.Fatal("Expected the rollout not to be done before the spec update is observed")
+	}
+}
+
 func TestAccKubernetesStatefulSetV1_minimal(t *testing.T) {
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
 	resourceName := "kubernetes_stateful_set_v1.test"
@@ -354,6 +414,88 @@ func TestAccKubernetesStatefulSetV1_waitForRollout(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesStatefulSetV1_ordinals(t *testing.T) {
+	var conf1, conf2 appsv1.StatefulSet
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "kubernetes_stateful_set_v1.test"
+	imageName := busyboxImage
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfClusterVersionLessThan(t, "1.27.0")
+		},
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesStatefulSetV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesStatefulSetV1ConfigOrdinals(name, imageName, 0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesStatefulSetV1Exists(resourceName, &conf1),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.ordinals.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.ordinals.0.start", "0"),
+				),
+			},
+			{
+				Config: testAccKubernetesStatefulSetV1ConfigOrdinals(name, imageName, 3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesStatefulSetV1Exists(resourceName, &conf2),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.ordinals.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.ordinals.0.start", "3"),
+					testAccCheckKubernetesStatefulSetForceNew(&conf1, &conf2, false),
+				),
+			},
+			{
+				Config: testAccKubernetesStatefulSetV1ConfigMinimal(name, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesStatefulSetV1Exists(resourceName, &conf2),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.ordinals.#", "0"),
+					testAccCheckKubernetesStatefulSetForceNew(&conf1, &conf2, false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesStatefulSetV1_waitForPartitionedRollout(t *testing.T) {
+	var conf1, conf2 appsv1.StatefulSet
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	resourceName := "kubernetes_stateful_set_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t); skipIfRunningInEks(t) },
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesStatefulSetV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesStatefulSetV1ConfigWaitForPartitionedRollout(name, busyboxImage, "300"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesStatefulSetV1Exists(resourceName, &conf1),
+				),
+			},
+			{
+				Config: testAccKubernetesStatefulSetV1ConfigWaitForPartitionedRollout(name, busyboxImage, "600"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesStatefulSetV1Exists(resourceName, &conf2),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.update_strategy.0.rolling_update.0.partition", "2"),
+					testAccCheckKubernetesStatefulSetForceNew(&conf1, &conf2, false),
+					func(s *terraform.State) error {
+						// Only the pod above the partition is updated.
+						if conf2.Status.UpdatedReplicas != 1 || conf2.Status.ReadyReplicas != 3 {
+							return fmt.Errorf("Expected 1 updated and 3 ready replicas, got %d updated and %d ready", conf2.Status.UpdatedReplicas, conf2.Status.ReadyReplicas)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesStatefulSetV1_minimalWithTemplateNamespace(t *testing.T) {
 	var conf1, conf2 appsv1.StatefulSet
 
@@ -1402,6 +1544,86 @@ func testAccKubernetesStatefulSetV1ConfigUpdatePersistentVolumeClaimRetentionPol
 `, name, imageName)
 }
 
+func testAccKubernetesStatefulSetV1ConfigOrdinals(name, imageName string, start int) string {
+	return fmt.Sprintf(`resource "kubernetes_stateful_set_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    ordinals {
+      start = %d
+    }
+    selector {
+      match_labels = {
+        app = "ss-test"
+      }
+    }
+    service_name = "ss-test-service"
+    template {
+      metadata {
+        labels = {
+          app = "ss-test"
+        }
+      }
+      spec {
+        container {
+          name    = "ss-test"
+          image   = "%s"
+          command = ["sleep", "300"]
+        }
+        termination_grace_period_seconds = 1
+      }
+    }
+  }
+}
+`, name, start, imageName)
+}
+
+func testAccKubernetesStatefulSetV1ConfigWaitForPartitionedRollout(name, imageName, sleep string) string {
+	return fmt.Sprintf(`resource "kubernetes_stateful_set_v1" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    replicas = 3
+
+    selector {
+      match_labels = {
+        app = "ss-test"
+      }
+    }
+
+    update_strategy {
+      type = "RollingUpdate"
+      rolling_update {
+        partition = 2
+      }
+    }
+
+    service_name = "ss-test-service"
+
+    template {
+      metadata {
+        labels = {
+          app = "ss-test"
+        }
+      }
+
+      spec {
+        container {
+          name    = "ss-test"
+          image   = "%s"
+          command = ["sleep", "%s"]
+        }
+        termination_grace_period_seconds = 1
+      }
+    }
+  }
+}
+`, name, imageName, sleep)
+}
+
 func testAccKubernetesStatefulSetV1ConfigMinimalWithTemplateNamespace(name, imageName string) string {
 	return fmt.Sprintf(`resource "kubernetes_stateful_set_v1" "test" {
   metadata {
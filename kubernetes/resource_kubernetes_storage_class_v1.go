@@ -6,8 +6,8 @@ package kubernetes
 import (
 	"context"
 	"fmt"
-	"log"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -145,12 +145,12 @@ func resourceKubernetesStorageClassV1Create(ctx context.Context, d *schema.Resou
 		storageClass.AllowedTopologies = expandStorageClassAllowedTopologies(v.([]interface{}))
 	}
 
-	log.Printf("[INFO] Creating new storage class: %#v", storageClass)
+	tflog.Info(ctx, fmt.Sprintf("Creating new storage class: %#v", storageClass))
 	out, err := conn.StorageV1().StorageClasses().Create(ctx, &storageClass, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Submitted new storage class: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new storage class: %#v", out))
 	d.SetId(out.Name)
 
 	return resourceKubernetesStorageClassV1Read(ctx, d, meta)
@@ -173,14 +173,14 @@ func resourceKubernetesStorageClassV1Read(ctx context.Context, d *schema.Resourc
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Reading storage class %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Reading storage class %s", name))
 	storageClass, err := conn.StorageV1().StorageClasses().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Received storage class: %#v", storageClass)
+	tflog.Info(ctx, fmt.Sprintf("Received storage class: %#v", storageClass))
 
 	err = d.Set("metadata", flattenMetadata(storageClass.ObjectMeta, d, meta))
 	if err != nil {
@@ -218,12 +218,12 @@ func resourceKubernetesStorageClassV1Update(ctx context.Context, d *schema.Resou
 		return diag.Errorf("Failed to marshal update operations: %s", err)
 	}
 
-	log.Printf("[INFO] Updating storage class %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating storage class %q: %v", name, string(data)))
 	out, err := conn.StorageV1().StorageClasses().Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
 	if err != nil {
 		return diag.Errorf("Failed to update storage class: %s", err)
 	}
-	log.Printf("[INFO] Submitted updated storage class: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated storage class: %#v", out))
 	d.SetId(out.ObjectMeta.Name)
 
 	return resourceKubernetesStorageClassV1Read(ctx, d, meta)
@@ -236,7 +236,7 @@ func resourceKubernetesStorageClassV1Delete(ctx context.Context, d *schema.Resou
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Deleting storage class: %#v", name)
+	tflog.Info(ctx, fmt.Sprintf("Deleting storage class: %#v", name))
 	err = conn.StorageV1().StorageClasses().Delete(ctx, name, metav1.DeleteOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
@@ -260,7 +260,7 @@ func resourceKubernetesStorageClassV1Delete(ctx context.Context, d *schema.Resou
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Printf("[INFO] Storage class %s deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("Storage class %s deleted", name))
 
 	d.SetId("")
 	return nil
@@ -273,13 +273,13 @@ func resourceKubernetesStorageClassV1Exists(ctx context.Context, d *schema.Resou
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Checking storage class %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking storage class %s", name))
 	_, err = conn.StorageV1().StorageClasses().Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && errors.IsNotFound(statusErr) {
 			return false, nil
 		}
-		log.Printf("[DEBUG] Received error: %#v", err)
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
 	}
 	return true, err
 }
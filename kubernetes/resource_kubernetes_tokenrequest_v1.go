@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	authv1 "k8s.io/api/authentication/v1"
@@ -59,7 +60,7 @@ func resourceKubernetesTokenRequestV1Create(ctx context.Context, d *schema.Resou
 		Spec:       *spec,
 	}
 
-	log.Printf("[INFO] Creating new TokenRequest: %#v", request)
+	tflog.Info(ctx, fmt.Sprintf("Creating new TokenRequest: %#v", request))
 	out, err := conn.CoreV1().ServiceAccounts(metadata.Namespace).CreateToken(ctx, saName, &request, metav1.CreateOptions{})
 	if err != nil {
 		return diag.FromErr(err)
@@ -71,7 +72,7 @@ func resourceKubernetesTokenRequestV1Create(ctx context.Context, d *schema.Resou
 	}
 	d.Set("spec", s)
 
-	log.Printf("[INFO] Submitted new TokenRequest: %#v", out)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new TokenRequest: %#v", out))
 	d.SetId(buildId(out.ObjectMeta))
 
 	return resourceKubernetesTokenRequestV1Read(ctx, d, meta)
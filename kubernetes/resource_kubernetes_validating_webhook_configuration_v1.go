@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -144,11 +145,11 @@ func resourceKubernetesValidatingWebhookConfigurationV1Create(ctx context.Contex
 		Webhooks:   expandValidatingWebhooks(d.Get("webhook").([]interface{})),
 	}
 
-	log.Printf("[INFO] Creating new ValidatingWebhookConfiguration: %#v", cfg)
+	tflog.Info(ctx, fmt.Sprintf("Creating new ValidatingWebhookConfiguration: %#v", cfg))
 
 	res := &admissionregistrationv1.ValidatingWebhookConfiguration{}
 
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -166,7 +167,7 @@ func resourceKubernetesValidatingWebhookConfigurationV1Create(ctx context.Contex
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitted new ValidatingWebhookConfiguration: %#v", res)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new ValidatingWebhookConfiguration: %#v", res))
 
 	d.SetId(res.Name)
 
@@ -191,8 +192,8 @@ func resourceKubernetesValidatingWebhookConfigurationV1Read(ctx context.Context,
 
 	cfg := &admissionregistrationv1.ValidatingWebhookConfiguration{}
 
-	log.Printf("[INFO] Reading ValidatingWebhookConfiguration %s", name)
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	tflog.Info(ctx, fmt.Sprintf("Reading ValidatingWebhookConfiguration %s", name))
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -212,7 +213,7 @@ func resourceKubernetesValidatingWebhookConfigurationV1Read(ctx context.Context,
 		return nil
 	}
 
-	log.Printf("[DEBUG] Setting webhook to: %#v", cfg.Webhooks)
+	tflog.Debug(ctx, fmt.Sprintf("Setting webhook to: %#v", cfg.Webhooks))
 
 	err = d.Set("webhook", flattenValidatingWebhooks(cfg.Webhooks))
 	if err != nil {
@@ -237,7 +238,7 @@ func resourceKubernetesValidatingWebhookConfigurationV1Update(ctx context.Contex
 
 		patch := expandValidatingWebhooks(d.Get("webhook").([]interface{}))
 
-		useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+		useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -258,11 +259,11 @@ func resourceKubernetesValidatingWebhookConfigurationV1Update(ctx context.Contex
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Updating ValidatingWebhookCoßnfiguration %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating ValidatingWebhookCoßnfiguration %q: %v", name, string(data)))
 
 	res := &admissionregistrationv1.ValidatingWebhookConfiguration{}
 
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -277,7 +278,7 @@ func resourceKubernetesValidatingWebhookConfigurationV1Update(ctx context.Contex
 		return diag.Errorf("Failed to update ValidatingWebhookConfiguration: %s", err)
 	}
 
-	log.Printf("[INFO] Submitted updated ValidatingWebhookConfiguration: %#v", res)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated ValidatingWebhookConfiguration: %#v", res))
 
 	return resourceKubernetesValidatingWebhookConfigurationV1Read(ctx, d, meta)
 }
@@ -290,8 +291,8 @@ func resourceKubernetesValidatingWebhookConfigurationV1Delete(ctx context.Contex
 
 	name := d.Id()
 
-	log.Printf("[INFO] Deleting ValidatingWebhookConfiguration: %#v", name)
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	tflog.Info(ctx, fmt.Sprintf("Deleting ValidatingWebhookConfiguration: %#v", name))
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -307,7 +308,7 @@ func resourceKubernetesValidatingWebhookConfigurationV1Delete(ctx context.Contex
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] ValidatingWebhookConfiguration %#v is deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("ValidatingWebhookConfiguration %#v is deleted", name))
 
 	d.SetId("")
 	return nil
@@ -321,9 +322,9 @@ func resourceKubernetesValidatingWebhookConfigurationV1Exists(ctx context.Contex
 
 	name := d.Id()
 
-	log.Printf("[INFO] Checking ValidatingWebhookConfiguration %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking ValidatingWebhookConfiguration %s", name))
 
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return false, err
 	}
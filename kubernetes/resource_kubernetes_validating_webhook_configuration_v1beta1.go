@@ -5,8 +5,9 @@ package kubernetes
 
 import (
 	"context"
-	"log"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -143,11 +144,11 @@ func resourceKubernetesValidatingWebhookConfigurationV1Beta1Create(ctx context.C
 		Webhooks:   expandValidatingWebhooks(d.Get("webhook").([]interface{})),
 	}
 
-	log.Printf("[INFO] Creating new ValidatingWebhookConfiguration: %#v", cfg)
+	tflog.Info(ctx, fmt.Sprintf("Creating new ValidatingWebhookConfiguration: %#v", cfg))
 
 	res := &admissionregistrationv1.ValidatingWebhookConfiguration{}
 
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -165,7 +166,7 @@ func resourceKubernetesValidatingWebhookConfigurationV1Beta1Create(ctx context.C
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Submitted new ValidatingWebhookConfiguration: %#v", res)
+	tflog.Info(ctx, fmt.Sprintf("Submitted new ValidatingWebhookConfiguration: %#v", res))
 
 	d.SetId(res.Name)
 
@@ -190,8 +191,8 @@ func resourceKubernetesValidatingWebhookConfigurationV1Beta1Read(ctx context.Con
 
 	cfg := &admissionregistrationv1.ValidatingWebhookConfiguration{}
 
-	log.Printf("[INFO] Reading ValidatingWebhookConfiguration %s", name)
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	tflog.Info(ctx, fmt.Sprintf("Reading ValidatingWebhookConfiguration %s", name))
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -211,7 +212,7 @@ func resourceKubernetesValidatingWebhookConfigurationV1Beta1Read(ctx context.Con
 		return nil
 	}
 
-	log.Printf("[DEBUG] Setting webhook to: %#v", cfg.Webhooks)
+	tflog.Debug(ctx, fmt.Sprintf("Setting webhook to: %#v", cfg.Webhooks))
 
 	err = d.Set("webhook", flattenValidatingWebhooks(cfg.Webhooks))
 	if err != nil {
@@ -236,7 +237,7 @@ func resourceKubernetesValidatingWebhookConfigurationV1Beta1Update(ctx context.C
 
 		patch := expandValidatingWebhooks(d.Get("webhook").([]interface{}))
 
-		useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+		useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -257,11 +258,11 @@ func resourceKubernetesValidatingWebhookConfigurationV1Beta1Update(ctx context.C
 	}
 
 	name := d.Id()
-	log.Printf("[INFO] Updating ValidatingWebhookCoßnfiguration %q: %v", name, string(data))
+	tflog.Info(ctx, fmt.Sprintf("Updating ValidatingWebhookCoßnfiguration %q: %v", name, string(data)))
 
 	res := &admissionregistrationv1.ValidatingWebhookConfiguration{}
 
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -276,7 +277,7 @@ func resourceKubernetesValidatingWebhookConfigurationV1Beta1Update(ctx context.C
 		return diag.Errorf("Failed to update ValidatingWebhookConfiguration: %s", err)
 	}
 
-	log.Printf("[INFO] Submitted updated ValidatingWebhookConfiguration: %#v", res)
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated ValidatingWebhookConfiguration: %#v", res))
 
 	return resourceKubernetesValidatingWebhookConfigurationV1Beta1Read(ctx, d, meta)
 }
@@ -289,8 +290,8 @@ func resourceKubernetesValidatingWebhookConfigurationV1Beta1Delete(ctx context.C
 
 	name := d.Id()
 
-	log.Printf("[INFO] Deleting ValidatingWebhookConfiguration: %#v", name)
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	tflog.Info(ctx, fmt.Sprintf("Deleting ValidatingWebhookConfiguration: %#v", name))
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -306,7 +307,7 @@ func resourceKubernetesValidatingWebhookConfigurationV1Beta1Delete(ctx context.C
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] ValidatingWebhookConfiguration %#v is deleted", name)
+	tflog.Info(ctx, fmt.Sprintf("ValidatingWebhookConfiguration %#v is deleted", name))
 
 	d.SetId("")
 	return nil
@@ -320,9 +321,9 @@ func resourceKubernetesValidatingWebhookConfigurationV1Beta1Exists(ctx context.C
 
 	name := d.Id()
 
-	log.Printf("[INFO] Checking ValidatingWebhookConfiguration %s", name)
+	tflog.Info(ctx, fmt.Sprintf("Checking ValidatingWebhookConfiguration %s", name))
 
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 	if err != nil {
 		return false, err
 	}
@@ -136,7 +136,7 @@ func testAccCheckKubernetesValdiatingWebhookConfigurationV1Beta1Destroy(s *terra
 
 		name := rs.Primary.ID
 
-		useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+		useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 		if err != nil {
 			return err
 		}
@@ -174,7 +174,7 @@ func testAccCheckKubernetesValidatingWebhookConfigurationV1Beta1Exists(n string)
 
 		name := rs.Primary.ID
 
-		useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+		useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(ctx, conn)
 		if err != nil {
 			return err
 		}
@@ -287,7 +287,7 @@ func skipIfNotAdmissionRegistrationV1(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(conn)
+	useadmissionregistrationv1beta1, err := useAdmissionregistrationV1beta1(context.Background(), conn)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,314 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+)
+
+var verticalPodAutoscalerV1GVR = k8sschema.GroupVersionResource{
+	Group:    "autoscaling.k8s.io",
+	Version:  "v1",
+	Resource: "verticalpodautoscalers",
+}
+
+func resourceKubernetesVerticalPodAutoscalerV1() *schema.Resource {
+	return &schema.Resource{
+		Description:   "A vertical pod autoscaler automatically sets the resource requests of the containers of the pods of a workload based on their usage. It requires the VerticalPodAutoscaler custom resource definition and controllers of the Kubernetes autoscaler project to be installed in the cluster.",
+		CreateContext: resourceKubernetesVerticalPodAutoscalerV1Create,
+		ReadContext:   resourceKubernetesVerticalPodAutoscalerV1Read,
+		UpdateContext: resourceKubernetesVerticalPodAutoscalerV1Update,
+		DeleteContext: resourceKubernetesVerticalPodAutoscalerV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("vertical pod autoscaler", true),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Behaviour of the autoscaler.",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"target_ref": {
+							Type:        schema.TypeList,
+							Description: "Reference to the controller managing the set of pods for the autoscaler to control, e.g. a deployment or a stateful set.",
+							Required:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"api_version": {
+										Type:        schema.TypeString,
+										Description: "API version of the referent, e.g. `apps/v1`.",
+										Required:    true,
+									},
+									"kind": {
+										Type:        schema.TypeString,
+										Description: "Kind of the referent, e.g. `Deployment`.",
+										Required:    true,
+									},
+									"name": {
+										Type:        schema.TypeString,
+										Description: "Name of the referent.",
+										Required:    true,
+									},
+								},
+							},
+						},
+						"update_policy": {
+							Type:        schema.TypeList,
+							Description: "Describes the rules on how changes are applied to the pods.",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"update_mode": {
+										Type:         schema.TypeString,
+										Description:  "Controls when the autoscaler applies changes to the resources of the pods, one of `Off`, `Initial`, `Recreate`, `InPlaceOrRecreate` or `Auto`. Defaults to `Auto` on the server.",
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice([]string{"Off", "Initial", "Recreate", "InPlaceOrRecreate", "Auto"}, false),
+									},
+									"min_replicas": {
+										Type:         schema.TypeInt,
+										Description:  "Minimal number of replicas which need to be alive for the updater to attempt the eviction of a pod.",
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+								},
+							},
+						},
+						"resource_policy": {
+							Type:        schema.TypeList,
+							Description: "Controls how the autoscaler computes the recommended resources.",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"container_policy": {
+										Type:        schema.TypeList,
+										Description: "Per-container resource policies.",
+										Optional:    true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"container_name": {
+													Type:        schema.TypeString,
+													Description: "Name of the container or `*`, which matches all the containers which don't have their own policy.",
+													Required:    true,
+												},
+												"mode": {
+													Type:         schema.TypeString,
+													Description:  "Whether the autoscaler is enabled for the container, `Auto` or `Off`. Defaults to `Auto` on the server.",
+													Optional:     true,
+													ValidateFunc: validation.StringInSlice([]string{"Auto", "Off"}, false),
+												},
+												"min_allowed": {
+													Type:        schema.TypeMap,
+													Description: "The minimal amounts of resources the autoscaler recommends for the container, e.g. `{ cpu = \"100m\", memory = \"64Mi\" }`.",
+													Optional:    true,
+													Elem:        &schema.Schema{Type: schema.TypeString},
+												},
+												"max_allowed": {
+													Type:        schema.TypeMap,
+													Description: "The maximal amounts of resources the autoscaler recommends for the container.",
+													Optional:    true,
+													Elem:        &schema.Schema{Type: schema.TypeString},
+												},
+												"controlled_resources": {
+													Type:        schema.TypeList,
+													Description: "The resources the autoscaler computes recommendations for, e.g. `[\"cpu\", \"memory\"]`. Defaults to `cpu` and `memory` on the server.",
+													Optional:    true,
+													Elem:        &schema.Schema{Type: schema.TypeString},
+												},
+												"controlled_values": {
+													Type:         schema.TypeString,
+													Description:  "Which resource values are updated, `RequestsAndLimits` or `RequestsOnly`. Defaults to `RequestsAndLimits` on the server.",
+													Optional:     true,
+													ValidateFunc: validation.StringInSlice([]string{"RequestsAndLimits", "RequestsOnly"}, false),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						"recommenders": {
+							Type:        schema.TypeList,
+							Description: "Names of the recommenders responsible for generating recommendations for the autoscaler. Only the first one is currently supported. Defaults to the default recommender.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesVerticalPodAutoscalerV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := checkVerticalPodAutoscalerV1Served(meta); err != nil {
+		return diag.FromErr(err)
+	}
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	vpa, err := expandVerticalPodAutoscalerV1(metadata, d.Get("spec").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating new vertical pod autoscaler: %#v", vpa))
+	out, err := conn.Resource(verticalPodAutoscalerV1GVR).Namespace(metadata.Namespace).Create(ctx, vpa, metav1.CreateOptions{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Submitted new vertical pod autoscaler: %#v", out))
+
+	d.SetId(buildId(metav1.ObjectMeta{Namespace: out.GetNamespace(), Name: out.GetName()}))
+
+	return resourceKubernetesVerticalPodAutoscalerV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesVerticalPodAutoscalerV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Reading vertical pod autoscaler %s", name))
+	vpa, err := conn.Resource(verticalPodAutoscalerV1GVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Received error: %#v", err))
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Received vertical pod autoscaler: %#v", vpa))
+
+	var metadata metav1.ObjectMeta
+	m, _, _ := unstructured.NestedMap(vpa.Object, "metadata")
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(m, &metadata)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("metadata", flattenMetadata(metadata, d, meta))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	spec, _, _ := unstructured.NestedMap(vpa.Object, "spec")
+	err = d.Set("spec", flattenVerticalPodAutoscalerV1Spec(spec))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKubernetesVerticalPodAutoscalerV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("spec") {
+		spec, err := expandVerticalPodAutoscalerV1Spec(d.Get("spec").([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/spec",
+			Value: spec,
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return diag.Errorf("Failed to marshal update operations: %s", err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating vertical pod autoscaler %q: %v", name, string(data)))
+	out, err := conn.Resource(verticalPodAutoscalerV1GVR).Namespace(namespace).Patch(ctx, name, pkgApi.JSONPatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return diag.Errorf("Failed to update vertical pod autoscaler: %s", err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Submitted updated vertical pod autoscaler: %#v", out))
+
+	return resourceKubernetesVerticalPodAutoscalerV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesVerticalPodAutoscalerV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Deleting vertical pod autoscaler: %#v", name))
+	err = conn.Resource(verticalPodAutoscalerV1GVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Vertical pod autoscaler %s deleted", name))
+
+	d.SetId("")
+	return nil
+}
+
+// checkVerticalPodAutoscalerV1Served returns an error explaining that the custom
+// resource definition of the autoscaler has to be installed when the cluster
+// doesn't serve the API of the vertical pod autoscaler.
+func checkVerticalPodAutoscalerV1Served(meta interface{}) error {
+	dc, err := meta.(KubeClientsets).DiscoveryClient()
+	if err != nil {
+		return err
+	}
+	gv := verticalPodAutoscalerV1GVR.GroupVersion().String()
+	resources, err := dc.ServerResourcesForGroupVersion(gv)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if resources != nil {
+		for _, r := range resources.APIResources {
+			if r.Name == verticalPodAutoscalerV1GVR.Resource {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("The cluster doesn't serve %s in %s. Install the VerticalPodAutoscaler custom resource definition and controllers of the Kubernetes autoscaler project before creating vertical pod autoscalers: https://github.com/kubernetes/autoscaler/tree/master/vertical-pod-autoscaler", verticalPodAutoscalerV1GVR.Resource, gv)
+}
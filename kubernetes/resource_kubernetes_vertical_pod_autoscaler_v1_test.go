@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVerticalPodAutoscalerV1Spec(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"target_ref": []interface{}{map[string]interface{}{
+				"api_version": "apps/v1",
+				"kind":        "Deployment",
+				"name":        "web",
+			}},
+			"update_policy": []interface{}{map[string]interface{}{
+				"update_mode":  "Initial",
+				"min_replicas": 2,
+			}},
+			"resource_policy": []interface{}{map[string]interface{}{
+				"container_policy": []interface{}{
+					map[string]interface{}{
+						"container_name":       "*",
+						"mode":                 "Auto",
+						"min_allowed":          map[string]interface{}{"cpu": "100m"},
+						"max_allowed":          map[string]interface{}{"cpu": "1", "memory": "1Gi"},
+						"controlled_resources": []interface{}{"cpu", "memory"},
+						"controlled_values":    "RequestsOnly",
+					},
+				},
+			}},
+			"recommenders": []interface{}{"custom"},
+		},
+	}
+
+	spec, err := expandVerticalPodAutoscalerV1Spec(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"targetRef":    map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "web"},
+		"updatePolicy": map[string]interface{}{"updateMode": "Initial", "minReplicas": int64(2)},
+		"resourcePolicy": map[string]interface{}{
+			"containerPolicies": []interface{}{
+				map[string]interface{}{
+					"containerName":       "*",
+					"mode":                "Auto",
+					"minAllowed":          map[string]interface{}{"cpu": "100m"},
+					"maxAllowed":          map[string]interface{}{"cpu": "1", "memory": "1Gi"},
+					"controlledResources": []interface{}{"cpu", "memory"},
+					"controlledValues":    "RequestsOnly",
+				},
+			},
+		},
+		"recommenders": []interface{}{map[string]interface{}{"name": "custom"}},
+	}
+	if diff := cmp.Diff(expected, spec); diff != "" {
+		t.Fatalf("Unexpected spec: mismatch (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(in, flattenVerticalPodAutoscalerV1Spec(spec)); diff != "" {
+		t.Fatalf("Unexpected flattened spec: mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAccKubernetesVerticalPodAutoscalerV1_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(10))
+	resourceName := "kubernetes_vertical_pod_autoscaler_v1.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			skipIfNoVerticalPodAutoscaler(t)
+		},
+		IDRefreshName:     resourceName,
+		IDRefreshIgnore:   []string{"metadata.0.resource_version"},
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKubernetesVerticalPodAutoscalerV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesVerticalPodAutoscalerV1Config_basic(name, "Off"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet(resourceName, "metadata.0.uid"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.target_ref.0.kind", "Deployment"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.update_policy.0.update_mode", "Off"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.resource_policy.0.container_policy.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "spec.0.resource_policy.0.container_policy.0.max_allowed.memory", "1Gi"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata.0.resource_version"},
+			},
+			{
+				Config: testAccKubernetesVerticalPodAutoscalerV1Config_basic(name, "Initial"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "spec.0.update_policy.0.update_mode", "Initial"),
+				),
+			},
+		},
+	})
+}
+
+func skipIfNoVerticalPodAutoscaler(t *testing.T) {
+	if err := checkVerticalPodAutoscalerV1Served(testAccProvider.Meta()); err != nil {
+		t.Skip("The VerticalPodAutoscaler custom resource definition must be installed for this test to run - skipping")
+	}
+}
+
+func testAccCheckKubernetesVerticalPodAutoscalerV1Destroy(s *terraform.State) error {
+	conn, err := testAccProvider.Meta().(KubeClientsets).DynamicClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.TODO()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_vertical_pod_autoscaler_v1" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.Resource(verticalPodAutoscalerV1GVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			return fmt.Errorf("Vertical pod autoscaler still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccKubernetesVerticalPodAutoscalerV1Config_basic(name, updateMode string) string {
+	return fmt.Sprintf(`resource "kubernetes_vertical_pod_autoscaler_v1" "test" {
+  metadata {
+    name = %[1]q
+  }
+  spec {
+    target_ref {
+      api_version = "apps/v1"
+      kind        = "Deployment"
+      name        = "TerraformAccTest"
+    }
+    update_policy {
+      update_mode = %[2]q
+    }
+    resource_policy {
+      container_policy {
+        container_name = "*"
+        min_allowed = {
+          cpu = "100m"
+        }
+        max_allowed = {
+          memory = "1Gi"
+        }
+      }
+    }
+  }
+}
+`, name, updateMode)
+}
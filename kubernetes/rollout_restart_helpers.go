@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// rolloutRestartedAtAnnotation is the annotation of the pod template of a
+// workload set by `kubectl rollout restart`. Changing it rolls out new pods.
+const rolloutRestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+func rolloutRestartTriggersSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: fmt.Sprintf("Arbitrary values which restart the pods of the %s when they change, like `kubectl rollout restart`, e.g. the hash of a configuration map the pods only read on startup. The pods are restarted by setting the `%s` annotation of the pod template to the time of the change.", kind, rolloutRestartedAtAnnotation),
+		Optional:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+func rolloutRestartedAtSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: fmt.Sprintf("The time the pods of the %s were last restarted, by `rollout_restart_triggers` or `kubectl rollout restart`, in RFC 3339 format. Empty if they never were.", kind),
+		Computed:    true,
+	}
+}
+
+// customizeRolloutRestartDiff plans a new restart time when the rollout
+// restart triggers of a workload change.
+func customizeRolloutRestartDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" && diff.HasChange("rollout_restart_triggers") {
+		return diff.SetNewComputed("rollout_restarted_at")
+	}
+	return nil
+}
+
+// flattenRolloutRestartedAt returns the time of the last restart of the pods
+// of a workload, and removes its annotation from the pod template unless it's
+// part of the configuration, so that the restarts don't show up as drift.
+func flattenRolloutRestartedAt(template *corev1.PodTemplateSpec, d *schema.ResourceData) string {
+	restartedAt := template.Annotations[rolloutRestartedAtAnnotation]
+	annotations, _ := d.Get("spec.0.template.0.metadata.0.annotations").(map[string]interface{})
+	if !isKeyInMap(rolloutRestartedAtAnnotation, annotations) {
+		delete(template.Annotations, rolloutRestartedAtAnnotation)
+	}
+	return restartedAt
+}
+
+// expandRolloutRestartedAt sets the restart annotation of the pod template of
+// a workload whose template is replaced, to the current time when the rollout
+// restart triggers changed, so that its pods are restarted, or else to the
+// time of the last restart, so that replacing the template doesn't restart
+// them again. The annotation is left alone when it's part of the
+// configuration.
+func expandRolloutRestartedAt(template *corev1.PodTemplateSpec, d *schema.ResourceData) {
+	if _, ok := template.Annotations[rolloutRestartedAtAnnotation]; ok {
+		return
+	}
+	restartedAt := d.Get("rollout_restarted_at").(string)
+	if d.HasChange("rollout_restart_triggers") {
+		restartedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	if restartedAt == "" {
+		return
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[rolloutRestartedAtAnnotation] = restartedAt
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRolloutRestartedAt(t *testing.T) {
+	s := resourceKubernetesDeploymentV1().Schema
+	templateAnnotations := func(annotations map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"spec": []interface{}{map[string]interface{}{
+				"template": []interface{}{map[string]interface{}{
+					"metadata": []interface{}{map[string]interface{}{"annotations": annotations}},
+				}},
+			}},
+		}
+	}
+
+	// The triggers changed: the pods are restarted.
+	raw := templateAnnotations(map[string]interface{}{"team": "web"})
+	raw["rollout_restart_triggers"] = map[string]interface{}{"config": "abc"}
+	d := schema.TestResourceDataRaw(t, s, raw)
+	template := &corev1.PodTemplateSpec{}
+	expandRolloutRestartedAt(template, d)
+	restartedAt, err := time.Parse(time.RFC3339, template.Annotations[rolloutRestartedAtAnnotation])
+	if err != nil || time.Since(restartedAt) > time.Minute {
+		t.Fatalf("Expected the pods to be restarted now, got %q", template.Annotations[rolloutRestartedAtAnnotation])
+	}
+
+	// The template is replaced: the time of the last restart is kept.
+	d = schema.TestResourceDataRaw(t, s, templateAnnotations(map[string]interface{}{"team": "web"}))
+	if err := d.Set("rollout_restarted_at", "2024-05-01T03:00:00Z"); err != nil {
+		t.Fatal(err)
+	}
+	template = &corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"team": "web"}}}
+	expandRolloutRestartedAt(template, d)
+	if got := template.Annotations[rolloutRestartedAtAnnotation]; got != "2024-05-01T03:00:00Z" {
+		t.Fatalf("Expected the time of the last restart to be kept, got %q", got)
+	}
+
+	// The annotation isn't part of the configuration: it's exported, not drift.
+	if got := flattenRolloutRestartedAt(template, d); got != "2024-05-01T03:00:00Z" {
+		t.Fatalf("Unexpected time of the last restart: %q", got)
+	}
+	if _, ok := template.Annotations[rolloutRestartedAtAnnotation]; ok || template.Annotations["team"] != "web" {
+		t.Fatalf("Expected only the restart annotation to be removed, got %v", template.Annotations)
+	}
+
+	// The annotation is part of the configuration: it's left alone.
+	configured := map[string]interface{}{rolloutRestartedAtAnnotation: "2024-01-01T00:00:00Z"}
+	raw = templateAnnotations(configured)
+	raw["rollout_restart_triggers"] = map[string]interface{}{"config": "abc"}
+	d = schema.TestResourceDataRaw(t, s, raw)
+	template = &corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{rolloutRestartedAtAnnotation: "2024-01-01T00:00:00Z"}}}
+	expandRolloutRestartedAt(template, d)
+	flattenRolloutRestartedAt(template, d)
+	if got := template.Annotations[rolloutRestartedAtAnnotation]; got != "2024-01-01T00:00:00Z" {
+		t.Fatalf("Expected the configured annotation to be left alone, got %q", got)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// withSchedulerNameValidation adds the validate_scheduler_name attribute to a
+// resource managing pods. When it is set, applying a pod spec which references
+// a scheduler other than the default one reports a warning when no such
+// scheduler seems to be running, as its pods would otherwise stay Pending
+// without any explanation.
+func withSchedulerNameValidation(r *schema.Resource, specPath []string) {
+	r.Schema["validate_scheduler_name"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Description: "Check on apply that the scheduler referenced by `scheduler_name` is running, by looking up its leader election lease in the `kube-system` namespace or a deployment of the same name, and report a warning when it isn't found. Requires permission to get leases and list deployments.",
+		Optional:    true,
+		ForceNew:    r.UpdateContext == nil,
+		Default:     false,
+	}
+
+	key := strings.Join(specPath, ".0.") + ".0.scheduler_name"
+	warn := func(ctx context.Context, d *schema.ResourceData, meta interface{}, diags diag.Diagnostics) diag.Diagnostics {
+		name := d.Get(key).(string)
+		if name == "" || name == corev1.DefaultSchedulerName {
+			return diags
+		}
+		conn, err := meta.(KubeClientsets).MainClientset()
+		if err != nil {
+			return diags
+		}
+		return appendSchedulerWarning(ctx, conn, name, diags)
+	}
+
+	create := r.CreateContext
+	r.CreateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := create(ctx, d, meta)
+		if !d.Get("validate_scheduler_name").(bool) {
+			return diags
+		}
+		return warn(ctx, d, meta, diags)
+	}
+	if r.UpdateContext == nil {
+		return
+	}
+	update := r.UpdateContext
+	r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		changed := d.HasChange(key) || d.HasChange("validate_scheduler_name")
+		diags := update(ctx, d, meta)
+		if !changed || !d.Get("validate_scheduler_name").(bool) {
+			return diags
+		}
+		return warn(ctx, d, meta, diags)
+	}
+}
+
+// appendSchedulerWarning appends the warning of checkSchedulerRunning to the
+// diagnostics of applying the pod spec. It is appended even when applying
+// failed, e.g. when waiting for pods which stay Pending as the scheduler isn't
+// running, since the warning explains the failure.
+func appendSchedulerWarning(ctx context.Context, conn kubernetes.Interface, name string, diags diag.Diagnostics) diag.Diagnostics {
+	if w := checkSchedulerRunning(ctx, conn, name); w != nil {
+		diags = append(diags, *w)
+	}
+	return diags
+}
+
+// checkSchedulerRunning returns a warning when no scheduler of the given name
+// seems to be running. A scheduler is considered running when it holds a lease
+// of its name in the kube-system namespace which was renewed recently, as
+// schedulers using leader election do, or when a deployment of its name has
+// available replicas. Lookups failing for other reasons than the objects not
+// being found, e.g. missing permissions, are only logged.
+func checkSchedulerRunning(ctx context.Context, conn kubernetes.Interface, name string) *diag.Diagnostic {
+	lease, err := conn.CoordinationV1().Leases(metav1.NamespaceSystem).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		if leaseHeld(lease.Spec.HolderIdentity, lease.Spec.RenewTime, lease.Spec.LeaseDurationSeconds, time.Now()) {
+			return nil
+		}
+	case !apierrors.IsNotFound(err):
+		tflog.Warn(ctx, fmt.Sprintf("Unable to check that scheduler %q is running: %s", name, err))
+		return nil
+	}
+
+	deployments, err := conn.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String(),
+	})
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to check that scheduler %q is running: %s", name, err))
+		return nil
+	}
+	for _, d := range deployments.Items {
+		if d.Name == name && d.Status.AvailableReplicas > 0 {
+			return nil
+		}
+	}
+
+	return &diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Scheduler %q doesn't seem to be running", name),
+		Detail: fmt.Sprintf("Neither a recently renewed lease %q in the %q namespace nor an available deployment %q were found. "+
+			"The pods referencing the scheduler will stay Pending until it is running.", name, metav1.NamespaceSystem, name),
+	}
+}
+
+// leaseHeld returns whether a lease has a holder which renewed it within twice
+// its duration, leaving room for a renewal being late.
+func leaseHeld(holder *string, renewTime *metav1.MicroTime, durationSeconds *int32, now time.Time) bool {
+	if holder == nil || *holder == "" || renewTime == nil {
+		return false
+	}
+	duration := 15 * time.Second
+	if durationSeconds != nil && *durationSeconds > 0 {
+		duration = time.Duration(*durationSeconds) * time.Second
+	}
+	return now.Sub(renewTime.Time) <= 2*duration
+}
@@ -0,0 +1,69 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	appsv1 "k8s.io/api/apps/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func TestCheckSchedulerRunning(t *testing.T) {
+	lease := func(name string, renewed time.Duration) *coordinationv1.Lease {
+		return &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceSystem},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(name + "-0"),
+				LeaseDurationSeconds: ptr.To(int32(15)),
+				RenewTime:            &metav1.MicroTime{Time: time.Now().Add(-renewed)},
+			},
+		}
+	}
+	deployment := func(name string, available int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "schedulers"},
+			Status:     appsv1.DeploymentStatus{AvailableReplicas: available},
+		}
+	}
+	conn := fake.NewSimpleClientset(
+		lease("lease-scheduler", 5*time.Second),
+		lease("stale-scheduler", time.Hour),
+		deployment("deployed-scheduler", 1),
+		deployment("scaled-down-scheduler", 0),
+	)
+
+	cases := []struct {
+		Name    string
+		Warning bool
+	}{
+		{"lease-scheduler", false},
+		{"deployed-scheduler", false},
+		{"stale-scheduler", true},
+		{"scaled-down-scheduler", true},
+		{"missing-scheduler", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			w := checkSchedulerRunning(context.Background(), conn, tc.Name)
+			if (w != nil) != tc.Warning {
+				t.Fatalf("Expected warning %t, got %v", tc.Warning, w)
+			}
+		})
+	}
+}
+
+func TestAppendSchedulerWarning(t *testing.T) {
+	conn := fake.NewSimpleClientset()
+	diags := appendSchedulerWarning(context.Background(), conn, "missing-scheduler", diag.Errorf("timed out waiting for the pods to be ready"))
+	if len(diags) != 2 || !diags.HasError() || diags[1].Severity != diag.Warning {
+		t.Fatalf("Expected the error followed by the scheduler warning, got %v", diags)
+	}
+}
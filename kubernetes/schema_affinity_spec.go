@@ -186,6 +186,20 @@ func podAffinityTermFields() map[string]*schema.Schema {
 			Description: "empty topology key is interpreted by the scheduler as 'all topologies'",
 			Required:    true,
 		},
+		"match_label_keys": {
+			Type:        schema.TypeSet,
+			Description: "A set of pod label keys to select which pods will be taken into consideration. The values of these keys are looked up from the labels of the incoming pod and merged with `label_selector` as `key in (value)`, e.g. with `pod-template-hash` to only consider the pods of the same revision. Requires `label_selector` to be set.",
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Set:         schema.HashString,
+		},
+		"mismatch_label_keys": {
+			Type:        schema.TypeSet,
+			Description: "A set of pod label keys to select which pods will be taken into consideration. The values of these keys are looked up from the labels of the incoming pod and merged with `label_selector` as `key notin (value)`, e.g. to keep the pods of different tenants apart. Requires `label_selector` to be set.",
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Set:         schema.HashString,
+		},
 	}
 }
 
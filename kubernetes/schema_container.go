@@ -125,6 +125,28 @@ func resourcesFieldV1(isUpdatable bool) map[string]*schema.Schema {
 			},
 			DiffSuppressFunc: suppressEquivalentResourceQuantity,
 		},
+		"claims": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    !isUpdatable,
+			Description: "The resource claims of the pod, listed in its `resource_claim` blocks, which are made available to the container.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Required:    true,
+						ForceNew:    !isUpdatable,
+						Description: "The name of a `resource_claim` of the pod.",
+					},
+					"request": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						ForceNew:    !isUpdatable,
+						Description: "The name of a request of the claim whose devices are made available to the container. All the devices of the claim are made available when it isn't set.",
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -191,6 +213,28 @@ func seccompProfileField(isUpdatable bool) map[string]*schema.Schema {
 	}
 }
 
+func appArmorProfileField(isUpdatable bool) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"localhost_profile": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    !isUpdatable,
+			Description: "The name of the profile loaded on the node, when `type` is `Localhost`. The profile must be preconfigured on the node to work.",
+		},
+		"type": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: !isUpdatable,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(api.AppArmorProfileTypeLocalhost),
+				string(api.AppArmorProfileTypeRuntimeDefault),
+				string(api.AppArmorProfileTypeUnconfined),
+			}, false),
+			Description: "Type indicates which kind of AppArmor profile will be applied. Valid options are: Localhost, RuntimeDefault, Unconfined.",
+		},
+	}
+}
+
 func seLinuxOptionsField(isUpdatable bool) map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"level": {
@@ -488,11 +532,12 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 			Description: "Docker image name. More info: https://kubernetes.io/docs/concepts/containers/images/",
 		},
 		"image_pull_policy": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Computed:    true,
-			ForceNew:    !isUpdatable,
-			Description: "Image pull policy. One of Always, Never, IfNotPresent. Defaults to Always if :latest tag is specified, or IfNotPresent otherwise. Cannot be updated. More info: https://kubernetes.io/docs/concepts/containers/images/#updating-images",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Computed:         true,
+			ForceNew:         !isUpdatable,
+			DiffSuppressFunc: suppressDefaultImagePullPolicy,
+			Description:      "Image pull policy. One of Always, Never, IfNotPresent. Defaults to Always if :latest tag is specified, or IfNotPresent otherwise. Cannot be updated. More info: https://kubernetes.io/docs/concepts/containers/images/#updating-images",
 		},
 		"lifecycle": {
 			Type:        schema.TypeList,
@@ -682,6 +727,10 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 			Description: "Container's working directory. If not specified, the container runtime's default will be used, which might be configured in the container image. Cannot be updated.",
 		},
 	}
+	// Admission may set the seccomp profile of containers without a security
+	// context.
+	sc := s["security_context"]
+	sc.DiffSuppressFunc = suppressAdmissionSecurityContext(sc.Elem.(*schema.Resource))
 	return s
 }
 
@@ -814,10 +863,21 @@ func securityContextSchema(isUpdatable bool) *schema.Resource {
 			ForceNew:     !isUpdatable,
 			ValidateFunc: validateTypeStringNullableInt,
 		},
+		"app_armor_profile": {
+			Type:        schema.TypeList,
+			Description: "The AppArmor options to use by this container. Overrides the AppArmor options of the pod. Note that this field cannot be set when spec.os.name is windows.",
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: appArmorProfileField(isUpdatable),
+			},
+		},
 		"seccomp_profile": {
 			Type:        schema.TypeList,
 			Description: "The seccomp options to use by the containers in this pod. Note that this field cannot be set when spec.os.name is windows.",
 			Optional:    true,
+			Computed:    true,
 			MaxItems:    1,
 			Elem: &schema.Resource{
 				Schema: seccompProfileField(isUpdatable),
@@ -46,8 +46,8 @@ func cronJobSpecFieldsV1() map[string]*schema.Schema {
 		"schedule": {
 			Type:         schema.TypeString,
 			Required:     true,
-			ValidateFunc: validateCronExpression,
-			Description:  "Cron format string, e.g. 0 * * * * or @hourly, as schedule time of its jobs to be created and executed.",
+			ValidateFunc: validateCronJobV1Schedule,
+			Description:  "Cron format string, e.g. 0 * * * * or @hourly, as schedule time of its jobs to be created and executed. Its time zone is set with `timezone`, rather than a TZ or CRON_TZ prefix.",
 		},
 		"starting_deadline_seconds": {
 			Type:        schema.TypeInt,
@@ -68,9 +68,10 @@ func cronJobSpecFieldsV1() map[string]*schema.Schema {
 			Description: "This flag tells the controller to suspend subsequent executions, it does not apply to already started executions. Defaults to false.",
 		},
 		"timezone": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "The time zone for the given schedule. If not specified, this will rely on the time zone of the kube-controller-manager process. ",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateTimeZone,
+			Description:  "The time zone for the given schedule, as defined in the IANA time zone database, e.g. `Europe/Paris`. If not specified, this will rely on the time zone of the kube-controller-manager process. ",
 		},
 	}
 }
@@ -109,9 +109,11 @@ func metricTargetFields() *schema.Resource {
 				Description: "averageUtilization is the target value of the average of the resource metric across all relevant pods, represented as a percentage of the requested value of the resource for the pods. Currently only valid for Resource metric source type",
 			},
 			"average_value": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "averageValue is the target value of the average of the metric across all relevant pods (as a quantity)",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "averageValue is the target value of the average of the metric across all relevant pods (as a quantity)",
+				ValidateFunc:     validateResourceQuantity,
+				DiffSuppressFunc: suppressEquivalentResourceQuantity,
 			},
 			"type": {
 				Type:        schema.TypeString,
@@ -119,9 +121,11 @@ func metricTargetFields() *schema.Resource {
 				Description: "type represents whether the metric type is Utilization, Value, or AverageValue",
 			},
 			"value": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "value is the target value of the metric (as a quantity).",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "value is the target value of the metric (as a quantity).",
+				ValidateFunc:     validateResourceQuantity,
+				DiffSuppressFunc: suppressEquivalentResourceQuantity,
 			},
 		},
 	}
@@ -381,3 +385,108 @@ func scalingPolicySpecFields() *schema.Resource {
 		},
 	}
 }
+
+func horizontalPodAutoscalerV2StatusSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The current status of the autoscaler, as last observed by the autoscaler.",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"current_replicas": {
+					Type:        schema.TypeInt,
+					Description: "The current number of replicas of pods managed by this autoscaler, as last seen by the autoscaler.",
+					Computed:    true,
+				},
+				"desired_replicas": {
+					Type:        schema.TypeInt,
+					Description: "The desired number of replicas of pods managed by this autoscaler, as last calculated by the autoscaler.",
+					Computed:    true,
+				},
+				"last_scale_time": {
+					Type:        schema.TypeString,
+					Description: "The last time the autoscaler scaled the number of pods.",
+					Computed:    true,
+				},
+				"observed_generation": {
+					Type:        schema.TypeInt,
+					Description: "The most recent generation observed by this autoscaler.",
+					Computed:    true,
+				},
+				"current_metric": {
+					Type:        schema.TypeList,
+					Description: "The last read state of the metrics used by this autoscaler.",
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"type": {
+								Type:        schema.TypeString,
+								Description: "The type of the metric source, one of `ContainerResource`, `External`, `Object`, `Pods` or `Resource`.",
+								Computed:    true,
+							},
+							"name": {
+								Type:        schema.TypeString,
+								Description: "The name of the resource or of the metric.",
+								Computed:    true,
+							},
+							"container": {
+								Type:        schema.TypeString,
+								Description: "The name of the container of `ContainerResource` metrics.",
+								Computed:    true,
+							},
+							"average_utilization": {
+								Type:        schema.TypeInt,
+								Description: "The current value of the average of the resource metric across all relevant pods, represented as a percentage of the requested value of the resource for the pods.",
+								Computed:    true,
+							},
+							"average_value": {
+								Type:        schema.TypeString,
+								Description: "The current value of the average of the metric across all relevant pods.",
+								Computed:    true,
+							},
+							"value": {
+								Type:        schema.TypeString,
+								Description: "The current value of the metric.",
+								Computed:    true,
+							},
+						},
+					},
+				},
+				"condition": {
+					Type:        schema.TypeList,
+					Description: "The conditions required for this autoscaler to scale its target, and indicates whether or not those conditions are met.",
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"type": {
+								Type:        schema.TypeString,
+								Description: "The type of the condition, e.g. `AbleToScale`, `ScalingActive` or `ScalingLimited`.",
+								Computed:    true,
+							},
+							"status": {
+								Type:        schema.TypeString,
+								Description: "The status of the condition, one of `True`, `False` or `Unknown`.",
+								Computed:    true,
+							},
+							"reason": {
+								Type:        schema.TypeString,
+								Description: "The reason for the last transition of the condition.",
+								Computed:    true,
+							},
+							"message": {
+								Type:        schema.TypeString,
+								Description: "A human-readable explanation containing details about the transition.",
+								Computed:    true,
+							},
+							"last_transition_time": {
+								Type:        schema.TypeString,
+								Description: "The last time the condition transitioned from one status to another.",
+								Computed:    true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
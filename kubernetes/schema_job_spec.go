@@ -5,6 +5,7 @@ package kubernetes
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -62,7 +63,7 @@ func jobSpecFields(specUpdatable bool) map[string]*schema.Schema {
 			Optional:     true,
 			ForceNew:     true,
 			ValidateFunc: validateNonNegativeInteger,
-			Description:  "Specifies the limit for the number of retries within an index before marking this index as failed. When enabled the number of failures per index is kept in the pod's batch.kubernetes.io/job-index-failure-count annotation. It can only be set when Job's completionMode=Indexed, and the Pod's restart policy is Never. The field is immutable.",
+			Description:  "Specifies the limit for the number of retries within an index before marking this index as failed, instead of `backoff_limit` for the whole job. The number of failures of an index is kept in the `batch.kubernetes.io/job-index-failure-count` annotation of its pods. It can only be set when `completion_mode` is `Indexed`, and requires the restart policy of the pod template to be `Never`. The field is immutable. More info: https://kubernetes.io/docs/concepts/workloads/controllers/job/#backoff-limit-per-index",
 		},
 		// This field is immutable in Jobs.
 		"completions": {
@@ -84,6 +85,14 @@ func jobSpecFields(specUpdatable bool) map[string]*schema.Schema {
 			}, false),
 			Description: "Specifies how Pod completions are tracked. It can be `NonIndexed` (default) or `Indexed`. More info: https://kubernetes.io/docs/concepts/workloads/controllers/job/#completion-mode",
 		},
+		"managed_by": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			DiffSuppressFunc: suppressDroppedJobManagedBy,
+			ValidateFunc:     validation.StringMatch(regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?/[^/]+$`), "must be a domain-prefixed path, e.g. `kueue.x-k8s.io/multikueue`"),
+			Description:      "The controller which manages the job, as a domain-prefixed path, e.g. `kueue.x-k8s.io/multikueue` for jobs dispatched by Kueue to other clusters. The built-in job controller ignores jobs managed by another controller, which then updates their status. Defaults to the built-in job controller, `kubernetes.io/job-controller`. The field is immutable. It is dropped by API servers without the `JobManagedBy` feature gate, which is enabled by default since Kubernetes 1.32, and then isn't reported as a diff. More info: https://kubernetes.io/docs/concepts/workloads/controllers/job/#delegation-of-managing-a-job-object-to-external-controller",
+		},
 		"manual_selector": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -95,7 +104,7 @@ func jobSpecFields(specUpdatable bool) map[string]*schema.Schema {
 			Optional:     true,
 			ForceNew:     false,
 			ValidateFunc: validateNonNegativeInteger,
-			Description:  "Controls generation of pod labels and pod selectors. Leave unset unless you are certain what you are doing. When false or unset, the system pick labels unique to this job and appends those labels to the pod template. When true, the user is responsible for picking unique labels and specifying the selector. Failure to pick a unique label may cause this and other jobs to not function correctly. More info: https://git.k8s.io/community/contributors/design-proposals/selector-generation.md",
+			Description:  "Specifies the maximal number of failed indexes before marking the job as failed. It can only be set together with `backoff_limit_per_index`, when `completion_mode` is `Indexed`, and must not exceed `completions`. More info: https://kubernetes.io/docs/concepts/workloads/controllers/job/#backoff-limit-per-index",
 		},
 		"parallelism": {
 			Type:         schema.TypeInt,
@@ -110,38 +119,46 @@ func jobSpecFields(specUpdatable bool) map[string]*schema.Schema {
 			Optional:    true,
 			ForceNew:    true,
 			MaxItems:    1,
-			Description: "Specifies the maximum desired number of pods the job should run at any given time. The actual number of pods running in steady state will be less than this number when ((.spec.completions - .status.successful) < .spec.parallelism), i.e. when the work left to do is less than max parallelism. More info: https://kubernetes.io/docs/concepts/workloads/controllers/jobs-run-to-completion/",
+			Description: "Specifies the policy of handling failed pods, e.g. to fail the job without retries on a given exit code, or to not count pods evicted by a disruption towards the backoff limit. It requires the restart policy of the pod template to be `Never`. More info: https://kubernetes.io/docs/concepts/workloads/controllers/job/#pod-failure-policy",
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"rule": {
 						Type:        schema.TypeList,
-						Description: "A label query over volumes to consider for binding.",
+						Description: "The rules of the policy, evaluated in order. Once a rule matches a failed pod, the remaining rules are ignored. When no rule matches, the default handling of failed pods applies.",
 						Required:    true,
+						MaxItems:    20,
 						Elem: &schema.Resource{
 							Schema: map[string]*schema.Schema{
 								"action": {
-									Type:     schema.TypeString,
-									Optional: true,
+									Type:         schema.TypeString,
+									Description:  "The action taken on a pod failure when the rule matches. `FailJob` fails the job and terminates its running pods, `FailIndex` fails the index of the pod and requires `backoff_limit_per_index`, `Ignore` doesn't count the failure towards the backoff limit and `Count` handles the failure in the default way.",
+									Required:     true,
+									ValidateFunc: validation.StringInSlice([]string{"FailJob", "FailIndex", "Ignore", "Count"}, false),
 								},
 								"on_exit_codes": {
-									Type:     schema.TypeList,
-									Optional: true,
-									MaxItems: 1,
+									Type:        schema.TypeList,
+									Description: "Requirement on the exit codes of the containers of the failed pod. Exactly one of `on_exit_codes` and `on_pod_condition` must be set.",
+									Optional:    true,
+									MaxItems:    1,
 									Elem: &schema.Resource{
 										Schema: map[string]*schema.Schema{
 											"container_name": {
-												Type:     schema.TypeString,
-												Optional: true,
+												Type:        schema.TypeString,
+												Description: "Restricts the check of the exit codes to the container with the given name. When omitted, the rule matches when any container exits with a matching exit code.",
+												Optional:    true,
 											},
 											"operator": {
-												Type:     schema.TypeString,
-												Optional: true,
+												Type:         schema.TypeString,
+												Description:  "The relationship between the exit codes of the containers and `values`, one of `In` or `NotIn`. Containers which exit successfully, with exit code 0, are excluded from the check.",
+												Required:     true,
+												ValidateFunc: validation.StringInSlice([]string{"In", "NotIn"}, false),
 											},
 											"values": {
-												Type:     schema.TypeList,
-												Required: true,
-												MinItems: 1,
-												MaxItems: 255,
+												Type:        schema.TypeList,
+												Description: "The exit codes, which cannot include 0.",
+												Required:    true,
+												MinItems:    1,
+												MaxItems:    255,
 												Elem: &schema.Schema{Type: schema.TypeInt,
 													ValidateFunc: validation.IntNotInSlice([]int{0})},
 											},
@@ -149,18 +166,23 @@ func jobSpecFields(specUpdatable bool) map[string]*schema.Schema {
 									},
 								},
 								"on_pod_condition": {
-									Type:     schema.TypeList,
-									Optional: true,
+									Type:        schema.TypeList,
+									Description: "Patterns of conditions of the failed pod, the rule matches when any of them matches a condition of the pod. Exactly one of `on_exit_codes` and `on_pod_condition` must be set.",
+									Optional:    true,
+									MaxItems:    20,
 									Elem: &schema.Resource{
 										Schema: map[string]*schema.Schema{
 											"status": {
-												Type:     schema.TypeString,
-												Optional: true,
-												Default:  "True",
+												Type:         schema.TypeString,
+												Description:  "The status of the condition, one of `True`, `False` or `Unknown`.",
+												Optional:     true,
+												Default:      "True",
+												ValidateFunc: validation.StringInSlice([]string{"True", "False", "Unknown"}, false),
 											},
 											"type": {
-												Type:     schema.TypeString,
-												Optional: true,
+												Type:        schema.TypeString,
+												Description: "The type of the condition, e.g. `DisruptionTarget`.",
+												Required:    true,
 											},
 										},
 									},
@@ -171,6 +193,16 @@ func jobSpecFields(specUpdatable bool) map[string]*schema.Schema {
 				},
 			},
 		},
+		"pod_replacement_policy": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(batchv1.TerminatingOrFailed),
+				string(batchv1.Failed),
+			}, false),
+			Description: "Specifies when to create the replacements of pods. `TerminatingOrFailed` replaces pods as soon as they are terminating, `Failed` waits for them to be fully terminated, so that two pods of the job never run at the same time, e.g. during a disruption. It must be `Failed` when `pod_failure_policy` is set. Defaults to `Failed` when `pod_failure_policy` is set, and to `TerminatingOrFailed` otherwise. More info: https://kubernetes.io/docs/concepts/workloads/controllers/job/#pod-replacement-policy",
+		},
 		// This field is immutable in Jobs.
 		"selector": {
 			Type:        schema.TypeList,
@@ -220,6 +252,45 @@ func jobSpecFields(specUpdatable bool) map[string]*schema.Schema {
 				},
 			},
 		},
+		"success_policy": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Description: "Specifies the policy of declaring the job succeeded before all its indexes succeeded, e.g. when the success of a leader index is enough. It can only be set when `completion_mode` is `Indexed`. More info: https://kubernetes.io/docs/concepts/workloads/controllers/job/#success-policy",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"rule": {
+						Type:        schema.TypeList,
+						Description: "The alternative rules of the policy. Once one of them is met, the job gets the `SuccessCriteriaMet` condition, its lingering pods are terminated, and it completes.",
+						Required:    true,
+						MaxItems:    20,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"succeeded_indexes": {
+									Type:         schema.TypeString,
+									Description:  "The indexes which must all succeed, as intervals separated by commas, e.g. `0,3-5`. When `succeeded_count` is set too, only the given number of these indexes must succeed.",
+									Optional:     true,
+									ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d+(-\d+)?(,\d+(-\d+)?)*$`), "must be a list of indexes and intervals of indexes separated by commas, e.g. `0,3-5`"),
+								},
+								"succeeded_count": {
+									Type:         schema.TypeInt,
+									Description:  "The number of indexes which must succeed, among `succeeded_indexes` when it is set.",
+									Optional:     true,
+									ValidateFunc: validation.IntAtLeast(1),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"suspend": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Specifies whether the job controller should create pods or not. Suspending a running job terminates its active pods, and resuming it creates them again. It can be changed without recreating the job. Defaults to false. More info: https://kubernetes.io/docs/concepts/workloads/controllers/job/#suspending-a-job",
+		},
 		// PodTemplate fields are immutable in Jobs.
 		"template": {
 			Type:        schema.TypeList,
@@ -64,6 +64,35 @@ func podSpecFields(isUpdatable, isComputed bool) map[string]*schema.Schema {
 				},
 			},
 		},
+		"resource_claim": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    !isUpdatable,
+			Description: "Resource claims of the Dynamic Resource Allocation API which must be allocated and reserved before the pod is allowed to start. The claims are made available to the containers which reference them by name in `resources.claims`.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ForceNew:     !isUpdatable,
+						Description:  "The name of the claim inside the pod, referenced by the containers in `resources.claims`. Must be a DNS label.",
+						ValidateFunc: validateName,
+					},
+					"resource_claim_name": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						ForceNew:    !isUpdatable,
+						Description: "The name of a resource claim in the namespace of the pod. Exactly one of `resource_claim_name` and `resource_claim_template_name` must be set.",
+					},
+					"resource_claim_template_name": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						ForceNew:    !isUpdatable,
+						Description: "The name of a resource claim template in the namespace of the pod, from which a resource claim is created for the pod and deleted with it. Exactly one of `resource_claim_name` and `resource_claim_template_name` must be set.",
+					},
+				},
+			},
+		},
 		"init_container": {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -311,10 +340,21 @@ func podSpecFields(isUpdatable, isComputed bool) map[string]*schema.Schema {
 						ValidateFunc: validateTypeStringNullableInt,
 						ForceNew:     !isUpdatable,
 					},
+					"app_armor_profile": {
+						Type:        schema.TypeList,
+						Description: "The AppArmor options to use by the containers in this pod. Note that this field cannot be set when spec.os.name is windows.",
+						Optional:    true,
+						Computed:    true,
+						MaxItems:    1,
+						Elem: &schema.Resource{
+							Schema: appArmorProfileField(isUpdatable),
+						},
+					},
 					"seccomp_profile": {
 						Type:        schema.TypeList,
 						Description: "The seccomp options to use by the containers in this pod. Note that this field cannot be set when spec.os.name is windows.",
 						Optional:    true,
+						Computed:    true,
 						MaxItems:    1,
 						Elem: &schema.Resource{
 							Schema: seccompProfileField(isUpdatable),
@@ -567,6 +607,9 @@ func podSpecFields(isUpdatable, isComputed bool) map[string]*schema.Schema {
 			Elem:        volumeSchema(isUpdatable),
 		},
 	}
+	// Admission may set the seccomp profile of pods without a security context.
+	sc := s["security_context"]
+	sc.DiffSuppressFunc = suppressAdmissionSecurityContext(sc.Elem.(*schema.Resource))
 	return s
 }
 
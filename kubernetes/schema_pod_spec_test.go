@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestPodSpecSecurityContextDiff(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"spec": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     &schema.Resource{Schema: podSpecFields(true, false)},
+			},
+		},
+	}
+	podSpec := func(pod, container map[string]interface{}) map[string]interface{} {
+		spec := map[string]interface{}{"name": "web", "image": "nginx"}
+		c := map[string]interface{}{"container": []interface{}{spec}}
+		if pod != nil {
+			c["security_context"] = []interface{}{pod}
+		}
+		if container != nil {
+			spec["security_context"] = []interface{}{container}
+		}
+		return map[string]interface{}{"spec": []interface{}{c}}
+	}
+	seccompProfile := []interface{}{map[string]interface{}{"type": "RuntimeDefault"}}
+
+	cases := map[string]struct {
+		// The state holds the fields configured, and the seccomp profiles set
+		// by admission.
+		state    map[string]interface{}
+		config   map[string]interface{}
+		expected []string
+	}{
+		"unchanged": {
+			state: podSpec(
+				map[string]interface{}{"run_as_user": "1000", "seccomp_profile": seccompProfile},
+				map[string]interface{}{"run_as_non_root": true, "seccomp_profile": seccompProfile},
+			),
+			config: podSpec(
+				map[string]interface{}{"run_as_user": "1000"},
+				map[string]interface{}{"run_as_non_root": true},
+			),
+		},
+		"pod field removed": {
+			state: podSpec(
+				map[string]interface{}{"run_as_user": "1000", "seccomp_profile": seccompProfile},
+				map[string]interface{}{"run_as_non_root": true, "seccomp_profile": seccompProfile},
+			),
+			config: podSpec(
+				map[string]interface{}{"run_as_group": "1000"},
+				map[string]interface{}{"run_as_non_root": true},
+			),
+			expected: []string{"spec.0.security_context.0.run_as_user"},
+		},
+		"container field removed": {
+			state: podSpec(
+				map[string]interface{}{"run_as_user": "1000", "seccomp_profile": seccompProfile},
+				map[string]interface{}{"run_as_non_root": true, "seccomp_profile": seccompProfile},
+			),
+			config: podSpec(
+				map[string]interface{}{"run_as_user": "1000"},
+				map[string]interface{}{"read_only_root_filesystem": true},
+			),
+			expected: []string{"spec.0.container.0.security_context.0.run_as_non_root"},
+		},
+		"security contexts set by admission": {
+			state: podSpec(
+				map[string]interface{}{"seccomp_profile": seccompProfile},
+				map[string]interface{}{"seccomp_profile": seccompProfile},
+			),
+			config: podSpec(nil, nil),
+		},
+		"security contexts removed": {
+			state: podSpec(
+				map[string]interface{}{"run_as_user": "1000", "seccomp_profile": seccompProfile},
+				map[string]interface{}{"run_as_non_root": true, "seccomp_profile": seccompProfile},
+			),
+			config: podSpec(nil, nil),
+			expected: []string{
+				"spec.0.security_context.#",
+				"spec.0.container.0.security_context.#",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, r.Schema, tc.state)
+			d.SetId("default/web")
+			state := d.State()
+			b, err := json.Marshal(tc.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			state.RawConfig, err = ctyjson.Unmarshal(b, r.CoreConfigSchema().ImpliedType())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(tc.config), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, k := range tc.expected {
+				if diff == nil || diff.Attributes[k] == nil || diff.Attributes[k].Old == diff.Attributes[k].New {
+					t.Fatalf("Expected a change of %s to be planned, got %v", k, diff)
+				}
+			}
+			if len(tc.expected) > 0 || diff == nil {
+				return
+			}
+			for k, v := range diff.Attributes {
+				if v.Old != v.New && (k == "spec.0.security_context.#" || k == "spec.0.container.0.security_context.#" || k == "spec.0.security_context.0.seccomp_profile.#" || k == "spec.0.container.0.security_context.0.seccomp_profile.#") {
+					t.Fatalf("Expected the seccomp profiles set by admission to be kept, got a diff of %s", k)
+				}
+			}
+		})
+	}
+}
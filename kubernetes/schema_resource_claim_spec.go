@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	resourcev1beta1 "k8s.io/api/resource/v1beta1"
+)
+
+func deviceSelectorSchema(isUpdatable bool) *schema.Schema {
+	docSelector := resourcev1beta1.DeviceSelector{}.SwaggerDoc()
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Criteria which must be satisfied by a device for it to be considered. All selectors must be satisfied.",
+		Optional:    true,
+		ForceNew:    !isUpdatable,
+		MaxItems:    resourcev1beta1.DeviceSelectorsMaxSize,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cel": {
+					Type:        schema.TypeList,
+					Description: docSelector["cel"],
+					Required:    true,
+					ForceNew:    !isUpdatable,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"expression": {
+								Type:         schema.TypeString,
+								Description:  "A CEL expression which evaluates a single device, available as `device` with its `driver`, `attributes` and `capacity`, and must evaluate to true when the device satisfies the desired criteria, e.g. `device.driver == \"gpu.example.com\"`.",
+								Required:     true,
+								ForceNew:     !isUpdatable,
+								ValidateFunc: validation.StringLenBetween(1, resourcev1beta1.CELSelectorExpressionMaxLength),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func opaqueDeviceConfigurationSchema(isUpdatable bool) *schema.Schema {
+	docConfiguration := resourcev1beta1.DeviceConfiguration{}.SwaggerDoc()
+	docOpaque := resourcev1beta1.OpaqueDeviceConfiguration{}.SwaggerDoc()
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: docConfiguration["opaque"],
+		Required:    true,
+		ForceNew:    !isUpdatable,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"driver": {
+					Type:        schema.TypeString,
+					Description: docOpaque["driver"],
+					Required:    true,
+					ForceNew:    !isUpdatable,
+				},
+				"parameters": {
+					Type:             schema.TypeString,
+					Description:      "The configuration parameters of the driver as a JSON document, in a format defined by the vendor of the driver. Typically includes `apiVersion` and `kind`.",
+					Required:         true,
+					ForceNew:         !isUpdatable,
+					ValidateFunc:     validation.StringIsJSON,
+					DiffSuppressFunc: suppressEquivalentJSON,
+				},
+			},
+		},
+	}
+}
+
+func resourceClaimSpecFields() map[string]*schema.Schema {
+	docSpec := resourcev1beta1.ResourceClaimSpec{}.SwaggerDoc()
+	docClaim := resourcev1beta1.DeviceClaim{}.SwaggerDoc()
+	docRequest := resourcev1beta1.DeviceRequest{}.SwaggerDoc()
+	docConstraint := resourcev1beta1.DeviceConstraint{}.SwaggerDoc()
+	docClaimConfiguration := resourcev1beta1.DeviceClaimConfiguration{}.SwaggerDoc()
+
+	// The spec of resource claims is immutable.
+	return map[string]*schema.Schema{
+		"devices": {
+			Type:        schema.TypeList,
+			Description: docSpec["devices"],
+			Required:    true,
+			ForceNew:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"request": {
+						Type:        schema.TypeList,
+						Description: docClaim["requests"],
+						Optional:    true,
+						ForceNew:    true,
+						MaxItems:    resourcev1beta1.DeviceRequestsMaxSize,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"name": {
+									Type:         schema.TypeString,
+									Description:  docRequest["name"],
+									Required:     true,
+									ForceNew:     true,
+									ValidateFunc: validateName,
+								},
+								"device_class_name": {
+									Type:        schema.TypeString,
+									Description: docRequest["deviceClassName"],
+									Required:    true,
+									ForceNew:    true,
+								},
+								"selectors": deviceSelectorSchema(false),
+								"allocation_mode": {
+									Type:        schema.TypeString,
+									Description: "How devices are allocated to satisfy the request, `ExactCount` for the number of devices in `count`, or `All` for all the matching devices in a pool.",
+									Optional:    true,
+									ForceNew:    true,
+									Computed:    true,
+									ValidateFunc: validation.StringInSlice([]string{
+										string(resourcev1beta1.DeviceAllocationModeExactCount),
+										string(resourcev1beta1.DeviceAllocationModeAll),
+									}, false),
+								},
+								"count": {
+									Type:         schema.TypeInt,
+									Description:  "The number of devices to allocate when `allocation_mode` is `ExactCount`. Defaults to 1.",
+									Optional:     true,
+									ForceNew:     true,
+									Computed:     true,
+									ValidateFunc: validation.IntAtLeast(1),
+								},
+								"admin_access": {
+									Type:        schema.TypeBool,
+									Description: docRequest["adminAccess"],
+									Optional:    true,
+									ForceNew:    true,
+								},
+							},
+						},
+					},
+					"constraint": {
+						Type:        schema.TypeList,
+						Description: docClaim["constraints"],
+						Optional:    true,
+						ForceNew:    true,
+						MaxItems:    resourcev1beta1.DeviceConstraintsMaxSize,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"requests": {
+									Type:        schema.TypeList,
+									Description: docConstraint["requests"],
+									Optional:    true,
+									ForceNew:    true,
+									Elem:        &schema.Schema{Type: schema.TypeString},
+								},
+								"match_attribute": {
+									Type:        schema.TypeString,
+									Description: "The fully qualified name of an attribute which all the devices in question must have with the same type and value, e.g. `dra.example.com/numa`.",
+									Required:    true,
+									ForceNew:    true,
+								},
+							},
+						},
+					},
+					"config": {
+						Type:        schema.TypeList,
+						Description: docClaim["config"],
+						Optional:    true,
+						ForceNew:    true,
+						MaxItems:    resourcev1beta1.DeviceConfigMaxSize,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"requests": {
+									Type:        schema.TypeList,
+									Description: docClaimConfiguration["requests"],
+									Optional:    true,
+									ForceNew:    true,
+									Elem:        &schema.Schema{Type: schema.TypeString},
+								},
+								"opaque": opaqueDeviceConfigurationSchema(false),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
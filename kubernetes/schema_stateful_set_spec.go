@@ -10,6 +10,23 @@ import (
 
 func statefulSetSpecFields() map[string]*schema.Schema {
 	s := map[string]*schema.Schema{
+		"ordinals": {
+			Type:        schema.TypeList,
+			Description: "Controls the numbering of the replica indices of the StatefulSet, e.g. to move or slice a StatefulSet across clusters or namespaces. Requires Kubernetes 1.27 or later.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"start": {
+						Type:         schema.TypeInt,
+						Description:  "The number of the first replica index. The pods are named `<name>-<start>` to `<name>-<start + replicas - 1>`. Changing it scales the StatefulSet to the new range of indices, in place. Default value is 0.",
+						Optional:     true,
+						Default:      0,
+						ValidateFunc: validateNonNegativeInteger,
+					},
+				},
+			},
+		},
 		"pod_management_policy": {
 			Type:        schema.TypeString,
 			Description: "Controls how pods are created during initial scale up, when replacing pods on nodes, or when scaling down.",
@@ -108,12 +125,13 @@ func statefulSetSpecFields() map[string]*schema.Schema {
 			Type:        schema.TypeList,
 			Optional:    true,
 			Computed:    true,
+			MaxItems:    1,
 			Description: "The field controls if and how PVCs are deleted during the lifecycle of a StatefulSet.",
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"when_deleted": {
 						Type:        schema.TypeString,
-						Description: "This field controls what happens when a Statefulset is deleted. Default is Retain.",
+						Description: "What happens to the PVCs created from `volume_claim_template` when the stateful set is deleted: `Retain` keeps them, `Delete` deletes them once their pods are gone. Defaults to `Retain`.",
 						Optional:    true,
 						Default:     "Retain",
 						ValidateFunc: validation.StringInSlice([]string{
@@ -123,7 +141,7 @@ func statefulSetSpecFields() map[string]*schema.Schema {
 					},
 					"when_scaled": {
 						Type:        schema.TypeString,
-						Description: "This field controls what happens when a Statefulset is scaled. Default is Retain.",
+						Description: "What happens to the PVCs of the pods removed when the stateful set is scaled down: `Retain` keeps them, so that they are reused when it's scaled up again, `Delete` deletes them. Defaults to `Retain`.",
 						Optional:    true,
 						Default:     "Retain",
 						ValidateFunc: validation.StringInSlice([]string{
@@ -4,9 +4,12 @@
 package kubernetes
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	batch "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
 
@@ -42,6 +45,19 @@ func flattenCronJobSpecV1(in batch.CronJobSpec, d *schema.ResourceData, meta int
 	return []interface{}{att}, nil
 }
 
+func flattenCronJobStatusV1(in batch.CronJobStatus) []interface{} {
+	formatTime := func(t *metav1.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	return []interface{}{map[string]interface{}{
+		"last_schedule_time":   formatTime(in.LastScheduleTime),
+		"last_successful_time": formatTime(in.LastSuccessfulTime),
+	}}
+}
+
 func flattenJobTemplateV1(in batch.JobTemplateSpec, d *schema.ResourceData, meta interface{}) ([]interface{}, error) {
 	att := make(map[string]interface{})
 
@@ -0,0 +1,51 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Expanders
+
+func expandEventV1Regarding(in []interface{}) corev1.ObjectReference {
+	ref := corev1.ObjectReference{}
+	if len(in) == 0 || in[0] == nil {
+		return ref
+	}
+	m := in[0].(map[string]interface{})
+	if v, ok := m["api_version"].(string); ok {
+		ref.APIVersion = v
+	}
+	if v, ok := m["field_path"].(string); ok {
+		ref.FieldPath = v
+	}
+	if v, ok := m["kind"].(string); ok {
+		ref.Kind = v
+	}
+	if v, ok := m["name"].(string); ok {
+		ref.Name = v
+	}
+	if v, ok := m["namespace"].(string); ok {
+		ref.Namespace = v
+	}
+	if v, ok := m["uid"].(string); ok {
+		ref.UID = types.UID(v)
+	}
+	return ref
+}
+
+// Flatteners
+
+func flattenEventV1Regarding(in corev1.ObjectReference) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"api_version": in.APIVersion,
+		"field_path":  in.FieldPath,
+		"kind":        in.Kind,
+		"name":        in.Name,
+		"namespace":   in.Namespace,
+		"uid":         string(in.UID),
+	}}
+}
@@ -5,6 +5,7 @@ package kubernetes
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
@@ -519,3 +520,74 @@ func patchHorizontalPodAutoscalerV2Spec(prefix string, pathPrefix string, d *sch
 
 	return ops
 }
+
+func flattenHorizontalPodAutoscalerV2Status(status autoscalingv2.HorizontalPodAutoscalerStatus) []interface{} {
+	m := map[string]interface{}{
+		"current_replicas": status.CurrentReplicas,
+		"desired_replicas": status.DesiredReplicas,
+	}
+	if status.LastScaleTime != nil {
+		m["last_scale_time"] = status.LastScaleTime.Format(time.RFC3339)
+	}
+	if status.ObservedGeneration != nil {
+		m["observed_generation"] = *status.ObservedGeneration
+	}
+
+	metrics := make([]interface{}, 0, len(status.CurrentMetrics))
+	for _, metric := range status.CurrentMetrics {
+		metrics = append(metrics, flattenV2MetricStatus(metric))
+	}
+	m["current_metric"] = metrics
+
+	conditions := make([]interface{}, 0, len(status.Conditions))
+	for _, c := range status.Conditions {
+		conditions = append(conditions, map[string]interface{}{
+			"type":                 string(c.Type),
+			"status":               string(c.Status),
+			"reason":               c.Reason,
+			"message":              c.Message,
+			"last_transition_time": c.LastTransitionTime.Format(time.RFC3339),
+		})
+	}
+	m["condition"] = conditions
+
+	return []interface{}{m}
+}
+
+func flattenV2MetricStatus(status autoscalingv2.MetricStatus) map[string]interface{} {
+	m := map[string]interface{}{
+		"type": string(status.Type),
+	}
+
+	var current autoscalingv2.MetricValueStatus
+	switch {
+	case status.Resource != nil:
+		m["name"] = status.Resource.Name.String()
+		current = status.Resource.Current
+	case status.ContainerResource != nil:
+		m["name"] = status.ContainerResource.Name.String()
+		m["container"] = status.ContainerResource.Container
+		current = status.ContainerResource.Current
+	case status.Pods != nil:
+		m["name"] = status.Pods.Metric.Name
+		current = status.Pods.Current
+	case status.Object != nil:
+		m["name"] = status.Object.Metric.Name
+		current = status.Object.Current
+	case status.External != nil:
+		m["name"] = status.External.Metric.Name
+		current = status.External.Current
+	}
+
+	if current.AverageUtilization != nil {
+		m["average_utilization"] = *current.AverageUtilization
+	}
+	if current.AverageValue != nil {
+		m["average_value"] = current.AverageValue.String()
+	}
+	if current.Value != nil {
+		m["value"] = current.Value.String()
+	}
+
+	return m
+}
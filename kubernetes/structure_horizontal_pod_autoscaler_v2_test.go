@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestFlattenHorizontalPodAutoscalerV2Status(t *testing.T) {
+	status := autoscalingv2.HorizontalPodAutoscalerStatus{
+		CurrentReplicas:    2,
+		DesiredReplicas:    3,
+		ObservedGeneration: ptr.To(int64(4)),
+		CurrentMetrics: []autoscalingv2.MetricStatus{
+			{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricStatus{
+					Name: corev1.ResourceCPU,
+					Current: autoscalingv2.MetricValueStatus{
+						AverageUtilization: ptr.To(int32(85)),
+						AverageValue:       ptr.To(resource.MustParse("170m")),
+					},
+				},
+			},
+			{
+				Type: autoscalingv2.ExternalMetricSourceType,
+				External: &autoscalingv2.ExternalMetricStatus{
+					Metric:  autoscalingv2.MetricIdentifier{Name: "queue_length"},
+					Current: autoscalingv2.MetricValueStatus{Value: ptr.To(resource.MustParse("12"))},
+				},
+			},
+		},
+		Conditions: []autoscalingv2.HorizontalPodAutoscalerCondition{
+			{
+				Type:    autoscalingv2.AbleToScale,
+				Status:  corev1.ConditionTrue,
+				Reason:  "ReadyForNewScale",
+				Message: "recommended size matches current size",
+			},
+		},
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"current_replicas":    int32(2),
+			"desired_replicas":    int32(3),
+			"observed_generation": int64(4),
+			"current_metric": []interface{}{
+				map[string]interface{}{
+					"type":                "Resource",
+					"name":                "cpu",
+					"average_utilization": int32(85),
+					"average_value":       "170m",
+				},
+				map[string]interface{}{
+					"type":  "External",
+					"name":  "queue_length",
+					"value": "12",
+				},
+			},
+			"condition": []interface{}{
+				map[string]interface{}{
+					"type":                 "AbleToScale",
+					"status":               "True",
+					"reason":               "ReadyForNewScale",
+					"message":              "recommended size matches current size",
+					"last_transition_time": "0001-01-01T00:00:00Z",
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, flattenHorizontalPodAutoscalerV2Status(status)); diff != "" {
+		t.Fatalf("Unexpected output: mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHorizontalPodAutoscalerV2AbleToScale(t *testing.T) {
+	hpa := func(conditions ...autoscalingv2.HorizontalPodAutoscalerCondition) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Status:     autoscalingv2.HorizontalPodAutoscalerStatus{Conditions: conditions},
+		}
+	}
+
+	if err := horizontalPodAutoscalerV2AbleToScale(hpa(
+		autoscalingv2.HorizontalPodAutoscalerCondition{Type: autoscalingv2.ScalingActive, Status: corev1.ConditionFalse},
+		autoscalingv2.HorizontalPodAutoscalerCondition{Type: autoscalingv2.AbleToScale, Status: corev1.ConditionTrue},
+	)); err != nil {
+		t.Fatalf("Expected the autoscaler to be able to scale, got %v", err.Err)
+	}
+
+	err := horizontalPodAutoscalerV2AbleToScale(hpa(autoscalingv2.HorizontalPodAutoscalerCondition{
+		Type:    autoscalingv2.AbleToScale,
+		Status:  corev1.ConditionFalse,
+		Reason:  "FailedGetScale",
+		Message: `deployments/scale.apps "web" not found`,
+	}))
+	if err == nil || !err.Retryable {
+		t.Fatalf("Expected a retryable error, got %v", err)
+	}
+	if !strings.Contains(err.Err.Error(), "AbleToScale is False (FailedGetScale)") {
+		t.Fatalf("Expected the error to explain the condition, got %q", err.Err)
+	}
+
+	if err := horizontalPodAutoscalerV2AbleToScale(hpa()); err == nil || !err.Retryable {
+		t.Fatalf("Expected a retryable error without conditions, got %v", err)
+	}
+}
@@ -4,11 +4,16 @@
 package kubernetes
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
 
@@ -39,6 +44,10 @@ func flattenJobV1Spec(in batchv1.JobSpec, d *schema.ResourceData, meta interface
 		att["completion_mode"] = string(*in.CompletionMode)
 	}
 
+	if in.ManagedBy != nil {
+		att["managed_by"] = *in.ManagedBy
+	}
+
 	if in.ManualSelector != nil {
 		att["manual_selector"] = *in.ManualSelector
 	}
@@ -51,10 +60,22 @@ func flattenJobV1Spec(in batchv1.JobSpec, d *schema.ResourceData, meta interface
 		att["pod_failure_policy"] = flattenPodFailurePolicy(in.PodFailurePolicy)
 	}
 
+	if in.PodReplacementPolicy != nil {
+		att["pod_replacement_policy"] = string(*in.PodReplacementPolicy)
+	}
+
 	if in.Selector != nil {
 		att["selector"] = flattenLabelSelector(in.Selector)
 	}
 
+	if in.SuccessPolicy != nil {
+		att["success_policy"] = flattenJobV1SuccessPolicy(in.SuccessPolicy)
+	}
+
+	if in.Suspend != nil {
+		att["suspend"] = *in.Suspend
+	}
+
 	removeGeneratedLabels(in.Template.ObjectMeta.Labels)
 
 	podSpec, err := flattenPodTemplateSpec(in.Template)
@@ -100,6 +121,10 @@ func expandJobV1Spec(j []interface{}) (batchv1.JobSpec, error) {
 		obj.CompletionMode = &m
 	}
 
+	if v, ok := in["managed_by"].(string); ok && v != "" {
+		obj.ManagedBy = ptr.To(v)
+	}
+
 	if v, ok := in["manual_selector"]; ok {
 		obj.ManualSelector = ptr.To(v.(bool))
 	}
@@ -116,10 +141,22 @@ func expandJobV1Spec(j []interface{}) (batchv1.JobSpec, error) {
 		obj.PodFailurePolicy = expandPodFailurePolicy(v)
 	}
 
+	if v, ok := in["pod_replacement_policy"].(string); ok && v != "" {
+		obj.PodReplacementPolicy = ptr.To(batchv1.PodReplacementPolicy(v))
+	}
+
 	if v, ok := in["selector"].([]interface{}); ok && len(v) > 0 {
 		obj.Selector = expandLabelSelector(v)
 	}
 
+	if v, ok := in["success_policy"].([]interface{}); ok && len(v) > 0 {
+		obj.SuccessPolicy = expandJobV1SuccessPolicy(v)
+	}
+
+	if v, ok := in["suspend"].(bool); ok {
+		obj.Suspend = ptr.To(v)
+	}
+
 	template, err := expandPodTemplate(in["template"].([]interface{}))
 	if err != nil {
 		return obj, err
@@ -241,7 +278,7 @@ func flattenPodFailurePolicyRules(in []batchv1.PodFailurePolicyRule) []interface
 
 	for i, r := range in {
 		m := make(map[string]interface{})
-		m["action"] = r.Action
+		m["action"] = string(r.Action)
 		if r.OnExitCodes != nil {
 			m["on_exit_codes"] = flattenPodFailurePolicyOnExitCodes(r.OnExitCodes)
 		}
@@ -256,10 +293,10 @@ func flattenPodFailurePolicyRules(in []batchv1.PodFailurePolicyRule) []interface
 
 func flattenPodFailurePolicyOnExitCodes(in *batchv1.PodFailurePolicyOnExitCodesRequirement) []interface{} {
 	att := make(map[string]interface{})
-	if *in.ContainerName != "" {
+	if in.ContainerName != nil && *in.ContainerName != "" {
 		att["container_name"] = *in.ContainerName
 	}
-	att["operator"] = in.Operator
+	att["operator"] = string(in.Operator)
 	if len(in.Values) > 0 {
 		vals := make([]int, len(in.Values))
 		for i := 0; i < len(vals); i++ {
@@ -277,10 +314,10 @@ func flattenPodFailurePolicyOnPodConditions(in []batchv1.PodFailurePolicyOnPodCo
 	for i, r := range in {
 		m := make(map[string]interface{})
 		if r.Status != "" {
-			m["status"] = r.Status
+			m["status"] = string(r.Status)
 		}
 		if r.Type != "" {
-			m["type"] = r.Type
+			m["type"] = string(r.Type)
 		}
 		att[i] = m
 	}
@@ -288,6 +325,46 @@ func flattenPodFailurePolicyOnPodConditions(in []batchv1.PodFailurePolicyOnPodCo
 	return att
 }
 
+func expandJobV1SuccessPolicy(l []interface{}) *batchv1.SuccessPolicy {
+	obj := &batchv1.SuccessPolicy{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+
+	for _, r := range in["rule"].([]interface{}) {
+		rule := batchv1.SuccessPolicyRule{}
+		if r != nil {
+			m := r.(map[string]interface{})
+			if v, ok := m["succeeded_indexes"].(string); ok && v != "" {
+				rule.SucceededIndexes = ptr.To(v)
+			}
+			if v, ok := m["succeeded_count"].(int); ok && v > 0 {
+				rule.SucceededCount = ptr.To(int32(v))
+			}
+		}
+		obj.Rules = append(obj.Rules, rule)
+	}
+	return obj
+}
+
+func flattenJobV1SuccessPolicy(in *batchv1.SuccessPolicy) []interface{} {
+	rules := make([]interface{}, len(in.Rules))
+	for i, r := range in.Rules {
+		m := make(map[string]interface{})
+		if r.SucceededIndexes != nil {
+			m["succeeded_indexes"] = *r.SucceededIndexes
+		}
+		if r.SucceededCount != nil {
+			m["succeeded_count"] = int(*r.SucceededCount)
+		}
+		rules[i] = m
+	}
+	return []interface{}{map[string]interface{}{
+		"rule": rules,
+	}}
+}
+
 func patchJobV1Spec(pathPrefix, prefix string, d *schema.ResourceData) PatchOperations {
 	ops := make([]PatchOperation, 0)
 
@@ -323,6 +400,33 @@ func patchJobV1Spec(pathPrefix, prefix string, d *schema.ResourceData) PatchOper
 		})
 	}
 
+	if d.HasChange(prefix + "max_failed_indexes") {
+		if rawConfigAt(d.GetRawConfig(), prefix+"max_failed_indexes").IsNull() {
+			ops = append(ops, &RemoveOperation{
+				Path: pathPrefix + "/maxFailedIndexes",
+			})
+		} else {
+			ops = append(ops, &AddOperation{
+				Path:  pathPrefix + "/maxFailedIndexes",
+				Value: d.Get(prefix + "max_failed_indexes").(int),
+			})
+		}
+	}
+
+	if v := d.Get(prefix + "pod_replacement_policy").(string); d.HasChange(prefix+"pod_replacement_policy") && v != "" {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "/podReplacementPolicy",
+			Value: v,
+		})
+	}
+
+	if d.HasChange(prefix + "suspend") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "/suspend",
+			Value: d.Get(prefix + "suspend").(bool),
+		})
+	}
+
 	if d.HasChange(prefix + "pod_failure_policy") {
 		ops = append(ops, &ReplaceOperation{
 			Path:  pathPrefix + "/podFailurePolicy",
@@ -333,6 +437,258 @@ func patchJobV1Spec(pathPrefix, prefix string, d *schema.ResourceData) PatchOper
 	return ops
 }
 
+func flattenJobV1Status(in batchv1.JobStatus) []interface{} {
+	formatTime := func(t *metav1.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	conditions := make([]interface{}, len(in.Conditions))
+	for i, c := range in.Conditions {
+		conditions[i] = map[string]interface{}{
+			"type":                 string(c.Type),
+			"status":               string(c.Status),
+			"reason":               c.Reason,
+			"message":              c.Message,
+			"last_transition_time": formatTime(&c.LastTransitionTime),
+		}
+	}
+	ready := 0
+	if in.Ready != nil {
+		ready = int(*in.Ready)
+	}
+	failedIndexes := ""
+	if in.FailedIndexes != nil {
+		failedIndexes = *in.FailedIndexes
+	}
+	return []interface{}{map[string]interface{}{
+		"start_time":        formatTime(in.StartTime),
+		"completion_time":   formatTime(in.CompletionTime),
+		"active":            int(in.Active),
+		"ready":             ready,
+		"succeeded":         int(in.Succeeded),
+		"failed":            int(in.Failed),
+		"completed_indexes": in.CompletedIndexes,
+		"failed_indexes":    failedIndexes,
+		"condition":         conditions,
+	}}
+}
+
+// expandJobV1WaitForCondition returns the condition a job is waited for
+// instead of its completion, if any, and how long it's waited for, which
+// defaults to the given timeout of the operation.
+func expandJobV1WaitForCondition(l []interface{}, timeout time.Duration) (*batchv1.JobCondition, time.Duration) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, timeout
+	}
+	in := l[0].(map[string]interface{})
+	if v, ok := in["timeout"].(string); ok && v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			timeout = d
+		}
+	}
+	return &batchv1.JobCondition{
+		Type:   batchv1.JobConditionType(in["type"].(string)),
+		Status: v1.ConditionStatus(in["status"].(string)),
+	}, timeout
+}
+
+// unsetJobV1IndexLimits unsets the limits of the failures of an indexed job
+// which aren't configured, given the configuration of its spec, as they can't
+// be told apart from a limit of 0 otherwise.
+func unsetJobV1IndexLimits(spec *batchv1.JobSpec, config cty.Value) {
+	if rawConfigAt(config, "backoff_limit_per_index").IsNull() {
+		spec.BackoffLimitPerIndex = nil
+	}
+	if rawConfigAt(config, "max_failed_indexes").IsNull() {
+		spec.MaxFailedIndexes = nil
+	}
+}
+
+// validateJobV1Spec checks the combinations of fields of a job spec which
+// the API server would reject, given its configuration: the fields specific to
+// indexed jobs are only allowed for indexed jobs, whose counts are bounded, and
+// a pod failure policy requires pods to be replaced once they failed.
+func validateJobV1Spec(config cty.Value) error {
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	replacement := rawConfigAt(config, "pod_replacement_policy")
+	failurePolicy := rawConfigAt(config, "pod_failure_policy")
+	if !replacement.IsNull() && replacement.IsKnown() && replacement.AsString() != string(batchv1.Failed) &&
+		!failurePolicy.IsNull() && failurePolicy.IsKnown() && failurePolicy.LengthInt() > 0 {
+		return fmt.Errorf("`pod_replacement_policy` must be `Failed` when `pod_failure_policy` is set")
+	}
+	if err := validateJobV1PodFailurePolicy(failurePolicy); err != nil {
+		return err
+	}
+	perIndex := !config.GetAttr("backoff_limit_per_index").IsNull()
+	maxFailed := !config.GetAttr("max_failed_indexes").IsNull()
+	successPolicy := config.GetAttr("success_policy")
+	if maxFailed && !perIndex {
+		return fmt.Errorf("`max_failed_indexes` can only be set together with `backoff_limit_per_index`")
+	}
+	if successPolicy.IsKnown() && !successPolicy.IsNull() {
+		for it := successPolicy.ElementIterator(); it.Next(); {
+			_, policy := it.Element()
+			rules := rawConfigAt(policy, "rule")
+			if rules.IsNull() || !rules.IsKnown() {
+				continue
+			}
+			for it := rules.ElementIterator(); it.Next(); {
+				_, rule := it.Element()
+				if rule.GetAttr("succeeded_indexes").IsNull() && rule.GetAttr("succeeded_count").IsNull() {
+					return fmt.Errorf("every `rule` of `success_policy` must set `succeeded_indexes`, `succeeded_count` or both")
+				}
+			}
+		}
+	}
+
+	mode := config.GetAttr("completion_mode")
+	if !mode.IsKnown() {
+		return nil
+	}
+	if !mode.IsNull() && mode.AsString() == string(batchv1.IndexedCompletion) {
+		return validateJobV1IndexedCounts(config)
+	}
+	switch {
+	case perIndex:
+		return fmt.Errorf("`backoff_limit_per_index` can only be set when `completion_mode` is `Indexed`")
+	case maxFailed:
+		return fmt.Errorf("`max_failed_indexes` can only be set when `completion_mode` is `Indexed`")
+	case !successPolicy.IsNull() && successPolicy.IsKnown() && successPolicy.LengthInt() > 0:
+		return fmt.Errorf("`success_policy` can only be set when `completion_mode` is `Indexed`")
+	}
+	return nil
+}
+
+// validateJobV1PodFailurePolicy checks that every rule of the pod failure policy
+// sets exactly one of on_exit_codes and on_pod_condition, which the API server
+// would otherwise reject on apply. Rules with unknown requirements are skipped.
+func validateJobV1PodFailurePolicy(failurePolicy cty.Value) error {
+	rules := rawConfigAt(failurePolicy, "0.rule")
+	if rules.IsNull() || !rules.IsKnown() {
+		return nil
+	}
+	for it := rules.ElementIterator(); it.Next(); {
+		_, rule := it.Element()
+		if rule.IsNull() || !rule.IsKnown() {
+			continue
+		}
+		set := 0
+		for _, k := range []string{"on_exit_codes", "on_pod_condition"} {
+			v := rawConfigAt(rule, k)
+			if !v.IsKnown() {
+				set = 1
+				break
+			}
+			if !v.IsNull() && v.LengthInt() > 0 {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("every `rule` of `pod_failure_policy` must set exactly one of `on_exit_codes` and `on_pod_condition`")
+		}
+	}
+	return nil
+}
+
+const (
+	// jobV1IndexedLimit is the limit of the parallelism and of the failed
+	// indexes of an indexed job, above which the API server rejects it, and
+	// the number of completions above which the failed indexes must be
+	// limited when the backoff limit is per index.
+	jobV1IndexedLimit = 100000
+	// jobV1HighCompletionsParallelismLimit is the limit of the parallelism of
+	// an indexed job with a backoff limit per index and more completions than
+	// jobV1IndexedLimit.
+	jobV1HighCompletionsParallelismLimit = 10000
+)
+
+// validateJobV1IndexedCounts checks the counts of an indexed job against its
+// completions and the limits of the API server, given the configuration of its
+// spec. Unknown counts are skipped, and unset counts take their defaults.
+func validateJobV1IndexedCounts(config cty.Value) error {
+	count := func(key string, def int64) (int64, bool) {
+		v := config.GetAttr(key)
+		switch {
+		case !v.IsKnown():
+			return 0, false
+		case v.IsNull():
+			return def, def >= 0
+		}
+		n, _ := v.AsBigFloat().Int64()
+		return n, true
+	}
+	completions, knownCompletions := count("completions", 1)
+	parallelism, knownParallelism := count("parallelism", 1)
+	maxFailed, knownMaxFailed := count("max_failed_indexes", -1)
+	perIndex := !config.GetAttr("backoff_limit_per_index").IsNull()
+
+	if knownParallelism && parallelism > jobV1IndexedLimit {
+		return fmt.Errorf("`parallelism` must be at most %d when `completion_mode` is `Indexed`", jobV1IndexedLimit)
+	}
+	if !knownCompletions {
+		return nil
+	}
+	if knownMaxFailed && maxFailed > completions {
+		return fmt.Errorf("`max_failed_indexes` must be at most `completions` (%d)", completions)
+	}
+	if knownMaxFailed && maxFailed > jobV1IndexedLimit {
+		return fmt.Errorf("`max_failed_indexes` must be at most %d", jobV1IndexedLimit)
+	}
+	if perIndex && completions > jobV1IndexedLimit {
+		if !config.GetAttr("max_failed_indexes").IsKnown() {
+			return nil
+		}
+		if !knownMaxFailed {
+			return fmt.Errorf("`max_failed_indexes` must be set when `completions` is above %d with `backoff_limit_per_index`", jobV1IndexedLimit)
+		}
+		if knownParallelism && parallelism > jobV1HighCompletionsParallelismLimit {
+			return fmt.Errorf("`parallelism` must be at most %d when `completions` is above %d with `backoff_limit_per_index`", jobV1HighCompletionsParallelismLimit, jobV1IndexedLimit)
+		}
+	}
+
+	successPolicy := config.GetAttr("success_policy")
+	if !successPolicy.IsKnown() || successPolicy.IsNull() {
+		return nil
+	}
+	for it := successPolicy.ElementIterator(); it.Next(); {
+		_, policy := it.Element()
+		rules := rawConfigAt(policy, "rule")
+		if rules.IsNull() || !rules.IsKnown() {
+			continue
+		}
+		for it := rules.ElementIterator(); it.Next(); {
+			_, rule := it.Element()
+			if count := rule.GetAttr("succeeded_count"); count.IsKnown() && !count.IsNull() {
+				if n, _ := count.AsBigFloat().Int64(); n > completions {
+					return fmt.Errorf("`succeeded_count` of `success_policy` must be at most `completions` (%d)", completions)
+				}
+			}
+			if indexes := rule.GetAttr("succeeded_indexes"); indexes.IsKnown() && !indexes.IsNull() {
+				if last := lastJobV1Index(indexes.AsString()); last >= completions {
+					return fmt.Errorf("`succeeded_indexes` of `success_policy` must be lower than `completions` (%d), got index %d", completions, last)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// lastJobV1Index returns the highest index of a list of indexes and ranges of
+// indexes, e.g. 7 for `1,3-5,7`, or -1 if there's none.
+func lastJobV1Index(indexes string) int64 {
+	last := int64(-1)
+	for _, index := range strings.FieldsFunc(indexes, func(r rune) bool { return r == ',' || r == '-' }) {
+		if n, err := strconv.ParseInt(index, 10, 64); err == nil && n > last {
+			last = n
+		}
+	}
+	return last
+}
+
 // removeGeneratedLabels removes server-generated labels
 func removeGeneratedLabels(labels map[string]string) map[string]string {
 	// The Jobs controller adds the following labels to the template block dynamically
@@ -0,0 +1,307 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-cty/cty"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func TestFlattenJobV1Status(t *testing.T) {
+	started := metav1.NewTime(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	failed := metav1.NewTime(time.Date(2024, 5, 1, 10, 2, 30, 0, time.UTC))
+	status := batchv1.JobStatus{
+		StartTime:        &started,
+		Active:           0,
+		Ready:            ptr.To(int32(0)),
+		Failed:           4,
+		CompletedIndexes: "0,2-3",
+		FailedIndexes:    ptr.To("1"),
+		Conditions: []batchv1.JobCondition{{
+			Type:               batchv1.JobFailed,
+			Status:             corev1.ConditionTrue,
+			Reason:             "BackoffLimitExceeded",
+			Message:            "Job has reached the specified backoff limit",
+			LastTransitionTime: failed,
+		}},
+	}
+	expected := []interface{}{map[string]interface{}{
+		"start_time":        "2024-05-01T10:00:00Z",
+		"completion_time":   "",
+		"active":            0,
+		"ready":             0,
+		"succeeded":         0,
+		"failed":            4,
+		"completed_indexes": "0,2-3",
+		"failed_indexes":    "1",
+		"condition": []interface{}{map[string]interface{}{
+			"type":                 "Failed",
+			"status":               "True",
+			"reason":               "BackoffLimitExceeded",
+			"message":              "Job has reached the specified backoff limit",
+			"last_transition_time": "2024-05-01T10:02:30Z",
+		}},
+	}}
+	if diff := cmp.Diff(expected, flattenJobV1Status(status)); diff != "" {
+		t.Fatalf("Unexpected status: mismatch (-want +got):\n%s", diff)
+	}
+
+	empty := flattenJobV1Status(batchv1.JobStatus{})[0].(map[string]interface{})
+	if empty["start_time"] != "" || empty["ready"] != 0 || empty["failed_indexes"] != "" || len(empty["condition"].([]interface{})) != 0 {
+		t.Fatalf("Unexpected status of a job which didn't start: %#v", empty)
+	}
+}
+
+func TestPodFailurePolicyRoundTrip(t *testing.T) {
+	policy := &batchv1.PodFailurePolicy{
+		Rules: []batchv1.PodFailurePolicyRule{
+			{
+				Action: batchv1.PodFailurePolicyActionFailJob,
+				OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+					ContainerName: ptr.To("main"),
+					Operator:      batchv1.PodFailurePolicyOnExitCodesOpIn,
+					Values:        []int32{1, 42},
+				},
+			},
+			{
+				Action: batchv1.PodFailurePolicyActionCount,
+				// The API server omits the container name when it isn't set.
+				OnExitCodes: &batchv1.PodFailurePolicyOnExitCodesRequirement{
+					Operator: batchv1.PodFailurePolicyOnExitCodesOpNotIn,
+					Values:   []int32{2, 3},
+				},
+			},
+			{
+				Action: batchv1.PodFailurePolicyActionIgnore,
+				OnPodConditions: []batchv1.PodFailurePolicyOnPodConditionsPattern{
+					{Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue},
+				},
+			},
+		},
+	}
+	flattened := flattenPodFailurePolicy(policy)
+	rules := flattened[0].(map[string]interface{})["rule"].([]interface{})
+	if _, ok := rules[1].(map[string]interface{})["on_exit_codes"].([]interface{})[0].(map[string]interface{})["container_name"]; ok {
+		t.Fatalf("Expected no container name in the second rule, got %#v", rules[1])
+	}
+
+	// The exit codes are read from the configuration as []interface{}, unlike
+	// the []int of the flattened rules.
+	for _, r := range rules {
+		if codes, ok := r.(map[string]interface{})["on_exit_codes"].([]interface{}); ok {
+			values := codes[0].(map[string]interface{})["values"].([]int)
+			l := make([]interface{}, len(values))
+			for i, v := range values {
+				l[i] = v
+			}
+			codes[0].(map[string]interface{})["values"] = l
+		}
+	}
+	if diff := cmp.Diff(policy, expandPodFailurePolicy(flattened)); diff != "" {
+		t.Fatalf("Unexpected pod failure policy: mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidateJobV1Spec(t *testing.T) {
+	ruleType := cty.Object(map[string]cty.Type{"succeeded_indexes": cty.String, "succeeded_count": cty.Number})
+	policyType := cty.Object(map[string]cty.Type{"rule": cty.List(ruleType)})
+	noPolicy := cty.ListValEmpty(policyType)
+	successPolicy := func(indexes, count cty.Value) cty.Value {
+		return cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"rule": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"succeeded_indexes": indexes,
+				"succeeded_count":   count,
+			})}),
+		})})
+	}
+	config := func(mode cty.Value, perIndex, maxFailed, successPolicy cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"completion_mode":         mode,
+			"backoff_limit_per_index": perIndex,
+			"max_failed_indexes":      maxFailed,
+			"success_policy":          successPolicy,
+			"completions":             cty.NullVal(cty.Number),
+			"parallelism":             cty.NullVal(cty.Number),
+		})
+	}
+	withCounts := func(config cty.Value, completions, parallelism cty.Value) cty.Value {
+		attrs := config.AsValueMap()
+		attrs["completions"] = completions
+		attrs["parallelism"] = parallelism
+		return cty.ObjectVal(attrs)
+	}
+	unset := cty.NullVal(cty.Number)
+	defaultMode := cty.NullVal(cty.String)
+	indexed := cty.StringVal("Indexed")
+	withReplacement := func(policy string, failurePolicy bool) cty.Value {
+		attrs := config(defaultMode, unset, unset, noPolicy).AsValueMap()
+		attrs["pod_replacement_policy"] = cty.StringVal(policy)
+		attrs["pod_failure_policy"] = cty.ListValEmpty(cty.EmptyObject)
+		if failurePolicy {
+			attrs["pod_failure_policy"] = cty.ListVal([]cty.Value{cty.EmptyObjectVal})
+		}
+		return cty.ObjectVal(attrs)
+	}
+	exitCodesType := cty.List(cty.Object(map[string]cty.Type{"operator": cty.String}))
+	podConditionType := cty.List(cty.Object(map[string]cty.Type{"type": cty.String}))
+	exitCodes := cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"operator": cty.StringVal("In")})})
+	podCondition := cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"type": cty.StringVal("DisruptionTarget")})})
+	withFailureRule := func(onExitCodes, onPodCondition cty.Value) cty.Value {
+		attrs := withReplacement("Failed", false).AsValueMap()
+		attrs["pod_failure_policy"] = cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"rule": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"action":           cty.StringVal("FailJob"),
+				"on_exit_codes":    onExitCodes,
+				"on_pod_condition": onPodCondition,
+			})}),
+		})})
+		return cty.ObjectVal(attrs)
+	}
+	cases := map[string]struct {
+		config cty.Value
+		err    string
+	}{
+		"failure rule exit codes":    {withFailureRule(exitCodes, cty.NullVal(podConditionType)), ""},
+		"failure rule pod condition": {withFailureRule(cty.ListValEmpty(exitCodesType.ElementType()), podCondition), ""},
+		"failure rule both":          {withFailureRule(exitCodes, podCondition), "every `rule` of `pod_failure_policy` must set exactly one of `on_exit_codes` and `on_pod_condition`"},
+		"failure rule neither":       {withFailureRule(cty.NullVal(exitCodesType), cty.NullVal(podConditionType)), "every `rule` of `pod_failure_policy` must set exactly one of `on_exit_codes` and `on_pod_condition`"},
+		"failure rule unknown":       {withFailureRule(cty.UnknownVal(exitCodesType), cty.NullVal(podConditionType)), ""},
+		"indexed":                    {withCounts(config(indexed, cty.NumberIntVal(0), cty.NumberIntVal(2), successPolicy(cty.StringVal("0"), unset)), cty.NumberIntVal(4), unset), ""},
+		"indexed without limit":      {config(indexed, unset, unset, noPolicy), ""},
+		"non indexed":                {config(cty.StringVal("NonIndexed"), cty.NumberIntVal(1), unset, noPolicy), "`backoff_limit_per_index` can only be set when `completion_mode` is `Indexed`"},
+		"default mode":               {config(defaultMode, cty.NumberIntVal(1), cty.NumberIntVal(1), noPolicy), "`backoff_limit_per_index` can only be set when `completion_mode` is `Indexed`"},
+		"unknown mode":               {config(cty.UnknownVal(cty.String), cty.NumberIntVal(1), unset, noPolicy), ""},
+		"max failed alone":           {config(indexed, unset, cty.NumberIntVal(1), noPolicy), "`max_failed_indexes` can only be set together with `backoff_limit_per_index`"},
+		"success policy":             {config(defaultMode, unset, unset, successPolicy(unset, cty.NumberIntVal(1))), "`success_policy` can only be set when `completion_mode` is `Indexed`"},
+		"empty success rule":         {config(indexed, unset, unset, successPolicy(cty.NullVal(cty.String), unset)), "every `rule` of `success_policy` must set `succeeded_indexes`, `succeeded_count` or both"},
+		"unknown success policy":     {config(defaultMode, unset, unset, cty.UnknownVal(cty.List(policyType))), ""},
+		"replacement policy":         {withReplacement("TerminatingOrFailed", false), ""},
+		"failure policy":             {withReplacement("Failed", true), ""},
+		"replacement of failure":     {withReplacement("TerminatingOrFailed", true), "`pod_replacement_policy` must be `Failed` when `pod_failure_policy` is set"},
+		"indexed counts":             {withCounts(config(indexed, cty.NumberIntVal(1), cty.NumberIntVal(3), successPolicy(cty.StringVal("0,2-4"), cty.NumberIntVal(5))), cty.NumberIntVal(5), cty.NumberIntVal(5)), ""},
+		"indexed parallelism":        {withCounts(config(indexed, unset, unset, noPolicy), cty.NumberIntVal(5), cty.NumberIntVal(100001)), "`parallelism` must be at most 100000 when `completion_mode` is `Indexed`"},
+		"non indexed parallel":       {withCounts(config(defaultMode, unset, unset, noPolicy), unset, cty.NumberIntVal(100001)), ""},
+		"max failed above":           {withCounts(config(indexed, cty.NumberIntVal(1), cty.NumberIntVal(6), noPolicy), cty.NumberIntVal(5), unset), "`max_failed_indexes` must be at most `completions` (5)"},
+		"default completions":        {config(indexed, cty.NumberIntVal(1), cty.NumberIntVal(2), noPolicy), "`max_failed_indexes` must be at most `completions` (1)"},
+		"unknown completions":        {withCounts(config(indexed, cty.NumberIntVal(1), cty.NumberIntVal(6), noPolicy), cty.UnknownVal(cty.Number), unset), ""},
+		"high completions":           {withCounts(config(indexed, cty.NumberIntVal(1), unset, noPolicy), cty.NumberIntVal(200000), cty.NumberIntVal(10)), "`max_failed_indexes` must be set when `completions` is above 100000 with `backoff_limit_per_index`"},
+		"high parallelism":           {withCounts(config(indexed, cty.NumberIntVal(1), cty.NumberIntVal(10), noPolicy), cty.NumberIntVal(200000), cty.NumberIntVal(20000)), "`parallelism` must be at most 10000 when `completions` is above 100000 with `backoff_limit_per_index`"},
+		"high max failed":            {withCounts(config(indexed, cty.NumberIntVal(1), cty.NumberIntVal(100001), noPolicy), cty.NumberIntVal(200000), cty.NumberIntVal(10)), "`max_failed_indexes` must be at most 100000"},
+		"succeeded count above":      {withCounts(config(indexed, unset, unset, successPolicy(unset, cty.NumberIntVal(6))), cty.NumberIntVal(5), unset), "`succeeded_count` of `success_policy` must be at most `completions` (5)"},
+		"succeeded index above":      {withCounts(config(indexed, unset, unset, successPolicy(cty.StringVal("0,3-5"), unset)), cty.NumberIntVal(5), unset), "`succeeded_indexes` of `success_policy` must be lower than `completions` (5), got index 5"},
+		"no spec":                    {cty.NilVal, ""},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateJobV1Spec(tc.config)
+			if tc.err == "" && err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if tc.err != "" && (err == nil || err.Error() != tc.err) {
+				t.Fatalf("Expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestUnsetJobV1IndexLimits(t *testing.T) {
+	spec := batchv1.JobSpec{BackoffLimitPerIndex: ptr.To(int32(0)), MaxFailedIndexes: ptr.To(int32(0))}
+	unsetJobV1IndexLimits(&spec, cty.ObjectVal(map[string]cty.Value{
+		"backoff_limit_per_index": cty.NumberIntVal(0),
+		"max_failed_indexes":      cty.NullVal(cty.Number),
+	}))
+	if spec.BackoffLimitPerIndex == nil || *spec.BackoffLimitPerIndex != 0 {
+		t.Fatalf("Expected the configured backoff limit per index of 0 to be kept, got %v", spec.BackoffLimitPerIndex)
+	}
+	if spec.MaxFailedIndexes != nil {
+		t.Fatalf("Expected the max failed indexes to be unset, got %v", *spec.MaxFailedIndexes)
+	}
+}
+
+func TestJobV1SuccessPolicyRoundTrip(t *testing.T) {
+	policy := &batchv1.SuccessPolicy{
+		Rules: []batchv1.SuccessPolicyRule{
+			{SucceededIndexes: ptr.To("0")},
+			{SucceededIndexes: ptr.To("1-4"), SucceededCount: ptr.To(int32(3))},
+			{SucceededCount: ptr.To(int32(5))},
+		},
+	}
+	if diff := cmp.Diff(policy, expandJobV1SuccessPolicy(flattenJobV1SuccessPolicy(policy))); diff != "" {
+		t.Fatalf("Unexpected success policy: mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExpandJobV1WaitForCondition(t *testing.T) {
+	target, timeout := expandJobV1WaitForCondition(nil, time.Minute)
+	if target != nil || timeout != time.Minute {
+		t.Fatalf("Expected no condition and the timeout of the operation, got %v and %s", target, timeout)
+	}
+
+	target, timeout = expandJobV1WaitForCondition([]interface{}{map[string]interface{}{
+		"type":    "SuccessCriteriaMet",
+		"status":  "True",
+		"timeout": "",
+	}}, time.Minute)
+	expected := &batchv1.JobCondition{Type: batchv1.JobSuccessCriteriaMet, Status: corev1.ConditionTrue}
+	if diff := cmp.Diff(expected, target); diff != "" || timeout != time.Minute {
+		t.Fatalf("Unexpected condition with timeout %s: mismatch (-want +got):\n%s", timeout, diff)
+	}
+
+	_, timeout = expandJobV1WaitForCondition([]interface{}{map[string]interface{}{
+		"type":    "FailureTarget",
+		"status":  "True",
+		"timeout": "5m",
+	}}, time.Minute)
+	if timeout != 5*time.Minute {
+		t.Fatalf("Expected the timeout of the condition, got %s", timeout)
+	}
+}
+
+func TestJobV1PodNames(t *testing.T) {
+	pod := func(name, namespace, jobName string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"batch.kubernetes.io/job-name": jobName},
+		}}
+	}
+	conn := fake.NewSimpleClientset(
+		pod("migrate-b", "default", "migrate"),
+		pod("migrate-a", "default", "migrate"),
+		pod("other-a", "default", "other"),
+		pod("migrate-c", "staging", "migrate"),
+	)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+		Spec: batchv1.JobSpec{Selector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"batch.kubernetes.io/job-name": "migrate"},
+		}},
+	}
+
+	names, err := jobV1PodNames(context.Background(), conn, job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"migrate-a", "migrate-b"}, names); diff != "" {
+		t.Fatalf("Unexpected pod names: mismatch (-want +got):\n%s", diff)
+	}
+
+	job.Spec.Selector = nil
+	names, err = jobV1PodNames(context.Background(), conn, job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 0 {
+		t.Fatalf("Expected no pods without a selector, got %v", names)
+	}
+}
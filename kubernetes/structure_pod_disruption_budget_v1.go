@@ -27,6 +27,10 @@ func expandPodDisruptionBudgetV1Spec(in []interface{}) (*policy.PodDisruptionBud
 	if v, ok := m["selector"].([]interface{}); ok && len(v) > 0 {
 		spec.Selector = expandLabelSelector(v)
 	}
+	if v, ok := m["unhealthy_pod_eviction_policy"].(string); ok && v != "" {
+		p := policy.UnhealthyPodEvictionPolicyType(v)
+		spec.UnhealthyPodEvictionPolicy = &p
+	}
 
 	return spec, nil
 }
@@ -42,6 +46,18 @@ func flattenPodDisruptionBudgetV1Spec(spec policy.PodDisruptionBudgetSpec) []int
 	if spec.Selector != nil {
 		m["selector"] = flattenLabelSelector(spec.Selector)
 	}
+	if spec.UnhealthyPodEvictionPolicy != nil {
+		m["unhealthy_pod_eviction_policy"] = string(*spec.UnhealthyPodEvictionPolicy)
+	}
 
 	return []interface{}{m}
 }
+
+func flattenPodDisruptionBudgetV1Status(status policy.PodDisruptionBudgetStatus) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"current_healthy":     status.CurrentHealthy,
+		"desired_healthy":     status.DesiredHealthy,
+		"disruptions_allowed": status.DisruptionsAllowed,
+		"expected_pods":       status.ExpectedPods,
+	}}
+}
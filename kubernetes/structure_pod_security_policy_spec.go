@@ -7,9 +7,8 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-
+	v1beta1 "github.com/hashicorp/terraform-provider-kubernetes/internal/policy/v1beta1"
 	v1 "k8s.io/api/core/v1"
-	v1beta1 "k8s.io/api/policy/v1beta1"
 	"k8s.io/utils/ptr"
 )
 
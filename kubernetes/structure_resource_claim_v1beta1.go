@@ -0,0 +1,299 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	resourcev1beta1 "k8s.io/api/resource/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+// Flatteners
+
+func flattenDeviceSelectors(in []resourcev1beta1.DeviceSelector) []interface{} {
+	att := make([]interface{}, 0, len(in))
+	for _, s := range in {
+		m := map[string]interface{}{}
+		if s.CEL != nil {
+			m["cel"] = []interface{}{map[string]interface{}{
+				"expression": s.CEL.Expression,
+			}}
+		}
+		att = append(att, m)
+	}
+	return att
+}
+
+func flattenOpaqueDeviceConfiguration(in *resourcev1beta1.OpaqueDeviceConfiguration) []interface{} {
+	if in == nil {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		"driver":     in.Driver,
+		"parameters": string(in.Parameters.Raw),
+	}}
+}
+
+func flattenDeviceClassV1Beta1Spec(in resourcev1beta1.DeviceClassSpec) []interface{} {
+	att := make(map[string]interface{})
+	if len(in.Selectors) > 0 {
+		att["selectors"] = flattenDeviceSelectors(in.Selectors)
+	}
+	if len(in.Config) > 0 {
+		config := make([]interface{}, 0, len(in.Config))
+		for _, c := range in.Config {
+			config = append(config, map[string]interface{}{
+				"opaque": flattenOpaqueDeviceConfiguration(c.Opaque),
+			})
+		}
+		att["config"] = config
+	}
+	return []interface{}{att}
+}
+
+func flattenResourceClaimV1Beta1Spec(in resourcev1beta1.ResourceClaimSpec) []interface{} {
+	devices := make(map[string]interface{})
+
+	requests := make([]interface{}, 0, len(in.Devices.Requests))
+	for _, r := range in.Devices.Requests {
+		m := map[string]interface{}{
+			"name":              r.Name,
+			"device_class_name": r.DeviceClassName,
+			"allocation_mode":   string(r.AllocationMode),
+			"count":             int(r.Count),
+		}
+		if len(r.Selectors) > 0 {
+			m["selectors"] = flattenDeviceSelectors(r.Selectors)
+		}
+		if r.AdminAccess != nil {
+			m["admin_access"] = *r.AdminAccess
+		}
+		requests = append(requests, m)
+	}
+	devices["request"] = requests
+
+	if len(in.Devices.Constraints) > 0 {
+		constraints := make([]interface{}, 0, len(in.Devices.Constraints))
+		for _, c := range in.Devices.Constraints {
+			m := map[string]interface{}{
+				"requests": flattenListOfStrings(c.Requests),
+			}
+			if c.MatchAttribute != nil {
+				m["match_attribute"] = string(*c.MatchAttribute)
+			}
+			constraints = append(constraints, m)
+		}
+		devices["constraint"] = constraints
+	}
+
+	if len(in.Devices.Config) > 0 {
+		config := make([]interface{}, 0, len(in.Devices.Config))
+		for _, c := range in.Devices.Config {
+			config = append(config, map[string]interface{}{
+				"requests": flattenListOfStrings(c.Requests),
+				"opaque":   flattenOpaqueDeviceConfiguration(c.Opaque),
+			})
+		}
+		devices["config"] = config
+	}
+
+	return []interface{}{map[string]interface{}{
+		"devices": []interface{}{devices},
+	}}
+}
+
+func flattenResourceClaimV1Beta1Status(in resourcev1beta1.ResourceClaimStatus) []interface{} {
+	att := make(map[string]interface{})
+
+	var allocated []interface{}
+	if in.Allocation != nil {
+		for _, r := range in.Allocation.Devices.Results {
+			allocated = append(allocated, map[string]interface{}{
+				"request": r.Request,
+				"driver":  r.Driver,
+				"pool":    r.Pool,
+				"device":  r.Device,
+			})
+		}
+	}
+	att["allocated"] = in.Allocation != nil
+	att["allocated_devices"] = allocated
+
+	reservedFor := make([]interface{}, 0, len(in.ReservedFor))
+	for _, r := range in.ReservedFor {
+		reservedFor = append(reservedFor, map[string]interface{}{
+			"api_group": r.APIGroup,
+			"resource":  r.Resource,
+			"name":      r.Name,
+			"uid":       string(r.UID),
+		})
+	}
+	att["reserved_for"] = reservedFor
+
+	return []interface{}{att}
+}
+
+// Expanders
+
+func expandDeviceSelectors(l []interface{}) []resourcev1beta1.DeviceSelector {
+	if len(l) == 0 {
+		return nil
+	}
+	obj := make([]resourcev1beta1.DeviceSelector, 0, len(l))
+	for _, s := range l {
+		in, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		selector := resourcev1beta1.DeviceSelector{}
+		if v, ok := in["cel"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			selector.CEL = &resourcev1beta1.CELDeviceSelector{
+				Expression: v[0].(map[string]interface{})["expression"].(string),
+			}
+		}
+		obj = append(obj, selector)
+	}
+	return obj
+}
+
+func expandOpaqueDeviceConfiguration(l []interface{}) *resourcev1beta1.OpaqueDeviceConfiguration {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	in := l[0].(map[string]interface{})
+	return &resourcev1beta1.OpaqueDeviceConfiguration{
+		Driver:     in["driver"].(string),
+		Parameters: runtime.RawExtension{Raw: []byte(in["parameters"].(string))},
+	}
+}
+
+func expandDeviceClassV1Beta1Spec(l []interface{}) resourcev1beta1.DeviceClassSpec {
+	obj := resourcev1beta1.DeviceClassSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+	if v, ok := in["selectors"].([]interface{}); ok {
+		obj.Selectors = expandDeviceSelectors(v)
+	}
+	if v, ok := in["config"].([]interface{}); ok {
+		for _, c := range v {
+			if c == nil {
+				continue
+			}
+			obj.Config = append(obj.Config, resourcev1beta1.DeviceClassConfiguration{
+				DeviceConfiguration: resourcev1beta1.DeviceConfiguration{
+					Opaque: expandOpaqueDeviceConfiguration(c.(map[string]interface{})["opaque"].([]interface{})),
+				},
+			})
+		}
+	}
+	return obj
+}
+
+func expandResourceClaimV1Beta1Spec(l []interface{}) resourcev1beta1.ResourceClaimSpec {
+	obj := resourcev1beta1.ResourceClaimSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+	d, ok := in["devices"].([]interface{})
+	if !ok || len(d) == 0 || d[0] == nil {
+		return obj
+	}
+	devices := d[0].(map[string]interface{})
+
+	if v, ok := devices["request"].([]interface{}); ok {
+		for _, r := range v {
+			if r == nil {
+				continue
+			}
+			m := r.(map[string]interface{})
+			request := resourcev1beta1.DeviceRequest{
+				Name:            m["name"].(string),
+				DeviceClassName: m["device_class_name"].(string),
+			}
+			if v, ok := m["selectors"].([]interface{}); ok {
+				request.Selectors = expandDeviceSelectors(v)
+			}
+			if v, ok := m["allocation_mode"].(string); ok && v != "" {
+				request.AllocationMode = resourcev1beta1.DeviceAllocationMode(v)
+			}
+			if v, ok := m["count"].(int); ok && v > 0 {
+				request.Count = int64(v)
+			}
+			if v, ok := m["admin_access"].(bool); ok && v {
+				request.AdminAccess = ptr.To(v)
+			}
+			obj.Devices.Requests = append(obj.Devices.Requests, request)
+		}
+	}
+
+	if v, ok := devices["constraint"].([]interface{}); ok {
+		for _, c := range v {
+			if c == nil {
+				continue
+			}
+			m := c.(map[string]interface{})
+			constraint := resourcev1beta1.DeviceConstraint{
+				Requests: expandStringSlice(m["requests"].([]interface{})),
+			}
+			if v, ok := m["match_attribute"].(string); ok && v != "" {
+				constraint.MatchAttribute = ptr.To(resourcev1beta1.FullyQualifiedName(v))
+			}
+			obj.Devices.Constraints = append(obj.Devices.Constraints, constraint)
+		}
+	}
+
+	if v, ok := devices["config"].([]interface{}); ok {
+		for _, c := range v {
+			if c == nil {
+				continue
+			}
+			m := c.(map[string]interface{})
+			obj.Devices.Config = append(obj.Devices.Config, resourcev1beta1.DeviceClaimConfiguration{
+				Requests: expandStringSlice(m["requests"].([]interface{})),
+				DeviceConfiguration: resourcev1beta1.DeviceConfiguration{
+					Opaque: expandOpaqueDeviceConfiguration(m["opaque"].([]interface{})),
+				},
+			})
+		}
+	}
+
+	return obj
+}
+
+func expandResourceClaimTemplateV1Beta1Spec(l []interface{}) resourcev1beta1.ResourceClaimTemplateSpec {
+	obj := resourcev1beta1.ResourceClaimTemplateSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+	if v, ok := in["metadata"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		m := v[0].(map[string]interface{})
+		if v, ok := m["annotations"].(map[string]interface{}); ok && len(v) > 0 {
+			obj.Annotations = expandStringMap(v)
+		}
+		if v, ok := m["labels"].(map[string]interface{}); ok && len(v) > 0 {
+			obj.Labels = expandStringMap(v)
+		}
+	}
+	if v, ok := in["spec"].([]interface{}); ok {
+		obj.Spec = expandResourceClaimV1Beta1Spec(v)
+	}
+	return obj
+}
+
+func flattenResourceClaimTemplateV1Beta1Spec(in resourcev1beta1.ResourceClaimTemplateSpec) []interface{} {
+	att := map[string]interface{}{
+		"spec": flattenResourceClaimV1Beta1Spec(in.Spec),
+	}
+	if len(in.Annotations) > 0 || len(in.Labels) > 0 {
+		att["metadata"] = []interface{}{map[string]interface{}{
+			"annotations": in.Annotations,
+			"labels":      in.Labels,
+		}}
+	}
+	return []interface{}{att}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	resourcev1beta1 "k8s.io/api/resource/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+)
+
+func TestResourceClaimV1Beta1Spec(t *testing.T) {
+	spec := resourcev1beta1.ResourceClaimSpec{
+		Devices: resourcev1beta1.DeviceClaim{
+			Requests: []resourcev1beta1.DeviceRequest{
+				{
+					Name:            "gpu",
+					DeviceClassName: "gpu.example.com",
+					Selectors: []resourcev1beta1.DeviceSelector{
+						{CEL: &resourcev1beta1.CELDeviceSelector{Expression: `device.attributes["gpu.example.com"].model == "a100"`}},
+					},
+					AllocationMode: resourcev1beta1.DeviceAllocationModeExactCount,
+					Count:          2,
+				},
+				{
+					Name:            "nic",
+					DeviceClassName: "nic.example.com",
+					AllocationMode:  resourcev1beta1.DeviceAllocationModeAll,
+					AdminAccess:     ptr.To(true),
+				},
+			},
+			Constraints: []resourcev1beta1.DeviceConstraint{
+				{
+					Requests:       []string{"gpu", "nic"},
+					MatchAttribute: ptr.To(resourcev1beta1.FullyQualifiedName("dra.example.com/numa")),
+				},
+			},
+			Config: []resourcev1beta1.DeviceClaimConfiguration{
+				{
+					Requests: []string{"gpu"},
+					DeviceConfiguration: resourcev1beta1.DeviceConfiguration{
+						Opaque: &resourcev1beta1.OpaqueDeviceConfiguration{
+							Driver:     "gpu.example.com",
+							Parameters: runtime.RawExtension{Raw: []byte(`{"apiVersion":"gpu.example.com/v1","kind":"GpuConfig","sharing":"TimeSlicing"}`)},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got := expandResourceClaimV1Beta1Spec(flattenResourceClaimV1Beta1Spec(spec))
+	if diff := cmp.Diff(spec, got); diff != "" {
+		t.Fatalf("Unexpected resource claim spec: mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDeviceClassV1Beta1Spec(t *testing.T) {
+	spec := resourcev1beta1.DeviceClassSpec{
+		Selectors: []resourcev1beta1.DeviceSelector{
+			{CEL: &resourcev1beta1.CELDeviceSelector{Expression: `device.driver == "gpu.example.com"`}},
+		},
+		Config: []resourcev1beta1.DeviceClassConfiguration{
+			{
+				DeviceConfiguration: resourcev1beta1.DeviceConfiguration{
+					Opaque: &resourcev1beta1.OpaqueDeviceConfiguration{
+						Driver:     "gpu.example.com",
+						Parameters: runtime.RawExtension{Raw: []byte(`{"kind":"GpuConfig"}`)},
+					},
+				},
+			},
+		},
+	}
+
+	got := expandDeviceClassV1Beta1Spec(flattenDeviceClassV1Beta1Spec(spec))
+	if diff := cmp.Diff(spec, got); diff != "" {
+		t.Fatalf("Unexpected device class spec: mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -4,6 +4,9 @@
 package kubernetes
 
 import (
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
 	gversion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	v1 "k8s.io/api/core/v1"
@@ -285,6 +288,56 @@ func expandServiceSpec(l []interface{}) v1.ServiceSpec {
 	return obj
 }
 
+// keepAssignedNodePorts sets the node port of the ports whose node port isn't
+// configured to the one assigned to the same port and protocol before, or to
+// zero so that the API server assigns one. Otherwise a port added, removed or
+// moved within the list would take over the node port of another port.
+func keepAssignedNodePorts(ports, prior []v1.ServicePort, configured []bool) {
+	if configured == nil {
+		return
+	}
+	assigned := make(map[string]int32, len(prior))
+	for _, p := range prior {
+		if p.NodePort != 0 {
+			assigned[servicePortKey(p)] = p.NodePort
+		}
+	}
+	for i := range ports {
+		if i < len(configured) && configured[i] {
+			continue
+		}
+		ports[i].NodePort = assigned[servicePortKey(ports[i])]
+	}
+}
+
+// nodePortsConfigured reports, for each port in the configuration of the ports of
+// a service, whether its node port is configured to a port other than zero.
+// Unknown node ports count as configured.
+func nodePortsConfigured(config cty.Value) []bool {
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	out := make([]bool, 0, config.LengthInt())
+	for it := config.ElementIterator(); it.Next(); {
+		_, p := it.Element()
+		if p.IsNull() || !p.IsKnown() {
+			out = append(out, true)
+			continue
+		}
+		v := p.GetAttr("node_port")
+		out = append(out, !v.IsKnown() || (!v.IsNull() && !v.RawEquals(cty.NumberIntVal(0))))
+	}
+	return out
+}
+
+func servicePortKey(p v1.ServicePort) string {
+	protocol := p.Protocol
+	if protocol == "" {
+		protocol = v1.ProtocolTCP
+	}
+	return fmt.Sprintf("%s/%d", protocol, p.Port)
+}
+
 // Patch Ops
 
 func patchServiceSpec(keyPrefix, pathPrefix string, d *schema.ResourceData, kv *gversion.Version) PatchOperations {
@@ -329,9 +382,12 @@ func patchServiceSpec(keyPrefix, pathPrefix string, d *schema.ResourceData, kv *
 		})
 	}
 	if d.HasChange(keyPrefix + "port") {
+		o, n := d.GetChange(keyPrefix + "port")
+		ports := expandServicePort(n.([]interface{}), false)
+		keepAssignedNodePorts(ports, expandServicePort(o.([]interface{}), false), nodePortsConfigured(rawConfigAt(d.GetRawConfig(), keyPrefix+"port")))
 		ops = append(ops, &ReplaceOperation{
 			Path:  pathPrefix + "ports",
-			Value: expandServicePort(d.Get(keyPrefix+"port").([]interface{}), false),
+			Value: ports,
 		})
 	}
 	if d.HasChange(keyPrefix + "type") {
@@ -395,6 +451,12 @@ func patchServiceSpec(keyPrefix, pathPrefix string, d *schema.ResourceData, kv *
 			Value: d.Get(keyPrefix + "internal_traffic_policy").(string),
 		})
 	}
+	if d.HasChange(keyPrefix + "cluster_ips") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  pathPrefix + "clusterIPs",
+			Value: d.Get(keyPrefix + "cluster_ips").([]interface{}),
+		})
+	}
 	if d.HasChange(keyPrefix + "ip_families") {
 		ops = append(ops, &ReplaceOperation{
 			Path:  pathPrefix + "ipFamilies",
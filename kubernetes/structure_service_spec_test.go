@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-cty/cty"
+	api "k8s.io/api/core/v1"
+)
+
+func TestKeepAssignedNodePorts(t *testing.T) {
+	prior := []api.ServicePort{
+		{Port: 80, Protocol: api.ProtocolTCP, NodePort: 30080},
+		{Port: 443, Protocol: api.ProtocolTCP, NodePort: 30443},
+		{Port: 53, Protocol: api.ProtocolUDP, NodePort: 30053},
+	}
+
+	cases := []struct {
+		Name       string
+		Ports      []api.ServicePort
+		Configured []bool
+		Expected   []int32
+	}{
+		{
+			"reordered",
+			[]api.ServicePort{
+				{Port: 443, Protocol: api.ProtocolTCP, NodePort: 30080},
+				{Port: 80, Protocol: api.ProtocolTCP, NodePort: 30443},
+			},
+			[]bool{false, false},
+			[]int32{30443, 30080},
+		},
+		{
+			"added",
+			[]api.ServicePort{
+				{Port: 8080, Protocol: api.ProtocolTCP, NodePort: 30080},
+				{Port: 80, Protocol: api.ProtocolTCP, NodePort: 30443},
+				{Port: 53, Protocol: api.ProtocolTCP, NodePort: 30053},
+			},
+			[]bool{false, false, false},
+			[]int32{0, 30080, 0},
+		},
+		{
+			"pinned",
+			[]api.ServicePort{
+				{Port: 443, Protocol: api.ProtocolTCP, NodePort: 31443},
+				{Port: 80, Protocol: api.ProtocolTCP, NodePort: 30443},
+			},
+			[]bool{true, false},
+			[]int32{31443, 30080},
+		},
+		{
+			"no configuration",
+			[]api.ServicePort{
+				{Port: 443, Protocol: api.ProtocolTCP, NodePort: 30080},
+			},
+			nil,
+			[]int32{30080},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			keepAssignedNodePorts(tc.Ports, prior, tc.Configured)
+			var got []int32
+			for _, p := range tc.Ports {
+				got = append(got, p.NodePort)
+			}
+			if diff := cmp.Diff(tc.Expected, got); diff != "" {
+				t.Fatalf("Unexpected node ports: mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNodePortsConfigured(t *testing.T) {
+	port := func(nodePort cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"port":      cty.NumberIntVal(80),
+			"node_port": nodePort,
+		})
+	}
+	config := cty.ObjectVal(map[string]cty.Value{
+		"spec": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"port": cty.ListVal([]cty.Value{
+					port(cty.NullVal(cty.Number)),
+					port(cty.NumberIntVal(0)),
+					port(cty.NumberIntVal(30080)),
+					port(cty.UnknownVal(cty.Number)),
+				}),
+			}),
+		}),
+	})
+
+	got := nodePortsConfigured(rawConfigAt(config, "spec.0.port"))
+	if diff := cmp.Diff([]bool{false, false, true, true}, got); diff != "" {
+		t.Fatalf("Unexpected output: mismatch (-want +got):\n%s", diff)
+	}
+	if got := nodePortsConfigured(rawConfigAt(config, "spec.1.port")); got != nil {
+		t.Fatalf("Expected no output for a missing block, got %v", got)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Expanders
+
+func expandVerticalPodAutoscalerV1(metadata metav1.ObjectMeta, in []interface{}) (*unstructured.Unstructured, error) {
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&metadata)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := expandVerticalPodAutoscalerV1Spec(in)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": verticalPodAutoscalerV1GVR.GroupVersion().String(),
+		"kind":       "VerticalPodAutoscaler",
+		"metadata":   m,
+		"spec":       spec,
+	}}, nil
+}
+
+func expandVerticalPodAutoscalerV1Spec(in []interface{}) (map[string]interface{}, error) {
+	if len(in) == 0 || in[0] == nil {
+		return nil, fmt.Errorf("failed to expand VerticalPodAutoscaler.Spec: null or empty input")
+	}
+	m := in[0].(map[string]interface{})
+	spec := map[string]interface{}{}
+
+	if v, ok := m["target_ref"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		ref := v[0].(map[string]interface{})
+		spec["targetRef"] = map[string]interface{}{
+			"apiVersion": ref["api_version"],
+			"kind":       ref["kind"],
+			"name":       ref["name"],
+		}
+	}
+
+	if v, ok := m["update_policy"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		p := v[0].(map[string]interface{})
+		policy := map[string]interface{}{}
+		if mode, ok := p["update_mode"].(string); ok && mode != "" {
+			policy["updateMode"] = mode
+		}
+		if n, ok := p["min_replicas"].(int); ok && n > 0 {
+			policy["minReplicas"] = int64(n)
+		}
+		spec["updatePolicy"] = policy
+	}
+
+	if v, ok := m["resource_policy"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		p := v[0].(map[string]interface{})
+		policies := []interface{}{}
+		for _, c := range p["container_policy"].([]interface{}) {
+			if c == nil {
+				continue
+			}
+			policies = append(policies, expandVerticalPodAutoscalerV1ContainerPolicy(c.(map[string]interface{})))
+		}
+		spec["resourcePolicy"] = map[string]interface{}{
+			"containerPolicies": policies,
+		}
+	}
+
+	if v, ok := m["recommenders"].([]interface{}); ok && len(v) > 0 {
+		recommenders := make([]interface{}, 0, len(v))
+		for _, name := range v {
+			recommenders = append(recommenders, map[string]interface{}{"name": name})
+		}
+		spec["recommenders"] = recommenders
+	}
+
+	return spec, nil
+}
+
+func expandVerticalPodAutoscalerV1ContainerPolicy(in map[string]interface{}) map[string]interface{} {
+	policy := map[string]interface{}{
+		"containerName": in["container_name"],
+	}
+	if v, ok := in["mode"].(string); ok && v != "" {
+		policy["mode"] = v
+	}
+	for k, attr := range map[string]string{"minAllowed": "min_allowed", "maxAllowed": "max_allowed"} {
+		if v, ok := in[attr].(map[string]interface{}); ok && len(v) > 0 {
+			policy[k] = v
+		}
+	}
+	if v, ok := in["controlled_resources"].([]interface{}); ok && len(v) > 0 {
+		policy["controlledResources"] = v
+	}
+	if v, ok := in["controlled_values"].(string); ok && v != "" {
+		policy["controlledValues"] = v
+	}
+	return policy
+}
+
+// Flatteners
+
+func flattenVerticalPodAutoscalerV1Spec(spec map[string]interface{}) []interface{} {
+	m := map[string]interface{}{}
+
+	if ref, ok := spec["targetRef"].(map[string]interface{}); ok {
+		m["target_ref"] = []interface{}{map[string]interface{}{
+			"api_version": ref["apiVersion"],
+			"kind":        ref["kind"],
+			"name":        ref["name"],
+		}}
+	}
+
+	if p, ok := spec["updatePolicy"].(map[string]interface{}); ok {
+		policy := map[string]interface{}{}
+		if v, ok := p["updateMode"]; ok {
+			policy["update_mode"] = v
+		}
+		if v, ok := p["minReplicas"].(int64); ok {
+			policy["min_replicas"] = int(v)
+		}
+		m["update_policy"] = []interface{}{policy}
+	}
+
+	if p, ok := spec["resourcePolicy"].(map[string]interface{}); ok {
+		policies := []interface{}{}
+		if cps, ok := p["containerPolicies"].([]interface{}); ok {
+			for _, c := range cps {
+				if cp, ok := c.(map[string]interface{}); ok {
+					policies = append(policies, flattenVerticalPodAutoscalerV1ContainerPolicy(cp))
+				}
+			}
+		}
+		m["resource_policy"] = []interface{}{map[string]interface{}{
+			"container_policy": policies,
+		}}
+	}
+
+	if rs, ok := spec["recommenders"].([]interface{}); ok {
+		recommenders := make([]interface{}, 0, len(rs))
+		for _, r := range rs {
+			if rm, ok := r.(map[string]interface{}); ok {
+				recommenders = append(recommenders, rm["name"])
+			}
+		}
+		m["recommenders"] = recommenders
+	}
+
+	return []interface{}{m}
+}
+
+func flattenVerticalPodAutoscalerV1ContainerPolicy(in map[string]interface{}) map[string]interface{} {
+	policy := map[string]interface{}{
+		"container_name": in["containerName"],
+	}
+	if v, ok := in["mode"]; ok {
+		policy["mode"] = v
+	}
+	for k, attr := range map[string]string{"minAllowed": "min_allowed", "maxAllowed": "max_allowed"} {
+		if v, ok := in[k].(map[string]interface{}); ok {
+			resources := make(map[string]interface{}, len(v))
+			for name, q := range v {
+				resources[name] = fmt.Sprint(q)
+			}
+			policy[attr] = resources
+		}
+	}
+	if v, ok := in["controlledResources"].([]interface{}); ok {
+		policy["controlled_resources"] = v
+	}
+	if v, ok := in["controlledValues"]; ok {
+		policy["controlled_values"] = v
+	}
+	return policy
+}
@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	api "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -141,6 +144,7 @@ func flattenMetadata(meta metav1.ObjectMeta, d *schema.ResourceData, providerMet
 	ignoreAnnotations := providerMeta.(providerMetadata).IgnoreAnnotations
 	removeInternalKeys(meta.Annotations, metadataAnnotations)
 	removeKeys(meta.Annotations, metadataAnnotations, ignoreAnnotations)
+	removeAttributionKeys(meta.Annotations, metadataAnnotations)
 
 	ignoreLabels := providerMeta.(providerMetadata).IgnoreLabels
 	removeInternalKeys(meta.Labels, metadataLabels)
@@ -725,3 +729,25 @@ func flattenPersistentVolumeMountOptions(in []string) *schema.Set {
 	}
 	return schema.NewSet(schema.HashString, out)
 }
+
+// rawConfigAt follows a key such as "spec.0.port" from the raw configuration of
+// a resource. It returns cty.NilVal when a step is missing, null or unknown.
+func rawConfigAt(v cty.Value, key string) cty.Value {
+	for _, step := range strings.Split(key, ".") {
+		if v.IsNull() || !v.IsKnown() {
+			return cty.NilVal
+		}
+		if i, err := strconv.Atoi(step); err == nil {
+			if !v.Type().IsListType() && !v.Type().IsTupleType() || i >= v.LengthInt() {
+				return cty.NilVal
+			}
+			v = v.Index(cty.NumberIntVal(int64(i)))
+			continue
+		}
+		if !v.Type().IsObjectType() || !v.Type().HasAttribute(step) {
+			return cty.NilVal
+		}
+		v = v.GetAttr(step)
+	}
+	return v
+}
@@ -92,6 +92,12 @@ func flattenPodAffinityTerms(in []v1.PodAffinityTerm) []interface{} {
 		if n.LabelSelector != nil {
 			m["label_selector"] = flattenLabelSelector(n.LabelSelector)
 		}
+		if len(n.MatchLabelKeys) > 0 {
+			m["match_label_keys"] = newStringSet(schema.HashString, n.MatchLabelKeys)
+		}
+		if len(n.MismatchLabelKeys) > 0 {
+			m["mismatch_label_keys"] = newStringSet(schema.HashString, n.MismatchLabelKeys)
+		}
 		att[i] = m
 	}
 	return att
@@ -232,6 +238,12 @@ func expandPodAffinityTerms(t []interface{}) []v1.PodAffinityTerm {
 		if v, ok := in["topology_key"].(string); ok {
 			obj[i].TopologyKey = v
 		}
+		if v, ok := in["match_label_keys"].(*schema.Set); ok && v.Len() > 0 {
+			obj[i].MatchLabelKeys = sliceOfString(v.List())
+		}
+		if v, ok := in["mismatch_label_keys"].(*schema.Set); ok && v.Len() > 0 {
+			obj[i].MismatchLabelKeys = sliceOfString(v.List())
+		}
 	}
 	return obj
 }
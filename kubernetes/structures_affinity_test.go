@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodAffinityTermsLabelKeys(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Terms []v1.PodAffinityTerm
+	}{
+		{
+			"without label keys",
+			[]v1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+					TopologyKey:   "kubernetes.io/hostname",
+				},
+			},
+		},
+		{
+			"with label keys",
+			[]v1.PodAffinityTerm{
+				{
+					LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+					TopologyKey:       "kubernetes.io/hostname",
+					MatchLabelKeys:    []string{"pod-template-hash"},
+					MismatchLabelKeys: []string{"tenant"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got := expandPodAffinityTerms(flattenPodAffinityTerms(tc.Terms))
+			if diff := cmp.Diff(tc.Terms[0].MatchLabelKeys, got[0].MatchLabelKeys); diff != "" {
+				t.Fatalf("Unexpected match label keys: mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.Terms[0].MismatchLabelKeys, got[0].MismatchLabelKeys); diff != "" {
+				t.Fatalf("Unexpected mismatch label keys: mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -46,6 +46,9 @@ func flattenContainerSecurityContext(in *v1.SecurityContext) []interface{} {
 	if in.RunAsUser != nil {
 		att["run_as_user"] = strconv.Itoa(int(*in.RunAsUser))
 	}
+	if in.AppArmorProfile != nil {
+		att["app_armor_profile"] = flattenAppArmorProfile(in.AppArmorProfile)
+	}
 	if in.SeccompProfile != nil {
 		att["seccomp_profile"] = flattenSeccompProfile(in.SeccompProfile)
 	}
@@ -389,6 +392,16 @@ func flattenContainerResourceRequirements(in v1.ResourceRequirements) []interfac
 	att := make(map[string]interface{})
 	att["limits"] = flattenResourceList(in.Limits)
 	att["requests"] = flattenResourceList(in.Requests)
+	if len(in.Claims) > 0 {
+		claims := make([]interface{}, len(in.Claims))
+		for i, c := range in.Claims {
+			claims[i] = map[string]interface{}{
+				"name":    c.Name,
+				"request": c.Request,
+			}
+		}
+		att["claims"] = claims
+	}
 	return []interface{}{att}
 }
 
@@ -645,6 +658,9 @@ func expandContainerSecurityContext(l []interface{}) (*v1.SecurityContext, error
 		}
 		obj.RunAsUser = ptr.To(int64(i))
 	}
+	if v, ok := in["app_armor_profile"].([]interface{}); ok && len(v) > 0 {
+		obj.AppArmorProfile = expandAppArmorProfile(v)
+	}
 	if v, ok := in["seccomp_profile"].([]interface{}); ok && len(v) > 0 {
 		obj.SeccompProfile = expandSeccompProfile(v)
 	}
@@ -1082,5 +1098,18 @@ func expandContainerResourceRequirements(l []interface{}) (*v1.ResourceRequireme
 		obj.Requests = *r
 	}
 
+	if v, ok := in["claims"].([]interface{}); ok && len(v) > 0 {
+		for _, c := range v {
+			if c == nil {
+				continue
+			}
+			m := c.(map[string]interface{})
+			obj.Claims = append(obj.Claims, v1.ResourceClaim{
+				Name:    m["name"].(string),
+				Request: m["request"].(string),
+			})
+		}
+	}
+
 	return obj, nil
 }
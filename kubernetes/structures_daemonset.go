@@ -124,3 +124,13 @@ func expandRollingUpdateDaemonSet(p []interface{}) *appsv1.RollingUpdateDaemonSe
 	}
 	return &obj
 }
+
+// isZeroIntOrPercent returns whether a number or percentage of pods, like
+// the max_surge of a rolling update, is zero.
+func isZeroIntOrPercent(v string) bool {
+	val := intstr.Parse(v)
+	if val.Type == intstr.String {
+		return val.StrVal == "0%"
+	}
+	return val.IntVal == 0
+}
@@ -5,12 +5,13 @@ package kubernetes
 
 import (
 	"fmt"
-	"log"
 	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apilog"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -67,6 +68,10 @@ func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
 
 	att["readiness_gate"] = flattenReadinessGates(in.ReadinessGates)
 
+	if len(in.ResourceClaims) > 0 {
+		att["resource_claim"] = flattenPodResourceClaims(in.ResourceClaims)
+	}
+
 	initContainers, err := flattenContainers(in.InitContainers, serviceAccountRegex)
 	if err != nil {
 		return nil, err
@@ -221,6 +226,9 @@ func flattenPodSecurityContext(in *v1.PodSecurityContext) []interface{} {
 	if in.RunAsUser != nil {
 		att["run_as_user"] = strconv.Itoa(int(*in.RunAsUser))
 	}
+	if in.AppArmorProfile != nil {
+		att["app_armor_profile"] = flattenAppArmorProfile(in.AppArmorProfile)
+	}
 	if in.SeccompProfile != nil {
 		att["seccomp_profile"] = flattenSeccompProfile(in.SeccompProfile)
 	}
@@ -252,9 +260,20 @@ func flattenPodSecurityContext(in *v1.PodSecurityContext) []interface{} {
 func flattenSeccompProfile(in *v1.SeccompProfile) []interface{} {
 	att := make(map[string]interface{})
 	if in.Type != "" {
-		att["type"] = in.Type
-		if in.Type == "Localhost" {
-			att["localhost_profile"] = in.LocalhostProfile
+		att["type"] = string(in.Type)
+		if in.Type == v1.SeccompProfileTypeLocalhost && in.LocalhostProfile != nil {
+			att["localhost_profile"] = *in.LocalhostProfile
+		}
+	}
+	return []interface{}{att}
+}
+
+func flattenAppArmorProfile(in *v1.AppArmorProfile) []interface{} {
+	att := make(map[string]interface{})
+	if in.Type != "" {
+		att["type"] = string(in.Type)
+		if in.Type == v1.AppArmorProfileTypeLocalhost && in.LocalhostProfile != nil {
+			att["localhost_profile"] = *in.LocalhostProfile
 		}
 	}
 	return []interface{}{att}
@@ -298,7 +317,7 @@ func flattenTolerations(tolerations []v1.Toleration) []interface{} {
 	for _, v := range tolerations {
 		// The API Server may automatically add several Tolerations to pods, strip these to avoid TF diff.
 		if _, ok := builtInTolerations[v.Key]; ok {
-			log.Printf("[INFO] ignoring toleration with key: %s", v.Key)
+			tflog.Info(apilog.Context(), fmt.Sprintf("Ignoring toleration with key: %s", v.Key))
 			continue
 		}
 		obj := map[string]interface{}{}
@@ -674,6 +693,23 @@ func flattenReadinessGates(in []v1.PodReadinessGate) []interface{} {
 	return att
 }
 
+func flattenPodResourceClaims(in []v1.PodResourceClaim) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, v := range in {
+		c := map[string]interface{}{
+			"name": v.Name,
+		}
+		if v.ResourceClaimName != nil {
+			c["resource_claim_name"] = *v.ResourceClaimName
+		}
+		if v.ResourceClaimTemplateName != nil {
+			c["resource_claim_template_name"] = *v.ResourceClaimTemplateName
+		}
+		att[i] = c
+	}
+	return att
+}
+
 func flattenPersistentVolumeClaimMetadata(in metav1.ObjectMeta) map[string]interface{} {
 	att := make(map[string]interface{})
 
@@ -755,6 +791,10 @@ func expandPodSpec(p []interface{}) (*v1.PodSpec, error) {
 		obj.ReadinessGates = expandReadinessGates(v)
 	}
 
+	if v, ok := in["resource_claim"].([]interface{}); ok && len(v) > 0 {
+		obj.ResourceClaims = expandPodResourceClaims(v)
+	}
+
 	if v, ok := in["init_container"].([]interface{}); ok && len(v) > 0 {
 		cs, err := expandContainers(v)
 		if err != nil {
@@ -1016,6 +1056,9 @@ func expandPodSecurityContext(l []interface{}) (*v1.PodSecurityContext, error) {
 		}
 		obj.RunAsUser = ptr.To(int64(i))
 	}
+	if v, ok := in["app_armor_profile"].([]interface{}); ok && len(v) > 0 {
+		obj.AppArmorProfile = expandAppArmorProfile(v)
+	}
 	if v, ok := in["seccomp_profile"].([]interface{}); ok && len(v) > 0 {
 		obj.SeccompProfile = expandSeccompProfile(v)
 	}
@@ -1064,8 +1107,25 @@ func expandSeccompProfile(l []interface{}) *v1.SeccompProfile {
 	obj := &v1.SeccompProfile{}
 	if v, ok := in["type"].(string); ok {
 		obj.Type = v1.SeccompProfileType(v)
-		if v == "Localhost" {
-			if lp, ok := in["localhost_profile"].(string); ok {
+		if obj.Type == v1.SeccompProfileTypeLocalhost {
+			if lp, ok := in["localhost_profile"].(string); ok && lp != "" {
+				obj.LocalhostProfile = &lp
+			}
+		}
+	}
+	return obj
+}
+
+func expandAppArmorProfile(l []interface{}) *v1.AppArmorProfile {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	in := l[0].(map[string]interface{})
+	obj := &v1.AppArmorProfile{}
+	if v, ok := in["type"].(string); ok {
+		obj.Type = v1.AppArmorProfileType(v)
+		if obj.Type == v1.AppArmorProfileTypeLocalhost {
+			if lp, ok := in["localhost_profile"].(string); ok && lp != "" {
 				obj.LocalhostProfile = &lp
 			}
 		}
@@ -1655,6 +1715,27 @@ func expandReadinessGates(gates []interface{}) []v1.PodReadinessGate {
 	return cs
 }
 
+func expandPodResourceClaims(claims []interface{}) []v1.PodResourceClaim {
+	cs := make([]v1.PodResourceClaim, 0, len(claims))
+	for _, c := range claims {
+		if c == nil {
+			continue
+		}
+		claim := c.(map[string]interface{})
+		obj := v1.PodResourceClaim{
+			Name: claim["name"].(string),
+		}
+		if v, ok := claim["resource_claim_name"].(string); ok && v != "" {
+			obj.ResourceClaimName = ptr.To(v)
+		}
+		if v, ok := claim["resource_claim_template_name"].(string); ok && v != "" {
+			obj.ResourceClaimTemplateName = ptr.To(v)
+		}
+		cs = append(cs, obj)
+	}
+	return cs
+}
+
 func patchPodSpec(pathPrefix, prefix string, d *schema.ResourceData) (PatchOperations, error) {
 	ops := make([]PatchOperation, 0)
 
@@ -563,6 +563,39 @@ func TestExpandThenFlatten_projected_volume(t *testing.T) {
 
 }
 
+func TestExpandThenFlatten_security_profiles(t *testing.T) {
+	seccompCases := []*corev1.SeccompProfile{
+		{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		{Type: corev1.SeccompProfileTypeLocalhost, LocalhostProfile: ptr.To("profiles/audit.json")},
+	}
+	for _, in := range seccompCases {
+		flattened := flattenSeccompProfile(in)
+		out := expandSeccompProfile(flattened)
+		if !cmp.Equal(in, out) {
+			t.Fatal(cmp.Diff(in, out))
+		}
+	}
+
+	appArmorCases := []*corev1.AppArmorProfile{
+		{Type: corev1.AppArmorProfileTypeRuntimeDefault},
+		{Type: corev1.AppArmorProfileTypeUnconfined},
+		{Type: corev1.AppArmorProfileTypeLocalhost, LocalhostProfile: ptr.To("k8s-apparmor-example-deny-write")},
+	}
+	for _, in := range appArmorCases {
+		flattened := flattenAppArmorProfile(in)
+		out := expandAppArmorProfile(flattened)
+		if !cmp.Equal(in, out) {
+			t.Fatal(cmp.Diff(in, out))
+		}
+	}
+
+	// The profile of a type other than Localhost is ignored, the API server rejects it.
+	out := expandAppArmorProfile([]interface{}{map[string]interface{}{"type": "RuntimeDefault", "localhost_profile": "unused"}})
+	if out.LocalhostProfile != nil {
+		t.Fatalf("Expected no localhost profile, got %q", *out.LocalhostProfile)
+	}
+}
+
 func TestExpandCSIVolumeSource(t *testing.T) {
 	cases := []struct {
 		Input          []interface{}
@@ -4,11 +4,12 @@
 package kubernetes
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -17,13 +18,17 @@ import (
 
 // Expanders
 
-func expandStatefulSetSpec(s []interface{}) (*v1.StatefulSetSpec, error) {
+func expandStatefulSetSpec(ctx context.Context, s []interface{}) (*v1.StatefulSetSpec, error) {
 	obj := &v1.StatefulSetSpec{}
 	if len(s) == 0 || s[0] == nil {
 		return obj, nil
 	}
 	in := s[0].(map[string]interface{})
 
+	if v, ok := in["ordinals"].([]interface{}); ok && len(v) > 0 {
+		obj.Ordinals = expandStatefulSetSpecOrdinals(v)
+	}
+
 	if v, ok := in["pod_management_policy"].(string); ok {
 		obj.PodManagementPolicy = v1.PodManagementPolicyType(v)
 	}
@@ -49,7 +54,7 @@ func expandStatefulSetSpec(s []interface{}) (*v1.StatefulSetSpec, error) {
 	}
 
 	if v, ok := in["update_strategy"].([]interface{}); ok {
-		us, err := expandStatefulSetSpecUpdateStrategy(v)
+		us, err := expandStatefulSetSpecUpdateStrategy(ctx, v)
 		if err != nil {
 			return obj, err
 		}
@@ -57,7 +62,7 @@ func expandStatefulSetSpec(s []interface{}) (*v1.StatefulSetSpec, error) {
 	}
 
 	if v, ok := in["persistent_volume_claim_retention_policy"].([]interface{}); ok {
-		ret, err := expandStatefulSetSpecPersistentVolumeClaimRetentionPolicy(v)
+		ret, err := expandStatefulSetSpecPersistentVolumeClaimRetentionPolicy(ctx, v)
 		if err != nil {
 			return obj, err
 		}
@@ -89,7 +94,7 @@ func expandStatefulSetSpec(s []interface{}) (*v1.StatefulSetSpec, error) {
 	}
 	return obj, nil
 }
-func expandStatefulSetSpecUpdateStrategy(s []interface{}) (*v1.StatefulSetUpdateStrategy, error) {
+func expandStatefulSetSpecUpdateStrategy(ctx context.Context, s []interface{}) (*v1.StatefulSetUpdateStrategy, error) {
 	ust := &v1.StatefulSetUpdateStrategy{}
 	if len(s) == 0 {
 		return ust, nil
@@ -120,11 +125,11 @@ func expandStatefulSetSpecUpdateStrategy(s []interface{}) (*v1.StatefulSetUpdate
 		u.Partition = ptr.To(int32(p))
 		ust.RollingUpdate = &u
 	}
-	log.Printf("[DEBUG] Expanded StatefulSet.Spec.UpdateStrategy: %#v", ust)
+	tflog.Debug(ctx, fmt.Sprintf("Expanded StatefulSet.Spec.UpdateStrategy: %#v", ust))
 	return ust, nil
 }
 
-func expandStatefulSetSpecPersistentVolumeClaimRetentionPolicy(s []interface{}) (*v1.StatefulSetPersistentVolumeClaimRetentionPolicy, error) {
+func expandStatefulSetSpecPersistentVolumeClaimRetentionPolicy(ctx context.Context, s []interface{}) (*v1.StatefulSetPersistentVolumeClaimRetentionPolicy, error) {
 	retPolicySpec := &v1.StatefulSetPersistentVolumeClaimRetentionPolicy{}
 	if len(s) == 0 {
 		return retPolicySpec, nil
@@ -145,13 +150,28 @@ func expandStatefulSetSpecPersistentVolumeClaimRetentionPolicy(s []interface{})
 	}
 	retPolicySpec.WhenScaled = v1.PersistentVolumeClaimRetentionPolicyType(retWs)
 
-	log.Printf("[DEBUG] Expanded StatefulSet.Spec.PersistentVolumeClaimRetentionPolicy: %#v", retPolicySpec)
+	tflog.Debug(ctx, fmt.Sprintf("Expanded StatefulSet.Spec.PersistentVolumeClaimRetentionPolicy: %#v", retPolicySpec))
 	return retPolicySpec, nil
 }
 
+func expandStatefulSetSpecOrdinals(s []interface{}) *v1.StatefulSetOrdinals {
+	obj := &v1.StatefulSetOrdinals{}
+	if len(s) == 0 || s[0] == nil {
+		return obj
+	}
+	in := s[0].(map[string]interface{})
+	if v, ok := in["start"].(int); ok {
+		obj.Start = int32(v)
+	}
+	return obj
+}
+
 func flattenStatefulSetSpec(spec v1.StatefulSetSpec, d *schema.ResourceData, meta interface{}) ([]interface{}, error) {
 	att := make(map[string]interface{})
 
+	if spec.Ordinals != nil {
+		att["ordinals"] = flattenStatefulSetSpecOrdinals(*spec.Ordinals)
+	}
 	if spec.PodManagementPolicy != "" {
 		att["pod_management_policy"] = spec.PodManagementPolicy
 	}
@@ -240,13 +260,19 @@ func flattenStatefulSetSpecPersistentVolumeClaimRetentionPolicy(s v1.StatefulSet
 	return []interface{}{ret}
 }
 
+func flattenStatefulSetSpecOrdinals(s v1.StatefulSetOrdinals) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"start": s.Start,
+	}}
+}
+
 // Patchers
 
-func patchStatefulSetSpec(d *schema.ResourceData) (PatchOperations, error) {
+func patchStatefulSetSpec(ctx context.Context, d *schema.ResourceData) (PatchOperations, error) {
 	ops := PatchOperations{}
 
 	if d.HasChange("spec.0.replicas") {
-		log.Printf("[TRACE] StatefulSet.Spec.Replicas has changes")
+		tflog.Trace(ctx, "StatefulSet.Spec.Replicas has changes")
 		if v, ok := d.Get("spec.0.replicas").(string); ok && v != "" {
 			vv, err := strconv.Atoi(v)
 			if err != nil {
@@ -259,12 +285,28 @@ func patchStatefulSetSpec(d *schema.ResourceData) (PatchOperations, error) {
 		}
 	}
 
-	if d.HasChange("spec.0.template") {
-		log.Printf("[TRACE] StatefulSet.Spec.Template has changes")
+	if d.HasChange("spec.0.ordinals") {
+		tflog.Trace(ctx, "StatefulSet.Spec.Ordinals has changes")
+		if v, ok := d.Get("spec.0.ordinals").([]interface{}); ok && len(v) > 0 {
+			// An add operation replaces the ordinals when they're already set.
+			ops = append(ops, &AddOperation{
+				Path:  "/spec/ordinals",
+				Value: expandStatefulSetSpecOrdinals(v),
+			})
+		} else {
+			ops = append(ops, &RemoveOperation{
+				Path: "/spec/ordinals",
+			})
+		}
+	}
+
+	if d.HasChange("spec.0.template") || d.HasChange("rollout_restart_triggers") {
+		tflog.Trace(ctx, "StatefulSet.Spec.Template has changes")
 		template, err := expandPodTemplate(d.Get("spec.0.template").([]interface{}))
 		if err != nil {
 			return ops, err
 		}
+		expandRolloutRestartedAt(template, d)
 		ops = append(ops, &ReplaceOperation{
 			Path:  "/spec/template",
 			Value: template,
@@ -272,8 +314,8 @@ func patchStatefulSetSpec(d *schema.ResourceData) (PatchOperations, error) {
 	}
 
 	if d.HasChange("spec.0.update_strategy") {
-		log.Printf("[TRACE] StatefulSet.Spec.UpdateStrategy has changes")
-		u, err := patchUpdateStrategy("spec.0.update_strategy.0.", "/spec/updateStrategy/", d)
+		tflog.Trace(ctx, "StatefulSet.Spec.UpdateStrategy has changes")
+		u, err := patchUpdateStrategy(ctx, "spec.0.update_strategy.0.", "/spec/updateStrategy/", d)
 		if err != nil {
 			return ops, err
 		}
@@ -281,7 +323,7 @@ func patchStatefulSetSpec(d *schema.ResourceData) (PatchOperations, error) {
 	}
 
 	if d.HasChange("spec.0.persistent_volume_claim_retention_policy") {
-		log.Printf("[TRACE] StatefulSet.Spec.PersistentVolumeClaimRetentionPolicy has changes")
+		tflog.Trace(ctx, "StatefulSet.Spec.PersistentVolumeClaimRetentionPolicy has changes")
 		if wd, ok := d.Get("spec.0.persistent_volume_claim_retention_policy.0.when_deleted").(string); ok && wd != "" {
 			ops = append(ops, &ReplaceOperation{
 				Path:  "/spec/persistentVolumeClaimRetentionPolicy/whenDeleted",
@@ -297,7 +339,7 @@ func patchStatefulSetSpec(d *schema.ResourceData) (PatchOperations, error) {
 	}
 
 	if d.HasChange("spec.0.min_ready_seconds") {
-		log.Printf("[TRACE] StatefulSet.Spec.MinReadySeconds has changes")
+		tflog.Trace(ctx, "StatefulSet.Spec.MinReadySeconds has changes")
 		if v, ok := d.Get("spec.0.min_ready_seconds").(int); ok {
 			vv := int32(v)
 			ops = append(ops, &ReplaceOperation{
@@ -309,11 +351,11 @@ func patchStatefulSetSpec(d *schema.ResourceData) (PatchOperations, error) {
 	return ops, nil
 }
 
-func patchUpdateStrategy(keyPrefix, pathPrefix string, d *schema.ResourceData) (PatchOperations, error) {
+func patchUpdateStrategy(ctx context.Context, keyPrefix, pathPrefix string, d *schema.ResourceData) (PatchOperations, error) {
 	ops := PatchOperations{}
 
 	if d.HasChange(keyPrefix + "type") {
-		log.Printf("[TRACE] StatefulSet.Spec.UpdateStrategy.Type has changes")
+		tflog.Trace(ctx, "StatefulSet.Spec.UpdateStrategy.Type has changes")
 		oldV, newV := d.GetChange(keyPrefix + "type")
 		o := oldV.(string)
 		n := newV.(string)
@@ -335,7 +377,7 @@ func patchUpdateStrategy(keyPrefix, pathPrefix string, d *schema.ResourceData) (
 
 	if d.HasChange(keyPrefix + "rolling_update") {
 		o, n := d.GetChange(keyPrefix + "rolling_update")
-		log.Printf("[TRACE] StatefulSet.Spec.UpdateStrategy.RollingUpdate has changes: %#v | %#v", o, n)
+		tflog.Trace(ctx, fmt.Sprintf("StatefulSet.Spec.UpdateStrategy.RollingUpdate has changes: %#v | %#v", o, n))
 
 		if len(o.([]interface{})) > 0 && len(n.([]interface{})) == 0 {
 			ops = append(ops, &RemoveOperation{
@@ -355,17 +397,17 @@ func patchUpdateStrategy(keyPrefix, pathPrefix string, d *schema.ResourceData) (
 		}
 
 		if len(o.([]interface{})) > 0 && len(n.([]interface{})) > 0 {
-			ops = append(ops, patchUpdateStrategyRollingUpdate(keyPrefix+"rolling_update.0.", pathPrefix+"rollingUpdate/", d)...)
+			ops = append(ops, patchUpdateStrategyRollingUpdate(ctx, keyPrefix+"rolling_update.0.", pathPrefix+"rollingUpdate/", d)...)
 		}
 	}
 
 	return ops, nil
 }
 
-func patchUpdateStrategyRollingUpdate(keyPrefix, pathPrefix string, d *schema.ResourceData) PatchOperations {
+func patchUpdateStrategyRollingUpdate(ctx context.Context, keyPrefix, pathPrefix string, d *schema.ResourceData) PatchOperations {
 	ops := PatchOperations{}
 	if d.HasChange(keyPrefix + "partition") {
-		log.Printf("[TRACE] StatefulSet.Spec.UpdateStrategy.RollingUpdate.Partition has changes")
+		tflog.Trace(ctx, "StatefulSet.Spec.UpdateStrategy.RollingUpdate.Partition has changes")
 		if p, ok := d.Get(keyPrefix + "partition").(int); ok {
 			ops = append(ops, &ReplaceOperation{
 				Path:  pathPrefix + "partition",
@@ -269,6 +269,34 @@ func TestFlattenMetadata(t *testing.T) {
 				"uid":              uid,
 			}},
 		},
+		"RunAttribution": {
+			metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"bar.example.com":            "foo",
+					"terraform.io/workspace":     "production",
+					"terraform.io/run-id":        "run-CZcmD7eagjhyX0vN",
+					"terraform.io/resource-type": "kubernetes_deployment_v1",
+				},
+				Generation:      1,
+				Name:            "foo",
+				ResourceVersion: "1",
+				UID:             types.UID(uid),
+			},
+			providerMetadata{
+				IgnoreAnnotations: []string{},
+				IgnoreLabels:      []string{},
+			},
+			[]interface{}{map[string]interface{}{
+				"annotations": map[string]string{
+					"bar.example.com": "foo",
+				},
+				"generation":       int64(1),
+				"labels":           map[string]string(nil),
+				"name":             "foo",
+				"resource_version": "1",
+				"uid":              uid,
+			}},
+		},
 	}
 	rawData := map[string]interface{}{
 		"metadata": []interface{}{map[string]interface{}{
@@ -11,6 +11,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+	_ "time/tzdata" // Time zones are validated regardless of the system time zone database.
 
 	"github.com/robfig/cron"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -74,6 +76,14 @@ func validateName(value interface{}, key string) (ws []string, es []error) {
 	return
 }
 
+func validateQualifiedName(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	for _, msg := range utilValidation.IsQualifiedName(v) {
+		es = append(es, fmt.Errorf("%s (%q) %s", key, v, msg))
+	}
+	return
+}
+
 func validateGenerateName(value interface{}, key string) (ws []string, es []error) {
 	v := value.(string)
 
@@ -296,8 +306,30 @@ func validateCronExpression(v interface{}, k string) ([]string, []error) {
 
 	_, err := cron.ParseStandard(v.(string))
 	if err != nil {
-		errors = append(errors, fmt.Errorf("%q should be an valid Cron expression", k))
+		errors = append(errors, fmt.Errorf("%q should be an valid Cron expression: %s", k, err))
 	}
 
 	return []string{}, errors
 }
+
+// validateCronJobV1Schedule validates the schedule of a batch/v1 cron job, whose
+// time zone can't be set in the schedule with a TZ or CRON_TZ prefix.
+func validateCronJobV1Schedule(v interface{}, k string) ([]string, []error) {
+	if strings.Contains(v.(string), "TZ") {
+		return nil, []error{fmt.Errorf("%q cannot use TZ or CRON_TZ, use `timezone` instead", k)}
+	}
+	return validateCronExpression(v, k)
+}
+
+// validateTimeZone validates an explicit time zone of the IANA time zone
+// database, e.g. `Europe/Paris`.
+func validateTimeZone(v interface{}, k string) ([]string, []error) {
+	tz := v.(string)
+	if strings.EqualFold(tz, "Local") {
+		return nil, []error{fmt.Errorf("%q must be an explicit time zone as defined in https://www.iana.org/time-zones, got %q", k, tz)}
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a time zone as defined in https://www.iana.org/time-zones: %s", k, err)}
+	}
+	return nil, nil
+}
@@ -164,3 +164,60 @@ func TestValidateTypeStringNullableIntOrPercent(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateCronJobV1Schedule(t *testing.T) {
+	validCases := []string{
+		"*/15 * * * *",
+		"0 3 * * MON-FRI",
+		"0 0 ? JAN *",
+		"@hourly",
+		"@every 90m",
+	}
+	for _, data := range validCases {
+		_, es := validateCronJobV1Schedule(data, "schedule")
+		if len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", data, es)
+		}
+	}
+	invalidCases := []string{
+		"",
+		"* * * * * *",
+		"0 0 L * *",
+		"0 0 * * 7",
+		"@fortnightly",
+		"CRON_TZ=Europe/Paris 0 3 * * *",
+	}
+	for _, data := range invalidCases {
+		_, es := validateCronJobV1Schedule(data, "schedule")
+		if len(es) == 0 {
+			t.Fatalf("Expected %q to be invalid", data)
+		}
+	}
+}
+
+func TestValidateTimeZone(t *testing.T) {
+	validCases := []string{
+		"",
+		"UTC",
+		"Europe/Paris",
+		"America/Argentina/Buenos_Aires",
+	}
+	for _, data := range validCases {
+		_, es := validateTimeZone(data, "timezone")
+		if len(es) > 0 {
+			t.Fatalf("Expected %q to be valid: %#v", data, es)
+		}
+	}
+	invalidCases := []string{
+		"Local",
+		"local",
+		"Europe/Pariss",
+		"GMT+2",
+	}
+	for _, data := range invalidCases {
+		_, es := validateTimeZone(data, "timezone")
+		if len(es) == 0 {
+			t.Fatalf("Expected %q to be invalid", data)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	gversion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// supportedVersionSkew is the number of minor versions the API server may
+	// be ahead of or behind the Kubernetes version of client-go.
+	supportedVersionSkew = 1
+	// versionSkewTimeout bounds the request for the version of the API server
+	// made when the provider is configured.
+	versionSkewTimeout = 5 * time.Second
+)
+
+// clientGoVersion returns the Kubernetes version of the client-go module the
+// provider is built with, e.g. 1.32.3 for k8s.io/client-go v0.32.3.
+func clientGoVersion() (*gversion.Version, error) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil, fmt.Errorf("build information isn't available")
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != "k8s.io/client-go" {
+			continue
+		}
+		v := dep.Version
+		if dep.Replace != nil {
+			v = dep.Replace.Version
+		}
+		cv, err := gversion.NewVersion(v)
+		if err != nil {
+			return nil, err
+		}
+		segments := cv.Segments()
+		return gversion.NewVersion(fmt.Sprintf("1.%d.%d", segments[1], segments[2]))
+	}
+	return nil, fmt.Errorf("k8s.io/client-go isn't a dependency")
+}
+
+// checkVersionSkew warns when the version of the API server is more than
+// supportedVersionSkew minor versions apart from the version of client-go.
+// Fields the provider doesn't know of are silently dropped with large skews.
+// The check is skipped when the API server can't be reached, e.g. because the
+// cluster doesn't exist yet.
+func checkVersionSkew(ctx context.Context, cfg *rest.Config) diag.Diagnostics {
+	if cfg == nil || cfg.Host == "" {
+		return nil
+	}
+	cv, err := clientGoVersion()
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping the version skew check, the version of client-go is unknown: %s", err))
+		return nil
+	}
+
+	c := rest.CopyConfig(cfg)
+	c.Timeout = versionSkewTimeout
+	dc, err := discovery.NewDiscoveryClientForConfig(c)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping the version skew check: %s", err))
+		return nil
+	}
+	info, err := dc.ServerVersion()
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping the version skew check, the version of the API server is unknown: %s", err))
+		return nil
+	}
+	sv, err := gversion.NewVersion(info.GitVersion)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Skipping the version skew check, the version %q of the API server is invalid: %s", info.GitVersion, err))
+		return nil
+	}
+
+	return versionSkewDiagnostics(sv, cv)
+}
+
+// versionSkewDiagnostics returns a warning when the minor versions of the API
+// server and of the client are more than supportedVersionSkew apart.
+func versionSkewDiagnostics(server, client *gversion.Version) diag.Diagnostics {
+	skew := server.Segments()[1] - client.Segments()[1]
+	if server.Segments()[0] != client.Segments()[0] || skew > supportedVersionSkew || skew < -supportedVersionSkew {
+		direction := "newer"
+		if skew < 0 {
+			direction = "older"
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Unsupported Kubernetes version skew",
+			Detail: fmt.Sprintf("The Kubernetes API server runs version %s, which is %s than the Kubernetes %d.%d client the provider is built with by more than %d minor version. "+
+				"Fields which are unknown to one side may be dropped silently, upgrade the provider or the cluster so that their minor versions are at most %d apart.",
+				server.Original(), direction, client.Segments()[0], client.Segments()[1], supportedVersionSkew, supportedVersionSkew),
+		}}
+	}
+	return nil
+}
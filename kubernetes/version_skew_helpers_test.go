@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"testing"
+
+	gversion "github.com/hashicorp/go-version"
+)
+
+func TestVersionSkewDiagnostics(t *testing.T) {
+	client := gversion.Must(gversion.NewVersion("1.32.3"))
+	cases := map[string]struct {
+		server  string
+		warning bool
+	}{
+		"Same":         {"v1.32.1", false},
+		"OneNewer":     {"v1.33.0", false},
+		"OneOlder":     {"v1.31.4-eks-a5565ad", false},
+		"TwoNewer":     {"v1.34.0", true},
+		"ThreeOlder":   {"v1.29.15-gke.1200", true},
+		"MajorVersion": {"v2.32.0", true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			diags := versionSkewDiagnostics(gversion.Must(gversion.NewVersion(tc.server)), client)
+			if got := len(diags) > 0; got != tc.warning {
+				t.Fatalf("expected warning %t, got %#v", tc.warning, diags)
+			}
+			if tc.warning && diags.HasError() {
+				t.Fatalf("expected a warning, got an error: %#v", diags)
+			}
+		})
+	}
+}
+
+func TestClientGoVersion(t *testing.T) {
+	v, err := clientGoVersion()
+	if err != nil {
+		t.Skipf("the version of client-go is unknown: %s", err)
+	}
+	if v.Segments()[0] != 1 || v.Segments()[1] < 20 {
+		t.Fatalf("unexpected Kubernetes version of client-go: %s", v)
+	}
+}
@@ -0,0 +1,713 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	gocty "github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	waitStatePending = "Pending"
+	waitStateDone    = "Done"
+)
+
+// waitableResource describes the API resource a structured resource manages,
+// so that the generic wait block can fetch it with the dynamic client.
+type waitableResource struct {
+	GroupVersionResource k8sschema.GroupVersionResource
+	Namespaced           bool
+}
+
+func namespacedWaitable(group, version, resource string) waitableResource {
+	return waitableResource{
+		GroupVersionResource: k8sschema.GroupVersionResource{Group: group, Version: version, Resource: resource},
+		Namespaced:           true,
+	}
+}
+
+func clusterWaitable(group, version, resource string) waitableResource {
+	return waitableResource{
+		GroupVersionResource: k8sschema.GroupVersionResource{Group: group, Version: version, Resource: resource},
+	}
+}
+
+// waitableResources lists the structured resources that support the wait block.
+// Resources which only manage a part of another object, such as kubernetes_labels
+// or kubernetes_config_map_v1_data, are intentionally left out.
+var waitableResources = map[string]waitableResource{
+	// core
+	"kubernetes_namespace":                  clusterWaitable("", "v1", "namespaces"),
+	"kubernetes_namespace_v1":               clusterWaitable("", "v1", "namespaces"),
+	"kubernetes_service":                    namespacedWaitable("", "v1", "services"),
+	"kubernetes_service_v1":                 namespacedWaitable("", "v1", "services"),
+	"kubernetes_service_account":            namespacedWaitable("", "v1", "serviceaccounts"),
+	"kubernetes_service_account_v1":         namespacedWaitable("", "v1", "serviceaccounts"),
+	"kubernetes_default_service_account":    namespacedWaitable("", "v1", "serviceaccounts"),
+	"kubernetes_default_service_account_v1": namespacedWaitable("", "v1", "serviceaccounts"),
+	"kubernetes_config_map":                 namespacedWaitable("", "v1", "configmaps"),
+	"kubernetes_config_map_v1":              namespacedWaitable("", "v1", "configmaps"),
+	"kubernetes_secret":                     namespacedWaitable("", "v1", "secrets"),
+	"kubernetes_secret_v1":                  namespacedWaitable("", "v1", "secrets"),
+	"kubernetes_pod":                        namespacedWaitable("", "v1", "pods"),
+	"kubernetes_pod_v1":                     namespacedWaitable("", "v1", "pods"),
+	"kubernetes_endpoints":                  namespacedWaitable("", "v1", "endpoints"),
+	"kubernetes_endpoints_v1":               namespacedWaitable("", "v1", "endpoints"),
+	"kubernetes_endpoint_slice_v1":          namespacedWaitable("discovery.k8s.io", "v1", "endpointslices"),
+	"kubernetes_limit_range":                namespacedWaitable("", "v1", "limitranges"),
+	"kubernetes_limit_range_v1":             namespacedWaitable("", "v1", "limitranges"),
+	"kubernetes_persistent_volume":          clusterWaitable("", "v1", "persistentvolumes"),
+	"kubernetes_persistent_volume_v1":       clusterWaitable("", "v1", "persistentvolumes"),
+	"kubernetes_persistent_volume_claim":    namespacedWaitable("", "v1", "persistentvolumeclaims"),
+	"kubernetes_persistent_volume_claim_v1": namespacedWaitable("", "v1", "persistentvolumeclaims"),
+	"kubernetes_replication_controller":     namespacedWaitable("", "v1", "replicationcontrollers"),
+	"kubernetes_replication_controller_v1":  namespacedWaitable("", "v1", "replicationcontrollers"),
+	"kubernetes_resource_quota":             namespacedWaitable("", "v1", "resourcequotas"),
+	"kubernetes_resource_quota_v1":          namespacedWaitable("", "v1", "resourcequotas"),
+
+	// api registration
+	"kubernetes_api_service":    clusterWaitable("apiregistration.k8s.io", "v1", "apiservices"),
+	"kubernetes_api_service_v1": clusterWaitable("apiregistration.k8s.io", "v1", "apiservices"),
+
+	// apps
+	"kubernetes_deployment":      namespacedWaitable("apps", "v1", "deployments"),
+	"kubernetes_deployment_v1":   namespacedWaitable("apps", "v1", "deployments"),
+	"kubernetes_daemonset":       namespacedWaitable("apps", "v1", "daemonsets"),
+	"kubernetes_daemon_set_v1":   namespacedWaitable("apps", "v1", "daemonsets"),
+	"kubernetes_stateful_set":    namespacedWaitable("apps", "v1", "statefulsets"),
+	"kubernetes_stateful_set_v1": namespacedWaitable("apps", "v1", "statefulsets"),
+
+	// batch
+	"kubernetes_job":         namespacedWaitable("batch", "v1", "jobs"),
+	"kubernetes_job_v1":      namespacedWaitable("batch", "v1", "jobs"),
+	"kubernetes_cron_job":    namespacedWaitable("batch", "v1beta1", "cronjobs"),
+	"kubernetes_cron_job_v1": namespacedWaitable("batch", "v1", "cronjobs"),
+
+	// autoscaling
+	"kubernetes_horizontal_pod_autoscaler":         namespacedWaitable("autoscaling", "v1", "horizontalpodautoscalers"),
+	"kubernetes_horizontal_pod_autoscaler_v1":      namespacedWaitable("autoscaling", "v1", "horizontalpodautoscalers"),
+	"kubernetes_horizontal_pod_autoscaler_v2beta2": namespacedWaitable("autoscaling", "v2beta2", "horizontalpodautoscalers"),
+	"kubernetes_horizontal_pod_autoscaler_v2":      namespacedWaitable("autoscaling", "v2", "horizontalpodautoscalers"),
+	"kubernetes_vertical_pod_autoscaler_v1":        namespacedWaitable("autoscaling.k8s.io", "v1", "verticalpodautoscalers"),
+
+	// rbac
+	"kubernetes_role":                    namespacedWaitable("rbac.authorization.k8s.io", "v1", "roles"),
+	"kubernetes_role_v1":                 namespacedWaitable("rbac.authorization.k8s.io", "v1", "roles"),
+	"kubernetes_role_binding":            namespacedWaitable("rbac.authorization.k8s.io", "v1", "rolebindings"),
+	"kubernetes_role_binding_v1":         namespacedWaitable("rbac.authorization.k8s.io", "v1", "rolebindings"),
+	"kubernetes_cluster_role":            clusterWaitable("rbac.authorization.k8s.io", "v1", "clusterroles"),
+	"kubernetes_cluster_role_v1":         clusterWaitable("rbac.authorization.k8s.io", "v1", "clusterroles"),
+	"kubernetes_cluster_role_binding":    clusterWaitable("rbac.authorization.k8s.io", "v1", "clusterrolebindings"),
+	"kubernetes_cluster_role_binding_v1": clusterWaitable("rbac.authorization.k8s.io", "v1", "clusterrolebindings"),
+
+	// networking
+	"kubernetes_ingress":           namespacedWaitable("extensions", "v1beta1", "ingresses"),
+	"kubernetes_ingress_v1":        namespacedWaitable("networking.k8s.io", "v1", "ingresses"),
+	"kubernetes_ingress_class":     clusterWaitable("networking.k8s.io", "v1", "ingressclasses"),
+	"kubernetes_ingress_class_v1":  clusterWaitable("networking.k8s.io", "v1", "ingressclasses"),
+	"kubernetes_network_policy":    namespacedWaitable("networking.k8s.io", "v1", "networkpolicies"),
+	"kubernetes_network_policy_v1": namespacedWaitable("networking.k8s.io", "v1", "networkpolicies"),
+
+	// policy
+	"kubernetes_pod_disruption_budget":       namespacedWaitable("policy", "v1beta1", "poddisruptionbudgets"),
+	"kubernetes_pod_disruption_budget_v1":    namespacedWaitable("policy", "v1", "poddisruptionbudgets"),
+	"kubernetes_pod_security_policy":         clusterWaitable("policy", "v1beta1", "podsecuritypolicies"),
+	"kubernetes_pod_security_policy_v1beta1": clusterWaitable("policy", "v1beta1", "podsecuritypolicies"),
+
+	// scheduling
+	"kubernetes_priority_class":    clusterWaitable("scheduling.k8s.io", "v1", "priorityclasses"),
+	"kubernetes_priority_class_v1": clusterWaitable("scheduling.k8s.io", "v1", "priorityclasses"),
+
+	// admission control
+	"kubernetes_validating_webhook_configuration":    clusterWaitable("admissionregistration.k8s.io", "v1", "validatingwebhookconfigurations"),
+	"kubernetes_validating_webhook_configuration_v1": clusterWaitable("admissionregistration.k8s.io", "v1", "validatingwebhookconfigurations"),
+	"kubernetes_mutating_webhook_configuration":      clusterWaitable("admissionregistration.k8s.io", "v1", "mutatingwebhookconfigurations"),
+	"kubernetes_mutating_webhook_configuration_v1":   clusterWaitable("admissionregistration.k8s.io", "v1", "mutatingwebhookconfigurations"),
+
+	// storage
+	"kubernetes_storage_class":    clusterWaitable("storage.k8s.io", "v1", "storageclasses"),
+	"kubernetes_storage_class_v1": clusterWaitable("storage.k8s.io", "v1", "storageclasses"),
+	"kubernetes_csi_driver":       clusterWaitable("storage.k8s.io", "v1beta1", "csidrivers"),
+	"kubernetes_csi_driver_v1":    clusterWaitable("storage.k8s.io", "v1", "csidrivers"),
+
+	// node
+	"kubernetes_runtime_class_v1": clusterWaitable("node.k8s.io", "v1", "runtimeclasses"),
+
+	// resource
+	"kubernetes_device_class_v1beta1":            clusterWaitable("resource.k8s.io", "v1beta1", "deviceclasses"),
+	"kubernetes_resource_claim_v1beta1":          namespacedWaitable("resource.k8s.io", "v1beta1", "resourceclaims"),
+	"kubernetes_resource_claim_template_v1beta1": namespacedWaitable("resource.k8s.io", "v1beta1", "resourceclaimtemplates"),
+}
+
+func waitSchema(forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Wait for the object to satisfy the given conditions and field values after it has been created or updated.",
+		Optional:    true,
+		ForceNew:    forceNew,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"condition": {
+					Type:        schema.TypeList,
+					Description: "Status conditions the object must report.",
+					Optional:    true,
+					ForceNew:    forceNew,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"type": {
+								Type:        schema.TypeString,
+								Description: "The type of the condition.",
+								Required:    true,
+								ForceNew:    forceNew,
+							},
+							"status": {
+								Type:        schema.TypeString,
+								Description: "The expected status of the condition.",
+								Optional:    true,
+								ForceNew:    forceNew,
+								Default:     "True",
+							},
+						},
+					},
+				},
+				"fields": {
+					Type:             schema.TypeMap,
+					Description:      "A map of paths to fields of the object and regular expressions their values must match. Use `*` to wait for a field to be present regardless of its value.",
+					Optional:         true,
+					ForceNew:         forceNew,
+					Elem:             &schema.Schema{Type: schema.TypeString},
+					ValidateDiagFunc: validateWaitFields,
+				},
+				"fail_on": {
+					Type:        schema.TypeList,
+					Description: "Stop waiting with an error as soon as the object matches any of these conditions, instead of waiting for the timeout to expire.",
+					Optional:    true,
+					ForceNew:    forceNew,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"condition": {
+								Type:        schema.TypeList,
+								Description: "Status conditions which indicate a failure.",
+								Optional:    true,
+								ForceNew:    forceNew,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"type": {
+											Type:        schema.TypeString,
+											Description: "The type of the condition.",
+											Required:    true,
+											ForceNew:    forceNew,
+										},
+										"status": {
+											Type:        schema.TypeString,
+											Description: "The status of the condition.",
+											Optional:    true,
+											ForceNew:    forceNew,
+											Default:     "True",
+										},
+										"reason": {
+											Type:        schema.TypeString,
+											Description: "The reason of the condition. Any reason matches when omitted.",
+											Optional:    true,
+											ForceNew:    forceNew,
+										},
+									},
+								},
+							},
+							"fields": {
+								Type:             schema.TypeMap,
+								Description:      "A map of paths to fields of the object and regular expressions which indicate a failure when a value matches.",
+								Optional:         true,
+								ForceNew:         forceNew,
+								Elem:             &schema.Schema{Type: schema.TypeString},
+								ValidateDiagFunc: validateWaitFields,
+							},
+							"container_waiting_reasons": {
+								Type:        schema.TypeList,
+								Description: "Reasons of waiting containers which indicate a failure, e.g. `CrashLoopBackOff` or `ImagePullBackOff`. The containers of a pod are checked directly, for other objects the containers of the pods matched by their `spec.selector` are checked.",
+								Optional:    true,
+								ForceNew:    forceNew,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+				"timeout": {
+					Type:         schema.TypeString,
+					Description:  "How long to wait before giving up, e.g. `5m`. Defaults to the create or update timeout of the resource.",
+					Optional:     true,
+					ForceNew:     forceNew,
+					ValidateFunc: validateWaitDuration,
+				},
+				"poll_interval": {
+					Type:         schema.TypeString,
+					Description:  "How often the object is checked, e.g. `10s`.",
+					Optional:     true,
+					ForceNew:     forceNew,
+					Default:      "1s",
+					ValidateFunc: validateWaitDuration,
+				},
+			},
+		},
+	}
+}
+
+func validateWaitDuration(value interface{}, key string) ([]string, []error) {
+	v := value.(string)
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %q is not a valid duration: %s", key, v, err)}
+	}
+	if d <= 0 {
+		return nil, []error{fmt.Errorf("%s: must be greater than zero, got %q", key, v)}
+	}
+	return nil, nil
+}
+
+func validateWaitFields(value interface{}, _ gocty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for path, expr := range value.(map[string]interface{}) {
+		if _, err := parseWaitFieldPath(path); err != nil {
+			diags = append(diags, diag.Errorf("%s", err)...)
+		}
+		if _, err := compileWaitFieldMatcher(expr.(string)); err != nil {
+			diags = append(diags, diag.Errorf("Invalid regular expression for field %q: %s", path, err)...)
+		}
+	}
+	return diags
+}
+
+// withWaitBlock adds the wait block to a structured resource and runs the wait
+// after every successful create or update.
+func withWaitBlock(r *schema.Resource, wr waitableResource) {
+	r.Schema["wait"] = waitSchema(r.UpdateContext == nil)
+
+	create := r.CreateContext
+	r.CreateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := create(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+		return append(diags, waitForResource(ctx, d, meta, wr, d.Timeout(schema.TimeoutCreate))...)
+	}
+
+	if r.UpdateContext == nil {
+		return
+	}
+	update := r.UpdateContext
+	r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := update(ctx, d, meta)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+		return append(diags, waitForResource(ctx, d, meta, wr, d.Timeout(schema.TimeoutUpdate))...)
+	}
+}
+
+// waitForResource polls the object managed by d until it satisfies the wait
+// block of the resource. It is a no-op when no wait block is configured.
+func waitForResource(ctx context.Context, d *schema.ResourceData, meta interface{}, wr waitableResource, defaultTimeout time.Duration) diag.Diagnostics {
+	w, err := expandWaitBlock(d.Get("wait").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if w == nil {
+		return nil
+	}
+
+	conn, err := meta.(KubeClientsets).DynamicClient()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	pods := conn.Resource(k8sschema.GroupVersionResource{Version: "v1", Resource: "pods"})
+
+	name := d.Get("metadata.0.name").(string)
+	namespace := ""
+	if wr.Namespaced {
+		namespace = d.Get("metadata.0.namespace").(string)
+	}
+	client := conn.Resource(wr.GroupVersionResource).Namespace(namespace)
+
+	timeout := w.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Waiting for %s %q to satisfy the wait block", wr.GroupVersionResource.Resource, d.Id()))
+	var unmet []string
+	stateConf := &retry.StateChangeConf{
+		Pending:      []string{waitStatePending},
+		Target:       []string{waitStateDone},
+		Timeout:      timeout,
+		PollInterval: w.PollInterval,
+		Refresh: func() (interface{}, string, error) {
+			obj, err := client.Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return nil, "", err
+			}
+			if failure := w.failure(obj); failure != "" {
+				return nil, "", fmt.Errorf("%s", failure)
+			}
+			if len(w.FailContainerReasons) > 0 {
+				failure, err := w.podFailure(ctx, pods.Namespace(namespace), obj)
+				if err != nil {
+					return nil, "", err
+				}
+				if failure != "" {
+					return nil, "", fmt.Errorf("%s", failure)
+				}
+			}
+			unmet = w.unmet(obj)
+			if len(unmet) > 0 {
+				tflog.Debug(ctx, fmt.Sprintf("%s %q is still waiting on: %s", wr.GroupVersionResource.Resource, d.Id(), strings.Join(unmet, ", ")))
+				return obj, waitStatePending, nil
+			}
+			return obj, waitStateDone, nil
+		},
+	}
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		if len(unmet) > 0 {
+			return diag.Errorf("Failed waiting for %s %q (still waiting on %s): %s", wr.GroupVersionResource.Resource, d.Id(), strings.Join(unmet, ", "), err)
+		}
+		return diag.Errorf("Failed waiting for %s %q: %s", wr.GroupVersionResource.Resource, d.Id(), err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("%s %q satisfies the wait block", wr.GroupVersionResource.Resource, d.Id()))
+
+	return nil
+}
+
+type waitCondition struct {
+	Type   string
+	Status string
+	Reason string
+}
+
+type waitField struct {
+	Path    string
+	Steps   hcl.Traversal
+	Matcher *regexp.Regexp
+}
+
+type waitBlock struct {
+	Conditions           []waitCondition
+	Fields               []waitField
+	FailConditions       []waitCondition
+	FailFields           []waitField
+	FailContainerReasons []string
+	Timeout              time.Duration
+	PollInterval         time.Duration
+}
+
+func expandWaitBlock(l []interface{}) (*waitBlock, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+	in := l[0].(map[string]interface{})
+	w := &waitBlock{PollInterval: time.Second}
+
+	var err error
+	if v, ok := in["condition"].([]interface{}); ok {
+		w.Conditions = expandWaitConditions(v)
+	}
+	if v, ok := in["fields"].(map[string]interface{}); ok {
+		w.Fields, err = expandWaitFields(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if v, ok := in["fail_on"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		failOn := v[0].(map[string]interface{})
+		if v, ok := failOn["condition"].([]interface{}); ok {
+			w.FailConditions = expandWaitConditions(v)
+		}
+		if v, ok := failOn["fields"].(map[string]interface{}); ok {
+			w.FailFields, err = expandWaitFields(v)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if v, ok := failOn["container_waiting_reasons"].([]interface{}); ok {
+			w.FailContainerReasons = expandStringSlice(v)
+		}
+	}
+
+	if v, ok := in["timeout"].(string); ok && v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, err
+		}
+		w.Timeout = d
+	}
+	if v, ok := in["poll_interval"].(string); ok && v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, err
+		}
+		w.PollInterval = d
+	}
+
+	return w, nil
+}
+
+func expandWaitConditions(l []interface{}) []waitCondition {
+	var conditions []waitCondition
+	for _, c := range l {
+		if c == nil {
+			continue
+		}
+		m := c.(map[string]interface{})
+		condition := waitCondition{
+			Type:   m["type"].(string),
+			Status: m["status"].(string),
+		}
+		if v, ok := m["reason"].(string); ok {
+			condition.Reason = v
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions
+}
+
+func expandWaitFields(m map[string]interface{}) ([]waitField, error) {
+	var fields []waitField
+	for path, expr := range m {
+		steps, err := parseWaitFieldPath(path)
+		if err != nil {
+			return nil, err
+		}
+		re, err := compileWaitFieldMatcher(expr.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid regular expression for field %q: %s", path, err)
+		}
+		fields = append(fields, waitField{Path: path, Steps: steps, Matcher: re})
+	}
+	// Map iteration order is random, keep the reported order stable.
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Path < fields[j].Path })
+	return fields, nil
+}
+
+// failure returns a description of the first failure condition or field of the
+// wait block matched by the object, or an empty string.
+func (w *waitBlock) failure(obj *unstructured.Unstructured) string {
+	for _, c := range w.FailConditions {
+		if objectHasCondition(obj.Object, c) {
+			if c.Reason != "" {
+				return fmt.Sprintf("%s %q reports the failure condition %s=%s with reason %s", obj.GetKind(), obj.GetName(), c.Type, c.Status, c.Reason)
+			}
+			return fmt.Sprintf("%s %q reports the failure condition %s=%s", obj.GetKind(), obj.GetName(), c.Type, c.Status)
+		}
+	}
+	for _, f := range w.FailFields {
+		if v, ok := lookupWaitField(obj.Object, f.Steps); ok && f.Matcher.MatchString(v) {
+			return fmt.Sprintf("%s %q has the failure value %q at %s", obj.GetKind(), obj.GetName(), v, f.Path)
+		}
+	}
+	return ""
+}
+
+// podFailure checks the containers of the object, when it is a pod, or of the
+// pods matched by its selector for a failure reason of the wait block.
+func (w *waitBlock) podFailure(ctx context.Context, pods dynamic.ResourceInterface, obj *unstructured.Unstructured) (string, error) {
+	if obj.GetKind() == "Pod" {
+		return containerFailure(obj, w.FailContainerReasons), nil
+	}
+
+	selector, ok, err := podSelectorForObject(obj.Object)
+	if err != nil || !ok {
+		return "", err
+	}
+	list, err := pods.List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return "", err
+	}
+	for i := range list.Items {
+		if failure := containerFailure(&list.Items[i], w.FailContainerReasons); failure != "" {
+			return failure, nil
+		}
+	}
+	return "", nil
+}
+
+func containerFailure(pod *unstructured.Unstructured, reasons []string) string {
+	for _, field := range []string{"initContainerStatuses", "containerStatuses"} {
+		statuses, _, _ := unstructured.NestedSlice(pod.Object, "status", field)
+		for _, s := range statuses {
+			status, ok := s.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			reason, _, _ := unstructured.NestedString(status, "state", "waiting", "reason")
+			for _, r := range reasons {
+				if reason != "" && reason == r {
+					message, _, _ := unstructured.NestedString(status, "state", "waiting", "message")
+					return strings.TrimSuffix(fmt.Sprintf("Container %q of pod %q is waiting with reason %s: %s", status["name"], pod.GetName(), reason, message), ": ")
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// podSelectorForObject returns the label selector of the pods managed by an
+// object, which is either a label selector, as used by workloads, or a plain
+// map of labels, as used by services and replication controllers.
+func podSelectorForObject(obj map[string]interface{}) (string, bool, error) {
+	sel, ok, _ := unstructured.NestedMap(obj, "spec", "selector")
+	if !ok || len(sel) == 0 {
+		return "", false, nil
+	}
+	_, hasLabels := sel["matchLabels"]
+	_, hasExpressions := sel["matchExpressions"]
+	if hasLabels || hasExpressions {
+		ls := &metav1.LabelSelector{}
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(sel, ls)
+		if err != nil {
+			return "", false, err
+		}
+		selector, err := metav1.LabelSelectorAsSelector(ls)
+		if err != nil {
+			return "", false, err
+		}
+		return selector.String(), true, nil
+	}
+	m, _, err := unstructured.NestedStringMap(obj, "spec", "selector")
+	if err != nil {
+		return "", false, err
+	}
+	return labels.SelectorFromSet(m).String(), true, nil
+}
+
+// unmet returns a description of every condition and field of the wait block
+// that the object doesn't satisfy yet.
+func (w *waitBlock) unmet(obj *unstructured.Unstructured) []string {
+	var unmet []string
+	for _, c := range w.Conditions {
+		if !objectHasCondition(obj.Object, c) {
+			unmet = append(unmet, fmt.Sprintf("condition %s=%s", c.Type, c.Status))
+		}
+	}
+	for _, f := range w.Fields {
+		v, ok := lookupWaitField(obj.Object, f.Steps)
+		if !ok || !f.Matcher.MatchString(v) {
+			unmet = append(unmet, fmt.Sprintf("field %s", f.Path))
+		}
+	}
+	return unmet
+}
+
+func objectHasCondition(obj map[string]interface{}, c waitCondition) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj, "status", "conditions")
+	for _, v := range conditions {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if m["type"] != c.Type || !strings.EqualFold(fmt.Sprintf("%v", m["status"]), c.Status) {
+			continue
+		}
+		if c.Reason == "" || m["reason"] == c.Reason {
+			return true
+		}
+	}
+	return false
+}
+
+// parseWaitFieldPath parses a path such as `status.loadBalancer.ingress[0].ip`
+// or `metadata.annotations["example.com/ready"]`.
+func parseWaitFieldPath(path string) (hcl.Traversal, error) {
+	t, d := hclsyntax.ParseTraversalAbs([]byte(path), "", hcl.Pos{Line: 1, Column: 1})
+	if d.HasErrors() {
+		return nil, fmt.Errorf("Invalid field path %q: %s", path, d.Error())
+	}
+	return t, nil
+}
+
+func compileWaitFieldMatcher(expr string) (*regexp.Regexp, error) {
+	if expr == "*" {
+		expr = "(.*)?"
+	}
+	return regexp.Compile(expr)
+}
+
+// lookupWaitField resolves a parsed field path against an unstructured object
+// and returns the string representation of the value it points to.
+func lookupWaitField(obj map[string]interface{}, steps hcl.Traversal) (string, bool) {
+	var current interface{} = obj
+	for _, step := range steps {
+		switch s := step.(type) {
+		case hcl.TraverseRoot:
+			m, ok := current.(map[string]interface{})
+			if !ok {
+				return "", false
+			}
+			current, ok = m[s.Name]
+			if !ok {
+				return "", false
+			}
+		case hcl.TraverseAttr:
+			m, ok := current.(map[string]interface{})
+			if !ok {
+				return "", false
+			}
+			current, ok = m[s.Name]
+			if !ok {
+				return "", false
+			}
+		case hcl.TraverseIndex:
+			switch s.Key.Type() {
+			case cty.String:
+				m, ok := current.(map[string]interface{})
+				if !ok {
+					return "", false
+				}
+				current, ok = m[s.Key.AsString()]
+				if !ok {
+					return "", false
+				}
+			case cty.Number:
+				l, ok := current.([]interface{})
+				if !ok {
+					return "", false
+				}
+				i, _ := s.Key.AsBigFloat().Int64()
+				if i < 0 || int(i) >= len(l) {
+					return "", false
+				}
+				current = l[i]
+			default:
+				return "", false
+			}
+		default:
+			return "", false
+		}
+	}
+	switch current.(type) {
+	case map[string]interface{}, []interface{}, nil:
+		return "", false
+	}
+	return fmt.Sprintf("%v", current), true
+}
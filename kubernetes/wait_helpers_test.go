@@ -0,0 +1,269 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestLookupWaitField(t *testing.T) {
+	obj := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				"example.com/ready": "yes",
+			},
+		},
+		"status": map[string]interface{}{
+			"readyReplicas": int64(3),
+			"loadBalancer": map[string]interface{}{
+				"ingress": []interface{}{
+					map[string]interface{}{"ip": "10.0.0.1"},
+				},
+			},
+		},
+	}
+	cases := []struct {
+		Path     string
+		Expected string
+		Found    bool
+	}{
+		{"status.readyReplicas", "3", true},
+		{"status.loadBalancer.ingress[0].ip", "10.0.0.1", true},
+		{`metadata.annotations["example.com/ready"]`, "yes", true},
+		{"status.loadBalancer.ingress[1].ip", "", false},
+		{"status.loadBalancer", "", false},
+		{"status.missing", "", false},
+		{"status.readyReplicas.value", "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Path, func(t *testing.T) {
+			steps, err := parseWaitFieldPath(tc.Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			v, ok := lookupWaitField(obj, steps)
+			if ok != tc.Found || v != tc.Expected {
+				t.Fatalf("expected (%q, %t), got (%q, %t)", tc.Expected, tc.Found, v, ok)
+			}
+		})
+	}
+}
+
+func TestParseWaitFieldPathInvalid(t *testing.T) {
+	for _, path := range []string{"", "status.", "status[", "0.foo"} {
+		if _, err := parseWaitFieldPath(path); err == nil {
+			t.Errorf("expected an error for path %q", path)
+		}
+	}
+}
+
+func TestWaitBlockUnmet(t *testing.T) {
+	w, err := expandWaitBlock([]interface{}{
+		map[string]interface{}{
+			"condition": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Progressing", "status": "False"},
+			},
+			"fields": map[string]interface{}{
+				"status.phase":     "^Running$",
+				"status.podIP":     "*",
+				"status.hostIP":    "*",
+				"spec.nodeName":    "^node-",
+				"status.qosClass":  "Burstable",
+				"status.startTime": "*",
+			},
+			"timeout":       "2m",
+			"poll_interval": "5s",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Timeout != 2*time.Minute || w.PollInterval != 5*time.Second {
+		t.Fatalf("unexpected durations: timeout %s, poll interval %s", w.Timeout, w.PollInterval)
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"nodeName": "node-1",
+		},
+		"status": map[string]interface{}{
+			"phase":     "Running",
+			"podIP":     "10.0.0.2",
+			"hostIP":    "",
+			"qosClass":  "BestEffort",
+			"startTime": "2024-01-01T00:00:00Z",
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Ready", "status": "True"},
+				map[string]interface{}{"type": "Progressing", "status": "True"},
+			},
+		},
+	}}
+	expected := []string{
+		"condition Progressing=False",
+		"field status.qosClass",
+	}
+	if unmet := w.unmet(obj); !reflect.DeepEqual(unmet, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, unmet)
+	}
+}
+
+func TestExpandWaitBlockEmpty(t *testing.T) {
+	w, err := expandWaitBlock([]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w != nil {
+		t.Fatalf("expected no wait block, got %#v", w)
+	}
+}
+
+func TestWaitBlockFailure(t *testing.T) {
+	w, err := expandWaitBlock([]interface{}{
+		map[string]interface{}{
+			"fail_on": []interface{}{
+				map[string]interface{}{
+					"condition": []interface{}{
+						map[string]interface{}{"type": "Progressing", "status": "False", "reason": "ProgressDeadlineExceeded"},
+					},
+					"fields": map[string]interface{}{
+						"status.phase": "^Failed$",
+					},
+					"container_waiting_reasons": []interface{}{"CrashLoopBackOff"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		Name   string
+		Status map[string]interface{}
+		Failed bool
+	}{
+		{
+			"healthy",
+			map[string]interface{}{"phase": "Running"},
+			false,
+		},
+		{
+			"other reason",
+			map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Progressing", "status": "False", "reason": "NewReplicaSetAvailable"},
+				},
+			},
+			false,
+		},
+		{
+			"condition",
+			map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Progressing", "status": "False", "reason": "ProgressDeadlineExceeded"},
+				},
+			},
+			true,
+		},
+		{
+			"field",
+			map[string]interface{}{"phase": "Failed"},
+			true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{
+				"kind":     "Deployment",
+				"metadata": map[string]interface{}{"name": "web"},
+				"status":   tc.Status,
+			}}
+			if failure := w.failure(obj); (failure != "") != tc.Failed {
+				t.Fatalf("expected failure %t, got %q", tc.Failed, failure)
+			}
+		})
+	}
+}
+
+func TestContainerFailure(t *testing.T) {
+	pod := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":     "Pod",
+		"metadata": map[string]interface{}{"name": "web-abc"},
+		"status": map[string]interface{}{
+			"containerStatuses": []interface{}{
+				map[string]interface{}{
+					"name":  "sidecar",
+					"state": map[string]interface{}{"running": map[string]interface{}{}},
+				},
+				map[string]interface{}{
+					"name": "web",
+					"state": map[string]interface{}{
+						"waiting": map[string]interface{}{"reason": "CrashLoopBackOff", "message": "back-off restarting failed container"},
+					},
+				},
+			},
+		},
+	}}
+
+	if failure := containerFailure(pod, []string{"ImagePullBackOff"}); failure != "" {
+		t.Fatalf("expected no failure, got %q", failure)
+	}
+	expected := `Container "web" of pod "web-abc" is waiting with reason CrashLoopBackOff: back-off restarting failed container`
+	if failure := containerFailure(pod, []string{"ImagePullBackOff", "CrashLoopBackOff"}); failure != expected {
+		t.Fatalf("expected %q, got %q", expected, failure)
+	}
+}
+
+func TestPodSelectorForObject(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Spec     map[string]interface{}
+		Expected string
+		Found    bool
+	}{
+		{
+			"none",
+			map[string]interface{}{},
+			"",
+			false,
+		},
+		{
+			"label selector",
+			map[string]interface{}{
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{"app": "web"},
+					"matchExpressions": []interface{}{
+						map[string]interface{}{"key": "tier", "operator": "In", "values": []interface{}{"frontend"}},
+					},
+				},
+			},
+			"app=web,tier in (frontend)",
+			true,
+		},
+		{
+			"map selector",
+			map[string]interface{}{
+				"selector": map[string]interface{}{"app": "web"},
+			},
+			"app=web",
+			true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			selector, ok, err := podSelectorForObject(map[string]interface{}{"spec": tc.Spec})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tc.Found || selector != tc.Expected {
+				t.Fatalf("expected (%q, %t), got (%q, %t)", tc.Expected, tc.Found, selector, ok)
+			}
+		})
+	}
+}
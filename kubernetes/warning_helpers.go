@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apiwarning"
+)
+
+// withAPIWarnings reports the warnings returned by the Kubernetes API for the
+// requests of a resource or data source, e.g. for deprecated APIs and fields,
+// as warning diagnostics of the resource.
+func withAPIWarnings(r *schema.Resource) {
+	r.CreateContext = collectAPIWarnings(r.CreateContext)
+	r.ReadContext = collectAPIWarnings(r.ReadContext)
+	r.UpdateContext = collectAPIWarnings(r.UpdateContext)
+	r.DeleteContext = collectAPIWarnings(r.DeleteContext)
+}
+
+func collectAPIWarnings[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx, warnings := apiwarning.WithCollector(ctx)
+		diags := f(ctx, d, meta)
+		return append(diags, apiWarningDiagnostics(warnings.Warnings())...)
+	}
+}
+
+func apiWarningDiagnostics(warnings []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, w := range warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Kubernetes API warning",
+			Detail:   w,
+		})
+	}
+	return diags
+}
@@ -0,0 +1,465 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+// objectListWatcher is implemented by the typed clients of the clientset,
+// e.g. conn.AppsV1().Deployments(namespace).
+type objectListWatcher[L runtime.Object] interface {
+	List(ctx context.Context, opts metav1.ListOptions) (L, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+}
+
+// singleObjectListWatch returns a ListerWatcher restricted to the object with the given name.
+func singleObjectListWatch[L runtime.Object](ctx context.Context, client objectListWatcher[L], name string) cache.ListerWatcher {
+	selector := fields.OneTermEqualSelector("metadata.name", name).String()
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return client.List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return watchOrPoll(ctx, client, options)
+		},
+	}
+}
+
+// labelSelectorListWatch returns a ListerWatcher restricted to the objects matching the label selector.
+func labelSelectorListWatch[L runtime.Object](ctx context.Context, client objectListWatcher[L], selector string) cache.ListerWatcher {
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = selector
+			return client.List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = selector
+			return watchOrPoll(ctx, client, options)
+		},
+	}
+}
+
+// watchPollInterval is the interval at which the objects of a wait are listed
+// when they can't be watched.
+var watchPollInterval = 2 * time.Second
+
+// watchOrPoll watches the objects of client matching options. When the watch
+// fails, e.g. behind a proxy which doesn't support long-lived requests, the
+// objects are polled instead, so that the wait doesn't depend on the backoff
+// of the retries of the watch.
+func watchOrPoll[L runtime.Object](ctx context.Context, client objectListWatcher[L], options metav1.ListOptions) (watch.Interface, error) {
+	w, err := client.Watch(ctx, options)
+	if err == nil || ctx.Err() != nil {
+		return w, err
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Unable to watch the objects matching %q %q, polling them instead: %s", options.FieldSelector, options.LabelSelector, err))
+	listOptions := metav1.ListOptions{FieldSelector: options.FieldSelector, LabelSelector: options.LabelSelector}
+	pw, perr := newPollWatch(ctx, func() (runtime.Object, error) {
+		return client.List(ctx, listOptions)
+	}, watchPollInterval)
+	if perr != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+// pollWatch is a watch.Interface which lists the objects at an interval and
+// reports their changes since the previous list.
+type pollWatch struct {
+	result chan watch.Event
+	stop   chan struct{}
+	once   sync.Once
+}
+
+func newPollWatch(ctx context.Context, list func() (runtime.Object, error), interval time.Duration) (*pollWatch, error) {
+	items, err := pollWatchItems(list)
+	if err != nil {
+		return nil, err
+	}
+	w := &pollWatch{
+		result: make(chan watch.Event),
+		stop:   make(chan struct{}),
+	}
+	go w.run(ctx, list, interval, items)
+	return w, nil
+}
+
+func (w *pollWatch) Stop() {
+	w.once.Do(func() { close(w.stop) })
+}
+
+func (w *pollWatch) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *pollWatch) run(ctx context.Context, list func() (runtime.Object, error), interval time.Duration, items map[string]runtime.Object) {
+	// Closing the result channel makes the reflector watch the objects again.
+	defer close(w.result)
+
+	send := func(eventType watch.EventType, obj runtime.Object) bool {
+		select {
+		case w.result <- watch.Event{Type: eventType, Object: obj}:
+			return true
+		case <-w.stop:
+		case <-ctx.Done():
+		}
+		return false
+	}
+	// The objects may have changed since they were listed by the reflector.
+	for _, obj := range items {
+		if !send(watch.Modified, obj) {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-w.stop:
+			return
+		case <-ctx.Done():
+			return
+		}
+		current, err := pollWatchItems(list)
+		if err != nil {
+			return
+		}
+		for key, obj := range current {
+			previous, ok := items[key]
+			switch {
+			case !ok:
+				if !send(watch.Added, obj) {
+					return
+				}
+			case resourceVersionOf(previous) != resourceVersionOf(obj):
+				if !send(watch.Modified, obj) {
+					return
+				}
+			}
+		}
+		for key, obj := range items {
+			if _, ok := current[key]; !ok && !send(watch.Deleted, obj) {
+				return
+			}
+		}
+		items = current
+	}
+}
+
+func pollWatchItems(list func() (runtime.Object, error)) (map[string]runtime.Object, error) {
+	l, err := list()
+	if err != nil {
+		return nil, err
+	}
+	objs, err := apimeta.ExtractList(l)
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[string]runtime.Object, len(objs))
+	for _, obj := range objs {
+		m, err := apimeta.Accessor(obj)
+		if err != nil {
+			return nil, err
+		}
+		items[m.GetNamespace()+"/"+m.GetName()] = obj
+	}
+	return items, nil
+}
+
+func resourceVersionOf(obj runtime.Object) string {
+	m, err := apimeta.Accessor(obj)
+	if err != nil {
+		return ""
+	}
+	return m.GetResourceVersion()
+}
+
+// namespaceListWatch returns a ListerWatcher of all the objects of client.
+func namespaceListWatch[L runtime.Object](ctx context.Context, client objectListWatcher[L]) cache.ListerWatcher {
+	ctx = context.WithoutCancel(ctx)
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return client.List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return watchOrPoll(ctx, client, options)
+		},
+	}
+}
+
+// watchUntil waits for an object using the watch API instead of polling it.
+// The object is listed once and then watched from the returned resourceVersion,
+// with bookmarks enabled so that an interrupted watch is resumed without listing
+// the object again.
+//
+// check is called with every observed change of the object, and with a Deleted
+// event carrying a nil object when the object doesn't exist. When lw returns more
+// than one object, check is called for the changes of each of them. It follows the
+// semantics of retry.RetryFunc: nil ends the wait, a retryable error keeps waiting
+// and a non-retryable error is returned immediately. The retryable errors are
+// reported as the progress of waiting for subject.
+func watchUntil(ctx context.Context, timeout time.Duration, subject string, lw cache.ListerWatcher, objType runtime.Object, check func(watch.Event) *retry.RetryError) error {
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(ctx, timeout)
+	defer cancel()
+
+	w := newWatchCheck(ctx, subject, check)
+	defer w.progress.Stop()
+
+	precondition := func(store cache.Store) (bool, error) {
+		if len(store.List()) > 0 {
+			return false, nil
+		}
+		return w.condition(ctx, watch.Event{Type: watch.Deleted})
+	}
+
+	_, err := watchtools.UntilWithSync(ctx, lw, objType, precondition, func(event watch.Event) (bool, error) {
+		return w.condition(ctx, event)
+	})
+	if err != nil && wait.Interrupted(err) {
+		return w.timeoutError(timeout)
+	}
+	return err
+}
+
+// watchCheck turns the check of a wait into a watch condition, reporting the
+// retryable errors as the progress of the wait.
+type watchCheck struct {
+	check    func(watch.Event) *retry.RetryError
+	progress *waitProgress
+	lastErr  error
+}
+
+func newWatchCheck(ctx context.Context, subject string, check func(watch.Event) *retry.RetryError) *watchCheck {
+	return &watchCheck{
+		check:    check,
+		progress: startWaitProgress(ctx, subject),
+	}
+}
+
+func (w *watchCheck) condition(ctx context.Context, event watch.Event) (bool, error) {
+	rerr := w.check(event)
+	if rerr == nil {
+		return true, nil
+	}
+	if !rerr.Retryable {
+		return false, rerr.Err
+	}
+	w.progress.Update(ctx, rerr.Err.Error())
+	w.lastErr = rerr.Err
+	return false, nil
+}
+
+func (w *watchCheck) timeoutError(timeout time.Duration) error {
+	if w.lastErr != nil {
+		return fmt.Errorf("timeout while waiting after %s: %s", timeout, w.lastErr)
+	}
+	return fmt.Errorf("timeout while waiting after %s", timeout)
+}
+
+// sharedWatches multiplexes the concurrent waits for objects of the same kind
+// and namespace over a single watch of the namespace, instead of a watch per
+// object, so that large applies don't trip the API priority and fairness
+// limits. A watch runs as long as a wait uses it.
+type sharedWatches struct {
+	mu      sync.Mutex
+	streams map[string]*sharedWatch
+	// forbidden are the keys of the namespaces which can't be listed.
+	forbidden map[string]bool
+}
+
+type sharedWatch struct {
+	informer cache.SharedIndexInformer
+	stop     context.CancelFunc
+	users    int
+
+	mu       sync.Mutex
+	watchErr error
+}
+
+func newSharedWatches() *sharedWatches {
+	return &sharedWatches{
+		streams:   map[string]*sharedWatch{},
+		forbidden: map[string]bool{},
+	}
+}
+
+// sharedWatchesOf returns the shared watches of the provider, nil when meta
+// doesn't have any.
+func sharedWatchesOf(meta interface{}) *sharedWatches {
+	if m, ok := meta.(providerMetadata); ok {
+		return m.watches
+	}
+	return nil
+}
+
+// acquire returns the watch of key, started with lw unless it is running
+// already, or nil when the namespace can't be listed. It must be released once
+// the wait is over.
+func (s *sharedWatches) acquire(ctx context.Context, key string, lw cache.ListerWatcher, objType runtime.Object) *sharedWatch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.forbidden[key] {
+		return nil
+	}
+	if w, ok := s.streams[key]; ok {
+		w.users++
+		return w
+	}
+
+	// The watch outlives the wait starting it.
+	ctx, stop := context.WithCancel(context.WithoutCancel(ctx))
+	w := &sharedWatch{
+		informer: cache.NewSharedIndexInformer(lw, objType, 0, cache.Indexers{}),
+		stop:     stop,
+		users:    1,
+	}
+	_ = w.informer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+		w.mu.Lock()
+		w.watchErr = err
+		w.mu.Unlock()
+		cache.DefaultWatchErrorHandler(r, err)
+	})
+	go w.informer.Run(ctx.Done())
+	s.streams[key] = w
+	return w
+}
+
+func (s *sharedWatches) release(key string, w *sharedWatch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if w.forbidden() {
+		s.forbidden[key] = true
+	}
+	w.users--
+	if w.users == 0 {
+		w.stop()
+		delete(s.streams, key)
+	}
+}
+
+// forbidden reports whether the watch isn't allowed to list the namespace.
+func (w *sharedWatch) forbidden() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return errors.IsForbidden(w.watchErr)
+}
+
+// watchObjectUntil is watchUntil for the object name of the collection of
+// client, using the watch of the namespace shared with the other waits when
+// watches is set. It falls back to watching the object on its own when the
+// namespace can't be listed.
+func watchObjectUntil[L runtime.Object](ctx context.Context, watches *sharedWatches, timeout time.Duration, subject string, client objectListWatcher[L], namespace, name string, objType runtime.Object, check func(watch.Event) *retry.RetryError) error {
+	if watches == nil {
+		return watchUntil(ctx, timeout, subject, singleObjectListWatch(ctx, client, name), objType, check)
+	}
+
+	key := fmt.Sprintf("%T/%s", objType, namespace)
+	w := watches.acquire(ctx, key, namespaceListWatch(ctx, client), objType)
+	if w == nil {
+		return watchUntil(ctx, timeout, subject, singleObjectListWatch(ctx, client, name), objType, check)
+	}
+	err := watchSharedObjectUntil(ctx, w, timeout, subject, namespace, name, check)
+	watches.release(key, w)
+	if err == errSharedWatchForbidden {
+		tflog.Debug(ctx, fmt.Sprintf("Not allowed to watch %s of namespace %q, watching %q on its own", key, namespace, name))
+		return watchUntil(ctx, timeout, subject, singleObjectListWatch(ctx, client, name), objType, check)
+	}
+	return err
+}
+
+var errSharedWatchForbidden = fmt.Errorf("the shared watch isn't allowed to list the namespace")
+
+func watchSharedObjectUntil(ctx context.Context, w *sharedWatch, timeout time.Duration, subject, namespace, name string, check func(watch.Event) *retry.RetryError) error {
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(ctx, timeout)
+	defer cancel()
+
+	wc := newWatchCheck(ctx, subject, check)
+	defer wc.progress.Stop()
+
+	events := make(chan watch.Event)
+	send := func(eventType watch.EventType, obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		o, ok := obj.(runtime.Object)
+		if !ok {
+			return
+		}
+		m, err := apimeta.Accessor(o)
+		if err != nil || m.GetName() != name || m.GetNamespace() != namespace {
+			return
+		}
+		select {
+		case events <- watch.Event{Type: eventType, Object: o}:
+		case <-ctx.Done():
+		}
+	}
+	registration, err := w.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { send(watch.Added, obj) },
+		UpdateFunc: func(_, obj interface{}) { send(watch.Modified, obj) },
+		DeleteFunc: func(obj interface{}) { send(watch.Deleted, obj) },
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = w.informer.RemoveEventHandler(registration)
+	}()
+
+	// Wait for the namespace to be listed, to tell whether the object exists.
+	synced := w.informer.HasSynced()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for !synced {
+		select {
+		case <-ctx.Done():
+			return wc.timeoutError(timeout)
+		case <-ticker.C:
+			if w.forbidden() {
+				return errSharedWatchForbidden
+			}
+			synced = w.informer.HasSynced()
+		case event := <-events:
+			if done, err := wc.condition(ctx, event); done || err != nil {
+				return err
+			}
+		}
+	}
+	if _, exists, _ := w.informer.GetStore().GetByKey(cache.NewObjectName(namespace, name).String()); !exists {
+		if done, err := wc.condition(ctx, watch.Event{Type: watch.Deleted}); done || err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return wc.timeoutError(timeout)
+		case event := <-events:
+			if done, err := wc.condition(ctx, event); done || err != nil {
+				return err
+			}
+		}
+	}
+}
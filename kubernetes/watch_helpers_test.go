@@ -0,0 +1,238 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+func testWatchListWatch(w *watch.FakeWatcher, items ...corev1.ConfigMap) cache.ListerWatcher {
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return &corev1.ConfigMapList{
+				ListMeta: metav1.ListMeta{ResourceVersion: "1"},
+				Items:    items,
+			}, nil
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return w, nil
+		},
+	}
+}
+
+func testWatchConfigMap(phase string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", ResourceVersion: "2"},
+		Data:       map[string]string{"phase": phase},
+	}
+}
+
+func testWatchCheck(event watch.Event) *retry.RetryError {
+	cm, ok := event.Object.(*corev1.ConfigMap)
+	if event.Type == watch.Deleted || !ok {
+		return retry.NonRetryableError(fmt.Errorf("deleted"))
+	}
+	switch cm.Data["phase"] {
+	case "Done":
+		return nil
+	case "Failed":
+		return retry.NonRetryableError(fmt.Errorf("failed"))
+	}
+	return retry.RetryableError(fmt.Errorf("phase is %s", cm.Data["phase"]))
+}
+
+func TestWatchUntil(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("change", func(t *testing.T) {
+		w := watch.NewFake()
+		lw := testWatchListWatch(w, *testWatchConfigMap("Pending"))
+		go w.Modify(testWatchConfigMap("Done"))
+
+		err := watchUntil(ctx, 10*time.Second, "test", lw, &corev1.ConfigMap{}, testWatchCheck)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		w := watch.NewFake()
+		lw := testWatchListWatch(w, *testWatchConfigMap("Pending"))
+		go w.Modify(testWatchConfigMap("Failed"))
+
+		err := watchUntil(ctx, 10*time.Second, "test", lw, &corev1.ConfigMap{}, testWatchCheck)
+		if err == nil || err.Error() != "failed" {
+			t.Fatalf("expected the non-retryable error, got %v", err)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		lw := testWatchListWatch(watch.NewFake())
+
+		err := watchUntil(ctx, 10*time.Second, "test", lw, &corev1.ConfigMap{}, testWatchCheck)
+		if err == nil || err.Error() != "deleted" {
+			t.Fatalf("expected the object to be reported as deleted, got %v", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		lw := testWatchListWatch(watch.NewFake(), *testWatchConfigMap("Pending"))
+
+		err := watchUntil(ctx, 100*time.Millisecond, "test", lw, &corev1.ConfigMap{}, testWatchCheck)
+		if err == nil || !strings.Contains(err.Error(), "phase is Pending") {
+			t.Fatalf("expected a timeout with the last state, got %v", err)
+		}
+	})
+}
+
+func TestWatchObjectUntilShared(t *testing.T) {
+	ctx := context.Background()
+	pending := testWatchConfigMap("Pending")
+	done := testWatchConfigMap("Done")
+	done.Name = "done"
+	conn := fake.NewSimpleClientset(pending, done)
+	watches := newSharedWatches()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		ticker := time.NewTicker(50 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				_, _ = conn.CoreV1().ConfigMaps("default").Update(ctx, testWatchConfigMap("Done"), metav1.UpdateOptions{})
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i, name := range []string{"test", "done", "missing"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = watchObjectUntil(ctx, watches, 10*time.Second, name, conn.CoreV1().ConfigMaps("default"), "default", name, &corev1.ConfigMap{}, testWatchCheck)
+		}()
+	}
+	wg.Wait()
+
+	if errs[0] != nil || errs[1] != nil {
+		t.Fatalf("expected the waits to succeed, got %v", errs)
+	}
+	if errs[2] == nil || errs[2].Error() != "deleted" {
+		t.Fatalf("expected the missing object to be reported as deleted, got %v", errs[2])
+	}
+	lists := 0
+	for _, a := range conn.Actions() {
+		if a.GetVerb() == "list" {
+			lists++
+		}
+	}
+	if lists != 1 {
+		t.Fatalf("expected the waits to share a single list, got %d", lists)
+	}
+	if len(watches.streams) != 0 {
+		t.Fatalf("expected the shared watch to be stopped, got %d running", len(watches.streams))
+	}
+}
+
+func TestWatchObjectUntilForbidden(t *testing.T) {
+	ctx := context.Background()
+	conn := fake.NewSimpleClientset(testWatchConfigMap("Done"))
+	conn.PrependReactor("list", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.(k8stesting.ListAction).GetListRestrictions().Fields.Empty() {
+			return true, nil, errors.NewForbidden(corev1.Resource("configmaps"), "", fmt.Errorf("namespace can't be listed"))
+		}
+		return false, nil, nil
+	})
+
+	err := watchObjectUntil(ctx, newSharedWatches(), 10*time.Second, "test", conn.CoreV1().ConfigMaps("default"), "default", "test", &corev1.ConfigMap{}, testWatchCheck)
+	if err != nil {
+		t.Fatalf("expected the wait to fall back to watching the object, got %v", err)
+	}
+}
+
+func TestWatchObjectUntilWatchFailing(t *testing.T) {
+	ctx := context.Background()
+	interval := watchPollInterval
+	watchPollInterval = 50 * time.Millisecond
+	defer func() { watchPollInterval = interval }()
+
+	failingWatch := func(objects ...runtime.Object) *fake.Clientset {
+		conn := fake.NewSimpleClientset(objects...)
+		conn.PrependWatchReactor("configmaps", func(action k8stesting.Action) (bool, watch.Interface, error) {
+			return true, nil, fmt.Errorf("watch isn't supported by the proxy")
+		})
+		return conn
+	}
+
+	t.Run("change", func(t *testing.T) {
+		conn := failingWatch(testWatchConfigMap("Pending"))
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			done := testWatchConfigMap("Done")
+			done.ResourceVersion = "3"
+			_, _ = conn.CoreV1().ConfigMaps("default").Update(ctx, done, metav1.UpdateOptions{})
+		}()
+
+		start := time.Now()
+		err := watchObjectUntil(ctx, nil, 10*time.Second, "test", conn.CoreV1().ConfigMaps("default"), "default", "test", &corev1.ConfigMap{}, testWatchCheck)
+		if err != nil {
+			t.Fatalf("expected the wait to fall back to polling the object, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Fatalf("expected the change to be polled, it took %s", elapsed)
+		}
+	})
+
+	t.Run("shared", func(t *testing.T) {
+		conn := failingWatch(testWatchConfigMap("Pending"))
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			done := testWatchConfigMap("Done")
+			done.ResourceVersion = "3"
+			_, _ = conn.CoreV1().ConfigMaps("default").Update(ctx, done, metav1.UpdateOptions{})
+		}()
+
+		start := time.Now()
+		err := watchObjectUntil(ctx, newSharedWatches(), 10*time.Second, "test", conn.CoreV1().ConfigMaps("default"), "default", "test", &corev1.ConfigMap{}, testWatchCheck)
+		if err != nil {
+			t.Fatalf("expected the shared wait to fall back to polling the namespace, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Fatalf("expected the change to be polled, it took %s", elapsed)
+		}
+	})
+
+	t.Run("deletion", func(t *testing.T) {
+		conn := failingWatch(testWatchConfigMap("Pending"))
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			_ = conn.CoreV1().ConfigMaps("default").Delete(ctx, "test", metav1.DeleteOptions{})
+		}()
+
+		err := watchObjectUntil(ctx, nil, 10*time.Second, "test", conn.CoreV1().ConfigMaps("default"), "default", "test", &corev1.ConfigMap{}, testWatchCheck)
+		if err == nil || err.Error() != "deleted" {
+			t.Fatalf("expected the deletion to be polled, got %v", err)
+		}
+	})
+}
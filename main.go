@@ -8,7 +8,6 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
-	"log"
 	"os"
 	"time"
 
@@ -16,13 +15,18 @@ import (
 	"github.com/hashicorp/terraform-exec/tfexec"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	tf5server "github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apilog"
 	"github.com/hashicorp/terraform-provider-kubernetes/internal/mux"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/telemetry"
 )
 
 const (
 	providerName = "registry.terraform.io/hashicorp/kubernetes"
 
 	Version = "dev"
+
+	telemetryShutdownTimeout = time.Second
 )
 
 // Generate docs for website
@@ -32,11 +36,16 @@ func main() {
 	debugFlag := flag.Bool("debug", false, "Start provider in stand-alone debug mode.")
 	flag.Parse()
 
-	ctx := context.Background()
+	ctx := apilog.Context()
+
+	shutdownTelemetry, err := telemetry.Start(ctx, Version)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Failed to set up OpenTelemetry: %s", err))
+	}
 
 	muxer, err := mux.MuxServer(ctx, Version)
 	if err != nil {
-		log.Println(err.Error())
+		tflog.Error(ctx, err.Error())
 		os.Exit(1)
 	}
 
@@ -55,6 +64,13 @@ func main() {
 	}
 
 	tf5server.Serve(providerName, func() tfprotov5.ProviderServer { return muxer }, opts...)
+
+	// Terraform kills the provider shortly after asking it to stop.
+	ctx, cancel := context.WithTimeout(ctx, telemetryShutdownTimeout)
+	defer cancel()
+	if err := shutdownTelemetry(ctx); err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Failed to flush OpenTelemetry data: %s", err))
+	}
 }
 
 // convertReattachConfig converts plugin.ReattachConfig to tfexec.ReattachConfig
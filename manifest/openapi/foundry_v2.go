@@ -10,6 +10,7 @@ import (
 	"sync"
 
 	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -34,15 +35,22 @@ func NewFoundryFromSpecV2(spec []byte) (Foundry, error) {
 		return nil, errors.New("spec has no type information")
 	}
 
+	return newFoundryFromDefinitions(d)
+}
+
+// newFoundryFromDefinitions creates a new tftypes.Type foundry from the schema
+// definitions of an OpenAPI document, indexed by their
+// "x-kubernetes-group-version-kind" extension.
+func newFoundryFromDefinitions(d map[string]*openapi3.SchemaRef) (Foundry, error) {
 	f := foapiv2{
-		swagger:        &swg,
+		definitions:    d,
 		typeCache:      sync.Map{},
 		gkvIndex:       sync.Map{}, //reverse lookup index from GVK to OpenAPI definition IDs
 		recursionDepth: 50,         // arbitrarily large number - a type this deep will likely kill Terraform anyway
 		gate:           sync.Mutex{},
 	}
 
-	err = f.buildGvkIndex()
+	err := f.buildGvkIndex()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build GVK index when creating new foundry: %s", err)
 	}
@@ -56,7 +64,7 @@ type Foundry interface {
 }
 
 type foapiv2 struct {
-	swagger        *openapi2.T
+	definitions    map[string]*openapi3.SchemaRef
 	typeCache      sync.Map
 	gkvIndex       sync.Map
 	recursionDepth uint64 // a last resort circuit-breaker for run-away recursion - hitting this will make for a bad day
@@ -90,7 +98,7 @@ func (f *foapiv2) GetTypeByGVK(gvk schema.GroupVersionKind) (tftypes.Type, map[s
 }
 
 func (f *foapiv2) getTypeByID(id string, h map[string]string, ap tftypes.AttributePath) (tftypes.Type, error) {
-	swd, ok := f.swagger.Definitions[id]
+	swd, ok := f.definitions[id]
 
 	if !ok {
 		return nil, errors.New("invalid type identifier")
@@ -100,19 +108,19 @@ func (f *foapiv2) getTypeByID(id string, h map[string]string, ap tftypes.Attribu
 		return nil, errors.New("invalid type reference (nil)")
 	}
 
-	sch, err := resolveSchemaRef(swd, f.swagger.Definitions)
+	sch, err := resolveSchemaRef(swd, f.definitions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve schema: %s", err)
 	}
 
-	return getTypeFromSchema(sch, f.recursionDepth, &(f.typeCache), f.swagger.Definitions, ap, h)
+	return getTypeFromSchema(sch, f.recursionDepth, &(f.typeCache), f.definitions, ap, h)
 }
 
 // buildGvkIndex builds the reverse lookup index that associates each GVK
-// to its corresponding string key in the swagger.Definitions map
+// to its corresponding string key in the definitions map
 func (f *foapiv2) buildGvkIndex() error {
-	for did, dRef := range f.swagger.Definitions {
-		def, err := resolveSchemaRef(dRef, f.swagger.Definitions)
+	for did, dRef := range f.definitions {
+		def, err := resolveSchemaRef(dRef, f.definitions)
 		if err != nil {
 			return err
 		}
@@ -4,6 +4,8 @@
 package openapi
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -21,6 +23,32 @@ func NewFoundryFromSpecV3(spec []byte) (Foundry, error) {
 	return &foapiv3{doc: oapi3}, nil
 }
 
+// NewFoundryFromGroupVersionSpecV3 creates a new tftypes.Type foundry from the
+// OpenAPI v3 document of a single group version, as served by the API server
+// at /openapi/v3/apis/<group>/<version>. Unlike the OpenAPI v2 document of the
+// cluster, it only describes the types of that group version and the types
+// they reference, e.g. ObjectMeta.
+func NewFoundryFromGroupVersionSpecV3(spec []byte) (Foundry, error) {
+	if len(spec) < 6 { // unlikely to be valid json
+		return nil, errors.New("empty spec")
+	}
+
+	// The document is decoded without resolving its references, they are
+	// resolved against its schemas when generating types, as for OpenAPI v2.
+	var doc openapi3.T
+	err := json.Unmarshal(spec, &doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %s", err)
+	}
+
+	d := doc.Components.Schemas
+	if len(d) == 0 {
+		return nil, errors.New("spec has no type information")
+	}
+
+	return newFoundryFromDefinitions(d)
+}
+
 func SchemaToSpec(key string, crschema map[string]interface{}) map[string]interface{} {
 	schema := make(map[string]interface{})
 	for k, v := range crschema {
@@ -5,7 +5,15 @@ package openapi
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestNewFoundryFromSpecV3(t *testing.T) {
@@ -66,3 +74,86 @@ func TestNewFoundryFromSpecV3(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestNewFoundryFromGroupVersionSpecV3(t *testing.T) {
+	input, err := os.ReadFile(filepath.Join("testdata", "k8s-swagger.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var swg openapi2.T
+	if err := swg.UnmarshalJSON(input); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := openapi2conv.ToV3(&swg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The API server wraps the references of the properties and items in allOf
+	// in its OpenAPI v3 documents.
+	for _, s := range doc.Components.Schemas {
+		wrapRefsInAllOf(s.Value)
+	}
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v3, err := NewFoundryFromGroupVersionSpecV3(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2, err := NewFoundryFromSpecV2(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	samples := []schema.GroupVersionKind{
+		ObjectMetaGVK,
+		{Group: "", Version: "v1", Kind: "Namespace"},
+		{Group: "", Version: "v1", Kind: "Service"},
+		{Group: "apps", Version: "v1", Kind: "Deployment"},
+		{Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition"},
+	}
+	for _, gvk := range samples {
+		t.Run(gvk.String(), func(t *testing.T) {
+			want, wantHints, err := v2.GetTypeByGVK(gvk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, gotHints, err := v3.GetTypeByGVK(gvk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(want) {
+				t.Fatalf("\nRETURNED type: %#v\nEXPECTED type: %#v", got, want)
+			}
+			if !reflect.DeepEqual(gotHints, wantHints) {
+				t.Fatalf("\nRETURNED hints: %#v\nEXPECTED hints: %#v", gotHints, wantHints)
+			}
+		})
+	}
+}
+
+func wrapRefsInAllOf(s *openapi3.Schema) {
+	if s == nil {
+		return
+	}
+	wrap := func(ref *openapi3.SchemaRef) *openapi3.SchemaRef {
+		if ref == nil {
+			return nil
+		}
+		if ref.Ref == "" {
+			wrapRefsInAllOf(ref.Value)
+			return ref
+		}
+		return &openapi3.SchemaRef{Value: &openapi3.Schema{
+			AllOf:   openapi3.SchemaRefs{&openapi3.SchemaRef{Ref: ref.Ref}},
+			Default: map[string]interface{}{},
+		}}
+	}
+	for k, p := range s.Properties {
+		s.Properties[k] = wrap(p)
+	}
+	s.Items = wrap(s.Items)
+	s.AdditionalProperties = wrap(s.AdditionalProperties)
+}
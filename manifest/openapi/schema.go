@@ -18,6 +18,11 @@ import (
 
 func resolveSchemaRef(ref *openapi3.SchemaRef, defs map[string]*openapi3.SchemaRef) (*openapi3.Schema, error) {
 	if ref.Value != nil {
+		// OpenAPI v3 documents wrap the references to other schemas in allOf
+		// when they carry a default value or a description.
+		if v := ref.Value; v.Type == "" && len(v.Properties) == 0 && len(v.AllOf) == 1 && v.AllOf[0].Ref != "" {
+			return resolveSchemaRef(v.AllOf[0], defs)
+		}
 		return ref.Value, nil
 	}
 
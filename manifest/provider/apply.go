@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apiwarning"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/morph"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/payload"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -28,6 +29,11 @@ var defaultDeleteTimeout = "10m"
 func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
 	resp := &tfprotov5.ApplyResourceChangeResponse{}
 
+	ctx, warnings := apiwarning.WithCollector(ctx)
+	defer func() {
+		resp.Diagnostics = append(resp.Diagnostics, apiWarningDiagnostics(warnings)...)
+	}()
+
 	execDiag := s.canExecute()
 	if len(execDiag) > 0 {
 		resp.Diagnostics = append(resp.Diagnostics, execDiag...)
@@ -313,10 +319,15 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 			rs = c.Resource(gvr)
 		}
 
-		// Check the resource does not exist if this is a create operation
+		// Check the resource does not exist if this is a create operation,
+		// unless it is to be adopted
+		adopting := false
 		if applyPriorState.IsNull() {
 			_, err := rs.Get(ctx, rname, metav1.GetOptions{})
-			if err == nil {
+			if err == nil && s.getAdoptExisting(plannedStateVal) {
+				s.logger.Debug("[ApplyResourceChange][Apply]", "adopting existing resource", rnn)
+				adopting = true
+			} else if err == nil {
 				resp.Diagnostics = append(resp.Diagnostics,
 					&tfprotov5.Diagnostic{
 						Severity: tfprotov5.DiagnosticSeverityError,
@@ -357,6 +368,45 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 			return resp, nil
 		}
 
+		migrateCSA, err := s.getMigrateClientSideApply(plannedStateVal)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Could not extract field_manager config",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+		transferFrom, err := s.getTransferOwnershipFrom(plannedStateVal)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Could not extract field_manager config",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+		if adopting {
+			// Take over the fields of the manifest from their current field
+			// managers, as they would otherwise conflict with the apply.
+			migrateCSA = true
+			forceConflicts = true
+		}
+		if migrateCSA && (!applyPriorState.IsNull() || adopting) {
+			migrated, err := migrateClientSideApply(ctx, rs, rname, fieldManagerName)
+			if err != nil {
+				resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  fmt.Sprintf("Failed to migrate the fields managed by kubectl apply for %q", rnn),
+					Detail:   err.Error(),
+				})
+				return resp, nil
+			}
+			if migrated {
+				s.logger.Debug("[ApplyResourceChange][Apply]", "migrated client-side apply field managers", rnn)
+			}
+		}
+
 		// figure out the timeout deadline
 		timeouts := s.getTimeouts(plannedStateVal)
 		var timeout time.Duration
@@ -371,11 +421,12 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 
 		// Call the Kubernetes API to create the new resource
 		s.logger.Trace("[ApplyResourceChange][API Payload]: %s", jsonManifest)
-		result, err := rs.Patch(ctxDeadline, rname, types.ApplyPatchType, jsonManifest,
+		result, err := applyTransferringOwnership(ctxDeadline, rs, rname, jsonManifest,
 			metav1.PatchOptions{
 				FieldManager: fieldManagerName,
 				Force:        &forceConflicts,
 			},
+			transferFrom,
 		)
 		if err != nil {
 			s.logger.Error("[ApplyResourceChange][Apply]", "API error", dump(err), "API response", dump(result))
@@ -392,7 +443,7 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 					},
 				)
 			} else if status := apierrors.APIStatus(nil); errors.As(err, &status) {
-				resp.Diagnostics = append(resp.Diagnostics, APIStatusErrorToDiagnostics(status.Status())...)
+				resp.Diagnostics = append(resp.Diagnostics, APIStatusErrorToDiagnostics(status.Status(), gvk, s.getOAPIDocument(gvk.GroupVersion()))...)
 			} else {
 				resp.Diagnostics = append(resp.Diagnostics,
 					&tfprotov5.Diagnostic{
@@ -422,8 +473,21 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 			s.logger.Trace("[ApplyResourceChange][Wait] Using waiter config from deprecated `wait_for` attribute")
 			waitConfig = wf
 		}
-		if !waitConfig.IsNull() {
+		waited := !waitConfig.IsNull()
+		if isCustomResourceDefinition(gvk) {
+			// Custom resources of the kind can only be applied once the
+			// definition is established, wait for it so that they can be
+			// sequenced after it in the same apply.
+			w := &CustomResourceDefinitionWaiter{rs, rname, s.logger}
+			err = w.Wait(ctxDeadline)
+			if err == nil {
+				err = s.waitForCompletion(ctxDeadline, waitConfig, rs, rname, wt, th)
+			}
+			waited = true
+		} else if waited {
 			err = s.waitForCompletion(ctxDeadline, waitConfig, rs, rname, wt, th)
+		}
+		if waited {
 			if err != nil {
 				if reason, ok := err.(WaiterError); ok {
 					resp.Diagnostics = append(resp.Diagnostics,
@@ -456,7 +520,7 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 			result = r
 		}
 
-		newResObject, err := payload.ToTFValue(RemoveServerSideFields(result.Object), tsch, th, tftypes.NewAttributePath())
+		newResObject, err := payload.ToTFValue(normalizeSecretStringData(RemoveServerSideFields(result.Object), rqObj), tsch, th, tftypes.NewAttributePath())
 		if err != nil {
 			resp.Diagnostics = append(resp.Diagnostics,
 				&tfprotov5.Diagnostic{
@@ -593,6 +657,16 @@ func (s *RawProviderServer) ApplyResourceChange(ctx context.Context, req *tfprot
 	return resp, nil
 }
 
+// getAdoptExisting returns whether an object which already exists is to be adopted
+// when the resource is created.
+func (s *RawProviderServer) getAdoptExisting(v map[string]tftypes.Value) bool {
+	adopt := false
+	if a, ok := v["adopt_existing"]; ok && !a.IsNull() && a.IsKnown() {
+		a.As(&adopt)
+	}
+	return adopt
+}
+
 func (s *RawProviderServer) getTimeouts(v map[string]tftypes.Value) map[string]string {
 	timeouts := map[string]string{
 		"create": defaultCreateTimeout,
@@ -0,0 +1,118 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+// lastAppliedConfigAnnotation is the annotation in which `kubectl apply` keeps
+// the configuration it last applied, when not using server-side apply.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// maxMigrationRetries is the number of times the migration of the managed fields
+// is attempted when the object changes in the meantime.
+const maxMigrationRetries = 5
+
+var lastAppliedAnnotationFieldPath = fieldpath.NewSet(
+	fieldpath.MakePathOrDie("metadata", "annotations", lastAppliedConfigAnnotation),
+)
+
+// removeLastAppliedConfig removes the last-applied-configuration annotation from
+// an object read from the API. It holds a copy of the whole object, which
+// would otherwise show up as a diff of the annotations.
+func removeLastAppliedConfig(meta map[string]interface{}) {
+	annotations, ok := meta["annotations"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	delete(annotations, lastAppliedConfigAnnotation)
+	if len(annotations) == 0 {
+		delete(meta, "annotations")
+	}
+}
+
+// getMigrateClientSideApply returns whether the field_manager block asks for the
+// fields managed by `kubectl apply` to be migrated to the field manager.
+func (s *RawProviderServer) getMigrateClientSideApply(v map[string]tftypes.Value) (bool, error) {
+	migrate := false
+	if v["field_manager"].IsNull() || !v["field_manager"].IsKnown() {
+		return migrate, nil
+	}
+	var fieldManagerBlock []tftypes.Value
+	if err := v["field_manager"].As(&fieldManagerBlock); err != nil {
+		return false, err
+	}
+	if len(fieldManagerBlock) == 0 {
+		return migrate, nil
+	}
+	var fieldManagerObj map[string]tftypes.Value
+	if err := fieldManagerBlock[0].As(&fieldManagerObj); err != nil {
+		return false, err
+	}
+	m, ok := fieldManagerObj["migrate_client_side_apply"]
+	if !ok || m.IsNull() || !m.IsKnown() {
+		return migrate, nil
+	}
+	err := m.As(&migrate)
+	return migrate, err
+}
+
+// migrateClientSideApply transfers the ownership of the fields managed by
+// client-side `kubectl apply` to the given field manager, the same way
+// `kubectl apply --server-side` does. The field managers of client-side apply
+// are the ones owning the last-applied-configuration annotation. As the
+// annotation is then owned by the field manager and missing from the manifest,
+// the apply which follows removes it. It reports whether the managed fields
+// were changed.
+func migrateClientSideApply(ctx context.Context, rs dynamic.ResourceInterface, name string, fieldManager string) (bool, error) {
+	var lastErr error
+	for i := 0; i < maxMigrationRetries; i++ {
+		obj, err := rs.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		managers := sets.New[string]()
+		for _, e := range csaupgrade.FindFieldsOwners(obj.GetManagedFields(), metav1.ManagedFieldsOperationUpdate, lastAppliedAnnotationFieldPath) {
+			managers.Insert(e.Manager)
+		}
+		if managers.Len() == 0 {
+			return false, nil
+		}
+
+		patch, err := csaupgrade.UpgradeManagedFieldsPatch(obj, managers, fieldManager)
+		if err != nil {
+			return false, err
+		}
+		if patch == nil {
+			// The object is already migrated.
+			return false, nil
+		}
+
+		// The patch tests the resource version, it fails with a conflict
+		// when the object changed since it was read.
+		_, err = rs.Patch(ctx, name, types.JSONPatchType, patch, metav1.PatchOptions{})
+		if err == nil {
+			return true, nil
+		}
+		if !apierrors.IsConflict(err) && !apierrors.IsInvalid(err) {
+			return false, err
+		}
+		lastErr = err
+	}
+	return false, lastErr
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func TestRemoveLastAppliedConfig(t *testing.T) {
+	meta := map[string]interface{}{
+		"annotations": map[string]interface{}{
+			lastAppliedConfigAnnotation: `{"apiVersion":"v1","kind":"ConfigMap"}`,
+		},
+	}
+	removeLastAppliedConfig(meta)
+	if _, ok := meta["annotations"]; ok {
+		t.Fatalf("expected annotations to be removed, got %v", meta)
+	}
+
+	meta = map[string]interface{}{
+		"annotations": map[string]interface{}{
+			lastAppliedConfigAnnotation: "{}",
+			"foo":                       "bar",
+		},
+	}
+	removeLastAppliedConfig(meta)
+	annotations := meta["annotations"].(map[string]interface{})
+	if len(annotations) != 1 || annotations["foo"] != "bar" {
+		t.Fatalf("expected only the foo annotation to be kept, got %v", annotations)
+	}
+}
+
+func TestMigrateClientSideApply(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetNamespace("default")
+	obj.SetName("test")
+	obj.SetResourceVersion("1")
+	obj.SetAnnotations(map[string]string{lastAppliedConfigAnnotation: "{}"})
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{
+			Manager:    "kubectl-client-side-apply",
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			APIVersion: "v1",
+			FieldsType: "FieldsV1",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:foo":{}},"f:metadata":{"f:annotations":{".":{},"f:` +
+				lastAppliedConfigAnnotation + `":{}}}}`)},
+		},
+	})
+	client := fake.NewSimpleDynamicClient(runtime.NewScheme(), obj)
+	rs := client.Resource(gvr).Namespace("default")
+
+	migrated, err := migrateClientSideApply(context.Background(), rs, "test", "Terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !migrated {
+		t.Fatal("expected the managed fields to be migrated")
+	}
+	out, err := rs.Get(context.Background(), "test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	managedFields := out.GetManagedFields()
+	if len(managedFields) != 1 || managedFields[0].Manager != "Terraform" || managedFields[0].Operation != metav1.ManagedFieldsOperationApply {
+		t.Fatalf("expected the fields to be owned by Terraform, got %#v", managedFields)
+	}
+
+	migrated, err = migrateClientSideApply(context.Background(), rs, "test", "Terraform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migrated {
+		t.Fatal("expected the object to be migrated already")
+	}
+}
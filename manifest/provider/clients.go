@@ -6,19 +6,19 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apierror"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/openapi"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
-	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
+	clientopenapi "k8s.io/client-go/openapi"
 	"k8s.io/client-go/rest"
-	"k8s.io/client-go/restmapper"
 
 	// this is how client-go expects auth plugins to be loaded
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -71,14 +71,7 @@ func (ps *RawProviderServer) getRestMapper() (meta.RESTMapper, error) {
 		return nil, err
 	}
 
-	// agr, err := restmapper.GetAPIGroupResources(dc)
-	// if err != nil {
-	// 	return nil, err
-	// }
-	// mapper := restmapper.NewDeferredDiscoveryRESTMapper(agr)
-
-	cache := memory.NewMemCacheClient(dc)
-	ps.restMapper = restmapper.NewDeferredDiscoveryRESTMapper(cache)
+	ps.restMapper = newGroupRESTMapper(dc)
 	return ps.restMapper, nil
 }
 
@@ -98,6 +91,85 @@ func (ps *RawProviderServer) getRestClient() (rest.Interface, error) {
 	return restClient, nil
 }
 
+// getOAPIFoundry returns an interface to request tftype types of the group
+// version gv. The types are taken from the OpenAPI v3 document of the group
+// version when the API server publishes one, which is much smaller than the
+// OpenAPI v2 spec of the whole cluster used otherwise.
+func (ps *RawProviderServer) getOAPIFoundry(gv schema.GroupVersion) (openapi.Foundry, error) {
+	ps.oapiMu.Lock()
+	defer ps.oapiMu.Unlock()
+
+	if f, ok := ps.oapiV3Foundries[gv]; ok {
+		return f, nil
+	}
+
+	gvDoc, ok, err := ps.getOAPIv3GroupVersion(gv)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return ps.getOAPIv2Foundry()
+	}
+	rs, err := gvDoc.Schema(runtime.ContentTypeJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed get OpenAPI spec of %s: %s", gv, err)
+	}
+	f, err := openapi.NewFoundryFromGroupVersionSpecV3(rs)
+	if err != nil {
+		return nil, fmt.Errorf("failed construct OpenAPI foundry for %s: %s", gv, err)
+	}
+	ps.oapiV3Foundries[gv] = f
+	return f, nil
+}
+
+// getOAPIv3GroupVersion returns the OpenAPI v3 document of the group version
+// gv, or false when the API server doesn't publish it. ps.oapiMu must be held.
+func (ps *RawProviderServer) getOAPIv3GroupVersion(gv schema.GroupVersion) (clientopenapi.GroupVersion, bool, error) {
+	if ps.oapiV3Paths == nil {
+		dc, err := ps.getDiscoveryClient()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed get OpenAPI spec: %s", err)
+		}
+		paths, err := dc.OpenAPIV3().Paths()
+		if err != nil {
+			ps.logger.Debug("[getOAPIFoundry] OpenAPI v3 isn't available, falling back to OpenAPI v2", "error", err)
+			paths = map[string]clientopenapi.GroupVersion{}
+		}
+		ps.oapiV3Paths = paths
+		ps.oapiV3Foundries = map[schema.GroupVersion]openapi.Foundry{}
+	}
+
+	path := "apis/" + gv.Group + "/" + gv.Version
+	if gv.Group == "" {
+		path = "api/" + gv.Version
+	}
+	gvDoc, ok := ps.oapiV3Paths[path]
+	return gvDoc, ok, nil
+}
+
+// getOAPIDocument returns the OpenAPI v3 document of the group version gv to
+// describe the fields of its kinds, or nil when it isn't available.
+func (ps *RawProviderServer) getOAPIDocument(gv schema.GroupVersion) *apierror.OpenAPIDocument {
+	ps.oapiMu.Lock()
+	defer ps.oapiMu.Unlock()
+
+	gvDoc, ok, err := ps.getOAPIv3GroupVersion(gv)
+	if err != nil || !ok {
+		return nil
+	}
+	rs, err := gvDoc.Schema(runtime.ContentTypeJSON)
+	if err != nil {
+		ps.logger.Debug("[getOAPIDocument] failed get OpenAPI spec", "group version", gv.String(), "error", err)
+		return nil
+	}
+	doc, err := apierror.ParseOpenAPIDocument(rs)
+	if err != nil {
+		ps.logger.Debug("[getOAPIDocument] failed parse OpenAPI spec", "group version", gv.String(), "error", err)
+		return nil
+	}
+	return doc
+}
+
 // getOAPIv2Foundry returns an interface to request tftype types from an OpenAPIv2 spec
 func (ps *RawProviderServer) getOAPIv2Foundry() (openapi.Foundry, error) {
 	if ps.OAPIFoundry != nil {
@@ -125,26 +197,6 @@ func (ps *RawProviderServer) getOAPIv2Foundry() (openapi.Foundry, error) {
 	return oapif, nil
 }
 
-func loggingTransport(rt http.RoundTripper) http.RoundTripper {
-	return &loggingRountTripper{
-		ot: rt,
-		lt: logging.NewSubsystemLoggingHTTPTransport("Kubernetes API", rt),
-	}
-}
-
-type loggingRountTripper struct {
-	ot http.RoundTripper
-	lt http.RoundTripper
-}
-
-func (t *loggingRountTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	if req.URL.Path == "/openapi/v2" {
-		// don't trace-log the OpenAPI spec document, it's really big
-		return t.ot.RoundTrip(req)
-	}
-	return t.lt.RoundTrip(req)
-}
-
 func (ps *RawProviderServer) checkValidCredentials(ctx context.Context) (diags []*tfprotov5.Diagnostic) {
 	rc, err := ps.getRestClient()
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -25,6 +26,10 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apilog"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apiwarning"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/telemetry"
 )
 
 const minTFVersion string = "v0.14.8"
@@ -606,8 +611,34 @@ func (s *RawProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov
 		return response, nil
 	}
 
-	if s.logger.IsTrace() {
-		clientConfig.WrapTransport = loggingTransport
+	// Handle 'trace_requests' attribute
+	//
+	var traceRequests bool
+	if !providerConfig["trace_requests"].IsNull() && providerConfig["trace_requests"].IsKnown() {
+		err = providerConfig["trace_requests"].As(&traceRequests)
+		if err != nil {
+			// invalid attribute type - this shouldn't happen, bail out for now
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Provider configuration: failed to assert type of 'trace_requests' value",
+				Detail:   err.Error(),
+			})
+			return response, nil
+		}
+	} else if traceEnv, ok := os.LookupEnv(apilog.TraceEnvVar); ok && traceEnv != "" {
+		tv, err := strconv.ParseBool(traceEnv)
+		if err != nil {
+			response.Diagnostics = append(response.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityInvalid,
+				Summary:  "Invalid provider configuration",
+				Detail:   "Environment variable " + apilog.TraceEnvVar + " contains invalid value: " + err.Error(),
+			})
+			return response, nil
+		}
+		traceRequests = tv
+	}
+	clientConfig.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		return telemetry.NewTransport(apiwarning.NewTransport(apilog.NewTransport(rt, traceRequests)))
 	}
 
 	codec := runtime.NoopEncoder{Decoder: scheme.Codecs.UniversalDecoder()}
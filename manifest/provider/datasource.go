@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apiwarning"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/morph"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/payload"
 
@@ -18,6 +19,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 )
 
 func (s *RawProviderServer) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
@@ -42,6 +44,11 @@ func (s *RawProviderServer) ReadPluralDataSource(ctx context.Context, req *tfpro
 
 	resp := &tfprotov5.ReadDataSourceResponse{}
 
+	ctx, warnings := apiwarning.WithCollector(ctx)
+	defer func() {
+		resp.Diagnostics = append(resp.Diagnostics, apiWarningDiagnostics(warnings)...)
+	}()
+
 	execDiag := s.canExecute()
 	if len(execDiag) > 0 {
 		resp.Diagnostics = append(resp.Diagnostics, execDiag...)
@@ -142,24 +149,27 @@ func (s *RawProviderServer) ReadPluralDataSource(ctx context.Context, req *tfpro
 	var limit big.Float
 	dsConfig["limit"].As(&limit)
 	lim, _ := limit.Int64()
+	var pageSize big.Float
+	dsConfig["page_size"].As(&pageSize)
+	ps, _ := pageSize.Int64()
+	if ps <= 0 {
+		ps = defaultListPageSize
+	}
 	listOptions := metav1.ListOptions{
 		LabelSelector: labelSelector,
 		FieldSelector: fieldSelector,
-		Limit:         lim,
 	}
 
-	var res *unstructured.UnstructuredList
-
+	var lister dynamic.ResourceInterface = rcl
 	if ns {
 		var namespace string
 		dsConfig["namespace"].As(&namespace)
 		if namespace == "" {
 			namespace = "default"
 		}
-		res, err = rcl.Namespace(namespace).List(ctx, listOptions)
-	} else {
-		res, err = rcl.List(ctx, listOptions)
+		lister = rcl.Namespace(namespace)
 	}
+	res, err := listPages(ctx, lister, listOptions, ps, lim)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return resp, nil
@@ -173,8 +183,13 @@ func (s *RawProviderServer) ReadPluralDataSource(ctx context.Context, req *tfpro
 		return resp, nil
 	}
 
+	var retainManagedFields, removeStatus bool
+	dsConfig["retain_managed_fields"].As(&retainManagedFields)
+	dsConfig["remove_status"].As(&removeStatus)
+
 	listObjects := []tftypes.Value{}
 	for _, item := range res.Items {
+		trimDataSourceObject(item.Object, retainManagedFields, removeStatus)
 		nobj, err := payload.ToTFValue(item.Object, objectType, th, tftypes.NewAttributePath())
 		if err != nil {
 			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
@@ -237,6 +252,11 @@ func (s *RawProviderServer) ReadSingularDataSource(ctx context.Context, req *tfp
 
 	resp := &tfprotov5.ReadDataSourceResponse{}
 
+	ctx, warnings := apiwarning.WithCollector(ctx)
+	defer func() {
+		resp.Diagnostics = append(resp.Diagnostics, apiWarningDiagnostics(warnings)...)
+	}()
+
 	execDiag := s.canExecute()
 	if len(execDiag) > 0 {
 		resp.Diagnostics = append(resp.Diagnostics, execDiag...)
@@ -364,6 +384,11 @@ func (s *RawProviderServer) ReadSingularDataSource(ctx context.Context, req *tfp
 		return resp, nil
 	}
 
+	var retainManagedFields, removeStatus bool
+	dsConfig["retain_managed_fields"].As(&retainManagedFields)
+	dsConfig["remove_status"].As(&removeStatus)
+	trimDataSourceObject(res.Object, retainManagedFields, removeStatus)
+
 	nobj, err := payload.ToTFValue(res.Object, objectType, th, tftypes.NewAttributePath())
 	if err != nil {
 		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
@@ -420,3 +445,46 @@ func getGVR(apiVersion, kind string, m meta.RESTMapper) (schema.GroupVersionReso
 	}
 	return mapping.Resource, err
 }
+
+// defaultListPageSize is the number of objects requested per page by the
+// kubernetes_resources data source, the same as kubectl.
+const defaultListPageSize = 500
+
+// listPages lists the objects page by page, pageSize objects at a time, until
+// all of them, or limit of them when limit is positive, are listed.
+func listPages(ctx context.Context, rcl dynamic.ResourceInterface, opts metav1.ListOptions, pageSize, limit int64) (*unstructured.UnstructuredList, error) {
+	res := &unstructured.UnstructuredList{}
+	for {
+		opts.Limit = pageSize
+		if remaining := limit - int64(len(res.Items)); limit > 0 && remaining < pageSize {
+			opts.Limit = remaining
+		}
+		page, err := rcl.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		res.Object = page.Object
+		res.Items = append(res.Items, page.Items...)
+		opts.Continue = page.GetContinue()
+		if limit > 0 && int64(len(res.Items)) >= limit {
+			res.Items = res.Items[:limit]
+			return res, nil
+		}
+		if opts.Continue == "" {
+			return res, nil
+		}
+	}
+}
+
+// trimDataSourceObject removes the managedFields of an object read by a data
+// source unless they are retained, and its status when asked to. Unlike
+// kubernetes_manifest, which always removes both, the data sources keep the
+// status by default, as reading it is a common reason to use them.
+func trimDataSourceObject(in map[string]interface{}, retainManagedFields, removeStatus bool) {
+	if !retainManagedFields {
+		removeManagedFields(in)
+	}
+	if removeStatus {
+		delete(in, "status")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+// pagedLister serves total objects page by page, with the index of the next
+// one as the continue token.
+type pagedLister struct {
+	dynamic.ResourceInterface
+	total  int
+	limits []int64
+}
+
+func (l *pagedLister) List(_ context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	l.limits = append(l.limits, opts.Limit)
+	start, _ := strconv.Atoi(opts.Continue)
+	end := start + int(opts.Limit)
+	if end > l.total {
+		end = l.total
+	}
+	res := &unstructured.UnstructuredList{Object: map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMapList"}}
+	for i := start; i < end; i++ {
+		o := unstructured.Unstructured{}
+		o.SetName(fmt.Sprintf("cm-%d", i))
+		res.Items = append(res.Items, o)
+	}
+	if end < l.total {
+		res.SetContinue(strconv.Itoa(end))
+	}
+	return res, nil
+}
+
+func TestListPages(t *testing.T) {
+	cases := map[string]struct {
+		total    int
+		pageSize int64
+		limit    int64
+		items    int
+		limits   []int64
+	}{
+		"SinglePage":   {3, 10, 0, 3, []int64{10}},
+		"SeveralPages": {25, 10, 0, 25, []int64{10, 10, 10}},
+		"Limited":      {25, 10, 15, 15, []int64{10, 5}},
+		"LimitAbove":   {12, 10, 20, 12, []int64{10, 10}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			l := &pagedLister{total: tc.total}
+			res, err := listPages(context.Background(), l, metav1.ListOptions{}, tc.pageSize, tc.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(res.Items) != tc.items {
+				t.Fatalf("expected %d items, got %d", tc.items, len(res.Items))
+			}
+			if fmt.Sprint(l.limits) != fmt.Sprint(tc.limits) {
+				t.Fatalf("expected the limits %v, got %v", tc.limits, l.limits)
+			}
+		})
+	}
+}
+
+func TestTrimDataSourceObject(t *testing.T) {
+	object := func() map[string]interface{} {
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":          "web",
+				"managedFields": []interface{}{map[string]interface{}{"manager": "kubectl"}},
+			},
+			"spec":   map[string]interface{}{"replicas": int64(2)},
+			"status": map[string]interface{}{"readyReplicas": int64(2)},
+		}
+	}
+	cases := []struct {
+		RetainManagedFields bool
+		RemoveStatus        bool
+		ManagedFields       bool
+		Status              bool
+	}{
+		{false, false, false, true},
+		{true, false, true, true},
+		{false, true, false, false},
+		{true, true, true, false},
+	}
+	for _, tc := range cases {
+		in := object()
+		trimDataSourceObject(in, tc.RetainManagedFields, tc.RemoveStatus)
+		_, managedFields := in["metadata"].(map[string]interface{})["managedFields"]
+		_, status := in["status"]
+		if managedFields != tc.ManagedFields || status != tc.Status {
+			t.Errorf("retain_managed_fields = %t, remove_status = %t: expected managedFields %t and status %t, got %t and %t",
+				tc.RetainManagedFields, tc.RemoveStatus, tc.ManagedFields, tc.Status, managedFields, status)
+		}
+		if in["spec"] == nil {
+			t.Errorf("Expected the spec to be kept")
+		}
+	}
+}
@@ -7,11 +7,16 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apierror"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-// APIStatusErrorToDiagnostics converts an Kubernetes API machinery StatusError into Terraform Diagnostics
-func APIStatusErrorToDiagnostics(s metav1.Status) []*tfprotov5.Diagnostic {
+// APIStatusErrorToDiagnostics converts an Kubernetes API machinery StatusError into Terraform Diagnostics.
+// The causes are described with the descriptions of their fields in the OpenAPI document doc of the
+// object of kind gvk, when doc isn't nil.
+func APIStatusErrorToDiagnostics(s metav1.Status, gvk schema.GroupVersionKind, doc *apierror.OpenAPIDocument) []*tfprotov5.Diagnostic {
 	var diags []*tfprotov5.Diagnostic
 	diags = append(diags, &tfprotov5.Diagnostic{
 		Severity: tfprotov5.DiagnosticSeverityError,
@@ -27,11 +32,39 @@ func APIStatusErrorToDiagnostics(s metav1.Status) []*tfprotov5.Diagnostic {
 		Summary:  fmt.Sprintf("Kubernetes API Error: %s %s [%s]", string(s.Reason), gk.String(), s.Details.Name),
 	})
 	for _, c := range s.Details.Causes {
+		detail := c.Message
+		if doc != nil {
+			if f, ok := doc.Lookup(gvk, c.Field); ok && f.Description != "" {
+				detail += fmt.Sprintf("\n\n%s: %s", c.Field, f.Description)
+			}
+		}
 		diags = append(diags, &tfprotov5.Diagnostic{
-			Severity: tfprotov5.DiagnosticSeverityError,
-			Detail:   c.Message,
-			Summary:  c.Field,
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Detail:    detail,
+			Summary:   c.Field,
+			Attribute: manifestFieldPath(c.Field),
 		})
 	}
 	return diags
 }
+
+// manifestFieldPath returns the path of the manifest attribute a field of the
+// object is set with, or nil when the field path isn't valid.
+func manifestFieldPath(field string) *tftypes.AttributePath {
+	elements, ok := apierror.ParseFieldPath(field)
+	if !ok {
+		return nil
+	}
+	path := tftypes.NewAttributePath().WithAttributeName("manifest")
+	for _, e := range elements {
+		switch {
+		case e.IsName():
+			path = path.WithAttributeName(e.Name)
+		case e.IsIndex():
+			path = path.WithElementKeyInt(e.Index)
+		default:
+			path = path.WithElementKeyString(e.Key)
+		}
+	}
+	return path
+}
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apierror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestManifestFieldPath(t *testing.T) {
+	got := manifestFieldPath("spec.template.spec.containers[0].env[1].name")
+	expected := tftypes.NewAttributePath().
+		WithAttributeName("manifest").
+		WithAttributeName("spec").
+		WithAttributeName("template").
+		WithAttributeName("spec").
+		WithAttributeName("containers").
+		WithElementKeyInt(0).
+		WithAttributeName("env").
+		WithElementKeyInt(1).
+		WithAttributeName("name")
+	if !got.Equal(expected) {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+
+	got = manifestFieldPath("metadata.annotations[example.com/owner]")
+	expected = tftypes.NewAttributePath().
+		WithAttributeName("manifest").
+		WithAttributeName("metadata").
+		WithAttributeName("annotations").
+		WithElementKeyString("example.com/owner")
+	if !got.Equal(expected) {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+
+	if got := manifestFieldPath(""); got != nil {
+		t.Fatalf("expected no path, got %s", got)
+	}
+}
+
+func TestAPIStatusErrorToDiagnostics(t *testing.T) {
+	doc, err := apierror.ParseOpenAPIDocument([]byte(`{"components": {"schemas": {
+		"io.k8s.api.apps.v1.Deployment": {
+			"type": "object",
+			"properties": {"spec": {"$ref": "#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}},
+			"x-kubernetes-group-version-kind": [{"group": "apps", "version": "v1", "kind": "Deployment"}]
+		},
+		"io.k8s.api.apps.v1.DeploymentSpec": {
+			"type": "object",
+			"properties": {"replicas": {"type": "integer", "description": "Number of desired pods."}}
+		}
+	}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	status := metav1.Status{
+		Status:  metav1.StatusFailure,
+		Message: `Deployment.apps "web" is invalid: spec.replicas: Invalid value: -1: must be greater than or equal to 0`,
+		Reason:  metav1.StatusReasonInvalid,
+		Details: &metav1.StatusDetails{
+			Name:  "web",
+			Group: "apps",
+			Kind:  "Deployment",
+			Causes: []metav1.StatusCause{{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "Invalid value: -1: must be greater than or equal to 0",
+				Field:   "spec.replicas",
+			}},
+		},
+	}
+
+	diags := APIStatusErrorToDiagnostics(status, gvk, doc)
+	if len(diags) != 3 {
+		t.Fatalf("expected 3 diagnostics, got %d", len(diags))
+	}
+	expected := "Invalid value: -1: must be greater than or equal to 0\n\nspec.replicas: Number of desired pods."
+	if diags[2].Detail != expected {
+		t.Fatalf("expected %q, got %q", expected, diags[2].Detail)
+	}
+
+	diags = APIStatusErrorToDiagnostics(status, gvk, nil)
+	if diags[2].Detail != status.Details.Causes[0].Message {
+		t.Fatalf("expected the message of the cause, got %q", diags[2].Detail)
+	}
+}
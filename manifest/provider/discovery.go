@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/restmapper"
+)
+
+// groupRESTMapper maps the kinds of an API group to their resources by only
+// discovering the resources of that group, the first time one of its kinds is
+// mapped. On clusters with hundreds of CRDs, discovering the resources of all
+// the groups dominates the time it takes to plan a few manifests. The lookups
+// by resource only search the group of the resource, the core group when it
+// is empty.
+type groupRESTMapper struct {
+	client discovery.DiscoveryInterface
+
+	mu      sync.Mutex
+	groups  map[string]metav1.APIGroup
+	mappers map[string]meta.RESTMapper
+}
+
+var _ meta.RESTMapper = &groupRESTMapper{}
+
+func newGroupRESTMapper(client discovery.DiscoveryInterface) *groupRESTMapper {
+	return &groupRESTMapper{
+		client:  client,
+		mappers: map[string]meta.RESTMapper{},
+	}
+}
+
+// mapperFor returns a RESTMapper for the resources of group. The groups of the
+// cluster, and the resources of the group, are discovered unless they already
+// were. When reset is set, they are discovered again, e.g. as a CRD may have
+// been created since.
+func (m *groupRESTMapper) mapperFor(group string, reset bool) (meta.RESTMapper, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if reset {
+		m.groups = nil
+		delete(m.mappers, group)
+	}
+	if rm, ok := m.mappers[group]; ok {
+		return rm, nil
+	}
+
+	if m.groups == nil {
+		gl, err := m.client.ServerGroups()
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover the API groups: %s", err)
+		}
+		m.groups = make(map[string]metav1.APIGroup, len(gl.Groups))
+		for _, g := range gl.Groups {
+			m.groups[g.Name] = g
+		}
+	}
+
+	var groupResources []*restmapper.APIGroupResources
+	if g, ok := m.groups[group]; ok {
+		gr := &restmapper.APIGroupResources{
+			Group:              g,
+			VersionedResources: map[string][]metav1.APIResource{},
+		}
+		for _, v := range g.Versions {
+			rl, err := m.client.ServerResourcesForGroupVersion(v.GroupVersion)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					// the version was removed since the groups were discovered
+					continue
+				}
+				return nil, fmt.Errorf("failed to discover the resources of %s: %s", v.GroupVersion, err)
+			}
+			gr.VersionedResources[v.Version] = rl.APIResources
+		}
+		groupResources = append(groupResources, gr)
+	}
+
+	rm := restmapper.NewDiscoveryRESTMapper(groupResources)
+	m.mappers[group] = rm
+	return rm, nil
+}
+
+// RESTMapping implements meta.RESTMapper.
+func (m *groupRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	rm, err := m.mapperFor(gk.Group, false)
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := rm.RESTMapping(gk, versions...)
+	if meta.IsNoMatchError(err) {
+		if rm, err = m.mapperFor(gk.Group, true); err != nil {
+			return nil, err
+		}
+		mapping, err = rm.RESTMapping(gk, versions...)
+	}
+	return mapping, err
+}
+
+// RESTMappings implements meta.RESTMapper.
+func (m *groupRESTMapper) RESTMappings(gk schema.GroupKind, versions ...string) ([]*meta.RESTMapping, error) {
+	rm, err := m.mapperFor(gk.Group, false)
+	if err != nil {
+		return nil, err
+	}
+	mappings, err := rm.RESTMappings(gk, versions...)
+	if meta.IsNoMatchError(err) {
+		if rm, err = m.mapperFor(gk.Group, true); err != nil {
+			return nil, err
+		}
+		mappings, err = rm.RESTMappings(gk, versions...)
+	}
+	return mappings, err
+}
+
+// KindFor implements meta.RESTMapper.
+func (m *groupRESTMapper) KindFor(resource schema.GroupVersionResource) (schema.GroupVersionKind, error) {
+	rm, err := m.mapperFor(resource.Group, false)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	return rm.KindFor(resource)
+}
+
+// KindsFor implements meta.RESTMapper.
+func (m *groupRESTMapper) KindsFor(resource schema.GroupVersionResource) ([]schema.GroupVersionKind, error) {
+	rm, err := m.mapperFor(resource.Group, false)
+	if err != nil {
+		return nil, err
+	}
+	return rm.KindsFor(resource)
+}
+
+// ResourceFor implements meta.RESTMapper.
+func (m *groupRESTMapper) ResourceFor(input schema.GroupVersionResource) (schema.GroupVersionResource, error) {
+	rm, err := m.mapperFor(input.Group, false)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	return rm.ResourceFor(input)
+}
+
+// ResourcesFor implements meta.RESTMapper.
+func (m *groupRESTMapper) ResourcesFor(input schema.GroupVersionResource) ([]schema.GroupVersionResource, error) {
+	rm, err := m.mapperFor(input.Group, false)
+	if err != nil {
+		return nil, err
+	}
+	return rm.ResourcesFor(input)
+}
+
+// ResourceSingularizer implements meta.RESTMapper.
+func (m *groupRESTMapper) ResourceSingularizer(resource string) (string, error) {
+	rm, err := m.mapperFor("", false)
+	if err != nil {
+		return "", err
+	}
+	return rm.ResourceSingularizer(resource)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGroupRESTMapper(t *testing.T) {
+	dc := &fake.FakeDiscovery{Fake: &k8stesting.Fake{}}
+	dc.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: "configmaps", Kind: "ConfigMap", Namespaced: true}},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{{Name: "deployments", Kind: "Deployment", Namespaced: true}},
+		},
+		{
+			GroupVersion: "example.com/v1",
+			APIResources: []metav1.APIResource{{Name: "widgets", Kind: "Widget"}},
+		},
+	}
+	m := newGroupRESTMapper(dc)
+
+	mapping, err := m.RESTMapping(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapping.Resource.Resource != "deployments" {
+		t.Fatalf("expected the deployments resource, got %s", mapping.Resource)
+	}
+	if n := countResourceDiscoveries(dc); n != 1 {
+		t.Fatalf("expected only the resources of apps/v1 to be discovered, got %d discoveries", n)
+	}
+
+	// the resources of a group are only discovered once
+	if _, err := m.RESTMapping(schema.GroupKind{Group: "apps", Kind: "Deployment"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := countResourceDiscoveries(dc); n != 1 {
+		t.Fatalf("expected the resources of apps/v1 to be cached, got %d discoveries", n)
+	}
+
+	mapping, err = m.RESTMapping(schema.GroupKind{Group: "example.com", Kind: "Widget"}, "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapping.Scope.Name() != "root" {
+		t.Fatalf("expected a cluster-scoped resource, got %s", mapping.Scope.Name())
+	}
+
+	// a group created since the groups were discovered, e.g. by a CRD
+	dc.Resources = append(dc.Resources, &metav1.APIResourceList{
+		GroupVersion: "stable.example.com/v1",
+		APIResources: []metav1.APIResource{{Name: "crontabs", Kind: "CronTab", Namespaced: true}},
+	})
+	mappings, err := m.RESTMappings(schema.GroupKind{Group: "stable.example.com", Kind: "CronTab"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mappings) != 1 || mappings[0].Resource.Resource != "crontabs" {
+		t.Fatalf("expected the crontabs resource, got %#v", mappings)
+	}
+
+	if _, err := m.RESTMapping(schema.GroupKind{Group: "apps", Kind: "Missing"}); err == nil {
+		t.Fatal("expected an error for an unknown kind")
+	}
+}
+
+func countResourceDiscoveries(dc *fake.FakeDiscovery) int {
+	n := 0
+	for _, a := range dc.Actions() {
+		if a.GetResource().Resource == "resource" {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// conflictManagerRegexp matches the field manager in the message of the causes
+// of a conflict error returned by server-side apply, e.g.
+// `conflict with "kubectl-client-side-apply" using v1`.
+var conflictManagerRegexp = regexp.MustCompile(`conflict with "([^"]*)"`)
+
+// getTransferOwnershipFrom returns the field managers the field_manager block
+// asks the ownership of the fields of the manifest to be transferred from.
+func (s *RawProviderServer) getTransferOwnershipFrom(v map[string]tftypes.Value) ([]string, error) {
+	if v["field_manager"].IsNull() || !v["field_manager"].IsKnown() {
+		return nil, nil
+	}
+	var fieldManagerBlock []tftypes.Value
+	if err := v["field_manager"].As(&fieldManagerBlock); err != nil {
+		return nil, err
+	}
+	if len(fieldManagerBlock) == 0 {
+		return nil, nil
+	}
+	var fieldManagerObj map[string]tftypes.Value
+	if err := fieldManagerBlock[0].As(&fieldManagerObj); err != nil {
+		return nil, err
+	}
+	t, ok := fieldManagerObj["transfer_ownership_from"]
+	if !ok || t.IsNull() || !t.IsKnown() {
+		return nil, nil
+	}
+	var elems []tftypes.Value
+	if err := t.As(&elems); err != nil {
+		return nil, err
+	}
+	managers := make([]string, 0, len(elems))
+	for _, e := range elems {
+		var m string
+		if err := e.As(&m); err != nil {
+			return nil, err
+		}
+		managers = append(managers, m)
+	}
+	return managers, nil
+}
+
+// conflictingManagers returns the field managers a server-side apply conflicted
+// with, or nil when the error isn't a conflict of server-side apply.
+func conflictingManagers(err error) []string {
+	var status apierrors.APIStatus
+	if !apierrors.IsConflict(err) || !errors.As(err, &status) {
+		return nil
+	}
+	details := status.Status().Details
+	if details == nil {
+		return nil
+	}
+	var managers []string
+	for _, c := range details.Causes {
+		if c.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		m := conflictManagerRegexp.FindStringSubmatch(c.Message)
+		if m == nil {
+			return nil
+		}
+		if !slices.Contains(managers, m[1]) {
+			managers = append(managers, m[1])
+		}
+	}
+	return managers
+}
+
+// applyTransferringOwnership applies the manifest with server-side apply. When
+// the apply conflicts with field managers which are all in transferFrom, the
+// manifest is applied again forcing the conflicts, which transfers the
+// ownership of the conflicting fields to the field manager. Conflicts with any
+// other field manager are returned.
+func applyTransferringOwnership(ctx context.Context, rs dynamic.ResourceInterface, name string, manifest []byte, opts metav1.PatchOptions, transferFrom []string) (*unstructured.Unstructured, error) {
+	result, err := rs.Patch(ctx, name, types.ApplyPatchType, manifest, opts)
+	if err == nil || len(transferFrom) == 0 || (opts.Force != nil && *opts.Force) {
+		return result, err
+	}
+	managers := conflictingManagers(err)
+	if len(managers) == 0 {
+		return result, err
+	}
+	for _, m := range managers {
+		if !slices.Contains(transferFrom, m) {
+			return result, err
+		}
+	}
+	force := true
+	opts.Force = &force
+	return rs.Patch(ctx, name, types.ApplyPatchType, manifest, opts)
+}
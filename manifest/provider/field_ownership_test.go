@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func conflictError(managers ...string) error {
+	causes := make([]metav1.StatusCause, 0, len(managers))
+	for _, m := range managers {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "` + m + `" using v1`,
+			Field:   ".data.foo",
+		})
+	}
+	return &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   409,
+		Reason: metav1.StatusReasonConflict,
+		Details: &metav1.StatusDetails{
+			Causes: causes,
+		},
+	}}
+}
+
+func TestConflictingManagers(t *testing.T) {
+	got := conflictingManagers(conflictError("kubectl-edit", "helm", "kubectl-edit"))
+	if len(got) != 2 || got[0] != "kubectl-edit" || got[1] != "helm" {
+		t.Fatalf("expected kubectl-edit and helm, got %v", got)
+	}
+	if got := conflictingManagers(apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "test")); got != nil {
+		t.Fatalf("expected no managers for a not found error, got %v", got)
+	}
+}
+
+func TestApplyTransferringOwnership(t *testing.T) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	cases := []struct {
+		Name         string
+		Conflicts    []string
+		TransferFrom []string
+		Forced       bool
+		Error        bool
+	}{
+		{"no conflict", nil, []string{"kubectl-edit"}, false, false},
+		{"transferred", []string{"kubectl-edit"}, []string{"kubectl-edit", "helm"}, true, false},
+		{"other manager", []string{"kubectl-edit", "other"}, []string{"kubectl-edit"}, false, true},
+		{"not configured", []string{"kubectl-edit"}, nil, false, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme())
+			// The fake client doesn't pass on the patch options, the second
+			// apply is the forced one.
+			calls := 0
+			client.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				calls++
+				if calls == 1 && len(tc.Conflicts) > 0 {
+					return true, nil, conflictError(tc.Conflicts...)
+				}
+				return true, &unstructured.Unstructured{}, nil
+			})
+
+			force := false
+			_, err := applyTransferringOwnership(context.Background(), client.Resource(gvr).Namespace("default"), "test", []byte("{}"),
+				metav1.PatchOptions{FieldManager: "Terraform", Force: &force}, tc.TransferFrom)
+			if (err != nil) != tc.Error {
+				t.Fatalf("expected error %t, got %v", tc.Error, err)
+			}
+			if forced := calls == 2; forced != tc.Forced {
+				t.Fatalf("expected forced %t, got %t", tc.Forced, forced)
+			}
+		})
+	}
+}
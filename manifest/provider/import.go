@@ -56,6 +56,7 @@ func (s *RawProviderServer) ImportResourceState(ctx context.Context, req *tfprot
 			Summary:  "Failed to parse import ID",
 			Detail:   err.Error(),
 		})
+		return resp, nil
 	}
 	s.logger.Trace("[ImportResourceState]", "[ID]", gvk, name, namespace)
 	rt, err := GetResourceType(req.TypeName)
@@ -159,20 +160,35 @@ func (s *RawProviderServer) ImportResourceState(ctx context.Context, req *tfprot
 	}
 	s.logger.Trace("[ImportResourceState]", "[tftypes.Value]", nobj)
 
+	// The manifest of the imported state is the object without the fields set by
+	// the API server, so that the configuration Terraform generates on import
+	// (terraform plan -generate-config-out) can be applied as is.
+	nman, err := payload.ToTFValue(fo, tftypes.DynamicPseudoType, th, tftypes.NewAttributePath())
+	if err != nil {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Failed to convert unstructured to manifest",
+			Detail:   err.Error(),
+		})
+		return resp, nil
+	}
+
 	newState := make(map[string]tftypes.Value)
 	wftype := rt.(tftypes.Object).AttributeTypes["wait_for"]
 	wtype := rt.(tftypes.Object).AttributeTypes["wait"]
 	timeoutsType := rt.(tftypes.Object).AttributeTypes["timeouts"]
 	fmType := rt.(tftypes.Object).AttributeTypes["field_manager"]
 	cmpType := rt.(tftypes.Object).AttributeTypes["computed_fields"]
+	adoptType := rt.(tftypes.Object).AttributeTypes["adopt_existing"]
 
-	newState["manifest"] = tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, nil)
+	newState["manifest"] = nman
 	newState["object"] = morph.UnknownToNull(nobj)
 	newState["wait_for"] = tftypes.NewValue(wftype, nil)
 	newState["wait"] = tftypes.NewValue(wtype, nil)
 	newState["timeouts"] = tftypes.NewValue(timeoutsType, nil)
 	newState["field_manager"] = tftypes.NewValue(fmType, nil)
 	newState["computed_fields"] = tftypes.NewValue(cmpType, nil)
+	newState["adopt_existing"] = tftypes.NewValue(adoptType, nil)
 
 	nsVal := tftypes.NewValue(rt, newState)
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apiwarning"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/morph"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/payload"
@@ -19,7 +20,7 @@ import (
 	"k8s.io/client-go/dynamic"
 )
 
-func (s *RawProviderServer) dryRun(ctx context.Context, obj tftypes.Value, fieldManager string, forceConflicts bool, isNamespaced bool) error {
+func (s *RawProviderServer) dryRun(ctx context.Context, obj tftypes.Value, fieldManager string, forceConflicts bool, transferFrom []string, isNamespaced bool) error {
 	c, err := s.getDynamicClient()
 	if err != nil {
 		return fmt.Errorf("failed to retrieve Kubernetes dynamic client during apply: %v", err)
@@ -58,12 +59,13 @@ func (s *RawProviderServer) dryRun(ctx context.Context, obj tftypes.Value, field
 	if err != nil {
 		return fmt.Errorf("failed to marshall resource %q to JSON: %v", rnn, err)
 	}
-	_, err = rs.Patch(ctx, rname, types.ApplyPatchType, jsonManifest,
+	_, err = applyTransferringOwnership(ctx, rs, rname, jsonManifest,
 		metav1.PatchOptions{
 			FieldManager: fieldManager,
 			Force:        &forceConflicts,
 			DryRun:       []string{"All"},
 		},
+		transferFrom,
 	)
 
 	return err
@@ -130,6 +132,11 @@ func isImportedFlagFromPrivate(p []byte) (f bool, d []*tfprotov5.Diagnostic) {
 func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
 	resp := &tfprotov5.PlanResourceChangeResponse{}
 
+	ctx, warnings := apiwarning.WithCollector(ctx)
+	defer func() {
+		resp.Diagnostics = append(resp.Diagnostics, apiWarningDiagnostics(warnings)...)
+	}()
+
 	rt, err := GetResourceType(req.TypeName)
 	if err != nil {
 		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
@@ -360,7 +367,17 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 			return resp, nil
 		}
 
-		err = s.dryRun(ctx, ppMan, fieldManagerName, forceConflicts, ns)
+		transferFrom, err := s.getTransferOwnershipFrom(proposedVal)
+		if err != nil {
+			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Could not extract field_manager config",
+				Detail:   err.Error(),
+			})
+			return resp, nil
+		}
+
+		err = s.dryRun(ctx, ppMan, fieldManagerName, forceConflicts, transferFrom, ns)
 		if err != nil {
 			resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
 				Severity: tfprotov5.DiagnosticSeverityError,
@@ -446,6 +463,11 @@ func (s *RawProviderServer) PlanResourceChange(ctx context.Context, req *tfproto
 			})
 			return resp, nil
 		}
+		if isImported {
+			// The manifest of an imported resource was generated from the
+			// object, none of its fields were actually configured.
+			priorMan = tftypes.NewValue(priorMan.Type(), nil)
+		}
 		updatedObj, err := tftypes.Transform(completePropMan, func(ap *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
 			_, isComputed := computedFields[ap.String()]
 			if v.IsKnown() { // this is a value from current configuration - include it in the plan
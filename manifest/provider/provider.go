@@ -128,6 +128,28 @@ func GetProviderResourceSchema() map[string]*tfprotov5.Schema {
 									DescriptionKind: 0,
 									Deprecated:      false,
 								},
+								{
+									Name:            "migrate_client_side_apply",
+									Type:            tftypes.Bool,
+									Required:        false,
+									Optional:        true,
+									Computed:        false,
+									Sensitive:       false,
+									Description:     "Transfer the ownership of the fields managed by client-side `kubectl apply` to this field manager before applying, so that objects previously managed with `kubectl apply` can be adopted without conflicts. The `kubectl.kubernetes.io/last-applied-configuration` annotation is removed by the apply.",
+									DescriptionKind: 0,
+									Deprecated:      false,
+								},
+								{
+									Name:            "transfer_ownership_from",
+									Type:            tftypes.List{ElementType: tftypes.String},
+									Required:        false,
+									Optional:        true,
+									Computed:        false,
+									Sensitive:       false,
+									Description:     "Field managers, e.g. `kubectl-client-side-apply`, `kubectl-edit` or `helm`, to take over the fields of the manifest from when applying conflicts with them. The ownership of the conflicting fields is transferred to this field manager, so that the following applies don't conflict. Conflicts with other field managers are still reported.",
+									DescriptionKind: 0,
+									Deprecated:      false,
+								},
 							},
 						},
 					},
@@ -173,6 +195,12 @@ func GetProviderResourceSchema() map[string]*tfprotov5.Schema {
 									Optional:    true,
 									Description: "A map of paths to fields to wait for a specific field value.",
 								},
+								{
+									Name:        "preset",
+									Type:        tftypes.String,
+									Optional:    true,
+									Description: "Wait for a condition commonly used by custom resources to be true for the latest generation of the resource. One of `Ready`, `Available` or `Reconciled`.",
+								},
 							},
 						},
 					},
@@ -210,6 +238,12 @@ func GetProviderResourceSchema() map[string]*tfprotov5.Schema {
 						Description: "List of manifest fields whose values can be altered by the API server during 'apply'. Defaults to: [\"metadata.annotations\", \"metadata.labels\"]",
 						Optional:    true,
 					},
+					{
+						Name:        "adopt_existing",
+						Type:        tftypes.Bool,
+						Description: "Adopt the object when it already exists on create, instead of failing. The field manager takes over the fields of the manifest from their current field managers, including `kubectl apply`, and the object is reconciled to the manifest.",
+						Optional:    true,
+					},
 				},
 			},
 		},
@@ -242,6 +276,18 @@ func GetProviderDataSourceSchema() map[string]*tfprotov5.Schema {
 						Computed:    true,
 						Description: "The response from the API server.",
 					},
+					{
+						Name:        "retain_managed_fields",
+						Type:        tftypes.Bool,
+						Optional:    true,
+						Description: "Keep the managedFields of the metadata of the object read from the API server. They are left out by default, as they make up a large part of the object and change on most writes to it.",
+					},
+					{
+						Name:        "remove_status",
+						Type:        tftypes.Bool,
+						Optional:    true,
+						Description: "Leave the status of the object read from the API server out of `object`, e.g. when only its spec is used and the status of a busy object would change it on every read.",
+					},
 				},
 				BlockTypes: []*tfprotov5.SchemaNestedBlock{
 					{
@@ -317,6 +363,24 @@ func GetProviderDataSourceSchema() map[string]*tfprotov5.Schema {
 						Optional:    true,
 						Description: "Limit is a maximum number of responses to return for a list call.",
 					},
+					{
+						Name:        "page_size",
+						Type:        tftypes.Number,
+						Optional:    true,
+						Description: "The number of objects requested from the API server at a time. The objects are listed page by page, so that listing many of them doesn't time out. Defaults to 500.",
+					},
+					{
+						Name:        "retain_managed_fields",
+						Type:        tftypes.Bool,
+						Optional:    true,
+						Description: "Keep the managedFields of the metadata of the objects read from the API server. They are left out by default, as they make up a large part of the objects and change on most writes to them.",
+					},
+					{
+						Name:        "remove_status",
+						Type:        tftypes.Bool,
+						Optional:    true,
+						Description: "Leave the status of the objects read from the API server out of `objects`, e.g. when only their spec is used and the status of busy objects would change them on every read.",
+					},
 				},
 			},
 		},
@@ -200,6 +200,28 @@ func GetProviderConfigSchema() *tfprotov5.Schema {
 				DescriptionKind: 0,
 				Deprecated:      false,
 			},
+			{
+				Name:            "trace_requests",
+				Type:            tftypes.Bool,
+				Description:     "Log the headers and bodies of the requests sent to the Kubernetes API and of their responses at DEBUG level, with the values of secrets and credentials redacted and bodies truncated to 4KiB. Can be set with the `KUBE_TRACE_REQUESTS` environment variable.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
+			{
+				Name:            "batch_reads",
+				Type:            tftypes.Bool,
+				Description:     "Refresh the resources of the same kind and namespace with a single `LIST` request of the namespace, sent in pages of 500 objects, instead of a `GET` request per object. Secrets are always read one by one. Can be set with the `KUBE_BATCH_READS` environment variable.",
+				Required:        false,
+				Optional:        true,
+				Computed:        false,
+				Sensitive:       false,
+				DescriptionKind: 0,
+				Deprecated:      false,
+			},
 		},
 		BlockTypes: []*tfprotov5.SchemaNestedBlock{
 			{
@@ -274,6 +296,39 @@ func GetProviderConfigSchema() *tfprotov5.Schema {
 					},
 				},
 			},
+			{
+				TypeName: "run_attribution",
+				Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+				MinItems: 0,
+				MaxItems: 1,
+				Block: &tfprotov5.SchemaBlock{
+					Description: "Annotate the objects created and updated by the structured resources with the Terraform workspace and run which last changed them, and with the type of the resource managing them. The annotations are ignored when comparing objects with the configuration.",
+					Attributes: []*tfprotov5.SchemaAttribute{
+						{
+							Name:            "workspace",
+							Type:            tftypes.String,
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							Description:     "The Terraform workspace recorded in the `terraform.io/workspace` annotation. Defaults to the `TFC_WORKSPACE_NAME` or `TF_WORKSPACE` environment variable.",
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+						{
+							Name:            "run_id",
+							Type:            tftypes.String,
+							Required:        false,
+							Optional:        true,
+							Computed:        false,
+							Sensitive:       false,
+							Description:     "The Terraform run recorded in the `terraform.io/run-id` annotation. Defaults to the `TFC_RUN_ID` environment variable.",
+							DescriptionKind: 0,
+							Deprecated:      false,
+						},
+					},
+				},
+			},
 		},
 	}
 
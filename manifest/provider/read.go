@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apiwarning"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/morph"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/payload"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -20,6 +21,11 @@ import (
 func (s *RawProviderServer) ReadResource(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
 	resp := &tfprotov5.ReadResourceResponse{}
 
+	ctx, warnings := apiwarning.WithCollector(ctx)
+	defer func() {
+		resp.Diagnostics = append(resp.Diagnostics, apiWarningDiagnostics(warnings)...)
+	}()
+
 	cp := req.ClientCapabilities
 	if cp != nil && cp.DeferralAllowed && s.clientConfigUnknown {
 		// if client support it, request deferral when client configuration not fully known
@@ -170,7 +176,7 @@ func (s *RawProviderServer) ReadResource(ctx context.Context, req *tfprotov5.Rea
 		return resp, nil
 	}
 
-	fo := RemoveServerSideFields(ro.Object)
+	fo := normalizeSecretStringData(RemoveServerSideFields(ro.Object), uo.Object)
 	nobj, err := payload.ToTFValue(fo, objectType, th, tftypes.NewAttributePath())
 	if err != nil {
 		return resp, err
@@ -5,12 +5,15 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-provider-kubernetes/manifest/openapi"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -86,7 +89,7 @@ func (ps *RawProviderServer) TFTypeFromOpenAPI(ctx context.Context, gvk schema.G
 	var tsch tftypes.Type
 	var hints map[string]string
 
-	oapi, err := ps.getOAPIv2Foundry()
+	oapi, err := ps.getOAPIFoundry(gvk.GroupVersion())
 	if err != nil {
 		return nil, hints, fmt.Errorf("cannot get OpenAPI foundry: %s", err)
 	}
@@ -196,8 +199,56 @@ func RemoveServerSideFields(in map[string]interface{}) map[string]interface{} {
 
 	// TODO: we should be filtering API responses based on the contents of 'managedFields'
 	// and only retain the attributes for which the manager is Terraform
-	delete(meta, "managedFields")
+	removeManagedFields(in)
 
+	removeLastAppliedConfig(meta)
+
+	return in
+}
+
+// removeManagedFields removes "metadata.managedFields", which is often larger
+// than the rest of the object and changes with every write by any manager.
+func removeManagedFields(in map[string]interface{}) map[string]interface{} {
+	if meta, ok := in["metadata"].(map[string]interface{}); ok {
+		delete(meta, "managedFields")
+	}
+	return in
+}
+
+// normalizeSecretStringData restores the stringData of a Secret read from the
+// API. The API server merges stringData into data, so the keys found in the
+// stringData of the applied object are moved back from data to stringData,
+// decoded. This way Secrets written with either representation read back the
+// way they were written.
+func normalizeSecretStringData(in map[string]interface{}, applied map[string]interface{}) map[string]interface{} {
+	if in["apiVersion"] != "v1" || in["kind"] != "Secret" {
+		return in
+	}
+	stringData, ok := applied["stringData"].(map[string]interface{})
+	if !ok || len(stringData) == 0 {
+		return in
+	}
+	data, _ := in["data"].(map[string]interface{})
+
+	out := make(map[string]interface{}, len(stringData))
+	for k := range stringData {
+		v, ok := data[k].(string)
+		if !ok {
+			continue
+		}
+		b, err := base64.StdEncoding.DecodeString(v)
+		if err != nil || !utf8.Valid(b) {
+			continue
+		}
+		out[k] = string(b)
+		delete(data, k)
+	}
+	if len(out) > 0 {
+		in["stringData"] = out
+	}
+	if data != nil && len(data) == 0 {
+		delete(in, "data")
+	}
 	return in
 }
 
@@ -211,6 +262,17 @@ func (ps *RawProviderServer) lookUpGVKinCRDs(ctx context.Context, gvk schema.Gro
 		return nil, err
 	}
 
+	// the resources of the core group are all built-in
+	if gvk.Group == "" {
+		return nil, nil
+	}
+	// the CRD defining the kind is named after its resource
+	rm, err := m.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	name := rm.Resource.Resource + "." + gvk.Group
+
 	crd := schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
 	crms, err := m.RESTMappings(crd)
 	if err != nil {
@@ -218,47 +280,48 @@ func (ps *RawProviderServer) lookUpGVKinCRDs(ctx context.Context, gvk schema.Gro
 	}
 	// check  CRD versions
 	for _, crm := range crms {
-		crdRes, err := c.Resource(crm.Resource).List(ctx, v1.ListOptions{})
+		r, err := c.Resource(crm.Resource).Get(ctx, name, v1.GetOptions{})
 		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
 			return nil, err
 		}
 
-		for _, r := range crdRes.Items {
-			spec := r.Object["spec"].(map[string]interface{})
-			if spec == nil {
-				continue
-			}
-			grp := spec["group"].(string)
-			if grp != gvk.Group {
-				continue
-			}
-			names := spec["names"]
-			if names == nil {
+		spec := r.Object["spec"].(map[string]interface{})
+		if spec == nil {
+			continue
+		}
+		grp := spec["group"].(string)
+		if grp != gvk.Group {
+			continue
+		}
+		names := spec["names"]
+		if names == nil {
+			continue
+		}
+		kind := names.(map[string]interface{})["kind"]
+		if kind != gvk.Kind {
+			continue
+		}
+		ver := spec["versions"]
+		if ver == nil {
+			ver = spec["version"]
+			if ver == nil {
 				continue
 			}
-			kind := names.(map[string]interface{})["kind"]
-			if kind != gvk.Kind {
+		}
+		for _, rv := range ver.([]interface{}) {
+			if rv == nil {
 				continue
 			}
-			ver := spec["versions"]
-			if ver == nil {
-				ver = spec["version"]
-				if ver == nil {
-					continue
-				}
-			}
-			for _, rv := range ver.([]interface{}) {
-				if rv == nil {
-					continue
-				}
-				v := rv.(map[string]interface{})
-				if v["name"] == gvk.Version {
-					s, ok := v["schema"].(map[string]interface{})
-					if !ok {
-						return nil, nil // non-structural CRD
-					}
-					return s["openAPIV3Schema"], nil
+			v := rv.(map[string]interface{})
+			if v["name"] == gvk.Version {
+				s, ok := v["schema"].(map[string]interface{})
+				if !ok {
+					return nil, nil // non-structural CRD
 				}
+				return s["openAPIV3Schema"], nil
 			}
 		}
 	}
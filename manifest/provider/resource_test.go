@@ -69,3 +69,45 @@ func TestRemoveNulls(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeSecretStringData(t *testing.T) {
+	applied := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"stringData": map[string]interface{}{
+			"username": "admin",
+			"missing":  "value",
+		},
+	}
+	in := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"data": map[string]interface{}{
+			"username": "YWRtaW4=",
+			"password": "c2VjcmV0",
+		},
+	}
+	out := normalizeSecretStringData(in, applied)
+	expected := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"data": map[string]interface{}{
+			"password": "c2VjcmV0",
+		},
+		"stringData": map[string]interface{}{
+			"username": "admin",
+		},
+	}
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("expected %v, got %v", expected, out)
+	}
+
+	configMap := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"data":       map[string]interface{}{"username": "YWRtaW4="},
+	}
+	if out := normalizeSecretStringData(configMap, applied); !reflect.DeepEqual(out["data"], map[string]interface{}{"username": "YWRtaW4="}) {
+		t.Fatalf("expected ConfigMap to be left alone, got %v", out)
+	}
+}
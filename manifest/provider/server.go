@@ -5,6 +5,7 @@ package provider
 
 import (
 	"context"
+	"sync"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
@@ -13,9 +14,11 @@ import (
 	"google.golang.org/grpc/status"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/install"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes/scheme"
+	clientopenapi "k8s.io/client-go/openapi"
 	"k8s.io/client-go/rest"
 )
 
@@ -37,6 +40,10 @@ type RawProviderServer struct {
 	restClient          rest.Interface
 	OAPIFoundry         openapi.Foundry
 
+	oapiMu          sync.Mutex
+	oapiV3Paths     map[string]clientopenapi.GroupVersion
+	oapiV3Foundries map[schema.GroupVersion]openapi.Foundry
+
 	hostTFVersion string
 }
 
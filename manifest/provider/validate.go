@@ -6,6 +6,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -106,6 +107,8 @@ func (s *RawProviderServer) ValidateResourceTypeConfig(ctx context.Context, req
 		}
 	}
 
+	resp.Diagnostics = append(resp.Diagnostics, validateConversionWebhook(manifest)...)
+
 	// validate timeouts block
 	timeouts := s.getTimeouts(configVal)
 	path := tftypes.NewAttributePath().WithAttributeName("timeouts")
@@ -149,6 +152,23 @@ func (s *RawProviderServer) ValidateResourceTypeConfig(ctx context.Context, req
 					Attribute: tftypes.NewAttributePath().WithAttributeName("wait"),
 				})
 			}
+			if preset, ok := w["preset"]; ok && !preset.IsNull() && preset.IsKnown() {
+				var p string
+				preset.As(&p)
+				if _, ok := WaitPresets[p]; !ok {
+					presets := make([]string, 0, len(WaitPresets))
+					for k := range WaitPresets {
+						presets = append(presets, k)
+					}
+					sort.Strings(presets)
+					resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+						Severity:  tfprotov5.DiagnosticSeverityError,
+						Summary:   "Invalid wait configuration",
+						Detail:    fmt.Sprintf(`Unknown preset %q, must be one of "%s".`, p, strings.Join(presets, "\", \"")),
+						Attribute: tftypes.NewAttributePath().WithAttributeName("wait").WithElementKeyInt(0).WithAttributeName("preset"),
+					})
+				}
+			}
 		}
 	}
 	if waitFor, ok := configVal["wait_for"]; ok && !waitFor.IsNull() {
@@ -239,3 +259,79 @@ func (s *RawProviderServer) validateResourceOnline(manifest *tftypes.Value) (dia
 	}
 	return
 }
+
+// validateConversionWebhook checks the conversion of the versions of a custom
+// resource definition manifest. Mistakes in the webhook configuration are
+// otherwise only reported by the API server when applying the manifest, and
+// may break the conversion of the existing custom resources.
+func validateConversionWebhook(manifest tftypes.Value) (diags []*tfprotov5.Diagnostic) {
+	apiVersion, _ := manifestString(manifest, tftypes.NewAttributePath().WithAttributeName("apiVersion"))
+	kind, _ := manifestString(manifest, tftypes.NewAttributePath().WithAttributeName("kind"))
+	if !strings.HasPrefix(apiVersion, "apiextensions.k8s.io/") || kind != "CustomResourceDefinition" {
+		return nil
+	}
+
+	conversion := tftypes.NewAttributePath().WithAttributeName("spec").WithAttributeName("conversion")
+	strategy, ok := manifestString(manifest, conversion.WithAttributeName("strategy"))
+	if !ok {
+		return nil
+	}
+	webhook := conversion.WithAttributeName("webhook")
+	attribute := tftypes.NewAttributePathWithSteps(append([]tftypes.AttributePathStep{tftypes.AttributeName("manifest")}, webhook.Steps()...))
+	invalid := func(detail string) {
+		diags = append(diags, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Invalid conversion webhook",
+			Detail:    detail,
+			Attribute: attribute,
+		})
+	}
+
+	switch strategy {
+	case "None":
+		if manifestHas(manifest, webhook) {
+			invalid(`"spec.conversion.webhook" may only be set when "spec.conversion.strategy" is "Webhook".`)
+		}
+	case "Webhook":
+		if !manifestHas(manifest, webhook) {
+			invalid(`"spec.conversion.webhook" is required when "spec.conversion.strategy" is "Webhook".`)
+			return
+		}
+		clientConfig := webhook.WithAttributeName("clientConfig")
+		url := manifestHas(manifest, clientConfig.WithAttributeName("url"))
+		service := manifestHas(manifest, clientConfig.WithAttributeName("service"))
+		if url == service {
+			invalid(`Exactly one of "spec.conversion.webhook.clientConfig.url" and "spec.conversion.webhook.clientConfig.service" must be set.`)
+		}
+		if !manifestHas(manifest, webhook.WithAttributeName("conversionReviewVersions")) {
+			invalid(`"spec.conversion.webhook.conversionReviewVersions" is required, e.g. ["v1"].`)
+		}
+	}
+	return
+}
+
+// manifestString returns the string at path in manifest, if it is known
+func manifestString(manifest tftypes.Value, path *tftypes.AttributePath) (string, bool) {
+	v, rest, err := tftypes.WalkAttributePath(manifest, path)
+	if err != nil || len(rest.Steps()) > 0 {
+		return "", false
+	}
+	tv, ok := v.(tftypes.Value)
+	if !ok || tv.IsNull() || !tv.IsKnown() || !tv.Type().Is(tftypes.String) {
+		return "", false
+	}
+	var s string
+	tv.As(&s)
+	return s, true
+}
+
+// manifestHas checks whether the value at path in manifest is set. Unknown
+// values are set.
+func manifestHas(manifest tftypes.Value, path *tftypes.AttributePath) bool {
+	v, rest, err := tftypes.WalkAttributePath(manifest, path)
+	if err != nil || len(rest.Steps()) > 0 {
+		return false
+	}
+	tv, ok := v.(tftypes.Value)
+	return ok && !tv.IsNull()
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// objectValue converts obj, made of maps, slices and strings, to an object
+// value like the manifests of the configuration
+func objectValue(obj interface{}) tftypes.Value {
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		types := map[string]tftypes.Type{}
+		values := map[string]tftypes.Value{}
+		for k, v := range o {
+			values[k] = objectValue(v)
+			types[k] = values[k].Type()
+		}
+		return tftypes.NewValue(tftypes.Object{AttributeTypes: types}, values)
+	case []interface{}:
+		types := []tftypes.Type{}
+		values := []tftypes.Value{}
+		for _, v := range o {
+			values = append(values, objectValue(v))
+			types = append(types, values[len(values)-1].Type())
+		}
+		return tftypes.NewValue(tftypes.Tuple{ElementTypes: types}, values)
+	default:
+		return tftypes.NewValue(tftypes.String, o)
+	}
+}
+
+func TestValidateConversionWebhook(t *testing.T) {
+	crd := func(conversion map[string]interface{}) tftypes.Value {
+		return objectValue(map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1",
+			"kind":       "CustomResourceDefinition",
+			"metadata":   map[string]interface{}{"name": "widgets.example.com"},
+			"spec":       map[string]interface{}{"group": "example.com", "conversion": conversion},
+		})
+	}
+	service := map[string]interface{}{"name": "widgets-webhook", "namespace": "widgets"}
+	samples := map[string]struct {
+		manifest tftypes.Value
+		errors   int
+	}{
+		"webhook": {
+			manifest: crd(map[string]interface{}{
+				"strategy": "Webhook",
+				"webhook": map[string]interface{}{
+					"clientConfig":             map[string]interface{}{"service": service},
+					"conversionReviewVersions": []interface{}{"v1"},
+				},
+			}),
+		},
+		"none": {
+			manifest: crd(map[string]interface{}{"strategy": "None"}),
+		},
+		"unknown strategy": {
+			manifest: crd(map[string]interface{}{
+				"strategy": tftypes.UnknownValue,
+				"webhook":  map[string]interface{}{"clientConfig": map[string]interface{}{}},
+			}),
+		},
+		"not a crd": {
+			manifest: objectValue(map[string]interface{}{
+				"apiVersion": "example.com/v1",
+				"kind":       "Widget",
+				"spec":       map[string]interface{}{"conversion": map[string]interface{}{"strategy": "Webhook"}},
+			}),
+		},
+		"webhook with none": {
+			manifest: crd(map[string]interface{}{
+				"strategy": "None",
+				"webhook":  map[string]interface{}{"conversionReviewVersions": []interface{}{"v1"}},
+			}),
+			errors: 1,
+		},
+		"missing webhook": {
+			manifest: crd(map[string]interface{}{"strategy": "Webhook"}),
+			errors:   1,
+		},
+		"url and service": {
+			manifest: crd(map[string]interface{}{
+				"strategy": "Webhook",
+				"webhook": map[string]interface{}{
+					"clientConfig":             map[string]interface{}{"url": "https://widgets.example.com/convert", "service": service},
+					"conversionReviewVersions": []interface{}{"v1"},
+				},
+			}),
+			errors: 1,
+		},
+		"missing client config and review versions": {
+			manifest: crd(map[string]interface{}{
+				"strategy": "Webhook",
+				"webhook":  map[string]interface{}{"timeoutSeconds": "5"},
+			}),
+			errors: 2,
+		},
+	}
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			diags := validateConversionWebhook(s.manifest)
+			if len(diags) != s.errors {
+				t.Fatalf("Expected %d errors, got %d: %v", s.errors, len(diags), diags)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/kubectl/pkg/polymorphichelpers"
 )
@@ -70,6 +72,21 @@ func NewResourceWaiter(resource dynamic.ResourceInterface, resourceName string,
 		}
 	}
 
+	if v, ok := waitForBlockVal["preset"]; ok && !v.IsNull() && v.IsKnown() {
+		var preset string
+		v.As(&preset)
+		conditionType, ok := WaitPresets[preset]
+		if !ok {
+			return nil, fmt.Errorf("unknown wait preset %q", preset)
+		}
+		return &PresetWaiter{
+			resource,
+			resourceName,
+			conditionType,
+			hl,
+		}, nil
+	}
+
 	if v, ok := waitForBlockVal["condition"]; ok {
 		var conditionsBlocks []tftypes.Value
 		v.As(&conditionsBlocks)
@@ -383,3 +400,141 @@ func (w *ConditionsWaiter) Wait(ctx context.Context) error {
 	w.logger.Info("[ApplyResourceChange][Wait] All conditions met.\n")
 	return nil
 }
+
+// WaitPresets maps the presets supported by the wait block to the condition
+// type they wait for. They cover the conventions followed by most controllers
+// of custom resources, such as cert-manager or Crossplane.
+var WaitPresets = map[string]string{
+	"Ready":      "Ready",
+	"Available":  "Available",
+	"Reconciled": "Reconciled",
+}
+
+// PresetWaiter will wait for a condition following the Kubernetes API
+// conventions to be true for the current generation of the resource
+type PresetWaiter struct {
+	resource      dynamic.ResourceInterface
+	resourceName  string
+	conditionType string
+	logger        hclog.Logger
+}
+
+// Wait blocks until the condition has status True and the controller has
+// observed the latest generation of the resource
+func (w *PresetWaiter) Wait(ctx context.Context) error {
+	w.logger.Info("[ApplyResourceChange][Wait] Waiting for condition...\n", "type", w.conditionType)
+
+	for {
+		if deadline, ok := ctx.Deadline(); ok {
+			if time.Now().After(deadline) {
+				return WaiterError{Reason: fmt.Sprintf("condition %q", w.conditionType)}
+			}
+		}
+
+		res, err := w.resource.Get(ctx, w.resourceName, v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		if presetConditionMet(res.Object, w.conditionType) {
+			break
+		}
+
+		time.Sleep(waiterSleepTime) // lintignore:R018
+	}
+
+	w.logger.Info("[ApplyResourceChange][Wait] Condition met.\n", "type", w.conditionType)
+	return nil
+}
+
+// presetConditionMet checks that the condition of the given type is True and,
+// when the resource reports it, that it reflects the latest generation
+func presetConditionMet(obj map[string]interface{}, conditionType string) bool {
+	generation, hasGeneration, _ := unstructured.NestedInt64(obj, "metadata", "generation")
+	if observed, ok, _ := unstructured.NestedInt64(obj, "status", "observedGeneration"); ok && hasGeneration && observed < generation {
+		return false
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != conditionType {
+			continue
+		}
+		if observed, ok, _ := unstructured.NestedInt64(condition, "observedGeneration"); ok && hasGeneration && observed < generation {
+			return false
+		}
+		return condition["status"] == "True"
+	}
+	return false
+}
+
+// CustomResourceDefinitionWaiter will wait for a custom resource definition
+// to be established, so that custom resources of its kind can be applied
+type CustomResourceDefinitionWaiter struct {
+	resource     dynamic.ResourceInterface
+	resourceName string
+	logger       hclog.Logger
+}
+
+// Wait blocks until the names of the custom resource definition are accepted
+// and it is established, and fails early when the names are rejected
+func (w *CustomResourceDefinitionWaiter) Wait(ctx context.Context) error {
+	w.logger.Info("[ApplyResourceChange][Wait] Waiting for custom resource definition to be established...\n")
+
+	for {
+		if deadline, ok := ctx.Deadline(); ok {
+			if time.Now().After(deadline) {
+				return WaiterError{Reason: "custom resource definition to be established"}
+			}
+		}
+
+		res, err := w.resource.Get(ctx, w.resourceName, v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		established, err := customResourceDefinitionEstablished(res.Object)
+		if err != nil {
+			return err
+		}
+		if established {
+			break
+		}
+
+		time.Sleep(waiterSleepTime) // lintignore:R018
+	}
+
+	w.logger.Info("[ApplyResourceChange][Wait] Custom resource definition established.\n")
+	return nil
+}
+
+// customResourceDefinitionEstablished checks that the NamesAccepted and
+// Established conditions of a custom resource definition are True. It returns
+// an error when the names were rejected, e.g. as they conflict with those of
+// another custom resource definition, as the definition can't become
+// established until they are changed.
+func customResourceDefinitionEstablished(obj map[string]interface{}) (bool, error) {
+	status := map[string]string{}
+	conditions, _, _ := unstructured.NestedSlice(obj, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditionType, _ := condition["type"].(string)
+		conditionStatus, _ := condition["status"].(string)
+		status[conditionType] = conditionStatus
+		if conditionType == "NamesAccepted" && conditionStatus == "False" {
+			message, _ := condition["message"].(string)
+			return false, fmt.Errorf("the names of the custom resource definition were not accepted: %s", message)
+		}
+	}
+	return status["NamesAccepted"] == "True" && status["Established"] == "True", nil
+}
+
+// isCustomResourceDefinition checks whether gvk is the kind of custom
+// resource definitions
+func isCustomResourceDefinition(gvk schema.GroupVersionKind) bool {
+	return gvk.Group == "apiextensions.k8s.io" && gvk.Kind == "CustomResourceDefinition"
+}
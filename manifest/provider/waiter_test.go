@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestPresetConditionMet(t *testing.T) {
+	samples := map[string]struct {
+		obj map[string]interface{}
+		met bool
+	}{
+		"ready": {
+			obj: map[string]interface{}{
+				"metadata": map[string]interface{}{"generation": int64(2)},
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Ready", "status": "True", "observedGeneration": int64(2)},
+					},
+				},
+			},
+			met: true,
+		},
+		"not ready": {
+			obj: map[string]interface{}{
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Ready", "status": "False"},
+					},
+				},
+			},
+			met: false,
+		},
+		"missing condition": {
+			obj: map[string]interface{}{
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Synced", "status": "True"},
+					},
+				},
+			},
+			met: false,
+		},
+		"stale condition": {
+			obj: map[string]interface{}{
+				"metadata": map[string]interface{}{"generation": int64(3)},
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Ready", "status": "True", "observedGeneration": int64(2)},
+					},
+				},
+			},
+			met: false,
+		},
+		"stale status": {
+			obj: map[string]interface{}{
+				"metadata": map[string]interface{}{"generation": int64(3)},
+				"status": map[string]interface{}{
+					"observedGeneration": int64(2),
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Ready", "status": "True"},
+					},
+				},
+			},
+			met: false,
+		},
+		"no status": {
+			obj: map[string]interface{}{},
+			met: false,
+		},
+	}
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			if met := presetConditionMet(s.obj, "Ready"); met != s.met {
+				t.Fatalf("expected %t, got %t", s.met, met)
+			}
+		})
+	}
+}
+
+func TestCustomResourceDefinitionEstablished(t *testing.T) {
+	withConditions := func(conditions ...map[string]interface{}) map[string]interface{} {
+		cs := []interface{}{}
+		for _, c := range conditions {
+			cs = append(cs, c)
+		}
+		return map[string]interface{}{"status": map[string]interface{}{"conditions": cs}}
+	}
+	samples := map[string]struct {
+		obj         map[string]interface{}
+		established bool
+		err         bool
+	}{
+		"established": {
+			obj: withConditions(
+				map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+				map[string]interface{}{"type": "Established", "status": "True"},
+			),
+			established: true,
+		},
+		"names accepted": {
+			obj: withConditions(
+				map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+				map[string]interface{}{"type": "Established", "status": "False"},
+			),
+		},
+		"no status": {
+			obj: map[string]interface{}{},
+		},
+		"names rejected": {
+			obj: withConditions(
+				map[string]interface{}{"type": "NamesAccepted", "status": "False", "message": `"widgets" is already in use`},
+				map[string]interface{}{"type": "Established", "status": "False"},
+			),
+			err: true,
+		},
+	}
+	for name, s := range samples {
+		t.Run(name, func(t *testing.T) {
+			established, err := customResourceDefinitionEstablished(s.obj)
+			if (err != nil) != s.err {
+				t.Fatalf("Expected error: %t, got %v", s.err, err)
+			}
+			if established != s.established {
+				t.Fatalf("Expected established: %t, got %t", s.established, established)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-provider-kubernetes/internal/apiwarning"
+)
+
+// apiWarningDiagnostics returns the warnings returned by the Kubernetes API
+// for the requests of an operation, e.g. for deprecated APIs and fields.
+func apiWarningDiagnostics(c *apiwarning.Collector) []*tfprotov5.Diagnostic {
+	var diags []*tfprotov5.Diagnostic
+	for _, w := range c.Warnings() {
+		diags = append(diags, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityWarning,
+			Summary:  "Kubernetes API warning",
+			Detail:   w,
+		})
+	}
+	return diags
+}
@@ -68,8 +68,14 @@ func TestKubernetesManifest_Import(t *testing.T) {
 		"kubernetes_manifest.test.object.metadata.namespace": namespace,
 		"kubernetes_manifest.test.object.metadata.name":      name,
 		"kubernetes_manifest.test.object.data.foo":           "bar",
+		"kubernetes_manifest.test.manifest.apiVersion":       "v1",
+		"kubernetes_manifest.test.manifest.kind":             "ConfigMap",
+		"kubernetes_manifest.test.manifest.metadata.name":    name,
+		"kubernetes_manifest.test.manifest.data.foo":         "bar",
 	})
 	tfstate.AssertAttributeDoesNotExist(t, "kubernetes_manifest.test.data.fizz")
+	tfstate.AssertAttributeDoesNotExist(t, "kubernetes_manifest.test.manifest.metadata.uid")
+	tfstate.AssertAttributeDoesNotExist(t, "kubernetes_manifest.test.manifest.metadata.resourceVersion")
 
 	err = tf.CreatePlan(ctx)
 	if err != nil {
@@ -8,24 +8,39 @@ package util
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
+// resourceIDExamples is appended to the errors about resource IDs which can't be
+// parsed, to show the accepted formats.
+const resourceIDExamples = `, e.g. "apiVersion=v1,kind=ConfigMap,namespace=default,name=test" or "v1/ConfigMap/default/test"`
+
+// versionRegexp matches the version of an API group, e.g. "v1" or "v2beta1".
+var versionRegexp = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]+)?$`)
+
 // ParseResourceID processes the resource ID string and extracts
 // the values for GVK, name and (optionally) namespace of the target resource
 //
-// The expected format for the resource ID is:
+// The expected format for the resource ID is either:
 // "apiVersion=<value>,kind=<value>,name=<value>[,namespace=<value>"]
+// or:
+// "<apiVersion>/<kind>/[<namespace>/]<name>"
 //
 // where 'namespace' is only required for resources that expect a namespace.
 // Example: "apiVersion=v1,kind=Secret,namespace=default,name=default-token-qgm6s"
+// Example: "apps/v1/Deployment/default/nginx"
 func ParseResourceID(id string) (schema.GroupVersionKind, string, string, error) {
+	if !strings.Contains(id, "=") && strings.Contains(id, "/") {
+		return parsePathResourceID(id)
+	}
+
 	parts := strings.Split(id, ",")
 	if len(parts) < 3 || len(parts) > 4 {
 		return schema.GroupVersionKind{}, "", "",
-			fmt.Errorf("could not parse ID: %q. ID must contain apiVersion, kind, and name", id)
+			fmt.Errorf("could not parse ID: %q. ID must contain apiVersion, kind, and name"+resourceIDExamples, id)
 	}
 
 	namespace := "default"
@@ -53,6 +68,45 @@ func ParseResourceID(id string) (schema.GroupVersionKind, string, string, error)
 		}
 	}
 
+	if apiVersion == "" || kind == "" || name == "" || namespace == "" {
+		return schema.GroupVersionKind{}, "", "",
+			fmt.Errorf("could not parse ID: %q. ID must contain apiVersion, kind, and name"+resourceIDExamples, id)
+	}
+
+	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+	return gvk, name, namespace, nil
+}
+
+// parsePathResourceID parses a resource ID in the "<apiVersion>/<kind>/[<namespace>/]<name>"
+// format. The apiVersion of resources of named API groups contains a slash, it
+// is told apart from the kind by the format of the version.
+func parsePathResourceID(id string) (schema.GroupVersionKind, string, string, error) {
+	parts := strings.Split(id, "/")
+	apiVersion := parts[0]
+	rest := parts[1:]
+	if len(parts) > 1 && versionRegexp.MatchString(parts[1]) {
+		apiVersion = parts[0] + "/" + parts[1]
+		rest = parts[2:]
+	}
+
+	namespace := "default"
+	var kind, name string
+	switch len(rest) {
+	case 2:
+		kind, name = rest[0], rest[1]
+	case 3:
+		kind, namespace, name = rest[0], rest[1], rest[2]
+	default:
+		return schema.GroupVersionKind{}, "", "",
+			fmt.Errorf("could not parse ID: %q. ID must be in apiVersion/kind/[namespace/]name format"+resourceIDExamples, id)
+	}
+	for _, p := range parts {
+		if p == "" {
+			return schema.GroupVersionKind{}, "", "",
+				fmt.Errorf("could not parse ID: %q. ID must not contain empty parts"+resourceIDExamples, id)
+		}
+	}
+
 	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
 	return gvk, name, namespace, nil
 }
@@ -46,13 +46,58 @@ func TestParseResourceID(t *testing.T) {
 		},
 		{
 			id:  "junk",
-			err: fmt.Errorf(`could not parse ID: "junk". ID must contain apiVersion, kind, and name`),
+			err: fmt.Errorf(`could not parse ID: "junk". ID must contain apiVersion, kind, and name, e.g. "apiVersion=v1,kind=ConfigMap,namespace=default,name=test" or "v1/ConfigMap/default/test"`),
+		},
+		{
+			id:  "apiVersion=v1,kind=ConfigMap,namespace=test",
+			err: fmt.Errorf(`could not parse ID: "apiVersion=v1,kind=ConfigMap,namespace=test". ID must contain apiVersion, kind, and name, e.g. "apiVersion=v1,kind=ConfigMap,namespace=default,name=test" or "v1/ConfigMap/default/test"`),
+		},
+		{
+			id:        "v1/ConfigMap/kube-system/test",
+			namespace: "kube-system",
+			name:      "test",
+			gvk:       schema.FromAPIVersionAndKind("v1", "ConfigMap"),
+		},
+		{
+			id:        "v1/Namespace/test",
+			namespace: "default",
+			name:      "test",
+			gvk:       schema.FromAPIVersionAndKind("v1", "Namespace"),
+		},
+		{
+			id:        "apps/v1/Deployment/test/app",
+			namespace: "test",
+			name:      "app",
+			gvk:       schema.FromAPIVersionAndKind("apps/v1", "Deployment"),
+		},
+		{
+			id:        "rbac.authorization.k8s.io/v1/ClusterRole/admin",
+			namespace: "default",
+			name:      "admin",
+			gvk:       schema.FromAPIVersionAndKind("rbac.authorization.k8s.io/v1", "ClusterRole"),
+		},
+		{
+			id:        "example.com/v1beta1/Widget/test/app",
+			namespace: "test",
+			name:      "app",
+			gvk:       schema.FromAPIVersionAndKind("example.com/v1beta1", "Widget"),
+		},
+		{
+			id:  "test/app",
+			err: fmt.Errorf(`could not parse ID: "test/app". ID must be in apiVersion/kind/[namespace/]name format, e.g. "apiVersion=v1,kind=ConfigMap,namespace=default,name=test" or "v1/ConfigMap/default/test"`),
+		},
+		{
+			id:  "apps/v1/Deployment//app",
+			err: fmt.Errorf(`could not parse ID: "apps/v1/Deployment//app". ID must not contain empty parts, e.g. "apiVersion=v1,kind=ConfigMap,namespace=default,name=test" or "v1/ConfigMap/default/test"`),
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.id, func(t *testing.T) {
 			gvk, n, ns, err := ParseResourceID(tc.id)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("expected error %v got %v", tc.err, err)
+			}
 			if err != nil && tc.err.Error() != err.Error() {
 				t.Errorf("expected error %q got %q", tc.err, err)
 			}